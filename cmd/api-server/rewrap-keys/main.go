@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Command rewrap-keys re-encrypts the stored custodial Canton keys under the
+// active master key of the api-server keyring (key_management.active_key_id)
+// and prints the report as JSON. Keys already under it are skipped, so an
+// interrupted run is resumed by running it again, optionally with -after-id
+// set to the last_user_id it reported.
+//
+//	rewrap-keys -config config.yaml -dry-run
+//	rewrap-keys -config config.yaml -batch-size 200 -after-id 1200
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/chainsafe/canton-middleware/pkg/app/api"
+	"github.com/chainsafe/canton-middleware/pkg/config"
+	applog "github.com/chainsafe/canton-middleware/pkg/log"
+	"github.com/chainsafe/canton-middleware/pkg/pgutil"
+	"github.com/chainsafe/canton-middleware/pkg/user/rewrap"
+	"github.com/chainsafe/canton-middleware/pkg/userstore"
+)
+
+func main() {
+	cfgPath := flag.String("config", "config.yaml", "Path to configuration file")
+	afterID := flag.Int64("after-id", 0, "Resume after this user ID")
+	batchSize := flag.Int("batch-size", rewrap.DefaultBatchSize, "Number of users rewrapped per batch")
+	dryRun := flag.Bool("dry-run", false, "Verify every key can be rewrapped without storing it")
+	flag.Parse()
+
+	cfg, err := config.LoadAPIServer(*cfgPath)
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	keyring, err := api.LoadKeyring(cfg.KeyManagement)
+	if err != nil {
+		log.Fatalf("load keyring: %v", err)
+	}
+
+	db, err := pgutil.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("connect database: %v", err)
+	}
+	defer db.Close()
+
+	logger, err := applog.NewLogger(cfg.Logging)
+	if err != nil {
+		log.Fatalf("create logger: %v", err)
+	}
+	defer func() { _ = logger.Sync() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := rewrap.New(userstore.NewStore(db), keyring, logger).Run(ctx, rewrap.Options{
+		AfterID:   *afterID,
+		BatchSize: *batchSize,
+		DryRun:    *dryRun,
+	})
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(report); encErr != nil {
+		log.Fatalf("write report: %v", encErr)
+	}
+	if err != nil {
+		log.Fatalf("rewrap keys (resume with -after-id %d): %v", report.LastUserID, err)
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkRecords verifies every schema record against the Daml-LF record of the
+// same Daml ID in the main packages of dars: field labels, order and types
+// must match. Records in modules none of the packages define are not checked.
+func checkRecords(s *schema, dars []*darInfo) error {
+	damlIDs := make(map[string]string)
+	for _, r := range s.Records {
+		damlIDs[r.Name] = r.Daml
+	}
+	for _, v := range append(append([]variant(nil), s.Variants...), s.Enums...) {
+		damlIDs[v.Name] = v.Daml
+	}
+
+	for _, r := range s.Records {
+		module, _, ok := strings.Cut(r.Daml, ":")
+		if !ok {
+			continue
+		}
+		for _, dar := range dars {
+			if !dar.Package.Modules[module] {
+				continue
+			}
+			if err := checkRecord(r, dar, damlIDs); err != nil {
+				return fmt.Errorf("record %s: %w", r.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkRecord(r record, dar *darInfo, damlIDs map[string]string) error {
+	fields, ok := dar.Package.Records[r.Daml]
+	if !ok {
+		return fmt.Errorf("%s is not a record in %s", r.Daml, dar.NameVersion)
+	}
+	for i := range max(len(r.Fields), len(fields)) {
+		var want, got string
+		if i < len(r.Fields) {
+			want = r.Fields[i].Label + ": " + r.Fields[i].Type.String()
+		}
+		if i < len(fields) {
+			got = fields[i].Label + ": " + fields[i].Type.String()
+		}
+		if i >= len(r.Fields) || i >= len(fields) ||
+			r.Fields[i].Label != fields[i].Label || !matchType(r.Fields[i].Type, fields[i].Type, damlIDs) {
+			return fmt.Errorf("field %d is %q in the schema but %q in %s (%s)",
+				i+1, want, got, dar.NameVersion, r.Daml)
+		}
+	}
+	return nil
+}
+
+// matchType reports whether the schema type st describes the Daml-LF type t.
+// Value matches any type, and a type imported from another schema any type
+// constructor; a local named type must be the record or variant of its Daml
+// ID.
+func matchType(st *damlType, t *lfType, damlIDs map[string]string) bool {
+	switch st.kind {
+	case kindValue:
+		return true
+	case kindNamed:
+		if !t.isCon() || len(t.args) > 0 {
+			return false
+		}
+		if strings.Contains(st.name, ".") {
+			return true
+		}
+		id := damlIDs[st.name]
+		return id == "" || id == t.name
+	case kindOptional, kindList, kindTextMap:
+		return t.name == st.keyword() && len(t.args) == 1 && matchType(st.elem, t.args[0], damlIDs)
+	}
+	return t.name == st.keyword() && len(t.args) == 0
+}
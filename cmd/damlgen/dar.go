@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const manifestPath = "META-INF/MANIFEST.MF"
+
+// archiveHashField is the field number of Archive.hash in daml_lf.proto. The
+// hash is the package ID.
+const archiveHashField = 4
+
+// darInfo is the main package of a DAR.
+type darInfo struct {
+	// NameVersion is the "<name>-<version>" prefix of the main dalf.
+	NameVersion string
+	PackageID   string
+	// Package holds the record layouts the main dalf defines.
+	Package *lfPackage
+}
+
+// readDAR reads the main package of the DAR at path from its manifest and
+// main dalf.
+func readDAR(darPath string) (*darInfo, error) {
+	zr, err := zip.OpenReader(darPath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	mf, ok := files[manifestPath]
+	if !ok {
+		return nil, fmt.Errorf("%s: no %s", darPath, manifestPath)
+	}
+	manifest, err := readZipFile(mf)
+	if err != nil {
+		return nil, err
+	}
+	mainDalf := parseManifest(string(manifest))["Main-Dalf"]
+	if mainDalf == "" {
+		return nil, fmt.Errorf("%s: manifest has no Main-Dalf", darPath)
+	}
+	dalf, ok := files[mainDalf]
+	if !ok {
+		return nil, fmt.Errorf("%s: main dalf %s not in archive", darPath, mainDalf)
+	}
+	archive, err := readZipFile(dalf)
+	if err != nil {
+		return nil, err
+	}
+	pkgID, err := archiveHash(archive)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", mainDalf, err)
+	}
+	pkg, err := decodeArchive(archive)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", mainDalf, err)
+	}
+
+	// Main dalfs are named <name>-<version>-<package id>.dalf.
+	base := strings.TrimSuffix(path.Base(mainDalf), ".dalf")
+	nameVersion, ok := strings.CutSuffix(base, "-"+pkgID)
+	if !ok {
+		return nil, fmt.Errorf("%s: main dalf %s does not carry package id %s", darPath, mainDalf, pkgID)
+	}
+	return &darInfo{NameVersion: nameVersion, PackageID: pkgID, Package: pkg}, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// parseManifest parses a JAR-style manifest, joining continuation lines
+// (which start with a single space).
+func parseManifest(s string) map[string]string {
+	out := make(map[string]string)
+	var key string
+	sc := bufio.NewScanner(strings.NewReader(s))
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.HasPrefix(line, " ") && key != "" {
+			out[key] += line[1:]
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			key = ""
+			continue
+		}
+		key = strings.TrimSpace(k)
+		out[key] = strings.TrimSpace(v)
+	}
+	return out
+}
+
+// archiveHash extracts Archive.hash from a serialized Daml-LF archive without
+// depending on the Daml-LF protos.
+func archiveHash(b []byte) (string, error) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		b = b[n:]
+		if num == archiveHashField && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return "", protowire.ParseError(n)
+			}
+			return string(v), nil
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return "", errors.New("archive has no hash")
+}
+
+// applyDAR records the package ID and version of info on the schema package
+// whose name it carries.
+func applyDAR(s *schema, info *darInfo) error {
+	for i := range s.Packages {
+		p := &s.Packages[i]
+		if version, ok := strings.CutPrefix(info.NameVersion, p.Name+"-"); ok {
+			p.Version = version
+			p.ID = info.PackageID
+			return nil
+		}
+	}
+	return fmt.Errorf("DAR package %s matches no package in the schema", info.NameVersion)
+}
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+const (
+	lapiImport   = "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	damlImport   = "github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	valuesImport = "github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+type generator struct {
+	buf bytes.Buffer
+}
+
+func (g *generator) p(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+	g.buf.WriteByte('\n')
+}
+
+// doc writes text as a Go comment, or fallback when text is empty.
+func (g *generator) doc(text, fallback string) {
+	if text == "" {
+		text = fallback
+	}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		g.p("// %s", strings.TrimSpace(line))
+	}
+}
+
+// generate renders the Go source for s. source names the schema file in the
+// generated-code header.
+func generate(s *schema, source string) ([]byte, error) {
+	var body generator
+	body.packages(s)
+	body.templates("Template", "template", s.Templates)
+	body.templates("Interface", "interface", s.Interfaces)
+	for _, r := range s.Records {
+		body.record(r)
+	}
+	for _, v := range s.Variants {
+		body.variant(v, "daml.VariantValue(string(v), daml.UnitValue())", "variant")
+	}
+	for _, v := range s.Enums {
+		body.variant(v, "daml.EnumValue(string(v))", "enum")
+	}
+
+	var g generator
+	g.p("// Code generated by damlgen from %s. DO NOT EDIT.", source)
+	g.p("")
+	g.p("package %s", s.Package)
+	g.p("")
+	g.imports(s, body.buf.String())
+	g.buf.Write(body.buf.Bytes())
+
+	out, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, g.buf.Bytes())
+	}
+	return out, nil
+}
+
+// imports writes the import block for whatever body references.
+func (g *generator) imports(s *schema, body string) {
+	var std, local []string
+	if strings.Contains(body, "fmt.") {
+		std = append(std, `"fmt"`)
+	}
+	if strings.Contains(body, "time.") {
+		std = append(std, `"time"`)
+	}
+	local = append(local, `"`+damlImport+`"`)
+	aliases := make([]string, 0, len(s.Imports))
+	for alias := range s.Imports {
+		if strings.Contains(body, alias+".") {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		local = append(local, `"`+s.Imports[alias]+`"`)
+	}
+	local = append(local, `lapiv2 "`+lapiImport+`"`)
+	if strings.Contains(body, "values.") {
+		local = append(local, `"`+valuesImport+`"`)
+	}
+	sort.Slice(local, func(i, j int) bool { return importPath(local[i]) < importPath(local[j]) })
+
+	g.p("import (")
+	for _, imp := range std {
+		g.p("\t%s", imp)
+	}
+	if len(std) > 0 {
+		g.p("")
+	}
+	for _, imp := range local {
+		g.p("\t%s", imp)
+	}
+	g.p(")")
+	g.p("")
+}
+
+func importPath(spec string) string {
+	_, path, _ := strings.Cut(spec, `"`)
+	return path
+}
+
+func (g *generator) packages(s *schema) {
+	if len(s.Packages) == 0 {
+		return
+	}
+	g.p("// Daml packages providing the templates and interfaces below.")
+	g.p("var (")
+	for _, p := range s.Packages {
+		lit := fmt.Sprintf("Name: %q", p.Name)
+		if p.Version != "" {
+			lit += fmt.Sprintf(", Version: %q", p.Version)
+		}
+		if p.ID != "" {
+			lit += fmt.Sprintf(", ID: %q", p.ID)
+		}
+		g.p("\t%sPackage = daml.Package{%s}", p.Go, lit)
+	}
+	g.p(")")
+	g.p("")
+}
+
+func (g *generator) templates(suffix, kind string, ts []template) {
+	if len(ts) == 0 {
+		return
+	}
+	var choices []string
+	g.p("var (")
+	for _, t := range ts {
+		module, entity, _ := t.moduleEntity()
+		g.doc(t.Doc, fmt.Sprintf("%s%s is the %s %s.", t.Name, suffix, t.ID, kind))
+		g.p("\t%s%s = daml.Template{Package: %q, Module: %q, Entity: %q}", t.Name, suffix, t.Package, module, entity)
+		for _, c := range t.Choices {
+			choices = append(choices, fmt.Sprintf("\t%s = %q // %s", choiceConst(c), c, t.ID))
+		}
+	}
+	g.p(")")
+	g.p("")
+	if len(choices) == 0 {
+		return
+	}
+	g.p("// Choices exercised on the %ss above.", kind)
+	g.p("const (")
+	for _, c := range choices {
+		g.p("%s", c)
+	}
+	g.p(")")
+	g.p("")
+}
+
+func (g *generator) record(r record) {
+	fallback := fmt.Sprintf("%s is the Daml record %s.", r.Name, r.Daml)
+	if r.Newtype {
+		fallback = fmt.Sprintf("%s is the Daml newtype %s.", r.Name, r.Daml)
+	}
+	g.doc(r.Doc, fallback)
+	g.p("type %s struct {", r.Name)
+	for _, f := range r.Fields {
+		g.p("\t%s %s", goName(f.Label), f.Type.goType())
+	}
+	g.p("}")
+	g.p("")
+
+	g.p("// ToRecord encodes r as a Ledger API record.")
+	g.p("func (r %s) ToRecord() *lapiv2.Record {", r.Name)
+	g.p("\treturn &lapiv2.Record{")
+	g.p("\t\tFields: []*lapiv2.RecordField{")
+	for _, f := range r.Fields {
+		value := f.Type.encExpr("r." + goName(f.Label))
+		if r.Newtype {
+			g.p("\t\t\t{Value: %s},", value)
+		} else {
+			g.p("\t\t\t{Label: %q, Value: %s},", f.Label, value)
+		}
+	}
+	g.p("\t\t},")
+	g.p("\t}")
+	g.p("}")
+	g.p("")
+
+	g.p("// ToValue encodes r as a Ledger API record value.")
+	g.p("func (r %s) ToValue() *lapiv2.Value {", r.Name)
+	g.p("\treturn daml.RecordValue(r.ToRecord())")
+	g.p("}")
+	g.p("")
+
+	g.p("// FromValue decodes r from a Ledger API record value.")
+	g.p("func (r *%s) FromValue(v *lapiv2.Value) error {", r.Name)
+	g.p("\trec, err := daml.DecodeRecord(v)")
+	g.p("\tif err != nil {")
+	g.p("\t\treturn err")
+	g.p("\t}")
+	g.p("\treturn r.FromRecord(rec)")
+	g.p("}")
+	g.p("")
+
+	if r.Newtype {
+		f := r.Fields[0]
+		g.p("// FromRecord decodes r from a Ledger API record, matching its field by")
+		g.p("// position. An empty record leaves r unchanged.")
+		g.p("func (r *%s) FromRecord(rec *lapiv2.Record) error {", r.Name)
+		g.p("\tif len(rec.GetFields()) == 0 {")
+		g.p("\t\treturn nil")
+		g.p("\t}")
+		g.p("\tv, err := %s", f.Type.decExpr("rec.Fields[0].Value"))
+		g.p("\tif err != nil {")
+		g.p("\t\treturn daml.FieldError(%q, %q, err)", r.Name, f.Label)
+		g.p("\t}")
+		g.p("\tr.%s = v", goName(f.Label))
+		g.p("\treturn nil")
+		g.p("}")
+		g.p("")
+		return
+	}
+
+	g.p("// FromRecord decodes r from a Ledger API record.")
+	g.p("func (r *%s) FromRecord(rec *lapiv2.Record) error {", r.Name)
+	g.p("\treturn r.FromFields(values.RecordToMap(rec))")
+	g.p("}")
+	g.p("")
+
+	g.p("// FromFields decodes r from record fields keyed by label. Absent fields keep")
+	g.p("// their current value, since upgraded packages may append optional fields.")
+	g.p("func (r *%s) FromFields(fields map[string]*lapiv2.Value) error {", r.Name)
+	g.p("\tvar err error")
+	for _, f := range r.Fields {
+		g.p("\tif v, ok := fields[%q]; ok {", f.Label)
+		g.p("\t\tif r.%s, err = %s; err != nil {", goName(f.Label), f.Type.decExpr("v"))
+		g.p("\t\t\treturn daml.FieldError(%q, %q, err)", r.Name, f.Label)
+		g.p("\t\t}")
+		g.p("\t}")
+	}
+	g.p("\treturn nil")
+	g.p("}")
+	g.p("")
+}
+
+func (g *generator) variant(v variant, enc, kind string) {
+	g.doc(v.Doc, fmt.Sprintf("%s is the Daml %s %s.", v.Name, kind, v.Daml))
+	g.p("type %s string", v.Name)
+	g.p("")
+	g.p("// %s constructors.", v.Name)
+	g.p("const (")
+	for _, c := range v.Constructors {
+		g.p("\t%s%s %s = %q", v.Name, c, v.Name, c)
+	}
+	g.p(")")
+	g.p("")
+
+	g.p("// ToValue encodes v as a Ledger API %s value.", kind)
+	g.p("func (v %s) ToValue() *lapiv2.Value {", v.Name)
+	g.p("\treturn %s", enc)
+	g.p("}")
+	g.p("")
+
+	consts := make([]string, len(v.Constructors))
+	for i, c := range v.Constructors {
+		consts[i] = v.Name + c
+	}
+	g.p("// FromValue decodes v from a Ledger API variant or enum value.")
+	g.p("func (v *%s) FromValue(val *lapiv2.Value) error {", v.Name)
+	g.p("\tc, err := daml.DecodeConstructor(val)")
+	g.p("\tif err != nil {")
+	g.p("\t\treturn err")
+	g.p("\t}")
+	g.p("\tswitch %s(c) {", v.Name)
+	g.p("\tcase %s:", strings.Join(consts, ", "))
+	g.p("\t\t*v = %s(c)", v.Name)
+	g.p("\t\treturn nil")
+	g.p("\t}")
+	g.p("\treturn fmt.Errorf(\"unknown %s constructor %%q\", c)", v.Name)
+	g.p("}")
+	g.p("")
+}
@@ -0,0 +1,478 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the Daml-LF messages read below, from daml_lf.proto and
+// daml_lf2.proto under proto/daml. Only what is needed to recover record
+// layouts is decoded, without depending on the Daml-LF protos.
+const (
+	archivePayloadField = 3 // Archive.payload
+
+	payloadLF1Field = 2 // ArchivePayload.daml_lf_1
+	payloadLF2Field = 4 // ArchivePayload.daml_lf_2
+
+	packageModulesField      = 1 // Package.modules
+	packageStringsField      = 2 // Package.interned_strings
+	packageDottedNamesField  = 3 // Package.interned_dotted_names
+	packageInternedTypeField = 5 // Package.interned_types
+
+	dottedNameSegmentsField = 1 // InternedDottedName.segments_interned_str
+
+	moduleNameField      = 1 // Module.name_interned_dname
+	moduleDataTypesField = 4 // Module.data_types
+
+	dataTypeNameField   = 2 // DefDataType.name_interned_dname
+	dataTypeRecordField = 5 // DefDataType.record
+	fieldsFieldsField   = 1 // DefDataType.Fields.fields
+
+	fieldTypeField = 2 // FieldWithType.type
+	fieldNameField = 3 // FieldWithType.field_interned_str
+
+	typeVarField      = 1 // Type.var
+	typeConField      = 2 // Type.con
+	typeBuiltinField  = 3 // Type.builtin
+	typeInternedField = 8 // Type.interned_type
+	typeTAppField     = 9 // Type.tapp
+
+	appliedHeadField = 1 // Type.Con.tycon, Type.Builtin.builtin, Type.TApp.lhs
+	appliedArgsField = 2 // Type.Con.args, Type.Builtin.args, Type.TApp.rhs
+
+	typeConModuleField = 1 // TypeConId.module
+	typeConNameField   = 2 // TypeConId.name_interned_dname
+	moduleIDNameField  = 2 // ModuleId.module_name_interned_dname
+)
+
+// builtinTypes names the Daml-LF 2 builtin types with the schema's keywords
+// where it has one.
+var builtinTypes = map[uint64]string{
+	0: "Unit", 1: "Bool", 2: "Int", 3: "Date", 4: "Time", 5: "Numeric", 6: "Party",
+	7: "Text", 8: "ContractId", 9: "Optional", 10: "List", 11: "GenMap", 19: "TextMap",
+}
+
+// lfType is a Daml-LF type as far as record layouts need it. name is a schema
+// keyword for builtins, "Module:Entity" for type constructors, and "var" for
+// type variables; the arguments of Numeric and ContractId are dropped.
+type lfType struct {
+	name string
+	args []*lfType
+}
+
+func (t *lfType) isCon() bool { return strings.Contains(t.name, ":") }
+
+func (t *lfType) String() string {
+	s := t.name
+	for _, a := range t.args {
+		if len(a.args) > 0 {
+			s += " (" + a.String() + ")"
+		} else {
+			s += " " + a.String()
+		}
+	}
+	return s
+}
+
+// lfField is one field of a Daml-LF record.
+type lfField struct {
+	Label string
+	Type  *lfType
+}
+
+// lfPackage holds the record layouts of a Daml-LF package, keyed by
+// "Module:Entity", and the names of its modules.
+type lfPackage struct {
+	Modules map[string]bool
+	Records map[string][]lfField
+}
+
+// lfDecoder resolves the interned tables of one package.
+type lfDecoder struct {
+	strings     []string
+	dottedNames [][]uint64
+	types       [][]byte
+	resolved    map[uint64]*lfType
+}
+
+// decodeArchive decodes the record layouts of a serialized Daml-LF archive.
+// Only Daml-LF 2, the version Canton 3 runs, is supported.
+func decodeArchive(b []byte) (*lfPackage, error) {
+	var payload []byte
+	if err := eachField(b, func(num protowire.Number, v []byte, _ uint64) error {
+		if num == archivePayloadField {
+			payload = v
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var pkg []byte
+	var lf1 bool
+	if err := eachField(payload, func(num protowire.Number, v []byte, _ uint64) error {
+		switch num {
+		case payloadLF2Field:
+			pkg = v
+		case payloadLF1Field:
+			lf1 = true
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if lf1 {
+		return nil, errors.New("Daml-LF 1 archives are not supported")
+	}
+	if pkg == nil {
+		return nil, errors.New("archive has no Daml-LF 2 package")
+	}
+	return decodePackage(pkg)
+}
+
+func decodePackage(b []byte) (*lfPackage, error) {
+	d := &lfDecoder{resolved: make(map[uint64]*lfType)}
+	var modules [][]byte
+	err := eachField(b, func(num protowire.Number, v []byte, _ uint64) error {
+		switch num {
+		case packageModulesField:
+			modules = append(modules, v)
+		case packageStringsField:
+			d.strings = append(d.strings, string(v))
+		case packageDottedNamesField:
+			segments, err := repeatedVarint(v, dottedNameSegmentsField)
+			if err != nil {
+				return err
+			}
+			d.dottedNames = append(d.dottedNames, segments)
+		case packageInternedTypeField:
+			d.types = append(d.types, v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &lfPackage{Modules: make(map[string]bool), Records: make(map[string][]lfField)}
+	for _, m := range modules {
+		if err := d.decodeModule(m, pkg); err != nil {
+			return nil, err
+		}
+	}
+	return pkg, nil
+}
+
+func (d *lfDecoder) decodeModule(b []byte, pkg *lfPackage) error {
+	var nameIdx uint64
+	var dataTypes [][]byte
+	err := eachField(b, func(num protowire.Number, v []byte, x uint64) error {
+		switch num {
+		case moduleNameField:
+			nameIdx = x
+		case moduleDataTypesField:
+			dataTypes = append(dataTypes, v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	module, err := d.dottedName(nameIdx)
+	if err != nil {
+		return err
+	}
+	pkg.Modules[module] = true
+
+	for _, dt := range dataTypes {
+		var nameIdx uint64
+		var record []byte
+		err := eachField(dt, func(num protowire.Number, v []byte, x uint64) error {
+			switch num {
+			case dataTypeNameField:
+				nameIdx = x
+			case dataTypeRecordField:
+				record = v
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			continue
+		}
+		name, err := d.dottedName(nameIdx)
+		if err != nil {
+			return err
+		}
+		fields, err := d.decodeFields(record)
+		if err != nil {
+			return fmt.Errorf("%s:%s: %w", module, name, err)
+		}
+		pkg.Records[module+":"+name] = fields
+	}
+	return nil
+}
+
+func (d *lfDecoder) decodeFields(b []byte) ([]lfField, error) {
+	fields := []lfField{}
+	err := eachField(b, func(num protowire.Number, v []byte, _ uint64) error {
+		if num != fieldsFieldsField {
+			return nil
+		}
+		var labelIdx uint64
+		var typ []byte
+		if err := eachField(v, func(num protowire.Number, v []byte, x uint64) error {
+			switch num {
+			case fieldNameField:
+				labelIdx = x
+			case fieldTypeField:
+				typ = v
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		label, err := d.string(labelIdx)
+		if err != nil {
+			return err
+		}
+		t, err := d.decodeType(typ)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", label, err)
+		}
+		fields = append(fields, lfField{Label: label, Type: t})
+		return nil
+	})
+	return fields, err
+}
+
+func (d *lfDecoder) decodeType(b []byte) (*lfType, error) {
+	var t *lfType
+	err := eachField(b, func(num protowire.Number, v []byte, x uint64) error {
+		var err error
+		switch num {
+		case typeVarField:
+			t = &lfType{name: "var"}
+		case typeConField:
+			t, err = d.decodeApplied(v, d.decodeTypeCon)
+		case typeBuiltinField:
+			t, err = d.decodeApplied(v, nil)
+		case typeInternedField:
+			t, err = d.internedType(x)
+		case typeTAppField:
+			t, err = d.decodeTApp(v)
+		default:
+			t = &lfType{name: fmt.Sprintf("type %d", num)}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, errors.New("empty type")
+	}
+	return t, nil
+}
+
+// decodeApplied decodes a Type.Con (with con set) or Type.Builtin: a head
+// applied to args.
+func (d *lfDecoder) decodeApplied(b []byte, con func([]byte) (string, error)) (*lfType, error) {
+	t := &lfType{name: builtinTypes[0]}
+	err := eachField(b, func(num protowire.Number, v []byte, x uint64) error {
+		switch num {
+		case appliedHeadField:
+			if con != nil {
+				name, err := con(v)
+				t.name = name
+				return err
+			}
+			name, ok := builtinTypes[x]
+			if !ok {
+				name = fmt.Sprintf("builtin %d", x)
+			}
+			t.name = name
+		case appliedArgsField:
+			arg, err := d.decodeType(v)
+			if err != nil {
+				return err
+			}
+			t.args = append(t.args, arg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if t.name == "Numeric" || t.name == "ContractId" {
+		t.args = nil
+	}
+	return t, nil
+}
+
+// decodeTApp decodes a type application into its flattened head.
+func (d *lfDecoder) decodeTApp(b []byte) (*lfType, error) {
+	var lhs, rhs *lfType
+	err := eachField(b, func(num protowire.Number, v []byte, _ uint64) error {
+		var err error
+		switch num {
+		case appliedHeadField:
+			lhs, err = d.decodeType(v)
+		case appliedArgsField:
+			rhs, err = d.decodeType(v)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lhs == nil || rhs == nil {
+		return nil, errors.New("incomplete type application")
+	}
+	t := &lfType{name: lhs.name, args: append(append([]*lfType(nil), lhs.args...), rhs)}
+	if t.name == "Numeric" || t.name == "ContractId" {
+		t.args = nil
+	}
+	return t, nil
+}
+
+// decodeTypeCon decodes a TypeConId to "Module:Entity".
+func (d *lfDecoder) decodeTypeCon(b []byte) (string, error) {
+	var moduleIdx, nameIdx uint64
+	err := eachField(b, func(num protowire.Number, v []byte, x uint64) error {
+		switch num {
+		case typeConModuleField:
+			return eachField(v, func(num protowire.Number, _ []byte, x uint64) error {
+				if num == moduleIDNameField {
+					moduleIdx = x
+				}
+				return nil
+			})
+		case typeConNameField:
+			nameIdx = x
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	module, err := d.dottedName(moduleIdx)
+	if err != nil {
+		return "", err
+	}
+	name, err := d.dottedName(nameIdx)
+	if err != nil {
+		return "", err
+	}
+	return module + ":" + name, nil
+}
+
+func (d *lfDecoder) internedType(i uint64) (*lfType, error) {
+	if t, ok := d.resolved[i]; ok {
+		if t == nil {
+			return nil, fmt.Errorf("interned type %d refers to itself", i)
+		}
+		return t, nil
+	}
+	if i >= uint64(len(d.types)) {
+		return nil, fmt.Errorf("interned type %d out of range", i)
+	}
+	d.resolved[i] = nil
+	t, err := d.decodeType(d.types[i])
+	if err != nil {
+		return nil, err
+	}
+	d.resolved[i] = t
+	return t, nil
+}
+
+func (d *lfDecoder) string(i uint64) (string, error) {
+	if i >= uint64(len(d.strings)) {
+		return "", fmt.Errorf("interned string %d out of range", i)
+	}
+	return d.strings[i], nil
+}
+
+func (d *lfDecoder) dottedName(i uint64) (string, error) {
+	if i >= uint64(len(d.dottedNames)) {
+		return "", fmt.Errorf("interned dotted name %d out of range", i)
+	}
+	segments := make([]string, len(d.dottedNames[i]))
+	for j, s := range d.dottedNames[i] {
+		seg, err := d.string(s)
+		if err != nil {
+			return "", err
+		}
+		segments[j] = seg
+	}
+	return strings.Join(segments, "."), nil
+}
+
+// eachField calls fn for every varint and length-delimited field of the
+// serialized message b, with v the payload of the latter and x the value of
+// the former. Other wire types are skipped.
+func eachField(b []byte, fn func(num protowire.Number, v []byte, x uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var err error
+		switch typ {
+		case protowire.VarintType:
+			var x uint64
+			x, n = protowire.ConsumeVarint(b)
+			if n >= 0 {
+				err = fn(num, nil, x)
+			}
+		case protowire.BytesType:
+			var v []byte
+			v, n = protowire.ConsumeBytes(b)
+			if n >= 0 {
+				err = fn(num, v, 0)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// repeatedVarint reads the repeated varint field num of b, packed or not.
+func repeatedVarint(b []byte, field protowire.Number) ([]uint64, error) {
+	var out []uint64
+	err := eachField(b, func(num protowire.Number, v []byte, x uint64) error {
+		if num != field {
+			return nil
+		}
+		if v == nil {
+			out = append(out, x)
+			return nil
+		}
+		for len(v) > 0 {
+			x, n := protowire.ConsumeVarint(v)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			out = append(out, x)
+			v = v[n:]
+		}
+		return nil
+	})
+	return out, err
+}
@@ -0,0 +1,346 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// lfBuilder serializes a Daml-LF 2 package the way the Daml compiler does:
+// names are interned, and module data types refer to them by index.
+type lfBuilder struct {
+	strs    []string
+	names   [][]uint64
+	types   [][]byte
+	modules map[string][]byte
+	order   []string
+}
+
+func newLFBuilder() *lfBuilder {
+	return &lfBuilder{modules: make(map[string][]byte)}
+}
+
+func bytesField(num protowire.Number, v []byte) []byte {
+	b := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func varintField(num protowire.Number, x uint64) []byte {
+	b := protowire.AppendTag(nil, num, protowire.VarintType)
+	return protowire.AppendVarint(b, x)
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func (b *lfBuilder) str(s string) uint64 {
+	for i, v := range b.strs {
+		if v == s {
+			return uint64(i)
+		}
+	}
+	b.strs = append(b.strs, s)
+	return uint64(len(b.strs) - 1)
+}
+
+func (b *lfBuilder) dname(s string) uint64 {
+	var segments []uint64
+	for _, seg := range strings.Split(s, ".") {
+		segments = append(segments, b.str(seg))
+	}
+	b.names = append(b.names, segments)
+	return uint64(len(b.names) - 1)
+}
+
+// builtin returns a Type.Builtin; UNIT (0) is left implicit, as proto3 does.
+func (b *lfBuilder) builtin(kind uint64, args ...[]byte) []byte {
+	var body []byte
+	if kind != 0 {
+		body = varintField(appliedHeadField, kind)
+	}
+	for _, a := range args {
+		body = append(body, bytesField(appliedArgsField, a)...)
+	}
+	return bytesField(typeBuiltinField, body)
+}
+
+func (b *lfBuilder) con(id string, args ...[]byte) []byte {
+	module, name, _ := strings.Cut(id, ":")
+	tycon := concat(
+		bytesField(typeConModuleField, varintField(moduleIDNameField, b.dname(module))),
+		varintField(typeConNameField, b.dname(name)),
+	)
+	body := bytesField(appliedHeadField, tycon)
+	for _, a := range args {
+		body = append(body, bytesField(appliedArgsField, a)...)
+	}
+	return bytesField(typeConField, body)
+}
+
+func (b *lfBuilder) tapp(lhs, rhs []byte) []byte {
+	return bytesField(typeTAppField, concat(bytesField(appliedHeadField, lhs), bytesField(appliedArgsField, rhs)))
+}
+
+func (b *lfBuilder) interned(t []byte) []byte {
+	b.types = append(b.types, t)
+	return varintField(typeInternedField, uint64(len(b.types)-1))
+}
+
+func nat(n int64) []byte {
+	return varintField(6, protowire.EncodeZigZag(n))
+}
+
+// record adds a record data type with the given alternating labels and types.
+func (b *lfBuilder) record(id string, fields ...any) {
+	module, name, _ := strings.Cut(id, ":")
+	var body []byte
+	for i := 0; i < len(fields); i += 2 {
+		f := concat(
+			varintField(fieldNameField, b.str(fields[i].(string))),
+			bytesField(fieldTypeField, fields[i+1].([]byte)),
+		)
+		body = append(body, bytesField(fieldsFieldsField, f)...)
+	}
+	dt := concat(varintField(dataTypeNameField, b.dname(name)), bytesField(dataTypeRecordField, body))
+	if _, ok := b.modules[module]; !ok {
+		b.order = append(b.order, module)
+	}
+	b.modules[module] = append(b.modules[module], bytesField(moduleDataTypesField, dt)...)
+}
+
+func (b *lfBuilder) pkg() []byte {
+	var modules []byte
+	for _, m := range b.order {
+		body := concat(varintField(moduleNameField, b.dname(m)), b.modules[m])
+		modules = append(modules, bytesField(packageModulesField, body)...)
+	}
+	var out []byte
+	out = append(out, modules...)
+	for _, s := range b.strs {
+		out = append(out, bytesField(packageStringsField, []byte(s))...)
+	}
+	for _, segments := range b.names {
+		var packed []byte
+		for _, s := range segments {
+			packed = protowire.AppendVarint(packed, s)
+		}
+		out = append(out, bytesField(packageDottedNamesField, bytesField(dottedNameSegmentsField, packed))...)
+	}
+	for _, t := range b.types {
+		out = append(out, bytesField(packageInternedTypeField, t)...)
+	}
+	return out
+}
+
+// Daml-LF 2 builtin type numbers.
+const (
+	lfBool       = 1
+	lfTimestamp  = 4
+	lfNumeric    = 5
+	lfParty      = 6
+	lfText       = 7
+	lfContractID = 8
+	lfOptional   = 9
+	lfList       = 10
+)
+
+// fingerprintPackage builds the records of bridge.yaml's FingerprintMapping
+// and InitiateWithdrawal, using interned types and type applications the way
+// the compiler emits them.
+func fingerprintPackage() []byte {
+	b := newLFBuilder()
+	b.record("Common.Types:EvmAddress", "value", b.builtin(lfText))
+	evm := b.interned(b.con("Common.Types:EvmAddress"))
+	b.record("Common.FingerprintAuth:FingerprintMapping",
+		"issuer", b.builtin(lfParty),
+		"userParty", b.builtin(lfParty),
+		"fingerprint", b.builtin(lfText),
+		"evmAddress", b.builtin(lfOptional, evm),
+	)
+	b.record("Wayfinder.Bridge:InitiateWithdrawal",
+		"mappingCid", b.builtin(lfContractID, b.con("Common.FingerprintAuth:FingerprintMapping")),
+		"holdingCid", b.tapp(b.builtin(lfContractID), b.con("CIP56.Token:CIP56Holding")),
+		"amount", b.builtin(lfNumeric, nat(10)),
+		"evmDestination", evm,
+	)
+	b.record("Wayfinder.Bridge:Flags",
+		"enabled", b.interned(b.builtin(lfBool)),
+		"parties", b.tapp(b.builtin(lfList), b.builtin(lfParty)),
+		"at", b.builtin(lfTimestamp),
+		"unit", b.builtin(0),
+	)
+	return b.pkg()
+}
+
+func TestDecodeArchive(t *testing.T) {
+	pkg, err := decodeArchive(archiveOf("abc", fingerprintPackage()))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{
+		"Common.Types": true, "Common.FingerprintAuth": true, "Wayfinder.Bridge": true,
+	}, pkg.Modules)
+
+	layout := func(id string) []string {
+		var out []string
+		for _, f := range pkg.Records[id] {
+			out = append(out, f.Label+": "+f.Type.String())
+		}
+		return out
+	}
+	assert.Equal(t, []string{"value: Text"}, layout("Common.Types:EvmAddress"))
+	assert.Equal(t, []string{
+		"issuer: Party", "userParty: Party", "fingerprint: Text",
+		"evmAddress: Optional Common.Types:EvmAddress",
+	}, layout("Common.FingerprintAuth:FingerprintMapping"))
+	assert.Equal(t, []string{
+		"mappingCid: ContractId", "holdingCid: ContractId", "amount: Numeric",
+		"evmDestination: Common.Types:EvmAddress",
+	}, layout("Wayfinder.Bridge:InitiateWithdrawal"))
+	assert.Equal(t, []string{
+		"enabled: Bool", "parties: List Party", "at: Time", "unit: Unit",
+	}, layout("Wayfinder.Bridge:Flags"))
+}
+
+func TestDecodeArchiveLF1(t *testing.T) {
+	var payload []byte
+	payload = protowire.AppendTag(payload, payloadLF1Field, protowire.BytesType)
+	payload = protowire.AppendBytes(payload, nil)
+	_, err := decodeArchive(bytesField(archivePayloadField, payload))
+	assert.ErrorContains(t, err, "Daml-LF 1")
+}
+
+const checkSchema = `
+package: bridge
+packages:
+  - name: bridge-wayfinder
+    go: BridgeWayfinder
+records:
+  - name: EvmAddress
+    daml: Common.Types:EvmAddress
+    newtype: true
+    fields:
+      - value: Text
+  - name: FingerprintMapping
+    daml: Common.FingerprintAuth:FingerprintMapping
+    fields:
+      - issuer: Party
+      - userParty: Party
+      - fingerprint: Text
+      - evmAddress: Optional EvmAddress
+  - name: InitiateWithdrawal
+    daml: Wayfinder.Bridge:InitiateWithdrawal
+    fields:
+      - mappingCid: ContractId
+      - holdingCid: ContractId
+      - amount: Numeric
+      - evmDestination: EvmAddress
+  - name: Other
+    daml: Other.Module:Other
+    fields:
+      - anything: Int
+`
+
+func loadTestSchema(t *testing.T, yaml string) *schema {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+	s, err := loadSchema(path)
+	require.NoError(t, err)
+	return s
+}
+
+func TestCheckRecords(t *testing.T) {
+	pkg, err := decodeArchive(archiveOf("abc", fingerprintPackage()))
+	require.NoError(t, err)
+	dars := []*darInfo{{NameVersion: "bridge-wayfinder-1.0.0", Package: pkg}}
+
+	tests := []struct {
+		name    string
+		edit    func(string) string
+		wantErr string
+	}{
+		{name: "matching layouts", edit: func(s string) string { return s }},
+		{
+			name:    "renamed field",
+			edit:    func(s string) string { return strings.Replace(s, "userParty: Party", "user: Party", 1) },
+			wantErr: `record FingerprintMapping: field 2 is "user: Party" in the schema but "userParty: Party"`,
+		},
+		{
+			name: "reordered fields",
+			edit: func(s string) string {
+				return strings.Replace(s, "- mappingCid: ContractId\n      - holdingCid", "- holdingCid: ContractId\n      - mappingCid", 1)
+			},
+			wantErr: `record InitiateWithdrawal: field 1 is "holdingCid: ContractId"`,
+		},
+		{
+			name:    "wrong type",
+			edit:    func(s string) string { return strings.Replace(s, "amount: Numeric", "amount: Text", 1) },
+			wantErr: `field 3 is "amount: Text" in the schema but "amount: Numeric"`,
+		},
+		{
+			name: "named type of another Daml ID",
+			edit: func(s string) string {
+				return strings.Replace(s, "evmAddress: Optional EvmAddress", "evmAddress: Optional Other", 1)
+			},
+			wantErr: `record FingerprintMapping: field 4`,
+		},
+		{
+			name:    "missing field",
+			edit:    func(s string) string { return strings.Replace(s, "      - evmDestination: EvmAddress\n", "", 1) },
+			wantErr: `field 4 is "" in the schema but "evmDestination: Common.Types:EvmAddress"`,
+		},
+		{
+			name:    "record not in module",
+			edit:    func(s string) string { return strings.Replace(s, "Common.Types:EvmAddress", "Common.Types:EvmAddr", 1) },
+			wantErr: "Common.Types:EvmAddr is not a record in bridge-wayfinder-1.0.0",
+		},
+		{
+			name: "opaque value",
+			edit: func(s string) string {
+				return strings.Replace(s, "evmAddress: Optional EvmAddress", "evmAddress: Value", 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := loadTestSchema(t, tt.edit(checkSchema))
+			err := checkRecords(s, dars)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRunChecksRecordsAgainstDAR(t *testing.T) {
+	const pkgID = "abc"
+	dalf := "bridge-wayfinder-1.0.0-" + pkgID + ".dalf"
+	dar := writeDAR(t, map[string][]byte{
+		manifestPath: []byte("Main-Dalf: " + dalf + "\n"),
+		dalf:         archiveOf(pkgID, fingerprintPackage()),
+	})
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "bridge.yaml")
+	outPath := filepath.Join(dir, "bridge_gen.go")
+
+	require.NoError(t, os.WriteFile(schemaPath, []byte(checkSchema), 0o600))
+	require.NoError(t, run(schemaPath, outPath, []string{dar}))
+
+	require.NoError(t, os.WriteFile(schemaPath, []byte(strings.Replace(checkSchema, "amount: Numeric", "amount: Text", 1)), 0o600))
+	err := run(schemaPath, outPath, []string{dar})
+	assert.ErrorContains(t, err, "record InitiateWithdrawal: field 3")
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Command damlgen generates typed Go codecs for Daml records from a package
+// schema.
+//
+// The schema (YAML) lists the Daml packages, records, variants, templates and
+// interfaces the middleware uses. For each record damlgen emits a Go struct
+// with ToRecord/ToValue and FromValue/FromRecord/FromFields methods, and for
+// each template a daml.Template identifier plus choice-name constants. Passing
+// the package DARs with -dar stamps the generated daml.Package values with the
+// version and package ID read from each DAR's main dalf, and checks every
+// record the schema declares in one of its modules against the Daml-LF record:
+// a field whose label, position or type differs fails generation.
+//
+// Generated packages live under pkg/cantonsdk/daml and are regenerated with:
+//
+//	go generate ./pkg/cantonsdk/daml/...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type darFlags []string
+
+func (d *darFlags) String() string     { return strings.Join(*d, ",") }
+func (d *darFlags) Set(v string) error { *d = append(*d, v); return nil }
+
+func main() {
+	schemaPath := flag.String("schema", "", "Path to the package schema (YAML)")
+	outPath := flag.String("out", "", "Output Go file (default: <schema>_gen.go next to the schema)")
+	var dars darFlags
+	flag.Var(&dars, "dar", "DAR to read package version and ID from and check records against (repeatable)")
+	flag.Parse()
+
+	if *schemaPath == "" {
+		fmt.Fprintln(os.Stderr, "damlgen: -schema is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *outPath == "" {
+		*outPath = strings.TrimSuffix(*schemaPath, filepath.Ext(*schemaPath)) + "_gen.go"
+	}
+
+	if err := run(*schemaPath, *outPath, dars); err != nil {
+		fmt.Fprintf(os.Stderr, "damlgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, outPath string, dars []string) error {
+	s, err := loadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	infos := make([]*darInfo, 0, len(dars))
+	for _, dar := range dars {
+		info, err := readDAR(dar)
+		if err != nil {
+			return err
+		}
+		if err := applyDAR(s, info); err != nil {
+			return fmt.Errorf("%s: %w", dar, err)
+		}
+		infos = append(infos, info)
+	}
+	if err := checkRecords(s, infos); err != nil {
+		return fmt.Errorf("%s: %w", schemaPath, err)
+	}
+	src, err := generate(s, filepath.Base(schemaPath))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, src, 0o600)
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// TestGeneratedUpToDate regenerates every checked-in codec and fails when the
+// output drifts from the committed _gen.go file.
+func TestGeneratedUpToDate(t *testing.T) {
+	schemas, err := filepath.Glob("../../pkg/cantonsdk/daml/*/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, schemas)
+
+	for _, path := range schemas {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			s, err := loadSchema(path)
+			require.NoError(t, err)
+			got, err := generate(s, filepath.Base(path))
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(strings.TrimSuffix(path, ".yaml") + "_gen.go")
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got), "run: go generate ./pkg/cantonsdk/daml/...")
+		})
+	}
+}
+
+func writeDAR(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.dar")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(body)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+	return path
+}
+
+// archive serializes a Daml-LF Archive of an empty Daml-LF 2 package.
+func archive(hash string) []byte {
+	return archiveOf(hash, nil)
+}
+
+// archiveOf serializes a Daml-LF Archive of the serialized Daml-LF 2 package
+// pkg.
+func archiveOf(hash string, pkg []byte) []byte {
+	var payload []byte
+	payload = protowire.AppendTag(payload, payloadLF2Field, protowire.BytesType)
+	payload = protowire.AppendBytes(payload, pkg)
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, 0)
+	b = protowire.AppendTag(b, archivePayloadField, protowire.BytesType)
+	b = protowire.AppendBytes(b, payload)
+	b = protowire.AppendTag(b, archiveHashField, protowire.BytesType)
+	return protowire.AppendString(b, hash)
+}
+
+func TestReadDAR(t *testing.T) {
+	const pkgID = "1a2b3c"
+	dalf := "cip56-token-1.2.0-" + pkgID + "/cip56-token-1.2.0-" + pkgID + ".dalf"
+	manifest := "Manifest-Version: 1.0\r\nMain-Dalf: " + dalf[:20] + "\r\n " + dalf[20:] + "\r\nSdk-Version: 3.3.0\r\n"
+
+	info, err := readDAR(writeDAR(t, map[string][]byte{
+		manifestPath: []byte(manifest),
+		dalf:         archive(pkgID),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "cip56-token-1.2.0", info.NameVersion)
+	assert.Equal(t, pkgID, info.PackageID)
+	assert.Empty(t, info.Package.Records)
+
+	s := &schema{Packages: []damlPackage{{Name: "cip56-token", Go: "CIP56Token"}}}
+	require.NoError(t, applyDAR(s, info))
+	assert.Equal(t, "1.2.0", s.Packages[0].Version)
+	assert.Equal(t, pkgID, s.Packages[0].ID)
+
+	err = applyDAR(&schema{Packages: []damlPackage{{Name: "common", Go: "Common"}}}, info)
+	assert.ErrorContains(t, err, "matches no package")
+}
+
+func TestReadDARErrors(t *testing.T) {
+	_, err := readDAR(writeDAR(t, map[string][]byte{"x.dalf": archive("abc")}))
+	assert.ErrorContains(t, err, "no "+manifestPath)
+
+	_, err = readDAR(writeDAR(t, map[string][]byte{manifestPath: []byte("Main-Dalf: missing.dalf\n")}))
+	assert.ErrorContains(t, err, "not in archive")
+
+	_, err = readDAR(writeDAR(t, map[string][]byte{
+		manifestPath:         []byte("Main-Dalf: pkg-1.0.0-abc.dalf\n"),
+		"pkg-1.0.0-abc.dalf": archive("def"),
+	}))
+	assert.ErrorContains(t, err, "does not carry package id")
+}
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		in     string
+		goType string
+	}{
+		{"Text", "string"},
+		{"Numeric", "string"},
+		{"Time", "time.Time"},
+		{"Optional Party", "*string"},
+		{"Optional Value", "*lapiv2.Value"},
+		{"List ContractId", "[]string"},
+		{"TextMap (Optional Int)", "map[string]*int64"},
+		{"splice.Metadata", "splice.Metadata"},
+		{"Optional splice.Metadata", "*splice.Metadata"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			typ, err := parseType(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.goType, typ.goType())
+		})
+	}
+
+	for _, bad := range []string{"", "Optional", "Optional (Optional Text)", "List Text Text", "(Text"} {
+		_, err := parseType(bad)
+		assert.Errorf(t, err, "parseType(%q)", bad)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "InputHoldingCIDs", goName("inputHoldingCids"))
+	assert.Equal(t, "HoldingCID", goName("holdingCid"))
+	assert.Equal(t, "InstrumentID", goName("instrumentId"))
+	assert.Equal(t, "EvmTxHash", goName("evmTxHash"))
+	assert.Equal(t, "ChoiceTransferFactoryTransfer", choiceConst("TransferFactory_Transfer"))
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schema describes one generated Go package: the Daml packages it covers and
+// the records, variants, enums, templates and interfaces to emit.
+type schema struct {
+	Package    string            `yaml:"package"`
+	Imports    map[string]string `yaml:"imports"`
+	Packages   []damlPackage     `yaml:"packages"`
+	Records    []record          `yaml:"records"`
+	Variants   []variant         `yaml:"variants"`
+	Enums      []variant         `yaml:"enums"`
+	Templates  []template        `yaml:"templates"`
+	Interfaces []template        `yaml:"interfaces"`
+}
+
+// damlPackage is a Daml package the schema's templates live in. Version and
+// ID are normally filled in from a DAR via -dar.
+type damlPackage struct {
+	Name    string `yaml:"name"`
+	Go      string `yaml:"go"`
+	Version string `yaml:"version"`
+	ID      string `yaml:"id"`
+}
+
+// record is a Daml record. A newtype has a single field that the Ledger API
+// matches by position, so it is encoded without a label.
+type record struct {
+	Name    string  `yaml:"name"`
+	Daml    string  `yaml:"daml"`
+	Doc     string  `yaml:"doc"`
+	Newtype bool    `yaml:"newtype"`
+	Fields  []field `yaml:"fields"`
+}
+
+// field is one record field, written in the schema as "- label: Type".
+type field struct {
+	Label string
+	Type  *damlType
+}
+
+// variant is a Daml variant or enum whose constructors carry no payload.
+type variant struct {
+	Name         string   `yaml:"name"`
+	Daml         string   `yaml:"daml"`
+	Doc          string   `yaml:"doc"`
+	Constructors []string `yaml:"constructors"`
+}
+
+// template is a Daml template or interface and the choices exercised on it.
+type template struct {
+	Name    string   `yaml:"name"`
+	Package string   `yaml:"package"`
+	ID      string   `yaml:"id"`
+	Doc     string   `yaml:"doc"`
+	Choices []string `yaml:"choices"`
+}
+
+func (t template) moduleEntity() (string, string, error) {
+	module, entity, ok := strings.Cut(t.ID, ":")
+	if !ok || module == "" || entity == "" {
+		return "", "", fmt.Errorf("template %s: id %q is not Module:Entity", t.Name, t.ID)
+	}
+	return module, entity, nil
+}
+
+func (f *field) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode || len(n.Content) != 2 {
+		return fmt.Errorf("line %d: field must be a single \"label: Type\" entry", n.Line)
+	}
+	var typ string
+	if err := n.Content[1].Decode(&typ); err != nil {
+		return fmt.Errorf("line %d: %w", n.Line, err)
+	}
+	t, err := parseType(typ)
+	if err != nil {
+		return fmt.Errorf("line %d: field %s: %w", n.Line, n.Content[0].Value, err)
+	}
+	f.Label = n.Content[0].Value
+	f.Type = t
+	return nil
+}
+
+func loadSchema(path string) (*schema, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s schema
+	if err := yaml.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func (s *schema) validate() error {
+	if s.Package == "" {
+		return errors.New("package is required")
+	}
+	names := make(map[string]string)
+	declare := func(name, kind string) error {
+		if prev, ok := names[name]; ok {
+			return fmt.Errorf("%s %s redeclares %s %s", kind, name, prev, name)
+		}
+		names[name] = kind
+		return nil
+	}
+
+	pkgs := make(map[string]bool, len(s.Packages))
+	for _, p := range s.Packages {
+		if p.Name == "" || p.Go == "" {
+			return errors.New("packages need name and go")
+		}
+		if err := declare(p.Go+"Package", "package"); err != nil {
+			return err
+		}
+		pkgs[p.Name] = true
+	}
+
+	for _, r := range s.Records {
+		if err := declare(r.Name, "record"); err != nil {
+			return err
+		}
+		if len(r.Fields) == 0 {
+			return fmt.Errorf("record %s has no fields", r.Name)
+		}
+		if r.Newtype && len(r.Fields) != 1 {
+			return fmt.Errorf("newtype %s must have exactly one field", r.Name)
+		}
+	}
+	for _, v := range append(append([]variant(nil), s.Variants...), s.Enums...) {
+		if err := declare(v.Name, "variant"); err != nil {
+			return err
+		}
+		if len(v.Constructors) == 0 {
+			return fmt.Errorf("variant %s has no constructors", v.Name)
+		}
+	}
+
+	for _, t := range s.Templates {
+		if err := s.validateTemplate(t, "Template", pkgs, declare); err != nil {
+			return err
+		}
+	}
+	for _, t := range s.Interfaces {
+		if err := s.validateTemplate(t, "Interface", pkgs, declare); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range s.Records {
+		for _, f := range r.Fields {
+			if err := s.checkType(f.Type, names); err != nil {
+				return fmt.Errorf("record %s field %s: %w", r.Name, f.Label, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *schema) validateTemplate(t template, suffix string, pkgs map[string]bool, declare func(string, string) error) error {
+	if _, _, err := t.moduleEntity(); err != nil {
+		return err
+	}
+	if !pkgs[t.Package] {
+		return fmt.Errorf("template %s: unknown package %q", t.Name, t.Package)
+	}
+	if err := declare(t.Name+suffix, "template"); err != nil {
+		return err
+	}
+	for _, c := range t.Choices {
+		if err := declare(choiceConst(c), "choice"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkType verifies that every named type is declared in the schema or
+// qualified by one of its imports.
+func (s *schema) checkType(t *damlType, names map[string]string) error {
+	switch t.kind {
+	case kindNamed:
+		if alias, _, ok := strings.Cut(t.name, "."); ok {
+			if _, imported := s.Imports[alias]; !imported {
+				return fmt.Errorf("type %s: unknown import %q", t.name, alias)
+			}
+			return nil
+		}
+		if k := names[t.name]; k != "record" && k != "variant" {
+			return fmt.Errorf("unknown type %s", t.name)
+		}
+	case kindOptional, kindList, kindTextMap:
+		return s.checkType(t.elem, names)
+	}
+	return nil
+}
+
+// goName converts a Daml field label to an exported Go identifier, spelling
+// the Id and Cid suffixes as Go initialisms.
+func goName(label string) string {
+	r := []rune(label)
+	r[0] = unicode.ToUpper(r[0])
+	name := string(r)
+	for _, suffix := range []struct{ daml, goName string }{
+		{"Cids", "CIDs"}, {"Cid", "CID"}, {"Ids", "IDs"}, {"Id", "ID"},
+	} {
+		if strings.HasSuffix(name, suffix.daml) {
+			return strings.TrimSuffix(name, suffix.daml) + suffix.goName
+		}
+	}
+	return name
+}
+
+// choiceConst is the Go constant naming a choice.
+func choiceConst(choice string) string {
+	return "Choice" + strings.ReplaceAll(choice, "_", "")
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type typeKind int
+
+const (
+	kindText typeKind = iota
+	kindParty
+	kindNumeric
+	kindContractID
+	kindTime
+	kindInt
+	kindBool
+	kindValue
+	kindOptional
+	kindList
+	kindTextMap
+	kindNamed
+)
+
+// damlType is a parsed schema type such as "Optional (List Party)".
+type damlType struct {
+	kind typeKind
+	elem *damlType // Optional, List and TextMap
+	name string    // Named: local record/variant or alias.Name
+}
+
+// primitive maps schema keywords to their kinds along with the Go type and
+// the values/daml helpers that encode and decode them.
+var primitives = map[string]struct {
+	kind   typeKind
+	goType string
+	enc    string
+	dec    string
+}{
+	"Text":       {kindText, "string", "values.TextValue", "daml.DecodeText"},
+	"Party":      {kindParty, "string", "values.PartyValue", "daml.DecodeParty"},
+	"Numeric":    {kindNumeric, "string", "values.NumericValue", "daml.DecodeNumeric"},
+	"ContractId": {kindContractID, "string", "values.ContractIDValue", "daml.DecodeContractID"},
+	"Time":       {kindTime, "time.Time", "values.TimestampValue", "daml.DecodeTime"},
+	"Int":        {kindInt, "int64", "values.Int64Value", "daml.DecodeInt64"},
+	"Bool":       {kindBool, "bool", "values.BoolValue", "daml.DecodeBool"},
+	// Value is an opaque *lapiv2.Value for types the middleware passes
+	// through without inspecting (AnyValue, Lock, ...).
+	"Value": {kindValue, "*lapiv2.Value", "daml.Raw", "daml.DecodeRaw"},
+}
+
+var containers = map[string]typeKind{
+	"Optional": kindOptional,
+	"List":     kindList,
+	"TextMap":  kindTextMap,
+}
+
+func parseType(s string) (*damlType, error) {
+	toks := tokenize(s)
+	if len(toks) == 0 {
+		return nil, errors.New("empty type")
+	}
+	t, rest, err := parseTokens(toks)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("type %q: unexpected %q", s, rest[0])
+	}
+	if t.kind == kindOptional && t.elem.kind == kindOptional {
+		return nil, fmt.Errorf("type %q: nested Optional is not supported", s)
+	}
+	return t, nil
+}
+
+func tokenize(s string) []string {
+	s = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(s)
+	return strings.Fields(s)
+}
+
+func parseTokens(toks []string) (*damlType, []string, error) {
+	if len(toks) == 0 {
+		return nil, nil, errors.New("missing type")
+	}
+	head, rest := toks[0], toks[1:]
+	switch {
+	case head == "(":
+		t, rest, err := parseTokens(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 || rest[0] != ")" {
+			return nil, nil, errors.New("missing )")
+		}
+		return t, rest[1:], nil
+	case head == ")":
+		return nil, nil, errors.New("unexpected )")
+	}
+	if kind, ok := containers[head]; ok {
+		elem, rest, err := parseTokens(rest)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", head, err)
+		}
+		return &damlType{kind: kind, elem: elem}, rest, nil
+	}
+	if p, ok := primitives[head]; ok {
+		return &damlType{kind: p.kind}, rest, nil
+	}
+	return &damlType{kind: kindNamed, name: head}, rest, nil
+}
+
+func (t *damlType) primitive() (goType, enc, dec string) {
+	for _, p := range primitives {
+		if p.kind == t.kind {
+			return p.goType, p.enc, p.dec
+		}
+	}
+	panic(fmt.Sprintf("damlgen: kind %d is not primitive", t.kind))
+}
+
+// isRawOptional reports whether t is Optional Value, which is carried as a
+// nilable *lapiv2.Value rather than a pointer to one.
+func (t *damlType) isRawOptional() bool {
+	return t.kind == kindOptional && t.elem.kind == kindValue
+}
+
+// goType returns the Go type t is generated as.
+func (t *damlType) goType() string {
+	switch t.kind {
+	case kindNamed:
+		return t.name
+	case kindOptional:
+		if t.isRawOptional() {
+			return "*lapiv2.Value"
+		}
+		return "*" + t.elem.goType()
+	case kindList:
+		return "[]" + t.elem.goType()
+	case kindTextMap:
+		return "map[string]" + t.elem.goType()
+	}
+	goType, _, _ := t.primitive()
+	return goType
+}
+
+// encFunc returns an expression of type func(goType) *lapiv2.Value.
+func (t *damlType) encFunc() string {
+	switch t.kind {
+	case kindNamed:
+		return t.name + ".ToValue"
+	case kindOptional, kindList, kindTextMap:
+		if t.isRawOptional() {
+			return "daml.EncodeOptionalRaw"
+		}
+		return fmt.Sprintf("func(v %s) *lapiv2.Value { return %s }", t.goType(), t.encExpr("v"))
+	}
+	_, enc, _ := t.primitive()
+	return enc
+}
+
+// encExpr returns an expression encoding the Go value x.
+func (t *damlType) encExpr(x string) string {
+	switch t.kind {
+	case kindNamed:
+		return x + ".ToValue()"
+	case kindOptional:
+		if t.isRawOptional() {
+			return "daml.EncodeOptionalRaw(" + x + ")"
+		}
+		return fmt.Sprintf("daml.EncodeOptional(%s, %s)", x, t.elem.encFunc())
+	case kindList:
+		return fmt.Sprintf("daml.EncodeList(%s, %s)", x, t.elem.encFunc())
+	case kindTextMap:
+		return fmt.Sprintf("daml.EncodeTextMap(%s, %s)", x, t.elem.encFunc())
+	}
+	_, enc, _ := t.primitive()
+	return enc + "(" + x + ")"
+}
+
+// decFunc returns an expression of type func(*lapiv2.Value) (goType, error).
+func (t *damlType) decFunc() string {
+	switch t.kind {
+	case kindNamed:
+		return "daml.Decode[" + t.name + "]"
+	case kindOptional, kindList, kindTextMap:
+		if t.isRawOptional() {
+			return "daml.DecodeOptionalRaw"
+		}
+		return fmt.Sprintf("func(v *lapiv2.Value) (%s, error) { return %s }", t.goType(), t.decExpr("v"))
+	}
+	_, _, dec := t.primitive()
+	return dec
+}
+
+// decExpr returns an expression decoding the *lapiv2.Value v, yielding
+// (goType, error).
+func (t *damlType) decExpr(v string) string {
+	switch t.kind {
+	case kindOptional:
+		if t.isRawOptional() {
+			return "daml.DecodeOptionalRaw(" + v + ")"
+		}
+		return fmt.Sprintf("daml.DecodeOptional(%s, %s)", v, t.elem.decFunc())
+	case kindList:
+		return fmt.Sprintf("daml.DecodeList(%s, %s)", v, t.elem.decFunc())
+	case kindTextMap:
+		return fmt.Sprintf("daml.DecodeTextMap(%s, %s)", v, t.elem.decFunc())
+	}
+	return t.decFunc() + "(" + v + ")"
+}
+
+// uses reports whether t or any element type satisfies pred.
+func (t *damlType) uses(pred func(*damlType) bool) bool {
+	for ; t != nil; t = t.elem {
+		if pred(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns t in schema syntax.
+func (t *damlType) String() string {
+	switch t.kind {
+	case kindNamed:
+		return t.name
+	case kindOptional, kindList, kindTextMap:
+		if t.elem.elem != nil {
+			return t.keyword() + " (" + t.elem.String() + ")"
+		}
+		return t.keyword() + " " + t.elem.String()
+	}
+	return t.keyword()
+}
+
+// keyword returns the schema keyword of a primitive or container type.
+func (t *damlType) keyword() string {
+	for name, kind := range containers {
+		if kind == t.kind {
+			return name
+		}
+	}
+	for name, p := range primitives {
+		if p.kind == t.kind {
+			return name
+		}
+	}
+	return t.name
+}
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 
@@ -32,6 +33,10 @@ func main() {
 
 	log.Printf("Running migrations for indexer database")
 
+	if err = indexerdb.RenameLegacyMigrations(context.Background(), db); err != nil {
+		log.Fatalf("rename legacy migrations: %v", err)
+	}
+
 	if err = mghelper.RunMigrations(migrate.NewMigrator(db, indexerdb.Migrations), flag.Args()...); err != nil {
 		mghelper.Exitf(err.Error())
 	}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Command reindex rewinds the indexer database to a past ledger offset or
+// rebuilds its balances, supplies and holder counts from the stored events,
+// and prints the report as JSON. Stop the indexer first: unlike the admin
+// API, this command cannot pause a running processor.
+//
+//	reindex -config config.yaml -rewind-to 1200 -dry-run
+//	reindex -config config.yaml -rebuild
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/config"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/reindex"
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+	"github.com/chainsafe/canton-middleware/pkg/pgutil"
+)
+
+func main() {
+	cfgPath := flag.String("config", "config.yaml", "Path to configuration file")
+	rewindTo := flag.Int64("rewind-to", -1, "Ledger offset to rewind the indexed state to")
+	rebuild := flag.Bool("rebuild", false, "Rebuild balances, supplies and holder counts from the stored events")
+	dryRun := flag.Bool("dry-run", false, "Report the changes without making them")
+	flag.Parse()
+
+	if (*rewindTo >= 0) == *rebuild {
+		log.Fatalf("exactly one of -rewind-to and -rebuild is required")
+	}
+
+	cfg, err := config.LoadIndexerServer(*cfgPath)
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	db, err := pgutil.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("connect database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	svc := reindex.NewService(indexerstore.NewStore(db), nil, zap.NewNop())
+	var report any
+	if *rebuild {
+		report, err = svc.Rebuild(ctx, *dryRun)
+	} else {
+		report, err = svc.Rewind(ctx, *rewindTo, *dryRun)
+	}
+	if err != nil {
+		log.Fatalf("reindex: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(report); err != nil {
+		log.Fatalf("write report: %v", err)
+	}
+}
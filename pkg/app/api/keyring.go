@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chainsafe/canton-middleware/pkg/config"
+	"github.com/chainsafe/canton-middleware/pkg/keys"
+	"github.com/chainsafe/canton-middleware/pkg/keys/vault"
+)
+
+// LoadKeyring builds the custodial key Keyring from the master keys named in
+// cfg. The MasterKeyEnv key also decrypts keys written before envelope
+// encryption; with a Vault Transit key configured it is optional.
+func LoadKeyring(cfg *config.KeyManagement) (*keys.Keyring, error) {
+	masterKeys := make(map[string]keys.MasterKey, len(cfg.MasterKeys)+2)
+	add := func(id string, mk keys.MasterKey) error {
+		if _, dup := masterKeys[id]; dup {
+			return fmt.Errorf("duplicate master key id %q", id)
+		}
+		masterKeys[id] = mk
+		return nil
+	}
+	addLocal := func(id, env string) error {
+		encoded := os.Getenv(env)
+		if encoded == "" {
+			return fmt.Errorf("canton master key not set: env=%s (hint: openssl rand -base64 32)", env)
+		}
+		raw, err := keys.MasterKeyFromBase64(encoded)
+		if err != nil {
+			return fmt.Errorf("invalid canton master key %q: %w", id, err)
+		}
+		key, err := keys.NewAESMasterKey(raw)
+		if err != nil {
+			return fmt.Errorf("invalid canton master key %q: %w", id, err)
+		}
+		return add(id, key)
+	}
+
+	legacyID, activeID := cfg.MasterKeyID, cfg.MasterKeyID
+	if cfg.VaultTransit != nil {
+		transit, err := vault.NewTransit(cfg.VaultTransit, os.Getenv(cfg.VaultTransit.TokenEnv))
+		if err != nil {
+			return nil, err
+		}
+		if err := add(cfg.VaultTransit.KeyID, transit); err != nil {
+			return nil, err
+		}
+		activeID = cfg.VaultTransit.KeyID
+		if os.Getenv(cfg.MasterKeyEnv) == "" {
+			legacyID = ""
+		}
+	}
+	if legacyID != "" {
+		if err := addLocal(cfg.MasterKeyID, cfg.MasterKeyEnv); err != nil {
+			return nil, err
+		}
+	}
+	for _, mk := range cfg.MasterKeys {
+		if err := addLocal(mk.ID, mk.Env); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ActiveKeyID != "" {
+		activeID = cfg.ActiveKeyID
+	}
+	return keys.NewKeyring(masterKeys, activeID, legacyID)
+}
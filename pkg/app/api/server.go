@@ -14,10 +14,18 @@ import (
 
 	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
 	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/auth/jwt"
+	authservice "github.com/chainsafe/canton-middleware/pkg/auth/service"
+	nonceprovider "github.com/chainsafe/canton-middleware/pkg/auth/service/nonce_provider"
+	authstore "github.com/chainsafe/canton-middleware/pkg/auth/store"
 	canton "github.com/chainsafe/canton-middleware/pkg/cantonsdk/client"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	cantontkn "github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/commandstore"
 	"github.com/chainsafe/canton-middleware/pkg/config"
 	"github.com/chainsafe/canton-middleware/pkg/custodial"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy"
 	ethrpcminer "github.com/chainsafe/canton-middleware/pkg/ethrpc/miner"
 	ethrpc "github.com/chainsafe/canton-middleware/pkg/ethrpc/service"
 	ethrpcstore "github.com/chainsafe/canton-middleware/pkg/ethrpc/store"
@@ -46,8 +54,17 @@ import (
 const (
 	defaultRequestTimeout = 60
 	topologyCacheTTL      = 5 * time.Minute
+	migrationCacheTTL     = 10 * time.Minute
 	transferCacheTTL      = 2 * time.Minute
 	transferCacheMaxSize  = 10000
+
+	// serviceTokenSubject names the api-server in the service tokens it mints.
+	serviceTokenSubject = "api-server"
+	// sessionPruneInterval is how often ended login sessions are deleted.
+	sessionPruneInterval = time.Hour
+	// actionNonceTTL is the nonce lifetime when auth is not configured and
+	// nonces only serve signed actions.
+	actionNonceTTL = 5 * time.Minute
 )
 
 // Server holds cfg to init the api server.
@@ -84,7 +101,7 @@ func (s *Server) Run() error {
 		zap.Int("port", cfg.Server.Port),
 	)
 
-	masterKey, err := s.getMasterKey()
+	cipher, err := LoadKeyring(cfg.KeyManagement)
 	if err != nil {
 		return err
 	}
@@ -102,11 +119,9 @@ func (s *Server) Run() error {
 		userstore.NewStore(dbBun),
 		userstore.NewStoreMetrics(reg),
 	)
-	cipher := keys.NewMasterKeyCipher(masterKey)
-
 	metrics := apphttp.NewHTTPMetrics(reg)
 
-	cantonClient, err := s.openCantonClient(ctx, userStore, cipher, reg, logger)
+	cantonClient, err := s.openCantonClient(ctx, userStore, commandstore.NewPostgres(dbBun), cipher, reg, logger)
 	if err != nil {
 		return err
 	}
@@ -118,7 +133,17 @@ func (s *Server) Run() error {
 	// indexer mode), the accept worker, and the transfers list endpoints
 	// all read from it. Build the HTTP client once and share — separate
 	// instances would just open redundant idle connections to the same host.
-	indexerClient, err := buildIndexerClient(cfg, reg)
+	// SIWE login + JWT (optional). The issuer also mints the service token the
+	// indexer client presents, so an indexer validating JWTs accepts our calls.
+	// One nonce store serves SIWE login and the signed actions (self-custody
+	// export, accept-policy changes), so a single GET /auth/nonce covers both.
+	nonces := newNonceStore(cfg.Auth, dbBun)
+	authn, err := buildAuth(cfg.Auth, nonces, dbBun, userStore, logger)
+	if err != nil {
+		return err
+	}
+
+	indexerClient, err := buildIndexerClient(cfg, authn, reg)
 	if err != nil {
 		return err
 	}
@@ -134,7 +159,7 @@ func (s *Server) Run() error {
 	// race with in-flight worker calls.
 	g, gCtx := errgroup.WithContext(ctx)
 
-	svcs, err := initServices(gCtx, g, cfg, dbBun, userStore, cantonClient, indexerClient, cipher, wl, reg, logger)
+	svcs, err := initServices(gCtx, g, cfg, dbBun, userStore, cantonClient, indexerClient, cipher, wl, nonces, reg, logger)
 	if err != nil {
 		// initServices may have already started workers on g (the caches, and on
 		// later failures the miner/submitter). Cancel the group's context and wait
@@ -145,21 +170,51 @@ func (s *Server) Run() error {
 		return err
 	}
 
+	if authn != nil {
+		g.Go(func() error { return authn.pruner.Run(gCtx) })
+	}
+
 	if cfg.AcceptWorker != nil {
+		var opts []custodial.AcceptWorkerOption
+		if streamCfg := cfg.AcceptWorker.Stream; streamCfg != nil {
+			offers, err := custodial.NewLedgerOfferStream(
+				cantonClient.Ledger, cfg.Canton.Token.UtilityRegistryAppPackageID, logger,
+			)
+			if err != nil {
+				stop()
+				_ = g.Wait()
+				return fmt.Errorf("accept worker stream: %w", err)
+			}
+			opts = append(opts, custodial.WithOfferStream(offers, custodial.NewPostgresCursor(dbBun), streamCfg))
+		}
 		worker := custodial.NewAcceptWorker(
 			cantonClient.Token,
 			userStore,
+			svcs.acceptPolicy,
 			indexerClient,
 			cfg.AcceptWorker.PollInterval,
 			custodial.NewMetrics(reg),
 			logger,
+			opts...,
 		)
 		g.Go(func() error { return worker.Run(gCtx) })
 		logger.Info("accept worker started",
 			zap.Duration("poll_interval", cfg.AcceptWorker.PollInterval),
+			zap.Bool("stream", cfg.AcceptWorker.Stream != nil),
 		)
 	}
 
+	if cfg.CompactionWorker != nil {
+		compactor := custodial.NewCompactionWorker(
+			cantonClient.Token,
+			userStore,
+			cfg.CompactionWorker,
+			custodial.NewCompactionMetrics(reg),
+			logger,
+		)
+		g.Go(func() error { return compactor.Run(gCtx) })
+	}
+
 	// Admin config is optional (nil when the `admin` block is omitted). The token
 	// is resolved by the config loader (api_key: "${ADMIN_API_KEY}") and validated
 	// as required when enabled, so setupRouter can read it straight off the value.
@@ -170,7 +225,7 @@ func (s *Server) Run() error {
 
 	router := s.setupRouter(
 		svcs.evmStore, wl, cantonClient, svcs.tokenService, svcs.regSvc, svcs.transferSvc,
-		adminCfg, metrics, logger,
+		svcs.acceptPolicy, authn, nonces, adminCfg, metrics, logger,
 	)
 
 	s.registerServers(g, gCtx, router, logger)
@@ -178,6 +233,73 @@ func (s *Server) Run() error {
 	return g.Wait()
 }
 
+// authComponents is the SIWE login service and the JWT plumbing built from the
+// auth config. A nil *authComponents means auth is disabled.
+type authComponents struct {
+	login    authservice.Service
+	issuer   *jwt.Issuer
+	readAuth func(http.Handler) http.Handler
+	pruner   *authservice.SessionPruner
+}
+
+// buildAuth wires SIWE login and JWT validation from cfg; nil cfg disables auth.
+// Tokens are validated in-process against the issuer's own keys, and their
+// session checked against the session store — other services use the published
+// JWKS and revoked-sessions list instead.
+func buildAuth(
+	cfg *auth.Config, nonces authservice.NonceStore, db *bun.DB, users authservice.UserLookup, logger *zap.Logger,
+) (*authComponents, error) {
+	if cfg == nil {
+		logger.Warn("auth not configured: transfer list endpoints are unauthenticated")
+		return nil, nil
+	}
+	sessions := authstore.NewStore(db)
+	key, err := jwt.ParseRSAPrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("auth.private_key: %w", err)
+	}
+	opts := make([]jwt.IssuerOption, 0, len(cfg.RetiredKeys))
+	for i, rk := range cfg.RetiredKeys {
+		if rk.KeyID == cfg.KeyID {
+			return nil, fmt.Errorf("auth.retired_keys[%d]: kid %q is the active signing key", i, rk.KeyID)
+		}
+		pub, err := jwt.ParseRSAPublicKey(rk.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("auth.retired_keys[%d]: %w", i, err)
+		}
+		opts = append(opts, jwt.WithRetiredKey(rk.KeyID, pub))
+	}
+	issuer := jwt.NewIssuer(key, cfg.KeyID, cfg.Issuer, cfg.Audience, cfg.TokenTTL, opts...)
+	validator := jwt.NewValidatorWithKeys(issuer.VerificationKeys(), cfg.Issuer, jwt.WithRevocationChecker(sessions))
+
+	login := authservice.New(
+		jwt.NewSIWEVerifier(cfg.Domain, cfg.URI, cfg.ChainID),
+		issuer,
+		nonces,
+		users,
+		sessions,
+		cfg.RefreshTokenTTL,
+	)
+	return &authComponents{
+		login:    authservice.NewLog(login, logger),
+		issuer:   issuer,
+		readAuth: jwt.RequireAuth(validator, cfg.Audience),
+		pruner:   authservice.NewSessionPruner(sessions, cfg.TokenTTL, sessionPruneInterval, logger),
+	}, nil
+}
+
+// newNonceStore returns the nonce store selected by cfg.NonceStore. Without auth
+// config it is the Postgres store, so signed actions work across replicas.
+func newNonceStore(cfg *auth.Config, db *bun.DB) authservice.NonceStore {
+	if cfg == nil {
+		return nonceprovider.NewPostgres(db, actionNonceTTL)
+	}
+	if cfg.NonceStore == auth.NonceStorePostgres {
+		return nonceprovider.NewPostgres(db, cfg.NonceTTL)
+	}
+	return nonceprovider.NewInMemory(cfg.NonceTTL)
+}
+
 // buildIndexerClient creates the single indexer HTTP client used by every
 // part of the api-server that talks to the indexer. The URL is read from
 // token_provider.indexer.url, falling back to accept_worker.indexer_url, since
@@ -187,8 +309,12 @@ func (s *Server) Run() error {
 //
 // An indexer is now required (the transfer list/history endpoints back onto it), so
 // startup fails fast if neither location is configured. The returned client is
-// wrapped with metrics so all outbound indexer calls are observed.
-func buildIndexerClient(cfg *config.APIServer, reg sharedmetrics.NamespacedRegisterer) (indexerclient.Client, error) {
+// wrapped with metrics so all outbound indexer calls are observed. With auth
+// enabled every call carries a service token, which is what lets internal
+// callers such as the accept worker read across parties.
+func buildIndexerClient(
+	cfg *config.APIServer, authn *authComponents, reg sharedmetrics.NamespacedRegisterer,
+) (indexerclient.Client, error) {
 	url := ""
 	if cfg.TokenProvider != nil && cfg.TokenProvider.Indexer != nil {
 		url = cfg.TokenProvider.Indexer.URL
@@ -199,7 +325,12 @@ func buildIndexerClient(cfg *config.APIServer, reg sharedmetrics.NamespacedRegis
 	if url == "" {
 		return nil, fmt.Errorf("indexer URL is required: set token_provider.indexer.url or accept_worker.indexer_url")
 	}
-	c, err := indexerclient.New(url, nil)
+	var httpClient *http.Client
+	if authn != nil {
+		src := jwt.NewServiceTokenSource(authn.issuer, serviceTokenSubject)
+		httpClient = &http.Client{Transport: src.Transport(nil)}
+	}
+	c, err := indexerclient.New(url, httpClient)
 	if err != nil {
 		return nil, fmt.Errorf("create indexer client (%s): %w", url, err)
 	}
@@ -225,6 +356,7 @@ type services struct {
 	tokenService *token.Service
 	regSvc       userservice.Service
 	transferSvc  transfer.Service
+	acceptPolicy *policy.Service
 }
 
 func initServices(
@@ -237,11 +369,14 @@ func initServices(
 	indexerClient indexerclient.Client,
 	cipher keys.KeyCipher,
 	wl whitelist.Checker,
+	nonces authservice.NonceStore,
 	reg sharedmetrics.NamespacedRegisterer,
 	logger *zap.Logger,
 ) (*services, error) {
 	topologyCache := userservice.NewTopologyCache(topologyCacheTTL)
 	g.Go(func() error { return topologyCache.Start(gCtx) })
+	migrationCache := userservice.NewPostgresMigrationCache(dbBun, migrationCacheTTL)
+	g.Go(func() error { return migrationCache.Start(gCtx) })
 
 	registrationService := userservice.NewService(
 		userStore,
@@ -251,6 +386,8 @@ func initServices(
 		cfg.SkipCantonSigVerify,
 		wl,
 		topologyCache,
+		migrationCache,
+		nonces,
 	)
 
 	tokenDataProvider, err := buildTokenProvider(cfg, cantonClient.Token, indexerClient)
@@ -305,28 +442,14 @@ func initServices(
 		tokenService: tokenService,
 		regSvc:       userservice.NewLog(registrationService, logger),
 		transferSvc:  transfer.NewLog(transferSvc, logger),
+		acceptPolicy: policy.NewService(policy.NewPostgres(dbBun), userStore),
 	}, nil
 }
 
-func (s *Server) getMasterKey() ([]byte, error) {
-	masterKeyStr := os.Getenv(s.cfg.KeyManagement.MasterKeyEnv)
-	if masterKeyStr == "" {
-		return nil, fmt.Errorf(
-			"canton master key not set: env=%s (hint: openssl rand -base64 32)",
-			s.cfg.KeyManagement.MasterKeyEnv,
-		)
-	}
-
-	masterKey, err := keys.MasterKeyFromBase64(masterKeyStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid canton master key: %w", err)
-	}
-	return masterKey, nil
-}
-
 func (s *Server) openCantonClient(
 	ctx context.Context,
 	keyStore userKeyStore,
+	commands ledger.CommandStore,
 	cipher keys.KeyCipher,
 	reg sharedmetrics.NamespacedRegisterer,
 	logger *zap.Logger,
@@ -348,6 +471,7 @@ func (s *Server) openCantonClient(
 		canton.WithLogger(logger),
 		canton.WithKeyResolver(keyResolver),
 		canton.WithPrometheusRegisterer(reg),
+		canton.WithCommandStore(commands),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create canton client: %w", err)
@@ -382,6 +506,9 @@ func (s *Server) setupRouter(
 	tokenService *token.Service,
 	userService userservice.Service,
 	transferSvc transfer.Service,
+	acceptPolicy *policy.Service,
+	authn *authComponents,
+	nonces authservice.NonceStore,
 	adminCfg config.AdminAPI,
 	metrics *apphttp.HTTPMetrics,
 	logger *zap.Logger,
@@ -396,11 +523,8 @@ func (s *Server) setupRouter(
 	r.Use(apphttp.RequestMetricsMiddleware(metrics))
 	r.Use(apphttp.CORSMiddleware(s.cfg.CORSOrigins))
 
-	// Health check
-	r.Get(s.cfg.Monitoring.HealthCheckURL, func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
+	// Health check, with the Daml package versions the canton client resolved
+	r.Get(s.cfg.Monitoring.HealthCheckURL, apphttp.HealthHandler(cantonClient.Packages))
 
 	// Supported tokens metadata
 	token.RegisterRoutes(r, tokenService, logger)
@@ -408,13 +532,32 @@ func (s *Server) setupRouter(
 	// Registration endpoints
 	userservice.RegisterRoutes(r, userService, logger)
 
-	// Admin endpoints (whitelist management), gated by a static bearer token.
+	// Admin endpoints (whitelist and accept-policy management), gated by a
+	// static bearer token.
 	if adminCfg.Enabled {
 		whitelist.RegisterAdminRoutes(r, wl, adminCfg.APIKey, logger)
+		if s.cfg.AcceptWorker != nil {
+			policy.RegisterAdminRoutes(r, acceptPolicy, adminCfg.APIKey, logger)
+		}
+	}
+
+	// SIWE login and JWKS; JWT-gated transfer list endpoints when auth is on.
+	// Without it /auth/nonce is still served for signed actions.
+	var readAuth func(http.Handler) http.Handler
+	if authn != nil {
+		authservice.RegisterRoutes(r, authn.login, authn.readAuth, logger)
+		readAuth = authn.readAuth
+	} else {
+		authservice.RegisterNonceRoute(r, nonces, logger)
+	}
+
+	// Custodial users' own accept-policy settings, read by the accept worker.
+	if s.cfg.AcceptWorker != nil {
+		policy.RegisterUserRoutes(r, acceptPolicy, nonces, readAuth, logger)
 	}
 
 	// Non-custodial transfer endpoints (prepare/execute)
-	transfer.RegisterRoutes(r, transferSvc, logger)
+	transfer.RegisterRoutes(r, transferSvc, readAuth, logger)
 
 	registryHandler := registry.NewHandler(cantonClient.Token, logger)
 	r.Handle("/registry/transfer-instruction/v1/transfer-factory", registryHandler)
@@ -227,6 +227,20 @@ func GoneError(err error, message string) error {
 	}
 }
 
+// UnavailableError returns an error with category CategoryRecovering (HTTP 503).
+// Use when the service is temporarily unable to serve the request, e.g. at capacity.
+// The error message provided is returned to the user.
+func UnavailableError(err error, message string) error {
+	if err == nil {
+		err = errors.New("unavailable: " + message)
+	}
+	return &ServiceError{
+		Category: CategoryRecovering,
+		Message:  message,
+		Err:      err,
+	}
+}
+
 // ConflictError returns an error with category CategoryDataConflict
 // the error message provided is returned to the user
 // the error object provided is logged in logger
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
+)
+
+// HealthResponse is the body of the health endpoint.
+type HealthResponse struct {
+	Status string `json:"status"`
+	// Packages lists the Daml packages the process resolved at startup.
+	Packages []HealthPackage `json:"packages,omitempty"`
+}
+
+// HealthPackage is one resolved Daml package. Version is empty for a package
+// ID pinned in config that the participant does not report as vetted.
+type HealthPackage struct {
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	PackageID string `json:"package_id"`
+}
+
+// HealthHandler answers the health check with status "OK" and the Daml
+// package versions resolved by pkgs, which may be nil.
+func HealthHandler(pkgs packages.Packages) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		resp := HealthResponse{Status: "OK"}
+		if pkgs != nil {
+			for _, p := range pkgs.Resolved() {
+				resp.Packages = append(resp.Packages, HealthPackage{Name: p.Name, Version: p.Version, PackageID: p.ID})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&resp)
+	}
+}
@@ -3,12 +3,17 @@
 package http
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
 )
 
 // RequestMetricsMiddleware returns a chi-compatible middleware that records
@@ -86,3 +91,33 @@ func CORSMiddleware(origins []string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// BearerAuthMiddleware returns a chi middleware that authorizes requests carrying
+// a static admin token as "Authorization: Bearer <token>". The comparison is
+// constant-time over SHA-256 digests so neither the token value nor its length
+// leaks via timing.
+func BearerAuthMiddleware(token string) func(http.Handler) http.Handler {
+	want := sha256.Sum256([]byte(token))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := bearerToken(r)
+			got := sha256.Sum256([]byte(provided))
+			if provided == "" || subtle.ConstantTimeCompare(got[:], want[:]) != 1 {
+				DefaultErrorHandler(w, apperrors.UnAuthorizedError(nil, "invalid or missing admin credentials"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header,
+// returning "" when the header is absent or not a bearer credential.
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if len(h) <= len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(h[len(prefix):])
+}
@@ -6,9 +6,16 @@
 //  1. A processor that streams TokenTransferEvents from the Canton ledger via
 //     GetUpdates and persists them (events, token supply, balances) into a
 //     dedicated PostgreSQL database.
-//  2. An HTTP read API that exposes the indexed data under /indexer/v1.
+//  2. An HTTP read API that exposes the indexed data under /indexer/v1,
+//     optionally gated by api-server-issued JWTs.
 //
-// Both halves run under the same context via errgroup so that an OS signal or
+// When webhooks are configured, a dispatcher additionally delivers the
+// notifications the processor writes to the webhook outbox. When streaming is
+// configured, a hub wakes the live SSE/WebSocket subscribers after every commit.
+// When the verifier is configured, a worker periodically compares the indexed
+// balances and supplies with the ledger's active contract set.
+//
+// All of them run under the same context via errgroup so that an OS signal or
 // a fatal error in either half cancels the other cleanly.
 package indexer
 
@@ -25,13 +32,21 @@ import (
 
 	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
 	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/auth/jwt"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/identity"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
 	"github.com/chainsafe/canton-middleware/pkg/config"
-	"github.com/chainsafe/canton-middleware/pkg/indexer"
 	"github.com/chainsafe/canton-middleware/pkg/indexer/engine"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/reindex"
 	indexerservice "github.com/chainsafe/canton-middleware/pkg/indexer/service"
 	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/stream"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/verify"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook"
 	"github.com/chainsafe/canton-middleware/pkg/log"
 	"github.com/chainsafe/canton-middleware/pkg/pgutil"
 
@@ -94,6 +109,23 @@ func (s *Server) Run() error {
 	defer func() { _ = ledgerClient.Close() }()
 	logger.Info("Canton ledger connection established", zap.String("rpc_url", cfg.CantonLedger.RPCURL))
 
+	// ── Daml package discovery ─────────────────────────────────────────────────
+	// Package IDs left empty under indexer (and verifier) are resolved from the
+	// participant's vetted packages before any filter is built from them.
+
+	pkgs, err := packages.New(cfg.Packages, ledgerClient, packages.WithLogger(logger))
+	if err != nil {
+		return fmt.Errorf("create packages client: %w", err)
+	}
+	bindings := cfg.Indexer.PackageBindings()
+	if cfg.Verifier != nil {
+		bindings = append(bindings, cfg.Verifier.Identity.PackageBindings()...)
+		bindings = append(bindings, cfg.Verifier.Token.PackageBindings()...)
+	}
+	if err = pkgs.Bind(ctx, bindings...); err != nil {
+		return fmt.Errorf("resolve daml packages: %w", err)
+	}
+
 	// ── Streaming client ──────────────────────────────────────────────────────
 	// One streaming.Client for the indexer in wildcard mode (FiltersForAnyParty).
 	// Wraps GetUpdates with automatic reconnection and OAuth2 token refresh
@@ -105,10 +137,6 @@ func (s *Server) Run() error {
 		return fmt.Errorf("create streaming client: %w", err)
 	}
 
-	// ── Template identifiers ──────────────────────────────────────────────────
-
-	templateIDs := indexerTemplateIDs(cfg.Indexer)
-
 	// ── Metrics — registered once, injected into processor and store ──────────
 
 	reg := sharedmetrics.WithNamespace(prometheus.DefaultRegisterer, "indexer_server")
@@ -118,20 +146,91 @@ func (s *Server) Run() error {
 
 	// ── Decoder / Fetcher / Processor (write path) ────────────────────────────
 
-	filterMode, instruments := cfg.Indexer.FilterModeAndKeys()
-	transferDecode := engine.NewTokenTransferDecoder(filterMode, instruments, logger)
-	offerDecode := engine.NewOfferDecoder(cfg.Indexer.UtilityRegistryPackageID, engineMetrics, logger)
-	holdingDecode := engine.NewHoldingDecoder(cfg.Indexer.UtilityRegistryHoldingPackageID, logger)
-	decode := engine.NewMultiDecoder(transferDecode, offerDecode, holdingDecode)
-	fetcher := engine.NewFetcher(streamClient, templateIDs, decode, logger)
+	// The registry maps each enabled template, under every configured package
+	// version, to its decoder; it yields both the stream filter and the decoder.
+	// Package-name references only match the vetted versions within range.
+
+	decoders, err := engine.NewConfiguredRegistry(cfg.Indexer, engineMetrics, logger, engine.WithPackageRefs(pkgs.InRange))
+	if err != nil {
+		return fmt.Errorf("create decoder registry: %w", err)
+	}
+	logger.Info("Indexer decoders enabled", zap.Strings("decoders", decoders.Names()))
+	fetcher := engine.NewFetcher(streamClient, decoders.TemplateIDs(), decoders.Decode, logger)
 	rawStore := indexerstore.NewStore(db)
 	store := indexerstore.NewInstrumentedStore(rawStore, storeMetrics)
-	processor := engine.NewProcessor(fetcher, store, engineMetrics, logger)
+	var processorOpts []engine.ProcessorOption
+	if cfg.Indexer.Bootstrap.Enabled {
+		snapshotIDs := decoders.SnapshotTemplateIDs()
+		if len(snapshotIDs) == 0 {
+			return fmt.Errorf("snapshot bootstrap requires a decoder that tracks holdings or transfers")
+		}
+		snapshots := engine.NewACSSnapshotSource(streamClient, snapshotIDs, decoders.Decode, logger)
+		processorOpts = append(processorOpts, engine.WithSnapshotBootstrap(snapshots, cfg.Indexer.Bootstrap.AtOffset))
+	}
+
+	// ── Webhooks (optional) ───────────────────────────────────────────────────
+	// The processor enqueues deliveries in its own transactions; the dispatcher
+	// is woken after every commit so they go out without waiting for a poll.
+
+	var (
+		dispatcher  *webhook.Dispatcher
+		webhookSvc  webhook.Service
+		afterCommit []func(offset int64)
+	)
+	if cfg.Webhooks != nil {
+		dispatcher = webhook.NewDispatcher(store, cfg.Webhooks, webhook.NewMetrics(reg), logger)
+		webhookSvc = webhook.NewLog(webhook.NewService(store, dispatcher.Notify, logger), logger)
+		afterCommit = append(afterCommit, func(int64) { dispatcher.Notify() })
+	}
+
+	// ── Streaming (optional) ──────────────────────────────────────────────────
+	// Subscribers read committed changes from the store; the hub only tells them
+	// when there is something new.
+
+	var (
+		hub       *stream.Hub
+		streamSvc stream.Service
+	)
+	if cfg.Streaming != nil {
+		hub = stream.NewHub()
+		streamSvc = stream.NewLog(stream.NewService(store, hub, cfg.Streaming, stream.NewMetrics(reg), logger), logger)
+		afterCommit = append(afterCommit, hub.Notify)
+	}
+	if len(afterCommit) > 0 {
+		processorOpts = append(processorOpts, engine.WithAfterCommit(func(offset int64) {
+			for _, fn := range afterCommit {
+				fn(offset)
+			}
+		}))
+	}
+	processor := engine.NewProcessor(fetcher, store, engineMetrics, logger, processorOpts...)
+
+	// Rewinds and rebuilds from the admin API pause the processor while they run.
+	reindexSvc := reindex.NewLog(reindex.NewService(store, processor, logger), logger)
+
+	// ── Drift verifier (optional) ─────────────────────────────────────────────
+	// Reads ledger holdings through a token client on the same ledger
+	// connection; repairs pause the processor like rewinds do.
+
+	var (
+		verifyWorker *verify.Worker
+		verifySvc    verify.Service
+	)
+	if cfg.Verifier != nil {
+		holdings, err := newHoldingReader(ctx, cfg.Verifier, ledgerClient, logger)
+		if err != nil {
+			return fmt.Errorf("create drift verifier token client: %w", err)
+		}
+		verifySvc = verify.NewLog(
+			verify.NewService(store, holdings, processor, cfg.Verifier, verify.NewMetrics(reg), logger), logger)
+		verifyWorker = verify.NewWorker(verifySvc, cfg.Verifier, logger)
+	}
 
 	// ── Service / Router (read path) ──────────────────────────────────────────
 
-	svc := indexerservice.NewService(store, logger)
-	router := s.newRouter(svc, httpMetrics, logger)
+	svc := indexerservice.NewService(store, cfg.Indexer.TokenMetadata, logger)
+	validator, revocations := newValidator(cfg.Auth, logger)
+	router := s.newRouter(svc, webhookSvc, streamSvc, reindexSvc, verifySvc, pkgs, validator, httpMetrics, logger)
 
 	// ── Run processor and HTTP servers under one errgroup ─────────────────────
 	// The write-path processor and the read-path HTTP server(s) all share gCtx:
@@ -146,6 +245,30 @@ func (s *Server) Run() error {
 		return processor.Run(gCtx)
 	})
 
+	if dispatcher != nil {
+		g.Go(func() error {
+			return dispatcher.Run(gCtx)
+		})
+	}
+
+	if hub != nil {
+		g.Go(func() error {
+			return hub.Run(gCtx)
+		})
+	}
+
+	if verifyWorker != nil {
+		g.Go(func() error {
+			return verifyWorker.Run(gCtx)
+		})
+	}
+
+	if revocations != nil {
+		g.Go(func() error {
+			return revocations.Run(gCtx)
+		})
+	}
+
 	s.registerServers(g, gCtx, router, logger)
 
 	return g.Wait()
@@ -178,54 +301,111 @@ func (s *Server) registerServers(g *errgroup.Group, gCtx context.Context, router
 	}
 }
 
-// indexerTemplateIDs builds the streaming template-ID list the fetcher subscribes
-// to. CIP-56 TokenTransferEvent is always included; Utility.Registry TransferOffer
-// and Holding are appended when their package IDs are configured.
-func indexerTemplateIDs(cfg *indexer.Config) []streaming.TemplateID {
-	ids := []streaming.TemplateID{{
-		PackageID:  cfg.CIP56PackageID,
-		ModuleName: "CIP56.Events",
-		EntityName: "TokenTransferEvent",
-	}}
-	if cfg.UtilityRegistryPackageID != "" {
-		ids = append(ids, streaming.TemplateID{
-			PackageID:  cfg.UtilityRegistryPackageID,
-			ModuleName: "Utility.Registry.App.V0.Model.Transfer",
-			EntityName: "TransferOffer",
-		})
+// newHoldingReader builds the token client the drift verifier reads ledger
+// holdings with, on the indexer's ledger connection. The verifier's domain and
+// issuer party are propagated to its identity and token configs, and an empty
+// user ID is resolved from the ledger auth token, as canton.New does.
+func newHoldingReader(ctx context.Context, cfg *verify.Config, l *ledger.Client, logger *zap.Logger) (*token.Client, error) {
+	idCfg, tokenCfg := *cfg.Identity, *cfg.Token
+	idCfg.DomainID, idCfg.IssuerParty = cfg.DomainID, cfg.IssuerParty
+	tokenCfg.DomainID, tokenCfg.IssuerParty = cfg.DomainID, cfg.IssuerParty
+	if tokenCfg.UserID == "" {
+		sub, err := l.JWTSubject(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("resolve ledger user: %w", err)
+		}
+		tokenCfg.UserID = sub
 	}
-	if cfg.UtilityRegistryHoldingPackageID != "" {
-		ids = append(ids, streaming.TemplateID{
-			PackageID:  cfg.UtilityRegistryHoldingPackageID,
-			ModuleName: "Utility.Registry.Holding.V0.Holding",
-			EntityName: "Holding",
-		})
+	idCfg.UserID = tokenCfg.UserID
+
+	id, err := identity.New(&idCfg, l, identity.WithLogger(logger))
+	if err != nil {
+		return nil, err
 	}
-	return ids
+	return token.New(&tokenCfg, l, id, token.WithLogger(logger))
 }
 
-// newRouter builds the chi router with standard middleware, a /health endpoint,
-// and the private admin routes under /indexer/v1/admin.
+// newValidator builds the JWT validator for cfg, or returns nil when auth is not
+// configured. When a revocation URL is configured it also returns the list the
+// validator consults, which the caller must Run.
+func newValidator(cfg *auth.ValidatorConfig, logger *zap.Logger) (jwt.TokenValidator, *jwt.RevocationList) {
+	if cfg == nil {
+		return nil, nil
+	}
+	if cfg.RevocationURL == "" {
+		logger.Warn("auth.revocation_url not set: tokens of revoked sessions are accepted until they expire")
+		return jwt.NewValidator(cfg.JWKSURL, cfg.Issuer), nil
+	}
+	revocations := jwt.NewRevocationList(cfg.RevocationURL, cfg.RevocationPollInterval, logger)
+	return jwt.NewValidator(cfg.JWKSURL, cfg.Issuer, jwt.WithRevocationChecker(revocations)), revocations
+}
+
+// newRouter builds the chi router with standard middleware, a /health endpoint
+// reporting the resolved Daml packages,
+// and the private admin routes under /indexer/v1/admin. The webhook admin routes
+// are added when webhookSvc is non-nil, the event stream routes when streamSvc
+// is non-nil, and the drift routes when verifySvc is non-nil. Streams, history
+// exports, rewinds, rebuilds and drift checks are long-lived, so they are
+// exempt from the request timeout.
 //
-// Currently the indexer exposes a single unauthenticated port intended for
-// internal/trusted callers (backend services, ops tooling). A public read API
-// with JWT authentication will be added in a future iteration on a separate
-// route group. Until then, restrict network access to this port at the
-// infrastructure level (firewall, private VPC, etc.).
-func (s *Server) newRouter(svc indexerservice.Service, metrics *apphttp.HTTPMetrics, logger *zap.Logger) http.Handler {
+// When validator is non-nil, every route but /health requires a JWT issued by the
+// api-server, validated against its JWKS: end-user tokens are scoped to their
+// own party (see indexerservice.RegisterPrivateRoutes) and the webhook,
+// re-index and drift admin APIs take service tokens only. Without auth the
+// routes are open; restrict network access to this port at the infrastructure
+// level (firewall, private VPC, etc.).
+func (s *Server) newRouter(
+	svc indexerservice.Service,
+	webhookSvc webhook.Service,
+	streamSvc stream.Service,
+	reindexSvc reindex.Service,
+	verifySvc verify.Service,
+	pkgs packages.Packages,
+	validator jwt.TokenValidator,
+	metrics *apphttp.HTTPMetrics,
+	logger *zap.Logger,
+) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(defaultMiddlewareTimeout))
 	r.Use(apphttp.RequestMetricsMiddleware(metrics))
 
-	r.Get(s.cfg.Monitoring.HealthCheckURL, func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
+	r.With(middleware.Timeout(defaultMiddlewareTimeout)).
+		Get(s.cfg.Monitoring.HealthCheckURL, apphttp.HealthHandler(pkgs))
+
+	r.Group(func(r chi.Router) {
+		if validator != nil {
+			r.Use(jwt.RequireAuth(validator, s.cfg.Auth.Audience))
+			logger.Info("indexer API requires JWT auth", zap.String("jwks_url", s.cfg.Auth.JWKSURL))
+		}
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Timeout(defaultMiddlewareTimeout))
+			indexerservice.RegisterPrivateRoutes(r, svc, logger)
+			if webhookSvc != nil {
+				r.Group(func(r chi.Router) {
+					if validator != nil {
+						r.Use(jwt.RequireService)
+					}
+					webhook.RegisterPrivateRoutes(r, webhookSvc, logger)
+				})
+			}
+		})
+		indexerservice.RegisterExportRoutes(r, svc, logger)
+		if streamSvc != nil {
+			stream.RegisterPrivateRoutes(r, streamSvc, s.cfg.Streaming, logger)
+		}
+		r.Group(func(r chi.Router) {
+			if validator != nil {
+				r.Use(jwt.RequireService)
+			}
+			reindex.RegisterPrivateRoutes(r, reindexSvc, logger)
+			if verifySvc != nil {
+				verify.RegisterPrivateRoutes(r, verifySvc, logger)
+			}
+		})
 	})
 
-	indexerservice.RegisterPrivateRoutes(r, svc, logger)
-
 	return r
 }
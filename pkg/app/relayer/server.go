@@ -15,6 +15,8 @@ import (
 	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
 	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
 	canton "github.com/chainsafe/canton-middleware/pkg/cantonsdk/client"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
+	"github.com/chainsafe/canton-middleware/pkg/commandstore"
 	"github.com/chainsafe/canton-middleware/pkg/config"
 	"github.com/chainsafe/canton-middleware/pkg/ethereum"
 	"github.com/chainsafe/canton-middleware/pkg/log"
@@ -78,7 +80,12 @@ func (s *Server) Run() error {
 	pgStore := relayerstore.NewStore(db)
 	store := relayerstore.NewInstrumentedStore(pgStore, storeMetrics)
 
-	cantonClient, err := canton.New(ctx, cfg.Canton, canton.WithLogger(logger))
+	// Tracked bridge commands are kept in the relayer database, so a deposit or
+	// withdrawal step submitted before a restart resolves before it is retried.
+	cantonClient, err := canton.New(ctx, cfg.Canton,
+		canton.WithLogger(logger),
+		canton.WithCommandStore(commandstore.NewPostgres(db)),
+	)
 	if err != nil {
 		return fmt.Errorf("initialize canton client: %w", err)
 	}
@@ -97,7 +104,7 @@ func (s *Server) Run() error {
 	}
 	defer engine.Stop()
 
-	router := s.newRouter(store, engine, httpMetrics, logger)
+	router := s.newRouter(store, engine, cantonClient.Packages, httpMetrics, logger)
 
 	g, gCtx := errgroup.WithContext(ctx)
 	s.registerServers(g, gCtx, router, logger)
@@ -127,7 +134,11 @@ func (s *Server) registerServers(g *errgroup.Group, gCtx context.Context, router
 }
 
 func (s *Server) newRouter(
-	store relayersvc.Store, engine *relayerengine.Engine, metrics *apphttp.HTTPMetrics, logger *zap.Logger,
+	store relayersvc.Store,
+	engine *relayerengine.Engine,
+	pkgs packages.Packages,
+	metrics *apphttp.HTTPMetrics,
+	logger *zap.Logger,
 ) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
@@ -136,10 +147,7 @@ func (s *Server) newRouter(
 	r.Use(middleware.Timeout(defaultHTTPMiddlewareTimeout))
 	r.Use(apphttp.RequestMetricsMiddleware(metrics))
 
-	r.Get(s.cfg.Monitoring.HealthCheckURL, func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
+	r.Get(s.cfg.Monitoring.HealthCheckURL, apphttp.HealthHandler(pkgs))
 
 	svc := relayersvc.NewLog(relayersvc.NewService(store), logger)
 	relayersvc.RegisterRoutes(r, svc, engine, logger)
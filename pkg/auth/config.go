@@ -5,9 +5,20 @@ package auth
 import "time"
 
 // Config configures Sign-In with Ethereum (EIP-4361) login and the JWTs it issues
-// for read endpoints. It is always required; read endpoints are gated by a bearer
-// token. The matching public key is published at /.well-known/jwks.json so other
+// for read endpoints. When it is set, read endpoints are gated by a bearer token.
+// The matching public key is published at /.well-known/jwks.json so other
 // services (e.g. the indexer) can validate tokens without a shared secret.
+//
+// Rotating the signing key:
+//  1. Generate a new key and deploy it as private_key under a new kid, moving the
+//     old kid and its public key into retired_keys. New tokens are signed with the
+//     new key; the JWKS keeps publishing the old one, so tokens it signed still
+//     validate (validators fetching the JWKS pick up the new kid on first sight).
+//  2. Once token_ttl has passed, every token signed by the old key has expired:
+//     remove it from retired_keys.
+//
+// Refresh tokens are opaque and stored in the database, so rotation does not
+// affect them.
 type Config struct {
 	// PrivateKey is the RSA signing key as a base64-encoded PEM (PKCS#1 or PKCS#8).
 	// Supply it via env substitution — private_key: "${JWT_PRIVATE_KEY}" — where the
@@ -20,10 +31,21 @@ type Config struct {
 	Issuer string `yaml:"issuer" validate:"required" default:"canton-middleware"`
 	// Audience is the JWT "aud" claim.
 	Audience string `yaml:"audience" validate:"required" default:"canton-middleware-api"`
+	// RetiredKeys are previous signing keys, published only so the tokens they
+	// signed keep validating until they expire.
+	RetiredKeys []RetiredKey `yaml:"retired_keys" validate:"dive"`
 	// TokenTTL is how long an issued JWT stays valid.
 	TokenTTL time.Duration `yaml:"token_ttl" validate:"required,gt=0" default:"30m"`
+	// RefreshTokenTTL is how long a login session lasts. Refresh tokens are rotated
+	// on every use, but refreshing never extends the session past this.
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" validate:"required,gt=0" default:"720h"`
 	// NonceTTL is how long a login nonce stays valid before it must be re-fetched.
 	NonceTTL time.Duration `yaml:"nonce_ttl" validate:"required,gt=0" default:"5m"`
+	// NonceStore selects where login nonces are kept: "memory" holds them in the
+	// process, which only works with a single api-server replica (or sticky
+	// sessions); "postgres" shares them through the api database so any replica
+	// can complete a login another replica started.
+	NonceStore string `yaml:"nonce_store" validate:"required,oneof=memory postgres" default:"memory"`
 	// Domain is the EIP-4361 domain the SIWE message must bind to (e.g. "app.example.com").
 	Domain string `yaml:"domain" validate:"required"`
 	// URI is the EIP-4361 uri the SIWE message must bind to (e.g. "https://app.example.com").
@@ -31,3 +53,37 @@ type Config struct {
 	// ChainID is the EIP-155 chain id the SIWE message must declare.
 	ChainID int `yaml:"chain_id" validate:"required,gt=0"`
 }
+
+// Nonce store backends for Config.NonceStore.
+const (
+	NonceStoreMemory   = "memory"
+	NonceStorePostgres = "postgres"
+)
+
+// RetiredKey is a former signing key that still validates tokens.
+type RetiredKey struct {
+	// KeyID is the kid the key signed under.
+	KeyID string `yaml:"kid" validate:"required"`
+	// PublicKey is the RSA public key as a base64-encoded PEM (PKIX or PKCS#1),
+	// e.g. `openssl rsa -in old.pem -pubout | base64 -w0`.
+	PublicKey string `yaml:"public_key" validate:"required"`
+}
+
+// ValidatorConfig configures a service that accepts the tokens issued under
+// Config without holding the signing key — e.g. the indexer. Tokens are checked
+// against the api-server's published JWKS.
+type ValidatorConfig struct {
+	// JWKSURL is the api-server's key set (e.g. "http://api-server:8081/.well-known/jwks.json").
+	JWKSURL string `yaml:"jwks_url" validate:"required,url"`
+	// Issuer must match Config.Issuer.
+	Issuer string `yaml:"issuer" validate:"required" default:"canton-middleware"`
+	// Audience must match Config.Audience.
+	Audience string `yaml:"audience" validate:"required" default:"canton-middleware-api"`
+	// RevocationURL is the api-server's revoked-sessions list (e.g.
+	// "http://api-server:8081/auth/revoked-sessions"). When set, tokens of revoked
+	// sessions are rejected within RevocationPollInterval of their revocation;
+	// when empty they stay valid until they expire.
+	RevocationURL string `yaml:"revocation_url" validate:"omitempty,url"`
+	// RevocationPollInterval is how often RevocationURL is fetched.
+	RevocationPollInterval time.Duration `yaml:"revocation_poll_interval" validate:"gt=0" default:"30s"`
+}
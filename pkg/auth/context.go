@@ -18,6 +18,9 @@ const (
 	ContextKeyFingerprint contextKey = "fingerprint"
 	// ContextKeyUserID is the context key for the user's database ID
 	ContextKeyUserID contextKey = "user_id"
+	// ContextKeyService is the context key marking a request authenticated with
+	// a service token rather than an end-user token
+	ContextKeyService contextKey = "service"
 )
 
 // WithEVMAddress adds the EVM address to the context
@@ -64,12 +67,25 @@ func UserIDFromContext(ctx context.Context) (int64, bool) {
 	return id, ok
 }
 
-// AuthInfo contains all authentication information for a request
+// WithService marks the context as authenticated by a service token
+func WithService(ctx context.Context, service bool) context.Context {
+	return context.WithValue(ctx, ContextKeyService, service)
+}
+
+// IsServiceFromContext reports whether the request carries a service token
+func IsServiceFromContext(ctx context.Context) bool {
+	service, _ := ctx.Value(ContextKeyService).(bool)
+	return service
+}
+
+// AuthInfo contains all authentication information for a request. Service
+// tokens (internal callers) carry no EVM address or Canton party.
 type AuthInfo struct {
 	EVMAddress  string
 	CantonParty string
 	Fingerprint string
 	UserID      int64
+	Service     bool
 }
 
 // WithAuthInfo adds all authentication info to the context
@@ -78,6 +94,7 @@ func WithAuthInfo(ctx context.Context, info *AuthInfo) context.Context {
 	ctx = WithCantonParty(ctx, info.CantonParty)
 	ctx = WithFingerprint(ctx, info.Fingerprint)
 	ctx = WithUserID(ctx, info.UserID)
+	ctx = WithService(ctx, info.Service)
 	return ctx
 }
 
@@ -88,5 +105,6 @@ func AuthInfoFromContext(ctx context.Context) *AuthInfo {
 	info.CantonParty, _ = CantonPartyFromContext(ctx)
 	info.Fingerprint, _ = FingerprintFromContext(ctx)
 	info.UserID, _ = UserIDFromContext(ctx)
+	info.Service = IsServiceFromContext(ctx)
 	return info
 }
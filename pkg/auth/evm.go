@@ -74,6 +74,26 @@ func NormalizeAddress(address string) string {
 	return common.HexToAddress(address).Hex()
 }
 
+// ParseActionMessage checks that msg has the form
+// "{action}:{address}:{field}...:{unix_seconds}" with exactly n fields, that its
+// address is the given (signer's) address and that its timestamp is within maxAge
+// of now, and returns the fields. Actions that change state put a server-issued
+// nonce among the fields and consume it, so a signature is good for one request
+// of one action only.
+func ParseActionMessage(msg, action, address string, n int, maxAge time.Duration) ([]string, error) {
+	parts := strings.Split(msg, ":")
+	if len(parts) != n+3 || parts[0] != action {
+		return nil, fmt.Errorf("message must have %d colon-separated fields starting with %q", n+3, action)
+	}
+	if !strings.EqualFold(parts[1], address) {
+		return nil, fmt.Errorf("message address %s is not the signer %s", parts[1], address)
+	}
+	if err := ValidateTimedMessage(msg, maxAge); err != nil {
+		return nil, err
+	}
+	return parts[2 : 2+n], nil
+}
+
 // ValidateTimedMessage checks that a message contains a Unix timestamp suffix
 // (format: "{prefix}:{unix_seconds}") and that it is within maxAge of now.
 // This provides replay protection: captured signatures expire after maxAge.
@@ -22,14 +22,28 @@ const (
 	// "sub" claim but is named explicitly so external verifiers (e.g. the indexer)
 	// can read the party without relying on "sub" semantics.
 	CantonPartyClaim = "canton_party_id"
+	// ScopeClaim distinguishes service tokens from end-user tokens.
+	ScopeClaim = "scope"
+	// SessionIDClaim carries the login session an end-user token belongs to, so
+	// validators can reject tokens of a revoked session before they expire.
+	SessionIDClaim = "sid"
 )
 
+// ServiceScope is the ScopeClaim value of a service token: one minted for an
+// internal caller (e.g. the api-server's indexer client) rather than a user.
+const ServiceScope = "service"
+
 // Claims are the claims minted at login. The Canton party id is both the subject
 // and an explicit canton_party_id claim; the EVM address rides alongside so handlers
 // can resolve either identity from the token.
+//
+// Service tokens instead carry Scope "service", the caller's name as subject,
+// and no EVM address, Canton party or session.
 type Claims struct {
-	EVMAddress    string `json:"evm_address"`
-	CantonPartyID string `json:"canton_party_id"`
+	EVMAddress    string `json:"evm_address,omitempty"`
+	CantonPartyID string `json:"canton_party_id,omitempty"`
+	SessionID     string `json:"sid,omitempty"`
+	Scope         string `json:"scope,omitempty"`
 	gojwt.RegisteredClaims
 }
 
@@ -37,15 +51,34 @@ type Claims struct {
 type Issuer struct {
 	key      *rsa.PrivateKey
 	kid      string
+	retired  []verificationKey
 	issuer   string
 	audience string
 	ttl      time.Duration
 	now      func() time.Time
 }
 
+// verificationKey is a public key that validates tokens but no longer signs them.
+type verificationKey struct {
+	kid string
+	pub *rsa.PublicKey
+}
+
+// IssuerOption configures an Issuer.
+type IssuerOption func(*Issuer)
+
+// WithRetiredKey publishes a previous signing key alongside the active one, so
+// tokens it signed keep validating until they expire. See auth.Config.RetiredKeys
+// for the rotation procedure.
+func WithRetiredKey(kid string, pub *rsa.PublicKey) IssuerOption {
+	return func(i *Issuer) {
+		i.retired = append(i.retired, verificationKey{kid: kid, pub: pub})
+	}
+}
+
 // NewIssuer creates an Issuer that signs with key, advertising it under kid.
-func NewIssuer(key *rsa.PrivateKey, kid, issuer, audience string, ttl time.Duration) *Issuer {
-	return &Issuer{
+func NewIssuer(key *rsa.PrivateKey, kid, issuer, audience string, ttl time.Duration, opts ...IssuerOption) *Issuer {
+	i := &Issuer{
 		key:      key,
 		kid:      kid,
 		issuer:   issuer,
@@ -53,25 +86,36 @@ func NewIssuer(key *rsa.PrivateKey, kid, issuer, audience string, ttl time.Durat
 		ttl:      ttl,
 		now:      time.Now,
 	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Issue mints a signed token for the (evmAddress, cantonPartyID) identity within
+// the given login session and returns it alongside its expiry time.
+func (i *Issuer) Issue(evmAddress, cantonPartyID, sessionID string) (string, time.Time, error) {
+	return i.sign(Claims{EVMAddress: evmAddress, CantonPartyID: cantonPartyID, SessionID: sessionID}, cantonPartyID)
+}
+
+// IssueService mints a service token for the named internal caller. Service
+// tokens are not bound to a user and are accepted wherever RequireAuth is.
+func (i *Issuer) IssueService(subject string) (string, time.Time, error) {
+	return i.sign(Claims{Scope: ServiceScope}, subject)
 }
 
-// Issue mints a signed token for the (evmAddress, cantonPartyID) identity and
-// returns it alongside its expiry time.
-func (i *Issuer) Issue(evmAddress, cantonPartyID string) (string, time.Time, error) {
+// sign fills in the registered claims and signs the token.
+func (i *Issuer) sign(claims Claims, subject string) (string, time.Time, error) {
 	now := i.now()
 	exp := now.Add(i.ttl)
 
-	claims := Claims{
-		EVMAddress:    evmAddress,
-		CantonPartyID: cantonPartyID,
-		RegisteredClaims: gojwt.RegisteredClaims{
-			Subject:   cantonPartyID,
-			Issuer:    i.issuer,
-			Audience:  gojwt.ClaimStrings{i.audience},
-			IssuedAt:  gojwt.NewNumericDate(now),
-			ExpiresAt: gojwt.NewNumericDate(exp),
-			ID:        siwe.GenerateNonce(),
-		},
+	claims.RegisteredClaims = gojwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    i.issuer,
+		Audience:  gojwt.ClaimStrings{i.audience},
+		IssuedAt:  gojwt.NewNumericDate(now),
+		ExpiresAt: gojwt.NewNumericDate(exp),
+		ID:        siwe.GenerateNonce(),
 	}
 
 	token := gojwt.NewWithClaims(gojwt.SigningMethodRS256, claims)
@@ -91,5 +135,25 @@ func (i *Issuer) PublicKey() *rsa.PublicKey { return &i.key.PublicKey }
 // KeyID returns the kid advertised for the signing key.
 func (i *Issuer) KeyID() string { return i.kid }
 
-// JWKS returns the public signing key as a JSON Web Key Set for publication.
-func (i *Issuer) JWKS() JWKS { return marshalJWKS(i.kid, &i.key.PublicKey) }
+// TTL returns how long the tokens it issues stay valid.
+func (i *Issuer) TTL() time.Duration { return i.ttl }
+
+// VerificationKeys returns every key that validates tokens from this issuer —
+// the signing key and any retired keys — by kid.
+func (i *Issuer) VerificationKeys() map[string]*rsa.PublicKey {
+	keys := map[string]*rsa.PublicKey{i.kid: &i.key.PublicKey}
+	for _, k := range i.retired {
+		keys[k.kid] = k.pub
+	}
+	return keys
+}
+
+// JWKS returns the verification keys as a JSON Web Key Set for publication, the
+// signing key first.
+func (i *Issuer) JWKS() JWKS {
+	set := marshalJWKS(i.kid, &i.key.PublicKey)
+	for _, k := range i.retired {
+		set.Keys = append(set.Keys, marshalJWK(k.kid, k.pub))
+	}
+	return set
+}
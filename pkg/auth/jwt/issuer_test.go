@@ -14,7 +14,7 @@ func TestIssuer_IssueSetsClaims(t *testing.T) {
 	issuer := NewIssuer(key, "kid-1", testIssuer, testAud, time.Hour)
 
 	before := time.Now()
-	token, exp, err := issuer.Issue("0xABC", "party::xyz")
+	token, exp, err := issuer.Issue("0xABC", "party::xyz", "session-1")
 	if err != nil {
 		t.Fatalf("issue: %v", err)
 	}
@@ -42,6 +42,9 @@ func TestIssuer_IssueSetsClaims(t *testing.T) {
 	if claims[EVMAddressClaim] != "0xABC" {
 		t.Fatalf("%s = %v, want 0xABC", EVMAddressClaim, claims[EVMAddressClaim])
 	}
+	if claims[SessionIDClaim] != "session-1" {
+		t.Fatalf("%s = %v, want session-1", SessionIDClaim, claims[SessionIDClaim])
+	}
 	if claims["iss"] != testIssuer {
 		t.Fatalf("iss = %v, want %s", claims["iss"], testIssuer)
 	}
@@ -80,3 +83,54 @@ func TestIssuer_JWKSDescribesSigningKey(t *testing.T) {
 		t.Fatal("published JWKS key does not match the signing key")
 	}
 }
+
+func TestIssuer_JWKSPublishesRetiredKeys(t *testing.T) {
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+	issuer := NewIssuer(newKey, "kid-2", testIssuer, testAud, time.Hour, WithRetiredKey("kid-1", &oldKey.PublicKey))
+
+	set := issuer.JWKS()
+	if len(set.Keys) != 2 || set.Keys[0].Kid != "kid-2" || set.Keys[1].Kid != "kid-1" {
+		t.Fatalf("JWKS keys = %+v, want kid-2 (signing) then kid-1 (retired)", set.Keys)
+	}
+
+	// New tokens are signed by the active key only.
+	token, _, err := issuer.Issue("0xABC", "party::xyz", "session-1")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	parsed, err := gojwt.Parse(token, func(*gojwt.Token) (any, error) { return &newKey.PublicKey, nil })
+	if err != nil || !parsed.Valid {
+		t.Fatalf("token not signed by the active key: %v", err)
+	}
+	if kid, _ := parsed.Header["kid"].(string); kid != "kid-2" {
+		t.Fatalf("kid header = %q, want kid-2", kid)
+	}
+}
+
+func TestIssuer_IssueServiceSetsScope(t *testing.T) {
+	key := newTestKey(t)
+	issuer := NewIssuer(key, "kid-1", testIssuer, testAud, time.Hour)
+
+	token, _, err := issuer.IssueService("api-server")
+	if err != nil {
+		t.Fatalf("issue service token: %v", err)
+	}
+	parsed, err := gojwt.Parse(token, func(*gojwt.Token) (any, error) { return &key.PublicKey, nil })
+	if err != nil || !parsed.Valid {
+		t.Fatalf("parse service token: valid=%v err=%v", parsed.Valid, err)
+	}
+
+	claims := parsed.Claims.(gojwt.MapClaims)
+	if claims[ScopeClaim] != ServiceScope {
+		t.Fatalf("%s = %v, want %s", ScopeClaim, claims[ScopeClaim], ServiceScope)
+	}
+	if claims["sub"] != "api-server" {
+		t.Fatalf("sub = %v, want api-server", claims["sub"])
+	}
+	if _, ok := claims[EVMAddressClaim]; ok {
+		t.Fatalf("service token must not carry %s", EVMAddressClaim)
+	}
+	if _, ok := claims[CantonPartyClaim]; ok {
+		t.Fatalf("service token must not carry %s", CantonPartyClaim)
+	}
+}
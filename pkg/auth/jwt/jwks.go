@@ -24,19 +24,24 @@ type JWKS struct {
 	Keys []JWK `json:"keys"`
 }
 
-// marshalJWKS renders an RSA public key as a single-key JWKS document. It is the
-// inverse of parseRSAPublicKey: modulus and exponent are base64url-encoded (no
-// padding) per RFC 7518.
+// marshalJWKS renders an RSA public key as a single-key JWKS document.
 func marshalJWKS(kid string, pub *rsa.PublicKey) JWKS {
+	return JWKS{Keys: []JWK{marshalJWK(kid, pub)}}
+}
+
+// marshalJWK renders an RSA public key as a JWK. It is the inverse of
+// parseRSAPublicKey: modulus and exponent are base64url-encoded (no padding) per
+// RFC 7518.
+func marshalJWK(kid string, pub *rsa.PublicKey) JWK {
 	eBytes := big.NewInt(int64(pub.E)).Bytes()
-	return JWKS{Keys: []JWK{{
+	return JWK{
 		Kty: "RSA",
 		Use: "sig",
 		Alg: "RS256",
 		Kid: kid,
 		N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
 		E:   base64.RawURLEncoding.EncodeToString(eBytes),
-	}}}
+	}
 }
 
 // parseRSAPublicKey reconstructs an RSA public key from base64url-encoded modulus
@@ -14,14 +14,9 @@ import (
 // ParseRSAPrivateKey decodes a PEM-encoded RSA private key in either PKCS#1
 // ("RSA PRIVATE KEY") or PKCS#8 ("PRIVATE KEY") form.
 func ParseRSAPrivateKey(pemString string) (*rsa.PrivateKey, error) {
-	pemBytes, err := base64.StdEncoding.DecodeString(pemString)
+	block, err := decodePEM(pemString)
 	if err != nil {
-		return nil, fmt.Errorf("JWT signing key is not valid base64: %w", err)
-	}
-
-	block, _ := pem.Decode(pemBytes)
-	if block == nil {
-		return nil, errors.New("no PEM block found in key")
+		return nil, err
 	}
 
 	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
@@ -38,3 +33,41 @@ func ParseRSAPrivateKey(pemString string) (*rsa.PrivateKey, error) {
 	}
 	return key, nil
 }
+
+// ParseRSAPublicKey decodes a PEM-encoded RSA public key in either PKCS#1
+// ("RSA PUBLIC KEY") or PKIX ("PUBLIC KEY") form. Like ParseRSAPrivateKey it
+// takes the PEM base64-encoded.
+func ParseRSAPublicKey(pemString string) (*rsa.PublicKey, error) {
+	block, err := decodePEM(pemString)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse RSA public key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("key is %T, want RSA", parsed)
+	}
+	return key, nil
+}
+
+// decodePEM base64-decodes pemString and returns its first PEM block.
+func decodePEM(pemString string) (*pem.Block, error) {
+	pemBytes, err := base64.StdEncoding.DecodeString(pemString)
+	if err != nil {
+		return nil, fmt.Errorf("JWT key is not valid base64: %w", err)
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM block found in key")
+	}
+	return block, nil
+}
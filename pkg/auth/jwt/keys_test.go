@@ -76,3 +76,39 @@ func TestParseRSAPrivateKey_NonRSA(t *testing.T) {
 		t.Fatal("expected error for a non-RSA key")
 	}
 }
+
+func TestParseRSAPublicKey_PKIXAndPKCS1(t *testing.T) {
+	key := newTestKey(t)
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal PKIX: %v", err)
+	}
+
+	for _, block := range []*pem.Block{
+		{Type: "PUBLIC KEY", Bytes: pkix},
+		{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)},
+	} {
+		got, err := ParseRSAPublicKey(b64PEM(block))
+		if err != nil {
+			t.Fatalf("parse %s: %v", block.Type, err)
+		}
+		if got.N.Cmp(key.N) != 0 || got.E != key.E {
+			t.Fatalf("%s: parsed key does not match original", block.Type)
+		}
+	}
+}
+
+func TestParseRSAPublicKey_NonRSA(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate EC key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal EC PKIX: %v", err)
+	}
+
+	if _, err := ParseRSAPublicKey(b64PEM(&pem.Block{Type: "PUBLIC KEY", Bytes: der})); err == nil {
+		t.Fatal("expected error for a non-RSA key")
+	}
+}
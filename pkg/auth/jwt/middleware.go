@@ -3,6 +3,7 @@
 package jwt
 
 import (
+	"context"
 	"net/http"
 	"slices"
 	"strings"
@@ -17,12 +18,14 @@ import (
 // TokenValidator verifies a bearer token and returns its claims. Satisfied by
 // Validator.
 type TokenValidator interface {
-	ValidateToken(token string) (gojwt.MapClaims, error)
+	ValidateToken(ctx context.Context, token string) (gojwt.MapClaims, error)
 }
 
 // RequireAuth returns middleware that rejects requests without a valid bearer token
 // for the expected audience and populates the request context with the
 // authenticated identity (EVM address + Canton party) from the token claims.
+// Service tokens are accepted too; they populate only the service marker, so
+// handlers that scope results to a user must check for it.
 func RequireAuth(validator TokenValidator, audience string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -46,7 +49,7 @@ func authenticate(validator TokenValidator, audience string, r *http.Request) (*
 	}
 
 	token := strings.TrimSpace(header[len(prefix):])
-	claims, err := validator.ValidateToken(token)
+	claims, err := validator.ValidateToken(r.Context(), token)
 	if err != nil {
 		return nil, apperrors.UnAuthorizedError(err, "invalid or expired token")
 	}
@@ -55,6 +58,13 @@ func authenticate(validator TokenValidator, audience string, r *http.Request) (*
 		return nil, apperrors.UnAuthorizedError(nil, "token audience mismatch")
 	}
 
+	if scope, _ := claims[ScopeClaim].(string); scope == ServiceScope {
+		if sub, _ := claims["sub"].(string); sub == "" {
+			return nil, apperrors.UnAuthorizedError(nil, "token missing identity claims")
+		}
+		return &auth.AuthInfo{Service: true}, nil
+	}
+
 	evmAddress, _ := claims[EVMAddressClaim].(string)
 	party, _ := claims["sub"].(string)
 	if evmAddress == "" || party == "" {
@@ -64,6 +74,18 @@ func authenticate(validator TokenValidator, audience string, r *http.Request) (*
 	return &auth.AuthInfo{EVMAddress: evmAddress, CantonParty: party}, nil
 }
 
+// RequireService returns 403 unless the request was authenticated with a service
+// token. It must run after RequireAuth.
+func RequireService(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !auth.IsServiceFromContext(r.Context()) {
+			apphttp.DefaultErrorHandler(w, apperrors.ForbiddenError(nil, "service token required"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // hasAudience reports whether the token's aud claim contains want. The claim may be
 // a single string or an array of strings per RFC 7519.
 func hasAudience(claims gojwt.MapClaims, want string) bool {
@@ -29,7 +29,7 @@ func TestRequireAuth_RejectsMissingAndAcceptsValid(t *testing.T) {
 	}
 
 	// Valid token -> 200, identity populated.
-	token, _, _ := issuer.Issue("0xdead", "party::dead")
+	token, _, _ := issuer.Issue("0xdead", "party::dead", "session-1")
 	rec = httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/x", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -50,7 +50,7 @@ func TestRequireAuth_WrongAudienceRejected(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
-	token, _, _ := issuer.Issue("0xdead", "party::dead")
+	token, _, _ := issuer.Issue("0xdead", "party::dead", "session-1")
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/x", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -60,3 +60,40 @@ func TestRequireAuth_WrongAudienceRejected(t *testing.T) {
 		t.Fatalf("audience mismatch status = %d, want 401", rec.Code)
 	}
 }
+
+func TestRequireAuth_ServiceToken(t *testing.T) {
+	issuer := NewIssuer(newTestKey(t), "kid-1", testIssuer, testAud, time.Hour)
+	validator := NewValidatorWithKey(issuer.KeyID(), issuer.PublicKey(), testIssuer)
+
+	var seen *auth.AuthInfo
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = auth.AuthInfoFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	protected := RequireAuth(validator, testAud)(ok)
+	serviceOnly := RequireAuth(validator, testAud)(RequireService(ok))
+
+	serviceToken, _, _ := issuer.IssueService("api-server")
+	userToken, _, _ := issuer.Issue("0xdead", "party::dead", "session-1")
+
+	serve := func(h http.Handler, token string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve(protected, serviceToken); code != http.StatusOK {
+		t.Fatalf("service token status = %d, want 200", code)
+	}
+	if !seen.Service || seen.EVMAddress != "" || seen.CantonParty != "" {
+		t.Fatalf("service token identity = %+v, want service only", seen)
+	}
+	if code := serve(serviceOnly, serviceToken); code != http.StatusOK {
+		t.Fatalf("RequireService with service token status = %d, want 200", code)
+	}
+	if code := serve(serviceOnly, userToken); code != http.StatusForbidden {
+		t.Fatalf("RequireService with user token status = %d, want 403", code)
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+)
+
+// errRevocationsNotLoaded is returned until the first successful fetch, so
+// tokens are refused rather than accepted unchecked.
+var errRevocationsNotLoaded = errors.New("session revocation list not loaded yet")
+
+// RevocationList is a RevocationChecker for services that validate tokens
+// without access to the api-server's database, e.g. the indexer. It polls the
+// api-server's revoked-sessions endpoint and answers from the last list fetched,
+// so a revocation takes effect here within one poll interval.
+type RevocationList struct {
+	url      string
+	interval time.Duration
+	client   *http.Client
+	logger   *zap.Logger
+
+	mu      sync.RWMutex
+	revoked map[string]struct{}
+	loaded  bool
+}
+
+// NewRevocationList creates a RevocationList fetching url every interval. It
+// holds nothing until Run has completed a fetch.
+func NewRevocationList(url string, interval time.Duration, logger *zap.Logger) *RevocationList {
+	return &RevocationList{
+		url:      url,
+		interval: interval,
+		client:   &http.Client{Timeout: jwksFetchTimeout},
+		logger:   logger,
+	}
+}
+
+// Run fetches the list immediately and then every interval until ctx is done.
+// Fetch failures are logged and the previous list is kept.
+func (l *RevocationList) Run(ctx context.Context) error {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := l.refresh(ctx); err != nil && ctx.Err() == nil {
+			l.logger.Warn("failed to refresh session revocation list", zap.String("url", l.url), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsSessionRevoked implements RevocationChecker.
+func (l *RevocationList) IsSessionRevoked(_ context.Context, sessionID string) (bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if !l.loaded {
+		return false, errRevocationsNotLoaded
+	}
+	_, revoked := l.revoked[sessionID]
+	return revoked, nil
+}
+
+func (l *RevocationList) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body auth.RevokedSessionsResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	revoked := make(map[string]struct{}, len(body.SessionIDs))
+	for _, id := range body.SessionIDs {
+		revoked[id] = struct{}{}
+	}
+
+	l.mu.Lock()
+	l.revoked = revoked
+	l.loaded = true
+	l.mu.Unlock()
+	return nil
+}
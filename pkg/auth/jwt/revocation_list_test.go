@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+)
+
+func TestRevocationList_RefusesUntilLoaded(t *testing.T) {
+	l := NewRevocationList("http://unused", time.Hour, zap.NewNop())
+	if _, err := l.IsSessionRevoked(context.Background(), "sid-1"); err == nil {
+		t.Fatal("expected an error before the first fetch")
+	}
+}
+
+func TestRevocationList_PollsEndpoint(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		ids := []string{"sid-1"}
+		if calls.Add(1) > 1 {
+			ids = append(ids, "sid-2")
+		}
+		_ = json.NewEncoder(w).Encode(auth.RevokedSessionsResponse{SessionIDs: ids})
+	}))
+	defer srv.Close()
+
+	l := NewRevocationList(srv.URL, 10*time.Millisecond, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- l.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for calls.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("revocation list was not polled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run returned %v", err)
+	}
+
+	for sid, want := range map[string]bool{"sid-1": true, "sid-2": true, "sid-3": false} {
+		got, err := l.IsSessionRevoked(context.Background(), sid)
+		if err != nil {
+			t.Fatalf("IsSessionRevoked(%s): %v", sid, err)
+		}
+		if got != want {
+			t.Fatalf("IsSessionRevoked(%s) = %v, want %v", sid, got, want)
+		}
+	}
+}
+
+func TestRevocationList_KeepsListOnFailure(t *testing.T) {
+	var fail atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(auth.RevokedSessionsResponse{SessionIDs: []string{"sid-1"}})
+	}))
+	defer srv.Close()
+
+	l := NewRevocationList(srv.URL, time.Hour, zap.NewNop())
+	if err := l.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	fail.Store(true)
+	if err := l.refresh(context.Background()); err == nil {
+		t.Fatal("expected refresh to fail")
+	}
+
+	revoked, err := l.IsSessionRevoked(context.Background(), "sid-1")
+	if err != nil || !revoked {
+		t.Fatalf("IsSessionRevoked = %v, %v; want previous list kept", revoked, err)
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// serviceTokenRefreshDivisor sets how early a service token is replaced: once
+// only 1/serviceTokenRefreshDivisor of its lifetime is left.
+const serviceTokenRefreshDivisor = 4
+
+// ServiceTokenSource hands out a service token for one internal caller, minting
+// a fresh one once three quarters of the current token's lifetime has passed so
+// requests in flight never carry a token about to expire.
+type ServiceTokenSource struct {
+	issuer  *Issuer
+	subject string
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+// NewServiceTokenSource creates a source of service tokens for subject.
+func NewServiceTokenSource(issuer *Issuer, subject string) *ServiceTokenSource {
+	return &ServiceTokenSource{issuer: issuer, subject: subject}
+}
+
+// Token returns a valid service token.
+func (s *ServiceTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.issuer.now()
+	if s.token != "" && now.Before(s.refreshAt) {
+		return s.token, nil
+	}
+	token, exp, err := s.issuer.IssueService(s.subject)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	lifetime := exp.Sub(now)
+	s.refreshAt = now.Add(lifetime - lifetime/serviceTokenRefreshDivisor)
+	return token, nil
+}
+
+// Transport returns an http.RoundTripper that sets a service bearer token on
+// every request before passing it to base (http.DefaultTransport when nil).
+func (s *ServiceTokenSource) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &serviceTransport{source: s, base: base}
+}
+
+type serviceTransport struct {
+	source *ServiceTokenSource
+	base   http.RoundTripper
+}
+
+func (t *serviceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("service token: %w", err)
+	}
+	// RoundTrippers must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
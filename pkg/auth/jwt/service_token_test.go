@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceTokenSource_CachesUntilRefreshPoint(t *testing.T) {
+	issuer := NewIssuer(newTestKey(t), "kid-1", testIssuer, testAud, time.Hour)
+	now := time.Unix(1_700_000_000, 0)
+	issuer.now = func() time.Time { return now }
+	src := NewServiceTokenSource(issuer, "api-server")
+
+	first, err := src.Token()
+	if err != nil {
+		t.Fatalf("token: %v", err)
+	}
+
+	now = now.Add(40 * time.Minute)
+	if again, _ := src.Token(); again != first {
+		t.Fatal("token re-minted before three quarters of its lifetime")
+	}
+
+	now = now.Add(6 * time.Minute) // 46m > 45m
+	refreshed, _ := src.Token()
+	if refreshed == first {
+		t.Fatal("token not re-minted after three quarters of its lifetime")
+	}
+}
+
+func TestServiceTokenSource_TransportSetsBearer(t *testing.T) {
+	issuer := NewIssuer(newTestKey(t), "kid-1", testIssuer, testAud, time.Hour)
+	validator := NewValidatorWithKey(issuer.KeyID(), issuer.PublicKey(), testIssuer)
+	src := NewServiceTokenSource(issuer, "api-server")
+
+	srv := httptest.NewServer(RequireAuth(validator, testAud)(RequireService(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	)))
+	defer srv.Close()
+
+	client := &http.Client{Transport: src.Transport(nil)}
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", resp.StatusCode)
+	}
+	if got := req.Header.Get("Authorization"); strings.HasPrefix(got, "Bearer") {
+		t.Fatal("transport must not modify the caller's request")
+	}
+}
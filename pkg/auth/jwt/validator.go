@@ -16,9 +16,9 @@ import (
 
 const jwksFetchTimeout = 10 * time.Second
 
-// Validator verifies RS256 JWTs. Keys come either from a single in-process public
-// key (NewValidatorWithKey) or from a remote JWKS endpoint fetched on demand and
-// cached by key id (NewValidator).
+// Validator verifies RS256 JWTs. Keys come either from in-process public keys
+// (NewValidatorWithKey, NewValidatorWithKeys) or from a remote JWKS endpoint
+// fetched on demand and cached by key id (NewValidator).
 type Validator struct {
 	jwksURL   string
 	issuer    string
@@ -26,34 +26,68 @@ type Validator struct {
 	keysMu    sync.RWMutex
 	refreshMu sync.Mutex // serializes JWKS refreshes so a burst of misses triggers one fetch
 	client    *http.Client
+	revoked   RevocationChecker
+}
+
+// RevocationChecker reports whether a login session has been revoked.
+type RevocationChecker interface {
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// ValidatorOption configures a Validator.
+type ValidatorOption func(*Validator)
+
+// WithRevocationChecker makes the Validator reject tokens whose session (the
+// "sid" claim) has been revoked. Tokens without a session — service tokens —
+// are not checked.
+func WithRevocationChecker(c RevocationChecker) ValidatorOption {
+	return func(v *Validator) { v.revoked = c }
 }
 
 // NewValidator creates a Validator that fetches signing keys from a JWKS endpoint.
 // If the issuer is non-empty, tokens must carry a matching "iss" claim.
-func NewValidator(jwksURL, issuer string) *Validator {
-	return &Validator{
+func NewValidator(jwksURL, issuer string, opts ...ValidatorOption) *Validator {
+	v := &Validator{
 		jwksURL: jwksURL,
 		issuer:  issuer,
 		keys:    make(map[string]any),
 		client:  &http.Client{Timeout: jwksFetchTimeout},
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // NewValidatorWithKey creates a Validator that trusts a single in-process RSA
 // public key, keyed by kid, and performs no network fetch. The token issuer's own
 // process uses this to validate the tokens it mints; external services validate the
 // same tokens via the published JWKS URL using NewValidator.
-func NewValidatorWithKey(kid string, pub *rsa.PublicKey, issuer string) *Validator {
-	return &Validator{
+func NewValidatorWithKey(kid string, pub *rsa.PublicKey, issuer string, opts ...ValidatorOption) *Validator {
+	return NewValidatorWithKeys(map[string]*rsa.PublicKey{kid: pub}, issuer, opts...)
+}
+
+// NewValidatorWithKeys is NewValidatorWithKey for several keys, e.g. an issuer's
+// VerificationKeys during a key rotation.
+func NewValidatorWithKeys(keys map[string]*rsa.PublicKey, issuer string, opts ...ValidatorOption) *Validator {
+	v := &Validator{
 		issuer: issuer,
-		keys:   map[string]any{kid: pub},
+		keys:   make(map[string]any, len(keys)),
 	}
+	for kid, pub := range keys {
+		v.keys[kid] = pub
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // ValidateToken parses and verifies a token, returning its claims. It enforces the
-// RS256 signing method, a known key id, standard time claims, and (when configured)
-// the expected issuer.
-func (v *Validator) ValidateToken(tokenString string) (gojwt.MapClaims, error) {
+// RS256 signing method, a known key id, standard time claims, (when configured)
+// the expected issuer, and (with a RevocationChecker) that the token's session is
+// still live.
+func (v *Validator) ValidateToken(ctx context.Context, tokenString string) (gojwt.MapClaims, error) {
 	token, err := gojwt.Parse(tokenString, func(token *gojwt.Token) (any, error) {
 		if _, ok := token.Method.(*gojwt.SigningMethodRSA); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -83,6 +117,16 @@ func (v *Validator) ValidateToken(tokenString string) (gojwt.MapClaims, error) {
 		}
 	}
 
+	if sid, _ := claims[SessionIDClaim].(string); sid != "" && v.revoked != nil {
+		revoked, err := v.revoked.IsSessionRevoked(ctx, sid)
+		if err != nil {
+			return nil, fmt.Errorf("check session revocation: %w", err)
+		}
+		if revoked {
+			return nil, fmt.Errorf("session revoked")
+		}
+	}
+
 	return claims, nil
 }
 
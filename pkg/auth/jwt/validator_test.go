@@ -3,7 +3,9 @@
 package jwt
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,7 +26,7 @@ func TestValidator_InProcessKey_Accepts(t *testing.T) {
 	key := newTestKey(t)
 	token := mintRS256(t, key, "kid-1", validClaims())
 
-	claims, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(token)
+	claims, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(context.Background(), token)
 	if err != nil {
 		t.Fatalf("validate: %v", err)
 	}
@@ -43,7 +45,7 @@ func TestValidator_RejectsWrongAlgorithm(t *testing.T) {
 		t.Fatalf("sign HS256: %v", err)
 	}
 
-	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(signed); err == nil {
+	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(context.Background(), signed); err == nil {
 		t.Fatal("HS256 token must be rejected")
 	}
 }
@@ -52,7 +54,7 @@ func TestValidator_RejectsUnknownKid(t *testing.T) {
 	key := newTestKey(t)
 	token := mintRS256(t, key, "other-kid", validClaims())
 
-	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(token); err == nil {
+	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(context.Background(), token); err == nil {
 		t.Fatal("token with unknown kid must be rejected")
 	}
 }
@@ -63,7 +65,7 @@ func TestValidator_RejectsWrongIssuer(t *testing.T) {
 	claims["iss"] = "someone-else"
 	token := mintRS256(t, key, "kid-1", claims)
 
-	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(token); err == nil {
+	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(context.Background(), token); err == nil {
 		t.Fatal("token with wrong issuer must be rejected")
 	}
 }
@@ -74,7 +76,7 @@ func TestValidator_RejectsExpiredToken(t *testing.T) {
 	claims["exp"] = time.Now().Add(-time.Hour).Unix()
 	token := mintRS256(t, key, "kid-1", claims)
 
-	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(token); err == nil {
+	if _, err := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer).ValidateToken(context.Background(), token); err == nil {
 		t.Fatal("expired token must be rejected")
 	}
 }
@@ -90,7 +92,7 @@ func TestValidator_FetchesKeysFromJWKS(t *testing.T) {
 
 	token := mintRS256(t, key, "kid-remote", validClaims())
 
-	claims, err := NewValidator(srv.URL, testIssuer).ValidateToken(token)
+	claims, err := NewValidator(srv.URL, testIssuer).ValidateToken(context.Background(), token)
 	if err != nil {
 		t.Fatalf("validate via JWKS: %v", err)
 	}
@@ -111,3 +113,70 @@ func TestValidator_IsConfigured(t *testing.T) {
 		t.Fatal("empty validator should report not configured")
 	}
 }
+
+// fakeRevocations is a RevocationChecker over a fixed set of revoked sessions.
+type fakeRevocations struct {
+	revoked map[string]bool
+	err     error
+	checked []string
+}
+
+func (f *fakeRevocations) IsSessionRevoked(_ context.Context, sessionID string) (bool, error) {
+	f.checked = append(f.checked, sessionID)
+	return f.revoked[sessionID], f.err
+}
+
+func TestValidator_RejectsRevokedSession(t *testing.T) {
+	key := newTestKey(t)
+	revocations := &fakeRevocations{revoked: map[string]bool{"sid-revoked": true}}
+	v := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer, WithRevocationChecker(revocations))
+
+	live := validClaims()
+	live[SessionIDClaim] = "sid-live"
+	if _, err := v.ValidateToken(context.Background(), mintRS256(t, key, "kid-1", live)); err != nil {
+		t.Fatalf("live session rejected: %v", err)
+	}
+
+	revoked := validClaims()
+	revoked[SessionIDClaim] = "sid-revoked"
+	if _, err := v.ValidateToken(context.Background(), mintRS256(t, key, "kid-1", revoked)); err == nil {
+		t.Fatal("expected revoked session to be rejected")
+	}
+
+	// Tokens without a session (service tokens) are not checked.
+	if _, err := v.ValidateToken(context.Background(), mintRS256(t, key, "kid-1", validClaims())); err != nil {
+		t.Fatalf("sessionless token rejected: %v", err)
+	}
+	if len(revocations.checked) != 2 {
+		t.Fatalf("revocation checks = %v, want one per session token", revocations.checked)
+	}
+}
+
+func TestValidator_RevocationCheckFailureRejects(t *testing.T) {
+	key := newTestKey(t)
+	v := NewValidatorWithKey("kid-1", &key.PublicKey, testIssuer,
+		WithRevocationChecker(&fakeRevocations{err: errors.New("db down")}))
+
+	claims := validClaims()
+	claims[SessionIDClaim] = "sid-1"
+	if _, err := v.ValidateToken(context.Background(), mintRS256(t, key, "kid-1", claims)); err == nil {
+		t.Fatal("expected token to be rejected when revocation cannot be checked")
+	}
+}
+
+func TestValidator_AcceptsRetiredKeyDuringRotation(t *testing.T) {
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+	issuer := NewIssuer(newKey, "kid-2", testIssuer, testAud, time.Hour, WithRetiredKey("kid-1", &oldKey.PublicKey))
+	v := NewValidatorWithKeys(issuer.VerificationKeys(), testIssuer)
+
+	if _, err := v.ValidateToken(context.Background(), mintRS256(t, oldKey, "kid-1", validClaims())); err != nil {
+		t.Fatalf("token signed by retired key rejected: %v", err)
+	}
+	token, _, err := issuer.Issue("0xabc", "party::xyz", "sid-1")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if _, err = v.ValidateToken(context.Background(), token); err != nil {
+		t.Fatalf("token signed by new key rejected: %v", err)
+	}
+}
@@ -3,6 +3,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -20,21 +21,36 @@ const maxRequestBodyBytes = 1 << 20 // 1MB
 
 type httpHandler struct {
 	svc    Service
+	issue  func(ctx context.Context, address string) (string, error)
 	logger *zap.Logger
 }
 
-// RegisterRoutes mounts the login and JWKS endpoints on r.
-func RegisterRoutes(r chi.Router, svc Service, logger *zap.Logger) {
-	h := &httpHandler{svc: svc, logger: logger}
+// RegisterRoutes mounts the login, session and JWKS endpoints on r. requireAuth
+// guards /auth/logout-all, which acts on the caller's own party.
+func RegisterRoutes(r chi.Router, svc Service, requireAuth func(http.Handler) http.Handler, logger *zap.Logger) {
+	h := &httpHandler{svc: svc, issue: svc.Nonce, logger: logger}
 
 	r.Get("/auth/nonce", apphttp.HandleError(h.nonce))
 	r.Post("/auth/login", apphttp.HandleError(h.login))
+	r.Post("/auth/refresh", apphttp.HandleError(h.refresh))
+	r.Post("/auth/logout", apphttp.HandleError(h.logout))
+	r.With(requireAuth).Post("/auth/logout-all", apphttp.HandleError(h.logoutAll))
+	r.Get("/auth/revoked-sessions", apphttp.HandleError(h.revokedSessions))
 	r.Get("/.well-known/jwks.json", apphttp.HandleError(h.jwks))
 
 	logger.Info("SIWE login enabled", zap.String("path", "/auth/login"))
 }
 
-// nonce issues a login nonce for the caller's address (GET /auth/nonce?address=).
+// RegisterNonceRoute mounts GET /auth/nonce alone, serving nonces from nonces.
+// It is used when SIWE login is disabled: signed actions such as the
+// self-custody export still need a single-use nonce to sign.
+func RegisterNonceRoute(r chi.Router, nonces NonceStore, logger *zap.Logger) {
+	h := &httpHandler{issue: nonces.Issue, logger: logger}
+	r.Get("/auth/nonce", apphttp.HandleError(h.nonce))
+}
+
+// nonce issues a nonce for the caller's address (GET /auth/nonce?address=), used
+// by SIWE login and by signed actions such as the self-custody export.
 // The nonce is keyed by address so repeat requests reuse the same live value, which
 // stops an unauthenticated caller from churning the store; the address is the one
 // the client will put in its SIWE message and is not a secret.
@@ -44,7 +60,7 @@ func (h *httpHandler) nonce(w http.ResponseWriter, r *http.Request) error {
 		return apperrors.BadRequestError(nil, "address query parameter must be a 0x-prefixed 40-hex-char EVM address")
 	}
 
-	nonce, err := h.svc.Nonce(auth.NormalizeAddress(address))
+	nonce, err := h.issue(r.Context(), auth.NormalizeAddress(address))
 	if err != nil {
 		h.logger.Warn("nonce issuance rejected", zap.Error(err))
 		return apperrors.GeneralError(err)
@@ -72,11 +88,81 @@ func (h *httpHandler) login(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func (h *httpHandler) refresh(w http.ResponseWriter, r *http.Request) error {
+	token, err := readRefreshToken(r)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.svc.Refresh(r.Context(), token)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, res)
+	return nil
+}
+
+// logout ends the session of the presented refresh token. Holding the refresh
+// token is proof enough, so no access token is required.
+func (h *httpHandler) logout(w http.ResponseWriter, r *http.Request) error {
+	token, err := readRefreshToken(r)
+	if err != nil {
+		return err
+	}
+	if err = h.svc.Logout(r.Context(), token); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// logoutAll ends every session of the authenticated user, e.g. after a device
+// is lost.
+func (h *httpHandler) logoutAll(w http.ResponseWriter, r *http.Request) error {
+	party, _ := auth.CantonPartyFromContext(r.Context())
+	if party == "" {
+		return apperrors.ForbiddenError(nil, "a user token is required")
+	}
+	if err := h.svc.LogoutAll(r.Context(), party); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// revokedSessions publishes recently revoked session ids so services validating
+// tokens via the JWKS (e.g. the indexer) can honour revocation too. Session ids
+// are opaque and only ever listed once revoked, so the list is public like the
+// JWKS.
+func (h *httpHandler) revokedSessions(w http.ResponseWriter, r *http.Request) error {
+	ids, err := h.svc.RevokedSessions(r.Context())
+	if err != nil {
+		return err
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	writeJSON(w, http.StatusOK, &auth.RevokedSessionsResponse{SessionIDs: ids})
+	return nil
+}
+
 func (h *httpHandler) jwks(w http.ResponseWriter, _ *http.Request) error {
 	writeJSON(w, http.StatusOK, h.svc.JWKS())
 	return nil
 }
 
+func readRefreshToken(r *http.Request) (string, error) {
+	var req auth.RefreshTokenRequest
+	if err := readJSON(r, &req); err != nil {
+		return "", err
+	}
+	if req.RefreshToken == "" {
+		return "", apperrors.BadRequestError(nil, "refresh_token is required")
+	}
+	return req.RefreshToken, nil
+}
+
 func readJSON(r *http.Request, dst any) error {
 	dec := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodyBytes))
 	dec.DisallowUnknownFields()
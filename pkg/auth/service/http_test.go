@@ -19,15 +19,30 @@ import (
 	"github.com/chainsafe/canton-middleware/pkg/auth/service/mocks"
 )
 
+// testPartyHeader stands in for a bearer token: fakeRequireAuth authenticates
+// the request as the party it names.
+const testPartyHeader = "X-Test-Party"
+
+func fakeRequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		party := r.Header.Get(testPartyHeader)
+		if party == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.WithCantonParty(r.Context(), party)))
+	})
+}
+
 func newLoginTestServer(svc Service) http.Handler {
 	r := chi.NewRouter()
-	RegisterRoutes(r, svc, zap.NewNop())
+	RegisterRoutes(r, svc, fakeRequireAuth, zap.NewNop())
 	return r
 }
 
 func TestNonceHTTP_ReturnsNonce(t *testing.T) {
 	svc := mocks.NewService(t)
-	svc.EXPECT().Nonce(mock.Anything).Return("the-nonce", nil)
+	svc.EXPECT().Nonce(mock.Anything, mock.Anything).Return("the-nonce", nil)
 
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/auth/nonce?address=0x0000000000000000000000000000000000000001", nil)
@@ -131,3 +146,87 @@ func TestJWKSHTTP_ServesKeySet(t *testing.T) {
 		t.Fatalf("jwks = %+v, want one key kid-1", got)
 	}
 }
+
+func TestRefreshHTTP_Success(t *testing.T) {
+	svc := mocks.NewService(t)
+	svc.EXPECT().Refresh(mock.Anything, "refresh-1").
+		Return(&auth.LoginResponse{Token: "tok", RefreshToken: "refresh-2"}, nil)
+
+	rec := httptest.NewRecorder()
+	newLoginTestServer(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/refresh",
+		strings.NewReader(`{"refresh_token":"refresh-1"}`)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body = %s", rec.Code, rec.Body.String())
+	}
+	var got auth.LoginResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.RefreshToken != "refresh-2" {
+		t.Fatalf("refresh_token = %q, want refresh-2", got.RefreshToken)
+	}
+}
+
+func TestRefreshHTTP_MissingToken_Returns400(t *testing.T) {
+	svc := mocks.NewService(t) // Refresh must not be called
+	rec := httptest.NewRecorder()
+	newLoginTestServer(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{}`)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestLogoutHTTP_Returns204(t *testing.T) {
+	svc := mocks.NewService(t)
+	svc.EXPECT().Logout(mock.Anything, "refresh-1").Return(nil)
+
+	rec := httptest.NewRecorder()
+	newLoginTestServer(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/logout",
+		strings.NewReader(`{"refresh_token":"refresh-1"}`)))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", rec.Code)
+	}
+}
+
+func TestLogoutAllHTTP_RevokesCallersParty(t *testing.T) {
+	svc := mocks.NewService(t)
+	svc.EXPECT().LogoutAll(mock.Anything, "party::abc").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout-all", nil)
+	req.Header.Set(testPartyHeader, "party::abc")
+	rec := httptest.NewRecorder()
+	newLoginTestServer(svc).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", rec.Code)
+	}
+}
+
+func TestLogoutAllHTTP_RequiresAuth(t *testing.T) {
+	svc := mocks.NewService(t) // LogoutAll must not be called
+	rec := httptest.NewRecorder()
+	newLoginTestServer(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/logout-all", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+}
+
+func TestRevokedSessionsHTTP_ListsIDs(t *testing.T) {
+	svc := mocks.NewService(t)
+	svc.EXPECT().RevokedSessions(mock.Anything).Return(nil, nil)
+
+	rec := httptest.NewRecorder()
+	newLoginTestServer(svc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/revoked-sessions", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	// An empty list is rendered as [], not null, so clients can range over it.
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"session_ids":[]}` {
+		t.Fatalf("body = %s", got)
+	}
+}
@@ -14,10 +14,10 @@ import (
 
 const loginServiceName = "LoginService"
 
-// logService wraps Service with logging of the login call. Nonce and JWKS are
-// trivial accessors and pass through unlogged; Login is the meaningful operation,
-// so its entry/exit, duration, and errors are recorded (never the message or
-// signature, which are sensitive).
+// logService wraps Service with logging of the login and session calls. Nonce,
+// JWKS and RevokedSessions are trivial accessors and pass through unlogged; for
+// the rest the outcome, duration, and errors are recorded (never the message,
+// signature or refresh token, which are sensitive).
 type logService struct {
 	svc    Service
 	logger *zap.Logger
@@ -28,42 +28,62 @@ func NewLog(svc Service, logger *zap.Logger) Service {
 	return &logService{svc: svc, logger: logger}
 }
 
-func (ls *logService) Nonce(address string) (string, error) { return ls.svc.Nonce(address) }
+func (ls *logService) Nonce(ctx context.Context, address string) (string, error) {
+	return ls.svc.Nonce(ctx, address)
+}
 
 func (ls *logService) JWKS() jwt.JWKS { return ls.svc.JWKS() }
 
 func (ls *logService) Login(ctx context.Context, message, signature string) (resp *auth.LoginResponse, err error) {
-	start := time.Now()
+	defer ls.logCall("Login", time.Now(), func() []zap.Field { return expiryFields(resp) }, &err)
+	return ls.svc.Login(ctx, message, signature)
+}
+
+func (ls *logService) Refresh(ctx context.Context, refreshToken string) (resp *auth.LoginResponse, err error) {
+	defer ls.logCall("Refresh", time.Now(), func() []zap.Field { return expiryFields(resp) }, &err)
+	return ls.svc.Refresh(ctx, refreshToken)
+}
+
+func (ls *logService) Logout(ctx context.Context, refreshToken string) (err error) {
+	defer ls.logCall("Logout", time.Now(), nil, &err)
+	return ls.svc.Logout(ctx, refreshToken)
+}
 
-	ls.logger.Info("Login started",
+func (ls *logService) LogoutAll(ctx context.Context, cantonPartyID string) (err error) {
+	defer ls.logCall("LogoutAll", time.Now(), func() []zap.Field {
+		return []zap.Field{zap.String("canton_party_id", cantonPartyID)}
+	}, &err)
+	return ls.svc.LogoutAll(ctx, cantonPartyID)
+}
+
+func (ls *logService) RevokedSessions(ctx context.Context) ([]string, error) {
+	return ls.svc.RevokedSessions(ctx)
+}
+
+// logCall records the entry/exit, duration and error of method. It is deferred
+// at the top of each logged method; extra, when set, adds fields on success.
+func (ls *logService) logCall(method string, start time.Time, extra func() []zap.Field, errp *error) {
+	fields := []zap.Field{
 		zap.String("service", loginServiceName),
-		zap.String("method", "Login"),
-	)
-
-	defer func() {
-		duration := time.Since(start)
-
-		if err != nil {
-			ls.logger.Error("Login failed",
-				zap.String("service", loginServiceName),
-				zap.String("method", "Login"),
-				zap.Duration("duration", duration),
-				zap.Error(err),
-			)
-		} else {
-			fields := []zap.Field{
-				zap.String("service", loginServiceName),
-				zap.String("method", "Login"),
-				zap.Duration("duration", duration),
-			}
-			// resp is non-nil on the nil-error path, but guard defensively: this
-			// decorator wraps an interface any implementation could satisfy.
-			if resp != nil {
-				fields = append(fields, zap.Int64("expires_at", resp.ExpiresAt))
-			}
-			ls.logger.Info("Login completed", fields...)
-		}
-	}()
+		zap.String("method", method),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if *errp != nil {
+		ls.logger.Error(method+" failed", append(fields, zap.Error(*errp))...)
+		return
+	}
+	if extra != nil {
+		fields = append(fields, extra()...)
+	}
+	ls.logger.Info(method+" completed", fields...)
+}
 
-	return ls.svc.Login(ctx, message, signature)
+// expiryFields logs a token response's expiry. resp is non-nil on the nil-error
+// path, but guard defensively: this decorator wraps an interface any
+// implementation could satisfy.
+func expiryFields(resp *auth.LoginResponse) []zap.Field {
+	if resp == nil {
+		return nil
+	}
+	return []zap.Field{zap.Int64("expires_at", resp.ExpiresAt)}
 }
@@ -2,9 +2,12 @@
 
 // Package service implements the Sign-In with Ethereum (EIP-4361) login flow: it
 // issues single-use nonces, verifies signed SIWE messages, and mints the JWTs that
-// authenticate read endpoints. The cryptographic primitives (JWT issuer, SIWE
-// verifier, JWKS) live in pkg/auth/jwt and the nonce store in
-// pkg/auth/service/nonce_provider; this package orchestrates them and binds the
+// authenticate read endpoints. Each login opens a session whose refresh tokens are
+// rotated on every use; revoking the session cuts off both refresh and (through
+// the validators' revocation check) its outstanding access tokens. The
+// cryptographic primitives (JWT issuer, SIWE verifier, JWKS) live in
+// pkg/auth/jwt, the nonce store in pkg/auth/service/nonce_provider and the
+// session store in pkg/auth/store; this package orchestrates them and binds the
 // authenticated address to a registered user's Canton party.
 package service
 
@@ -24,13 +27,14 @@ import (
 // The login service depends only on the narrow interfaces below, declared at the
 // consumer so they can be mocked in isolation. Concrete implementations come from
 // pkg/auth/jwt (Verifier, Issuer), pkg/auth/service/nonce_provider (NonceStore),
-// and pkg/userstore (UserLookup).
+// pkg/auth/store (SessionStore) and pkg/userstore (UserLookup).
 
 //go:generate mockery --name Service --output mocks --outpkg mocks --filename mock_service.go --with-expecter
 //go:generate mockery --name Verifier --output mocks --outpkg mocks --filename mock_verifier.go --with-expecter
 //go:generate mockery --name Issuer --output mocks --outpkg mocks --filename mock_issuer.go --with-expecter
 //go:generate mockery --name NonceStore --output mocks --outpkg mocks --filename mock_nonce_store.go --with-expecter
 //go:generate mockery --name UserLookup --output mocks --outpkg mocks --filename mock_user_lookup.go --with-expecter
+//go:generate mockery --name SessionStore --output mocks --outpkg mocks --filename mock_session_store.go --with-expecter
 
 // UserLookup resolves a registered user by EVM address so login can bind the token
 // to the user's Canton party. Satisfied by *userstore.Store.
@@ -40,15 +44,16 @@ type UserLookup interface {
 
 // NonceStore issues and consumes single-use login nonces keyed by address. Consume
 // must be atomic so a given nonce satisfies at most one login. Implementations live
-// under nonce_provider (e.g. nonceprovider.InMemory).
+// under nonce_provider (nonceprovider.InMemory, nonceprovider.Postgres).
 type NonceStore interface {
 	// Issue returns a nonce for address, valid for the store's TTL. Calling it again
 	// for an address that still holds a live nonce returns the same value. It may
 	// return an error if the store is at capacity.
-	Issue(address string) (string, error)
+	Issue(ctx context.Context, address string) (string, error)
 	// Consume returns true exactly once for a live, previously-issued nonce and
-	// removes it; false for unknown, expired, or already-consumed nonces.
-	Consume(nonce string) bool
+	// removes it; false for unknown, expired, or already-consumed nonces. An error
+	// means the store could not be reached, not that the nonce is invalid.
+	Consume(ctx context.Context, nonce string) (bool, error)
 }
 
 // Verifier validates a signed SIWE login message and returns the recovered EVM
@@ -60,44 +65,83 @@ type Verifier interface {
 // Issuer mints session JWTs and publishes the signing key set. Satisfied by
 // *jwt.Issuer.
 type Issuer interface {
-	Issue(evmAddress, cantonPartyID string) (string, time.Time, error)
+	Issue(evmAddress, cantonPartyID, sessionID string) (string, time.Time, error)
+	// TTL is the lifetime of an issued token.
+	TTL() time.Duration
 	JWKS() jwt.JWKS
 }
 
-// Service orchestrates the SIWE login flow.
+// SessionStore persists login sessions and their refresh tokens, which are
+// addressed by hash only. Satisfied by *store.PGStore (pkg/auth/store).
+type SessionStore interface {
+	CreateSession(ctx context.Context, session *auth.Session, tokenHash []byte) error
+	// GetRefreshToken returns the token with its session, or nil if unknown.
+	GetRefreshToken(ctx context.Context, tokenHash []byte) (*auth.RefreshToken, error)
+	// RotateRefreshToken marks oldHash used and adds newHash to its session in one
+	// step, returning false if oldHash was already used.
+	RotateRefreshToken(ctx context.Context, oldHash, newHash []byte, at time.Time) (bool, error)
+	RevokeSession(ctx context.Context, id string, at time.Time) (bool, error)
+	RevokeSessionsForParty(ctx context.Context, partyID string, at time.Time) (int64, error)
+	RevokedSessionsSince(ctx context.Context, since time.Time) ([]string, error)
+	DeleteExpiredSessions(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Service orchestrates the SIWE login flow and the sessions it opens.
 type Service interface {
 	// Nonce issues a single-use login nonce for the given EVM address.
-	Nonce(address string) (string, error)
+	Nonce(ctx context.Context, address string) (string, error)
 	// JWKS returns the public signing key set for token validation by other services.
 	JWKS() jwt.JWKS
 	// Login verifies a signed SIWE message and, if the recovered address belongs to
-	// a registered user, issues a JWT bound to that user's Canton party.
+	// a registered user, opens a session and issues a JWT bound to that user's
+	// Canton party together with the session's first refresh token.
 	Login(ctx context.Context, message, signature string) (*auth.LoginResponse, error)
+	// Refresh exchanges a refresh token for a new access token and a new refresh
+	// token. A refresh token works once; presenting a used one revokes the session.
+	Refresh(ctx context.Context, refreshToken string) (*auth.LoginResponse, error)
+	// Logout revokes the session the refresh token belongs to.
+	Logout(ctx context.Context, refreshToken string) error
+	// LogoutAll revokes every session of the given party.
+	LogoutAll(ctx context.Context, cantonPartyID string) error
+	// RevokedSessions lists sessions revoked within the access-token lifetime, for
+	// validators that cannot query the session store directly.
+	RevokedSessions(ctx context.Context) ([]string, error)
 }
 
 type loginService struct {
-	verifier Verifier
-	issuer   Issuer
-	nonces   NonceStore
-	users    UserLookup
+	verifier   Verifier
+	issuer     Issuer
+	nonces     NonceStore
+	users      UserLookup
+	sessions   SessionStore
+	refreshTTL time.Duration
+	now        func() time.Time
 }
 
-// New builds a login Service from its collaborators.
+// New builds a login Service from its collaborators. Sessions last refreshTTL
+// from login; refreshing does not extend them.
 func New(
 	verifier Verifier,
 	issuer Issuer,
 	nonces NonceStore,
 	users UserLookup,
+	sessions SessionStore,
+	refreshTTL time.Duration,
 ) Service {
 	return &loginService{
-		verifier: verifier,
-		issuer:   issuer,
-		nonces:   nonces,
-		users:    users,
+		verifier:   verifier,
+		issuer:     issuer,
+		nonces:     nonces,
+		users:      users,
+		sessions:   sessions,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
 	}
 }
 
-func (s *loginService) Nonce(address string) (string, error) { return s.nonces.Issue(address) }
+func (s *loginService) Nonce(ctx context.Context, address string) (string, error) {
+	return s.nonces.Issue(ctx, address)
+}
 
 func (s *loginService) JWKS() jwt.JWKS { return s.issuer.JWKS() }
 
@@ -108,7 +152,11 @@ func (s *loginService) Login(ctx context.Context, message, signature string) (*a
 	}
 	// Enforce single-use only after the signature checks out, so a bad signature
 	// cannot burn a victim's outstanding nonce.
-	if !s.nonces.Consume(nonce) {
+	consumed, err := s.nonces.Consume(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
 		return nil, apperrors.UnAuthorizedError(nil, "nonce is unknown, expired, or already used")
 	}
 	evmAddress := auth.NormalizeAddress(addr.Hex())
@@ -124,10 +172,5 @@ func (s *loginService) Login(ctx context.Context, message, signature string) (*a
 		return nil, apperrors.UnAuthorizedError(nil, "user has no Canton party id")
 	}
 
-	token, exp, err := s.issuer.Issue(evmAddress, usr.CantonPartyID)
-	if err != nil {
-		return nil, apperrors.GeneralError(err)
-	}
-
-	return &auth.LoginResponse{Token: token, ExpiresAt: exp.Unix()}, nil
+	return s.startSession(ctx, evmAddress, usr.CantonPartyID)
 }
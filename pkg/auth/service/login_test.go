@@ -31,22 +31,29 @@ var (
 	loginAddr = auth.NormalizeAddress(testAddr.Hex())
 )
 
-func newLoginDeps(t *testing.T) (*mocks.Verifier, *mocks.Issuer, *mocks.NonceStore, *mocks.UserLookup) {
+const refreshTTL = 24 * time.Hour
+
+func newLoginDeps(t *testing.T) (*mocks.Verifier, *mocks.Issuer, *mocks.NonceStore, *mocks.UserLookup, *mocks.SessionStore) {
 	t.Helper()
-	return mocks.NewVerifier(t), mocks.NewIssuer(t), mocks.NewNonceStore(t), mocks.NewUserLookup(t)
+	return mocks.NewVerifier(t), mocks.NewIssuer(t), mocks.NewNonceStore(t), mocks.NewUserLookup(t), mocks.NewSessionStore(t)
 }
 
 func TestLogin_Success(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	exp := time.Unix(1_700_000_000, 0)
 
 	v.EXPECT().Verify(loginMessage, loginSig).Return(testAddr, loginNonce, nil)
-	n.EXPECT().Consume(loginNonce).Return(true)
+	n.EXPECT().Consume(mock.Anything, loginNonce).Return(true, nil)
 	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).
 		Return(&user.User{EVMAddress: loginAddr, CantonPartyID: "party::abc"}, nil)
-	iss.EXPECT().Issue(loginAddr, "party::abc").Return("the-token", exp, nil)
-
-	res, err := New(v, iss, n, u).Login(context.Background(), loginMessage, loginSig)
+	var session *auth.Session
+	var tokenHash []byte
+	ss.EXPECT().CreateSession(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, s *auth.Session, h []byte) { session, tokenHash = s, h }).
+		Return(nil)
+	iss.EXPECT().Issue(loginAddr, "party::abc", mock.Anything).Return("the-token", exp, nil)
+
+	res, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -56,80 +63,106 @@ func TestLogin_Success(t *testing.T) {
 	if res.ExpiresAt != exp.Unix() {
 		t.Fatalf("expires_at = %d, want %d", res.ExpiresAt, exp.Unix())
 	}
+
+	if session.EVMAddress != loginAddr || session.CantonPartyID != "party::abc" || session.ID == "" {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	if got := session.ExpiresAt.Sub(session.CreatedAt); got != refreshTTL {
+		t.Fatalf("session lifetime = %v, want %v", got, refreshTTL)
+	}
+	if res.RefreshExpiresAt != session.ExpiresAt.Unix() {
+		t.Fatalf("refresh_expires_at = %d, want %d", res.RefreshExpiresAt, session.ExpiresAt.Unix())
+	}
+	// Only the hash of the refresh token is persisted.
+	if res.RefreshToken == "" || string(tokenHash) != string(hashRefreshToken(res.RefreshToken)) {
+		t.Fatal("stored hash does not match the returned refresh token")
+	}
 }
 
 func TestLogin_InvalidSignature(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	// Verification fails; nothing else must be called — in particular the nonce must
 	// not be consumed on a bad signature (the mocks fail the test on any extra call).
 	v.EXPECT().Verify(loginMessage, loginSig).Return(common.Address{}, "", errors.New("bad signature"))
 
-	_, err := New(v, iss, n, u).Login(context.Background(), loginMessage, loginSig)
+	_, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
 	requireUnauthorized(t, err)
 }
 
 func TestLogin_NonceRejected(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	v.EXPECT().Verify(loginMessage, loginSig).Return(testAddr, loginNonce, nil)
-	n.EXPECT().Consume(loginNonce).Return(false) // reused / expired / unknown
+	n.EXPECT().Consume(mock.Anything, loginNonce).Return(false, nil) // reused / expired / unknown
 
-	_, err := New(v, iss, n, u).Login(context.Background(), loginMessage, loginSig)
+	_, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
 	requireUnauthorized(t, err)
 }
 
+func TestLogin_NonceStoreErrorIsNotUnauthorized(t *testing.T) {
+	v, iss, n, u, ss := newLoginDeps(t)
+	v.EXPECT().Verify(loginMessage, loginSig).Return(testAddr, loginNonce, nil)
+	n.EXPECT().Consume(mock.Anything, loginNonce).Return(false, errors.New("db down"))
+
+	_, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
+	if err == nil || apperrors.Is(err, apperrors.CategoryUnauthorized) {
+		t.Fatalf("expected a non-auth error, got %v", err)
+	}
+}
+
 func TestLogin_UnregisteredAddress(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	v.EXPECT().Verify(loginMessage, loginSig).Return(testAddr, loginNonce, nil)
-	n.EXPECT().Consume(loginNonce).Return(true)
+	n.EXPECT().Consume(mock.Anything, loginNonce).Return(true, nil)
 	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).Return(nil, user.ErrUserNotFound)
 
-	_, err := New(v, iss, n, u).Login(context.Background(), loginMessage, loginSig)
+	_, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
 	requireUnauthorized(t, err)
 }
 
 func TestLogin_MissingCantonParty(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	v.EXPECT().Verify(loginMessage, loginSig).Return(testAddr, loginNonce, nil)
-	n.EXPECT().Consume(loginNonce).Return(true)
+	n.EXPECT().Consume(mock.Anything, loginNonce).Return(true, nil)
 	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).
 		Return(&user.User{EVMAddress: loginAddr}, nil) // CantonPartyID empty
 
-	_, err := New(v, iss, n, u).Login(context.Background(), loginMessage, loginSig)
+	_, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
 	requireUnauthorized(t, err)
 }
 
 func TestLogin_StoreError(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	storeErr := errors.New("db unavailable")
 	v.EXPECT().Verify(loginMessage, loginSig).Return(testAddr, loginNonce, nil)
-	n.EXPECT().Consume(loginNonce).Return(true)
+	n.EXPECT().Consume(mock.Anything, loginNonce).Return(true, nil)
 	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).Return(nil, storeErr)
 
-	_, err := New(v, iss, n, u).Login(context.Background(), loginMessage, loginSig)
+	_, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
 	if !errors.Is(err, storeErr) {
 		t.Fatalf("expected wrapped store error, got %v", err)
 	}
 }
 
 func TestLogin_IssueError(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	v.EXPECT().Verify(loginMessage, loginSig).Return(testAddr, loginNonce, nil)
-	n.EXPECT().Consume(loginNonce).Return(true)
+	n.EXPECT().Consume(mock.Anything, loginNonce).Return(true, nil)
 	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).
 		Return(&user.User{CantonPartyID: "party::abc"}, nil)
-	iss.EXPECT().Issue(loginAddr, "party::abc").Return("", time.Time{}, errors.New("sign failure"))
+	ss.EXPECT().CreateSession(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	iss.EXPECT().Issue(loginAddr, "party::abc", mock.Anything).Return("", time.Time{}, errors.New("sign failure"))
 
-	_, err := New(v, iss, n, u).Login(context.Background(), loginMessage, loginSig)
+	_, err := New(v, iss, n, u, ss, refreshTTL).Login(context.Background(), loginMessage, loginSig)
 	if !apperrors.Is(err, apperrors.CategoryGeneralError) {
 		t.Fatalf("expected CategoryGeneralError, got %v", err)
 	}
 }
 
 func TestNonce_Delegates(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
-	n.EXPECT().Issue(loginAddr).Return("fresh-nonce", nil)
+	v, iss, n, u, ss := newLoginDeps(t)
+	n.EXPECT().Issue(mock.Anything, loginAddr).Return("fresh-nonce", nil)
 
-	got, err := New(v, iss, n, u).Nonce(loginAddr)
+	got, err := New(v, iss, n, u, ss, refreshTTL).Nonce(context.Background(), loginAddr)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -139,11 +172,11 @@ func TestNonce_Delegates(t *testing.T) {
 }
 
 func TestJWKS_Delegates(t *testing.T) {
-	v, iss, n, u := newLoginDeps(t)
+	v, iss, n, u, ss := newLoginDeps(t)
 	want := jwt.JWKS{Keys: []jwt.JWK{{Kid: "kid-1", Kty: "RSA"}}}
 	iss.EXPECT().JWKS().Return(want)
 
-	got := New(v, iss, n, u).JWKS()
+	got := New(v, iss, n, u, ss, refreshTTL).JWKS()
 	if len(got.Keys) != 1 || got.Keys[0].Kid != "kid-1" {
 		t.Fatalf("JWKS() = %+v, want %+v", got, want)
 	}
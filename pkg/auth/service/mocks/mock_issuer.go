@@ -22,9 +22,9 @@ func (_m *Issuer) EXPECT() *Issuer_Expecter {
 	return &Issuer_Expecter{mock: &_m.Mock}
 }
 
-// Issue provides a mock function with given fields: evmAddress, cantonPartyID
-func (_m *Issuer) Issue(evmAddress string, cantonPartyID string) (string, time.Time, error) {
-	ret := _m.Called(evmAddress, cantonPartyID)
+// Issue provides a mock function with given fields: evmAddress, cantonPartyID, sessionID
+func (_m *Issuer) Issue(evmAddress string, cantonPartyID string, sessionID string) (string, time.Time, error) {
+	ret := _m.Called(evmAddress, cantonPartyID, sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Issue")
@@ -33,23 +33,23 @@ func (_m *Issuer) Issue(evmAddress string, cantonPartyID string) (string, time.T
 	var r0 string
 	var r1 time.Time
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, time.Time, error)); ok {
-		return rf(evmAddress, cantonPartyID)
+	if rf, ok := ret.Get(0).(func(string, string, string) (string, time.Time, error)); ok {
+		return rf(evmAddress, cantonPartyID, sessionID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(evmAddress, cantonPartyID)
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(evmAddress, cantonPartyID, sessionID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) time.Time); ok {
-		r1 = rf(evmAddress, cantonPartyID)
+	if rf, ok := ret.Get(1).(func(string, string, string) time.Time); ok {
+		r1 = rf(evmAddress, cantonPartyID, sessionID)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
 
-	if rf, ok := ret.Get(2).(func(string, string) error); ok {
-		r2 = rf(evmAddress, cantonPartyID)
+	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
+		r2 = rf(evmAddress, cantonPartyID, sessionID)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -65,13 +65,14 @@ type Issuer_Issue_Call struct {
 // Issue is a helper method to define mock.On call
 //   - evmAddress string
 //   - cantonPartyID string
-func (_e *Issuer_Expecter) Issue(evmAddress interface{}, cantonPartyID interface{}) *Issuer_Issue_Call {
-	return &Issuer_Issue_Call{Call: _e.mock.On("Issue", evmAddress, cantonPartyID)}
+//   - sessionID string
+func (_e *Issuer_Expecter) Issue(evmAddress interface{}, cantonPartyID interface{}, sessionID interface{}) *Issuer_Issue_Call {
+	return &Issuer_Issue_Call{Call: _e.mock.On("Issue", evmAddress, cantonPartyID, sessionID)}
 }
 
-func (_c *Issuer_Issue_Call) Run(run func(evmAddress string, cantonPartyID string)) *Issuer_Issue_Call {
+func (_c *Issuer_Issue_Call) Run(run func(evmAddress string, cantonPartyID string, sessionID string)) *Issuer_Issue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -81,7 +82,7 @@ func (_c *Issuer_Issue_Call) Return(_a0 string, _a1 time.Time, _a2 error) *Issue
 	return _c
 }
 
-func (_c *Issuer_Issue_Call) RunAndReturn(run func(string, string) (string, time.Time, error)) *Issuer_Issue_Call {
+func (_c *Issuer_Issue_Call) RunAndReturn(run func(string, string, string) (string, time.Time, error)) *Issuer_Issue_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -131,6 +132,51 @@ func (_c *Issuer_JWKS_Call) RunAndReturn(run func() jwt.JWKS) *Issuer_JWKS_Call
 	return _c
 }
 
+// TTL provides a mock function with no fields
+func (_m *Issuer) TTL() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TTL")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// Issuer_TTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TTL'
+type Issuer_TTL_Call struct {
+	*mock.Call
+}
+
+// TTL is a helper method to define mock.On call
+func (_e *Issuer_Expecter) TTL() *Issuer_TTL_Call {
+	return &Issuer_TTL_Call{Call: _e.mock.On("TTL")}
+}
+
+func (_c *Issuer_TTL_Call) Run(run func()) *Issuer_TTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Issuer_TTL_Call) Return(_a0 time.Duration) *Issuer_TTL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Issuer_TTL_Call) RunAndReturn(run func() time.Duration) *Issuer_TTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewIssuer creates a new instance of Issuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIssuer(t interface {
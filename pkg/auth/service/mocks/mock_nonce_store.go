@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // NonceStore is an autogenerated mock type for the NonceStore type
 type NonceStore struct {
@@ -17,22 +21,32 @@ func (_m *NonceStore) EXPECT() *NonceStore_Expecter {
 	return &NonceStore_Expecter{mock: &_m.Mock}
 }
 
-// Consume provides a mock function with given fields: nonce
-func (_m *NonceStore) Consume(nonce string) bool {
-	ret := _m.Called(nonce)
+// Consume provides a mock function with given fields: ctx, nonce
+func (_m *NonceStore) Consume(ctx context.Context, nonce string) (bool, error) {
+	ret := _m.Called(ctx, nonce)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Consume")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(nonce)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, nonce)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NonceStore_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
@@ -41,31 +55,32 @@ type NonceStore_Consume_Call struct {
 }
 
 // Consume is a helper method to define mock.On call
+//   - ctx context.Context
 //   - nonce string
-func (_e *NonceStore_Expecter) Consume(nonce interface{}) *NonceStore_Consume_Call {
-	return &NonceStore_Consume_Call{Call: _e.mock.On("Consume", nonce)}
+func (_e *NonceStore_Expecter) Consume(ctx interface{}, nonce interface{}) *NonceStore_Consume_Call {
+	return &NonceStore_Consume_Call{Call: _e.mock.On("Consume", ctx, nonce)}
 }
 
-func (_c *NonceStore_Consume_Call) Run(run func(nonce string)) *NonceStore_Consume_Call {
+func (_c *NonceStore_Consume_Call) Run(run func(ctx context.Context, nonce string)) *NonceStore_Consume_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *NonceStore_Consume_Call) Return(_a0 bool) *NonceStore_Consume_Call {
-	_c.Call.Return(_a0)
+func (_c *NonceStore_Consume_Call) Return(_a0 bool, _a1 error) *NonceStore_Consume_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *NonceStore_Consume_Call) RunAndReturn(run func(string) bool) *NonceStore_Consume_Call {
+func (_c *NonceStore_Consume_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *NonceStore_Consume_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Issue provides a mock function with given fields: address
-func (_m *NonceStore) Issue(address string) (string, error) {
-	ret := _m.Called(address)
+// Issue provides a mock function with given fields: ctx, address
+func (_m *NonceStore) Issue(ctx context.Context, address string) (string, error) {
+	ret := _m.Called(ctx, address)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Issue")
@@ -73,17 +88,17 @@ func (_m *NonceStore) Issue(address string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(address)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, address)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(address)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, address)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(address)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -97,14 +112,15 @@ type NonceStore_Issue_Call struct {
 }
 
 // Issue is a helper method to define mock.On call
+//   - ctx context.Context
 //   - address string
-func (_e *NonceStore_Expecter) Issue(address interface{}) *NonceStore_Issue_Call {
-	return &NonceStore_Issue_Call{Call: _e.mock.On("Issue", address)}
+func (_e *NonceStore_Expecter) Issue(ctx interface{}, address interface{}) *NonceStore_Issue_Call {
+	return &NonceStore_Issue_Call{Call: _e.mock.On("Issue", ctx, address)}
 }
 
-func (_c *NonceStore_Issue_Call) Run(run func(address string)) *NonceStore_Issue_Call {
+func (_c *NonceStore_Issue_Call) Run(run func(ctx context.Context, address string)) *NonceStore_Issue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -114,7 +130,7 @@ func (_c *NonceStore_Issue_Call) Return(_a0 string, _a1 error) *NonceStore_Issue
 	return _c
 }
 
-func (_c *NonceStore_Issue_Call) RunAndReturn(run func(string) (string, error)) *NonceStore_Issue_Call {
+func (_c *NonceStore_Issue_Call) RunAndReturn(run func(context.Context, string) (string, error)) *NonceStore_Issue_Call {
 	_c.Call.Return(run)
 	return _c
 }
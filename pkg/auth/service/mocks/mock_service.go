@@ -130,9 +130,103 @@ func (_c *Service_Login_Call) RunAndReturn(run func(context.Context, string, str
 	return _c
 }
 
-// Nonce provides a mock function with given fields: address
-func (_m *Service) Nonce(address string) (string, error) {
-	ret := _m.Called(address)
+// Logout provides a mock function with given fields: ctx, refreshToken
+func (_m *Service) Logout(ctx context.Context, refreshToken string) error {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type Service_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *Service_Expecter) Logout(ctx interface{}, refreshToken interface{}) *Service_Logout_Call {
+	return &Service_Logout_Call{Call: _e.mock.On("Logout", ctx, refreshToken)}
+}
+
+func (_c *Service_Logout_Call) Run(run func(ctx context.Context, refreshToken string)) *Service_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_Logout_Call) Return(_a0 error) *Service_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Service_Logout_Call) RunAndReturn(run func(context.Context, string) error) *Service_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutAll provides a mock function with given fields: ctx, cantonPartyID
+func (_m *Service) LogoutAll(ctx context.Context, cantonPartyID string) error {
+	ret := _m.Called(ctx, cantonPartyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, cantonPartyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type Service_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cantonPartyID string
+func (_e *Service_Expecter) LogoutAll(ctx interface{}, cantonPartyID interface{}) *Service_LogoutAll_Call {
+	return &Service_LogoutAll_Call{Call: _e.mock.On("LogoutAll", ctx, cantonPartyID)}
+}
+
+func (_c *Service_LogoutAll_Call) Run(run func(ctx context.Context, cantonPartyID string)) *Service_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_LogoutAll_Call) Return(_a0 error) *Service_LogoutAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Service_LogoutAll_Call) RunAndReturn(run func(context.Context, string) error) *Service_LogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Nonce provides a mock function with given fields: ctx, address
+func (_m *Service) Nonce(ctx context.Context, address string) (string, error) {
+	ret := _m.Called(ctx, address)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Nonce")
@@ -140,17 +234,17 @@ func (_m *Service) Nonce(address string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(address)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, address)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(address)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, address)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(address)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -164,14 +258,15 @@ type Service_Nonce_Call struct {
 }
 
 // Nonce is a helper method to define mock.On call
+//   - ctx context.Context
 //   - address string
-func (_e *Service_Expecter) Nonce(address interface{}) *Service_Nonce_Call {
-	return &Service_Nonce_Call{Call: _e.mock.On("Nonce", address)}
+func (_e *Service_Expecter) Nonce(ctx interface{}, address interface{}) *Service_Nonce_Call {
+	return &Service_Nonce_Call{Call: _e.mock.On("Nonce", ctx, address)}
 }
 
-func (_c *Service_Nonce_Call) Run(run func(address string)) *Service_Nonce_Call {
+func (_c *Service_Nonce_Call) Run(run func(ctx context.Context, address string)) *Service_Nonce_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -181,7 +276,124 @@ func (_c *Service_Nonce_Call) Return(_a0 string, _a1 error) *Service_Nonce_Call
 	return _c
 }
 
-func (_c *Service_Nonce_Call) RunAndReturn(run func(string) (string, error)) *Service_Nonce_Call {
+func (_c *Service_Nonce_Call) RunAndReturn(run func(context.Context, string) (string, error)) *Service_Nonce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *Service) Refresh(ctx context.Context, refreshToken string) (*auth.LoginResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *auth.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.LoginResponse, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.LoginResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type Service_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *Service_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *Service_Refresh_Call {
+	return &Service_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+}
+
+func (_c *Service_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *Service_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_Refresh_Call) Return(_a0 *auth.LoginResponse, _a1 error) *Service_Refresh_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_Refresh_Call) RunAndReturn(run func(context.Context, string) (*auth.LoginResponse, error)) *Service_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokedSessions provides a mock function with given fields: ctx
+func (_m *Service) RevokedSessions(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokedSessions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_RevokedSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokedSessions'
+type Service_RevokedSessions_Call struct {
+	*mock.Call
+}
+
+// RevokedSessions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Service_Expecter) RevokedSessions(ctx interface{}) *Service_RevokedSessions_Call {
+	return &Service_RevokedSessions_Call{Call: _e.mock.On("RevokedSessions", ctx)}
+}
+
+func (_c *Service_RevokedSessions_Call) Run(run func(ctx context.Context)) *Service_RevokedSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Service_RevokedSessions_Call) Return(_a0 []string, _a1 error) *Service_RevokedSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_RevokedSessions_Call) RunAndReturn(run func(context.Context) ([]string, error)) *Service_RevokedSessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,6 +4,7 @@
 package nonceprovider
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -32,7 +33,7 @@ type entry struct {
 // InMemory is an in-process nonce store suitable for a single api-server replica.
 // Nonces are keyed by the requesting address so a given address holds at most one
 // live nonce. Nonces are short-lived, so losing them on restart is harmless; run
-// more than one replica and this must be replaced with a shared (e.g. Postgres) store.
+// more than one replica and use Postgres instead.
 type InMemory struct {
 	mu        sync.Mutex
 	byAddr    map[string]entry  // address -> its live nonce + expiry
@@ -59,7 +60,7 @@ func NewInMemory(ttl time.Duration) *InMemory {
 // same address) do not grow the store. Otherwise a fresh nonce is minted. It
 // purges expired entries on a fixed cadence and, when the store is full of live
 // entries, returns ErrStoreFull instead of evicting another address's live nonce.
-func (s *InMemory) Issue(address string) (string, error) {
+func (s *InMemory) Issue(_ context.Context, address string) (string, error) {
 	now := s.now()
 
 	s.mu.Lock()
@@ -97,7 +98,7 @@ func (s *InMemory) Issue(address string) (string, error) {
 }
 
 // Consume removes the nonce and reports whether it was live at the moment of use.
-func (s *InMemory) Consume(nonce string) bool {
+func (s *InMemory) Consume(_ context.Context, nonce string) (bool, error) {
 	now := s.now()
 
 	s.mu.Lock()
@@ -105,12 +106,12 @@ func (s *InMemory) Consume(nonce string) bool {
 
 	address, ok := s.byNonce[nonce]
 	if !ok {
-		return false
+		return false, nil
 	}
 	e := s.byAddr[address]
 	delete(s.byNonce, nonce)
 	delete(s.byAddr, address)
-	return now.Before(e.expiry)
+	return now.Before(e.expiry), nil
 }
 
 // purgeExpired removes all entries whose expiry has passed. Callers must hold s.mu.
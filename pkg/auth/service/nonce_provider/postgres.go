@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nonceprovider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	siwe "github.com/spruceid/siwe-go"
+	"github.com/uptrace/bun"
+)
+
+// NonceDao maps to the auth_nonces table. The address is the primary key, which
+// is what caps each address at one live nonce; the nonce is unique so Consume can
+// find it without the address.
+type NonceDao struct {
+	bun.BaseModel `bun:"table:auth_nonces"`
+	Address       string    `bun:"address,pk,type:varchar(42)"`
+	Nonce         string    `bun:"nonce,notnull,unique,type:varchar(64)"`
+	ExpiresAt     time.Time `bun:"expires_at,notnull"`
+}
+
+// Postgres is a nonce store shared by every api-server replica, so a nonce issued
+// by one replica can be consumed by another and login works behind a load
+// balancer without sticky sessions. It keeps the InMemory semantics: one live
+// nonce per address, reused on repeat requests; single use; and a cap on the
+// number of live nonces that rejects issuance instead of evicting.
+//
+// The cap is checked before insert without a lock, so concurrent issuance on
+// several replicas can overshoot it by a few rows; it bounds a flood, it is not
+// an exact quota.
+type Postgres struct {
+	db  *bun.DB
+	ttl time.Duration
+	max int
+	now func() time.Time
+
+	mu        sync.Mutex
+	nextSweep time.Time
+}
+
+// NewPostgres creates a Postgres nonce store whose nonces expire after ttl.
+func NewPostgres(db *bun.DB, ttl time.Duration) *Postgres {
+	return &Postgres{
+		db:  db,
+		ttl: ttl,
+		max: maxAddresses,
+		now: time.Now,
+	}
+}
+
+// Issue returns a nonce for address, reusing the address's live nonce if it has
+// one. When max live nonces exist it returns ErrStoreFull rather than evicting
+// one. Expired rows are swept at most once per ttl per replica.
+func (s *Postgres) Issue(ctx context.Context, address string) (string, error) {
+	now := s.now()
+	if s.sweepDue(now) {
+		if err := s.purgeExpired(ctx, now); err != nil {
+			return "", err
+		}
+	}
+
+	if nonce, err := s.liveNonce(ctx, address, now); err != nil || nonce != "" {
+		return nonce, err
+	}
+
+	// Only live rows count towards the cap, so expired ones never block issuance
+	// even before a sweep has removed them.
+	full, err := s.full(ctx, now)
+	if err != nil {
+		return "", err
+	}
+	if full {
+		return "", ErrStoreFull
+	}
+
+	// Insert, or replace the address's expired nonce. A live nonce that appeared
+	// since the lookup above (another replica issuing for the same address) is
+	// left alone and returned instead.
+	row := &NonceDao{Address: address, Nonce: siwe.GenerateNonce(), ExpiresAt: now.Add(s.ttl)}
+	res, err := s.db.NewInsert().Model(row).
+		On("CONFLICT (address) DO UPDATE").
+		Set("nonce = EXCLUDED.nonce").
+		Set("expires_at = EXCLUDED.expires_at").
+		Where("?TableAlias.expires_at <= ?", now).
+		Exec(ctx)
+	if err != nil {
+		return "", fmt.Errorf("insert nonce: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return row.Nonce, nil
+	}
+	return s.liveNonce(ctx, address, now)
+}
+
+// Consume deletes the nonce and reports whether it was live at the moment of use.
+// The delete is what makes it single-use: of two concurrent calls, only one gets
+// the row back.
+func (s *Postgres) Consume(ctx context.Context, nonce string) (bool, error) {
+	row := new(NonceDao)
+	err := s.db.NewDelete().Model(row).
+		Where("nonce = ?", nonce).
+		Returning("expires_at").
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("consume nonce: %w", err)
+	}
+	return s.now().Before(row.ExpiresAt), nil
+}
+
+// liveNonce returns the address's unexpired nonce, or "" if it has none.
+func (s *Postgres) liveNonce(ctx context.Context, address string, now time.Time) (string, error) {
+	row := new(NonceDao)
+	err := s.db.NewSelect().Model(row).
+		Column("nonce").
+		Where("address = ?", address).
+		Where("expires_at > ?", now).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get nonce: %w", err)
+	}
+	return row.Nonce, nil
+}
+
+// full reports whether the store holds max live nonces.
+func (s *Postgres) full(ctx context.Context, now time.Time) (bool, error) {
+	n, err := s.db.NewSelect().Model((*NonceDao)(nil)).Where("expires_at > ?", now).Count(ctx)
+	if err != nil {
+		return false, fmt.Errorf("count nonces: %w", err)
+	}
+	return n >= s.max, nil
+}
+
+// purgeExpired deletes every nonce whose expiry has passed.
+func (s *Postgres) purgeExpired(ctx context.Context, now time.Time) error {
+	if _, err := s.db.NewDelete().Model((*NonceDao)(nil)).Where("expires_at <= ?", now).Exec(ctx); err != nil {
+		return fmt.Errorf("purge expired nonces: %w", err)
+	}
+	return nil
+}
+
+// sweepDue reports whether this replica's periodic sweep is due and, if so,
+// schedules the next one.
+func (s *Postgres) sweepDue(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !now.After(s.nextSweep) {
+		return false
+	}
+	s.nextSweep = now.Add(s.ttl)
+	return true
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nonceprovider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/pgutil"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+func setupPostgres(t *testing.T, ttl time.Duration) (context.Context, *Postgres) {
+	t.Helper()
+	requireDockerAccess(t)
+
+	ctx := context.Background()
+	db, cleanup := pgutil.SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	if err := mghelper.CreateSchema(ctx, db, &NonceDao{}); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	return ctx, NewPostgres(db, ttl)
+}
+
+func requireDockerAccess(t *testing.T) {
+	t.Helper()
+
+	candidates := []string{
+		"/var/run/docker.sock",
+		filepath.Join(os.Getenv("HOME"), ".docker/run/docker.sock"),
+	}
+
+	for _, sock := range candidates {
+		if sock == "" {
+			continue
+		}
+		if _, err := os.Stat(sock); err != nil {
+			continue
+		}
+		conn, err := (&net.Dialer{}).DialContext(context.Background(), "unix", sock)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+	}
+
+	t.Skip("docker daemon socket is not accessible; skipping testcontainer-backed nonce store tests")
+}
+
+func TestPostgres_SingleUseAcrossReplicas(t *testing.T) {
+	ctx, store := setupPostgres(t, time.Minute)
+	// A second replica sharing the database.
+	other := NewPostgres(store.db, time.Minute)
+
+	nonce, err := store.Issue(ctx, "0xabc")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if again, _ := other.Issue(ctx, "0xabc"); again != nonce {
+		t.Fatalf("other replica issued %q, want the live nonce %q", again, nonce)
+	}
+
+	if ok, err := other.Consume(ctx, nonce); err != nil || !ok {
+		t.Fatalf("consume on other replica = %v, %v; want true", ok, err)
+	}
+	if ok, _ := store.Consume(ctx, nonce); ok {
+		t.Fatal("second consume of same nonce must fail")
+	}
+	if ok, _ := store.Consume(ctx, "never-issued"); ok {
+		t.Fatal("consuming an unknown nonce must fail")
+	}
+}
+
+func TestPostgres_ExpiredNonceIsReplaced(t *testing.T) {
+	ctx, store := setupPostgres(t, time.Minute)
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	store.now = func() time.Time { return base }
+
+	stale, _ := store.Issue(ctx, "0xabc")
+
+	store.now = func() time.Time { return base.Add(2 * time.Minute) }
+	fresh, err := store.Issue(ctx, "0xabc")
+	if err != nil {
+		t.Fatalf("re-issue: %v", err)
+	}
+	if fresh == stale {
+		t.Fatal("an expired nonce must not be reused")
+	}
+	if ok, _ := store.Consume(ctx, stale); ok {
+		t.Fatal("the replaced nonce must be gone")
+	}
+	if ok, _ := store.Consume(ctx, fresh); !ok {
+		t.Fatal("the fresh nonce must be consumable")
+	}
+}
+
+func TestPostgres_RejectsWhenFull_ReclaimsExpired(t *testing.T) {
+	ctx, store := setupPostgres(t, time.Minute)
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	store.now = func() time.Time { return base }
+	store.max = 2
+
+	for i := 0; i < store.max; i++ {
+		if _, err := store.Issue(ctx, "addr-"+strconv.Itoa(i)); err != nil {
+			t.Fatalf("issue %d: %v", i, err)
+		}
+	}
+	if _, err := store.Issue(ctx, "addr-overflow"); !errors.Is(err, ErrStoreFull) {
+		t.Fatalf("expected ErrStoreFull, got %v", err)
+	}
+	// An address that already holds a live nonce still gets it back.
+	if _, err := store.Issue(ctx, "addr-0"); err != nil {
+		t.Fatalf("re-issue for a live address: %v", err)
+	}
+
+	store.now = func() time.Time { return base.Add(2 * time.Minute) }
+	if _, err := store.Issue(ctx, "addr-overflow"); err != nil {
+		t.Fatalf("expected expired entries to be reclaimed, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SessionPruner periodically deletes ended sessions and their refresh tokens.
+// A session is kept for retention past its end — the access-token lifetime —
+// because tokens refreshed just before the end are still valid until then.
+type SessionPruner struct {
+	store     SessionStore
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+	logger    *zap.Logger
+}
+
+// NewSessionPruner creates a SessionPruner.
+func NewSessionPruner(store SessionStore, retention, interval time.Duration, logger *zap.Logger) *SessionPruner {
+	return &SessionPruner{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+// Run prunes every interval until ctx is done. Failures are logged and retried
+// on the next tick.
+func (p *SessionPruner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		n, err := p.store.DeleteExpiredSessions(ctx, p.now().Add(-p.retention))
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Warn("failed to prune auth sessions", zap.Error(err))
+			}
+			continue
+		}
+		if n > 0 {
+			p.logger.Info("pruned expired auth sessions", zap.Int64("count", n))
+		}
+	}
+}
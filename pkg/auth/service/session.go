@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+const (
+	sessionIDBytes    = 16
+	refreshTokenBytes = 32
+)
+
+// startSession opens a session for the identity and issues its first token pair.
+func (s *loginService) startSession(ctx context.Context, evmAddress, partyID string) (*auth.LoginResponse, error) {
+	id, err := randomString(sessionIDBytes, hex.EncodeToString)
+	if err != nil {
+		return nil, apperrors.GeneralError(err)
+	}
+	refresh, err := randomString(refreshTokenBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, apperrors.GeneralError(err)
+	}
+
+	now := s.now()
+	session := &auth.Session{
+		ID:            id,
+		EVMAddress:    evmAddress,
+		CantonPartyID: partyID,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(s.refreshTTL),
+	}
+	if err = s.sessions.CreateSession(ctx, session, hashRefreshToken(refresh)); err != nil {
+		return nil, err
+	}
+	return s.respond(session, refresh)
+}
+
+func (s *loginService) Refresh(ctx context.Context, refreshToken string) (*auth.LoginResponse, error) {
+	hash := hashRefreshToken(refreshToken)
+	rt, err := s.sessions.GetRefreshToken(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if rt == nil {
+		return nil, apperrors.UnAuthorizedError(nil, "invalid refresh token")
+	}
+	session := rt.Session
+	now := s.now()
+
+	// A used token coming back means it was copied: either the holder or a thief
+	// already moved on to its successor. We can't tell which, so end the session.
+	if rt.UsedAt != nil {
+		return nil, s.revokeReused(ctx, session.ID)
+	}
+	if !session.Active(now) {
+		return nil, apperrors.UnAuthorizedError(nil, "session expired or revoked")
+	}
+
+	// The user may have been removed or re-bound since login.
+	usr, err := s.users.GetUserByEVMAddress(ctx, session.EVMAddress)
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return nil, err
+	}
+	if usr == nil || usr.CantonPartyID != session.CantonPartyID {
+		if _, err = s.sessions.RevokeSession(ctx, session.ID, now); err != nil {
+			return nil, err
+		}
+		return nil, apperrors.UnAuthorizedError(nil, "address is no longer registered to this party")
+	}
+
+	next, err := randomString(refreshTokenBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, apperrors.GeneralError(err)
+	}
+	rotated, err := s.sessions.RotateRefreshToken(ctx, hash, hashRefreshToken(next), now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Lost a race with another exchange of the same token.
+		return nil, s.revokeReused(ctx, session.ID)
+	}
+	return s.respond(session, next)
+}
+
+func (s *loginService) Logout(ctx context.Context, refreshToken string) error {
+	rt, err := s.sessions.GetRefreshToken(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if rt == nil {
+		return apperrors.UnAuthorizedError(nil, "invalid refresh token")
+	}
+	// Already-revoked sessions are fine: logout is idempotent.
+	_, err = s.sessions.RevokeSession(ctx, rt.SessionID, s.now())
+	return err
+}
+
+func (s *loginService) LogoutAll(ctx context.Context, cantonPartyID string) error {
+	_, err := s.sessions.RevokeSessionsForParty(ctx, cantonPartyID, s.now())
+	return err
+}
+
+func (s *loginService) RevokedSessions(ctx context.Context) ([]string, error) {
+	// Access tokens of sessions revoked longer ago than their lifetime have
+	// expired on their own, so they no longer need listing.
+	return s.sessions.RevokedSessionsSince(ctx, s.now().Add(-s.issuer.TTL()))
+}
+
+// revokeReused ends a session after refresh-token reuse and returns the error
+// reported to the caller.
+func (s *loginService) revokeReused(ctx context.Context, sessionID string) error {
+	if _, err := s.sessions.RevokeSession(ctx, sessionID, s.now()); err != nil {
+		return err
+	}
+	return apperrors.UnAuthorizedError(nil, "refresh token reuse detected; session revoked")
+}
+
+// respond issues an access token for the session and pairs it with refresh.
+func (s *loginService) respond(session *auth.Session, refresh string) (*auth.LoginResponse, error) {
+	token, exp, err := s.issuer.Issue(session.EVMAddress, session.CantonPartyID, session.ID)
+	if err != nil {
+		return nil, apperrors.GeneralError(err)
+	}
+	return &auth.LoginResponse{
+		Token:            token,
+		ExpiresAt:        exp.Unix(),
+		RefreshToken:     refresh,
+		RefreshExpiresAt: session.ExpiresAt.Unix(),
+	}, nil
+}
+
+// hashRefreshToken is the key refresh tokens are stored under. The tokens are
+// 256-bit random values, so an unsalted fast hash is sufficient.
+func hashRefreshToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random value: %w", err)
+	}
+	return encode(b), nil
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/auth/service/mocks"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+const (
+	sessionParty = "party::abc"
+	sessionID    = "session-1"
+	oldRefresh   = "old-refresh-token"
+)
+
+var sessionNow = time.Unix(1_700_000_000, 0)
+
+// newSessionService returns a login service with a fixed clock, plus the mocks a
+// refresh touches.
+func newSessionService(t *testing.T) (*loginService, *mocks.Issuer, *mocks.UserLookup, *mocks.SessionStore) {
+	t.Helper()
+	v, iss, n, u, ss := newLoginDeps(t)
+	svc := New(v, iss, n, u, ss, refreshTTL).(*loginService)
+	svc.now = func() time.Time { return sessionNow }
+	return svc, iss, u, ss
+}
+
+func storedToken(usedAt, revokedAt *time.Time) *auth.RefreshToken {
+	return &auth.RefreshToken{
+		Hash:      hashRefreshToken(oldRefresh),
+		SessionID: sessionID,
+		UsedAt:    usedAt,
+		Session: &auth.Session{
+			ID:            sessionID,
+			EVMAddress:    loginAddr,
+			CantonPartyID: sessionParty,
+			CreatedAt:     sessionNow.Add(-time.Hour),
+			ExpiresAt:     sessionNow.Add(time.Hour),
+			RevokedAt:     revokedAt,
+		},
+	}
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	svc, iss, u, ss := newSessionService(t)
+	ss.EXPECT().GetRefreshToken(mock.Anything, hashRefreshToken(oldRefresh)).Return(storedToken(nil, nil), nil)
+	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).
+		Return(&user.User{EVMAddress: loginAddr, CantonPartyID: sessionParty}, nil)
+	var newHash []byte
+	ss.EXPECT().RotateRefreshToken(mock.Anything, hashRefreshToken(oldRefresh), mock.Anything, sessionNow).
+		Run(func(_ context.Context, _, h []byte, _ time.Time) { newHash = h }).
+		Return(true, nil)
+	iss.EXPECT().Issue(loginAddr, sessionParty, sessionID).Return("access", sessionNow.Add(time.Minute), nil)
+
+	res, err := svc.Refresh(context.Background(), oldRefresh)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if res.Token != "access" {
+		t.Fatalf("token = %q, want access", res.Token)
+	}
+	if res.RefreshToken == oldRefresh || string(newHash) != string(hashRefreshToken(res.RefreshToken)) {
+		t.Fatal("refresh token was not rotated to the stored successor")
+	}
+	// Refreshing does not extend the session.
+	if res.RefreshExpiresAt != sessionNow.Add(time.Hour).Unix() {
+		t.Fatalf("refresh_expires_at = %d, want the session end", res.RefreshExpiresAt)
+	}
+}
+
+func TestRefresh_UnknownToken(t *testing.T) {
+	svc, _, _, ss := newSessionService(t)
+	ss.EXPECT().GetRefreshToken(mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := svc.Refresh(context.Background(), "nope")
+	requireUnauthorized(t, err)
+}
+
+func TestRefresh_ReusedTokenRevokesSession(t *testing.T) {
+	svc, _, _, ss := newSessionService(t)
+	used := sessionNow.Add(-time.Minute)
+	ss.EXPECT().GetRefreshToken(mock.Anything, mock.Anything).Return(storedToken(&used, nil), nil)
+	ss.EXPECT().RevokeSession(mock.Anything, sessionID, sessionNow).Return(true, nil)
+
+	_, err := svc.Refresh(context.Background(), oldRefresh)
+	requireUnauthorized(t, err)
+}
+
+func TestRefresh_LostRotationRaceRevokesSession(t *testing.T) {
+	svc, _, u, ss := newSessionService(t)
+	ss.EXPECT().GetRefreshToken(mock.Anything, mock.Anything).Return(storedToken(nil, nil), nil)
+	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).
+		Return(&user.User{EVMAddress: loginAddr, CantonPartyID: sessionParty}, nil)
+	ss.EXPECT().RotateRefreshToken(mock.Anything, mock.Anything, mock.Anything, sessionNow).Return(false, nil)
+	ss.EXPECT().RevokeSession(mock.Anything, sessionID, sessionNow).Return(true, nil)
+
+	_, err := svc.Refresh(context.Background(), oldRefresh)
+	requireUnauthorized(t, err)
+}
+
+func TestRefresh_RevokedOrExpiredSession(t *testing.T) {
+	revoked := storedToken(nil, &sessionNow)
+	expired := storedToken(nil, nil)
+	expired.Session.ExpiresAt = sessionNow
+
+	for name, rt := range map[string]*auth.RefreshToken{"revoked": revoked, "expired": expired} {
+		t.Run(name, func(t *testing.T) {
+			svc, _, _, ss := newSessionService(t)
+			ss.EXPECT().GetRefreshToken(mock.Anything, mock.Anything).Return(rt, nil)
+
+			_, err := svc.Refresh(context.Background(), oldRefresh)
+			requireUnauthorized(t, err)
+		})
+	}
+}
+
+func TestRefresh_UserGoneRevokesSession(t *testing.T) {
+	svc, _, u, ss := newSessionService(t)
+	ss.EXPECT().GetRefreshToken(mock.Anything, mock.Anything).Return(storedToken(nil, nil), nil)
+	u.EXPECT().GetUserByEVMAddress(mock.Anything, loginAddr).Return(nil, user.ErrUserNotFound)
+	ss.EXPECT().RevokeSession(mock.Anything, sessionID, sessionNow).Return(true, nil)
+
+	_, err := svc.Refresh(context.Background(), oldRefresh)
+	requireUnauthorized(t, err)
+}
+
+func TestLogout(t *testing.T) {
+	t.Run("revokes the token's session", func(t *testing.T) {
+		svc, _, _, ss := newSessionService(t)
+		ss.EXPECT().GetRefreshToken(mock.Anything, hashRefreshToken(oldRefresh)).Return(storedToken(nil, nil), nil)
+		ss.EXPECT().RevokeSession(mock.Anything, sessionID, sessionNow).Return(true, nil)
+
+		if err := svc.Logout(context.Background(), oldRefresh); err != nil {
+			t.Fatalf("logout: %v", err)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		svc, _, _, ss := newSessionService(t)
+		ss.EXPECT().GetRefreshToken(mock.Anything, mock.Anything).Return(nil, nil)
+
+		requireUnauthorized(t, svc.Logout(context.Background(), "nope"))
+	})
+}
+
+func TestLogoutAll(t *testing.T) {
+	svc, _, _, ss := newSessionService(t)
+	ss.EXPECT().RevokeSessionsForParty(mock.Anything, sessionParty, sessionNow).Return(2, nil)
+
+	if err := svc.LogoutAll(context.Background(), sessionParty); err != nil {
+		t.Fatalf("logout all: %v", err)
+	}
+}
+
+func TestRevokedSessions_CoversAccessTokenLifetime(t *testing.T) {
+	svc, iss, _, ss := newSessionService(t)
+	iss.EXPECT().TTL().Return(30 * time.Minute)
+	ss.EXPECT().RevokedSessionsSince(mock.Anything, sessionNow.Add(-30*time.Minute)).Return([]string{sessionID}, nil)
+
+	ids, err := svc.RevokedSessions(context.Background())
+	if err != nil {
+		t.Fatalf("revoked sessions: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != sessionID {
+		t.Fatalf("ids = %v, want [%s]", ids, sessionID)
+	}
+}
+
+func TestSessionPruner_DeletesPastRetention(t *testing.T) {
+	ss := mocks.NewSessionStore(t)
+	p := NewSessionPruner(ss, time.Hour, time.Millisecond, zap.NewNop())
+	p.now = func() time.Time { return sessionNow }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ss.EXPECT().DeleteExpiredSessions(mock.Anything, sessionNow.Add(-time.Hour)).
+		Run(func(context.Context, time.Time) { cancel() }).
+		Return(0, errors.New("db down")).Once()
+
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import "time"
+
+// Session is one SIWE login. Access tokens carry its ID, and the session's
+// refresh tokens are rotated on every use. Revoking the session cuts off both:
+// refresh is refused and validators reject access tokens bearing its ID.
+type Session struct {
+	ID            string
+	EVMAddress    string
+	CantonPartyID string
+	CreatedAt     time.Time
+	// ExpiresAt is the hard end of the session; refreshing does not extend it.
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// Active reports whether the session can still be refreshed at now.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is one refresh token of a session. Only the SHA-256 hash of the
+// token is stored; UsedAt is set when it is exchanged, after which presenting it
+// again is treated as theft.
+type RefreshToken struct {
+	Hash      []byte
+	SessionID string
+	CreatedAt time.Time
+	UsedAt    *time.Time
+	Session   *Session
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+)
+
+// SessionDao maps to the auth_sessions table.
+type SessionDao struct {
+	bun.BaseModel `bun:"table:auth_sessions"`
+	ID            string     `bun:"id,pk,type:varchar(64)"`
+	EVMAddress    string     `bun:"evm_address,notnull,type:varchar(42)"`
+	CantonPartyID string     `bun:"canton_party_id,notnull,type:varchar(255)"`
+	CreatedAt     time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	ExpiresAt     time.Time  `bun:"expires_at,notnull"`
+	RevokedAt     *time.Time `bun:"revoked_at"`
+}
+
+// RefreshTokenDao maps to the auth_refresh_tokens table. Tokens are looked up
+// by the SHA-256 hash of their value; the value itself is never stored.
+type RefreshTokenDao struct {
+	bun.BaseModel `bun:"table:auth_refresh_tokens"`
+	TokenHash     []byte     `bun:"token_hash,pk,type:bytea"`
+	SessionID     string     `bun:"session_id,notnull,type:varchar(64)"`
+	CreatedAt     time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UsedAt        *time.Time `bun:"used_at"`
+}
+
+func toSessionDao(s *auth.Session) *SessionDao {
+	return &SessionDao{
+		ID:            s.ID,
+		EVMAddress:    s.EVMAddress,
+		CantonPartyID: s.CantonPartyID,
+		CreatedAt:     s.CreatedAt,
+		ExpiresAt:     s.ExpiresAt,
+		RevokedAt:     s.RevokedAt,
+	}
+}
+
+func fromSessionDao(dao *SessionDao) *auth.Session {
+	return &auth.Session{
+		ID:            dao.ID,
+		EVMAddress:    dao.EVMAddress,
+		CantonPartyID: dao.CantonPartyID,
+		CreatedAt:     dao.CreatedAt,
+		ExpiresAt:     dao.ExpiresAt,
+		RevokedAt:     dao.RevokedAt,
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package store persists login sessions and their refresh tokens in apidb.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+)
+
+// PGStore is a PostgreSQL-backed session store.
+type PGStore struct {
+	db *bun.DB
+}
+
+// NewStore creates a new PostgreSQL-backed session store.
+func NewStore(db *bun.DB) *PGStore {
+	return &PGStore{db: db}
+}
+
+// CreateSession inserts a session together with its first refresh token.
+func (s *PGStore) CreateSession(ctx context.Context, session *auth.Session, tokenHash []byte) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(toSessionDao(session)).Exec(ctx); err != nil {
+			return fmt.Errorf("insert session: %w", err)
+		}
+		token := &RefreshTokenDao{TokenHash: tokenHash, SessionID: session.ID, CreatedAt: session.CreatedAt}
+		if _, err := tx.NewInsert().Model(token).Exec(ctx); err != nil {
+			return fmt.Errorf("insert refresh token: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetRefreshToken returns the refresh token with the given hash and its
+// session, or nil if no such token exists.
+func (s *PGStore) GetRefreshToken(ctx context.Context, tokenHash []byte) (*auth.RefreshToken, error) {
+	token := new(RefreshTokenDao)
+	err := s.db.NewSelect().Model(token).Where("token_hash = ?", tokenHash).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get refresh token: %w", err)
+	}
+
+	session := new(SessionDao)
+	if err = s.db.NewSelect().Model(session).Where("id = ?", token.SessionID).Scan(ctx); err != nil {
+		// Tokens are only deleted together with their session, so a miss here
+		// is an inconsistency rather than "not found".
+		return nil, fmt.Errorf("get session %s: %w", token.SessionID, err)
+	}
+
+	return &auth.RefreshToken{
+		Hash:      token.TokenHash,
+		SessionID: token.SessionID,
+		CreatedAt: token.CreatedAt,
+		UsedAt:    token.UsedAt,
+		Session:   fromSessionDao(session),
+	}, nil
+}
+
+// RotateRefreshToken marks the token oldHash used and adds newHash to the same
+// session, atomically. It returns false, changing nothing, if oldHash had
+// already been used — i.e. a concurrent or replayed refresh won the race.
+func (s *PGStore) RotateRefreshToken(ctx context.Context, oldHash, newHash []byte, at time.Time) (bool, error) {
+	rotated := false
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		old := new(RefreshTokenDao)
+		err := tx.NewUpdate().Model(old).
+			Set("used_at = ?", at).
+			Where("token_hash = ?", oldHash).
+			Where("used_at IS NULL").
+			Returning("session_id").
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("mark refresh token used: %w", err)
+		}
+
+		token := &RefreshTokenDao{TokenHash: newHash, SessionID: old.SessionID, CreatedAt: at}
+		if _, err = tx.NewInsert().Model(token).Exec(ctx); err != nil {
+			return fmt.Errorf("insert refresh token: %w", err)
+		}
+		rotated = true
+		return nil
+	})
+	return rotated, err
+}
+
+// RevokeSession revokes the session. It returns false if the session does not
+// exist or was already revoked.
+func (s *PGStore) RevokeSession(ctx context.Context, id string, at time.Time) (bool, error) {
+	res, err := s.db.NewUpdate().Model((*SessionDao)(nil)).
+		Set("revoked_at = ?", at).
+		Where("id = ?", id).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("revoke session %s: %w", id, err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// RevokeSessionsForParty revokes every live session of the party and returns
+// how many were revoked.
+func (s *PGStore) RevokeSessionsForParty(ctx context.Context, partyID string, at time.Time) (int64, error) {
+	res, err := s.db.NewUpdate().Model((*SessionDao)(nil)).
+		Set("revoked_at = ?", at).
+		Where("canton_party_id = ?", partyID).
+		Where("revoked_at IS NULL").
+		Where("expires_at > ?", at).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("revoke sessions for party %s: %w", partyID, err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// IsSessionRevoked reports whether the session is revoked. A session that does
+// not exist (e.g. pruned after expiry) is reported as revoked.
+func (s *PGStore) IsSessionRevoked(ctx context.Context, id string) (bool, error) {
+	session := new(SessionDao)
+	err := s.db.NewSelect().Model(session).Column("revoked_at").Where("id = ?", id).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get session %s: %w", id, err)
+	}
+	return session.RevokedAt != nil, nil
+}
+
+// RevokedSessionsSince returns the IDs of sessions revoked at or after since.
+func (s *PGStore) RevokedSessionsSince(ctx context.Context, since time.Time) ([]string, error) {
+	var ids []string
+	err := s.db.NewSelect().Model((*SessionDao)(nil)).
+		Column("id").
+		Where("revoked_at >= ?", since).
+		OrderExpr("revoked_at").
+		Scan(ctx, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("list revoked sessions: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteExpiredSessions removes sessions (and their refresh tokens) that ended
+// before the given time, returning how many sessions were removed.
+func (s *PGStore) DeleteExpiredSessions(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		expired := tx.NewSelect().Model((*SessionDao)(nil)).Column("id").Where("expires_at < ?", before)
+		if _, err := tx.NewDelete().Model((*RefreshTokenDao)(nil)).
+			Where("session_id IN (?)", expired).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("delete refresh tokens: %w", err)
+		}
+		res, err := tx.NewDelete().Model((*SessionDao)(nil)).Where("expires_at < ?", before).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete sessions: %w", err)
+		}
+		n, _ = res.RowsAffected()
+		return nil
+	})
+	return n, err
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/pgutil"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+func setupSessionStore(t *testing.T) (context.Context, *PGStore) {
+	t.Helper()
+	requireDockerAccess(t)
+
+	ctx := context.Background()
+	db, cleanup := pgutil.SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	if err := mghelper.CreateSchema(ctx, db, &SessionDao{}, &RefreshTokenDao{}); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	return ctx, NewStore(db)
+}
+
+func requireDockerAccess(t *testing.T) {
+	t.Helper()
+
+	candidates := []string{
+		"/var/run/docker.sock",
+		filepath.Join(os.Getenv("HOME"), ".docker/run/docker.sock"),
+	}
+
+	for _, sock := range candidates {
+		if sock == "" {
+			continue
+		}
+		if _, err := os.Stat(sock); err != nil {
+			continue
+		}
+		conn, err := (&net.Dialer{}).DialContext(context.Background(), "unix", sock)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+	}
+
+	t.Skip("docker daemon socket is not accessible; skipping testcontainer-backed auth store tests")
+}
+
+func hash(s string) []byte {
+	sum := sha256.Sum256([]byte(s))
+	return sum[:]
+}
+
+func newSession(id, party string, now time.Time) *auth.Session {
+	return &auth.Session{
+		ID:            id,
+		EVMAddress:    "0x00000000000000000000000000000000000000ff",
+		CantonPartyID: party,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(time.Hour),
+	}
+}
+
+func TestPGStore_RefreshTokenRotation(t *testing.T) {
+	ctx, store := setupSessionStore(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	if err := store.CreateSession(ctx, newSession("s1", "party::a", now), hash("t1")); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	rt, err := store.GetRefreshToken(ctx, hash("t1"))
+	if err != nil || rt == nil {
+		t.Fatalf("GetRefreshToken(t1) = %v, %v", rt, err)
+	}
+	if rt.SessionID != "s1" || rt.UsedAt != nil || rt.Session.CantonPartyID != "party::a" {
+		t.Fatalf("unexpected token %+v / session %+v", rt, rt.Session)
+	}
+
+	rotated, err := store.RotateRefreshToken(ctx, hash("t1"), hash("t2"), now)
+	if err != nil || !rotated {
+		t.Fatalf("RotateRefreshToken(t1→t2) = %v, %v", rotated, err)
+	}
+	// The used token can't be rotated again.
+	rotated, err = store.RotateRefreshToken(ctx, hash("t1"), hash("t3"), now)
+	if err != nil || rotated {
+		t.Fatalf("second RotateRefreshToken(t1) = %v, %v; want false", rotated, err)
+	}
+
+	old, err := store.GetRefreshToken(ctx, hash("t1"))
+	if err != nil || old.UsedAt == nil {
+		t.Fatalf("t1 should be marked used: %+v, %v", old, err)
+	}
+	next, err := store.GetRefreshToken(ctx, hash("t2"))
+	if err != nil || next == nil || next.SessionID != "s1" {
+		t.Fatalf("t2 should belong to s1: %+v, %v", next, err)
+	}
+	if missing, _ := store.GetRefreshToken(ctx, hash("t3")); missing != nil {
+		t.Fatal("t3 must not have been stored")
+	}
+}
+
+func TestPGStore_Revocation(t *testing.T) {
+	ctx, store := setupSessionStore(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for _, s := range []struct{ id, party string }{{"s1", "party::a"}, {"s2", "party::a"}, {"s3", "party::b"}} {
+		if err := store.CreateSession(ctx, newSession(s.id, s.party, now), hash(s.id)); err != nil {
+			t.Fatalf("CreateSession(%s): %v", s.id, err)
+		}
+	}
+
+	if revoked, err := store.IsSessionRevoked(ctx, "s1"); err != nil || revoked {
+		t.Fatalf("IsSessionRevoked(s1) = %v, %v; want false", revoked, err)
+	}
+	if revoked, err := store.IsSessionRevoked(ctx, "unknown"); err != nil || !revoked {
+		t.Fatalf("IsSessionRevoked(unknown) = %v, %v; want true", revoked, err)
+	}
+
+	if ok, err := store.RevokeSession(ctx, "s3", now); err != nil || !ok {
+		t.Fatalf("RevokeSession(s3) = %v, %v", ok, err)
+	}
+	if ok, _ := store.RevokeSession(ctx, "s3", now); ok {
+		t.Fatal("revoking twice should report false")
+	}
+
+	n, err := store.RevokeSessionsForParty(ctx, "party::a", now.Add(time.Second))
+	if err != nil || n != 2 {
+		t.Fatalf("RevokeSessionsForParty = %d, %v; want 2", n, err)
+	}
+
+	ids, err := store.RevokedSessionsSince(ctx, now.Add(time.Second))
+	if err != nil {
+		t.Fatalf("RevokedSessionsSince: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("revoked since = %v, want s1 and s2", ids)
+	}
+}
+
+func TestPGStore_DeleteExpiredSessions(t *testing.T) {
+	ctx, store := setupSessionStore(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	if err := store.CreateSession(ctx, newSession("old", "party::a", now.Add(-2*time.Hour)), hash("old")); err != nil {
+		t.Fatalf("CreateSession(old): %v", err)
+	}
+	if err := store.CreateSession(ctx, newSession("live", "party::a", now), hash("live")); err != nil {
+		t.Fatalf("CreateSession(live): %v", err)
+	}
+
+	n, err := store.DeleteExpiredSessions(ctx, now)
+	if err != nil || n != 1 {
+		t.Fatalf("DeleteExpiredSessions = %d, %v; want 1", n, err)
+	}
+	if rt, _ := store.GetRefreshToken(ctx, hash("old")); rt != nil {
+		t.Fatal("tokens of a deleted session must be deleted too")
+	}
+	if rt, _ := store.GetRefreshToken(ctx, hash("live")); rt == nil {
+		t.Fatal("live session must be kept")
+	}
+}
@@ -9,7 +9,22 @@ type LoginRequest struct {
 	Signature string `json:"signature"`
 }
 
+// LoginResponse is returned by both login and refresh. The refresh token is
+// single-use: each refresh returns a new one and retires the old.
 type LoginResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"` // unix seconds
+	Token            string `json:"token"`
+	ExpiresAt        int64  `json:"expires_at"` // unix seconds
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at"` // unix seconds; the session's hard end
+}
+
+// RefreshTokenRequest carries a refresh token, for refresh and logout.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RevokedSessionsResponse lists sessions revoked recently enough that access
+// tokens minted for them may still be unexpired.
+type RevokedSessionsResponse struct {
+	SessionIDs []string `json:"session_ids"`
 }
@@ -12,12 +12,14 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	bridgedaml "github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/bridge"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/cip56"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/identity"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,8 +33,6 @@ const (
 	// stream end after hours of stable operation from inheriting a maxed-out delay.
 	streamHealthyThreshold    = 30 * time.Second
 	withdrawalEventChannelCap = 10
-
-	bridgeContractsModule = "Bridge.Contracts"
 )
 
 // Bridge defines bridge operations.
@@ -65,6 +65,11 @@ type Bridge interface {
 
 	// GetLatestLedgerOffset returns the ledger end
 	GetLatestLedgerOffset(ctx context.Context) (int64, error)
+
+	// ResolveOutcome reports what became of a bridge command, identified by its
+	// deterministic command ID (e.g. DepositCommandID). Call it before retrying
+	// a step whose submission ended ambiguously. See ledger.Client.ResolveOutcome.
+	ResolveOutcome(ctx context.Context, commandID string) (*ledger.Outcome, error)
 }
 
 // Client implements bridge operations.
@@ -105,11 +110,7 @@ func (c *Client) GetWayfinderBridgeConfigCID(ctx context.Context) (string, error
 		return "", fmt.Errorf("ledger is empty, no contracts exist")
 	}
 
-	tid := &lapiv2.Identifier{
-		PackageId:  c.cfg.PackageID,
-		ModuleName: c.cfg.Module,
-		EntityName: "WayfinderBridgeConfig",
-	}
+	tid := c.bridgeConfigTemplate().ID(c.cfg.PackageID)
 
 	events, err := c.ledger.GetActiveContractsByTemplate(ctx, end, []string{c.cfg.OperatorParty}, tid)
 	if err != nil {
@@ -138,16 +139,8 @@ func (c *Client) IsDepositProcessed(ctx context.Context, evmTxHash string) (bool
 	// Common.FingerprintAuth templates live in the identity package, not the bridge package.
 	// Use the identity client's package ID so the ACS query targets the correct package.
 	identityPkgID := c.identity.PackageID()
-	pendingTID := &lapiv2.Identifier{
-		PackageId:  identityPkgID,
-		ModuleName: "Common.FingerprintAuth",
-		EntityName: "PendingDeposit",
-	}
-	receiptTID := &lapiv2.Identifier{
-		PackageId:  identityPkgID,
-		ModuleName: "Common.FingerprintAuth",
-		EntityName: "DepositReceipt",
-	}
+	pendingTID := bridgedaml.PendingDepositTemplate.ID(identityPkgID)
+	receiptTID := bridgedaml.DepositReceiptTemplate.ID(identityPkgID)
 
 	check := func(tid *lapiv2.Identifier) (bool, error) {
 		var events []*lapiv2.CreatedEvent
@@ -198,30 +191,15 @@ func (c *Client) CreatePendingDeposit(ctx context.Context, req CreatePendingDepo
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.cfg.PackageID,
-					ModuleName: c.cfg.Module,
-					EntityName: "WayfinderBridgeConfig",
-				},
+				TemplateId:     c.bridgeConfigTemplate().ID(c.cfg.PackageID),
 				ContractId:     configCID,
-				Choice:         "CreatePendingDeposit",
+				Choice:         bridgedaml.ChoiceCreatePendingDeposit,
 				ChoiceArgument: &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: encodeCreatePendingDepositArgs(req)}},
 			},
 		},
 	}
 
-	resp, err := c.ledger.Command().SubmitAndWaitForTransaction(
-		c.ledger.AuthContext(ctx),
-		&lapiv2.SubmitAndWaitForTransactionRequest{
-			Commands: &lapiv2.Commands{
-				SynchronizerId: c.cfg.DomainID,
-				CommandId:      uuid.NewString(),
-				UserId:         c.cfg.UserID,
-				ActAs:          []string{c.cfg.OperatorParty},
-				Commands:       []*lapiv2.Command{cmd},
-			},
-		},
-	)
+	resp, err := c.submit(ctx, DepositCommandID(req.EvmTxHash), cmd)
 	if err != nil {
 		return nil, fmt.Errorf("create pending deposit: %w", err)
 	}
@@ -234,7 +212,7 @@ func (c *Client) CreatePendingDeposit(ctx context.Context, req CreatePendingDepo
 		if created == nil || created.TemplateId == nil {
 			continue
 		}
-		if created.TemplateId.ModuleName == "Common.FingerprintAuth" && created.TemplateId.EntityName == "PendingDeposit" {
+		if bridgedaml.PendingDepositTemplate.Matches(created.TemplateId) {
 			return &PendingDeposit{
 				ContractID:  created.ContractId,
 				MappingCID:  m.ContractID,
@@ -260,30 +238,15 @@ func (c *Client) ProcessDepositAndMint(ctx context.Context, req ProcessDepositRe
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.cfg.PackageID,
-					ModuleName: c.cfg.Module,
-					EntityName: "WayfinderBridgeConfig",
-				},
+				TemplateId:     c.bridgeConfigTemplate().ID(c.cfg.PackageID),
 				ContractId:     configCID,
-				Choice:         "ProcessDepositAndMint",
+				Choice:         bridgedaml.ChoiceProcessDepositAndMint,
 				ChoiceArgument: &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: encodeProcessDepositAndMintArgs(req)}},
 			},
 		},
 	}
 
-	resp, err := c.ledger.Command().SubmitAndWaitForTransaction(
-		c.ledger.AuthContext(ctx),
-		&lapiv2.SubmitAndWaitForTransactionRequest{
-			Commands: &lapiv2.Commands{
-				SynchronizerId: c.cfg.DomainID,
-				CommandId:      uuid.NewString(),
-				UserId:         c.cfg.UserID,
-				ActAs:          []string{c.cfg.OperatorParty},
-				Commands:       []*lapiv2.Command{cmd},
-			},
-		},
-	)
+	resp, err := c.submit(ctx, mintCommandID(req.DepositCID), cmd)
 	if err != nil {
 		return nil, fmt.Errorf("process deposit and mint: %w", err)
 	}
@@ -312,9 +275,9 @@ func (c *Client) ProcessDepositAndMint(ctx context.Context, req ProcessDepositRe
 			zap.String("contract_id", created.ContractId))
 
 		switch {
-		case created.TemplateId.ModuleName == "CIP56.Token" && created.TemplateId.EntityName == "CIP56Holding":
+		case cip56.CIP56HoldingTemplate.Matches(created.TemplateId):
 			holdingCID = created.ContractId
-		case created.TemplateId.ModuleName == "CIP56.Events" && created.TemplateId.EntityName == "TokenTransferEvent":
+		case cip56.TokenTransferEventTemplate.Matches(created.TemplateId):
 			transferEventPackageID = created.TemplateId.PackageId
 		}
 	}
@@ -352,30 +315,15 @@ func (c *Client) InitiateWithdrawal(ctx context.Context, req InitiateWithdrawalR
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.cfg.PackageID,
-					ModuleName: c.cfg.Module,
-					EntityName: "WayfinderBridgeConfig",
-				},
+				TemplateId:     c.bridgeConfigTemplate().ID(c.cfg.PackageID),
 				ContractId:     configCID,
-				Choice:         "InitiateWithdrawal",
+				Choice:         bridgedaml.ChoiceInitiateWithdrawal,
 				ChoiceArgument: &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: encodeInitiateWithdrawalArgs(req)}},
 			},
 		},
 	}
 
-	resp, err := c.ledger.Command().SubmitAndWaitForTransaction(
-		c.ledger.AuthContext(ctx),
-		&lapiv2.SubmitAndWaitForTransactionRequest{
-			Commands: &lapiv2.Commands{
-				SynchronizerId: c.cfg.DomainID,
-				CommandId:      uuid.NewString(),
-				UserId:         c.cfg.UserID,
-				ActAs:          []string{c.cfg.OperatorParty},
-				Commands:       []*lapiv2.Command{cmd},
-			},
-		},
-	)
+	resp, err := c.submit(ctx, initiateWithdrawalCommandID(req.HoldingCID), cmd)
 	if err != nil {
 		return "", fmt.Errorf("initiate withdrawal: %w", err)
 	}
@@ -388,7 +336,7 @@ func (c *Client) InitiateWithdrawal(ctx context.Context, req InitiateWithdrawalR
 		if created == nil || created.TemplateId == nil {
 			continue
 		}
-		if created.TemplateId.ModuleName == bridgeContractsModule && created.TemplateId.EntityName == "WithdrawalRequest" {
+		if bridgedaml.WithdrawalRequestTemplate.Matches(created.TemplateId) {
 			return created.ContractId, nil
 		}
 	}
@@ -400,30 +348,15 @@ func (c *Client) ProcessWithdrawal(ctx context.Context, withdrawalRequestCID str
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.corePackageID(),
-					ModuleName: bridgeContractsModule,
-					EntityName: "WithdrawalRequest",
-				},
+				TemplateId:     bridgedaml.WithdrawalRequestTemplate.ID(c.corePackageID()),
 				ContractId:     withdrawalRequestCID,
-				Choice:         "ProcessWithdrawal",
+				Choice:         bridgedaml.ChoiceProcessWithdrawal,
 				ChoiceArgument: &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: encodeProcessWithdrawalArgs()}},
 			},
 		},
 	}
 
-	resp, err := c.ledger.Command().SubmitAndWaitForTransaction(
-		c.ledger.AuthContext(ctx),
-		&lapiv2.SubmitAndWaitForTransactionRequest{
-			Commands: &lapiv2.Commands{
-				SynchronizerId: c.cfg.DomainID,
-				CommandId:      uuid.NewString(),
-				UserId:         c.cfg.UserID,
-				ActAs:          []string{c.cfg.OperatorParty},
-				Commands:       []*lapiv2.Command{cmd},
-			},
-		},
-	)
+	resp, err := c.submit(ctx, processWithdrawalCommandID(withdrawalRequestCID), cmd)
 	if err != nil {
 		return "", fmt.Errorf("process withdrawal: %w", err)
 	}
@@ -436,7 +369,7 @@ func (c *Client) ProcessWithdrawal(ctx context.Context, withdrawalRequestCID str
 		if created == nil || created.TemplateId == nil {
 			continue
 		}
-		if created.TemplateId.ModuleName == bridgeContractsModule && created.TemplateId.EntityName == "WithdrawalEvent" {
+		if bridgedaml.WithdrawalEventTemplate.Matches(created.TemplateId) {
 			return created.ContractId, nil
 		}
 	}
@@ -452,32 +385,19 @@ func (c *Client) CompleteWithdrawal(ctx context.Context, req CompleteWithdrawalR
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.corePackageID(),
-					ModuleName: bridgeContractsModule,
-					EntityName: "WithdrawalEvent",
-				},
+				TemplateId:     bridgedaml.WithdrawalEventTemplate.ID(c.corePackageID()),
 				ContractId:     req.WithdrawalEventCID,
-				Choice:         "CompleteWithdrawal",
+				Choice:         bridgedaml.ChoiceCompleteWithdrawal,
 				ChoiceArgument: &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: encodeCompleteWithdrawalArgs(req.EvmTxHash)}},
 			},
 		},
 	}
 
-	_, err := c.ledger.Command().SubmitAndWait(
-		c.ledger.AuthContext(ctx),
-		&lapiv2.SubmitAndWaitRequest{
-			Commands: &lapiv2.Commands{
-				SynchronizerId: c.cfg.DomainID,
-				CommandId:      uuid.NewString(),
-				UserId:         c.cfg.UserID,
-				ActAs:          []string{c.cfg.OperatorParty},
-				Commands:       []*lapiv2.Command{cmd},
-			},
-		},
-	)
+	_, err := c.submit(ctx, completeWithdrawalCommandID(req.WithdrawalEventCID), cmd)
 	if err != nil {
-		if isAlreadyExistsError(err) { // TODO: verify it works
+		// The command ID is derived from the withdrawal event, so a duplicate
+		// means an earlier submission already completed it.
+		if isAlreadyExistsError(err) {
 			return nil
 		}
 		return fmt.Errorf("complete withdrawal: %w", err)
@@ -486,6 +406,36 @@ func (c *Client) CompleteWithdrawal(ctx context.Context, req CompleteWithdrawalR
 	return nil
 }
 
+// submit submits cmd as the operator under commandID, tracked so that
+// ResolveOutcome can tell what became of a submission that ended ambiguously.
+func (c *Client) submit(
+	ctx context.Context, commandID string, cmd *lapiv2.Command,
+) (*lapiv2.SubmitAndWaitForTransactionResponse, error) {
+	commands := &lapiv2.Commands{
+		SynchronizerId: c.cfg.DomainID,
+		CommandId:      commandID,
+		UserId:         c.cfg.UserID,
+		ActAs:          []string{c.cfg.OperatorParty},
+		Commands:       []*lapiv2.Command{cmd},
+	}
+	ctx, sub, err := c.ledger.TrackCommand(ctx, commands.UserId, commands.CommandId, commands.ActAs)
+	if err != nil {
+		return nil, err
+	}
+	sub.ApplyToCommands(commands)
+
+	resp, err := c.ledger.Command().SubmitAndWaitForTransaction(
+		c.ledger.AuthContext(ctx),
+		&lapiv2.SubmitAndWaitForTransactionRequest{Commands: commands},
+	)
+	c.ledger.SettleCommand(commands.CommandId, err)
+	return resp, err
+}
+
+func (c *Client) ResolveOutcome(ctx context.Context, commandID string) (*ledger.Outcome, error) {
+	return c.ledger.ResolveOutcome(ctx, commandID)
+}
+
 func (c *Client) StreamWithdrawalEvents(ctx context.Context, offset string) <-chan *WithdrawalEvent {
 	outCh := make(chan *WithdrawalEvent, withdrawalEventChannelCap)
 
@@ -560,11 +510,7 @@ func (c *Client) streamWithdrawalEventsOnce(ctx context.Context, offset string,
 							{
 								IdentifierFilter: &lapiv2.CumulativeFilter_TemplateFilter{
 									TemplateFilter: &lapiv2.TemplateFilter{
-										TemplateId: &lapiv2.Identifier{
-											PackageId:  c.corePackageID(),
-											ModuleName: bridgeContractsModule,
-											EntityName: "WithdrawalEvent",
-										},
+										TemplateId: bridgedaml.WithdrawalEventTemplate.ID(c.corePackageID()),
 									},
 								},
 							},
@@ -602,12 +548,18 @@ func (c *Client) streamWithdrawalEventsOnce(ctx context.Context, offset string,
 				continue
 			}
 
-			tid := created.TemplateId
-			if tid.ModuleName != bridgeContractsModule || tid.EntityName != "WithdrawalEvent" {
+			if !bridgedaml.WithdrawalEventTemplate.Matches(created.TemplateId) {
 				continue
 			}
 
-			we := decodeWithdrawalEvent(created, tx.UpdateId)
+			we, err := decodeWithdrawalEvent(created, tx.UpdateId)
+			if err != nil {
+				c.logger.Error("skipping undecodable WithdrawalEvent",
+					zap.String("contract_id", created.ContractId),
+					zap.Error(err))
+				*lastOffset = strconv.FormatInt(created.Offset, 10)
+				continue
+			}
 
 			if we.Status != WithdrawalStatusPending {
 				continue
@@ -650,6 +602,14 @@ func (c *Client) GetLatestLedgerOffset(ctx context.Context) (int64, error) {
 	return c.ledger.GetLedgerEnd(ctx)
 }
 
+// bridgeConfigTemplate returns the WayfinderBridgeConfig template under the
+// configured module name.
+func (c *Client) bridgeConfigTemplate() daml.Template {
+	t := bridgedaml.WayfinderBridgeConfigTemplate
+	t.Module = c.cfg.Module
+	return t
+}
+
 // corePackageID returns the bridge-core package ID for Bridge.Contracts templates
 // (WithdrawalRequest, WithdrawalEvent). CorePackageID is validated as required at
 // construction time, so this will never be empty in practice.
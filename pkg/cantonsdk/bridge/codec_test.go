@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// The expected records below are the hand-written encodings the generated
+// codecs replaced; the generated output must stay wire-identical to them.
+
+// stampedAt returns the "timestamp" field of rec, checking it was taken
+// between before and now.
+func stampedAt(t *testing.T, rec *lapiv2.Record, before time.Time) time.Time {
+	t.Helper()
+	ts, ok := values.TimestampOK(values.RecordToMap(rec)["timestamp"])
+	require.True(t, ok, "record has no timestamp field")
+	assert.False(t, ts.Before(before.Truncate(time.Microsecond)))
+	assert.False(t, ts.After(time.Now()))
+	return ts
+}
+
+func assertRecordEqual(t *testing.T, want, got *lapiv2.Record) {
+	t.Helper()
+	assert.Truef(t, proto.Equal(want, got), "want %v\ngot  %v", want, got)
+}
+
+func TestEncodeCreatePendingDepositArgs(t *testing.T) {
+	before := time.Now()
+	got := encodeCreatePendingDepositArgs(CreatePendingDepositRequest{
+		Fingerprint: "fp-1",
+		Amount:      "12.5",
+		EvmTxHash:   "0xabc",
+	})
+	ts := stampedAt(t, got, before)
+
+	assertRecordEqual(t, &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "fingerprint", Value: values.TextValue("fp-1")},
+			{Label: "amount", Value: values.NumericValue("12.5")},
+			{Label: "evmTxHash", Value: values.TextValue("0xabc")},
+			{Label: "timestamp", Value: values.TimestampValue(ts)},
+		},
+	}, got)
+}
+
+func TestEncodeProcessDepositAndMintArgs(t *testing.T) {
+	before := time.Now()
+	got := encodeProcessDepositAndMintArgs(ProcessDepositRequest{DepositCID: "dep-1", MappingCID: "map-1"})
+	ts := stampedAt(t, got, before)
+
+	assertRecordEqual(t, &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "depositCid", Value: values.ContractIDValue("dep-1")},
+			{Label: "mappingCid", Value: values.ContractIDValue("map-1")},
+			{Label: "timestamp", Value: values.TimestampValue(ts)},
+		},
+	}, got)
+}
+
+func TestEncodeInitiateWithdrawalArgs(t *testing.T) {
+	got := encodeInitiateWithdrawalArgs(InitiateWithdrawalRequest{
+		MappingCID:     "map-1",
+		HoldingCID:     "hold-1",
+		Amount:         "3",
+		EvmDestination: "0xdef",
+	})
+
+	assertRecordEqual(t, &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "mappingCid", Value: values.ContractIDValue("map-1")},
+			{Label: "holdingCid", Value: values.ContractIDValue("hold-1")},
+			{Label: "amount", Value: values.NumericValue("3")},
+			{Label: "evmDestination", Value: values.NewtypeValue(values.TextValue("0xdef"))},
+		},
+	}, got)
+}
+
+func TestEncodeWithdrawalChoiceArgs(t *testing.T) {
+	before := time.Now()
+	got := encodeProcessWithdrawalArgs()
+	ts := stampedAt(t, got, before)
+	assertRecordEqual(t, &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "timestamp", Value: values.TimestampValue(ts)},
+		},
+	}, got)
+
+	got = encodeCompleteWithdrawalArgs("0x123")
+	ts = stampedAt(t, got, before)
+	assertRecordEqual(t, &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "evmTxHash", Value: values.TextValue("0x123")},
+			{Label: "timestamp", Value: values.TimestampValue(ts)},
+		},
+	}, got)
+}
+
+func TestDecodeWithdrawalEvent(t *testing.T) {
+	withStatus := func(status *lapiv2.Value) *lapiv2.CreatedEvent {
+		fields := []*lapiv2.RecordField{
+			{Label: "issuer", Value: values.PartyValue("issuer::1")},
+			{Label: "userParty", Value: values.PartyValue("user::1")},
+			{Label: "evmDestination", Value: values.NewtypeValue(values.TextValue("0xdef"))},
+			{Label: "amount", Value: values.NumericValue("7.25")},
+			{Label: "fingerprint", Value: values.TextValue("fp-1")},
+		}
+		if status != nil {
+			fields = append(fields, &lapiv2.RecordField{Label: "status", Value: status})
+		}
+		return &lapiv2.CreatedEvent{
+			ContractId:      "wd-1",
+			Offset:          42,
+			NodeId:          3,
+			CreateArguments: &lapiv2.Record{Fields: fields},
+		}
+	}
+	variant := func(c string) *lapiv2.Value {
+		return &lapiv2.Value{Sum: &lapiv2.Value_Variant{Variant: &lapiv2.Variant{
+			Constructor: c,
+			Value:       &lapiv2.Value{Sum: &lapiv2.Value_Unit{}},
+		}}}
+	}
+
+	tests := []struct {
+		name   string
+		status *lapiv2.Value
+		want   WithdrawalStatus
+	}{
+		{"pending", variant("Pending"), WithdrawalStatusPending},
+		{"completed", variant("Completed"), WithdrawalStatusCompleted},
+		{"failed", variant("Failed"), WithdrawalStatusFailed},
+		{"absent status defaults to pending", nil, WithdrawalStatusPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeWithdrawalEvent(withStatus(tt.status), "tx-1")
+			require.NoError(t, err)
+			assert.Equal(t, &WithdrawalEvent{
+				ContractID:     "wd-1",
+				EventID:        "42-3",
+				TransactionID:  "tx-1",
+				Issuer:         "issuer::1",
+				UserParty:      "user::1",
+				EvmDestination: "0xdef",
+				Amount:         "7.25",
+				Fingerprint:    "fp-1",
+				Status:         tt.want,
+			}, got)
+		})
+	}
+
+	t.Run("unknown status is an error", func(t *testing.T) {
+		_, err := decodeWithdrawalEvent(withStatus(variant("Exploded")), "tx-1")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Bridge commands carry command IDs derived from the deposit or withdrawal
+// they act on, so a retried step is deduplicated by the participant and its
+// outcome can be looked up with ResolveOutcome.
+
+// DepositCommandID returns the command ID CreatePendingDeposit submits for the
+// EVM deposit with the given transaction hash.
+func DepositCommandID(evmTxHash string) string {
+	return commandID("create-pending-deposit", strings.ToLower(evmTxHash))
+}
+
+// mintCommandID returns the command ID of ProcessDepositAndMint for a
+// PendingDeposit contract.
+func mintCommandID(depositCID string) string {
+	return commandID("process-deposit-and-mint", depositCID)
+}
+
+// initiateWithdrawalCommandID returns the command ID of InitiateWithdrawal for
+// the holding being withdrawn.
+func initiateWithdrawalCommandID(holdingCID string) string {
+	return commandID("initiate-withdrawal", holdingCID)
+}
+
+// processWithdrawalCommandID returns the command ID of ProcessWithdrawal for a
+// WithdrawalRequest contract.
+func processWithdrawalCommandID(withdrawalRequestCID string) string {
+	return commandID("process-withdrawal", withdrawalRequestCID)
+}
+
+// completeWithdrawalCommandID returns the command ID of CompleteWithdrawal for
+// a WithdrawalEvent contract.
+func completeWithdrawalCommandID(withdrawalEventCID string) string {
+	return commandID("complete-withdrawal", withdrawalEventCID)
+}
+
+// commandID hashes key so the ID stays within the Ledger API's length and
+// character limits whatever the key.
+func commandID(step, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "bridge-" + step + "-" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandIDs(t *testing.T) {
+	const hash = "0xAbC123"
+	assert.Equal(t, DepositCommandID(hash), DepositCommandID("0xabc123"), "tx hash case must not matter")
+	assert.NotEqual(t, DepositCommandID(hash), DepositCommandID("0xabc124"))
+
+	// The same contract ID under different steps must not collide.
+	ids := map[string]bool{
+		mintCommandID("cid"):               true,
+		initiateWithdrawalCommandID("cid"): true,
+		processWithdrawalCommandID("cid"):  true,
+		completeWithdrawalCommandID("cid"): true,
+	}
+	assert.Len(t, ids, 4)
+
+	long := make([]byte, 1024)
+	for i := range long {
+		long[i] = 'f'
+	}
+	assert.Regexp(t, `^bridge-process-withdrawal-[0-9a-f]{64}$`, processWithdrawalCommandID(string(long)))
+}
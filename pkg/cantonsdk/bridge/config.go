@@ -2,7 +2,12 @@
 
 package bridge
 
-import "fmt"
+import (
+	"fmt"
+
+	bridgedaml "github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/bridge"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
+)
 
 // Config contains bridge-client configuration.
 type Config struct {
@@ -13,14 +18,24 @@ type Config struct {
 	// OperatorParty is the party that controls WayfinderBridgeConfig (the bridge operator).
 	OperatorParty string `yaml:"operator_party"`
 	// PackageID is the package id that contains Wayfinder.Bridge templates/choices.
-	PackageID string `yaml:"package_id" validate:"required"`
+	// Leave empty to discover it from the participant's vetted packages.
+	PackageID string `yaml:"package_id"`
 	// CorePackageID is the package id for Bridge.Contracts (bridge-core package).
 	// WithdrawalRequest and WithdrawalEvent live here, separate from PackageID.
-	CorePackageID string `yaml:"core_package_id" validate:"required"`
+	// Leave empty to discover it from the participant's vetted packages.
+	CorePackageID string `yaml:"core_package_id"`
 	// Module is the DAML module name that contains WayfinderBridgeConfig template.
 	Module string `yaml:"module" validate:"required"`
 }
 
+// PackageBindings ties the package ID settings to the Daml packages they name.
+func (c *Config) PackageBindings() []packages.Binding {
+	return []packages.Binding{
+		{Name: bridgedaml.BridgeWayfinderPackage.Name, Target: &c.PackageID},
+		{Name: bridgedaml.BridgeCorePackage.Name, Target: &c.CorePackageID},
+	}
+}
+
 // Validate validates config for bridge operations.
 func (c *Config) validate() error {
 	if c == nil {
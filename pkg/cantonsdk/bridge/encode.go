@@ -5,40 +5,34 @@ package bridge
 import (
 	"time"
 
+	bridgedaml "github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/bridge"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
-	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
 )
 
 func encodeCreatePendingDepositArgs(req CreatePendingDepositRequest) *lapiv2.Record {
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "fingerprint", Value: values.TextValue(req.Fingerprint)},
-			{Label: "amount", Value: values.NumericValue(req.Amount)},
-			{Label: "evmTxHash", Value: values.TextValue(req.EvmTxHash)},
-			{Label: "timestamp", Value: values.TimestampValue(time.Now())},
-		},
-	}
+	return bridgedaml.CreatePendingDeposit{
+		Fingerprint: req.Fingerprint,
+		Amount:      req.Amount,
+		EvmTxHash:   req.EvmTxHash,
+		Timestamp:   time.Now(),
+	}.ToRecord()
 }
 
 func encodeProcessDepositAndMintArgs(req ProcessDepositRequest) *lapiv2.Record {
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "depositCid", Value: values.ContractIDValue(req.DepositCID)},
-			{Label: "mappingCid", Value: values.ContractIDValue(req.MappingCID)},
-			{Label: "timestamp", Value: values.TimestampValue(time.Now())},
-		},
-	}
+	return bridgedaml.ProcessDepositAndMint{
+		DepositCID: req.DepositCID,
+		MappingCID: req.MappingCID,
+		Timestamp:  time.Now(),
+	}.ToRecord()
 }
 
 func encodeInitiateWithdrawalArgs(req InitiateWithdrawalRequest) *lapiv2.Record {
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "mappingCid", Value: values.ContractIDValue(req.MappingCID)},
-			{Label: "holdingCid", Value: values.ContractIDValue(req.HoldingCID)},
-			{Label: "amount", Value: values.NumericValue(req.Amount)},
-			{Label: "evmDestination", Value: values.NewtypeValue(values.TextValue(req.EvmDestination))},
-		},
-	}
+	return bridgedaml.InitiateWithdrawal{
+		MappingCID:     req.MappingCID,
+		HoldingCID:     req.HoldingCID,
+		Amount:         req.Amount,
+		EvmDestination: bridgedaml.EvmAddress{Value: req.EvmDestination},
+	}.ToRecord()
 }
 
 // encodeProcessWithdrawalArgs encodes the argument record for the
@@ -46,18 +40,12 @@ func encodeInitiateWithdrawalArgs(req InitiateWithdrawalRequest) *lapiv2.Record
 // DAML signature: ProcessWithdrawal : Time -> ContractId WithdrawalEvent
 // The choice takes a single `timestamp : Time` field.
 func encodeProcessWithdrawalArgs() *lapiv2.Record {
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "timestamp", Value: values.TimestampValue(time.Now())},
-		},
-	}
+	return bridgedaml.ProcessWithdrawal{Timestamp: time.Now()}.ToRecord()
 }
 
 func encodeCompleteWithdrawalArgs(evmTxHash string) *lapiv2.Record {
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "evmTxHash", Value: values.TextValue(evmTxHash)},
-			{Label: "timestamp", Value: values.TimestampValue(time.Now())},
-		},
-	}
+	return bridgedaml.CompleteWithdrawal{
+		EvmTxHash: evmTxHash,
+		Timestamp: time.Now(),
+	}.ToRecord()
 }
@@ -3,7 +3,8 @@
 // Package client provides the high-level Canton SDK client.
 //
 // It exposes a unified interface for interacting with a Canton ledger,
-// including identity, token, and optional bridge operations.
+// including identity, token, and optional bridge operations. Package IDs left
+// empty in the config are discovered from the participant at startup.
 package client
 
 import (
@@ -15,8 +16,10 @@ import (
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/bridge"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/identity"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
 
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
@@ -26,6 +29,7 @@ type Client struct {
 	Identity identity.Identity
 	Token    token.Token
 	Bridge   bridge.Bridge // optional; nil when disabled
+	Packages packages.Packages
 }
 
 // New creates an SDK client from SDK-native config.
@@ -33,7 +37,6 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Client, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("nil canton config")
 	}
-	_ = ctx // reserved for future (e.g. eager connectivity check)
 	s := applyOptions(opts)
 
 	propagateCommonConfig(cfg)
@@ -55,6 +58,12 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Client, error) {
 	if len(dialOpts) > 0 {
 		ledgerOpts = append(ledgerOpts, ledger.WithGRPCDialOptions(dialOpts...))
 	}
+	if s.promRegisterer != nil {
+		ledgerOpts = append(ledgerOpts, ledger.WithPrometheusRegisterer(s.promRegisterer))
+	}
+	if s.commandStore != nil {
+		ledgerOpts = append(ledgerOpts, ledger.WithCommandStore(s.commandStore))
+	}
 	l, err := ledger.New(cfg.Ledger, ledgerOpts...)
 	if err != nil {
 		return nil, err
@@ -70,6 +79,17 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Client, error) {
 		cfg.Token.UserID = sub
 	}
 
+	bridgeCfg := cfg.Bridge
+	if s.bridgeCfg != nil {
+		bridgeCfg = s.bridgeCfg
+	}
+
+	pk, err := bindPackages(ctx, cfg, bridgeCfg, l, s.logger)
+	if err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+
 	id, err := identity.New(cfg.Identity, l, identity.WithLogger(s.logger))
 	if err != nil {
 		_ = l.Close()
@@ -92,10 +112,6 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Client, error) {
 	}
 
 	var br bridge.Bridge
-	bridgeCfg := cfg.Bridge
-	if s.bridgeCfg != nil {
-		bridgeCfg = s.bridgeCfg
-	}
 	if bridgeCfg != nil {
 		bridgeCfg.UserID = cfg.Identity.UserID
 		br, err = bridge.New(bridgeCfg, l, id, bridge.WithLogger(s.logger))
@@ -110,9 +126,35 @@ func New(ctx context.Context, cfg *Config, opts ...Option) (*Client, error) {
 		Identity: id,
 		Token:    tk,
 		Bridge:   br,
+		Packages: pk,
 	}, nil
 }
 
+// bindPackages fills the package ID settings left empty in the sub-component
+// configs from the participant's vetted packages.
+func bindPackages(
+	ctx context.Context, cfg *Config, bridgeCfg *bridge.Config, l ledger.Ledger, logger *zap.Logger,
+) (*packages.Client, error) {
+	pk, err := packages.New(cfg.Packages, l, packages.WithLogger(logger))
+	if err != nil {
+		return nil, err
+	}
+	var bindings []packages.Binding
+	if cfg.Identity != nil {
+		bindings = append(bindings, cfg.Identity.PackageBindings()...)
+	}
+	if cfg.Token != nil {
+		bindings = append(bindings, cfg.Token.PackageBindings()...)
+	}
+	if bridgeCfg != nil {
+		bindings = append(bindings, bridgeCfg.PackageBindings()...)
+	}
+	if err := pk.Bind(ctx, bindings...); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
 // propagateCommonConfig copies top-level common fields (DomainID, IssuerParty) into
 // each sub-client config. Note that sub-config validation is intentionally two-phase:
 // the startup validator (called from LoadAPIServer/LoadRelayerServer) cannot validate
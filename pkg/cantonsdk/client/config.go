@@ -6,6 +6,7 @@ import (
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/bridge"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/identity"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
 )
 
@@ -20,4 +21,8 @@ type Config struct {
 	Identity *identity.Config `yaml:"identity" validate:"required"`
 	Token    *token.Config    `yaml:"token" validate:"required"`
 	Bridge   *bridge.Config   `yaml:"bridge" default:"-"` // nil = bridge disabled
+
+	// Packages bounds the Daml package versions discovered for the package ID
+	// settings left empty above. nil accepts any vetted version.
+	Packages *packages.Config `yaml:"packages" default:"-"`
 }
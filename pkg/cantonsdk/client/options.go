@@ -7,6 +7,7 @@ import (
 
 	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/bridge"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
 
 	"go.uber.org/zap"
@@ -23,6 +24,7 @@ type settings struct {
 	keyResolver    token.KeyResolver
 	dialOpts       []grpc.DialOption
 	promRegisterer sharedmetrics.NamespacedRegisterer
+	commandStore   ledger.CommandStore
 }
 
 // WithLogger sets a custom logger for the SDK client.
@@ -60,6 +62,12 @@ func WithPrometheusRegisterer(reg sharedmetrics.NamespacedRegisterer) Option {
 	return func(s *settings) { s.promRegisterer = reg }
 }
 
+// WithCommandStore persists the commands the ledger client tracks, so that
+// their outcome can be resolved after a restart. See ledger.WithCommandStore.
+func WithCommandStore(store ledger.CommandStore) Option {
+	return func(s *settings) { s.commandStore = store }
+}
+
 func applyOptions(opts []Option) settings {
 	s := settings{
 		logger:     zap.NewNop(),
@@ -0,0 +1,549 @@
+// Code generated by damlgen from bridge.yaml. DO NOT EDIT.
+
+package bridge
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// Daml packages providing the templates and interfaces below.
+var (
+	CommonPackage          = daml.Package{Name: "common"}
+	BridgeCorePackage      = daml.Package{Name: "bridge-core"}
+	BridgeWayfinderPackage = daml.Package{Name: "bridge-wayfinder"}
+)
+
+var (
+	// FingerprintMappingTemplate is the Common.FingerprintAuth:FingerprintMapping template.
+	FingerprintMappingTemplate = daml.Template{Package: "common", Module: "Common.FingerprintAuth", Entity: "FingerprintMapping"}
+	// PendingDepositTemplate is the Common.FingerprintAuth:PendingDeposit template.
+	PendingDepositTemplate = daml.Template{Package: "common", Module: "Common.FingerprintAuth", Entity: "PendingDeposit"}
+	// DepositReceiptTemplate is the Common.FingerprintAuth:DepositReceipt template.
+	DepositReceiptTemplate = daml.Template{Package: "common", Module: "Common.FingerprintAuth", Entity: "DepositReceipt"}
+	// WayfinderBridgeConfigTemplate is the Wayfinder.Bridge:WayfinderBridgeConfig
+	// template. Deployments may rename the module (bridge.Config.Module).
+	WayfinderBridgeConfigTemplate = daml.Template{Package: "bridge-wayfinder", Module: "Wayfinder.Bridge", Entity: "WayfinderBridgeConfig"}
+	// WithdrawalRequestTemplate is the Bridge.Contracts:WithdrawalRequest template.
+	WithdrawalRequestTemplate = daml.Template{Package: "bridge-core", Module: "Bridge.Contracts", Entity: "WithdrawalRequest"}
+	// WithdrawalEventTemplate is the Bridge.Contracts:WithdrawalEvent template.
+	WithdrawalEventTemplate = daml.Template{Package: "bridge-core", Module: "Bridge.Contracts", Entity: "WithdrawalEvent"}
+)
+
+// Choices exercised on the templates above.
+const (
+	ChoiceCreatePendingDeposit  = "CreatePendingDeposit"  // Wayfinder.Bridge:WayfinderBridgeConfig
+	ChoiceProcessDepositAndMint = "ProcessDepositAndMint" // Wayfinder.Bridge:WayfinderBridgeConfig
+	ChoiceInitiateWithdrawal    = "InitiateWithdrawal"    // Wayfinder.Bridge:WayfinderBridgeConfig
+	ChoiceProcessWithdrawal     = "ProcessWithdrawal"     // Bridge.Contracts:WithdrawalRequest
+	ChoiceCompleteWithdrawal    = "CompleteWithdrawal"    // Bridge.Contracts:WithdrawalEvent
+)
+
+// EvmAddress is the Daml newtype Common.Types:EvmAddress.
+type EvmAddress struct {
+	Value string
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r EvmAddress) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Value: values.TextValue(r.Value)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r EvmAddress) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *EvmAddress) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record, matching its field by
+// position. An empty record leaves r unchanged.
+func (r *EvmAddress) FromRecord(rec *lapiv2.Record) error {
+	if len(rec.GetFields()) == 0 {
+		return nil
+	}
+	v, err := daml.DecodeText(rec.Fields[0].Value)
+	if err != nil {
+		return daml.FieldError("EvmAddress", "value", err)
+	}
+	r.Value = v
+	return nil
+}
+
+// FingerprintMapping is the Daml record Common.FingerprintAuth:FingerprintMapping.
+type FingerprintMapping struct {
+	Issuer      string
+	UserParty   string
+	Fingerprint string
+	EvmAddress  *EvmAddress
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r FingerprintMapping) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "issuer", Value: values.PartyValue(r.Issuer)},
+			{Label: "userParty", Value: values.PartyValue(r.UserParty)},
+			{Label: "fingerprint", Value: values.TextValue(r.Fingerprint)},
+			{Label: "evmAddress", Value: daml.EncodeOptional(r.EvmAddress, EvmAddress.ToValue)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r FingerprintMapping) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *FingerprintMapping) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *FingerprintMapping) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *FingerprintMapping) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["issuer"]; ok {
+		if r.Issuer, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("FingerprintMapping", "issuer", err)
+		}
+	}
+	if v, ok := fields["userParty"]; ok {
+		if r.UserParty, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("FingerprintMapping", "userParty", err)
+		}
+	}
+	if v, ok := fields["fingerprint"]; ok {
+		if r.Fingerprint, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("FingerprintMapping", "fingerprint", err)
+		}
+	}
+	if v, ok := fields["evmAddress"]; ok {
+		if r.EvmAddress, err = daml.DecodeOptional(v, daml.Decode[EvmAddress]); err != nil {
+			return daml.FieldError("FingerprintMapping", "evmAddress", err)
+		}
+	}
+	return nil
+}
+
+// CreatePendingDeposit is the Daml record Wayfinder.Bridge:CreatePendingDeposit.
+type CreatePendingDeposit struct {
+	Fingerprint string
+	Amount      string
+	EvmTxHash   string
+	Timestamp   time.Time
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r CreatePendingDeposit) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "fingerprint", Value: values.TextValue(r.Fingerprint)},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "evmTxHash", Value: values.TextValue(r.EvmTxHash)},
+			{Label: "timestamp", Value: values.TimestampValue(r.Timestamp)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r CreatePendingDeposit) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *CreatePendingDeposit) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *CreatePendingDeposit) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *CreatePendingDeposit) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["fingerprint"]; ok {
+		if r.Fingerprint, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("CreatePendingDeposit", "fingerprint", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("CreatePendingDeposit", "amount", err)
+		}
+	}
+	if v, ok := fields["evmTxHash"]; ok {
+		if r.EvmTxHash, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("CreatePendingDeposit", "evmTxHash", err)
+		}
+	}
+	if v, ok := fields["timestamp"]; ok {
+		if r.Timestamp, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("CreatePendingDeposit", "timestamp", err)
+		}
+	}
+	return nil
+}
+
+// ProcessDepositAndMint is the Daml record Wayfinder.Bridge:ProcessDepositAndMint.
+type ProcessDepositAndMint struct {
+	DepositCID string
+	MappingCID string
+	Timestamp  time.Time
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r ProcessDepositAndMint) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "depositCid", Value: values.ContractIDValue(r.DepositCID)},
+			{Label: "mappingCid", Value: values.ContractIDValue(r.MappingCID)},
+			{Label: "timestamp", Value: values.TimestampValue(r.Timestamp)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r ProcessDepositAndMint) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *ProcessDepositAndMint) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *ProcessDepositAndMint) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *ProcessDepositAndMint) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["depositCid"]; ok {
+		if r.DepositCID, err = daml.DecodeContractID(v); err != nil {
+			return daml.FieldError("ProcessDepositAndMint", "depositCid", err)
+		}
+	}
+	if v, ok := fields["mappingCid"]; ok {
+		if r.MappingCID, err = daml.DecodeContractID(v); err != nil {
+			return daml.FieldError("ProcessDepositAndMint", "mappingCid", err)
+		}
+	}
+	if v, ok := fields["timestamp"]; ok {
+		if r.Timestamp, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("ProcessDepositAndMint", "timestamp", err)
+		}
+	}
+	return nil
+}
+
+// InitiateWithdrawal is the Daml record Wayfinder.Bridge:InitiateWithdrawal.
+type InitiateWithdrawal struct {
+	MappingCID     string
+	HoldingCID     string
+	Amount         string
+	EvmDestination EvmAddress
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r InitiateWithdrawal) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "mappingCid", Value: values.ContractIDValue(r.MappingCID)},
+			{Label: "holdingCid", Value: values.ContractIDValue(r.HoldingCID)},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "evmDestination", Value: r.EvmDestination.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r InitiateWithdrawal) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *InitiateWithdrawal) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *InitiateWithdrawal) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *InitiateWithdrawal) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["mappingCid"]; ok {
+		if r.MappingCID, err = daml.DecodeContractID(v); err != nil {
+			return daml.FieldError("InitiateWithdrawal", "mappingCid", err)
+		}
+	}
+	if v, ok := fields["holdingCid"]; ok {
+		if r.HoldingCID, err = daml.DecodeContractID(v); err != nil {
+			return daml.FieldError("InitiateWithdrawal", "holdingCid", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("InitiateWithdrawal", "amount", err)
+		}
+	}
+	if v, ok := fields["evmDestination"]; ok {
+		if r.EvmDestination, err = daml.Decode[EvmAddress](v); err != nil {
+			return daml.FieldError("InitiateWithdrawal", "evmDestination", err)
+		}
+	}
+	return nil
+}
+
+// ProcessWithdrawal is the Daml record Bridge.Contracts:ProcessWithdrawal.
+type ProcessWithdrawal struct {
+	Timestamp time.Time
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r ProcessWithdrawal) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "timestamp", Value: values.TimestampValue(r.Timestamp)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r ProcessWithdrawal) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *ProcessWithdrawal) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *ProcessWithdrawal) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *ProcessWithdrawal) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["timestamp"]; ok {
+		if r.Timestamp, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("ProcessWithdrawal", "timestamp", err)
+		}
+	}
+	return nil
+}
+
+// CompleteWithdrawal is the Daml record Bridge.Contracts:CompleteWithdrawal.
+type CompleteWithdrawal struct {
+	EvmTxHash string
+	Timestamp time.Time
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r CompleteWithdrawal) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "evmTxHash", Value: values.TextValue(r.EvmTxHash)},
+			{Label: "timestamp", Value: values.TimestampValue(r.Timestamp)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r CompleteWithdrawal) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *CompleteWithdrawal) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *CompleteWithdrawal) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *CompleteWithdrawal) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["evmTxHash"]; ok {
+		if r.EvmTxHash, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("CompleteWithdrawal", "evmTxHash", err)
+		}
+	}
+	if v, ok := fields["timestamp"]; ok {
+		if r.Timestamp, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("CompleteWithdrawal", "timestamp", err)
+		}
+	}
+	return nil
+}
+
+// WithdrawalEvent is the Daml record Bridge.Contracts:WithdrawalEvent.
+type WithdrawalEvent struct {
+	Issuer         string
+	UserParty      string
+	EvmDestination EvmAddress
+	Amount         string
+	Fingerprint    string
+	Status         WithdrawalStatus
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r WithdrawalEvent) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "issuer", Value: values.PartyValue(r.Issuer)},
+			{Label: "userParty", Value: values.PartyValue(r.UserParty)},
+			{Label: "evmDestination", Value: r.EvmDestination.ToValue()},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "fingerprint", Value: values.TextValue(r.Fingerprint)},
+			{Label: "status", Value: r.Status.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r WithdrawalEvent) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *WithdrawalEvent) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *WithdrawalEvent) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *WithdrawalEvent) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["issuer"]; ok {
+		if r.Issuer, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("WithdrawalEvent", "issuer", err)
+		}
+	}
+	if v, ok := fields["userParty"]; ok {
+		if r.UserParty, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("WithdrawalEvent", "userParty", err)
+		}
+	}
+	if v, ok := fields["evmDestination"]; ok {
+		if r.EvmDestination, err = daml.Decode[EvmAddress](v); err != nil {
+			return daml.FieldError("WithdrawalEvent", "evmDestination", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("WithdrawalEvent", "amount", err)
+		}
+	}
+	if v, ok := fields["fingerprint"]; ok {
+		if r.Fingerprint, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("WithdrawalEvent", "fingerprint", err)
+		}
+	}
+	if v, ok := fields["status"]; ok {
+		if r.Status, err = daml.Decode[WithdrawalStatus](v); err != nil {
+			return daml.FieldError("WithdrawalEvent", "status", err)
+		}
+	}
+	return nil
+}
+
+// WithdrawalStatus is the Daml variant Bridge.Contracts:WithdrawalStatus.
+type WithdrawalStatus string
+
+// WithdrawalStatus constructors.
+const (
+	WithdrawalStatusPending   WithdrawalStatus = "Pending"
+	WithdrawalStatusCompleted WithdrawalStatus = "Completed"
+	WithdrawalStatusFailed    WithdrawalStatus = "Failed"
+)
+
+// ToValue encodes v as a Ledger API variant value.
+func (v WithdrawalStatus) ToValue() *lapiv2.Value {
+	return daml.VariantValue(string(v), daml.UnitValue())
+}
+
+// FromValue decodes v from a Ledger API variant or enum value.
+func (v *WithdrawalStatus) FromValue(val *lapiv2.Value) error {
+	c, err := daml.DecodeConstructor(val)
+	if err != nil {
+		return err
+	}
+	switch WithdrawalStatus(c) {
+	case WithdrawalStatusPending, WithdrawalStatusCompleted, WithdrawalStatusFailed:
+		*v = WithdrawalStatus(c)
+		return nil
+	}
+	return fmt.Errorf("unknown WithdrawalStatus constructor %q", c)
+}
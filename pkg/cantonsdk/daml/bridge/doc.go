@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bridge holds the generated codecs for the Wayfinder bridge
+// templates: the FingerprintAuth contracts from the common package, the
+// withdrawal contracts from bridge-core and WayfinderBridgeConfig.
+package bridge
+
+//go:generate go run ../../../../cmd/damlgen -schema bridge.yaml
@@ -0,0 +1,340 @@
+// Code generated by damlgen from cip56.yaml. DO NOT EDIT.
+
+package cip56
+
+import (
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/splice"
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// Daml packages providing the templates and interfaces below.
+var (
+	CIP56TokenPackage = daml.Package{Name: "cip56-token"}
+)
+
+var (
+	// TokenConfigTemplate is the CIP56.Config:TokenConfig template.
+	TokenConfigTemplate = daml.Template{Package: "cip56-token", Module: "CIP56.Config", Entity: "TokenConfig"}
+	// CIP56HoldingTemplate is the CIP56.Token:CIP56Holding template.
+	CIP56HoldingTemplate = daml.Template{Package: "cip56-token", Module: "CIP56.Token", Entity: "CIP56Holding"}
+	// TransferFactoryTemplate is the CIP56.TransferFactory:CIP56TransferFactory template.
+	TransferFactoryTemplate = daml.Template{Package: "cip56-token", Module: "CIP56.TransferFactory", Entity: "CIP56TransferFactory"}
+	// TokenTransferEventTemplate is the CIP56.Events:TokenTransferEvent template.
+	TokenTransferEventTemplate = daml.Template{Package: "cip56-token", Module: "CIP56.Events", Entity: "TokenTransferEvent"}
+)
+
+// Choices exercised on the templates above.
+const (
+	ChoiceIssuerMint = "IssuerMint" // CIP56.Config:TokenConfig
+	ChoiceIssuerBurn = "IssuerBurn" // CIP56.Config:TokenConfig
+)
+
+// IssuerMint is the Daml record CIP56.Config:IssuerMint.
+type IssuerMint struct {
+	Recipient string
+	Amount    string
+	EventTime time.Time
+	EventMeta *splice.Metadata
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r IssuerMint) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "recipient", Value: values.PartyValue(r.Recipient)},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "eventTime", Value: values.TimestampValue(r.EventTime)},
+			{Label: "eventMeta", Value: daml.EncodeOptional(r.EventMeta, splice.Metadata.ToValue)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r IssuerMint) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *IssuerMint) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *IssuerMint) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *IssuerMint) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["recipient"]; ok {
+		if r.Recipient, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("IssuerMint", "recipient", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("IssuerMint", "amount", err)
+		}
+	}
+	if v, ok := fields["eventTime"]; ok {
+		if r.EventTime, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("IssuerMint", "eventTime", err)
+		}
+	}
+	if v, ok := fields["eventMeta"]; ok {
+		if r.EventMeta, err = daml.DecodeOptional(v, daml.Decode[splice.Metadata]); err != nil {
+			return daml.FieldError("IssuerMint", "eventMeta", err)
+		}
+	}
+	return nil
+}
+
+// IssuerBurn is the Daml record CIP56.Config:IssuerBurn.
+type IssuerBurn struct {
+	HoldingCID string
+	Amount     string
+	EventTime  time.Time
+	EventMeta  *splice.Metadata
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r IssuerBurn) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "holdingCid", Value: values.ContractIDValue(r.HoldingCID)},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "eventTime", Value: values.TimestampValue(r.EventTime)},
+			{Label: "eventMeta", Value: daml.EncodeOptional(r.EventMeta, splice.Metadata.ToValue)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r IssuerBurn) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *IssuerBurn) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *IssuerBurn) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *IssuerBurn) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["holdingCid"]; ok {
+		if r.HoldingCID, err = daml.DecodeContractID(v); err != nil {
+			return daml.FieldError("IssuerBurn", "holdingCid", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("IssuerBurn", "amount", err)
+		}
+	}
+	if v, ok := fields["eventTime"]; ok {
+		if r.EventTime, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("IssuerBurn", "eventTime", err)
+		}
+	}
+	if v, ok := fields["eventMeta"]; ok {
+		if r.EventMeta, err = daml.DecodeOptional(v, daml.Decode[splice.Metadata]); err != nil {
+			return daml.FieldError("IssuerBurn", "eventMeta", err)
+		}
+	}
+	return nil
+}
+
+// CIP56Holding is the Daml record CIP56.Token:CIP56Holding.
+type CIP56Holding struct {
+	Issuer       string
+	Owner        string
+	InstrumentID splice.InstrumentId
+	Amount       string
+	Meta         splice.Metadata
+	Lock         *lapiv2.Value
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r CIP56Holding) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "issuer", Value: values.PartyValue(r.Issuer)},
+			{Label: "owner", Value: values.PartyValue(r.Owner)},
+			{Label: "instrumentId", Value: r.InstrumentID.ToValue()},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "meta", Value: r.Meta.ToValue()},
+			{Label: "lock", Value: daml.EncodeOptionalRaw(r.Lock)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r CIP56Holding) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *CIP56Holding) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *CIP56Holding) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *CIP56Holding) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["issuer"]; ok {
+		if r.Issuer, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("CIP56Holding", "issuer", err)
+		}
+	}
+	if v, ok := fields["owner"]; ok {
+		if r.Owner, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("CIP56Holding", "owner", err)
+		}
+	}
+	if v, ok := fields["instrumentId"]; ok {
+		if r.InstrumentID, err = daml.Decode[splice.InstrumentId](v); err != nil {
+			return daml.FieldError("CIP56Holding", "instrumentId", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("CIP56Holding", "amount", err)
+		}
+	}
+	if v, ok := fields["meta"]; ok {
+		if r.Meta, err = daml.Decode[splice.Metadata](v); err != nil {
+			return daml.FieldError("CIP56Holding", "meta", err)
+		}
+	}
+	if v, ok := fields["lock"]; ok {
+		if r.Lock, err = daml.DecodeOptionalRaw(v); err != nil {
+			return daml.FieldError("CIP56Holding", "lock", err)
+		}
+	}
+	return nil
+}
+
+// TokenTransferEvent is the Daml record CIP56.Events:TokenTransferEvent.
+type TokenTransferEvent struct {
+	Issuer         string
+	FromParty      *string
+	ToParty        *string
+	Amount         string
+	InstrumentID   splice.InstrumentId
+	Timestamp      time.Time
+	Meta           *splice.Metadata
+	AuditObservers []string
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r TokenTransferEvent) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "issuer", Value: values.PartyValue(r.Issuer)},
+			{Label: "fromParty", Value: daml.EncodeOptional(r.FromParty, values.PartyValue)},
+			{Label: "toParty", Value: daml.EncodeOptional(r.ToParty, values.PartyValue)},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "instrumentId", Value: r.InstrumentID.ToValue()},
+			{Label: "timestamp", Value: values.TimestampValue(r.Timestamp)},
+			{Label: "meta", Value: daml.EncodeOptional(r.Meta, splice.Metadata.ToValue)},
+			{Label: "auditObservers", Value: daml.EncodeList(r.AuditObservers, values.PartyValue)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r TokenTransferEvent) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *TokenTransferEvent) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *TokenTransferEvent) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *TokenTransferEvent) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["issuer"]; ok {
+		if r.Issuer, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("TokenTransferEvent", "issuer", err)
+		}
+	}
+	if v, ok := fields["fromParty"]; ok {
+		if r.FromParty, err = daml.DecodeOptional(v, daml.DecodeParty); err != nil {
+			return daml.FieldError("TokenTransferEvent", "fromParty", err)
+		}
+	}
+	if v, ok := fields["toParty"]; ok {
+		if r.ToParty, err = daml.DecodeOptional(v, daml.DecodeParty); err != nil {
+			return daml.FieldError("TokenTransferEvent", "toParty", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("TokenTransferEvent", "amount", err)
+		}
+	}
+	if v, ok := fields["instrumentId"]; ok {
+		if r.InstrumentID, err = daml.Decode[splice.InstrumentId](v); err != nil {
+			return daml.FieldError("TokenTransferEvent", "instrumentId", err)
+		}
+	}
+	if v, ok := fields["timestamp"]; ok {
+		if r.Timestamp, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("TokenTransferEvent", "timestamp", err)
+		}
+	}
+	if v, ok := fields["meta"]; ok {
+		if r.Meta, err = daml.DecodeOptional(v, daml.Decode[splice.Metadata]); err != nil {
+			return daml.FieldError("TokenTransferEvent", "meta", err)
+		}
+	}
+	if v, ok := fields["auditObservers"]; ok {
+		if r.AuditObservers, err = daml.DecodeList(v, daml.DecodeParty); err != nil {
+			return daml.FieldError("TokenTransferEvent", "auditObservers", err)
+		}
+	}
+	return nil
+}
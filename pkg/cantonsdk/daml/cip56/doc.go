@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cip56 holds the generated codecs for the CIP-56 token templates
+// (TokenConfig, CIP56Holding, CIP56TransferFactory, TokenTransferEvent).
+package cip56
+
+//go:generate go run ../../../../cmd/damlgen -schema cip56.yaml
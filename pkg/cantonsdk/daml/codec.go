@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package daml
+
+import (
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// RecordValue wraps rec as a Ledger API value.
+func RecordValue(rec *lapiv2.Record) *lapiv2.Value {
+	return &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: rec}}
+}
+
+// UnitValue returns the Daml unit value.
+func UnitValue() *lapiv2.Value {
+	return &lapiv2.Value{Sum: &lapiv2.Value_Unit{Unit: &emptypb.Empty{}}}
+}
+
+// VariantValue returns a variant value of constructor carrying inner.
+func VariantValue(constructor string, inner *lapiv2.Value) *lapiv2.Value {
+	return &lapiv2.Value{
+		Sum: &lapiv2.Value_Variant{
+			Variant: &lapiv2.Variant{Constructor: constructor, Value: inner},
+		},
+	}
+}
+
+// EnumValue returns an enum value of constructor.
+func EnumValue(constructor string) *lapiv2.Value {
+	return &lapiv2.Value{
+		Sum: &lapiv2.Value_Enum{
+			Enum: &lapiv2.Enum{Constructor: constructor},
+		},
+	}
+}
+
+// Raw returns v unchanged. It is the encoder for fields whose Daml type is
+// carried as an opaque *lapiv2.Value.
+func Raw(v *lapiv2.Value) *lapiv2.Value {
+	return v
+}
+
+// EncodeOptional encodes v as Some when non-nil and None otherwise.
+func EncodeOptional[T any](v *T, enc func(T) *lapiv2.Value) *lapiv2.Value {
+	if v == nil {
+		return values.None()
+	}
+	return values.Optional(enc(*v))
+}
+
+// EncodeOptionalRaw encodes an opaque optional value: nil is None.
+func EncodeOptionalRaw(v *lapiv2.Value) *lapiv2.Value {
+	if v == nil {
+		return values.None()
+	}
+	return values.Optional(v)
+}
+
+// EncodeList encodes vs as a Daml list.
+func EncodeList[T any](vs []T, enc func(T) *lapiv2.Value) *lapiv2.Value {
+	elems := make([]*lapiv2.Value, len(vs))
+	for i, v := range vs {
+		elems[i] = enc(v)
+	}
+	return values.ListValue(elems)
+}
+
+// EncodeTextMap encodes m as a Daml TextMap. Keys are sorted for deterministic
+// encoding.
+func EncodeTextMap[T any](m map[string]T, enc func(T) *lapiv2.Value) *lapiv2.Value {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]*lapiv2.TextMap_Entry, 0, len(m))
+	for _, k := range keys {
+		entries = append(entries, &lapiv2.TextMap_Entry{Key: k, Value: enc(m[k])})
+	}
+	return &lapiv2.Value{
+		Sum: &lapiv2.Value_TextMap{
+			TextMap: &lapiv2.TextMap{Entries: entries},
+		},
+	}
+}
+
+// DecodeText decodes a Daml Text value.
+func DecodeText(v *lapiv2.Value) (string, error) {
+	s, ok := values.TextOK(v)
+	if !ok {
+		return "", &TypeError{Want: "Text", Got: v}
+	}
+	return s, nil
+}
+
+// DecodeParty decodes a Daml Party value.
+func DecodeParty(v *lapiv2.Value) (string, error) {
+	s, ok := values.PartyOK(v)
+	if !ok {
+		return "", &TypeError{Want: "Party", Got: v}
+	}
+	return s, nil
+}
+
+// DecodeNumeric decodes a Daml Numeric value as its decimal string.
+func DecodeNumeric(v *lapiv2.Value) (string, error) {
+	s, ok := values.NumericOK(v)
+	if !ok {
+		return "", &TypeError{Want: "Numeric", Got: v}
+	}
+	return s, nil
+}
+
+// DecodeContractID decodes a Daml ContractId value.
+func DecodeContractID(v *lapiv2.Value) (string, error) {
+	c, ok := v.GetSum().(*lapiv2.Value_ContractId)
+	if !ok {
+		return "", &TypeError{Want: "ContractId", Got: v}
+	}
+	return c.ContractId, nil
+}
+
+// DecodeTime decodes a Daml Time value.
+func DecodeTime(v *lapiv2.Value) (time.Time, error) {
+	t, ok := values.TimestampOK(v)
+	if !ok {
+		return time.Time{}, &TypeError{Want: "Time", Got: v}
+	}
+	return t, nil
+}
+
+// DecodeInt64 decodes a Daml Int value.
+func DecodeInt64(v *lapiv2.Value) (int64, error) {
+	n, ok := v.GetSum().(*lapiv2.Value_Int64)
+	if !ok {
+		return 0, &TypeError{Want: "Int", Got: v}
+	}
+	return n.Int64, nil
+}
+
+// DecodeBool decodes a Daml Bool value.
+func DecodeBool(v *lapiv2.Value) (bool, error) {
+	b, ok := v.GetSum().(*lapiv2.Value_Bool)
+	if !ok {
+		return false, &TypeError{Want: "Bool", Got: v}
+	}
+	return b.Bool, nil
+}
+
+// DecodeRaw returns v unchanged. It is the decoder for fields whose Daml type
+// is carried as an opaque *lapiv2.Value.
+func DecodeRaw(v *lapiv2.Value) (*lapiv2.Value, error) {
+	return v, nil
+}
+
+// DecodeRecord returns the record carried by v.
+func DecodeRecord(v *lapiv2.Value) (*lapiv2.Record, error) {
+	r, ok := v.GetSum().(*lapiv2.Value_Record)
+	if !ok || r.Record == nil {
+		return nil, &TypeError{Want: "Record", Got: v}
+	}
+	return r.Record, nil
+}
+
+// DecodeConstructor returns the constructor of a variant or enum value. Unit
+// constructors may arrive as either, depending on how the Daml type is
+// declared, so both are accepted.
+func DecodeConstructor(v *lapiv2.Value) (string, error) {
+	switch s := v.GetSum().(type) {
+	case *lapiv2.Value_Variant:
+		if s.Variant != nil {
+			return s.Variant.Constructor, nil
+		}
+	case *lapiv2.Value_Enum:
+		if s.Enum != nil {
+			return s.Enum.Constructor, nil
+		}
+	}
+	return "", &TypeError{Want: "Variant", Got: v}
+}
+
+// Decode decodes v into a new generated value of type T.
+func Decode[T any, PT interface {
+	*T
+	Decoder
+}](v *lapiv2.Value) (T, error) {
+	var out T
+	err := PT(&out).FromValue(v)
+	return out, err
+}
+
+// DecodeOptional decodes a Daml Optional value: None is nil.
+func DecodeOptional[T any](v *lapiv2.Value, dec func(*lapiv2.Value) (T, error)) (*T, error) {
+	inner, err := optionalInner(v)
+	if err != nil || inner == nil {
+		return nil, err
+	}
+	out, err := dec(inner)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DecodeOptionalRaw decodes an opaque optional value: None is nil.
+func DecodeOptionalRaw(v *lapiv2.Value) (*lapiv2.Value, error) {
+	return optionalInner(v)
+}
+
+func optionalInner(v *lapiv2.Value) (*lapiv2.Value, error) {
+	o, ok := v.GetSum().(*lapiv2.Value_Optional)
+	if !ok {
+		return nil, &TypeError{Want: "Optional", Got: v}
+	}
+	return o.Optional.GetValue(), nil
+}
+
+// DecodeList decodes a Daml List value.
+func DecodeList[T any](v *lapiv2.Value, dec func(*lapiv2.Value) (T, error)) ([]T, error) {
+	l, ok := v.GetSum().(*lapiv2.Value_List)
+	if !ok {
+		return nil, &TypeError{Want: "List", Got: v}
+	}
+	out := make([]T, 0, len(l.List.GetElements()))
+	for _, e := range l.List.GetElements() {
+		d, err := dec(e)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// DecodeTextMap decodes a Daml TextMap value. A GenMap with Text keys (DA.Map)
+// is accepted too.
+func DecodeTextMap[T any](v *lapiv2.Value, dec func(*lapiv2.Value) (T, error)) (map[string]T, error) {
+	out := make(map[string]T)
+	switch m := v.GetSum().(type) {
+	case *lapiv2.Value_TextMap:
+		for _, e := range m.TextMap.GetEntries() {
+			d, err := dec(e.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			out[e.GetKey()] = d
+		}
+	case *lapiv2.Value_GenMap:
+		for _, e := range m.GenMap.GetEntries() {
+			k, err := DecodeText(e.GetKey())
+			if err != nil {
+				return nil, err
+			}
+			d, err := dec(e.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			out[k] = d
+		}
+	default:
+		return nil, &TypeError{Want: "TextMap", Got: v}
+	}
+	return out, nil
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package daml is the runtime for the Daml codecs generated by cmd/damlgen.
+//
+// Generated packages (cip56, splice, bridge, registry) declare one Go struct
+// per Daml record with ToValue/FromValue methods, plus Template identifiers and
+// choice-name constants. The helpers here are what that generated code calls;
+// they are exported so hand-written code can compose generated types with
+// values that have no generated counterpart.
+package daml
+
+import (
+	"fmt"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+)
+
+// Package identifies a Daml package by name. Version and ID are set when the
+// codec was generated from a DAR and are empty otherwise.
+type Package struct {
+	Name    string
+	Version string
+	ID      string
+}
+
+// Ref returns the package-name reference ("#name") that the Ledger API
+// resolves to the preferred vetted version of the package.
+func (p Package) Ref() string {
+	return "#" + p.Name
+}
+
+// Template names a Daml template or interface by module and entity, leaving
+// the package to be chosen at the call site. Package is the name of the Daml
+// package that declares it.
+type Template struct {
+	Package string
+	Module  string
+	Entity  string
+}
+
+// ID returns the Ledger API identifier of t within packageID. packageID may be
+// a package hash or a package-name reference ("#name").
+func (t Template) ID(packageID string) *lapiv2.Identifier {
+	return &lapiv2.Identifier{
+		PackageId:  packageID,
+		ModuleName: t.Module,
+		EntityName: t.Entity,
+	}
+}
+
+// Matches reports whether id names t, ignoring the package. Events carry the
+// resolved package hash even when the filter used a package-name reference, so
+// callers match by module and entity only.
+func (t Template) Matches(id *lapiv2.Identifier) bool {
+	return id != nil && id.ModuleName == t.Module && id.EntityName == t.Entity
+}
+
+// String returns t as "Module:Entity".
+func (t Template) String() string {
+	return t.Module + ":" + t.Entity
+}
+
+// Encoder is implemented by every generated type.
+type Encoder interface {
+	ToValue() *lapiv2.Value
+}
+
+// Decoder is implemented by a pointer to every generated type.
+type Decoder interface {
+	FromValue(v *lapiv2.Value) error
+}
+
+// FieldsDecoder is implemented by a pointer to every generated record with
+// labeled fields. It decodes from a record already split into labeled fields,
+// as held by streaming.LedgerEvent.
+type FieldsDecoder interface {
+	FromFields(fields map[string]*lapiv2.Value) error
+}
+
+// TypeError reports a Ledger API value whose kind does not match the Daml type
+// a codec expected.
+type TypeError struct {
+	Want string
+	Got  *lapiv2.Value
+}
+
+func (e *TypeError) Error() string {
+	if e.Got == nil || e.Got.Sum == nil {
+		return fmt.Sprintf("expected %s, got no value", e.Want)
+	}
+	return fmt.Sprintf("expected %s, got %T", e.Want, e.Got.Sum)
+}
+
+// FieldError wraps err with the record and field it was decoded from.
+func FieldError(record, field string, err error) error {
+	return fmt.Errorf("%s.%s: %w", record, field, err)
+}
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package registry holds the generated codecs for the Utility.Registry
+// templates used for externally issued tokens such as USDCx.
+package registry
+
+//go:generate go run ../../../../cmd/damlgen -schema registry.yaml
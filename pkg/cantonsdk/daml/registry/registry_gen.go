@@ -0,0 +1,241 @@
+// Code generated by damlgen from registry.yaml. DO NOT EDIT.
+
+package registry
+
+import (
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/splice"
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// Daml packages providing the templates and interfaces below.
+var (
+	UtilityRegistryPackage    = daml.Package{Name: "utility-registry-v0"}
+	UtilityRegistryAppPackage = daml.Package{Name: "utility-registry-app-v0"}
+)
+
+var (
+	// HoldingTemplate is the Utility.Registry.Holding.V0.Holding:Holding template.
+	HoldingTemplate = daml.Template{Package: "utility-registry-v0", Module: "Utility.Registry.Holding.V0.Holding", Entity: "Holding"}
+	// InstrumentConfigurationTemplate is the Utility.Registry.V0.Configuration.Instrument:InstrumentConfiguration template.
+	InstrumentConfigurationTemplate = daml.Template{Package: "utility-registry-v0", Module: "Utility.Registry.V0.Configuration.Instrument", Entity: "InstrumentConfiguration"}
+	// TransferRuleTemplate is the Utility.Registry.V0.Rule.Transfer:TransferRule template.
+	TransferRuleTemplate = daml.Template{Package: "utility-registry-v0", Module: "Utility.Registry.V0.Rule.Transfer", Entity: "TransferRule"}
+	// TransferOfferTemplate is the Utility.Registry.App.V0.Model.Transfer:TransferOffer template.
+	TransferOfferTemplate = daml.Template{Package: "utility-registry-app-v0", Module: "Utility.Registry.App.V0.Model.Transfer", Entity: "TransferOffer"}
+)
+
+// InstrumentIdentifier is the Daml record Utility.Registry.Holding.V0.Types:InstrumentIdentifier.
+type InstrumentIdentifier struct {
+	Source string
+	ID     string
+	Scheme string
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r InstrumentIdentifier) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "source", Value: values.PartyValue(r.Source)},
+			{Label: "id", Value: values.TextValue(r.ID)},
+			{Label: "scheme", Value: values.TextValue(r.Scheme)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r InstrumentIdentifier) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *InstrumentIdentifier) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *InstrumentIdentifier) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *InstrumentIdentifier) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["source"]; ok {
+		if r.Source, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("InstrumentIdentifier", "source", err)
+		}
+	}
+	if v, ok := fields["id"]; ok {
+		if r.ID, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("InstrumentIdentifier", "id", err)
+		}
+	}
+	if v, ok := fields["scheme"]; ok {
+		if r.Scheme, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("InstrumentIdentifier", "scheme", err)
+		}
+	}
+	return nil
+}
+
+// Holding is the Daml record Utility.Registry.Holding.V0.Holding:Holding.
+// The Splice HoldingV1 view derives instrumentId.admin from Registrar and
+// instrumentId.id from Instrument.ID.
+type Holding struct {
+	Operator   string
+	Provider   string
+	Registrar  string
+	Owner      string
+	Instrument InstrumentIdentifier
+	Label      string
+	Amount     string
+	Lock       *lapiv2.Value
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r Holding) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "operator", Value: values.PartyValue(r.Operator)},
+			{Label: "provider", Value: values.PartyValue(r.Provider)},
+			{Label: "registrar", Value: values.PartyValue(r.Registrar)},
+			{Label: "owner", Value: values.PartyValue(r.Owner)},
+			{Label: "instrument", Value: r.Instrument.ToValue()},
+			{Label: "label", Value: values.TextValue(r.Label)},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "lock", Value: daml.EncodeOptionalRaw(r.Lock)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r Holding) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *Holding) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *Holding) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *Holding) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["operator"]; ok {
+		if r.Operator, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("Holding", "operator", err)
+		}
+	}
+	if v, ok := fields["provider"]; ok {
+		if r.Provider, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("Holding", "provider", err)
+		}
+	}
+	if v, ok := fields["registrar"]; ok {
+		if r.Registrar, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("Holding", "registrar", err)
+		}
+	}
+	if v, ok := fields["owner"]; ok {
+		if r.Owner, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("Holding", "owner", err)
+		}
+	}
+	if v, ok := fields["instrument"]; ok {
+		if r.Instrument, err = daml.Decode[InstrumentIdentifier](v); err != nil {
+			return daml.FieldError("Holding", "instrument", err)
+		}
+	}
+	if v, ok := fields["label"]; ok {
+		if r.Label, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("Holding", "label", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("Holding", "amount", err)
+		}
+	}
+	if v, ok := fields["lock"]; ok {
+		if r.Lock, err = daml.DecodeOptionalRaw(v); err != nil {
+			return daml.FieldError("Holding", "lock", err)
+		}
+	}
+	return nil
+}
+
+// TransferOffer is the Daml record Utility.Registry.App.V0.Model.Transfer:TransferOffer.
+type TransferOffer struct {
+	Operator string
+	Provider string
+	Transfer splice.Transfer
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r TransferOffer) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "operator", Value: values.PartyValue(r.Operator)},
+			{Label: "provider", Value: values.PartyValue(r.Provider)},
+			{Label: "transfer", Value: r.Transfer.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r TransferOffer) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *TransferOffer) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *TransferOffer) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *TransferOffer) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["operator"]; ok {
+		if r.Operator, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("TransferOffer", "operator", err)
+		}
+	}
+	if v, ok := fields["provider"]; ok {
+		if r.Provider, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("TransferOffer", "provider", err)
+		}
+	}
+	if v, ok := fields["transfer"]; ok {
+		if r.Transfer, err = daml.Decode[splice.Transfer](v); err != nil {
+			return daml.FieldError("TransferOffer", "transfer", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package splice holds the generated codecs for the Splice token-standard API
+// records and interfaces (HoldingV1, MetadataV1, TransferInstructionV1).
+package splice
+
+//go:generate go run ../../../../cmd/damlgen -schema splice.yaml
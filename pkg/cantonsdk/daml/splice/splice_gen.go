@@ -0,0 +1,518 @@
+// Code generated by damlgen from splice.yaml. DO NOT EDIT.
+
+package splice
+
+import (
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// Daml packages providing the templates and interfaces below.
+var (
+	MetadataPackage            = daml.Package{Name: "splice-api-token-metadata-v1"}
+	HoldingPackage             = daml.Package{Name: "splice-api-token-holding-v1"}
+	TransferInstructionPackage = daml.Package{Name: "splice-api-token-transfer-instruction-v1"}
+)
+
+var (
+	// HoldingInterface is the Splice.Api.Token.HoldingV1:Holding interface.
+	HoldingInterface = daml.Template{Package: "splice-api-token-holding-v1", Module: "Splice.Api.Token.HoldingV1", Entity: "Holding"}
+	// TransferFactoryInterface is the Splice.Api.Token.TransferInstructionV1:TransferFactory interface.
+	TransferFactoryInterface = daml.Template{Package: "splice-api-token-transfer-instruction-v1", Module: "Splice.Api.Token.TransferInstructionV1", Entity: "TransferFactory"}
+	// TransferInstructionInterface is the Splice.Api.Token.TransferInstructionV1:TransferInstruction interface.
+	TransferInstructionInterface = daml.Template{Package: "splice-api-token-transfer-instruction-v1", Module: "Splice.Api.Token.TransferInstructionV1", Entity: "TransferInstruction"}
+)
+
+// Choices exercised on the interfaces above.
+const (
+	ChoiceTransferFactoryTransfer     = "TransferFactory_Transfer"     // Splice.Api.Token.TransferInstructionV1:TransferFactory
+	ChoiceTransferInstructionAccept   = "TransferInstruction_Accept"   // Splice.Api.Token.TransferInstructionV1:TransferInstruction
+	ChoiceTransferInstructionReject   = "TransferInstruction_Reject"   // Splice.Api.Token.TransferInstructionV1:TransferInstruction
+	ChoiceTransferInstructionWithdraw = "TransferInstruction_Withdraw" // Splice.Api.Token.TransferInstructionV1:TransferInstruction
+)
+
+// Metadata is the Daml record Splice.Api.Token.MetadataV1:Metadata.
+type Metadata struct {
+	Values map[string]string
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r Metadata) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "values", Value: daml.EncodeTextMap(r.Values, values.TextValue)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r Metadata) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *Metadata) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *Metadata) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *Metadata) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["values"]; ok {
+		if r.Values, err = daml.DecodeTextMap(v, daml.DecodeText); err != nil {
+			return daml.FieldError("Metadata", "values", err)
+		}
+	}
+	return nil
+}
+
+// ChoiceContext is the Daml record Splice.Api.Token.MetadataV1:ChoiceContext.
+// Values are AnyValue variants, carried opaquely.
+type ChoiceContext struct {
+	Values map[string]*lapiv2.Value
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r ChoiceContext) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "values", Value: daml.EncodeTextMap(r.Values, daml.Raw)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r ChoiceContext) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *ChoiceContext) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *ChoiceContext) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *ChoiceContext) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["values"]; ok {
+		if r.Values, err = daml.DecodeTextMap(v, daml.DecodeRaw); err != nil {
+			return daml.FieldError("ChoiceContext", "values", err)
+		}
+	}
+	return nil
+}
+
+// ExtraArgs is the Daml record Splice.Api.Token.MetadataV1:ExtraArgs.
+type ExtraArgs struct {
+	Context ChoiceContext
+	Meta    Metadata
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r ExtraArgs) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "context", Value: r.Context.ToValue()},
+			{Label: "meta", Value: r.Meta.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r ExtraArgs) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *ExtraArgs) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *ExtraArgs) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *ExtraArgs) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["context"]; ok {
+		if r.Context, err = daml.Decode[ChoiceContext](v); err != nil {
+			return daml.FieldError("ExtraArgs", "context", err)
+		}
+	}
+	if v, ok := fields["meta"]; ok {
+		if r.Meta, err = daml.Decode[Metadata](v); err != nil {
+			return daml.FieldError("ExtraArgs", "meta", err)
+		}
+	}
+	return nil
+}
+
+// InstrumentId is the Daml record Splice.Api.Token.HoldingV1:InstrumentId.
+type InstrumentId struct {
+	Admin string
+	ID    string
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r InstrumentId) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "admin", Value: values.PartyValue(r.Admin)},
+			{Label: "id", Value: values.TextValue(r.ID)},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r InstrumentId) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *InstrumentId) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *InstrumentId) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *InstrumentId) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["admin"]; ok {
+		if r.Admin, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("InstrumentId", "admin", err)
+		}
+	}
+	if v, ok := fields["id"]; ok {
+		if r.ID, err = daml.DecodeText(v); err != nil {
+			return daml.FieldError("InstrumentId", "id", err)
+		}
+	}
+	return nil
+}
+
+// Transfer is the Daml record Splice.Api.Token.TransferInstructionV1:Transfer.
+type Transfer struct {
+	Sender           string
+	Receiver         string
+	Amount           string
+	InstrumentID     InstrumentId
+	RequestedAt      time.Time
+	ExecuteBefore    time.Time
+	InputHoldingCIDs []string
+	Meta             Metadata
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r Transfer) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "sender", Value: values.PartyValue(r.Sender)},
+			{Label: "receiver", Value: values.PartyValue(r.Receiver)},
+			{Label: "amount", Value: values.NumericValue(r.Amount)},
+			{Label: "instrumentId", Value: r.InstrumentID.ToValue()},
+			{Label: "requestedAt", Value: values.TimestampValue(r.RequestedAt)},
+			{Label: "executeBefore", Value: values.TimestampValue(r.ExecuteBefore)},
+			{Label: "inputHoldingCids", Value: daml.EncodeList(r.InputHoldingCIDs, values.ContractIDValue)},
+			{Label: "meta", Value: r.Meta.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r Transfer) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *Transfer) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *Transfer) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *Transfer) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["sender"]; ok {
+		if r.Sender, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("Transfer", "sender", err)
+		}
+	}
+	if v, ok := fields["receiver"]; ok {
+		if r.Receiver, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("Transfer", "receiver", err)
+		}
+	}
+	if v, ok := fields["amount"]; ok {
+		if r.Amount, err = daml.DecodeNumeric(v); err != nil {
+			return daml.FieldError("Transfer", "amount", err)
+		}
+	}
+	if v, ok := fields["instrumentId"]; ok {
+		if r.InstrumentID, err = daml.Decode[InstrumentId](v); err != nil {
+			return daml.FieldError("Transfer", "instrumentId", err)
+		}
+	}
+	if v, ok := fields["requestedAt"]; ok {
+		if r.RequestedAt, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("Transfer", "requestedAt", err)
+		}
+	}
+	if v, ok := fields["executeBefore"]; ok {
+		if r.ExecuteBefore, err = daml.DecodeTime(v); err != nil {
+			return daml.FieldError("Transfer", "executeBefore", err)
+		}
+	}
+	if v, ok := fields["inputHoldingCids"]; ok {
+		if r.InputHoldingCIDs, err = daml.DecodeList(v, daml.DecodeContractID); err != nil {
+			return daml.FieldError("Transfer", "inputHoldingCids", err)
+		}
+	}
+	if v, ok := fields["meta"]; ok {
+		if r.Meta, err = daml.Decode[Metadata](v); err != nil {
+			return daml.FieldError("Transfer", "meta", err)
+		}
+	}
+	return nil
+}
+
+// TransferFactoryTransfer is the Daml record Splice.Api.Token.TransferInstructionV1:TransferFactory_Transfer.
+type TransferFactoryTransfer struct {
+	ExpectedAdmin string
+	Transfer      Transfer
+	ExtraArgs     ExtraArgs
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r TransferFactoryTransfer) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "expectedAdmin", Value: values.PartyValue(r.ExpectedAdmin)},
+			{Label: "transfer", Value: r.Transfer.ToValue()},
+			{Label: "extraArgs", Value: r.ExtraArgs.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r TransferFactoryTransfer) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *TransferFactoryTransfer) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *TransferFactoryTransfer) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *TransferFactoryTransfer) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["expectedAdmin"]; ok {
+		if r.ExpectedAdmin, err = daml.DecodeParty(v); err != nil {
+			return daml.FieldError("TransferFactoryTransfer", "expectedAdmin", err)
+		}
+	}
+	if v, ok := fields["transfer"]; ok {
+		if r.Transfer, err = daml.Decode[Transfer](v); err != nil {
+			return daml.FieldError("TransferFactoryTransfer", "transfer", err)
+		}
+	}
+	if v, ok := fields["extraArgs"]; ok {
+		if r.ExtraArgs, err = daml.Decode[ExtraArgs](v); err != nil {
+			return daml.FieldError("TransferFactoryTransfer", "extraArgs", err)
+		}
+	}
+	return nil
+}
+
+// TransferInstructionAccept is the Daml record Splice.Api.Token.TransferInstructionV1:TransferInstruction_Accept.
+type TransferInstructionAccept struct {
+	ExtraArgs ExtraArgs
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r TransferInstructionAccept) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "extraArgs", Value: r.ExtraArgs.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r TransferInstructionAccept) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *TransferInstructionAccept) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *TransferInstructionAccept) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *TransferInstructionAccept) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["extraArgs"]; ok {
+		if r.ExtraArgs, err = daml.Decode[ExtraArgs](v); err != nil {
+			return daml.FieldError("TransferInstructionAccept", "extraArgs", err)
+		}
+	}
+	return nil
+}
+
+// TransferInstructionReject is the Daml record Splice.Api.Token.TransferInstructionV1:TransferInstruction_Reject.
+type TransferInstructionReject struct {
+	ExtraArgs ExtraArgs
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r TransferInstructionReject) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "extraArgs", Value: r.ExtraArgs.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r TransferInstructionReject) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *TransferInstructionReject) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *TransferInstructionReject) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *TransferInstructionReject) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["extraArgs"]; ok {
+		if r.ExtraArgs, err = daml.Decode[ExtraArgs](v); err != nil {
+			return daml.FieldError("TransferInstructionReject", "extraArgs", err)
+		}
+	}
+	return nil
+}
+
+// TransferInstructionWithdraw is the Daml record Splice.Api.Token.TransferInstructionV1:TransferInstruction_Withdraw.
+type TransferInstructionWithdraw struct {
+	ExtraArgs ExtraArgs
+}
+
+// ToRecord encodes r as a Ledger API record.
+func (r TransferInstructionWithdraw) ToRecord() *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "extraArgs", Value: r.ExtraArgs.ToValue()},
+		},
+	}
+}
+
+// ToValue encodes r as a Ledger API record value.
+func (r TransferInstructionWithdraw) ToValue() *lapiv2.Value {
+	return daml.RecordValue(r.ToRecord())
+}
+
+// FromValue decodes r from a Ledger API record value.
+func (r *TransferInstructionWithdraw) FromValue(v *lapiv2.Value) error {
+	rec, err := daml.DecodeRecord(v)
+	if err != nil {
+		return err
+	}
+	return r.FromRecord(rec)
+}
+
+// FromRecord decodes r from a Ledger API record.
+func (r *TransferInstructionWithdraw) FromRecord(rec *lapiv2.Record) error {
+	return r.FromFields(values.RecordToMap(rec))
+}
+
+// FromFields decodes r from record fields keyed by label. Absent fields keep
+// their current value, since upgraded packages may append optional fields.
+func (r *TransferInstructionWithdraw) FromFields(fields map[string]*lapiv2.Value) error {
+	var err error
+	if v, ok := fields["extraArgs"]; ok {
+		if r.ExtraArgs, err = daml.Decode[ExtraArgs](v); err != nil {
+			return daml.FieldError("TransferInstructionWithdraw", "extraArgs", err)
+		}
+	}
+	return nil
+}
@@ -7,12 +7,13 @@ package identity
 import (
 	"context"
 	"fmt"
+	"iter"
 	"strings"
 
+	bridgedaml "github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/bridge"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
 	adminv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2/admin"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
-	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -33,7 +34,10 @@ type ExternalPartyKey interface {
 type Identity interface {
 	AllocateParty(ctx context.Context, hint string) (*Party, error)
 	AllocateExternalParty(ctx context.Context, hint string, spkiPublicKey []byte, signer ExternalPartyKey) (*Party, error)
-	ListParties(ctx context.Context) ([]*Party, error) // TODO: add iterator
+	ListParties(ctx context.Context) ([]*Party, error)
+	// Parties iterates over the parties known to the participant, fetching one
+	// page of listKnownPartiesPageSize at a time. An error ends the sequence.
+	Parties(ctx context.Context) iter.Seq2[*Party, error]
 	// PartyExists reports whether the party id is known to the participant's
 	// topology (which includes parties hosted on other participants connected
 	// to the same synchronizer).
@@ -227,31 +231,43 @@ func (c *Client) AllocateExternalPartyWithSignature(
 }
 
 func (c *Client) ListParties(ctx context.Context) ([]*Party, error) {
-	authCtx := c.ledger.AuthContext(ctx)
-
 	var out []*Party
-	pageToken := ""
-
-	for {
-		resp, err := c.ledger.PartyAdmin().ListKnownParties(authCtx, &adminv2.ListKnownPartiesRequest{
-			PageSize:  listKnownPartiesPageSize,
-			PageToken: pageToken,
-		})
+	for p, err := range c.Parties(ctx) {
 		if err != nil {
-			return nil, fmt.Errorf("error listing parties: %w", err)
+			return nil, err
 		}
+		out = append(out, p)
+	}
+	return out, nil
+}
 
-		for _, p := range resp.PartyDetails {
-			out = append(out, &Party{PartyID: p.Party, IsLocal: p.IsLocal})
-		}
+func (c *Client) Parties(ctx context.Context) iter.Seq2[*Party, error] {
+	return func(yield func(*Party, error) bool) {
+		authCtx := c.ledger.AuthContext(ctx)
+		pageToken := ""
+
+		for {
+			resp, err := c.ledger.PartyAdmin().ListKnownParties(authCtx, &adminv2.ListKnownPartiesRequest{
+				PageSize:  listKnownPartiesPageSize,
+				PageToken: pageToken,
+			})
+			if err != nil {
+				yield(nil, fmt.Errorf("error listing parties: %w", err))
+				return
+			}
+
+			for _, p := range resp.PartyDetails {
+				if !yield(&Party{PartyID: p.Party, IsLocal: p.IsLocal}, nil) {
+					return
+				}
+			}
 
-		if resp.NextPageToken == "" {
-			break
+			if resp.NextPageToken == "" {
+				return
+			}
+			pageToken = resp.NextPageToken
 		}
-		pageToken = resp.NextPageToken
 	}
-
-	return out, nil
 }
 
 // PartyExists reports whether the party id is known to the participant's
@@ -297,18 +313,11 @@ func (c *Client) CreateFingerprintMapping(ctx context.Context, req CreateFingerp
 	}
 
 	authCtx := c.ledger.AuthContext(ctx)
-	packageID := c.cfg.PackageID
-	module := "Common.FingerprintAuth"
-	entity := "FingerprintMapping"
 
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Create{
 			Create: &lapiv2.CreateCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  packageID,
-					ModuleName: module,
-					EntityName: entity,
-				},
+				TemplateId: bridgedaml.FingerprintMappingTemplate.ID(c.cfg.PackageID),
 				CreateArguments: encodeFingerprintMappingCreate(
 					c.cfg.IssuerParty,
 					req.UserParty,
@@ -336,8 +345,8 @@ func (c *Client) CreateFingerprintMapping(ctx context.Context, req CreateFingerp
 	if resp.Transaction != nil {
 		for _, e := range resp.Transaction.Events {
 			if created := e.GetCreated(); created != nil {
-				if created.TemplateId.ModuleName == module && created.TemplateId.EntityName == entity {
-					return fingerprintMappingFromCreateEvent(created), nil
+				if bridgedaml.FingerprintMappingTemplate.Matches(created.TemplateId) {
+					return fingerprintMappingFromCreateEvent(created)
 				}
 			}
 		}
@@ -357,23 +366,21 @@ func (c *Client) GetFingerprintMapping(ctx context.Context, fingerprint string)
 		return nil, fmt.Errorf("ledger is empty, no contracts exist")
 	}
 
-	tid := &lapiv2.Identifier{
-		PackageId:  c.cfg.PackageID,
-		ModuleName: "Common.FingerprintAuth",
-		EntityName: "FingerprintMapping",
-	}
-
-	events, err := c.ledger.GetActiveContractsByTemplate(ctx, end, []string{c.cfg.IssuerParty}, tid)
-	if err != nil {
-		return nil, err
-	}
+	tid := bridgedaml.FingerprintMappingTemplate.ID(c.cfg.PackageID)
 
 	// Return the last match (most recently created) to handle stale mappings
-	// from prior bootstrap runs on shared ledgers.
+	// from prior bootstrap runs on shared ledgers. The mappings are streamed so
+	// only the match is retained, however many parties are registered.
 	var latest *FingerprintMapping
-	for _, ce := range events {
-		m := fingerprintMappingFromCreateEvent(ce)
-		if m != nil && m.Fingerprint == fp {
+	for ce, err := range c.ledger.ActiveContractsByTemplate(ctx, end, []string{c.cfg.IssuerParty}, tid) {
+		if err != nil {
+			return nil, err
+		}
+		m, err := fingerprintMappingFromCreateEvent(ce)
+		if err != nil {
+			return nil, err
+		}
+		if m.Fingerprint == fp {
 			latest = m
 		}
 	}
@@ -384,17 +391,22 @@ func (c *Client) GetFingerprintMapping(ctx context.Context, fingerprint string)
 	return nil, fmt.Errorf("no FingerprintMapping found for fingerprint: %s", fp)
 }
 
-func fingerprintMappingFromCreateEvent(event *lapiv2.CreatedEvent) *FingerprintMapping {
-	fields := values.RecordToMap(event.CreateArguments)
-	mfp := normalizeFingerprint(values.Text(fields["fingerprint"]))
+func fingerprintMappingFromCreateEvent(event *lapiv2.CreatedEvent) (*FingerprintMapping, error) {
+	var args bridgedaml.FingerprintMapping
+	if err := args.FromRecord(event.CreateArguments); err != nil {
+		return nil, fmt.Errorf("decode FingerprintMapping %s: %w", event.ContractId, err)
+	}
 
-	return &FingerprintMapping{
+	m := &FingerprintMapping{
 		ContractID:  event.ContractId,
-		Issuer:      values.Party(fields["issuer"]),
-		UserParty:   values.Party(fields["userParty"]),
-		Fingerprint: mfp,
-		EvmAddress:  values.Text(fields["evmAddress"]),
+		Issuer:      args.Issuer,
+		UserParty:   args.UserParty,
+		Fingerprint: normalizeFingerprint(args.Fingerprint),
+	}
+	if args.EvmAddress != nil {
+		m.EvmAddress = args.EvmAddress.Value
 	}
+	return m, nil
 }
 
 func (c *Client) GrantActAsParty(ctx context.Context, partyID string) error {
@@ -2,14 +2,24 @@
 
 package identity
 
-import "errors"
+import (
+	"errors"
+
+	bridgedaml "github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/bridge"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
+)
 
 // Config contains the configuration required to initialize the identity client.
 type Config struct {
 	DomainID    string `yaml:"domain_id"`
 	IssuerParty string `yaml:"issuer_party"`
 	UserID      string `yaml:"user_id"`
-	PackageID   string `yaml:"package_id" validate:"required"` // package ID for FingerprintMapping (Common.FingerprintAuth)
+	PackageID   string `yaml:"package_id"` // package ID for FingerprintMapping (Common.FingerprintAuth); empty = discover
+}
+
+// PackageBindings ties PackageID to the Daml package it names.
+func (c *Config) PackageBindings() []packages.Binding {
+	return []packages.Binding{{Name: bridgedaml.CommonPackage.Name, Target: &c.PackageID}}
 }
 
 func (c *Config) validate() error {
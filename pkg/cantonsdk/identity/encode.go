@@ -3,33 +3,18 @@
 package identity
 
 import (
+	bridgedaml "github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/bridge"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
-	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
 )
 
 func encodeFingerprintMappingCreate(issuer, userParty, fingerprint, evmAddress string) *lapiv2.Record {
-	evmVal := values.None()
-	if evmAddress != "" {
-		evmVal = values.Optional(RecordValue(values.TextValue(evmAddress)))
-	}
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "issuer", Value: values.PartyValue(issuer)},
-			{Label: "userParty", Value: values.PartyValue(userParty)},
-			{Label: "fingerprint", Value: values.TextValue(fingerprint)},
-			{Label: "evmAddress", Value: evmVal},
-		},
-	}
-}
-
-func RecordValue(fields ...*lapiv2.Value) *lapiv2.Value {
-	recordFields := make([]*lapiv2.RecordField, len(fields))
-	for i, f := range fields {
-		recordFields[i] = &lapiv2.RecordField{Value: f}
+	args := bridgedaml.FingerprintMapping{
+		Issuer:      issuer,
+		UserParty:   userParty,
+		Fingerprint: fingerprint,
 	}
-	return &lapiv2.Value{
-		Sum: &lapiv2.Value_Record{
-			Record: &lapiv2.Record{Fields: recordFields},
-		},
+	if evmAddress != "" {
+		args.EvmAddress = &bridgedaml.EvmAddress{Value: evmAddress}
 	}
+	return args.ToRecord()
 }
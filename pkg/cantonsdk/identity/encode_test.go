@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// legacyFingerprintMapping is the hand-written encoding the generated codec
+// replaced; the generated output must stay wire-identical to it.
+func legacyFingerprintMapping(evmAddress *lapiv2.Value) *lapiv2.Record {
+	return &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "issuer", Value: values.PartyValue("issuer::1")},
+			{Label: "userParty", Value: values.PartyValue("alice::1")},
+			{Label: "fingerprint", Value: values.TextValue("1220abcd")},
+			{Label: "evmAddress", Value: evmAddress},
+		},
+	}
+}
+
+func TestFingerprintMappingCodec(t *testing.T) {
+	tests := []struct {
+		name       string
+		evmAddress string
+		wire       *lapiv2.Value
+	}{
+		{"with evm address", "0xdef", values.Optional(values.NewtypeValue(values.TextValue("0xdef")))},
+		{"without evm address", "", values.None()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := legacyFingerprintMapping(tt.wire)
+			got := encodeFingerprintMappingCreate("issuer::1", "alice::1", "1220abcd", tt.evmAddress)
+			assert.Truef(t, proto.Equal(want, got), "want %v\ngot  %v", want, got)
+
+			m, err := fingerprintMappingFromCreateEvent(&lapiv2.CreatedEvent{ContractId: "fm-1", CreateArguments: want})
+			require.NoError(t, err)
+			assert.Equal(t, &FingerprintMapping{
+				ContractID:  "fm-1",
+				Issuer:      "issuer::1",
+				UserParty:   "alice::1",
+				Fingerprint: normalizeFingerprint("1220abcd"),
+				EvmAddress:  tt.evmAddress,
+			}, m)
+		})
+	}
+}
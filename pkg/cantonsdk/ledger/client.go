@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"strings"
 	"sync"
 	"time"
@@ -50,6 +51,12 @@ type Ledger interface {
 	// Update returns the Ledger API UpdateService client.
 	Update() lapiv2.UpdateServiceClient
 
+	// CommandCompletion returns the Ledger API CommandCompletionService client.
+	CommandCompletion() lapiv2.CommandCompletionServiceClient
+
+	// Package returns the Ledger API PackageService client.
+	Package() lapiv2.PackageServiceClient
+
 	// PartyAdmin returns the PartyManagementService client.
 	PartyAdmin() adminv2.PartyManagementServiceClient
 
@@ -81,10 +88,40 @@ type Ledger interface {
 		interfaceID *lapiv2.Identifier,
 	) ([]*lapiv2.CreatedEvent, error)
 
-	// Conn returns the underlying gRPC client connection.
+	// ActiveContractsByTemplate streams the active contracts of templateID
+	// visible to parties at activeAtOffset. Unlike GetActiveContractsByTemplate
+	// it does not hold the whole result in memory; stopping the iteration early
+	// closes the underlying stream. An error ends the sequence.
+	ActiveContractsByTemplate(
+		ctx context.Context,
+		activeAtOffset int64,
+		parties []string,
+		templateID *lapiv2.Identifier,
+	) iter.Seq2[*lapiv2.CreatedEvent, error]
+
+	// ActiveContractsByInterface is the streaming form of GetActiveContractsByInterface.
+	ActiveContractsByInterface(
+		ctx context.Context,
+		activeAtOffset int64,
+		parties []string,
+		interfaceID *lapiv2.Identifier,
+	) iter.Seq2[*lapiv2.CreatedEvent, error]
+
+	// TrackCommand registers a command about to be submitted so that
+	// ResolveOutcome can tell what became of it. See Client.TrackCommand.
+	TrackCommand(ctx context.Context, userID, commandID string, actAs []string) (context.Context, *Submission, error)
+
+	// SettleCommand stops tracking a command unless err leaves its outcome open.
+	SettleCommand(commandID string, err error)
+
+	// ResolveOutcome reports whether a tracked command committed, was
+	// rejected, or is still pending, from the participant's command completions.
+	ResolveOutcome(ctx context.Context, commandID string) (*Outcome, error)
+
+	// Conn returns the gRPC client connection to the primary participant.
 	Conn() *grpc.ClientConn
 
-	// Close closes the underlying gRPC connection.
+	// Close closes the underlying gRPC connections.
 	Close() error
 }
 
@@ -96,12 +133,20 @@ type Client struct {
 	cfg    *Config
 	logger *zap.Logger
 
-	conn *grpc.ClientConn
+	conn      *grpc.ClientConn
+	failover  *failoverConn
+	stopProbe context.CancelFunc
 
 	state   lapiv2.StateServiceClient
 	command lapiv2.CommandServiceClient
 	update  lapiv2.UpdateServiceClient
 
+	completion  lapiv2.CommandCompletionServiceClient
+	completions *completionTracker
+	commands    CommandStore // optional; see WithCommandStore
+
+	pkg lapiv2.PackageServiceClient
+
 	partyAdmin  adminv2.PartyManagementServiceClient
 	userAdmin   adminv2.UserManagementServiceClient
 	interactive interactivev2.InteractiveSubmissionServiceClient
@@ -114,6 +159,10 @@ type Client struct {
 }
 
 // New creates a new Ledger client using the provided configuration.
+//
+// With FailoverRPCURLs set, service calls are spread over RPCURL and the
+// failover participants as described on failoverConn, and each endpoint is
+// probed every HealthCheckInterval.
 func New(cfg *Config, opts ...Option) (*Client, error) {
 	s := applyOptions(opts)
 
@@ -122,43 +171,84 @@ func New(cfg *Config, opts ...Option) (*Client, error) {
 		return nil, err
 	}
 
-	target := cfg.RPCURL
-	if !strings.Contains(target, "://") {
-		target = "dns:///" + target
-	}
-
-	conn, err := grpc.NewClient(target, dopts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Canton client: %w", err)
+	urls := append([]string{cfg.RPCURL}, cfg.FailoverRPCURLs...)
+	endpoints := make([]*endpoint, 0, len(urls))
+	for _, url := range urls {
+		conn, err := dial(url, dopts)
+		if err != nil {
+			for _, ep := range endpoints {
+				_ = ep.conn.Close()
+			}
+			return nil, err
+		}
+		endpoints = append(endpoints, &endpoint{url: url, conn: conn})
 	}
+	fc := newFailoverConn(endpoints, newEndpointMetrics(s.registerer), s.logger)
 
 	var ap = s.authProvider
 	if ap == nil {
 		ap = NewOAuthClientCredentialsProvider(cfg.Auth, s.httpClient)
 	}
 
+	stopProbe := func() {}
+	if len(endpoints) > 1 && cfg.HealthCheckInterval > 0 {
+		var probeCtx context.Context
+		probeCtx, stopProbe = context.WithCancel(context.Background())
+		go fc.probe(probeCtx, cfg.HealthCheckInterval, cfg.HealthCheckTimeout)
+	}
+
 	s.logger.Info("Connected to Canton Network (cantonsdk)",
 		zap.String("rpc_url", cfg.RPCURL),
+		zap.Strings("failover_rpc_urls", cfg.FailoverRPCURLs),
 		zap.String("ledger_id", cfg.LedgerID),
 	)
 
 	return &Client{
 		cfg:         cfg,
 		logger:      s.logger,
-		conn:        conn,
-		state:       lapiv2.NewStateServiceClient(conn),
-		command:     lapiv2.NewCommandServiceClient(conn),
-		update:      lapiv2.NewUpdateServiceClient(conn),
-		partyAdmin:  adminv2.NewPartyManagementServiceClient(conn),
-		userAdmin:   adminv2.NewUserManagementServiceClient(conn),
-		interactive: interactivev2.NewInteractiveSubmissionServiceClient(conn),
+		conn:        endpoints[0].conn,
+		failover:    fc,
+		stopProbe:   stopProbe,
+		state:       lapiv2.NewStateServiceClient(fc),
+		command:     lapiv2.NewCommandServiceClient(fc),
+		update:      lapiv2.NewUpdateServiceClient(fc),
+		completion:  lapiv2.NewCommandCompletionServiceClient(fc),
+		completions: newCompletionTracker(),
+		commands:    s.commands,
+		pkg:         lapiv2.NewPackageServiceClient(fc),
+		partyAdmin:  adminv2.NewPartyManagementServiceClient(fc),
+		userAdmin:   adminv2.NewUserManagementServiceClient(fc),
+		interactive: interactivev2.NewInteractiveSubmissionServiceClient(fc),
 		auth:        ap,
 	}, nil
 }
 
+// dial creates the gRPC connection to one participant.
+func dial(url string, dopts []grpc.DialOption) (*grpc.ClientConn, error) {
+	target := url
+	if !strings.Contains(target, "://") {
+		target = "dns:///" + target
+	}
+	conn, err := grpc.NewClient(target, dopts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Canton client for %s: %w", url, err)
+	}
+	return conn, nil
+}
+
+// Conn returns the connection to the primary participant (RPCURL); calls made
+// on it directly do not fail over.
 func (c *Client) Conn() *grpc.ClientConn { return c.conn }
 
-func (c *Client) Close() error { return c.conn.Close() }
+// Close stops the health probe and closes every participant connection.
+func (c *Client) Close() error {
+	c.stopProbe()
+	errs := make([]error, 0, len(c.failover.endpoints))
+	for _, ep := range c.failover.endpoints {
+		errs = append(errs, ep.conn.Close())
+	}
+	return errors.Join(errs...)
+}
 
 func (c *Client) State() lapiv2.StateServiceClient { return c.state }
 
@@ -166,6 +256,12 @@ func (c *Client) Command() lapiv2.CommandServiceClient { return c.command }
 
 func (c *Client) Update() lapiv2.UpdateServiceClient { return c.update }
 
+func (c *Client) CommandCompletion() lapiv2.CommandCompletionServiceClient {
+	return c.completion
+}
+
+func (c *Client) Package() lapiv2.PackageServiceClient { return c.pkg }
+
 func (c *Client) PartyAdmin() adminv2.PartyManagementServiceClient {
 	return c.partyAdmin
 }
@@ -262,109 +358,127 @@ func (c *Client) GetActiveContractsByTemplate(
 	parties []string,
 	templateID *lapiv2.Identifier,
 ) ([]*lapiv2.CreatedEvent, error) {
-	if activeAtOffset == 0 {
-		return nil, fmt.Errorf("ledger is empty, no contracts exist")
-	}
-	if len(parties) == 0 {
-		return nil, fmt.Errorf("at least one party is required")
-	}
-	if templateID == nil {
-		return nil, fmt.Errorf("templateID is required")
-	}
+	return collectActiveContracts(c.ActiveContractsByTemplate(ctx, activeAtOffset, parties, templateID))
+}
 
-	authCtx := c.AuthContext(ctx)
+func (c *Client) GetActiveContractsByInterface(
+	ctx context.Context,
+	activeAtOffset int64,
+	parties []string,
+	interfaceID *lapiv2.Identifier,
+) ([]*lapiv2.CreatedEvent, error) {
+	return collectActiveContracts(c.ActiveContractsByInterface(ctx, activeAtOffset, parties, interfaceID))
+}
 
-	filtersByParty := make(map[string]*lapiv2.Filters, len(parties))
-	for _, p := range parties {
-		filtersByParty[p] = &lapiv2.Filters{
-			Cumulative: []*lapiv2.CumulativeFilter{
-				{
-					IdentifierFilter: &lapiv2.CumulativeFilter_TemplateFilter{
-						TemplateFilter: &lapiv2.TemplateFilter{
-							TemplateId: templateID,
-						},
-					},
-				},
+func (c *Client) ActiveContractsByTemplate(
+	ctx context.Context,
+	activeAtOffset int64,
+	parties []string,
+	templateID *lapiv2.Identifier,
+) iter.Seq2[*lapiv2.CreatedEvent, error] {
+	if templateID == nil {
+		return failedContracts(fmt.Errorf("templateID is required"))
+	}
+	filter := &lapiv2.CumulativeFilter{
+		IdentifierFilter: &lapiv2.CumulativeFilter_TemplateFilter{
+			TemplateFilter: &lapiv2.TemplateFilter{
+				TemplateId: templateID,
 			},
-		}
+		},
 	}
+	return c.activeContracts(ctx, activeAtOffset, parties, filter, "failed to get active contracts by template")
+}
 
-	stream, err := c.state.GetActiveContracts(authCtx, &lapiv2.GetActiveContractsRequest{
-		ActiveAtOffset: activeAtOffset,
-		EventFormat: &lapiv2.EventFormat{
-			FiltersByParty: filtersByParty,
-			Verbose:        true,
+func (c *Client) ActiveContractsByInterface(
+	ctx context.Context,
+	activeAtOffset int64,
+	parties []string,
+	interfaceID *lapiv2.Identifier,
+) iter.Seq2[*lapiv2.CreatedEvent, error] {
+	if interfaceID == nil {
+		return failedContracts(fmt.Errorf("interfaceID is required"))
+	}
+	filter := &lapiv2.CumulativeFilter{
+		IdentifierFilter: &lapiv2.CumulativeFilter_InterfaceFilter{
+			InterfaceFilter: &lapiv2.InterfaceFilter{
+				InterfaceId:             interfaceID,
+				IncludeCreatedEventBlob: false,
+			},
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get active contracts by template: %w", err)
 	}
-
-	return c.collectActiveContracts(stream)
+	return c.activeContracts(ctx, activeAtOffset, parties, filter, "failed to get active contracts")
 }
 
-func (c *Client) GetActiveContractsByInterface(
+// activeContracts streams the active contracts matching filter for each of
+// parties. The GetActiveContracts stream is opened when iteration starts and
+// closed when it ends, so only one contract is held at a time.
+func (c *Client) activeContracts(
 	ctx context.Context,
 	activeAtOffset int64,
 	parties []string,
-	interfaceID *lapiv2.Identifier,
-) ([]*lapiv2.CreatedEvent, error) {
+	filter *lapiv2.CumulativeFilter,
+	errPrefix string,
+) iter.Seq2[*lapiv2.CreatedEvent, error] {
 	if activeAtOffset == 0 {
-		return nil, fmt.Errorf("ledger is empty, no contracts exist")
+		return failedContracts(fmt.Errorf("ledger is empty, no contracts exist"))
 	}
 	if len(parties) == 0 {
-		return nil, fmt.Errorf("at least one party is required")
-	}
-	if interfaceID == nil {
-		return nil, fmt.Errorf("interfaceID is required")
+		return failedContracts(fmt.Errorf("at least one party is required"))
 	}
 
-	authCtx := c.AuthContext(ctx)
-
 	filtersByParty := make(map[string]*lapiv2.Filters, len(parties))
 	for _, p := range parties {
-		filtersByParty[p] = &lapiv2.Filters{
-			Cumulative: []*lapiv2.CumulativeFilter{
-				{
-					IdentifierFilter: &lapiv2.CumulativeFilter_InterfaceFilter{
-						InterfaceFilter: &lapiv2.InterfaceFilter{
-							InterfaceId:             interfaceID,
-							IncludeCreatedEventBlob: false,
-						},
-					},
-				},
+		filtersByParty[p] = &lapiv2.Filters{Cumulative: []*lapiv2.CumulativeFilter{filter}}
+	}
+
+	return func(yield func(*lapiv2.CreatedEvent, error) bool) {
+		// Canceling on return closes the stream when the caller stops early.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := c.state.GetActiveContracts(c.AuthContext(ctx), &lapiv2.GetActiveContractsRequest{
+			ActiveAtOffset: activeAtOffset,
+			EventFormat: &lapiv2.EventFormat{
+				FiltersByParty: filtersByParty,
+				Verbose:        true,
 			},
+		})
+		if err != nil {
+			yield(nil, fmt.Errorf("%s: %w", errPrefix, err))
+			return
 		}
-	}
 
-	stream, err := c.state.GetActiveContracts(authCtx, &lapiv2.GetActiveContractsRequest{
-		ActiveAtOffset: activeAtOffset,
-		EventFormat: &lapiv2.EventFormat{
-			FiltersByParty: filtersByParty,
-			Verbose:        true,
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get active contracts: %w", err)
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					yield(nil, fmt.Errorf("failed to receive active contract: %w", err))
+				}
+				return
+			}
+			if ac := msg.GetActiveContract(); ac != nil && ac.CreatedEvent != nil {
+				if !yield(ac.CreatedEvent, nil) {
+					return
+				}
+			}
+		}
 	}
-
-	return c.collectActiveContracts(stream)
 }
 
-func (*Client) collectActiveContracts(stream lapiv2.StateService_GetActiveContractsClient) ([]*lapiv2.CreatedEvent, error) {
+func collectActiveContracts(seq iter.Seq2[*lapiv2.CreatedEvent, error]) ([]*lapiv2.CreatedEvent, error) {
 	var out []*lapiv2.CreatedEvent
-	for {
-		msg, err := stream.Recv()
+	for ce, err := range seq {
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return nil, fmt.Errorf("failed to receive active contract: %w", err)
-		}
-		if ac := msg.GetActiveContract(); ac != nil && ac.CreatedEvent != nil {
-			out = append(out, ac.CreatedEvent)
+			return nil, err
 		}
+		out = append(out, ce)
 	}
-
 	return out, nil
 }
+
+// failedContracts returns a sequence that yields err alone.
+func failedContracts(err error) iter.Seq2[*lapiv2.CreatedEvent, error] {
+	return func(yield func(*lapiv2.CreatedEvent, error) bool) {
+		yield(nil, err)
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+)
+
+// acsNode is an in-process StateService that streams count active contracts
+// and reports on done how each stream ended.
+type acsNode struct {
+	lapiv2.UnimplementedStateServiceServer
+
+	count int
+	fail  bool
+	done  chan error
+}
+
+func startACSNode(t *testing.T, count int, fail bool) (*acsNode, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	n := &acsNode{count: count, fail: fail, done: make(chan error, 1)}
+	server := grpc.NewServer()
+	lapiv2.RegisterStateServiceServer(server, n)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return n, lis.Addr().String()
+}
+
+func (n *acsNode) GetActiveContracts(
+	_ *lapiv2.GetActiveContractsRequest, stream grpc.ServerStreamingServer[lapiv2.GetActiveContractsResponse],
+) error {
+	err := n.send(stream)
+	n.done <- err
+	return err
+}
+
+func (n *acsNode) send(stream grpc.ServerStreamingServer[lapiv2.GetActiveContractsResponse]) error {
+	for i := range n.count {
+		if err := stream.Send(&lapiv2.GetActiveContractsResponse{
+			ContractEntry: &lapiv2.GetActiveContractsResponse_ActiveContract{
+				ActiveContract: &lapiv2.ActiveContract{
+					CreatedEvent: &lapiv2.CreatedEvent{ContractId: fmt.Sprintf("cid-%d", i)},
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	if n.fail {
+		return status.Error(codes.Internal, "boom")
+	}
+	// Wait for the client to close the stream so an early stop is observable.
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+var testTemplateID = &lapiv2.Identifier{PackageId: "pkg", ModuleName: "Mod", EntityName: "Tpl"}
+
+func TestActiveContractsByTemplate_StopEarlyClosesStream(t *testing.T) {
+	node, url := startACSNode(t, 100, false)
+	c := newTestClient(t, url, time.Minute)
+
+	var got []string
+	for ce, err := range c.ActiveContractsByTemplate(context.Background(), 10, []string{"alice"}, testTemplateID) {
+		require.NoError(t, err)
+		got = append(got, ce.ContractId)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"cid-0", "cid-1"}, got)
+
+	select {
+	case err := <-node.done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream not closed after the iteration stopped")
+	}
+}
+
+func TestActiveContractsByTemplate_StreamError(t *testing.T) {
+	_, url := startACSNode(t, 3, true)
+	c := newTestClient(t, url, time.Minute)
+
+	var n int
+	var lastErr error
+	for _, err := range c.ActiveContractsByTemplate(context.Background(), 10, []string{"alice"}, testTemplateID) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		n++
+	}
+	assert.Equal(t, 3, n)
+	require.Error(t, lastErr)
+	assert.Equal(t, codes.Internal, status.Code(lastErr))
+}
+
+func TestActiveContractsByTemplate_Validation(t *testing.T) {
+	_, url := startACSNode(t, 0, false)
+	c := newTestClient(t, url, time.Minute)
+
+	_, err := c.GetActiveContractsByTemplate(context.Background(), 0, []string{"alice"}, testTemplateID)
+	require.ErrorContains(t, err, "ledger is empty")
+
+	_, err = c.GetActiveContractsByTemplate(context.Background(), 10, nil, testTemplateID)
+	require.ErrorContains(t, err, "at least one party is required")
+
+	_, err = c.GetActiveContractsByInterface(context.Background(), 10, []string{"alice"}, nil)
+	require.ErrorContains(t, err, "interfaceID is required")
+}
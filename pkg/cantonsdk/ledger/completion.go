@@ -0,0 +1,421 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	interactivev2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2/interactive"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// defaultTrackRetention is how long a command stays tracked when neither a
+	// deduplication period nor a completion timeout is configured.
+	defaultTrackRetention = 24 * time.Hour
+
+	// settleTimeout bounds the CommandStore delete of SettleCommand, which has
+	// no caller context.
+	settleTimeout = 5 * time.Second
+)
+
+// OutcomeStatus is the resolved state of a submitted command.
+type OutcomeStatus string
+
+const (
+	// OutcomeUnknown means the command is not tracked: it was never submitted
+	// through TrackCommand, was settled, or was submitted by an earlier process
+	// without a CommandStore. It may also be a tracked command the participant never reported on
+	// within CompletionTimeout, i.e. one that most likely never reached it.
+	// Resubmitting with the same command ID is safe within the deduplication
+	// period.
+	OutcomeUnknown OutcomeStatus = "unknown"
+	// OutcomePending means the command is tracked but has no completion yet.
+	// Do not resubmit it.
+	OutcomePending OutcomeStatus = "pending"
+	// OutcomeCommitted means the command's transaction was committed.
+	OutcomeCommitted OutcomeStatus = "committed"
+	// OutcomeRejected means the latest submission of the command was rejected;
+	// resubmitting is safe.
+	OutcomeRejected OutcomeStatus = "rejected"
+)
+
+// Outcome is the result of ResolveOutcome.
+type Outcome struct {
+	CommandID string
+	Status    OutcomeStatus
+	// UpdateID and Offset locate the committed transaction, or the rejection.
+	UpdateID string
+	Offset   int64
+	// Reason is the rejection, as a gRPC status error, for OutcomeRejected.
+	Reason error
+}
+
+// Submission carries the deduplication settings of a tracked command. Apply
+// it to the request that submits the command.
+type Submission struct {
+	CommandID    string
+	SubmissionID string
+	// Deduplication is zero when the participant's maximum is to be used.
+	Deduplication time.Duration
+}
+
+// ApplyToCommands sets the submission ID and deduplication period on commands.
+func (s *Submission) ApplyToCommands(commands *lapiv2.Commands) {
+	commands.SubmissionId = s.SubmissionID
+	if s.Deduplication > 0 {
+		commands.DeduplicationPeriod = &lapiv2.Commands_DeduplicationDuration{
+			DeduplicationDuration: durationpb.New(s.Deduplication),
+		}
+	}
+}
+
+// ApplyToExecute sets the submission ID and deduplication period on an
+// interactive execute request.
+func (s *Submission) ApplyToExecute(req *interactivev2.ExecuteSubmissionAndWaitRequest) {
+	req.SubmissionId = s.SubmissionID
+	if s.Deduplication > 0 {
+		req.DeduplicationPeriod = &interactivev2.ExecuteSubmissionAndWaitRequest_DeduplicationDuration{
+			DeduplicationDuration: durationpb.New(s.Deduplication),
+		}
+	}
+}
+
+// TrackedCommand is what ResolveOutcome needs to find a command's completion.
+type TrackedCommand struct {
+	CommandID    string
+	UserID       string
+	ActAs        []string
+	SubmissionID string // of the latest submission
+	Endpoint     string // participant the command was submitted to
+	BeginOffset  int64  // ledger end before the first submission
+	SubmittedAt  time.Time
+}
+
+// CommandStore persists tracked commands, so that ResolveOutcome can resolve a
+// command submitted by an earlier process, or by another client sharing the
+// store, rather than report it unknown. See WithCommandStore.
+type CommandStore interface {
+	// SaveCommand inserts cmd or replaces the record with its command ID.
+	SaveCommand(ctx context.Context, cmd *TrackedCommand) error
+	// LoadCommand returns the record of the command, or nil when there is none.
+	LoadCommand(ctx context.Context, commandID string) (*TrackedCommand, error)
+	// DeleteCommand removes the record of the command, if any.
+	DeleteCommand(ctx context.Context, commandID string) error
+	// ExpireCommands removes the records of commands last submitted before cutoff.
+	ExpireCommands(ctx context.Context, cutoff time.Time) error
+}
+
+// completionTracker holds the commands submitted and not yet settled.
+type completionTracker struct {
+	mu       sync.Mutex
+	commands map[string]*TrackedCommand
+}
+
+func newCompletionTracker() *completionTracker {
+	return &completionTracker{commands: make(map[string]*TrackedCommand)}
+}
+
+func (t *completionTracker) get(commandID string) *TrackedCommand {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tc := t.commands[commandID]; tc != nil {
+		cp := *tc
+		return &cp
+	}
+	return nil
+}
+
+func (t *completionTracker) add(tc *TrackedCommand) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cp := *tc
+	t.commands[tc.CommandID] = &cp
+}
+
+// resubmit records a new submission of a tracked command and returns a copy
+// of it, or nil when the command is not tracked.
+func (t *completionTracker) resubmit(commandID, submissionID string) *TrackedCommand {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tc := t.commands[commandID]
+	if tc == nil {
+		return nil
+	}
+	tc.SubmissionID = submissionID
+	tc.SubmittedAt = time.Now()
+	cp := *tc
+	return &cp
+}
+
+func (t *completionTracker) forget(commandID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.commands, commandID)
+}
+
+// expire drops commands last submitted before cutoff.
+func (t *completionTracker) expire(cutoff time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, tc := range t.commands {
+		if tc.SubmittedAt.Before(cutoff) {
+			delete(t.commands, id)
+		}
+	}
+}
+
+// TrackCommand registers a command about to be submitted so its outcome can be
+// resolved with ResolveOutcome if the submission ends ambiguously. Submit the
+// command with the returned context, which routes it to the participant it is
+// tracked on, and with the returned Submission applied to the request; then
+// pass the submission's error to SettleCommand.
+//
+// Tracking a command again (a retry with the same command ID) keeps it on the
+// participant of its first submission: deduplication is participant-local, so
+// a retry elsewhere could commit it twice.
+//
+// With a CommandStore, the command is saved before it is submitted; a command
+// the store holds is tracked again as a retry even after a restart. Failing to
+// save it is an error, as a command submitted without a record could not be
+// resolved after one.
+func (c *Client) TrackCommand(
+	ctx context.Context, userID, commandID string, actAs []string,
+) (context.Context, *Submission, error) {
+	if commandID == "" {
+		return nil, nil, errors.New("command ID is required")
+	}
+	cutoff := time.Now().Add(-c.trackRetention())
+	c.completions.expire(cutoff)
+	if c.commands != nil {
+		if err := c.commands.ExpireCommands(ctx, cutoff); err != nil {
+			c.logger.Warn("failed to expire stored commands", zap.Error(err))
+		}
+	}
+	sub := &Submission{
+		CommandID:     commandID,
+		SubmissionID:  uuid.NewString(),
+		Deduplication: c.cfg.DeduplicationPeriod,
+	}
+
+	tc := c.completions.resubmit(commandID, sub.SubmissionID)
+	if tc == nil {
+		stored, err := c.loadCommand(ctx, commandID, cutoff)
+		if err != nil {
+			return nil, nil, fmt.Errorf("track command %s: %w", commandID, err)
+		}
+		if stored != nil {
+			stored.SubmissionID, stored.SubmittedAt = sub.SubmissionID, time.Now()
+			tc = stored
+		}
+	}
+	if tc != nil {
+		if err := c.saveCommand(ctx, tc); err != nil {
+			return nil, nil, fmt.Errorf("track command %s: %w", commandID, err)
+		}
+		return WithEndpoint(ctx, tc.Endpoint), sub, nil
+	}
+
+	ctx = StickyContext(ctx)
+	begin, err := c.GetLedgerEnd(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("track command %s: %w", commandID, err)
+	}
+	tc = &TrackedCommand{
+		CommandID:    commandID,
+		UserID:       userID,
+		ActAs:        append([]string(nil), actAs...),
+		SubmissionID: sub.SubmissionID,
+		Endpoint:     StickyEndpoint(ctx),
+		BeginOffset:  begin,
+		SubmittedAt:  time.Now(),
+	}
+	if err = c.saveCommand(ctx, tc); err != nil {
+		return nil, nil, fmt.Errorf("track command %s: %w", commandID, err)
+	}
+	return ctx, sub, nil
+}
+
+// saveCommand tracks tc in memory and, with a CommandStore, saves it.
+func (c *Client) saveCommand(ctx context.Context, tc *TrackedCommand) error {
+	if c.commands != nil {
+		if err := c.commands.SaveCommand(ctx, tc); err != nil {
+			return fmt.Errorf("save tracked command: %w", err)
+		}
+	}
+	c.completions.add(tc)
+	return nil
+}
+
+// loadCommand returns the stored record of a command submitted since cutoff,
+// or nil without a CommandStore or record.
+func (c *Client) loadCommand(ctx context.Context, commandID string, cutoff time.Time) (*TrackedCommand, error) {
+	if c.commands == nil {
+		return nil, nil
+	}
+	tc, err := c.commands.LoadCommand(ctx, commandID)
+	if err != nil {
+		return nil, fmt.Errorf("load tracked command: %w", err)
+	}
+	if tc == nil || tc.SubmittedAt.Before(cutoff) {
+		return nil, nil
+	}
+	return tc, nil
+}
+
+// trackRetention is how long a command stays tracked after its latest
+// submission: past both its deduplication period, after which a resubmission is
+// no longer recognized as a duplicate, and CompletionTimeout, after which it is
+// reported unknown anyway. defaultTrackRetention bounds it when neither is set,
+// so commands that are never settled do not accumulate.
+func (c *Client) trackRetention() time.Duration {
+	if d := max(c.cfg.DeduplicationPeriod, c.cfg.CompletionTimeout); d > 0 {
+		return d
+	}
+	return defaultTrackRetention
+}
+
+// SettleCommand stops tracking a command whose submission returned err, unless
+// err leaves its outcome open: a timeout, a cancellation or a lost connection,
+// after which the command may still commit, or a duplicate-command rejection,
+// which means an earlier submission of it did.
+func (c *Client) SettleCommand(commandID string, err error) {
+	if err != nil && outcomeOpen(err) {
+		return
+	}
+	c.completions.forget(commandID)
+	if c.commands == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), settleTimeout)
+	defer cancel()
+	if delErr := c.commands.DeleteCommand(ctx, commandID); delErr != nil {
+		// The record expires with the command's retention; until then
+		// ResolveOutcome finds the settled completion on the ledger.
+		c.logger.Warn("failed to delete settled command",
+			zap.String("command_id", commandID), zap.Error(delErr))
+	}
+}
+
+func outcomeOpen(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.Canceled, codes.Unavailable, codes.Unknown, codes.Internal,
+		codes.AlreadyExists:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveOutcome reports what became of a command tracked with TrackCommand by
+// reading the participant's command completions since its first submission.
+// Call it before resubmitting a command whose submission ended ambiguously.
+//
+// A command this client does not track is looked up in the CommandStore, so a
+// command submitted before a restart resolves from its recorded offset.
+func (c *Client) ResolveOutcome(ctx context.Context, commandID string) (*Outcome, error) {
+	tc := c.completions.get(commandID)
+	if tc == nil {
+		stored, err := c.loadCommand(ctx, commandID, time.Now().Add(-c.trackRetention()))
+		if err != nil {
+			return nil, fmt.Errorf("resolve outcome of %s: %w", commandID, err)
+		}
+		if stored == nil {
+			return &Outcome{CommandID: commandID, Status: OutcomeUnknown}, nil
+		}
+		c.completions.add(stored)
+		tc = stored
+	}
+	ctx = WithEndpoint(ctx, tc.Endpoint)
+
+	end, err := c.GetLedgerEnd(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("resolve outcome of %s: %w", commandID, err)
+	}
+	if end > tc.BeginOffset {
+		outcome, err := c.findCompletion(ctx, commandID, tc, end)
+		if err != nil {
+			return nil, fmt.Errorf("resolve outcome of %s: %w", commandID, err)
+		}
+		if outcome != nil {
+			return outcome, nil
+		}
+	}
+
+	if time.Since(tc.SubmittedAt) < c.cfg.CompletionTimeout {
+		return &Outcome{CommandID: commandID, Status: OutcomePending}, nil
+	}
+	return &Outcome{CommandID: commandID, Status: OutcomeUnknown}, nil
+}
+
+// findCompletion reads the completions of tc's parties from tc.BeginOffset up
+// to end. A commit of any submission of the command wins; otherwise only a
+// rejection of its latest submission counts, as earlier ones were retried.
+func (c *Client) findCompletion(ctx context.Context, commandID string, tc *TrackedCommand, end int64) (*Outcome, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.completion.CompletionStream(c.AuthContext(ctx), &lapiv2.CompletionStreamRequest{
+		UserId:         tc.UserID,
+		Parties:        tc.ActAs,
+		BeginExclusive: tc.BeginOffset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rejected *Outcome
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return rejected, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var offset int64
+		switch r := resp.CompletionResponse.(type) {
+		case *lapiv2.CompletionStreamResponse_Completion:
+			comp := r.Completion
+			offset = comp.Offset
+			if comp.CommandId != commandID {
+				break
+			}
+			if comp.Status.GetCode() == int32(codes.OK) {
+				return &Outcome{
+					CommandID: commandID,
+					Status:    OutcomeCommitted,
+					UpdateID:  comp.UpdateId,
+					Offset:    comp.Offset,
+				}, nil
+			}
+			if comp.SubmissionId == tc.SubmissionID {
+				rejected = &Outcome{
+					CommandID: commandID,
+					Status:    OutcomeRejected,
+					Offset:    comp.Offset,
+					Reason:    status.ErrorProto(comp.Status),
+				}
+			}
+		case *lapiv2.CompletionStreamResponse_OffsetCheckpoint:
+			offset = r.OffsetCheckpoint.Offset
+		}
+		if offset >= end {
+			return rejected, nil
+		}
+	}
+}
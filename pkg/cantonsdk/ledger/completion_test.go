@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+)
+
+// completionNode is an in-process Ledger API stand-in whose ledger end and
+// command completions are set by the test.
+type completionNode struct {
+	lapiv2.UnimplementedStateServiceServer
+	lapiv2.UnimplementedCommandCompletionServiceServer
+
+	mu          sync.Mutex
+	end         int64
+	completions []*lapiv2.Completion
+}
+
+func startCompletionNode(t *testing.T, end int64) (*completionNode, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	n := &completionNode{end: end}
+	server := grpc.NewServer()
+	lapiv2.RegisterStateServiceServer(server, n)
+	lapiv2.RegisterCommandCompletionServiceServer(server, n)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return n, lis.Addr().String()
+}
+
+// complete appends a completion at the next offset.
+func (n *completionNode) complete(commandID, submissionID string, code codes.Code) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.end++
+	n.completions = append(n.completions, &lapiv2.Completion{
+		CommandId:    commandID,
+		SubmissionId: submissionID,
+		UpdateId:     "update-" + commandID,
+		Offset:       n.end,
+		Status:       &status.Status{Code: int32(code)},
+	})
+}
+
+func (n *completionNode) GetLedgerEnd(context.Context, *lapiv2.GetLedgerEndRequest) (*lapiv2.GetLedgerEndResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &lapiv2.GetLedgerEndResponse{Offset: n.end}, nil
+}
+
+func (n *completionNode) CompletionStream(
+	req *lapiv2.CompletionStreamRequest, stream grpc.ServerStreamingServer[lapiv2.CompletionStreamResponse],
+) error {
+	n.mu.Lock()
+	completions := append([]*lapiv2.Completion(nil), n.completions...)
+	end := n.end
+	n.mu.Unlock()
+
+	for _, c := range completions {
+		if c.Offset <= req.BeginExclusive {
+			continue
+		}
+		if err := stream.Send(&lapiv2.CompletionStreamResponse{
+			CompletionResponse: &lapiv2.CompletionStreamResponse_Completion{Completion: c},
+		}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&lapiv2.CompletionStreamResponse{
+		CompletionResponse: &lapiv2.CompletionStreamResponse_OffsetCheckpoint{
+			OffsetCheckpoint: &lapiv2.OffsetCheckpoint{Offset: end},
+		},
+	})
+}
+
+func newTestClient(t *testing.T, url string, completionTimeout time.Duration, opts ...Option) *Client {
+	t.Helper()
+	c, err := New(&Config{
+		RPCURL:              url,
+		DeduplicationPeriod: time.Hour,
+		CompletionTimeout:   completionTimeout,
+		TLS:                 &TLSConfig{},
+		Auth:                &AuthConfig{},
+	}, append([]Option{WithAuthProvider(staticAuth{})}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestResolveOutcome_Untracked(t *testing.T) {
+	_, url := startCompletionNode(t, 10)
+	c := newTestClient(t, url, time.Minute)
+
+	outcome, err := c.ResolveOutcome(context.Background(), "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeUnknown, outcome.Status)
+}
+
+func TestResolveOutcome_Committed(t *testing.T) {
+	node, url := startCompletionNode(t, 10)
+	c := newTestClient(t, url, time.Minute)
+	ctx := context.Background()
+
+	_, sub, err := c.TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, sub.Deduplication)
+
+	outcome, err := c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomePending, outcome.Status)
+
+	node.complete("cmd-other", "sub-other", codes.OK)
+	node.complete("cmd-1", sub.SubmissionID, codes.OK)
+
+	outcome, err = c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeCommitted, outcome.Status)
+	assert.Equal(t, "update-cmd-1", outcome.UpdateID)
+	assert.Equal(t, int64(12), outcome.Offset)
+}
+
+func TestResolveOutcome_RejectedOnlyForLatestSubmission(t *testing.T) {
+	node, url := startCompletionNode(t, 10)
+	c := newTestClient(t, url, time.Minute)
+	ctx := context.Background()
+
+	_, first, err := c.TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+	_, retry, err := c.TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+	require.NotEqual(t, first.SubmissionID, retry.SubmissionID)
+
+	// The rejection of the first submission is superseded by the retry.
+	node.complete("cmd-1", first.SubmissionID, codes.FailedPrecondition)
+	outcome, err := c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomePending, outcome.Status)
+
+	node.complete("cmd-1", retry.SubmissionID, codes.FailedPrecondition)
+	outcome, err = c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeRejected, outcome.Status)
+	require.Error(t, outcome.Reason)
+}
+
+func TestResolveOutcome_NoCompletionAfterTimeout(t *testing.T) {
+	_, url := startCompletionNode(t, 10)
+	c := newTestClient(t, url, 0)
+	ctx := context.Background()
+
+	_, _, err := c.TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+
+	outcome, err := c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeUnknown, outcome.Status)
+}
+
+func TestSettleCommand(t *testing.T) {
+	_, url := startCompletionNode(t, 10)
+	c := newTestClient(t, url, time.Minute)
+	ctx := context.Background()
+
+	_, _, err := c.TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+	c.SettleCommand("cmd-1", context.DeadlineExceeded)
+	assert.NotNil(t, c.completions.get("cmd-1"))
+
+	c.SettleCommand("cmd-1", nil)
+	assert.Nil(t, c.completions.get("cmd-1"))
+}
+
+// memCommandStore is a CommandStore outliving the clients that share it.
+type memCommandStore struct {
+	mu       sync.Mutex
+	commands map[string]TrackedCommand
+}
+
+func newMemCommandStore() *memCommandStore {
+	return &memCommandStore{commands: make(map[string]TrackedCommand)}
+}
+
+func (s *memCommandStore) SaveCommand(_ context.Context, cmd *TrackedCommand) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands[cmd.CommandID] = *cmd
+	return nil
+}
+
+func (s *memCommandStore) LoadCommand(_ context.Context, commandID string) (*TrackedCommand, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cmd, ok := s.commands[commandID]
+	if !ok {
+		return nil, nil
+	}
+	return &cmd, nil
+}
+
+func (s *memCommandStore) DeleteCommand(_ context.Context, commandID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.commands, commandID)
+	return nil
+}
+
+func (s *memCommandStore) ExpireCommands(_ context.Context, cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, cmd := range s.commands {
+		if cmd.SubmittedAt.Before(cutoff) {
+			delete(s.commands, id)
+		}
+	}
+	return nil
+}
+
+func TestResolveOutcome_AfterRestart(t *testing.T) {
+	node, url := startCompletionNode(t, 10)
+	store := newMemCommandStore()
+	ctx := context.Background()
+
+	_, sub, err := newTestClient(t, url, time.Minute, WithCommandStore(store)).
+		TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+
+	// A new client, as after a restart, sharing the store.
+	c := newTestClient(t, url, time.Minute, WithCommandStore(store))
+	outcome, err := c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomePending, outcome.Status, "a stored command is not reported unknown")
+
+	node.complete("cmd-1", sub.SubmissionID, codes.OK)
+	outcome, err = c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeCommitted, outcome.Status)
+
+	c.SettleCommand("cmd-1", nil)
+	stored, err := store.LoadCommand(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestTrackCommand_RetryAfterRestartKeepsBeginOffset(t *testing.T) {
+	node, url := startCompletionNode(t, 10)
+	store := newMemCommandStore()
+	ctx := context.Background()
+
+	_, first, err := newTestClient(t, url, time.Minute, WithCommandStore(store)).
+		TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+	node.complete("cmd-1", first.SubmissionID, codes.OK)
+
+	// The retry after a restart is tracked from the first submission, so the
+	// earlier commit is still found.
+	c := newTestClient(t, url, time.Minute, WithCommandStore(store))
+	_, retry, err := c.TrackCommand(ctx, "user", "cmd-1", []string{"alice"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.SubmissionID, retry.SubmissionID)
+
+	stored, err := store.LoadCommand(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), stored.BeginOffset)
+	assert.Equal(t, retry.SubmissionID, stored.SubmissionID)
+
+	outcome, err := c.ResolveOutcome(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeCommitted, outcome.Status)
+}
+
+func TestTrackCommand_ExpiresStaleCommands(t *testing.T) {
+	tests := []struct {
+		name          string
+		deduplication time.Duration
+		retention     time.Duration
+	}{
+		{name: "deduplication period", deduplication: time.Hour, retention: time.Hour},
+		{name: "completion timeout without deduplication period", retention: time.Minute},
+		{name: "neither configured", retention: defaultTrackRetention},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, url := startCompletionNode(t, 10)
+			c := newTestClient(t, url, time.Minute)
+			c.cfg.DeduplicationPeriod = tc.deduplication
+			if tc.retention == defaultTrackRetention {
+				c.cfg.CompletionTimeout = 0
+			}
+			ctx := context.Background()
+
+			for _, id := range []string{"stale", "fresh"} {
+				_, _, err := c.TrackCommand(ctx, "user", id, []string{"alice"})
+				require.NoError(t, err)
+			}
+			c.completions.mu.Lock()
+			c.completions.commands["stale"].SubmittedAt = time.Now().Add(-tc.retention - time.Second)
+			c.completions.commands["fresh"].SubmittedAt = time.Now().Add(-tc.retention + time.Second)
+			c.completions.mu.Unlock()
+
+			_, _, err := c.TrackCommand(ctx, "user", "cmd-3", []string{"alice"})
+			require.NoError(t, err)
+			assert.Nil(t, c.completions.get("stale"))
+			assert.NotNil(t, c.completions.get("fresh"))
+		})
+	}
+}
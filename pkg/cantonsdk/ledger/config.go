@@ -14,6 +14,26 @@ type Config struct {
 	LedgerID       string `yaml:"ledger_id" default:""`
 	MaxMessageSize int    `yaml:"max_inbound_message_size" default:"52428800"`
 
+	// FailoverRPCURLs lists further participants hosting the same parties, tried
+	// in order when RPCURL is down. They share the TLS and auth settings.
+	//
+	// Ledger offsets are participant-local: services that persist offsets (the
+	// indexer, the relayer) must not be given failover endpoints.
+	FailoverRPCURLs []string `yaml:"failover_rpc_urls" validate:"omitempty,dive,required"`
+	// HealthCheckInterval is how often each endpoint is probed when failover
+	// endpoints are configured; HealthCheckTimeout bounds a single probe.
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" default:"5s"`
+	HealthCheckTimeout  time.Duration `yaml:"health_check_timeout" default:"2s"`
+
+	// DeduplicationPeriod is sent with tracked command submissions (see
+	// Client.TrackCommand): a resubmission with the same command ID within it is
+	// rejected as a duplicate. It must not exceed the participant's maximum
+	// deduplication duration.
+	DeduplicationPeriod time.Duration `yaml:"deduplication_period" default:"24h"`
+	// CompletionTimeout is how long after its submission a tracked command
+	// without a completion is reported pending rather than unknown.
+	CompletionTimeout time.Duration `yaml:"completion_timeout" default:"2m"`
+
 	TLS  *TLSConfig  `yaml:"tls" validate:"required"`
 	Auth *AuthConfig `yaml:"auth" validate:"required"`
 }
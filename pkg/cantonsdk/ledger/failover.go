@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// endpoint is one participant connection of a failoverConn.
+type endpoint struct {
+	url     string
+	conn    *grpc.ClientConn
+	healthy atomic.Bool
+}
+
+// failoverConn spreads Ledger API calls over participants hosting the same
+// parties. Every call goes to the first healthy endpoint in configuration
+// order, so the primary serves everything while it is up; endpoints marked
+// down are still tried, last, rather than failing outright.
+//
+// A call that finds its endpoint unreachable marks it down. Calls that cannot
+// have changed the ledger (reads and interactive prepares) then move on to the
+// next endpoint. Commands do not: one may have reached the participant before
+// the connection failed, and command deduplication is participant-local, so
+// resubmitting it elsewhere could commit it twice. The caller's retry, with the
+// same command ID, goes to the next healthy endpoint instead.
+type failoverConn struct {
+	endpoints []*endpoint
+	metrics   *endpointMetrics
+	logger    *zap.Logger
+}
+
+// Compile-time check that failoverConn can back the generated service clients.
+var _ grpc.ClientConnInterface = (*failoverConn)(nil)
+
+func newFailoverConn(endpoints []*endpoint, metrics *endpointMetrics, logger *zap.Logger) *failoverConn {
+	for _, ep := range endpoints {
+		ep.healthy.Store(true)
+		metrics.Healthy.WithLabelValues(ep.url).Set(1)
+	}
+	return &failoverConn{endpoints: endpoints, metrics: metrics, logger: logger}
+}
+
+func (f *failoverConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	var err error
+	route := f.route(ctx)
+	for i, ep := range route {
+		err = ep.conn.Invoke(ctx, method, args, reply, opts...)
+		if !f.observe(ctx, ep, err) {
+			return err
+		}
+		if !failoverSafe(method) || i == len(route)-1 {
+			return err
+		}
+		f.failover(ep, method, err)
+	}
+	return err
+}
+
+func (f *failoverConn) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	var (
+		stream grpc.ClientStream
+		err    error
+	)
+	route := f.route(ctx)
+	for i, ep := range route {
+		stream, err = ep.conn.NewStream(ctx, desc, method, opts...)
+		if !f.observe(ctx, ep, err) {
+			return stream, err
+		}
+		if !failoverSafe(method) || i == len(route)-1 {
+			return stream, err
+		}
+		f.failover(ep, method, err)
+	}
+	return stream, err
+}
+
+// route returns the endpoints to try, in order: the pinned endpoint alone for
+// a sticky context that has one, otherwise the healthy endpoints followed by
+// the ones marked down.
+func (f *failoverConn) route(ctx context.Context) []*endpoint {
+	if s := stickyFrom(ctx); s != nil {
+		if url := s.get(); url != "" {
+			for _, ep := range f.endpoints {
+				if ep.url == url {
+					return []*endpoint{ep}
+				}
+			}
+		}
+	}
+	healthy := make([]*endpoint, 0, len(f.endpoints))
+	var down []*endpoint
+	for _, ep := range f.endpoints {
+		if ep.healthy.Load() {
+			healthy = append(healthy, ep)
+		} else {
+			down = append(down, ep)
+		}
+	}
+	return append(healthy, down...)
+}
+
+// observe records a call's outcome on ep and reports whether ep was
+// unreachable. A call that reached the participant marks it up again and pins a
+// sticky context to it.
+func (f *failoverConn) observe(ctx context.Context, ep *endpoint, err error) bool {
+	if isUnreachable(err) && ctx.Err() == nil {
+		f.metrics.CallsTotal.WithLabelValues(ep.url, resultUnavailable).Inc()
+		f.setHealthy(ep, false, err)
+		return true
+	}
+	result := resultOK
+	if err != nil {
+		result = resultError
+	}
+	f.metrics.CallsTotal.WithLabelValues(ep.url, result).Inc()
+	f.setHealthy(ep, true, nil)
+	if s := stickyFrom(ctx); s != nil {
+		s.set(ep.url)
+	}
+	return false
+}
+
+func (f *failoverConn) failover(ep *endpoint, method string, err error) {
+	f.metrics.FailoversTotal.WithLabelValues(ep.url).Inc()
+	f.logger.Warn("Canton participant unavailable, failing over",
+		zap.String("endpoint", ep.url),
+		zap.String("method", method),
+		zap.Error(err),
+	)
+}
+
+// probe checks every endpoint each interval until ctx is canceled.
+func (f *failoverConn) probe(ctx context.Context, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, ep := range f.endpoints {
+				f.check(ctx, ep, timeout)
+			}
+		}
+	}
+}
+
+// check probes ep with VersionService.GetLedgerApiVersion. Any answer, even
+// an error such as Unauthenticated, shows the participant is up.
+func (f *failoverConn) check(ctx context.Context, ep *endpoint, timeout time.Duration) {
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err := lapiv2.NewVersionServiceClient(ep.conn).GetLedgerApiVersion(probeCtx, &lapiv2.GetLedgerApiVersionRequest{})
+	if ctx.Err() != nil {
+		return
+	}
+	f.setHealthy(ep, !isUnreachable(err) && !errorIs(err, codes.DeadlineExceeded), err)
+}
+
+func (f *failoverConn) setHealthy(ep *endpoint, healthy bool, err error) {
+	if ep.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		f.metrics.Healthy.WithLabelValues(ep.url).Set(1)
+		f.logger.Info("Canton participant endpoint recovered", zap.String("endpoint", ep.url))
+		return
+	}
+	f.metrics.Healthy.WithLabelValues(ep.url).Set(0)
+	f.logger.Warn("Canton participant endpoint down", zap.String("endpoint", ep.url), zap.Error(err))
+}
+
+// failoverSafe reports whether a call cannot have changed ledger state, so it
+// may be repeated on another participant: anything on the read-only services,
+// Get*/List* methods elsewhere, and interactive prepares, which only compute a
+// transaction for signing.
+func failoverSafe(method string) bool {
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok {
+		return false
+	}
+	switch service[strings.LastIndex(service, ".")+1:] {
+	case "StateService", "UpdateService", "VersionService", "PackageService",
+		"EventQueryService", "CommandCompletionService":
+		return true
+	}
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List") || name == "PrepareSubmission"
+}
+
+func isUnreachable(err error) bool {
+	return errorIs(err, codes.Unavailable)
+}
+
+func errorIs(err error, code codes.Code) bool {
+	return err != nil && status.Code(err) == code
+}
+
+// sticky pins the calls made with a context to one participant.
+type sticky struct {
+	mu  sync.Mutex
+	url string
+}
+
+func (s *sticky) get() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.url
+}
+
+// set pins s to url unless it is pinned already.
+func (s *sticky) set(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.url == "" {
+		s.url = url
+	}
+}
+
+type stickyKey struct{}
+
+func stickyFrom(ctx context.Context) *sticky {
+	s, _ := ctx.Value(stickyKey{}).(*sticky)
+	return s
+}
+
+// StickyContext returns a context whose Ledger API calls all go to the same
+// participant: the one that answers the first call. Use it for interactive
+// prepare/execute pairs, which must run on one participant. A context that is
+// already sticky, or routed with WithEndpoint, is returned as is.
+func StickyContext(ctx context.Context) context.Context {
+	if stickyFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, stickyKey{}, &sticky{})
+}
+
+// StickyEndpoint returns the participant a StickyContext is pinned to, or ""
+// before its first call. Keep it with a prepared transaction and execute the
+// transaction with WithEndpoint.
+func StickyEndpoint(ctx context.Context) string {
+	if s := stickyFrom(ctx); s != nil {
+		return s.get()
+	}
+	return ""
+}
+
+// WithEndpoint returns a context whose Ledger API calls go to the participant
+// at url, as reported by StickyEndpoint. An empty or unknown url routes calls
+// normally.
+func WithEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, stickyKey{}, &sticky{url: url})
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+)
+
+// participant is an in-process Ledger API stand-in that answers GetLedgerEnd
+// with its own offset and records the methods it is called with.
+type participant struct {
+	lapiv2.UnimplementedStateServiceServer
+	lapiv2.UnimplementedCommandServiceServer
+
+	url    string
+	offset int64
+	server *grpc.Server
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func startParticipant(t *testing.T, offset int64) *participant {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	p := &participant{url: lis.Addr().String(), offset: offset}
+	p.server = grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			p.mu.Lock()
+			p.calls = append(p.calls, info.FullMethod)
+			p.mu.Unlock()
+			return handler(ctx, req)
+		},
+	))
+	lapiv2.RegisterStateServiceServer(p.server, p)
+	lapiv2.RegisterCommandServiceServer(p.server, p)
+	go func() { _ = p.server.Serve(lis) }()
+	t.Cleanup(p.server.Stop)
+	return p
+}
+
+func (p *participant) GetLedgerEnd(context.Context, *lapiv2.GetLedgerEndRequest) (*lapiv2.GetLedgerEndResponse, error) {
+	return &lapiv2.GetLedgerEndResponse{Offset: p.offset}, nil
+}
+
+func (p *participant) SubmitAndWait(context.Context, *lapiv2.SubmitAndWaitRequest) (*lapiv2.SubmitAndWaitResponse, error) {
+	return nil, status.Error(codes.Aborted, "not under test")
+}
+
+func (p *participant) called(method string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, m := range p.calls {
+		if m == method {
+			n++
+		}
+	}
+	return n
+}
+
+type staticAuth struct{}
+
+func (staticAuth) Token(context.Context) (string, time.Time, error) {
+	return "test-token", time.Now().Add(time.Hour), nil
+}
+
+func newFailoverClient(t *testing.T, interval time.Duration, urls ...string) *Client {
+	t.Helper()
+	c, err := New(&Config{
+		RPCURL:              urls[0],
+		FailoverRPCURLs:     urls[1:],
+		HealthCheckInterval: interval,
+		HealthCheckTimeout:  time.Second,
+		TLS:                 &TLSConfig{},
+		Auth:                &AuthConfig{},
+	}, WithAuthProvider(staticAuth{}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestFailover_PrimaryServesWhileUp(t *testing.T) {
+	primary, secondary := startParticipant(t, 100), startParticipant(t, 200)
+	c := newFailoverClient(t, 0, primary.url, secondary.url)
+
+	offset, err := c.GetLedgerEnd(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), offset)
+	assert.Zero(t, secondary.called(lapiv2.StateService_GetLedgerEnd_FullMethodName))
+}
+
+func TestFailover_ReadFailsOver(t *testing.T) {
+	primary, secondary := startParticipant(t, 100), startParticipant(t, 200)
+	c := newFailoverClient(t, 0, primary.url, secondary.url)
+	primary.server.Stop()
+
+	offset, err := c.GetLedgerEnd(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), offset)
+	assert.False(t, c.failover.endpoints[0].healthy.Load())
+}
+
+func TestFailover_CommandNotRetriedOnAnotherParticipant(t *testing.T) {
+	primary, secondary := startParticipant(t, 100), startParticipant(t, 200)
+	c := newFailoverClient(t, 0, primary.url, secondary.url)
+	primary.server.Stop()
+
+	ctx := context.Background()
+	_, err := c.Command().SubmitAndWait(ctx, &lapiv2.SubmitAndWaitRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Zero(t, secondary.called(lapiv2.CommandService_SubmitAndWait_FullMethodName))
+
+	// The primary is now marked down, so the caller's retry goes to the secondary.
+	_, err = c.Command().SubmitAndWait(ctx, &lapiv2.SubmitAndWaitRequest{})
+	require.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, 1, secondary.called(lapiv2.CommandService_SubmitAndWait_FullMethodName))
+}
+
+func TestFailover_StickyContext(t *testing.T) {
+	primary, secondary := startParticipant(t, 100), startParticipant(t, 200)
+	c := newFailoverClient(t, 0, primary.url, secondary.url)
+
+	ctx := StickyContext(context.Background())
+	_, err := c.GetLedgerEnd(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primary.url, StickyEndpoint(ctx))
+
+	// A pinned endpoint is not failed over from.
+	primary.server.Stop()
+	_, err = c.GetLedgerEnd(ctx)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Zero(t, secondary.called(lapiv2.StateService_GetLedgerEnd_FullMethodName))
+}
+
+func TestFailover_WithEndpoint(t *testing.T) {
+	primary, secondary := startParticipant(t, 100), startParticipant(t, 200)
+	c := newFailoverClient(t, 0, primary.url, secondary.url)
+
+	offset, err := c.GetLedgerEnd(WithEndpoint(context.Background(), secondary.url))
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), offset)
+
+	offset, err = c.GetLedgerEnd(WithEndpoint(context.Background(), "unknown:1"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), offset)
+}
+
+func TestFailover_ProbeMarksEndpointDown(t *testing.T) {
+	primary, secondary := startParticipant(t, 100), startParticipant(t, 200)
+	c := newFailoverClient(t, 10*time.Millisecond, primary.url, secondary.url)
+	primary.server.Stop()
+
+	require.Eventually(t, func() bool {
+		return !c.failover.endpoints[0].healthy.Load()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, c.failover.endpoints[1].healthy.Load(), "an Unimplemented answer still shows the participant is up")
+}
+
+func TestFailoverSafe(t *testing.T) {
+	for method, want := range map[string]bool{
+		lapiv2.StateService_GetLedgerEnd_FullMethodName:                                             true,
+		lapiv2.UpdateService_GetUpdates_FullMethodName:                                              true,
+		lapiv2.CommandService_SubmitAndWait_FullMethodName:                                          false,
+		"/com.daml.ledger.api.v2.admin.PartyManagementService/ListKnownParties":                     true,
+		"/com.daml.ledger.api.v2.admin.PartyManagementService/AllocateParty":                        false,
+		"/com.daml.ledger.api.v2.interactive.InteractiveSubmissionService/PrepareSubmission":        true,
+		"/com.daml.ledger.api.v2.interactive.InteractiveSubmissionService/ExecuteSubmissionAndWait": false,
+	} {
+		assert.Equal(t, want, failoverSafe(method), method)
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Call results reported by endpointMetrics.CallsTotal.
+const (
+	resultOK          = "ok"
+	resultError       = "error"       // the participant answered with an error
+	resultUnavailable = "unavailable" // the participant could not be reached
+)
+
+// endpointMetrics holds Prometheus collectors for the participant endpoints.
+type endpointMetrics struct {
+	// CallsTotal counts Ledger API calls per endpoint and result.
+	CallsTotal *prometheus.CounterVec
+
+	// FailoversTotal counts calls moved to the next endpoint because this one
+	// was unavailable.
+	FailoversTotal *prometheus.CounterVec
+
+	// Healthy is 1 while the last probe of the endpoint succeeded.
+	Healthy *prometheus.GaugeVec
+}
+
+// newEndpointMetrics registers endpoint metrics against reg, or against a
+// throwaway registry when reg is nil.
+func newEndpointMetrics(reg sharedmetrics.NamespacedRegisterer) *endpointMetrics {
+	if reg == nil {
+		reg = sharedmetrics.WithNamespace(prometheus.NewRegistry(), "nop")
+	}
+	f := promauto.With(reg)
+	ns := reg.Namespace()
+	sub := "canton_ledger"
+	return &endpointMetrics{
+		CallsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "endpoint_calls_total",
+			Help: "Ledger API calls per participant endpoint, labeled by result (ok, error, unavailable)",
+		}, []string{"endpoint", "result"}),
+
+		FailoversTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "endpoint_failovers_total",
+			Help: "Ledger API calls moved to the next participant endpoint because this one was unavailable",
+		}, []string{"endpoint"}),
+
+		Healthy: f.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "endpoint_healthy",
+			Help: "1 while the last health probe of the participant endpoint succeeded, 0 otherwise",
+		}, []string{"endpoint"}),
+	}
+}
@@ -5,6 +5,8 @@ package ledger
 import (
 	"net/http"
 
+	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
+
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -19,6 +21,8 @@ type settings struct {
 	logger     *zap.Logger
 	httpClient *http.Client
 	dialOpts   []grpc.DialOption
+	registerer sharedmetrics.NamespacedRegisterer
+	commands   CommandStore
 
 	authProvider AuthProvider // optional override, primarily for tests
 }
@@ -38,6 +42,19 @@ func WithGRPCDialOptions(opts ...grpc.DialOption) Option {
 	return func(s *settings) { s.dialOpts = append(s.dialOpts, opts...) }
 }
 
+// WithPrometheusRegisterer registers the participant endpoint metrics against
+// reg. Without it the metrics are not exported.
+func WithPrometheusRegisterer(reg sharedmetrics.NamespacedRegisterer) Option {
+	return func(s *settings) { s.registerer = reg }
+}
+
+// WithCommandStore persists the commands tracked by TrackCommand in store, so
+// that ResolveOutcome can resolve the commands of an earlier process. Without
+// it commands are tracked in memory only.
+func WithCommandStore(store CommandStore) Option {
+	return func(s *settings) { s.commands = store }
+}
+
 // WithAuthProvider overrides the default authentication provider.
 func WithAuthProvider(p AuthProvider) Option {
 	return func(s *settings) { s.authProvider = p }
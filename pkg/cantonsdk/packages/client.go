@@ -0,0 +1,342 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package packages discovers the Daml packages the middleware depends on from
+// the participant's vetted packages.
+//
+// Instead of pinning package IDs in configuration, callers Bind each package ID
+// setting to a Daml package name. Bind picks the highest version vetted on the
+// participant within the configured VersionRange and fills the setting with a
+// package-name reference ("#name"), which the Ledger API resolves to the
+// preferred vetted version on every command and filter. The resolved package
+// ID is used instead when Config.PinPackageIDs is set, or when a higher version
+// than the selected one is vetted: a reference would resolve to that version,
+// outside the range. Settings that already hold a package ID are kept as
+// configured.
+package packages
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	adminv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2/admin"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+
+	"go.uber.org/zap"
+)
+
+// Packages resolves package ID settings against the participant's vetted packages.
+type Packages interface {
+	// Bind resolves every binding whose target is empty and records the
+	// package behind every binding, configured or resolved.
+	Bind(ctx context.Context, bindings ...Binding) error
+
+	// Resolved returns the packages recorded by Bind, sorted by name.
+	Resolved() []daml.Package
+
+	// InRange returns the IDs of the versions of name vetted within its
+	// configured version range, as listed by Bind. It returns nil when any
+	// version of name is accepted: no range is configured, or Bind did not
+	// list name.
+	InRange(name string) []string
+}
+
+// Binding ties a package ID setting to the Daml package it must name.
+type Binding struct {
+	// Name is the Daml package name, e.g. "cip56-token".
+	Name string
+
+	// Target is the package ID setting. An empty target is filled by Bind; a
+	// package-name reference is checked against the version range and pinned
+	// to the selected package ID when it would resolve outside it; a package
+	// ID is kept.
+	Target *string
+
+	// Optional bindings are resolved only when Name has an entry in
+	// Config.Versions. Otherwise an empty target stays empty, which leaves the
+	// feature that depends on it disabled.
+	Optional bool
+}
+
+// packageName returns the package b resolves: the one its target references
+// by name, if any, else Name.
+func (b Binding) packageName() string {
+	if name, ok := strings.CutPrefix(*b.Target, "#"); ok && name != "" {
+		return name
+	}
+	return b.Name
+}
+
+// Client implements Packages.
+type Client struct {
+	cfg    *Config
+	ledger ledger.Ledger
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	resolved map[string]daml.Package
+	inRange  map[string][]string
+}
+
+// New creates a packages client. A nil cfg accepts any vetted version.
+func New(cfg *Config, l ledger.Ledger, opts ...Option) (*Client, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, fmt.Errorf("nil ledger client")
+	}
+	s := applyOptions(opts)
+	return &Client{
+		cfg:      cfg,
+		ledger:   l,
+		logger:   s.logger,
+		resolved: make(map[string]daml.Package),
+		inRange:  make(map[string][]string),
+	}, nil
+}
+
+// Bind implements Packages. The vetted packages are listed once per call, so
+// callers pass every binding of a component together.
+func (c *Client) Bind(ctx context.Context, bindings ...Binding) error {
+	var names []string
+	needed := false
+	for _, b := range bindings {
+		if b.Target == nil {
+			return fmt.Errorf("binding for %s has no target", b.Name)
+		}
+		if !c.wants(b) {
+			continue
+		}
+		names = append(names, b.packageName())
+		if !isPackageID(*b.Target) {
+			needed = true
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	vetted, err := c.listVetted(ctx, names)
+	if err != nil {
+		if needed {
+			return fmt.Errorf("discover packages %s: %w", strings.Join(names, ", "), err)
+		}
+		// Every binding is pinned to a package ID, so discovery only adds the
+		// version to what is reported.
+		c.logger.Warn("Could not list vetted packages; reporting pinned package IDs without versions",
+			zap.Strings("packages", names), zap.Error(err))
+	}
+	return c.bind(vetted, bindings)
+}
+
+// wants reports whether b takes part in discovery.
+func (c *Client) wants(b Binding) bool {
+	if !b.Optional || *b.Target != "" {
+		return true
+	}
+	_, ok := c.cfg.Versions[b.Name]
+	return ok
+}
+
+func (c *Client) bind(vetted []*lapiv2.VettedPackage, bindings []Binding) error {
+	for _, b := range bindings {
+		if !c.wants(b) {
+			continue
+		}
+		if vetted != nil {
+			c.recordRange(vetted, b.packageName())
+		}
+		if target := *b.Target; isPackageID(target) {
+			p := daml.Package{Name: b.Name, ID: target}
+			for _, v := range vetted {
+				if v.GetPackageId() == target {
+					p.Version = v.GetPackageVersion()
+				}
+			}
+			c.record(p, "configured")
+			continue
+		}
+
+		p, err := c.selectPackage(vetted, b.packageName())
+		if err != nil {
+			return err
+		}
+		latest := isLatest(vetted, p)
+		switch {
+		case *b.Target == "" && (c.cfg.PinPackageIDs || !latest):
+			*b.Target = p.ID
+		case *b.Target == "":
+			*b.Target = p.Ref()
+		case !latest:
+			c.logger.Warn("Package-name reference would resolve to a vetted version outside the range; pinning the package ID",
+				zap.String("reference", *b.Target),
+				zap.String("version", p.Version),
+				zap.String("package_id", p.ID))
+			*b.Target = p.ID
+		}
+		c.record(p, "discovered")
+	}
+	return nil
+}
+
+// selectPackage picks the highest version of name among vetted that lies
+// within its configured range.
+func (c *Client) selectPackage(vetted []*lapiv2.VettedPackage, name string) (daml.Package, error) {
+	r := c.cfg.Versions[name]
+	var (
+		best *lapiv2.VettedPackage
+		seen []string
+	)
+	for _, v := range vetted {
+		if v.GetPackageName() != name {
+			continue
+		}
+		seen = append(seen, v.GetPackageVersion())
+		if !r.allows(v.GetPackageVersion()) {
+			continue
+		}
+		if best == nil {
+			best = v
+			continue
+		}
+		// Versions that fail to parse only get here with an open range; keep
+		// the first one seen rather than guess an order.
+		if cmp, err := compareVersions(v.GetPackageVersion(), best.GetPackageVersion()); err == nil && cmp > 0 {
+			best = v
+		}
+	}
+	if best == nil {
+		if len(seen) == 0 {
+			return daml.Package{}, fmt.Errorf("package %s is not vetted on the participant", name)
+		}
+		return daml.Package{}, fmt.Errorf("no vetted version of package %s within %s (vetted: %s)",
+			name, r, strings.Join(seen, ", "))
+	}
+	return daml.Package{Name: name, Version: best.GetPackageVersion(), ID: best.GetPackageId()}, nil
+}
+
+// isLatest reports whether no version of p's package above p is vetted, so
+// that a package-name reference resolves to p. Versions that fail to parse
+// count as higher.
+func isLatest(vetted []*lapiv2.VettedPackage, p daml.Package) bool {
+	for _, v := range vetted {
+		if v.GetPackageName() != p.Name || v.GetPackageId() == p.ID {
+			continue
+		}
+		if cmp, err := compareVersions(v.GetPackageVersion(), p.Version); err != nil || cmp > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// recordRange records the IDs of the versions of name vetted within its
+// configured range, when the range is bounded.
+func (c *Client) recordRange(vetted []*lapiv2.VettedPackage, name string) {
+	r, ok := c.cfg.Versions[name]
+	if !ok || (r.Min == "" && r.Max == "") {
+		return
+	}
+	ids := []string{}
+	for _, v := range vetted {
+		if v.GetPackageName() == name && r.allows(v.GetPackageVersion()) {
+			ids = append(ids, v.GetPackageId())
+		}
+	}
+	c.mu.Lock()
+	c.inRange[name] = ids
+	c.mu.Unlock()
+}
+
+func (c *Client) record(p daml.Package, how string) {
+	c.mu.Lock()
+	c.resolved[p.Name] = p
+	c.mu.Unlock()
+	c.logger.Info("Daml package "+how,
+		zap.String("package", p.Name),
+		zap.String("version", p.Version),
+		zap.String("package_id", p.ID))
+}
+
+// Resolved implements Packages.
+func (c *Client) Resolved() []daml.Package {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]daml.Package, 0, len(c.resolved))
+	for _, p := range c.resolved {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// InRange implements Packages.
+func (c *Client) InRange(name string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.inRange[name])
+}
+
+// listVetted returns the packages named in names that are vetted on this
+// participant and currently valid.
+func (c *Client) listVetted(ctx context.Context, names []string) ([]*lapiv2.VettedPackage, error) {
+	authCtx := c.ledger.AuthContext(ctx)
+
+	participant, err := c.ledger.PartyAdmin().GetParticipantId(authCtx, &adminv2.GetParticipantIdRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("get participant id: %w", err)
+	}
+
+	req := &lapiv2.ListVettedPackagesRequest{
+		PackageMetadataFilter: &lapiv2.PackageMetadataFilter{PackageNamePrefixes: names},
+		TopologyStateFilter: &lapiv2.TopologyStateFilter{
+			ParticipantIds: []string{participant.GetParticipantId()},
+		},
+	}
+	now := time.Now()
+	var out []*lapiv2.VettedPackage
+	for {
+		resp, err := c.ledger.Package().ListVettedPackages(authCtx, req)
+		if err != nil {
+			return nil, fmt.Errorf("list vetted packages: %w", err)
+		}
+		for _, set := range resp.GetVettedPackages() {
+			for _, v := range set.GetPackages() {
+				if vettedAt(v, now) {
+					out = append(out, v)
+				}
+			}
+		}
+		if resp.GetNextPageToken() == "" {
+			return out, nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+// vettedAt reports whether v's vetting window contains t.
+func vettedAt(v *lapiv2.VettedPackage, t time.Time) bool {
+	if from := v.GetValidFromInclusive(); from != nil && t.Before(from.AsTime()) {
+		return false
+	}
+	if until := v.GetValidUntilExclusive(); until != nil && !t.Before(until.AsTime()) {
+		return false
+	}
+	return true
+}
+
+// isPackageID reports whether s is a package ID rather than empty or a
+// package-name reference.
+func isPackageID(s string) bool {
+	return s != "" && !strings.HasPrefix(s, "#")
+}
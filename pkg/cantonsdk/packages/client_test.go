@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package packages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+)
+
+func newTestClient(t *testing.T, cfg *Config) *Client {
+	t.Helper()
+	require.NoError(t, cfg.validate())
+	return &Client{cfg: cfg, logger: zap.NewNop(), resolved: make(map[string]daml.Package), inRange: make(map[string][]string)}
+}
+
+func vetted(name, version, id string) *lapiv2.VettedPackage {
+	return &lapiv2.VettedPackage{PackageName: name, PackageVersion: version, PackageId: id}
+}
+
+var testVetted = []*lapiv2.VettedPackage{
+	vetted("cip56-token", "1.0.0", "cip56-100"),
+	vetted("cip56-token", "1.10.0", "cip56-1100"),
+	vetted("cip56-token", "1.2.0", "cip56-120"),
+	vetted("cip56-token-extras", "9.0.0", "extras-900"),
+	vetted("common", "2.0.0", "common-200"),
+}
+
+func TestBind_PicksHighestVersionInRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       VersionRange
+		wantRef string
+		wantVer string
+	}{
+		{"open range", VersionRange{}, "#cip56-token", "1.10.0"},
+		{"min bound", VersionRange{Min: "1.2.0"}, "#cip56-token", "1.10.0"},
+		// A reference would resolve to the vetted 1.10.0, above the range.
+		{"max bound", VersionRange{Max: "1.9"}, "cip56-120", "1.2.0"},
+		{"min and max", VersionRange{Min: "1.0.0", Max: "1.0.0"}, "cip56-100", "1.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, &Config{Versions: map[string]VersionRange{"cip56-token": tt.r}})
+			var target string
+			require.NoError(t, c.bind(testVetted, []Binding{{Name: "cip56-token", Target: &target}}))
+			assert.Equal(t, tt.wantRef, target)
+			require.Len(t, c.Resolved(), 1)
+			assert.Equal(t, tt.wantVer, c.Resolved()[0].Version)
+		})
+	}
+}
+
+func TestBind_PinPackageIDs(t *testing.T) {
+	c := newTestClient(t, &Config{PinPackageIDs: true})
+	var target string
+	require.NoError(t, c.bind(testVetted, []Binding{{Name: "cip56-token", Target: &target}}))
+	assert.Equal(t, "cip56-1100", target)
+}
+
+func TestBind_ConfiguredTargets(t *testing.T) {
+	c := newTestClient(t, &Config{Versions: map[string]VersionRange{"cip56-token": {Max: "1.2.0"}}})
+	pinned := "cip56-1100" // outside the range, but pinned IDs are kept as configured
+	ref := "#cip56-token"
+	unknown := "not-vetted"
+	require.NoError(t, c.bind(testVetted, []Binding{
+		{Name: "cip56-token", Target: &pinned},
+		{Name: "common", Target: &unknown},
+	}))
+	assert.Equal(t, "cip56-1100", pinned)
+	assert.Equal(t, []daml.Package{
+		{Name: "cip56-token", Version: "1.10.0", ID: "cip56-1100"},
+		{Name: "common", ID: "not-vetted"},
+	}, c.Resolved())
+
+	// The reference would resolve to 1.10.0, so it is pinned to the selected
+	// version.
+	require.NoError(t, c.bind(testVetted, []Binding{{Name: "cip56-token", Target: &ref}}))
+	assert.Equal(t, "cip56-120", ref)
+	assert.Contains(t, c.Resolved(), daml.Package{Name: "cip56-token", Version: "1.2.0", ID: "cip56-120"})
+
+	c = newTestClient(t, &Config{Versions: map[string]VersionRange{"cip56-token": {Min: "1.2.0"}}})
+	ref = "#cip56-token"
+	require.NoError(t, c.bind(testVetted, []Binding{{Name: "cip56-token", Target: &ref}}))
+	assert.Equal(t, "#cip56-token", ref)
+}
+
+func TestInRange(t *testing.T) {
+	c := newTestClient(t, &Config{Versions: map[string]VersionRange{
+		"cip56-token": {Min: "1.1", Max: "1.9"},
+		"common":      {},
+		"bridge-core": {Max: "1.0.0"},
+	}})
+	var token, common, pinned string
+	pinned = "bridge-core-id"
+	require.NoError(t, c.bind(testVetted, []Binding{
+		{Name: "cip56-token", Target: &token},
+		{Name: "common", Target: &common},
+		{Name: "bridge-core", Target: &pinned},
+	}))
+	assert.Equal(t, []string{"cip56-120"}, c.InRange("cip56-token"))
+	assert.Nil(t, c.InRange("common"), "an open range accepts any version")
+	assert.Nil(t, c.InRange("splice-amulet"), "a package Bind did not list accepts any version")
+	assert.Equal(t, []string{}, c.InRange("bridge-core"), "no vetted version lies in the range")
+}
+
+func TestBind_OptionalBindings(t *testing.T) {
+	c := newTestClient(t, &Config{Versions: map[string]VersionRange{"common": {}}})
+	var registry, common string
+	require.NoError(t, c.bind(testVetted, []Binding{
+		{Name: "utility-registry-v0", Target: &registry, Optional: true},
+		{Name: "common", Target: &common, Optional: true},
+	}))
+	assert.Empty(t, registry, "optional package without a version entry stays disabled")
+	assert.Equal(t, "#common", common)
+}
+
+func TestBind_Errors(t *testing.T) {
+	c := newTestClient(t, &Config{Versions: map[string]VersionRange{"cip56-token": {Min: "2.0.0"}}})
+
+	var target string
+	err := c.bind(testVetted, []Binding{{Name: "cip56-token", Target: &target}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no vetted version of package cip56-token within [2.0.0, *]")
+	assert.Contains(t, err.Error(), "1.10.0")
+	assert.Empty(t, target)
+
+	err = c.bind(testVetted, []Binding{{Name: "bridge-core", Target: &target}})
+	assert.ErrorContains(t, err, "package bridge-core is not vetted")
+}
+
+func TestVettedAt(t *testing.T) {
+	now := time.Now()
+	v := vetted("common", "1.0.0", "id")
+	assert.True(t, vettedAt(v, now))
+
+	v.ValidFromInclusive = timestamppb.New(now.Add(time.Hour))
+	assert.False(t, vettedAt(v, now))
+
+	v.ValidFromInclusive = timestamppb.New(now.Add(-time.Hour))
+	v.ValidUntilExclusive = timestamppb.New(now)
+	assert.False(t, vettedAt(v, now))
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"0.9", "1.0", -1},
+	}
+	for _, tt := range tests {
+		got, err := compareVersions(tt.a, tt.b)
+		require.NoError(t, err)
+		assert.Equalf(t, tt.want, got, "compareVersions(%q, %q)", tt.a, tt.b)
+	}
+
+	for _, bad := range []string{"", "1..0", "v1.0", "1.01", "-1"} {
+		_, err := parseVersion(bad)
+		assert.Errorf(t, err, "parseVersion(%q)", bad)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (&Config{Versions: map[string]VersionRange{"common": {Min: "1.0", Max: "1.0.0"}}}).validate())
+	assert.ErrorContains(t,
+		(&Config{Versions: map[string]VersionRange{"common": {Min: "2.0", Max: "1.0"}}}).validate(),
+		"min 2.0 is above max 1.0")
+	assert.ErrorContains(t,
+		(&Config{Versions: map[string]VersionRange{"common": {Max: "latest"}}}).validate(),
+		`invalid package version "latest"`)
+}
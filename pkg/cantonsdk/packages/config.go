@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package packages
+
+import "fmt"
+
+// Config bounds the Daml package versions the middleware accepts.
+type Config struct {
+	// Versions bounds the accepted versions of each Daml package, keyed by
+	// package name (e.g. "cip56-token"). Packages without an entry accept any
+	// vetted version. Optional packages (see Binding) are only discovered when
+	// they have an entry.
+	Versions map[string]VersionRange `yaml:"versions"`
+
+	// PinPackageIDs fills package ID settings with the resolved package ID
+	// instead of a package-name reference ("#name"). Set it for participants
+	// whose Ledger API does not accept package-name references.
+	PinPackageIDs bool `yaml:"pin_package_ids"`
+}
+
+// VersionRange is an inclusive range of Daml package versions. An empty bound
+// leaves that side open.
+type VersionRange struct {
+	Min string `yaml:"min"`
+	Max string `yaml:"max"`
+}
+
+func (r VersionRange) String() string {
+	lo, hi := r.Min, r.Max
+	if lo == "" {
+		lo = "*"
+	}
+	if hi == "" {
+		hi = "*"
+	}
+	return "[" + lo + ", " + hi + "]"
+}
+
+// allows reports whether version lies within r. Unparsable versions are never
+// allowed by a bounded range.
+func (r VersionRange) allows(version string) bool {
+	if r.Min != "" {
+		if c, err := compareVersions(version, r.Min); err != nil || c < 0 {
+			return false
+		}
+	}
+	if r.Max != "" {
+		if c, err := compareVersions(version, r.Max); err != nil || c > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Config) validate() error {
+	for name, r := range c.Versions {
+		for _, v := range []string{r.Min, r.Max} {
+			if v == "" {
+				continue
+			}
+			if _, err := parseVersion(v); err != nil {
+				return fmt.Errorf("packages.versions.%s: %w", name, err)
+			}
+		}
+		if r.Min != "" && r.Max != "" {
+			if c, _ := compareVersions(r.Min, r.Max); c > 0 {
+				return fmt.Errorf("packages.versions.%s: min %s is above max %s", name, r.Min, r.Max)
+			}
+		}
+	}
+	return nil
+}
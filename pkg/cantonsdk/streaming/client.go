@@ -133,24 +133,11 @@ func (c *Client) runStream(
 ) error {
 	authCtx := c.ledger.AuthContext(ctx)
 
-	eventFormat := &lapiv2.EventFormat{Verbose: true}
-	filters := buildTemplateFilters(templateIDs)
-	if c.party == nil {
-		// FiltersForAnyParty subscribes to all contracts on the participant without
-		// restricting to a specific party's stakeholder view. Requires the Canton
-		// auth token to carry CanReadAsAnyParty rights.
-		eventFormat.FiltersForAnyParty = filters
-	} else {
-		eventFormat.FiltersByParty = map[string]*lapiv2.Filters{
-			*c.party: filters,
-		}
-	}
-
 	stream, err := c.ledger.Update().GetUpdates(authCtx, &lapiv2.GetUpdatesRequest{
 		BeginExclusive: fromOffset,
 		UpdateFormat: &lapiv2.UpdateFormat{
 			IncludeTransactions: &lapiv2.TransactionFormat{
-				EventFormat: eventFormat,
+				EventFormat: c.eventFormat(templateIDs),
 				// LEDGER_EFFECTS (rather than ACS_DELTA) so archives arrive as
 				// consuming ExercisedEvents carrying the choice name — consumers
 				// need it to tell an accepted offer from a withdrawn one.
@@ -191,6 +178,24 @@ func (c *Client) runStream(
 	}
 }
 
+// eventFormat builds the EventFormat shared by the update stream and ACS
+// snapshots, so both see exactly the same contracts.
+func (c *Client) eventFormat(templateIDs []TemplateID) *lapiv2.EventFormat {
+	eventFormat := &lapiv2.EventFormat{Verbose: true}
+	filters := buildTemplateFilters(templateIDs)
+	if c.party == nil {
+		// FiltersForAnyParty subscribes to all contracts on the participant without
+		// restricting to a specific party's stakeholder view. Requires the Canton
+		// auth token to carry CanReadAsAnyParty rights.
+		eventFormat.FiltersForAnyParty = filters
+	} else {
+		eventFormat.FiltersByParty = map[string]*lapiv2.Filters{
+			*c.party: filters,
+		}
+	}
+	return eventFormat
+}
+
 // buildTemplateFilters constructs the Filters value for a set of TemplateIDs.
 //
 // This is the gRPC-level (template-level) filter. It controls which contract types
@@ -257,17 +262,7 @@ func decodeLedgerTransaction(tx *lapiv2.Transaction) *LedgerTransaction {
 // non-consuming exercises, plain ArchivedEvents from ACS_DELTA streams).
 func decodeLedgerEvent(ev *lapiv2.Event) *LedgerEvent {
 	if created := ev.GetCreated(); created != nil && created.GetAcsDelta() {
-		le := &LedgerEvent{
-			ContractID: created.GetContractId(),
-			IsCreated:  true,
-			fields:     values.RecordToMap(created.GetCreateArguments()),
-		}
-		if tid := created.GetTemplateId(); tid != nil {
-			le.PackageID = tid.GetPackageId()
-			le.ModuleName = tid.GetModuleName()
-			le.TemplateName = tid.GetEntityName()
-		}
-		return le
+		return decodeCreatedEvent(created)
 	}
 
 	if exercised := ev.GetExercised(); exercised != nil && exercised.GetConsuming() && exercised.GetAcsDelta() {
@@ -287,6 +282,22 @@ func decodeLedgerEvent(ev *lapiv2.Event) *LedgerEvent {
 	return nil
 }
 
+// decodeCreatedEvent converts a proto CreatedEvent into a created LedgerEvent
+// with its CreateArguments pre-decoded.
+func decodeCreatedEvent(created *lapiv2.CreatedEvent) *LedgerEvent {
+	le := &LedgerEvent{
+		ContractID: created.GetContractId(),
+		IsCreated:  true,
+		fields:     values.RecordToMap(created.GetCreateArguments()),
+	}
+	if tid := created.GetTemplateId(); tid != nil {
+		le.PackageID = tid.GetPackageId()
+		le.ModuleName = tid.GetModuleName()
+		le.TemplateName = tid.GetEntityName()
+	}
+	return le
+}
+
 // isAuthError returns true if err signals authentication or authorisation failure.
 func isAuthError(err error) bool {
 	if err == nil {
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+
+	"go.uber.org/zap"
+)
+
+// Snapshotter is the interface for reading the active contract set (ACS) at a
+// ledger offset. *Client satisfies this interface.
+type Snapshotter interface {
+	ActiveContracts(ctx context.Context, req SnapshotRequest) (*Snapshot, error)
+}
+
+// ActiveContracts reads every active contract of the requested templates as of
+// req.AtOffset (the current ledger end when zero) through StateService.
+// GetActiveContracts. It uses the same party filter as Subscribe, so a snapshot
+// followed by Subscribe(FromOffset: snapshot.Offset) observes each contract
+// exactly once.
+//
+// An empty ledger (end offset 0) yields an empty snapshot at offset 0.
+func (c *Client) ActiveContracts(ctx context.Context, req SnapshotRequest) (*Snapshot, error) {
+	offset := req.AtOffset
+	if offset == 0 {
+		end, err := c.ledger.GetLedgerEnd(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get ledger end: %w", err)
+		}
+		offset = end
+	}
+	snap := &Snapshot{Offset: offset}
+	if offset == 0 {
+		return snap, nil
+	}
+
+	authCtx := c.ledger.AuthContext(ctx)
+	stream, err := c.ledger.State().GetActiveContracts(authCtx, &lapiv2.GetActiveContractsRequest{
+		ActiveAtOffset: offset,
+		EventFormat:    c.eventFormat(req.TemplateIDs),
+	})
+	if err != nil {
+		if isAuthError(err) {
+			c.ledger.InvalidateToken()
+		}
+		return nil, fmt.Errorf("open active contracts stream: %w", err)
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if isAuthError(err) {
+				c.ledger.InvalidateToken()
+			}
+			return nil, fmt.Errorf("receive active contract: %w", err)
+		}
+		created := msg.GetActiveContract().GetCreatedEvent()
+		if created == nil {
+			// Incomplete reassignments are not part of the settled ACS.
+			continue
+		}
+		snap.Contracts = append(snap.Contracts, &ActiveContract{
+			Event:         decodeCreatedEvent(created),
+			CreatedOffset: created.GetOffset(),
+			CreatedAt:     created.GetCreatedAt().AsTime(),
+		})
+	}
+
+	c.logger.Info("read active contract snapshot",
+		zap.Int64("offset", snap.Offset),
+		zap.Int("contracts", len(snap.Contracts)),
+	)
+	return snap, nil
+}
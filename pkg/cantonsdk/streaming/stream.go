@@ -2,14 +2,18 @@
 
 package streaming
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Batch carries decoded items from one LedgerTransaction, preserving the
 // transaction boundary for atomic offset writes.
 type Batch[T any] struct {
-	Offset   int64
-	UpdateID string
-	Items    []T
+	Offset        int64
+	UpdateID      string
+	EffectiveTime time.Time
+	Items         []T
 }
 
 // Stream[T] wraps a Streamer and applies a per-event decode function.
@@ -40,9 +44,10 @@ func (s *Stream[T]) Subscribe(ctx context.Context, req SubscribeRequest, lastOff
 					return
 				}
 				batch := &Batch[T]{
-					Offset:   tx.Offset,
-					UpdateID: tx.UpdateID,
-					Items:    make([]T, 0, len(tx.Events)),
+					Offset:        tx.Offset,
+					UpdateID:      tx.UpdateID,
+					EffectiveTime: tx.EffectiveTime,
+					Items:         make([]T, 0, len(tx.Events)),
 				}
 				for _, ev := range tx.Events {
 					if item, ok := s.decode(tx, ev); ok {
@@ -9,6 +9,7 @@ package streaming
 import (
 	"time"
 
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
 )
@@ -172,6 +173,27 @@ func (e *LedgerEvent) DoublyNestedTextField(outer, middle, field string) string
 	return values.NestedTextField(mid[middle], field)
 }
 
+// Record returns a view of the DAML Record reached by following path from the
+// event's create arguments, so every field accessor can be used on it.
+// Example: event.Record("allocation", "transferLeg").PartyField("sender")
+// A missing or non-Record path segment yields an empty view whose accessors
+// return their zero values. The view carries the event's identity fields.
+func (e *LedgerEvent) Record(path ...string) *LedgerEvent {
+	fields := e.fields
+	for _, name := range path {
+		fields = values.RecordField(fields[name])
+	}
+	view := *e
+	view.fields = fields
+	return &view
+}
+
+// Decode decodes the event's create arguments into a generated Daml record
+// (see cantonsdk/daml). Fields absent from the event keep their value in into.
+func (e *LedgerEvent) Decode(into daml.FieldsDecoder) error {
+	return into.FromFields(e.fields)
+}
+
 // OptionalMetaLookup looks up a string key within an Optional Metadata field.
 // Metadata is encoded as Optional(Record{values: Map Text Text}).
 // Returns "" when the Optional is None, the key is absent, or the field is absent.
@@ -182,3 +204,27 @@ func (e *LedgerEvent) OptionalMetaLookup(metaField, key string) string {
 	}
 	return values.MapLookupText(inner["values"], key)
 }
+
+// SnapshotRequest configures an active contract set read.
+type SnapshotRequest struct {
+	// AtOffset is the offset the ACS is read at. Use 0 for the current ledger end.
+	AtOffset int64
+
+	// TemplateIDs lists the DAML templates to include.
+	TemplateIDs []TemplateID
+}
+
+// Snapshot is the set of contracts active at Offset. Streaming from Offset
+// (exclusive) afterwards continues exactly where the snapshot ends.
+type Snapshot struct {
+	Offset    int64
+	Contracts []*ActiveContract
+}
+
+// ActiveContract is one contract of a Snapshot. Event is always a created event;
+// CreatedOffset and CreatedAt describe the transaction that created the contract.
+type ActiveContract struct {
+	Event         *LedgerEvent
+	CreatedOffset int64
+	CreatedAt     time.Time
+}
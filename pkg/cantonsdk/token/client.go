@@ -10,8 +10,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"time"
 
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/cip56"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/registry"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/splice"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/identity"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
 	interactivev2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2/interactive"
@@ -34,26 +39,6 @@ const (
 	// cache deadline, unrelated to the on-ledger transfer validity (executeBefore),
 	// which is supplied per-call by the caller.
 	preparedTxCacheTTL = time.Hour
-
-	moduleConfig          = "CIP56.Config"
-	entityTokenConfig     = "TokenConfig"
-	moduleToken           = "CIP56.Token"
-	entityHolding         = "CIP56Holding"
-	moduleTransferFactory = "CIP56.TransferFactory"
-	entityTransferFactory = "CIP56TransferFactory"
-	moduleEvents          = "CIP56.Events"
-
-	spliceTransferModule  = "Splice.Api.Token.TransferInstructionV1"
-	spliceTransferFactory = "TransferFactory"
-	transferInstrEntity   = "TransferInstruction"
-	acceptChoice          = "TransferInstruction_Accept"
-	withdrawChoice        = "TransferInstruction_Withdraw"
-
-	spliceHoldingModule = "Splice.Api.Token.HoldingV1"
-	spliceHoldingEntity = "Holding"
-
-	moduleTransferOffer = "Utility.Registry.App.V0.Model.Transfer"
-	entityTransferOffer = "TransferOffer"
 )
 
 // Token defines CIP-56 token operations.
@@ -78,7 +63,13 @@ type Token interface {
 
 	// GetAllHoldings returns all CIP56Holding contracts queried as IssuerParty.
 	// Used by the indexer and totalSupply — does NOT use the unified HoldingV1 path.
-	GetAllHoldings(ctx context.Context) ([]*Holding, error) // TODO: use pagination
+	// Prefer AllHoldings where the result need not be held in memory at once.
+	GetAllHoldings(ctx context.Context) ([]*Holding, error)
+
+	// AllHoldings streams the CIP56Holding contracts visible to IssuerParty at the
+	// ledger end that match filter, holding one contract in memory at a time.
+	// An error ends the sequence.
+	AllHoldings(ctx context.Context, filter HoldingFilter) iter.Seq2[*Holding, error]
 
 	// GetBalanceByFingerprint returns the owner's total balance (sum of holdings) for the token symbol.
 	GetBalanceByFingerprint(ctx context.Context, fingerprint string, tokenSymbol string) (string, error)
@@ -112,6 +103,11 @@ type Token interface {
 		ctx context.Context, idempotencyKey, fromParty, toParty, amount, tokenSymbol string, validity time.Duration,
 	) error
 
+	// ResolveOutcome reports what became of a transfer, merge or split submitted
+	// with commandID as its idempotency key. Call it before retrying one whose
+	// submission ended ambiguously, e.g. on a timeout. See ledger.Client.ResolveOutcome.
+	ResolveOutcome(ctx context.Context, commandID string) (*ledger.Outcome, error)
+
 	// GetTokenTransferEvents returns all active CIP56.Events.TokenTransferEvent contracts visible to relayerParty.
 	GetTokenTransferEvents(ctx context.Context) ([]*TokenTransferEvent, error)
 
@@ -136,6 +132,11 @@ type Token interface {
 	// the AnyValue choiceContext, and exercises TransferInstruction_Accept via SubmitAndWait.
 	AcceptTransferInstruction(ctx context.Context, partyID, instructionCID, instrumentAdmin string) error
 
+	// RejectTransferInstruction rejects a pending inbound transfer for a custodial party
+	// by exercising TransferInstruction_Reject server-side, returning the locked holding
+	// to the sender immediately instead of letting the offer expire.
+	RejectTransferInstruction(ctx context.Context, partyID, instructionCID, instrumentAdmin string) error
+
 	// PrepareAcceptTransfer builds a Canton transaction for accepting a pending inbound
 	// transfer instruction and returns the hash that the client must sign externally.
 	// Use ExecuteTransfer to complete the accept once the client has signed.
@@ -153,6 +154,23 @@ type Token interface {
 	PrepareWithdrawTransfer(
 		ctx context.Context, partyID, instructionCID, instrumentAdmin string,
 	) (*PreparedTransfer, error)
+
+	// MergeHoldings consolidates up to MaxMergeInputs unlocked holdings of the token
+	// into one via a server-signed self-transfer (KeyResolver required). Returns the
+	// merged inputs, or ErrNothingToMerge when fewer than two holdings exist.
+	MergeHoldings(ctx context.Context, commandID, partyID, tokenSymbol string) (*HoldingSelection, error)
+
+	// PrepareMergeHoldings builds the merge self-transfer for external signing.
+	// Use ExecuteTransfer to complete it.
+	PrepareMergeHoldings(ctx context.Context, partyID, tokenSymbol string) (*PreparedTransfer, error)
+
+	// SplitHolding splits holdingCID into a holding of exactly amount plus change via
+	// a server-signed self-transfer, returning the change holding's contract id.
+	SplitHolding(ctx context.Context, commandID, partyID, tokenSymbol, holdingCID, amount string) (string, error)
+
+	// PrepareSplitHolding builds the split self-transfer for external signing.
+	// Use ExecuteTransfer to complete it.
+	PrepareSplitHolding(ctx context.Context, partyID, tokenSymbol, holdingCID, amount string) (*PreparedTransfer, error)
 }
 
 // Client implements CIP-56 token operations.
@@ -198,11 +216,7 @@ func (c *Client) GetTokenConfigCID(ctx context.Context, tokenSymbol string) (str
 		return "", fmt.Errorf("ledger is empty, no contracts exist")
 	}
 
-	tid := &lapiv2.Identifier{
-		PackageId:  c.cfg.CIP56PackageID,
-		ModuleName: moduleConfig,
-		EntityName: entityTokenConfig,
-	}
+	tid := cip56.TokenConfigTemplate.ID(c.cfg.CIP56PackageID)
 
 	events, err := c.ledger.GetActiveContractsByTemplate(ctx, end, []string{c.cfg.IssuerParty}, tid)
 	if err != nil {
@@ -236,13 +250,9 @@ func (c *Client) Mint(ctx context.Context, req *MintRequest) (string, error) {
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.cfg.CIP56PackageID,
-					ModuleName: moduleConfig,
-					EntityName: entityTokenConfig,
-				},
+				TemplateId:     cip56.TokenConfigTemplate.ID(c.cfg.CIP56PackageID),
 				ContractId:     cid,
-				Choice:         "IssuerMint",
+				Choice:         cip56.ChoiceIssuerMint,
 				ChoiceArgument: &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: encodeIssuerMintArgs(req)}},
 			},
 		},
@@ -270,7 +280,7 @@ func (c *Client) Mint(ctx context.Context, req *MintRequest) (string, error) {
 		if created == nil || created.TemplateId == nil {
 			continue
 		}
-		if created.TemplateId.ModuleName == moduleToken && created.TemplateId.EntityName == entityHolding {
+		if cip56.CIP56HoldingTemplate.Matches(created.TemplateId) {
 			return created.ContractId, nil
 		}
 	}
@@ -292,13 +302,9 @@ func (c *Client) Burn(ctx context.Context, req *BurnRequest) error {
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.cfg.CIP56PackageID,
-					ModuleName: moduleConfig,
-					EntityName: entityTokenConfig,
-				},
+				TemplateId:     cip56.TokenConfigTemplate.ID(c.cfg.CIP56PackageID),
 				ContractId:     configCID,
-				Choice:         "IssuerBurn",
+				Choice:         cip56.ChoiceIssuerBurn,
 				ChoiceArgument: &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: encodeIssuerBurnArgs(req)}},
 			},
 		},
@@ -343,34 +349,39 @@ func (c *Client) GetHoldingsByParty(ctx context.Context, ownerParty, instrumentI
 	if err != nil {
 		return nil, err
 	}
-	if end == 0 {
+	return c.GetHoldingsByPartyAt(ctx, ownerParty, instrumentID, end)
+}
+
+// GetHoldingsByPartyAt is GetHoldingsByParty against the active contract set
+// at offset rather than at ledger end, for comparing the ledger with state
+// derived up to that offset. offset must not be pruned.
+func (c *Client) GetHoldingsByPartyAt(ctx context.Context, ownerParty, instrumentID string, offset int64) ([]*Holding, error) {
+	if ownerParty == "" {
+		return nil, fmt.Errorf("owner party is required")
+	}
+	if offset == 0 {
 		return []*Holding{}, nil
 	}
 
-	iid := &lapiv2.Identifier{
-		PackageId:  c.cfg.SpliceHoldingPackageID,
-		ModuleName: spliceHoldingModule,
-		EntityName: spliceHoldingEntity,
-	}
+	iid := splice.HoldingInterface.ID(c.cfg.SpliceHoldingPackageID)
 
 	// GetActiveContractsByInterface returns CreatedEvents with create_arguments populated
 	// (Required field per Canton Ledger API v2 proto), so decodeHolding works identically
 	// for both template-based and interface-based queries.
-	events, err := c.ledger.GetActiveContractsByInterface(ctx, end, []string{ownerParty}, iid)
-	if err != nil {
-		return nil, fmt.Errorf("query holdings by party: %w", err)
-	}
-
-	out := make([]*Holding, 0, len(events))
-	for _, ce := range events {
-		h := decodeHolding(ce)
-		if h.Owner != ownerParty {
-			continue
+	// Stream the party's view so holdings of other instruments are never retained.
+	filter := HoldingFilter{Owner: ownerParty, InstrumentID: instrumentID}
+	out := []*Holding{}
+	for ce, err := range c.ledger.ActiveContractsByInterface(ctx, offset, []string{ownerParty}, iid) {
+		if err != nil {
+			return nil, fmt.Errorf("query holdings by party: %w", err)
 		}
-		if instrumentID != "" && h.InstrumentID != instrumentID {
-			continue
+		h, err := decodeHolding(ce)
+		if err != nil {
+			return nil, err
+		}
+		if filter.matches(h) {
+			out = append(out, h)
 		}
-		out = append(out, h)
 	}
 	return out, nil
 }
@@ -380,26 +391,65 @@ func (c *Client) GetAllHoldings(ctx context.Context) ([]*Holding, error) {
 	if err != nil {
 		return nil, err
 	}
-	if end == 0 {
-		return []*Holding{}, nil
-	}
+	return c.GetAllHoldingsAt(ctx, end)
+}
 
-	tid := &lapiv2.Identifier{
-		PackageId:  c.cfg.CIP56PackageID,
-		ModuleName: moduleToken,
-		EntityName: entityHolding,
+// GetAllHoldingsAt returns every CIP56Holding visible to the issuer party in
+// the active contract set at offset. offset must not be pruned.
+func (c *Client) GetAllHoldingsAt(ctx context.Context, offset int64) ([]*Holding, error) {
+	out := []*Holding{}
+	for h, err := range c.AllHoldingsAt(ctx, offset, HoldingFilter{}) {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, h)
 	}
+	return out, nil
+}
 
-	events, err := c.ledger.GetActiveContractsByTemplate(ctx, end, []string{c.cfg.IssuerParty}, tid)
-	if err != nil {
-		return nil, fmt.Errorf("query holdings: %w", err)
+func (c *Client) AllHoldings(ctx context.Context, filter HoldingFilter) iter.Seq2[*Holding, error] {
+	return func(yield func(*Holding, error) bool) {
+		end, err := c.ledger.GetLedgerEnd(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for h, err := range c.AllHoldingsAt(ctx, end, filter) {
+			if !yield(h, err) {
+				return
+			}
+		}
 	}
+}
 
-	out := make([]*Holding, 0, len(events))
-	for _, ce := range events {
-		out = append(out, decodeHolding(ce))
+// AllHoldingsAt is AllHoldings against the active contract set at offset.
+// offset must not be pruned.
+func (c *Client) AllHoldingsAt(ctx context.Context, offset int64, filter HoldingFilter) iter.Seq2[*Holding, error] {
+	return func(yield func(*Holding, error) bool) {
+		if offset == 0 {
+			return
+		}
+
+		tid := cip56.CIP56HoldingTemplate.ID(c.cfg.CIP56PackageID)
+
+		for ce, err := range c.ledger.ActiveContractsByTemplate(ctx, offset, []string{c.cfg.IssuerParty}, tid) {
+			if err != nil {
+				yield(nil, fmt.Errorf("query holdings: %w", err))
+				return
+			}
+			h, err := decodeHolding(ce)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !filter.matches(h) {
+				continue
+			}
+			if !yield(h, nil) {
+				return
+			}
+		}
 	}
-	return out, nil
 }
 
 func (c *Client) GetBalanceByFingerprint(ctx context.Context, fingerprint string, tokenSymbol string) (string, error) {
@@ -441,11 +491,7 @@ func (c *Client) GetTotalSupply(ctx context.Context, tokenSymbol string) (string
 		return "0", nil
 	}
 
-	tid := &lapiv2.Identifier{
-		PackageId:  c.cfg.CIP56PackageID,
-		ModuleName: moduleToken,
-		EntityName: entityHolding,
-	}
+	tid := cip56.CIP56HoldingTemplate.ID(c.cfg.CIP56PackageID)
 
 	events, err := c.ledger.GetActiveContractsByTemplate(ctx, end, []string{c.cfg.IssuerParty}, tid)
 	if err != nil {
@@ -538,17 +584,25 @@ func (c *Client) TransferInternalByPartyID(
 	if err != nil {
 		return err
 	}
+	commands := &lapiv2.Commands{
+		SynchronizerId: c.cfg.DomainID,
+		CommandId:      idempotencyKey,
+		UserId:         c.cfg.UserID,
+		ActAs:          []string{fromParty},
+		ReadAs:         []string{c.cfg.IssuerParty},
+		Commands:       []*lapiv2.Command{cmd},
+	}
+	ctx, sub, err := c.ledger.TrackCommand(ctx, commands.UserId, commands.CommandId, commands.ActAs)
+	if err != nil {
+		return err
+	}
+	sub.ApplyToCommands(commands)
+
 	authCtx := c.ledger.AuthContext(ctx)
 	_, err = c.ledger.Command().SubmitAndWaitForTransaction(authCtx, &lapiv2.SubmitAndWaitForTransactionRequest{
-		Commands: &lapiv2.Commands{
-			SynchronizerId: c.cfg.DomainID,
-			CommandId:      idempotencyKey,
-			UserId:         c.cfg.UserID,
-			ActAs:          []string{fromParty},
-			ReadAs:         []string{c.cfg.IssuerParty},
-			Commands:       []*lapiv2.Command{cmd},
-		},
+		Commands: commands,
 	})
+	c.ledger.SettleCommand(commands.CommandId, err)
 	return err
 }
 
@@ -616,6 +670,10 @@ func (c *Client) TransferByPartyID(
 	return c.transferViaFactory(ctx, req)
 }
 
+func (c *Client) ResolveOutcome(ctx context.Context, commandID string) (*ledger.Outcome, error) {
+	return c.ledger.ResolveOutcome(ctx, commandID)
+}
+
 type transferFactoryRequest struct {
 	CommandID        string
 	FromPartyID      string
@@ -675,7 +733,13 @@ func (c *Client) transferViaFactory(ctx context.Context, req *transferFactoryReq
 		DisclosedContracts: req.DisclosedContracts,
 	}
 
-	return c.prepareAndExecuteAsUser(ctx, commands, signerKey, req.FromPartyID)
+	ctx, sub, err := c.ledger.TrackCommand(ctx, commands.UserId, commands.CommandId, commands.ActAs)
+	if err != nil {
+		return err
+	}
+	err = c.prepareAndExecuteAsUser(ctx, commands, signerKey, req.FromPartyID, sub)
+	c.ledger.SettleCommand(commands.CommandId, err)
+	return err
 }
 
 // buildTransferCommand creates the exercise command for a TransferFactory_Transfer.
@@ -696,36 +760,23 @@ func (c *Client) buildTransferCommand(req *transferFactoryRequest) (*lapiv2.Comm
 
 	// For AllocationFactory tokens the choice context must be encoded as TextMap AnyValue.
 	// For CIP56TransferFactory tokens (empty context) the standard TextMap Text encoding is used.
-	var extraArgsValue *lapiv2.Value
+	var extraArgs splice.ExtraArgs
 	if len(req.AnyValueContext.Values) > 0 {
-		ctxValue, err := encodeChoiceContextRecord(req.AnyValueContext)
+		choiceCtx, err := encodeChoiceContext(req.AnyValueContext)
 		if err != nil {
 			return nil, fmt.Errorf("encode choice context: %w", err)
 		}
-		extraArgsValue = &lapiv2.Value{
-			Sum: &lapiv2.Value_Record{
-				Record: &lapiv2.Record{
-					Fields: []*lapiv2.RecordField{
-						{Label: "context", Value: ctxValue},
-						{Label: "meta", Value: values.EmptyMetadata()},
-					},
-				},
-			},
-		}
+		extraArgs.Context = choiceCtx
 	} else {
-		extraArgsValue = values.EncodeExtraArgs(req.ChoiceContext)
+		extraArgs.Context = encodeTextChoiceContext(req.ChoiceContext)
 	}
 
 	return &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.cfg.SpliceTransferPackageID,
-					ModuleName: spliceTransferModule,
-					EntityName: spliceTransferFactory,
-				},
+				TemplateId: splice.TransferFactoryInterface.ID(c.cfg.SpliceTransferPackageID),
 				ContractId: req.FactoryCID,
-				Choice:     "TransferFactory_Transfer",
+				Choice:     splice.ChoiceTransferFactoryTransfer,
 				ChoiceArgument: &lapiv2.Value{
 					Sum: &lapiv2.Value_Record{
 						Record: encodeTransferFactoryTransferArgs(
@@ -738,7 +789,7 @@ func (c *Client) buildTransferCommand(req *transferFactoryRequest) (*lapiv2.Comm
 							requestedAt,
 							executeBefore,
 							req.InputHoldingCIDs,
-							extraArgsValue,
+							extraArgs,
 						),
 					},
 				},
@@ -771,11 +822,7 @@ func (c *Client) getTransferFactoryCID(ctx context.Context) (string, error) {
 // Uses an interface filter keyed on SpliceTransferPackageID so the concrete
 // template's package ID never needs to be known.
 func (c *Client) GetAllocationFactory(ctx context.Context) (*TransferFactoryInfo, error) {
-	ifaceID := &lapiv2.Identifier{
-		PackageId:  c.cfg.SpliceTransferPackageID,
-		ModuleName: spliceTransferModule,
-		EntityName: spliceTransferFactory,
-	}
+	ifaceID := splice.TransferFactoryInterface.ID(c.cfg.SpliceTransferPackageID)
 	filter := &lapiv2.CumulativeFilter{
 		IdentifierFilter: &lapiv2.CumulativeFilter_InterfaceFilter{
 			InterfaceFilter: &lapiv2.InterfaceFilter{
@@ -822,19 +869,11 @@ func (c *Client) getUtilityRegistryChoiceContext(ctx context.Context) (AcceptCho
 		return AcceptChoiceContext{}, fmt.Errorf("get ledger end: %w", err)
 	}
 
-	configCID, err := c.firstContractCID(ctx, end, &lapiv2.Identifier{
-		PackageId:  c.cfg.UtilityRegistryPackageID,
-		ModuleName: "Utility.Registry.V0.Configuration.Instrument",
-		EntityName: "InstrumentConfiguration",
-	})
+	configCID, err := c.firstContractCID(ctx, end, registry.InstrumentConfigurationTemplate.ID(c.cfg.UtilityRegistryPackageID))
 	if err != nil {
 		return AcceptChoiceContext{}, fmt.Errorf("InstrumentConfiguration: %w", err)
 	}
-	ruleCID, err := c.firstContractCID(ctx, end, &lapiv2.Identifier{
-		PackageId:  c.cfg.UtilityRegistryPackageID,
-		ModuleName: "Utility.Registry.V0.Rule.Transfer",
-		EntityName: "TransferRule",
-	})
+	ruleCID, err := c.firstContractCID(ctx, end, registry.TransferRuleTemplate.ID(c.cfg.UtilityRegistryPackageID))
 	if err != nil {
 		return AcceptChoiceContext{}, fmt.Errorf("TransferRule: %w", err)
 	}
@@ -1003,11 +1042,7 @@ func (c *Client) resolveTransferFactory(ctx context.Context, req *transferFactor
 }
 
 func (c *Client) GetTransferFactory(ctx context.Context) (*TransferFactoryInfo, error) {
-	tid := &lapiv2.Identifier{
-		PackageId:  c.cfg.CIP56PackageID,
-		ModuleName: moduleTransferFactory,
-		EntityName: entityTransferFactory,
-	}
+	tid := cip56.TransferFactoryTemplate.ID(c.cfg.CIP56PackageID)
 	filter := &lapiv2.CumulativeFilter{
 		IdentifierFilter: &lapiv2.CumulativeFilter_TemplateFilter{
 			TemplateFilter: &lapiv2.TemplateFilter{
@@ -1035,8 +1070,14 @@ func (c *Client) GetTransferFactory(ctx context.Context) (*TransferFactoryInfo,
 // prepareAndExecuteAsUser uses the Interactive Submission API to submit a
 // transaction on behalf of an external party. It prepares the transaction,
 // signs the hash with the party's private key, and executes it.
-func (c *Client) prepareAndExecuteAsUser(ctx context.Context, commands *lapiv2.Commands, signerKey Signer, partyID string) error {
-	authCtx := c.ledger.AuthContext(ctx)
+//
+// sub carries the deduplication settings of a command tracked with
+// ledger.TrackCommand; pass nil for an untracked command.
+func (c *Client) prepareAndExecuteAsUser(
+	ctx context.Context, commands *lapiv2.Commands, signerKey Signer, partyID string, sub *ledger.Submission,
+) error {
+	// The execute must reach the participant that prepared the transaction.
+	authCtx := c.ledger.AuthContext(ledger.StickyContext(ctx))
 
 	prepResp, err := c.ledger.Interactive().PrepareSubmission(authCtx, &interactivev2.PrepareSubmissionRequest{
 		UserId:             commands.UserId,
@@ -1077,13 +1118,17 @@ func (c *Client) prepareAndExecuteAsUser(ctx context.Context, commands *lapiv2.C
 		},
 	}
 
-	_, err = c.ledger.Interactive().ExecuteSubmissionAndWait(authCtx, &interactivev2.ExecuteSubmissionAndWaitRequest{
+	execReq := &interactivev2.ExecuteSubmissionAndWaitRequest{
 		PreparedTransaction:  prepResp.PreparedTransaction,
 		PartySignatures:      partySigs,
 		SubmissionId:         uuid.NewString(),
 		UserId:               commands.UserId,
 		HashingSchemeVersion: prepResp.HashingSchemeVersion,
-	})
+	}
+	if sub != nil {
+		sub.ApplyToExecute(execReq)
+	}
+	_, err = c.ledger.Interactive().ExecuteSubmissionAndWait(authCtx, execReq)
 	if err != nil {
 		return fmt.Errorf("execute submission: %w", err)
 	}
@@ -1097,27 +1142,40 @@ type selectedHoldings struct {
 	InstrumentID    string
 }
 
-// selectHoldingsForTransfer selects holdings whose combined value covers the
-// required transfer amount. With multi-input TransferFactory, fragmentation
-// is no longer an issue -- we just accumulate holdings until we have enough.
-func selectHoldingsForTransfer(holdings []*Holding, requiredAmount string) (*selectedHoldings, error) {
+// HoldingSelection is the set of input holdings a transfer of a given amount
+// would consume. Total is the sum of the selected amounts and is always at
+// least the required amount; the difference is returned to the sender as change.
+type HoldingSelection struct {
+	Holdings        []*Holding
+	InstrumentAdmin string
+	InstrumentID    string
+	Total           string
+}
+
+// SelectHoldings picks holdings whose combined value covers requiredAmount,
+// skipping locked holdings and any holding of a different instrument than the
+// first one selected. It is the same selection PrepareTransfer and
+// TransferByPartyID apply, exported so callers can preview a transfer's inputs
+// without submitting anything. Returns an error wrapping ErrInsufficientBalance
+// when the unlocked holdings do not cover the amount.
+func SelectHoldings(holdings []*Holding, requiredAmount string) (*HoldingSelection, error) {
 	if len(holdings) == 0 {
 		return nil, fmt.Errorf("%w: no holdings found", ErrInsufficientBalance)
 	}
 
-	result := &selectedHoldings{}
+	result := &HoldingSelection{}
 	total := "0"
 	for _, h := range holdings {
 		if h.Locked {
 			continue
 		}
-		if len(result.CIDs) == 0 {
+		if len(result.Holdings) == 0 {
 			result.InstrumentAdmin = h.InstrumentAdmin
 			result.InstrumentID = h.InstrumentID
 		} else if h.InstrumentAdmin != result.InstrumentAdmin || h.InstrumentID != result.InstrumentID {
 			continue
 		}
-		result.CIDs = append(result.CIDs, h.ContractID)
+		result.Holdings = append(result.Holdings, h)
 		next, err := addDecimalStrings(total, h.Amount)
 		if err != nil {
 			return nil, err
@@ -1129,6 +1187,7 @@ func selectHoldingsForTransfer(holdings []*Holding, requiredAmount string) (*sel
 			return nil, err
 		}
 		if cmp >= 0 {
+			result.Total = total
 			return result, nil
 		}
 	}
@@ -1137,6 +1196,25 @@ func selectHoldingsForTransfer(holdings []*Holding, requiredAmount string) (*sel
 		ErrInsufficientBalance, total, requiredAmount)
 }
 
+// selectHoldingsForTransfer selects holdings whose combined value covers the
+// required transfer amount. With multi-input TransferFactory, fragmentation
+// is no longer an issue -- we just accumulate holdings until we have enough.
+func selectHoldingsForTransfer(holdings []*Holding, requiredAmount string) (*selectedHoldings, error) {
+	sel, err := SelectHoldings(holdings, requiredAmount)
+	if err != nil {
+		return nil, err
+	}
+	cids := make([]string, 0, len(sel.Holdings))
+	for _, h := range sel.Holdings {
+		cids = append(cids, h.ContractID)
+	}
+	return &selectedHoldings{
+		CIDs:            cids,
+		InstrumentAdmin: sel.InstrumentAdmin,
+		InstrumentID:    sel.InstrumentID,
+	}, nil
+}
+
 func (c *Client) PrepareTransfer(ctx context.Context, req *PrepareTransferRequest) (*PreparedTransfer, error) {
 	if err := req.validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
@@ -1185,7 +1263,7 @@ func (c *Client) PrepareTransfer(ctx context.Context, req *PrepareTransferReques
 		DisclosedContracts: factoryReq.DisclosedContracts,
 	}
 
-	authCtx := c.ledger.AuthContext(ctx)
+	authCtx := c.ledger.AuthContext(ledger.StickyContext(ctx))
 	prepResp, err := c.ledger.Interactive().PrepareSubmission(authCtx, &interactivev2.PrepareSubmissionRequest{
 		UserId:             commands.UserId,
 		CommandId:          commands.CommandId,
@@ -1205,6 +1283,7 @@ func (c *Client) PrepareTransfer(ctx context.Context, req *PrepareTransferReques
 		PreparedTransaction:  prepResp.PreparedTransaction,
 		HashingSchemeVersion: prepResp.HashingSchemeVersion,
 		PartyID:              req.FromPartyID,
+		Endpoint:             ledger.StickyEndpoint(authCtx),
 	}
 
 	c.logger.Info("Prepared non-custodial transfer",
@@ -1223,7 +1302,7 @@ func (c *Client) ExecuteTransfer(ctx context.Context, req *ExecuteTransferReques
 	}
 
 	pt := req.PreparedTransfer
-	authCtx := c.ledger.AuthContext(ctx)
+	authCtx := c.ledger.AuthContext(ledger.WithEndpoint(ctx, pt.Endpoint))
 
 	partySigs := &interactivev2.PartySignatures{
 		Signatures: []*interactivev2.SinglePartySignatures{
@@ -1275,11 +1354,7 @@ func (c *Client) FindPendingInboundTransferInstructions(ctx context.Context, par
 		return nil, nil
 	}
 
-	tid := &lapiv2.Identifier{
-		PackageId:  c.cfg.UtilityRegistryAppPackageID,
-		ModuleName: moduleTransferOffer,
-		EntityName: entityTransferOffer,
-	}
+	tid := registry.TransferOfferTemplate.ID(c.cfg.UtilityRegistryAppPackageID)
 
 	events, err := c.ledger.GetActiveContractsByTemplate(ctx, end, []string{partyID}, tid)
 	if err != nil {
@@ -1297,7 +1372,7 @@ func (c *Client) AcceptTransferInstruction(ctx context.Context, partyID, instruc
 	if partyID == "" || instructionCID == "" || instrumentAdmin == "" {
 		return fmt.Errorf("partyID, instructionCID, and instrumentAdmin are required")
 	}
-	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "accept", acceptChoice)
+	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "accept", splice.ChoiceTransferInstructionAccept)
 	if err != nil {
 		return err
 	}
@@ -1307,6 +1382,23 @@ func (c *Client) AcceptTransferInstruction(ctx context.Context, partyID, instruc
 	return nil
 }
 
+// RejectTransferInstruction declines a pending inbound offer for a custodial receiver by
+// exercising TransferInstruction_Reject server-side. Mirrors AcceptTransferInstruction;
+// the sender gets the locked holding back without waiting for the offer to expire.
+func (c *Client) RejectTransferInstruction(ctx context.Context, partyID, instructionCID, instrumentAdmin string) error {
+	if partyID == "" || instructionCID == "" || instrumentAdmin == "" {
+		return fmt.Errorf("partyID, instructionCID, and instrumentAdmin are required")
+	}
+	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "reject", splice.ChoiceTransferInstructionReject)
+	if err != nil {
+		return err
+	}
+	if err := c.exerciseInstructionAsCustodial(ctx, partyID, cmd, disclosed); err != nil {
+		return fmt.Errorf("reject transfer instruction: %w", err)
+	}
+	return nil
+}
+
 // WithdrawTransferInstruction reclaims a pending or expired offer-based transfer for a
 // custodial sender: the middleware holds the user's key and exercises
 // TransferInstruction_Withdraw server-side, returning the locked holding to the sender.
@@ -1315,7 +1407,7 @@ func (c *Client) WithdrawTransferInstruction(ctx context.Context, partyID, instr
 	if partyID == "" || instructionCID == "" || instrumentAdmin == "" {
 		return fmt.Errorf("partyID, instructionCID, and instrumentAdmin are required")
 	}
-	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "withdraw", withdrawChoice)
+	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "withdraw", splice.ChoiceTransferInstructionWithdraw)
 	if err != nil {
 		return err
 	}
@@ -1326,8 +1418,8 @@ func (c *Client) WithdrawTransferInstruction(ctx context.Context, partyID, instr
 }
 
 // buildInstructionChoiceCommand fetches the registrar's choice-context for the given
-// action ("accept"/"withdraw") on a TransferInstruction and builds the exercise command
-// plus its disclosed contracts. Shared by the accept and withdraw (claim-back) flows,
+// action ("accept"/"reject"/"withdraw") on a TransferInstruction and builds the exercise command
+// plus its disclosed contracts. Shared by the accept, reject and withdraw (claim-back) flows,
 // which differ only in the registry endpoint and the on-ledger choice name.
 func (c *Client) buildInstructionChoiceCommand(
 	ctx context.Context, instructionCID, instrumentAdmin, action, choice string,
@@ -1345,6 +1437,8 @@ func (c *Client) buildInstructionChoiceCommand(
 	switch action {
 	case "accept":
 		ctxResp, err = c.registryClient.GetAcceptChoiceContext(ctx, extCfg.RegistryURL, instrumentAdmin, instructionCID)
+	case "reject":
+		ctxResp, err = c.registryClient.GetRejectChoiceContext(ctx, extCfg.RegistryURL, instrumentAdmin, instructionCID)
 	case "withdraw":
 		ctxResp, err = c.registryClient.GetWithdrawChoiceContext(ctx, extCfg.RegistryURL, instrumentAdmin, instructionCID)
 	default:
@@ -1354,22 +1448,11 @@ func (c *Client) buildInstructionChoiceCommand(
 		return nil, nil, fmt.Errorf("get %s choice context: %w", action, err)
 	}
 
-	ctxValue, err := encodeChoiceContextRecord(ctxResp.ChoiceContextData)
+	choiceCtx, err := encodeChoiceContext(ctxResp.ChoiceContextData)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encode choice context: %w", err)
 	}
 
-	extraArgs := &lapiv2.Value{
-		Sum: &lapiv2.Value_Record{
-			Record: &lapiv2.Record{
-				Fields: []*lapiv2.RecordField{
-					{Label: "context", Value: ctxValue},
-					{Label: "meta", Value: values.EmptyMetadata()},
-				},
-			},
-		},
-	}
-
 	disclosed, err := convertDisclosedContractSlice(ctxResp.DisclosedContracts, c.cfg.DomainID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("convert disclosed contracts: %w", err)
@@ -1378,22 +1461,10 @@ func (c *Client) buildInstructionChoiceCommand(
 	cmd := &lapiv2.Command{
 		Command: &lapiv2.Command_Exercise{
 			Exercise: &lapiv2.ExerciseCommand{
-				TemplateId: &lapiv2.Identifier{
-					PackageId:  c.cfg.SpliceTransferPackageID,
-					ModuleName: spliceTransferModule,
-					EntityName: transferInstrEntity,
-				},
-				ContractId: instructionCID,
-				Choice:     choice,
-				ChoiceArgument: &lapiv2.Value{
-					Sum: &lapiv2.Value_Record{
-						Record: &lapiv2.Record{
-							Fields: []*lapiv2.RecordField{
-								{Label: "extraArgs", Value: extraArgs},
-							},
-						},
-					},
-				},
+				TemplateId:     splice.TransferInstructionInterface.ID(c.cfg.SpliceTransferPackageID),
+				ContractId:     instructionCID,
+				Choice:         choice,
+				ChoiceArgument: encodeInstructionChoiceArgs(choice, splice.ExtraArgs{Context: choiceCtx}),
 			},
 		},
 	}
@@ -1422,7 +1493,7 @@ func (c *Client) exerciseInstructionAsCustodial(
 		Commands:           []*lapiv2.Command{cmd},
 		DisclosedContracts: disclosed,
 	}
-	return c.prepareAndExecuteAsUser(ctx, commands, signerKey, partyID)
+	return c.prepareAndExecuteAsUser(ctx, commands, signerKey, partyID, nil)
 }
 
 func (c *Client) PrepareAcceptTransfer(
@@ -1431,7 +1502,7 @@ func (c *Client) PrepareAcceptTransfer(
 	if partyID == "" || instructionCID == "" || instrumentAdmin == "" {
 		return nil, fmt.Errorf("partyID, instructionCID, and instrumentAdmin are required")
 	}
-	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "accept", acceptChoice)
+	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "accept", splice.ChoiceTransferInstructionAccept)
 	if err != nil {
 		return nil, err
 	}
@@ -1447,7 +1518,7 @@ func (c *Client) PrepareWithdrawTransfer(
 	if partyID == "" || instructionCID == "" || instrumentAdmin == "" {
 		return nil, fmt.Errorf("partyID, instructionCID, and instrumentAdmin are required")
 	}
-	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "withdraw", withdrawChoice)
+	cmd, disclosed, err := c.buildInstructionChoiceCommand(ctx, instructionCID, instrumentAdmin, "withdraw", splice.ChoiceTransferInstructionWithdraw)
 	if err != nil {
 		return nil, err
 	}
@@ -1460,7 +1531,7 @@ func (c *Client) PrepareWithdrawTransfer(
 func (c *Client) prepareInstructionTx(
 	ctx context.Context, partyID, instructionCID string, cmd *lapiv2.Command, disclosed []*lapiv2.DisclosedContract,
 ) (*PreparedTransfer, error) {
-	authCtx := c.ledger.AuthContext(ctx)
+	authCtx := c.ledger.AuthContext(ledger.StickyContext(ctx))
 	prepResp, err := c.ledger.Interactive().PrepareSubmission(authCtx, &interactivev2.PrepareSubmissionRequest{
 		UserId:             c.cfg.UserID,
 		CommandId:          uuid.NewString(),
@@ -1480,6 +1551,7 @@ func (c *Client) prepareInstructionTx(
 		HashingSchemeVersion: prepResp.HashingSchemeVersion,
 		PartyID:              partyID,
 		ExpiresAt:            time.Now().UTC().Add(preparedTxCacheTTL),
+		Endpoint:             ledger.StickyEndpoint(authCtx),
 	}
 
 	c.logger.Info("prepared non-custodial instruction tx",
@@ -1497,7 +1569,7 @@ func (c *Client) GetTokenTransferEvents(ctx context.Context) ([]*TokenTransferEv
 		c.ledger,
 		c.cfg.CIP56PackageID,
 		c.cfg.IssuerParty,
-		"TokenTransferEvent",
+		cip56.TokenTransferEventTemplate,
 		decodeTokenTransferEvent,
 	)
 }
@@ -1507,8 +1579,8 @@ func getEvents[T any](
 	ldr ledger.Ledger,
 	cip56PackageID string,
 	relayerParty string,
-	eventName string,
-	decode func(*lapiv2.CreatedEvent) T,
+	event daml.Template,
+	decode func(*lapiv2.CreatedEvent) (T, error),
 ) ([]T, error) {
 	end, err := ldr.GetLedgerEnd(ctx)
 	if err != nil {
@@ -1518,20 +1590,18 @@ func getEvents[T any](
 		return []T{}, nil
 	}
 
-	tid := &lapiv2.Identifier{
-		PackageId:  cip56PackageID,
-		ModuleName: moduleEvents,
-		EntityName: eventName,
-	}
-
-	events, err := ldr.GetActiveContractsByTemplate(ctx, end, []string{relayerParty}, tid)
+	events, err := ldr.GetActiveContractsByTemplate(ctx, end, []string{relayerParty}, event.ID(cip56PackageID))
 	if err != nil {
-		return nil, fmt.Errorf("query %s: %w", eventName, err)
+		return nil, fmt.Errorf("query %s: %w", event.Entity, err)
 	}
 
 	out := make([]T, 0, len(events))
 	for _, ce := range events {
-		out = append(out, decode(ce))
+		ev, err := decode(ce)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s %s: %w", event.Entity, ce.ContractId, err)
+		}
+		out = append(out, ev)
 	}
 
 	return out, nil
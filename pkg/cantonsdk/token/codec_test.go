@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/splice"
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
+)
+
+// The expected values below are built with the hand-written values helpers the
+// generated codecs replaced; the generated output must stay wire-identical.
+
+func assertProtoEqual(t *testing.T, want, got proto.Message) {
+	t.Helper()
+	assert.Truef(t, proto.Equal(want, got), "want %v\ngot  %v", want, got)
+}
+
+// eventTimeOf returns the "eventTime" field of rec, checking it was taken
+// after before.
+func eventTimeOf(t *testing.T, rec *lapiv2.Record, before time.Time) time.Time {
+	t.Helper()
+	ts, ok := values.TimestampOK(values.RecordToMap(rec)["eventTime"])
+	require.True(t, ok, "record has no eventTime field")
+	assert.False(t, ts.Before(before.Truncate(time.Microsecond)))
+	return ts
+}
+
+func TestEncodeIssuerMintArgs(t *testing.T) {
+	meta := map[string]string{"bridge.externalTxId": "0xabc", "bridge.fingerprint": "fp-1"}
+
+	before := time.Now()
+	got := encodeIssuerMintArgs(&MintRequest{RecipientParty: "alice::1", Amount: "10", EventMeta: meta})
+	ts := eventTimeOf(t, got, before)
+
+	assertProtoEqual(t, &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "recipient", Value: values.PartyValue("alice::1")},
+			{Label: "amount", Value: values.NumericValue("10")},
+			{Label: "eventTime", Value: values.TimestampValue(ts)},
+			{Label: "eventMeta", Value: values.EncodeOptionalMetadata(meta)},
+		},
+	}, got)
+}
+
+func TestEncodeIssuerBurnArgs(t *testing.T) {
+	before := time.Now()
+	got := encodeIssuerBurnArgs(&BurnRequest{HoldingCID: "hold-1", Amount: "2.5"})
+	ts := eventTimeOf(t, got, before)
+
+	assertProtoEqual(t, &lapiv2.Record{
+		Fields: []*lapiv2.RecordField{
+			{Label: "holdingCid", Value: values.ContractIDValue("hold-1")},
+			{Label: "amount", Value: values.NumericValue("2.5")},
+			{Label: "eventTime", Value: values.TimestampValue(ts)},
+			{Label: "eventMeta", Value: values.None()},
+		},
+	}, got)
+}
+
+func TestEncodeTransferFactoryTransferArgs(t *testing.T) {
+	requestedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	executeBefore := requestedAt.Add(time.Hour)
+	choiceCtx := map[string]string{"b": "2", "a": "1"}
+
+	for name, ctx := range map[string]map[string]string{"with context": choiceCtx, "empty context": nil} {
+		t.Run(name, func(t *testing.T) {
+			got := encodeTransferFactoryTransferArgs(
+				"admin::1", "alice::1", "bob::1", "5",
+				"admin::1", "DEMO",
+				requestedAt, executeBefore,
+				[]string{"h-1", "h-2"},
+				splice.ExtraArgs{Context: encodeTextChoiceContext(ctx)},
+			)
+
+			transfer := &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: &lapiv2.Record{
+				Fields: []*lapiv2.RecordField{
+					{Label: "sender", Value: values.PartyValue("alice::1")},
+					{Label: "receiver", Value: values.PartyValue("bob::1")},
+					{Label: "amount", Value: values.NumericValue("5")},
+					{Label: "instrumentId", Value: values.EncodeInstrumentId("admin::1", "DEMO")},
+					{Label: "requestedAt", Value: values.TimestampValue(requestedAt)},
+					{Label: "executeBefore", Value: values.TimestampValue(executeBefore)},
+					{Label: "inputHoldingCids", Value: values.ListValue([]*lapiv2.Value{
+						values.ContractIDValue("h-1"), values.ContractIDValue("h-2"),
+					})},
+					{Label: "meta", Value: values.EmptyMetadata()},
+				},
+			}}}
+			assertProtoEqual(t, &lapiv2.Record{
+				Fields: []*lapiv2.RecordField{
+					{Label: "expectedAdmin", Value: values.PartyValue("admin::1")},
+					{Label: "transfer", Value: transfer},
+					{Label: "extraArgs", Value: values.EncodeExtraArgs(ctx)},
+				},
+			}, got)
+		})
+	}
+}
+
+func TestDecodeHolding(t *testing.T) {
+	created := func(fields ...*lapiv2.RecordField) *lapiv2.CreatedEvent {
+		return &lapiv2.CreatedEvent{ContractId: "h-1", CreateArguments: &lapiv2.Record{Fields: fields}}
+	}
+	someLock := values.Optional(&lapiv2.Value{Sum: &lapiv2.Value_Record{Record: &lapiv2.Record{}}})
+
+	t.Run("CIP56Holding", func(t *testing.T) {
+		meta := map[string]string{values.MetaKeySymbol: "DEMO"}
+		got, err := decodeHolding(created(
+			&lapiv2.RecordField{Label: "issuer", Value: values.PartyValue("issuer::1")},
+			&lapiv2.RecordField{Label: "owner", Value: values.PartyValue("alice::1")},
+			&lapiv2.RecordField{Label: "instrumentId", Value: values.EncodeInstrumentId("issuer::1", "demo")},
+			&lapiv2.RecordField{Label: "amount", Value: values.NumericValue("1.5")},
+			&lapiv2.RecordField{Label: "meta", Value: values.EncodeMetadata(meta)},
+			&lapiv2.RecordField{Label: "lock", Value: values.None()},
+		))
+		require.NoError(t, err)
+		assert.Equal(t, &Holding{
+			ContractID:      "h-1",
+			Issuer:          "issuer::1",
+			Owner:           "alice::1",
+			Amount:          "1.5",
+			Symbol:          "DEMO",
+			InstrumentAdmin: "issuer::1",
+			InstrumentID:    "demo",
+			Metadata:        meta,
+		}, got)
+	})
+
+	t.Run("Utility.Registry Holding", func(t *testing.T) {
+		instrument := &lapiv2.Value{Sum: &lapiv2.Value_Record{Record: &lapiv2.Record{
+			Fields: []*lapiv2.RecordField{
+				{Label: "source", Value: values.PartyValue("registrar::1")},
+				{Label: "id", Value: values.TextValue("USDCx")},
+				{Label: "scheme", Value: values.TextValue("RegistrarInternalScheme")},
+			},
+		}}}
+		got, err := decodeHolding(created(
+			&lapiv2.RecordField{Label: "operator", Value: values.PartyValue("op::1")},
+			&lapiv2.RecordField{Label: "provider", Value: values.PartyValue("prov::1")},
+			&lapiv2.RecordField{Label: "registrar", Value: values.PartyValue("registrar::1")},
+			&lapiv2.RecordField{Label: "owner", Value: values.PartyValue("alice::1")},
+			&lapiv2.RecordField{Label: "instrument", Value: instrument},
+			&lapiv2.RecordField{Label: "label", Value: values.TextValue("")},
+			&lapiv2.RecordField{Label: "amount", Value: values.NumericValue("3")},
+			&lapiv2.RecordField{Label: "lock", Value: someLock},
+		))
+		require.NoError(t, err)
+		assert.Equal(t, &Holding{
+			ContractID:      "h-1",
+			Issuer:          "registrar::1",
+			Owner:           "alice::1",
+			Amount:          "3",
+			Symbol:          "USDCx",
+			InstrumentAdmin: "registrar::1",
+			InstrumentID:    "USDCx",
+			Locked:          true,
+			Metadata:        map[string]string{},
+		}, got)
+	})
+
+	t.Run("mistyped field", func(t *testing.T) {
+		_, err := decodeHolding(created(
+			&lapiv2.RecordField{Label: "instrumentId", Value: values.EncodeInstrumentId("issuer::1", "demo")},
+			&lapiv2.RecordField{Label: "amount", Value: values.TextValue("not numeric")},
+		))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CIP56Holding.amount")
+	})
+}
+
+func TestDecodeTokenTransferEvent(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	meta := map[string]string{"bridge.externalTxId": "0xabc"}
+
+	got, err := decodeTokenTransferEvent(&lapiv2.CreatedEvent{
+		ContractId: "ev-1",
+		CreateArguments: &lapiv2.Record{Fields: []*lapiv2.RecordField{
+			{Label: "issuer", Value: values.PartyValue("issuer::1")},
+			{Label: "fromParty", Value: values.None()},
+			{Label: "toParty", Value: values.Optional(values.PartyValue("bob::1"))},
+			{Label: "amount", Value: values.NumericValue("9")},
+			{Label: "instrumentId", Value: values.EncodeInstrumentId("issuer::1", "demo")},
+			{Label: "timestamp", Value: values.TimestampValue(ts)},
+			{Label: "meta", Value: values.EncodeOptionalMetadata(meta)},
+			{Label: "auditObservers", Value: values.ListValue([]*lapiv2.Value{values.PartyValue("auditor::1")})},
+		}},
+	})
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(got.Timestamp), "timestamp %v", got.Timestamp)
+	got.Timestamp = ts
+	assert.Equal(t, &TokenTransferEvent{
+		ContractID:      "ev-1",
+		Issuer:          "issuer::1",
+		ToParty:         "bob::1",
+		Amount:          "9",
+		InstrumentAdmin: "issuer::1",
+		InstrumentID:    "demo",
+		Timestamp:       ts,
+		Meta:            meta,
+		AuditObservers:  []string{"auditor::1"},
+	}, got)
+	assert.Equal(t, EventTypeMint, got.EventType())
+}
@@ -2,7 +2,14 @@
 
 package token
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/cip56"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/registry"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/splice"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
+)
 
 // ExternalTokenConfig holds the registry endpoint for an external token issuer.
 // Key in the map is the InstrumentAdmin party ID (e.g., Circle's Bridge-Operator).
@@ -16,9 +23,12 @@ type Config struct {
 	IssuerParty string `yaml:"issuer_party"`
 	UserID      string `yaml:"user_id"`
 
-	CIP56PackageID              string `yaml:"cip56_package_id" validate:"required"`
-	SpliceTransferPackageID     string `yaml:"splice_transfer_package_id" validate:"required"`
-	SpliceHoldingPackageID      string `yaml:"splice_holding_package_id" validate:"required"`
+	// Package IDs or package-name references (#name). Leave them empty to
+	// discover them from the participant's vetted packages at startup (see
+	// PackageBindings); a package ID set here pins that version.
+	CIP56PackageID              string `yaml:"cip56_package_id"`
+	SpliceTransferPackageID     string `yaml:"splice_transfer_package_id"`
+	SpliceHoldingPackageID      string `yaml:"splice_holding_package_id"`
 	UtilityRegistryAppPackageID string `yaml:"utility_registry_app_package_id"`
 	// UtilityRegistryPackageID is the utility_registry_v0 package ID.
 	// Required when using AllocationFactory-based tokens (e.g., USDCx on P2) so that
@@ -32,6 +42,20 @@ type Config struct {
 	ExternalTokens map[string]ExternalTokenConfig `yaml:"external_tokens"`
 }
 
+// PackageBindings ties the package ID settings to the Daml packages they name.
+// The Utility.Registry packages are optional: they are only discovered when
+// given a version range, as leaving them empty disables the features that use
+// them.
+func (c *Config) PackageBindings() []packages.Binding {
+	return []packages.Binding{
+		{Name: cip56.CIP56TokenPackage.Name, Target: &c.CIP56PackageID},
+		{Name: splice.TransferInstructionPackage.Name, Target: &c.SpliceTransferPackageID},
+		{Name: splice.HoldingPackage.Name, Target: &c.SpliceHoldingPackageID},
+		{Name: registry.UtilityRegistryAppPackage.Name, Target: &c.UtilityRegistryAppPackageID, Optional: true},
+		{Name: registry.UtilityRegistryPackage.Name, Target: &c.UtilityRegistryPackageID, Optional: true},
+	}
+}
+
 func (c *Config) validate() error {
 	if c == nil {
 		return errors.New("nil config")
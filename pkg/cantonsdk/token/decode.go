@@ -3,59 +3,84 @@
 package token
 
 import (
+	"fmt"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/cip56"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/registry"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
 )
 
-func decodeHolding(ce *lapiv2.CreatedEvent) *Holding {
+func decodeHolding(ce *lapiv2.CreatedEvent) (*Holding, error) {
 	fields := values.RecordToMap(ce.CreateArguments)
+	h := &Holding{ContractID: ce.ContractId, Metadata: map[string]string{}}
 
 	// CIP56Holding shape: {issuer, owner, instrumentId{admin,id}, amount, meta, lock}.
 	// Utility.Registry.Holding.V0.Holding shape: {operator, provider, registrar, owner,
 	// instrument{source,id,scheme}, label, amount, lock} — no top-level meta/instrumentId.
 	// The Splice HoldingV1 view derives instrumentId.admin from registrar and
 	// instrumentId.id from instrument.id, which is what we mirror here.
-	var admin, id, issuer, symbol string
-	meta := map[string]string{}
-	if fields["instrumentId"] != nil {
-		admin, id = values.DecodeInstrumentId(fields["instrumentId"])
-		meta = values.DecodeMetadata(fields["meta"])
-		issuer = values.Party(fields["issuer"])
-		symbol = meta[values.MetaKeySymbol]
-	} else if fields["instrument"] != nil {
-		admin = values.NestedPartyField(fields["instrument"], "source")
-		id = values.NestedTextField(fields["instrument"], "id")
-		issuer = values.Party(fields["registrar"])
-		symbol = id
-	}
-
-	return &Holding{
-		ContractID:      ce.ContractId,
-		Issuer:          issuer,
-		Owner:           values.Party(fields["owner"]),
-		Amount:          values.Numeric(fields["amount"]),
-		Symbol:          symbol,
-		InstrumentAdmin: admin,
-		InstrumentID:    id,
-		Locked:          !values.IsNone(fields["lock"]),
-		Metadata:        meta,
+	switch {
+	case fields["instrumentId"] != nil:
+		var args cip56.CIP56Holding
+		if err := args.FromFields(fields); err != nil {
+			return nil, fmt.Errorf("decode holding %s: %w", ce.ContractId, err)
+		}
+		h.Issuer = args.Issuer
+		h.Owner = args.Owner
+		h.Amount = args.Amount
+		h.InstrumentAdmin = args.InstrumentID.Admin
+		h.InstrumentID = args.InstrumentID.ID
+		h.Locked = args.Lock != nil
+		if args.Meta.Values != nil {
+			h.Metadata = args.Meta.Values
+		}
+		h.Symbol = h.Metadata[values.MetaKeySymbol]
+	case fields["instrument"] != nil:
+		var args registry.Holding
+		if err := args.FromFields(fields); err != nil {
+			return nil, fmt.Errorf("decode holding %s: %w", ce.ContractId, err)
+		}
+		h.Issuer = args.Registrar
+		h.Owner = args.Owner
+		h.Amount = args.Amount
+		h.InstrumentAdmin = args.Instrument.Source
+		h.InstrumentID = args.Instrument.ID
+		h.Symbol = args.Instrument.ID
+		h.Locked = args.Lock != nil
+	default:
+		// Other HoldingV1 implementations (e.g. Amulet) have no generated codec;
+		// only the owner and amount are read, leniently.
+		h.Owner = values.Party(fields["owner"])
+		h.Amount = values.Numeric(fields["amount"])
+		h.Locked = !values.IsNone(fields["lock"])
 	}
+	return h, nil
 }
 
-func decodeTokenTransferEvent(ce *lapiv2.CreatedEvent) *TokenTransferEvent {
-	fields := values.RecordToMap(ce.CreateArguments)
-	admin, id := values.DecodeInstrumentId(fields["instrumentId"])
+func decodeTokenTransferEvent(ce *lapiv2.CreatedEvent) (*TokenTransferEvent, error) {
+	var args cip56.TokenTransferEvent
+	if err := args.FromRecord(ce.CreateArguments); err != nil {
+		return nil, err
+	}
 
-	return &TokenTransferEvent{
+	ev := &TokenTransferEvent{
 		ContractID:      ce.ContractId,
-		Issuer:          values.Party(fields["issuer"]),
-		FromParty:       values.OptionalParty(fields["fromParty"]),
-		ToParty:         values.OptionalParty(fields["toParty"]),
-		Amount:          values.Numeric(fields["amount"]),
-		InstrumentAdmin: admin,
-		InstrumentID:    id,
-		Timestamp:       values.Timestamp(fields["timestamp"]),
-		Meta:            values.DecodeOptionalMetadata(fields["meta"]),
-		AuditObservers:  values.PartyList(fields["auditObservers"]),
+		Issuer:          args.Issuer,
+		Amount:          args.Amount,
+		InstrumentAdmin: args.InstrumentID.Admin,
+		InstrumentID:    args.InstrumentID.ID,
+		Timestamp:       args.Timestamp,
+		AuditObservers:  args.AuditObservers,
+	}
+	if args.FromParty != nil {
+		ev.FromParty = *args.FromParty
+	}
+	if args.ToParty != nil {
+		ev.ToParty = *args.ToParty
+	}
+	if args.Meta != nil {
+		ev.Meta = args.Meta.Values
 	}
+	return ev, nil
 }
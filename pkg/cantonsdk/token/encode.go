@@ -5,33 +5,38 @@ package token
 import (
 	"encoding/json"
 	"fmt"
-	"sort"
 	"time"
 
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/cip56"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/splice"
 	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/values"
 )
 
 func encodeIssuerMintArgs(req *MintRequest) *lapiv2.Record {
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "recipient", Value: values.PartyValue(req.RecipientParty)},
-			{Label: "amount", Value: values.NumericValue(req.Amount)},
-			{Label: "eventTime", Value: values.TimestampValue(time.Now())},
-			{Label: "eventMeta", Value: values.EncodeOptionalMetadata(req.EventMeta)},
-		},
-	}
+	return cip56.IssuerMint{
+		Recipient: req.RecipientParty,
+		Amount:    req.Amount,
+		EventTime: time.Now(),
+		EventMeta: optionalMetadata(req.EventMeta),
+	}.ToRecord()
 }
 
 func encodeIssuerBurnArgs(req *BurnRequest) *lapiv2.Record {
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "holdingCid", Value: values.ContractIDValue(req.HoldingCID)},
-			{Label: "amount", Value: values.NumericValue(req.Amount)},
-			{Label: "eventTime", Value: values.TimestampValue(time.Now())},
-			{Label: "eventMeta", Value: values.EncodeOptionalMetadata(req.EventMeta)},
-		},
+	return cip56.IssuerBurn{
+		HoldingCID: req.HoldingCID,
+		Amount:     req.Amount,
+		EventTime:  time.Now(),
+		EventMeta:  optionalMetadata(req.EventMeta),
+	}.ToRecord()
+}
+
+// optionalMetadata returns nil (None) for empty metadata.
+func optionalMetadata(kvs map[string]string) *splice.Metadata {
+	if len(kvs) == 0 {
+		return nil
 	}
+	return &splice.Metadata{Values: kvs}
 }
 
 // encodeAnyValue converts an AnyValue (JSON ADT) into a Daml-LF Variant value.
@@ -63,7 +68,7 @@ func encodeAnyValue(av AnyValue) (*lapiv2.Value, error) {
 		if err = json.Unmarshal(av.Value, &b); err != nil {
 			return nil, fmt.Errorf("AV_Bool: %w", err)
 		}
-		inner = &lapiv2.Value{Sum: &lapiv2.Value_Bool{Bool: b}}
+		inner = values.BoolValue(b)
 	case "AV_Int":
 		var n json.Number
 		if err = json.Unmarshal(av.Value, &n); err != nil {
@@ -148,38 +153,27 @@ func encodeAnyValueMap(raw json.RawMessage) (*lapiv2.Value, error) {
 	return values.ListValue(elems), nil
 }
 
-// encodeChoiceContextRecord builds Splice ChoiceContext { values: TextMap AnyValue }.
-func encodeChoiceContextRecord(ctx AcceptChoiceContext) (*lapiv2.Value, error) {
-	keys := make([]string, 0, len(ctx.Values))
-	for k := range ctx.Values {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	entries := make([]*lapiv2.TextMap_Entry, 0, len(ctx.Values))
-	for _, k := range keys {
-		v, err := encodeAnyValue(ctx.Values[k])
+// encodeChoiceContext builds Splice ChoiceContext { values: TextMap AnyValue }.
+func encodeChoiceContext(ctx AcceptChoiceContext) (splice.ChoiceContext, error) {
+	vals := make(map[string]*lapiv2.Value, len(ctx.Values))
+	for k, av := range ctx.Values {
+		v, err := encodeAnyValue(av)
 		if err != nil {
-			return nil, fmt.Errorf("encode key %q: %w", k, err)
+			return splice.ChoiceContext{}, fmt.Errorf("encode key %q: %w", k, err)
 		}
-		entries = append(entries, &lapiv2.TextMap_Entry{Key: k, Value: v})
+		vals[k] = v
 	}
-	return &lapiv2.Value{
-		Sum: &lapiv2.Value_Record{
-			Record: &lapiv2.Record{
-				Fields: []*lapiv2.RecordField{
-					{
-						Label: "values",
-						Value: &lapiv2.Value{
-							Sum: &lapiv2.Value_TextMap{
-								TextMap: &lapiv2.TextMap{Entries: entries},
-							},
-						},
-					},
-				},
-			},
-		},
-	}, nil
+	return splice.ChoiceContext{Values: vals}, nil
+}
+
+// encodeTextChoiceContext builds a ChoiceContext whose values are plain Text,
+// as returned by the Transfer Factory Registry for CIP56 factories.
+func encodeTextChoiceContext(kvs map[string]string) splice.ChoiceContext {
+	vals := make(map[string]*lapiv2.Value, len(kvs))
+	for k, v := range kvs {
+		vals[k] = values.TextValue(v)
+	}
+	return splice.ChoiceContext{Values: vals}
 }
 
 func encodeTransferFactoryTransferArgs(
@@ -192,35 +186,32 @@ func encodeTransferFactoryTransferArgs(
 	requestedAt time.Time,
 	executeBefore time.Time,
 	inputHoldingCIDs []string,
-	extraArgs *lapiv2.Value,
+	extraArgs splice.ExtraArgs,
 ) *lapiv2.Record {
-	holdingCidValues := make([]*lapiv2.Value, len(inputHoldingCIDs))
-	for i, cid := range inputHoldingCIDs {
-		holdingCidValues[i] = values.ContractIDValue(cid)
-	}
-
-	transfer := &lapiv2.Value{
-		Sum: &lapiv2.Value_Record{
-			Record: &lapiv2.Record{
-				Fields: []*lapiv2.RecordField{
-					{Label: "sender", Value: values.PartyValue(sender)},
-					{Label: "receiver", Value: values.PartyValue(receiver)},
-					{Label: "amount", Value: values.NumericValue(amount)},
-					{Label: "instrumentId", Value: values.EncodeInstrumentId(instrumentAdmin, instrumentID)},
-					{Label: "requestedAt", Value: values.TimestampValue(requestedAt)},
-					{Label: "executeBefore", Value: values.TimestampValue(executeBefore)},
-					{Label: "inputHoldingCids", Value: values.ListValue(holdingCidValues)},
-					{Label: "meta", Value: values.EmptyMetadata()},
-				},
-			},
+	return splice.TransferFactoryTransfer{
+		ExpectedAdmin: expectedAdmin,
+		Transfer: splice.Transfer{
+			Sender:           sender,
+			Receiver:         receiver,
+			Amount:           amount,
+			InstrumentID:     splice.InstrumentId{Admin: instrumentAdmin, ID: instrumentID},
+			RequestedAt:      requestedAt,
+			ExecuteBefore:    executeBefore,
+			InputHoldingCIDs: inputHoldingCIDs,
 		},
-	}
+		ExtraArgs: extraArgs,
+	}.ToRecord()
+}
 
-	return &lapiv2.Record{
-		Fields: []*lapiv2.RecordField{
-			{Label: "expectedAdmin", Value: values.PartyValue(expectedAdmin)},
-			{Label: "transfer", Value: transfer},
-			{Label: "extraArgs", Value: extraArgs},
-		},
+// encodeInstructionChoiceArgs encodes the argument of a TransferInstruction
+// choice. Accept, reject and withdraw all take only extraArgs.
+func encodeInstructionChoiceArgs(choice string, extraArgs splice.ExtraArgs) *lapiv2.Value {
+	switch choice {
+	case splice.ChoiceTransferInstructionReject:
+		return splice.TransferInstructionReject{ExtraArgs: extraArgs}.ToValue()
+	case splice.ChoiceTransferInstructionWithdraw:
+		return splice.TransferInstructionWithdraw{ExtraArgs: extraArgs}.ToValue()
+	default:
+		return splice.TransferInstructionAccept{ExtraArgs: extraArgs}.ToValue()
 	}
 }
@@ -125,7 +125,7 @@ func TestEncodeAnyValueUnsupportedTag(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported AnyValue tag")
 }
 
-func TestEncodeChoiceContextRecord(t *testing.T) {
+func TestEncodeChoiceContext(t *testing.T) {
 	ctx := AcceptChoiceContext{
 		Values: map[string]AnyValue{
 			"utility.digitalasset.com/transfer-rule": {
@@ -139,9 +139,10 @@ func TestEncodeChoiceContextRecord(t *testing.T) {
 		},
 	}
 
-	got, err := encodeChoiceContextRecord(ctx)
+	choiceCtx, err := encodeChoiceContext(ctx)
 	require.NoError(t, err)
 
+	got := choiceCtx.ToValue()
 	rec := got.Sum.(*lapiv2.Value_Record).Record
 	require.Len(t, rec.Fields, 1)
 	assert.Equal(t, "values", rec.Fields[0].Label)
@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	lapiv2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2"
+	interactivev2 "github.com/chainsafe/canton-middleware/pkg/cantonsdk/lapi/v2/interactive"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// MaxMergeInputs bounds how many holdings a single merge transaction consumes.
+// Larger sets are merged over several calls so one transaction never grows
+// past what the participant accepts; each call reduces the count by up to
+// MaxMergeInputs-1.
+const MaxMergeInputs = 100
+
+// selfTransferValidity is the executeBefore window for merge/split
+// self-transfers. A self-transfer settles within the submitting transaction, so
+// the window only needs to cover the prepare→sign→execute round-trip, which is
+// itself bounded by preparedTxCacheTTL.
+const selfTransferValidity = preparedTxCacheTTL
+
+// ErrNothingToMerge indicates the party holds fewer than two unlocked holdings of
+// the instrument, so a merge would not reduce the holding count.
+var ErrNothingToMerge = errors.New("fewer than two unlocked holdings to merge")
+
+// ErrHoldingNotFound indicates the holding to split is not an active, unlocked
+// holding of the party for the given token.
+var ErrHoldingNotFound = errors.New("holding not found")
+
+// selectMergeInputs picks up to MaxMergeInputs unlocked holdings of the first
+// instrument found, mirroring SelectHoldings' single-instrument rule. Total is
+// the merged amount.
+func selectMergeInputs(holdings []*Holding) (*HoldingSelection, error) {
+	sel := &HoldingSelection{}
+	total := "0"
+	for _, h := range holdings {
+		if h.Locked {
+			continue
+		}
+		if len(sel.Holdings) == 0 {
+			sel.InstrumentAdmin = h.InstrumentAdmin
+			sel.InstrumentID = h.InstrumentID
+		} else if h.InstrumentAdmin != sel.InstrumentAdmin || h.InstrumentID != sel.InstrumentID {
+			continue
+		}
+		next, err := addDecimalStrings(total, h.Amount)
+		if err != nil {
+			return nil, err
+		}
+		total = next
+		sel.Holdings = append(sel.Holdings, h)
+		if len(sel.Holdings) == MaxMergeInputs {
+			break
+		}
+	}
+	if len(sel.Holdings) < 2 {
+		return nil, ErrNothingToMerge
+	}
+	sel.Total = total
+	return sel, nil
+}
+
+// findSplitSource returns the unlocked holding to split and checks that amount
+// leaves a positive remainder (splitting off the whole holding is a no-op).
+func findSplitSource(holdings []*Holding, holdingCID, amount string) (*Holding, error) {
+	for _, h := range holdings {
+		if h.ContractID != holdingCID {
+			continue
+		}
+		if h.Locked {
+			return nil, fmt.Errorf("%w: holding %s is locked", ErrHoldingNotFound, holdingCID)
+		}
+		cmp, err := compareDecimalStrings(amount, h.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if cmp >= 0 {
+			return nil, fmt.Errorf("%w: split amount %s must be less than holding amount %s",
+				ErrInsufficientBalance, amount, h.Amount)
+		}
+		return h, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrHoldingNotFound, holdingCID)
+}
+
+// selfTransferRequest builds a TransferFactory_Transfer where sender and
+// receiver are the same party. Per the token standard a self-transfer settles
+// immediately: the inputs are archived and the party receives a holding of
+// amount plus, when the inputs exceed it, a change holding. That makes it the
+// standard way to merge (amount = sum of inputs) and split (amount < input).
+func selfTransferRequest(commandID, partyID, amount string, sel *HoldingSelection) *transferFactoryRequest {
+	cids := make([]string, 0, len(sel.Holdings))
+	for _, h := range sel.Holdings {
+		cids = append(cids, h.ContractID)
+	}
+	return &transferFactoryRequest{
+		CommandID:        commandID,
+		FromPartyID:      partyID,
+		ToPartyID:        partyID,
+		Amount:           amount,
+		InstrumentAdmin:  sel.InstrumentAdmin,
+		InstrumentID:     sel.InstrumentID,
+		InputHoldingCIDs: cids,
+		Validity:         selfTransferValidity,
+	}
+}
+
+// MergeHoldings consolidates up to MaxMergeInputs of the party's unlocked
+// holdings of tokenSymbol into a single holding, signing server-side through the
+// configured KeyResolver. Returns the merged inputs, or ErrNothingToMerge.
+func (c *Client) MergeHoldings(ctx context.Context, commandID, partyID, tokenSymbol string) (*HoldingSelection, error) {
+	if commandID == "" {
+		return nil, fmt.Errorf("commandID is required")
+	}
+	holdings, err := c.GetHoldings(ctx, partyID, tokenSymbol)
+	if err != nil {
+		return nil, err
+	}
+	sel, err := selectMergeInputs(holdings)
+	if err != nil {
+		return nil, err
+	}
+
+	req := selfTransferRequest(commandID, partyID, sel.Total, sel)
+	if err = c.resolveTransferFactory(ctx, req); err != nil {
+		return nil, err
+	}
+	if err = c.transferViaFactory(ctx, req); err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("merged holdings",
+		zap.String("party_id", partyID),
+		zap.String("token", tokenSymbol),
+		zap.Int("inputs", len(sel.Holdings)),
+		zap.String("amount", sel.Total))
+	return sel, nil
+}
+
+// PrepareMergeHoldings builds the merge self-transfer for external signing and
+// returns the hash to sign. Complete it with ExecuteTransfer.
+func (c *Client) PrepareMergeHoldings(ctx context.Context, partyID, tokenSymbol string) (*PreparedTransfer, error) {
+	holdings, err := c.GetHoldings(ctx, partyID, tokenSymbol)
+	if err != nil {
+		return nil, err
+	}
+	sel, err := selectMergeInputs(holdings)
+	if err != nil {
+		return nil, err
+	}
+	return c.prepareSelfTransfer(ctx, selfTransferRequest(uuid.NewString(), partyID, sel.Total, sel))
+}
+
+// SplitHolding splits the holding holdingCID into a holding of exactly amount
+// and a change holding of the remainder, signing server-side. It returns the
+// change holding's contract id so callers can keep splitting it into further
+// denominations.
+func (c *Client) SplitHolding(
+	ctx context.Context, commandID, partyID, tokenSymbol, holdingCID, amount string,
+) (string, error) {
+	if commandID == "" {
+		return "", fmt.Errorf("commandID is required")
+	}
+	holdings, err := c.GetHoldings(ctx, partyID, tokenSymbol)
+	if err != nil {
+		return "", err
+	}
+	source, err := findSplitSource(holdings, holdingCID, amount)
+	if err != nil {
+		return "", err
+	}
+
+	req := selfTransferRequest(commandID, partyID, amount, &HoldingSelection{
+		Holdings:        []*Holding{source},
+		InstrumentAdmin: source.InstrumentAdmin,
+		InstrumentID:    source.InstrumentID,
+		Total:           source.Amount,
+	})
+	if err = c.resolveTransferFactory(ctx, req); err != nil {
+		return "", err
+	}
+	if err = c.transferViaFactory(ctx, req); err != nil {
+		return "", err
+	}
+
+	changeCID, err := c.findChangeHolding(ctx, partyID, tokenSymbol, holdings, source.Amount, amount)
+	if err != nil {
+		return "", fmt.Errorf("locate change holding: %w", err)
+	}
+
+	c.logger.Info("split holding",
+		zap.String("party_id", partyID),
+		zap.String("token", tokenSymbol),
+		zap.String("holding_cid", holdingCID),
+		zap.String("amount", amount),
+		zap.String("change_cid", changeCID))
+	return changeCID, nil
+}
+
+// PrepareSplitHolding builds the split self-transfer for external signing and
+// returns the hash to sign. Complete it with ExecuteTransfer.
+func (c *Client) PrepareSplitHolding(
+	ctx context.Context, partyID, tokenSymbol, holdingCID, amount string,
+) (*PreparedTransfer, error) {
+	holdings, err := c.GetHoldings(ctx, partyID, tokenSymbol)
+	if err != nil {
+		return nil, err
+	}
+	source, err := findSplitSource(holdings, holdingCID, amount)
+	if err != nil {
+		return nil, err
+	}
+	return c.prepareSelfTransfer(ctx, selfTransferRequest(uuid.NewString(), partyID, amount, &HoldingSelection{
+		Holdings:        []*Holding{source},
+		InstrumentAdmin: source.InstrumentAdmin,
+		InstrumentID:    source.InstrumentID,
+		Total:           source.Amount,
+	}))
+}
+
+// prepareSelfTransfer resolves the transfer factory and prepares req for
+// external signing.
+func (c *Client) prepareSelfTransfer(ctx context.Context, req *transferFactoryRequest) (*PreparedTransfer, error) {
+	if err := c.resolveTransferFactory(ctx, req); err != nil {
+		return nil, err
+	}
+	cmd, err := c.buildTransferCommand(req)
+	if err != nil {
+		return nil, err
+	}
+
+	readAs := []string{c.cfg.IssuerParty}
+	if req.IsExternal {
+		readAs = nil
+	}
+
+	authCtx := c.ledger.AuthContext(ledger.StickyContext(ctx))
+	prepResp, err := c.ledger.Interactive().PrepareSubmission(authCtx, &interactivev2.PrepareSubmissionRequest{
+		UserId:             c.cfg.UserID,
+		CommandId:          req.CommandID,
+		Commands:           []*lapiv2.Command{cmd},
+		ActAs:              []string{req.FromPartyID},
+		ReadAs:             readAs,
+		SynchronizerId:     c.cfg.DomainID,
+		DisclosedContracts: req.DisclosedContracts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("prepare submission: %w", err)
+	}
+
+	pt := &PreparedTransfer{
+		TransferID:           uuid.NewString(),
+		TransactionHash:      prepResp.PreparedTransactionHash,
+		PreparedTransaction:  prepResp.PreparedTransaction,
+		HashingSchemeVersion: prepResp.HashingSchemeVersion,
+		PartyID:              req.FromPartyID,
+		ExpiresAt:            time.Now().UTC().Add(preparedTxCacheTTL),
+		Endpoint:             ledger.StickyEndpoint(authCtx),
+	}
+
+	c.logger.Info("prepared non-custodial self-transfer",
+		zap.String("transfer_id", pt.TransferID),
+		zap.String("party_id", req.FromPartyID),
+		zap.String("amount", req.Amount),
+		zap.Int("inputs", len(req.InputHoldingCIDs)))
+	return pt, nil
+}
+
+// findChangeHolding identifies the change holding created by a split: a holding
+// absent before the split whose amount is the source amount minus the split
+// amount. When both outputs have the same amount either one is returned.
+func (c *Client) findChangeHolding(
+	ctx context.Context, partyID, tokenSymbol string, before []*Holding, sourceAmount, amount string,
+) (string, error) {
+	src, err := decimal.NewFromString(sourceAmount)
+	if err != nil {
+		return "", fmt.Errorf("parse decimal: %w", err)
+	}
+	amt, err := decimal.NewFromString(amount)
+	if err != nil {
+		return "", fmt.Errorf("parse decimal: %w", err)
+	}
+	change := src.Sub(amt)
+
+	seen := make(map[string]bool, len(before))
+	for _, h := range before {
+		seen[h.ContractID] = true
+	}
+
+	after, err := c.GetHoldings(ctx, partyID, tokenSymbol)
+	if err != nil {
+		return "", err
+	}
+	for _, h := range after {
+		if seen[h.ContractID] || h.Locked {
+			continue
+		}
+		v, parseErr := decimal.NewFromString(h.Amount)
+		if parseErr != nil {
+			continue
+		}
+		if v.Equal(change) {
+			return h.ContractID, nil
+		}
+	}
+	return "", fmt.Errorf("no new holding of %s found", change.String())
+}
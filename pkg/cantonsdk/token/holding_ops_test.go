@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHolding(cid, amount, admin string, locked bool) *Holding {
+	return &Holding{ContractID: cid, Amount: amount, InstrumentAdmin: admin, InstrumentID: "DEMO", Locked: locked}
+}
+
+func TestSelectHoldings(t *testing.T) {
+	holdings := []*Holding{
+		testHolding("locked", "100", "issuer", true),
+		testHolding("a", "5", "issuer", false),
+		testHolding("other-admin", "100", "someone-else", false),
+		testHolding("b", "7", "issuer", false),
+		testHolding("c", "9", "issuer", false),
+	}
+
+	sel, err := SelectHoldings(holdings, "10")
+	require.NoError(t, err)
+	require.Len(t, sel.Holdings, 2)
+	assert.Equal(t, "a", sel.Holdings[0].ContractID)
+	assert.Equal(t, "b", sel.Holdings[1].ContractID)
+	assert.Equal(t, "12", sel.Total)
+	assert.Equal(t, "issuer", sel.InstrumentAdmin)
+
+	_, err = SelectHoldings(holdings, "22")
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	_, err = SelectHoldings(nil, "1")
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+}
+
+func TestSelectMergeInputs(t *testing.T) {
+	t.Run("merges unlocked holdings of one instrument", func(t *testing.T) {
+		sel, err := selectMergeInputs([]*Holding{
+			testHolding("a", "1.5", "issuer", false),
+			testHolding("locked", "3", "issuer", true),
+			testHolding("b", "2", "issuer", false),
+		})
+		require.NoError(t, err)
+		assert.Len(t, sel.Holdings, 2)
+		assert.Equal(t, "3.5", sel.Total)
+	})
+
+	t.Run("single holding is nothing to merge", func(t *testing.T) {
+		_, err := selectMergeInputs([]*Holding{
+			testHolding("a", "1", "issuer", false),
+			testHolding("locked", "3", "issuer", true),
+		})
+		require.ErrorIs(t, err, ErrNothingToMerge)
+	})
+
+	t.Run("caps inputs per transaction", func(t *testing.T) {
+		holdings := make([]*Holding, 0, MaxMergeInputs+10)
+		for i := range MaxMergeInputs + 10 {
+			holdings = append(holdings, testHolding(fmt.Sprintf("h%d", i), "1", "issuer", false))
+		}
+		sel, err := selectMergeInputs(holdings)
+		require.NoError(t, err)
+		assert.Len(t, sel.Holdings, MaxMergeInputs)
+		assert.Equal(t, fmt.Sprint(MaxMergeInputs), sel.Total)
+	})
+}
+
+func TestFindSplitSource(t *testing.T) {
+	holdings := []*Holding{
+		testHolding("a", "10", "issuer", false),
+		testHolding("locked", "10", "issuer", true),
+	}
+
+	h, err := findSplitSource(holdings, "a", "4")
+	require.NoError(t, err)
+	assert.Equal(t, "a", h.ContractID)
+
+	_, err = findSplitSource(holdings, "a", "10")
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	_, err = findSplitSource(holdings, "locked", "1")
+	require.ErrorIs(t, err, ErrHoldingNotFound)
+
+	_, err = findSplitSource(holdings, "missing", "1")
+	require.ErrorIs(t, err, ErrHoldingNotFound)
+}
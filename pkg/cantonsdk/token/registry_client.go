@@ -19,7 +19,7 @@ import (
 const (
 	registryPathFmt = "/api/token-standard/v0/registrars/%s/registry/transfer-instruction/v1/transfer-factory"
 	// choiceContextPathFmt is the registrar's per-instruction choice-context
-	// endpoint. The final %s is the action ("accept" or "withdraw").
+	// endpoint. The final %s is the action ("accept", "reject" or "withdraw").
 	choiceContextPathFmt = "/api/token-standard/v0/registrars/%s/registry/transfer-instruction/v1/%s/choice-contexts/%s"
 )
 
@@ -299,6 +299,15 @@ func (rc *RegistryClient) GetAcceptChoiceContext(
 	return rc.getChoiceContext(ctx, registryBaseURL, registrarParty, instructionCID, "accept")
 }
 
+// GetRejectChoiceContext calls the registrar's reject choice-context endpoint for a pending
+// TransferInstruction. Returns the choiceContextData and disclosed contracts needed to
+// exercise TransferInstruction_Reject (receiver declines the offer).
+func (rc *RegistryClient) GetRejectChoiceContext(
+	ctx context.Context, registryBaseURL, registrarParty, instructionCID string,
+) (*AcceptContextResponse, error) {
+	return rc.getChoiceContext(ctx, registryBaseURL, registrarParty, instructionCID, "reject")
+}
+
 // GetWithdrawChoiceContext calls the registrar's withdraw choice-context endpoint for a
 // TransferInstruction. Returns the choiceContextData and disclosed contracts needed to
 // exercise TransferInstruction_Withdraw (sender reclaims a pending/expired offer).
@@ -309,8 +318,8 @@ func (rc *RegistryClient) GetWithdrawChoiceContext(
 }
 
 // getChoiceContext fetches a per-instruction choice-context for the given action
-// ("accept" or "withdraw"). The response shape is identical across actions, so both
-// the accept and withdraw flows share this. action is interpolated into the registrar
+// ("accept", "reject" or "withdraw"). The response shape is identical across actions, so
+// all instruction flows share this. action is interpolated into the registrar
 // endpoint path and the error messages.
 func (rc *RegistryClient) getChoiceContext(
 	ctx context.Context, registryBaseURL, registrarParty, instructionCID, action string,
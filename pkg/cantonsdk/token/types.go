@@ -35,6 +35,19 @@ type Holding struct {
 	Metadata        map[string]string
 }
 
+// HoldingFilter narrows a holdings iteration. Empty fields match everything.
+type HoldingFilter struct {
+	Owner        string
+	InstrumentID string
+}
+
+func (f HoldingFilter) matches(h *Holding) bool {
+	if f.Owner != "" && h.Owner != f.Owner {
+		return false
+	}
+	return f.InstrumentID == "" || h.InstrumentID == f.InstrumentID
+}
+
 // MintRequest represents an issuer mint request via TokenConfig.
 type MintRequest struct {
 	RecipientParty string
@@ -172,6 +185,7 @@ type PreparedTransfer struct {
 	HashingSchemeVersion interactivev2.HashingSchemeVersion // Must match on execute
 	PartyID              string                             // The acting party
 	ExpiresAt            time.Time                          // Cache TTL deadline
+	Endpoint             string                             // Participant that prepared it; the execute goes there too
 }
 
 // ExecuteTransferRequest contains the client-signed data to complete a non-custodial transfer.
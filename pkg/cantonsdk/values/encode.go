@@ -44,6 +44,15 @@ func ContractIDValue(v string) *lapiv2.Value {
 	}
 }
 
+// BoolValue returns a bool ledger value.
+func BoolValue(v bool) *lapiv2.Value {
+	return &lapiv2.Value{
+		Sum: &lapiv2.Value_Bool{
+			Bool: v,
+		},
+	}
+}
+
 // TimestampValue returns a timestamp ledger value.
 func TimestampValue(t time.Time) *lapiv2.Value {
 	return &lapiv2.Value{
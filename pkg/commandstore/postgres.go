@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commandstore persists the Canton commands the ledger client tracks,
+// so the outcome of a command submitted before a restart can still be resolved.
+package commandstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+)
+
+// CommandDao maps to the ledger_tracked_commands table: one row per command
+// submitted and not yet settled, keyed by command ID.
+type CommandDao struct {
+	bun.BaseModel `bun:"table:ledger_tracked_commands"`
+	CommandID     string    `bun:"command_id,pk,type:varchar(255)"`
+	UserID        string    `bun:"user_id,notnull,type:varchar(255)"`
+	ActAs         []string  `bun:"act_as,notnull,type:jsonb"`
+	SubmissionID  string    `bun:"submission_id,notnull,type:varchar(64)"`
+	Endpoint      string    `bun:"endpoint,notnull,type:varchar(255)"`
+	BeginOffset   int64     `bun:"begin_offset,notnull"`
+	SubmittedAt   time.Time `bun:"submitted_at,notnull"`
+}
+
+// Postgres is the ledger.CommandStore backed by a service database. Replicas
+// sharing the database resolve each other's commands.
+type Postgres struct {
+	db *bun.DB
+}
+
+var _ ledger.CommandStore = (*Postgres)(nil)
+
+// NewPostgres creates a Postgres command store.
+func NewPostgres(db *bun.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+// SaveCommand inserts cmd or replaces the record with its command ID.
+func (s *Postgres) SaveCommand(ctx context.Context, cmd *ledger.TrackedCommand) error {
+	actAs := cmd.ActAs
+	if actAs == nil {
+		actAs = []string{}
+	}
+	_, err := s.db.NewInsert().
+		Model(&CommandDao{
+			CommandID:    cmd.CommandID,
+			UserID:       cmd.UserID,
+			ActAs:        actAs,
+			SubmissionID: cmd.SubmissionID,
+			Endpoint:     cmd.Endpoint,
+			BeginOffset:  cmd.BeginOffset,
+			SubmittedAt:  cmd.SubmittedAt,
+		}).
+		On("CONFLICT (command_id) DO UPDATE").
+		Set("user_id = EXCLUDED.user_id").
+		Set("act_as = EXCLUDED.act_as").
+		Set("submission_id = EXCLUDED.submission_id").
+		Set("endpoint = EXCLUDED.endpoint").
+		Set("begin_offset = EXCLUDED.begin_offset").
+		Set("submitted_at = EXCLUDED.submitted_at").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("save tracked command: %w", err)
+	}
+	return nil
+}
+
+// LoadCommand returns the record of the command, or nil when there is none.
+func (s *Postgres) LoadCommand(ctx context.Context, commandID string) (*ledger.TrackedCommand, error) {
+	dao := new(CommandDao)
+	err := s.db.NewSelect().Model(dao).Where("command_id = ?", commandID).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load tracked command: %w", err)
+	}
+	return &ledger.TrackedCommand{
+		CommandID:    dao.CommandID,
+		UserID:       dao.UserID,
+		ActAs:        dao.ActAs,
+		SubmissionID: dao.SubmissionID,
+		Endpoint:     dao.Endpoint,
+		BeginOffset:  dao.BeginOffset,
+		SubmittedAt:  dao.SubmittedAt,
+	}, nil
+}
+
+// DeleteCommand removes the record of the command, if any.
+func (s *Postgres) DeleteCommand(ctx context.Context, commandID string) error {
+	_, err := s.db.NewDelete().Model((*CommandDao)(nil)).Where("command_id = ?", commandID).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete tracked command: %w", err)
+	}
+	return nil
+}
+
+// ExpireCommands removes the records of commands last submitted before cutoff.
+func (s *Postgres) ExpireCommands(ctx context.Context, cutoff time.Time) error {
+	_, err := s.db.NewDelete().Model((*CommandDao)(nil)).Where("submitted_at < ?", cutoff).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("expire tracked commands: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package commandstore
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+	"github.com/chainsafe/canton-middleware/pkg/pgutil"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+func setupPostgres(t *testing.T) (context.Context, *Postgres) {
+	t.Helper()
+	requireDockerAccess(t)
+
+	ctx := context.Background()
+	db, cleanup := pgutil.SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	require.NoError(t, mghelper.CreateSchema(ctx, db, &CommandDao{}))
+	return ctx, NewPostgres(db)
+}
+
+func requireDockerAccess(t *testing.T) {
+	t.Helper()
+
+	candidates := []string{
+		"/var/run/docker.sock",
+		filepath.Join(os.Getenv("HOME"), ".docker/run/docker.sock"),
+	}
+
+	for _, sock := range candidates {
+		if _, err := os.Stat(sock); err != nil {
+			continue
+		}
+		conn, err := (&net.Dialer{}).DialContext(context.Background(), "unix", sock)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+	}
+
+	t.Skip("docker daemon socket is not accessible; skipping testcontainer-backed command store tests")
+}
+
+func TestPostgres_SaveLoadDelete(t *testing.T) {
+	ctx, store := setupPostgres(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	missing, err := store.LoadCommand(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	cmd := &ledger.TrackedCommand{
+		CommandID:    "cmd-1",
+		UserID:       "user",
+		ActAs:        []string{"alice::1"},
+		SubmissionID: "sub-1",
+		Endpoint:     "participant-1:5001",
+		BeginOffset:  10,
+		SubmittedAt:  now,
+	}
+	require.NoError(t, store.SaveCommand(ctx, cmd))
+
+	// A resubmission replaces the record.
+	cmd.SubmissionID, cmd.SubmittedAt = "sub-2", now.Add(time.Second)
+	require.NoError(t, store.SaveCommand(ctx, cmd))
+
+	got, err := store.LoadCommand(ctx, "cmd-1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, cmd.SubmissionID, got.SubmissionID)
+	assert.Equal(t, cmd.ActAs, got.ActAs)
+	assert.Equal(t, int64(10), got.BeginOffset)
+	assert.True(t, cmd.SubmittedAt.Equal(got.SubmittedAt))
+
+	require.NoError(t, store.DeleteCommand(ctx, "cmd-1"))
+	got, err = store.LoadCommand(ctx, "cmd-1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestPostgres_ExpireCommands(t *testing.T) {
+	ctx, store := setupPostgres(t)
+	now := time.Now().UTC()
+
+	for id, at := range map[string]time.Time{"stale": now.Add(-2 * time.Hour), "fresh": now} {
+		require.NoError(t, store.SaveCommand(ctx, &ledger.TrackedCommand{CommandID: id, SubmittedAt: at}))
+	}
+	require.NoError(t, store.ExpireCommands(ctx, now.Add(-time.Hour)))
+
+	stale, err := store.LoadCommand(ctx, "stale")
+	require.NoError(t, err)
+	assert.Nil(t, stale)
+	fresh, err := store.LoadCommand(ctx, "fresh")
+	require.NoError(t, err)
+	assert.NotNil(t, fresh)
+}
@@ -13,10 +13,15 @@ import (
 	"github.com/chainsafe/canton-middleware/pkg/auth"
 	canton "github.com/chainsafe/canton-middleware/pkg/cantonsdk/client"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
 	"github.com/chainsafe/canton-middleware/pkg/custodial"
 	"github.com/chainsafe/canton-middleware/pkg/ethereum"
 	"github.com/chainsafe/canton-middleware/pkg/ethrpc"
 	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/stream"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/verify"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook"
+	"github.com/chainsafe/canton-middleware/pkg/keys/vault"
 	"github.com/chainsafe/canton-middleware/pkg/log"
 	pgdb "github.com/chainsafe/canton-middleware/pkg/pgutil"
 	"github.com/chainsafe/canton-middleware/pkg/relayer"
@@ -69,21 +74,22 @@ type TokenProviderConfig struct {
 
 // APIServer represents the ERC-20 API server configuration
 type APIServer struct {
-	Server              *http.ServerConfig            `yaml:"server" validate:"required"`
-	Database            *pgdb.DatabaseConfig          `yaml:"database" validate:"required"`
-	Canton              *canton.Config                `yaml:"canton" validate:"required"`
-	Token               *token.Config                 `yaml:"token" validate:"required"`
-	TokenProvider       *TokenProviderConfig          `yaml:"token_provider" default:"-"` // omit → defaults to canton mode
-	EthRPC              *ethrpc.Config                `yaml:"eth_rpc" validate:"required"`
-	Auth                *auth.Config                  `yaml:"auth" default:"-"`          // nil disables read-endpoint auth (SIWE login + JWT)
-	AcceptWorker        *custodial.AcceptWorkerConfig `yaml:"accept_worker" default:"-"` // nil disables the worker
-	Monitoring          *Monitoring                   `yaml:"monitoring" validate:"required"`
-	Logging             *log.Config                   `yaml:"logging" validate:"required"`
-	KeyManagement       *KeyManagement                `yaml:"key_management" validate:"required"`
-	SkipCantonSigVerify bool                          `yaml:"skip_canton_sig_verify" default:"false"`
-	SkipWhitelistCheck  bool                          `yaml:"skip_whitelist_check" default:"false"`
-	CORSOrigins         []string                      `yaml:"cors" default:"[\"*\"]"`
-	Admin               *AdminAPI                     `yaml:"admin" default:"-"` // nil disables the admin endpoints
+	Server              *http.ServerConfig                `yaml:"server" validate:"required"`
+	Database            *pgdb.DatabaseConfig              `yaml:"database" validate:"required"`
+	Canton              *canton.Config                    `yaml:"canton" validate:"required"`
+	Token               *token.Config                     `yaml:"token" validate:"required"`
+	TokenProvider       *TokenProviderConfig              `yaml:"token_provider" default:"-"` // omit → defaults to canton mode
+	EthRPC              *ethrpc.Config                    `yaml:"eth_rpc" validate:"required"`
+	Auth                *auth.Config                      `yaml:"auth" default:"-"`              // nil disables read-endpoint auth (SIWE login + JWT)
+	AcceptWorker        *custodial.AcceptWorkerConfig     `yaml:"accept_worker" default:"-"`     // nil disables the worker
+	CompactionWorker    *custodial.CompactionWorkerConfig `yaml:"compaction_worker" default:"-"` // nil disables holding compaction
+	Monitoring          *Monitoring                       `yaml:"monitoring" validate:"required"`
+	Logging             *log.Config                       `yaml:"logging" validate:"required"`
+	KeyManagement       *KeyManagement                    `yaml:"key_management" validate:"required"`
+	SkipCantonSigVerify bool                              `yaml:"skip_canton_sig_verify" default:"false"`
+	SkipWhitelistCheck  bool                              `yaml:"skip_whitelist_check" default:"false"`
+	CORSOrigins         []string                          `yaml:"cors" default:"[\"*\"]"`
+	Admin               *AdminAPI                         `yaml:"admin" default:"-"` // nil disables the admin endpoints
 }
 
 // AdminAPI configures the optional admin HTTP endpoints (whitelist management).
@@ -117,12 +123,33 @@ type Monitoring struct {
 
 // KeyManagement contains settings for custodial Canton key management
 type KeyManagement struct {
-	// MasterKeyEnv is the environment variable name containing the master encryption key (base64)
+	// MasterKeyEnv is the environment variable name containing the master encryption key (base64).
+	// Keys encrypted before envelope encryption was introduced are decrypted with it. The
+	// variable may be left unset when VaultTransit is configured and no key predates it.
 	MasterKeyEnv string `yaml:"master_key_env" validate:"required" default:"CANTON_MASTER_KEY"`
+	// MasterKeyID is the keyring ID of the master key in MasterKeyEnv, recorded in every
+	// ciphertext it encrypts.
+	MasterKeyID string `yaml:"master_key_id" default:"primary" validate:"required,excludesall=:"`
+	// MasterKeys adds further master keys to the keyring, e.g. the new key during a rotation.
+	MasterKeys []MasterKey `yaml:"master_keys" validate:"dive"`
+	// VaultTransit adds a HashiCorp Vault Transit key to the keyring, which then wraps
+	// data keys remotely. Omit to keep all master keys local.
+	VaultTransit *vault.Config `yaml:"vault_transit" default:"-"`
+	// ActiveKeyID selects the master key new Canton keys are encrypted under and that
+	// rewrap-keys rewraps existing ones to. Empty means the Vault Transit key when
+	// configured, MasterKeyID otherwise.
+	ActiveKeyID string `yaml:"active_key_id"`
 	// KeyDerivation specifies how to generate Canton keys: "generate" (random) or "derive" (from EVM + seed)
 	KeyDerivation string `yaml:"key_derivation" default:"generate" validate:"required,oneof=generate derive"`
 }
 
+// MasterKey names an additional keyring master key.
+type MasterKey struct {
+	ID string `yaml:"id" validate:"required,excludesall=:"`
+	// Env is the environment variable name containing the key (base64).
+	Env string `yaml:"env" validate:"required"`
+}
+
 // LoadAPIServer loads, defaults, and validates API app configuration from file.
 func LoadAPIServer(configPath string) (*APIServer, error) {
 	var cfg APIServer
@@ -161,8 +188,17 @@ type IndexerServer struct {
 	// Bridge sub-clients are not required.
 	CantonLedger *ledger.Config  `yaml:"canton_ledger" validate:"required"`
 	Indexer      *indexer.Config `yaml:"indexer" validate:"required"`
-	Monitoring   *Monitoring     `yaml:"monitoring" validate:"required"`
-	Logging      *log.Config     `yaml:"logging" validate:"required"`
+	// Packages bounds the Daml package versions discovered for the package IDs
+	// left empty under indexer and verifier. nil accepts any vetted version.
+	Packages  *packages.Config `yaml:"packages" default:"-"`
+	Webhooks  *webhook.Config  `yaml:"webhooks" default:"-"`  // nil disables the webhook API and delivery
+	Streaming *stream.Config   `yaml:"streaming" default:"-"` // nil disables the event stream endpoints
+	Verifier  *verify.Config   `yaml:"verifier" default:"-"`  // nil disables drift checks and the drift API
+	// Auth validates api-server-issued JWTs on the read API. nil leaves the API
+	// unauthenticated, for deployments that restrict it at the network level.
+	Auth       *auth.ValidatorConfig `yaml:"auth" default:"-"`
+	Monitoring *Monitoring           `yaml:"monitoring" validate:"required"`
+	Logging    *log.Config           `yaml:"logging" validate:"required"`
 }
 
 // LoadIndexerServer loads, defaults, and validates indexer configuration from file.
@@ -245,6 +245,27 @@ func TestLoadRelayerServer_AppliesDefaults(t *testing.T) {
 	}
 }
 
+func TestLoadAPIServer_PackageDiscovery(t *testing.T) {
+	cfg, err := LoadAPIServer(testConfigPath(t, "package-discovery.api.yaml"))
+	if err != nil {
+		t.Fatalf("expected package IDs to be optional, got error: %v", err)
+	}
+
+	if cfg.Canton.Token.CIP56PackageID != "" || cfg.Canton.Identity.PackageID != "" {
+		t.Fatalf("expected package IDs to stay empty for discovery, got %+v", cfg.Canton.Token)
+	}
+	pkgs := cfg.Canton.Packages
+	if pkgs == nil || !pkgs.PinPackageIDs {
+		t.Fatalf("expected packages.pin_package_ids to load, got %+v", pkgs)
+	}
+	if r := pkgs.Versions["cip56-token"]; r.Min != "1.0.0" || r.Max != "1.9.9" {
+		t.Fatalf("unexpected cip56-token version range: %+v", r)
+	}
+	if _, ok := pkgs.Versions["utility-registry-app-v0"]; !ok {
+		t.Fatal("expected open range for utility-registry-app-v0")
+	}
+}
+
 func TestLoadAPIServer_RequiredValidation(t *testing.T) {
 	path := testConfigPath(t, "missing-required.api.yaml")
 
@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package custodial
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+
+	"go.uber.org/zap"
+)
+
+// retryTick is how often the retry queue is checked for due offers.
+const retryTick = time.Second
+
+// partyLock is held by the handler of an offer to a party; refs counts the
+// handlers holding or waiting for it.
+type partyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// retryEntry is a failed offer waiting for its next attempt.
+type retryEntry struct {
+	evmAddress string
+	transfer   *indexer.Transfer
+	attempts   int
+	due        time.Time
+}
+
+// runStream is Run in stream mode.
+//
+// Offers whose receiver is a custodial party are handed to a pool of at most
+// MaxConcurrentAccepts handlers as they arrive. Offers to the same party are
+// handled one at a time — in process by lockParty, across replicas by the
+// policy's party lock — so the policy's daily cap is evaluated against every
+// accept recorded before it; offers to different parties run concurrently. The
+// cursor is saved after each batch is dispatched, so a restart resumes the stream without replaying it;
+// offers still being handled at that point are caught by the sweep every start
+// begins with. A failed accept is retried with exponential backoff, and after
+// MaxRetries is left to the sweep, which pages through all pending offers every
+// SweepInterval exactly as the polling worker does.
+//
+// The custodial party set is reloaded by every sweep and, when the stream
+// brings an unknown receiver, at most once per poll interval; an offer to a
+// user registered since is caught by the next sweep.
+func (w *AcceptWorker) runStream(ctx context.Context) error {
+	w.logger.Info("accept worker started in stream mode",
+		zap.Duration("sweep_interval", w.streamCfg.SweepInterval),
+		zap.Int("max_concurrent_accepts", w.streamCfg.MaxConcurrentAccepts),
+	)
+	defer func() {
+		w.wg.Wait()
+		w.logger.Info("accept worker stopped")
+	}()
+
+	offset, ok := w.startOffset(ctx)
+	if !ok {
+		return nil
+	}
+	w.acceptPending(ctx)
+
+	batches := w.stream.Subscribe(ctx, offset)
+	sweepTicker := time.NewTicker(w.streamCfg.SweepInterval)
+	defer sweepTicker.Stop()
+	retryTicker := time.NewTicker(retryTick)
+	defer retryTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case batch, ok := <-batches:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				w.logger.Warn("accept worker: offer stream ended, resubscribing", zap.Int64("offset", offset))
+				batches = w.stream.Subscribe(ctx, offset)
+				continue
+			}
+			w.handleBatch(ctx, batch)
+			offset = batch.Offset
+		case <-sweepTicker.C:
+			w.acceptPending(ctx)
+		case <-retryTicker.C:
+			w.retryDue(ctx)
+		}
+	}
+}
+
+// startOffset returns the saved cursor or, on first start, the ledger end —
+// offers created before it are left to the first sweep. Failures are retried
+// every poll interval; false is returned when ctx is canceled first.
+func (w *AcceptWorker) startOffset(ctx context.Context) (int64, bool) {
+	for {
+		offset, saved, err := w.cursor.LoadOffset(ctx)
+		if err == nil && !saved {
+			offset, err = w.stream.LedgerEnd(ctx)
+		}
+		if err == nil {
+			w.metrics.StreamOffset.Set(float64(offset))
+			return offset, true
+		}
+		w.metrics.ErrorsTotal.WithLabelValues("load_cursor").Inc()
+		w.logger.Warn("accept worker: failed to determine stream start offset", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return 0, false
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// handleBatch dispatches the custodial offers created in batch, forgets the
+// archived ones and saves the batch's offset.
+func (w *AcceptWorker) handleBatch(ctx context.Context, batch *streaming.Batch[*indexer.Transfer]) {
+	for _, transfer := range batch.Items {
+		if transfer.Archived {
+			w.forget(transfer.ContractID)
+			continue
+		}
+		evmAddress, ok := w.custodialParty(ctx, transfer.ToPartyID)
+		if !ok {
+			continue
+		}
+		w.metrics.StreamOffersTotal.Inc()
+		w.dispatch(ctx, evmAddress, transfer)
+	}
+
+	if err := w.cursor.SaveOffset(ctx, batch.Offset); err != nil {
+		w.metrics.ErrorsTotal.WithLabelValues("save_cursor").Inc()
+		w.logger.Warn("accept worker: failed to save stream cursor",
+			zap.Int64("offset", batch.Offset),
+			zap.Error(err),
+		)
+		return
+	}
+	w.metrics.StreamOffset.Set(float64(batch.Offset))
+}
+
+// custodialParty returns the EVM address of the custodial user owning
+// partyID. An unknown party reloads the party set if it is older than the poll
+// interval.
+func (w *AcceptWorker) custodialParty(ctx context.Context, partyID string) (string, bool) {
+	w.mu.Lock()
+	evmAddress, ok := w.parties[partyID]
+	stale := time.Since(w.partiesAt) >= w.pollInterval
+	w.mu.Unlock()
+	if ok || !stale {
+		return evmAddress, ok
+	}
+
+	parties, err := w.loadParties(ctx)
+	if err != nil {
+		return "", false
+	}
+	evmAddress, ok = parties[partyID]
+	return evmAddress, ok
+}
+
+// dispatch hands the offer to the handler pool, blocking while the pool is
+// full. Offers already being handled, or waiting out a retry backoff, are
+// skipped. The handler waits for any other handler of an offer to the same
+// party to finish first.
+func (w *AcceptWorker) dispatch(ctx context.Context, evmAddress string, transfer *indexer.Transfer) {
+	cid := transfer.ContractID
+	w.mu.Lock()
+	if r := w.retries[cid]; w.inflight[cid] || (r != nil && time.Now().Before(r.due)) {
+		w.mu.Unlock()
+		return
+	}
+	w.inflight[cid] = true
+	w.mu.Unlock()
+
+	select {
+	case w.sem <- struct{}{}:
+	case <-ctx.Done():
+		w.mu.Lock()
+		delete(w.inflight, cid)
+		w.mu.Unlock()
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer func() { <-w.sem }()
+
+		unlock := w.lockParty(transfer.ToPartyID)
+		err := w.handleOffer(ctx, evmAddress, transfer)
+		unlock()
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.inflight, cid)
+		if err == nil || ctx.Err() != nil {
+			delete(w.retries, cid)
+		} else {
+			w.scheduleRetry(evmAddress, transfer)
+		}
+		w.metrics.RetryQueue.Set(float64(len(w.retries)))
+	}()
+}
+
+// lockParty blocks until no other handler holds partyID and returns the
+// function releasing it. Locks are dropped once no handler references them.
+func (w *AcceptWorker) lockParty(partyID string) func() {
+	w.mu.Lock()
+	l := w.partyLocks[partyID]
+	if l == nil {
+		l = &partyLock{}
+		w.partyLocks[partyID] = l
+	}
+	l.refs++
+	w.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		w.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(w.partyLocks, partyID)
+		}
+		w.mu.Unlock()
+	}
+}
+
+// scheduleRetry queues a failed offer for another attempt, or drops it once it
+// has used up its retries. Callers hold w.mu.
+func (w *AcceptWorker) scheduleRetry(evmAddress string, transfer *indexer.Transfer) {
+	cid := transfer.ContractID
+	r := w.retries[cid]
+	if r == nil {
+		r = &retryEntry{evmAddress: evmAddress, transfer: transfer}
+		w.retries[cid] = r
+	}
+	r.attempts++
+	if r.attempts > w.streamCfg.MaxRetries {
+		delete(w.retries, cid)
+		w.metrics.RetriesExhaustedTotal.Inc()
+		w.logger.Warn("accept worker: retries exhausted, leaving offer to the sweep",
+			zap.String("party_id", transfer.ToPartyID),
+			zap.String("contract_id", cid),
+			zap.Int("attempts", r.attempts),
+		)
+		return
+	}
+	r.due = time.Now().Add(w.backoff(r.attempts))
+}
+
+// backoff returns the wait before the given retry attempt: RetryBaseDelay
+// doubling per attempt, capped at RetryMaxDelay.
+func (w *AcceptWorker) backoff(attempt int) time.Duration {
+	d := w.streamCfg.RetryBaseDelay
+	for i := 1; i < attempt && d < w.streamCfg.RetryMaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, w.streamCfg.RetryMaxDelay)
+}
+
+// retryDue dispatches the queued offers whose backoff has elapsed.
+func (w *AcceptWorker) retryDue(ctx context.Context) {
+	now := time.Now()
+	w.mu.Lock()
+	due := make([]*retryEntry, 0, len(w.retries))
+	for _, r := range w.retries {
+		if !now.Before(r.due) {
+			due = append(due, r)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, r := range due {
+		w.dispatch(ctx, r.evmAddress, r.transfer)
+	}
+}
+
+// forget drops the state kept for an offer that is no longer pending.
+func (w *AcceptWorker) forget(contractID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.held, contractID)
+	delete(w.retries, contractID)
+	w.metrics.RetryQueue.Set(float64(len(w.retries)))
+}
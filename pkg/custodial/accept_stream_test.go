@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package custodial
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/mocks"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	indexermocks "github.com/chainsafe/canton-middleware/pkg/indexer/client/mocks"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+func testStreamConfig() *AcceptStreamConfig {
+	return &AcceptStreamConfig{
+		SweepInterval:        time.Hour,
+		MaxConcurrentAccepts: 4,
+		RetryBaseDelay:       time.Second,
+		RetryMaxDelay:        10 * time.Second,
+		MaxRetries:           2,
+	}
+}
+
+// streamOffer returns a pending offer as decoded from the ledger stream.
+func streamOffer(contractID, receiver string) *indexer.Transfer {
+	offer := pendingOffer(contractID)
+	offer.ToPartyID = receiver
+	return &offer
+}
+
+// expectEmptySweep sets up the sweep every stream-mode start begins with.
+func expectEmptySweep(lister *mocks.UserLister, ic *indexermocks.Client) {
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).Return(allOffersPage(), nil)
+}
+
+// runWorker runs w until the test ends.
+func runWorker(t *testing.T, w *AcceptWorker) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, w.Run(ctx))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestAcceptWorker_Stream_ResumesFromCursor(t *testing.T) {
+	tok := mocks.NewToken(t)
+	lister := mocks.NewUserLister(t)
+	ic := indexermocks.NewClient(t)
+	offers := mocks.NewOfferStream(t)
+	cursor := mocks.NewCursorStore(t)
+	expectEmptySweep(lister, ic)
+
+	batches := make(chan *streaming.Batch[*indexer.Transfer], 1)
+	cursor.EXPECT().LoadOffset(mock.Anything).Return(100, true, nil)
+	offers.EXPECT().Subscribe(mock.Anything, int64(100)).Return(batches)
+
+	accepted := make(chan string, 2)
+	tok.EXPECT().AcceptTransferInstruction(mock.Anything, "custodial-party::abc", mock.Anything, testInstrumentAdmin).
+		RunAndReturn(func(_ context.Context, _, cid, _ string) error {
+			accepted <- cid
+			return nil
+		})
+	saved := make(chan int64, 1)
+	cursor.EXPECT().SaveOffset(mock.Anything, int64(101)).RunAndReturn(func(_ context.Context, offset int64) error {
+		saved <- offset
+		return nil
+	})
+
+	w := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(offers, cursor, testStreamConfig()))
+	runWorker(t, w)
+
+	// Only the custodial receiver's offer is accepted; the archive is ignored.
+	batches <- &streaming.Batch[*indexer.Transfer]{Offset: 101, Items: []*indexer.Transfer{
+		streamOffer("contract-other", "other-party::xyz"),
+		streamOffer("contract-1", "custodial-party::abc"),
+		{ContractID: "contract-old", Archived: true},
+	}}
+	require.Equal(t, "contract-1", <-accepted)
+	require.Equal(t, int64(101), <-saved)
+}
+
+func TestAcceptWorker_Stream_StartsAtLedgerEndWithoutCursor(t *testing.T) {
+	lister := mocks.NewUserLister(t)
+	ic := indexermocks.NewClient(t)
+	offers := mocks.NewOfferStream(t)
+	cursor := mocks.NewCursorStore(t)
+	expectEmptySweep(lister, ic)
+
+	cursor.EXPECT().LoadOffset(mock.Anything).Return(0, false, nil)
+	offers.EXPECT().LedgerEnd(mock.Anything).Return(500, nil)
+	subscribed := make(chan int64, 1)
+	offers.EXPECT().Subscribe(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, after int64) <-chan *streaming.Batch[*indexer.Transfer] {
+			subscribed <- after
+			return make(chan *streaming.Batch[*indexer.Transfer])
+		})
+
+	w := NewAcceptWorker(mocks.NewToken(t), lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(offers, cursor, testStreamConfig()))
+	runWorker(t, w)
+
+	require.Equal(t, int64(500), <-subscribed)
+}
+
+func TestAcceptWorker_Stream_BoundsConcurrentAccepts(t *testing.T) {
+	tok := mocks.NewToken(t)
+	cfg := testStreamConfig()
+	cfg.MaxConcurrentAccepts = 2
+	w := NewAcceptWorker(tok, nil, nil, nil, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(nil, nil, cfg))
+
+	var (
+		mu            sync.Mutex
+		running, peak int
+	)
+	release := make(chan struct{})
+	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string, string, string) error {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		}).Times(5)
+
+	ctx := context.Background()
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		for _, cid := range []string{"c-1", "c-2", "c-3", "c-4", "c-5"} {
+			w.dispatch(ctx, custodialUser().EVMAddress, streamOffer(cid, "custodial-party::"+cid))
+		}
+	}()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return running == 2
+	}, time.Second, time.Millisecond)
+	close(release)
+	<-dispatched
+	w.wg.Wait()
+	assert.Equal(t, 2, peak)
+}
+
+func TestAcceptWorker_Stream_SerializesOffersToSameParty(t *testing.T) {
+	tok := mocks.NewToken(t)
+	cfg := testStreamConfig()
+	cfg.MaxConcurrentAccepts = 4
+	w := NewAcceptWorker(tok, nil, nil, nil, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(nil, nil, cfg))
+
+	var (
+		mu      sync.Mutex
+		running = make(map[string]int)
+		peak    = make(map[string]int)
+		total   int
+	)
+	release := make(chan struct{})
+	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, partyID, _, _ string) error {
+			mu.Lock()
+			running[partyID]++
+			total++
+			peak[partyID] = max(peak[partyID], running[partyID])
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			running[partyID]--
+			mu.Unlock()
+			return nil
+		}).Times(4)
+
+	ctx := context.Background()
+	w.dispatch(ctx, custodialUser().EVMAddress, streamOffer("c-1", "custodial-party::abc"))
+	w.dispatch(ctx, custodialUser().EVMAddress, streamOffer("c-2", "custodial-party::abc"))
+	w.dispatch(ctx, custodialUser().EVMAddress, streamOffer("c-3", "custodial-party::abc"))
+	w.dispatch(ctx, custodialUser().EVMAddress, streamOffer("c-4", "custodial-party::def"))
+
+	// One offer per party is accepted at once; the other two wait their turn.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return total == 2
+	}, time.Second, time.Millisecond)
+	close(release)
+	w.wg.Wait()
+	assert.Equal(t, map[string]int{"custodial-party::abc": 1, "custodial-party::def": 1}, peak)
+	assert.Empty(t, w.partyLocks)
+}
+
+func TestAcceptWorker_Stream_RetriesFailedAccept(t *testing.T) {
+	tok := mocks.NewToken(t)
+	w := NewAcceptWorker(tok, nil, nil, nil, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(nil, nil, testStreamConfig()))
+	ctx := context.Background()
+	offer := streamOffer("contract-1", "custodial-party::abc")
+
+	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, "contract-1", mock.Anything).
+		Return(errors.New("unavailable")).Once()
+	w.dispatch(ctx, custodialUser().EVMAddress, offer)
+	w.wg.Wait()
+	require.Contains(t, w.retries, "contract-1")
+	assert.Equal(t, 1, w.retries["contract-1"].attempts)
+
+	// Within the backoff the offer is neither retried nor re-dispatched.
+	w.retryDue(ctx)
+	w.dispatch(ctx, custodialUser().EVMAddress, offer)
+	w.wg.Wait()
+
+	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, "contract-1", mock.Anything).
+		Return(nil).Once()
+	w.retries["contract-1"].due = time.Now()
+	w.retryDue(ctx)
+	w.wg.Wait()
+	assert.Empty(t, w.retries)
+}
+
+func TestAcceptWorker_Stream_RetriesExhausted(t *testing.T) {
+	w := NewAcceptWorker(nil, nil, nil, nil, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(nil, nil, testStreamConfig()))
+	offer := streamOffer("contract-1", "custodial-party::abc")
+
+	for range 2 {
+		w.scheduleRetry(custodialUser().EVMAddress, offer)
+		require.Contains(t, w.retries, "contract-1")
+	}
+	w.scheduleRetry(custodialUser().EVMAddress, offer)
+	assert.NotContains(t, w.retries, "contract-1", "left to the sweep after MaxRetries")
+}
+
+func TestAcceptWorker_Stream_ArchiveForgetsOffer(t *testing.T) {
+	w := NewAcceptWorker(nil, nil, nil, nil, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(nil, nil, testStreamConfig()))
+	w.held["contract-1"] = "sender_denied"
+	w.scheduleRetry(custodialUser().EVMAddress, streamOffer("contract-1", "custodial-party::abc"))
+
+	w.forget("contract-1")
+	assert.Empty(t, w.held)
+	assert.Empty(t, w.retries)
+}
+
+func TestAcceptWorker_Backoff(t *testing.T) {
+	w := NewAcceptWorker(nil, nil, nil, nil, time.Hour, NewNopMetrics(), zap.NewNop(),
+		WithOfferStream(nil, nil, testStreamConfig()))
+
+	for attempt, want := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		4:  8 * time.Second,
+		5:  10 * time.Second,
+		60: 10 * time.Second,
+	} {
+		assert.Equal(t, want, w.backoff(attempt), "attempt %d", attempt)
+	}
+}
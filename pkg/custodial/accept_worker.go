@@ -5,9 +5,12 @@ package custodial
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
 	cantontkn "github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy"
 	"github.com/chainsafe/canton-middleware/pkg/indexer"
 	indexerclient "github.com/chainsafe/canton-middleware/pkg/indexer/client"
 	"github.com/chainsafe/canton-middleware/pkg/user"
@@ -25,6 +28,51 @@ type UserLister interface {
 	ListCustodialUsers(ctx context.Context) ([]*user.User, error)
 }
 
+// Policy decides each offer before the AcceptWorker acts on it and records the
+// decision. LockParty serializes the decisions on one party's offers across
+// worker replicas, so each sees the accepts recorded before it. It is
+// satisfied by policy.Service.
+//
+//go:generate mockery --name Policy --output mocks --outpkg mocks --filename mock_policy.go --with-expecter
+type Policy interface {
+	Evaluate(ctx context.Context, evmAddress string, offer *indexer.Transfer) (*policy.Decision, error)
+	Record(ctx context.Context, rec *policy.AuditRecord) error
+	LockParty(ctx context.Context, partyID string) (func(), error)
+}
+
+// OfferStream pushes TransferOffer creations and archives as they are
+// committed. It is satisfied by LedgerOfferStream.
+//
+//go:generate mockery --name OfferStream --output mocks --outpkg mocks --filename mock_offer_stream.go --with-expecter
+type OfferStream interface {
+	LedgerEnd(ctx context.Context) (int64, error)
+	Subscribe(ctx context.Context, after int64) <-chan *streaming.Batch[*indexer.Transfer]
+}
+
+// CursorStore persists the last ledger offset the stream consumer handled. It
+// is satisfied by PostgresCursor.
+//
+//go:generate mockery --name CursorStore --output mocks --outpkg mocks --filename mock_cursor_store.go --with-expecter
+type CursorStore interface {
+	LoadOffset(ctx context.Context) (int64, bool, error)
+	SaveOffset(ctx context.Context, offset int64) error
+}
+
+// AcceptWorkerOption configures optional AcceptWorker behavior.
+type AcceptWorkerOption func(*AcceptWorker)
+
+// WithOfferStream makes the worker event-driven: offers are taken from stream
+// as they are committed, resuming from the offset kept in cursor, and the
+// indexer is only swept every cfg.SweepInterval. See runStream.
+func WithOfferStream(stream OfferStream, cursor CursorStore, cfg *AcceptStreamConfig) AcceptWorkerOption {
+	return func(w *AcceptWorker) {
+		w.stream = stream
+		w.cursor = cursor
+		w.streamCfg = *cfg
+		w.sem = make(chan struct{}, cfg.MaxConcurrentAccepts)
+	}
+}
+
 // AcceptWorker polls the indexer for all pending TransferOffers and automatically
 // accepts them on behalf of registered custodial parties.
 //
@@ -36,43 +84,92 @@ type UserLister interface {
 //
 // A custodial user registered after the ListUsers call at the start of a cycle
 // is caught on the next tick — at most one poll-interval delay.
+//
+// With a Policy, every offer is evaluated before it is accepted: it is
+// accepted, rejected on-ledger, or held (left pending). Accepts and rejects are
+// recorded with their outcome; a held offer is re-evaluated every cycle but
+// recorded only when it is first held or held for a different reason.
+//
+// WithOfferStream replaces the poll loop with a push feed; polling remains the
+// default.
 type AcceptWorker struct {
 	cantonToken  cantontkn.Token
 	userLister   UserLister
+	policy       Policy
 	indexer      indexerclient.Client
 	pollInterval time.Duration
 	metrics      *Metrics
 	logger       *zap.Logger
+
+	// Stream mode; see WithOfferStream. sem bounds the concurrent offer
+	// handlers, wg waits for them on shutdown.
+	stream    OfferStream
+	cursor    CursorStore
+	streamCfg AcceptStreamConfig
+	sem       chan struct{}
+	wg        sync.WaitGroup
+
+	mu sync.Mutex
+	// held maps the contract IDs of held offers to the reason they were last
+	// recorded with.
+	held map[string]string
+	// parties maps custodial party IDs to their users' EVM addresses, as last
+	// loaded at partiesAt.
+	parties   map[string]string
+	partiesAt time.Time
+	// inflight holds the contract IDs being handled, retries the failed offers
+	// waiting for their next attempt. Both are used in stream mode only.
+	inflight map[string]bool
+	retries  map[string]*retryEntry
+	// partyLocks serializes the handlers of offers to the same party in stream
+	// mode before they contend for the party's Policy.LockParty, which
+	// serializes them across replicas.
+	partyLocks map[string]*partyLock
 }
 
 // NewAcceptWorker creates a new AcceptWorker.
 //
+// acceptPolicy may be nil, in which case every offer to a custodial party is accepted.
 // metrics receives Prometheus observations for cycle duration, per-phase
 // errors, and per-offer accept outcomes. Pass NewNopMetrics() in tests where
 // metric values aren't asserted.
 func NewAcceptWorker(
 	cantonToken cantontkn.Token,
 	userLister UserLister,
+	acceptPolicy Policy,
 	indexerClient indexerclient.Client,
 	pollInterval time.Duration,
 	metrics *Metrics,
 	logger *zap.Logger,
+	opts ...AcceptWorkerOption,
 ) *AcceptWorker {
 	if metrics == nil {
 		metrics = NewNopMetrics()
 	}
-	return &AcceptWorker{
+	w := &AcceptWorker{
 		cantonToken:  cantonToken,
 		userLister:   userLister,
+		policy:       acceptPolicy,
 		indexer:      indexerClient,
 		pollInterval: pollInterval,
 		metrics:      metrics,
 		logger:       logger,
+		held:         make(map[string]string),
+		inflight:     make(map[string]bool),
+		retries:      make(map[string]*retryEntry),
+		partyLocks:   make(map[string]*partyLock),
+	}
+	for _, opt := range opts {
+		opt(w)
 	}
+	return w
 }
 
 // Run starts the accept worker loop. It blocks until ctx is canceled.
 func (w *AcceptWorker) Run(ctx context.Context) error {
+	if w.stream != nil {
+		return w.runStream(ctx)
+	}
 	w.logger.Info("accept worker started", zap.Duration("poll_interval", w.pollInterval))
 	ticker := time.NewTicker(w.pollInterval)
 	defer ticker.Stop()
@@ -95,23 +192,16 @@ func (w *AcceptWorker) acceptPending(ctx context.Context) {
 		w.metrics.RunDuration.Observe(time.Since(start).Seconds())
 	}()
 
-	users, err := w.userLister.ListCustodialUsers(ctx)
+	custodialParties, err := w.loadParties(ctx)
 	if err != nil {
-		w.metrics.ErrorsTotal.WithLabelValues("list_users").Inc()
-		w.logger.Warn("accept worker: failed to list custodial users", zap.Error(err))
 		return
 	}
-	w.metrics.CustodialUsers.Set(float64(len(users)))
-	if len(users) == 0 {
+	if len(custodialParties) == 0 {
 		// Vacuously successful — there's nothing to accept, but the loop is alive.
 		w.metrics.LastSuccessfulRunTimestamp.SetToCurrentTime()
 		return
 	}
-
-	custodialParties := make(map[string]bool, len(users))
-	for _, u := range users {
-		custodialParties[u.CantonPartyID] = true
-	}
+	seen := make(map[string]bool)
 
 	page := 1
 	for {
@@ -134,32 +224,14 @@ func (w *AcceptWorker) acceptPending(ctx context.Context) {
 		w.metrics.OffersFetchedTotal.Add(float64(len(result.Items)))
 
 		for i := range result.Items {
-			transfer := result.Items[i]
+			transfer := &result.Items[i]
 			// ToPartyID is the offer receiver — the custodial party that must accept.
-			if !custodialParties[transfer.ToPartyID] {
+			evmAddress, ok := custodialParties[transfer.ToPartyID]
+			if !ok {
 				continue
 			}
-			acceptStart := time.Now()
-			err := w.cantonToken.AcceptTransferInstruction(
-				ctx, transfer.ToPartyID, transfer.ContractID, transfer.InstrumentAdmin,
-			)
-			w.metrics.OfferAcceptDuration.Observe(time.Since(acceptStart).Seconds())
-			if err != nil {
-				w.metrics.OffersAcceptedTotal.WithLabelValues("error").Inc()
-				w.logger.Warn("accept worker: failed to accept offer",
-					zap.String("party_id", transfer.ToPartyID),
-					zap.String("contract_id", transfer.ContractID),
-					zap.Error(err),
-				)
-				continue
-			}
-			w.metrics.OffersAcceptedTotal.WithLabelValues("success").Inc()
-			w.logger.Info("accept worker: accepted transfer offer",
-				zap.String("party_id", transfer.ToPartyID),
-				zap.String("contract_id", transfer.ContractID),
-				zap.String("sender", transfer.FromPartyID),
-				zap.String("amount", transfer.Amount),
-			)
+			seen[transfer.ContractID] = true
+			w.process(ctx, evmAddress, transfer)
 		}
 
 		if int64(page*acceptWorkerPageLimit) >= result.Total {
@@ -168,8 +240,169 @@ func (w *AcceptWorker) acceptPending(ctx context.Context) {
 			// against the cycle's success: those are tracked in
 			// OffersAcceptedTotal{result="error"}.
 			w.metrics.LastSuccessfulRunTimestamp.SetToCurrentTime()
+			// Every pending offer was seen, so held offers no longer pending
+			// (accepted manually, withdrawn, expired) can be forgotten.
+			w.mu.Lock()
+			for cid := range w.held {
+				if !seen[cid] {
+					delete(w.held, cid)
+				}
+			}
+			w.mu.Unlock()
 			return
 		}
 		page++
 	}
 }
+
+// loadParties lists the custodial users and remembers their parties, mapped
+// to the owning user's EVM address, which scopes the user's policy rules.
+func (w *AcceptWorker) loadParties(ctx context.Context) (map[string]string, error) {
+	users, err := w.userLister.ListCustodialUsers(ctx)
+	if err != nil {
+		w.metrics.ErrorsTotal.WithLabelValues("list_users").Inc()
+		w.logger.Warn("accept worker: failed to list custodial users", zap.Error(err))
+		return nil, err
+	}
+	w.metrics.CustodialUsers.Set(float64(len(users)))
+
+	parties := make(map[string]string, len(users))
+	for _, u := range users {
+		parties[u.CantonPartyID] = u.EVMAddress
+	}
+	w.mu.Lock()
+	w.parties, w.partiesAt = parties, time.Now()
+	w.mu.Unlock()
+	return parties, nil
+}
+
+// process handles a custodial offer: inline when polling, on the bounded
+// handler pool in stream mode.
+func (w *AcceptWorker) process(ctx context.Context, evmAddress string, transfer *indexer.Transfer) {
+	if w.stream != nil {
+		w.dispatch(ctx, evmAddress, transfer)
+		return
+	}
+	_ = w.handleOffer(ctx, evmAddress, transfer)
+}
+
+// handleOffer evaluates one custodial offer against the policy and acts on the
+// decision. Without a policy the offer is accepted. A policy evaluation error
+// leaves the offer pending — the worker fails closed. The returned error means
+// the offer is still pending and worth retrying; when polling, that happens on
+// the next cycle.
+//
+// The receiver's party lock is held from the evaluation until the decision is
+// recorded, so no other worker replica evaluates an offer to the same party
+// against a daily total that misses this one.
+func (w *AcceptWorker) handleOffer(ctx context.Context, evmAddress string, transfer *indexer.Transfer) error {
+	if w.policy == nil {
+		return w.accept(ctx, transfer)
+	}
+
+	unlock, err := w.policy.LockParty(ctx, transfer.ToPartyID)
+	if err != nil {
+		w.metrics.PolicyDecisionsTotal.WithLabelValues("error").Inc()
+		w.logger.Warn("accept worker: failed to lock party for accept policy",
+			zap.String("party_id", transfer.ToPartyID),
+			zap.String("contract_id", transfer.ContractID),
+			zap.Error(err),
+		)
+		return err
+	}
+	defer unlock()
+
+	decision, err := w.policy.Evaluate(ctx, evmAddress, transfer)
+	if err != nil {
+		w.metrics.PolicyDecisionsTotal.WithLabelValues("error").Inc()
+		w.logger.Warn("accept worker: failed to evaluate accept policy",
+			zap.String("party_id", transfer.ToPartyID),
+			zap.String("contract_id", transfer.ContractID),
+			zap.Error(err),
+		)
+		return err
+	}
+	w.metrics.PolicyDecisionsTotal.WithLabelValues(string(decision.Action)).Inc()
+
+	rec := policy.NewAuditRecord(evmAddress, transfer, decision)
+	switch decision.Action {
+	case policy.ActionAccept:
+		err = w.accept(ctx, transfer)
+	case policy.ActionReject:
+		err = w.reject(ctx, transfer, decision.Reason)
+	case policy.ActionHold:
+		w.mu.Lock()
+		unchanged := w.held[transfer.ContractID] == decision.Reason
+		w.held[transfer.ContractID] = decision.Reason
+		w.mu.Unlock()
+		if unchanged {
+			return nil
+		}
+		w.logger.Info("accept worker: holding transfer offer",
+			zap.String("party_id", transfer.ToPartyID),
+			zap.String("contract_id", transfer.ContractID),
+			zap.String("reason", decision.Reason),
+		)
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	if recErr := w.policy.Record(ctx, rec); recErr != nil {
+		w.logger.Error("accept worker: failed to record accept policy decision",
+			zap.String("contract_id", transfer.ContractID),
+			zap.String("action", string(decision.Action)),
+			zap.Error(recErr),
+		)
+	}
+	return err
+}
+
+// accept accepts the offer on behalf of its custodial receiver.
+func (w *AcceptWorker) accept(ctx context.Context, transfer *indexer.Transfer) error {
+	acceptStart := time.Now()
+	err := w.cantonToken.AcceptTransferInstruction(
+		ctx, transfer.ToPartyID, transfer.ContractID, transfer.InstrumentAdmin,
+	)
+	w.metrics.OfferAcceptDuration.Observe(time.Since(acceptStart).Seconds())
+	if err != nil {
+		w.metrics.OffersAcceptedTotal.WithLabelValues("error").Inc()
+		w.logger.Warn("accept worker: failed to accept offer",
+			zap.String("party_id", transfer.ToPartyID),
+			zap.String("contract_id", transfer.ContractID),
+			zap.Error(err),
+		)
+		return err
+	}
+	w.metrics.OffersAcceptedTotal.WithLabelValues("success").Inc()
+	w.logger.Info("accept worker: accepted transfer offer",
+		zap.String("party_id", transfer.ToPartyID),
+		zap.String("contract_id", transfer.ContractID),
+		zap.String("sender", transfer.FromPartyID),
+		zap.String("amount", transfer.Amount),
+	)
+	return nil
+}
+
+// reject rejects the offer on-ledger, returning the holding to the sender.
+func (w *AcceptWorker) reject(ctx context.Context, transfer *indexer.Transfer, reason string) error {
+	err := w.cantonToken.RejectTransferInstruction(
+		ctx, transfer.ToPartyID, transfer.ContractID, transfer.InstrumentAdmin,
+	)
+	if err != nil {
+		w.metrics.OffersRejectedTotal.WithLabelValues("error").Inc()
+		w.logger.Warn("accept worker: failed to reject offer",
+			zap.String("party_id", transfer.ToPartyID),
+			zap.String("contract_id", transfer.ContractID),
+			zap.Error(err),
+		)
+		return err
+	}
+	w.metrics.OffersRejectedTotal.WithLabelValues("success").Inc()
+	w.logger.Info("accept worker: rejected transfer offer",
+		zap.String("party_id", transfer.ToPartyID),
+		zap.String("contract_id", transfer.ContractID),
+		zap.String("sender", transfer.FromPartyID),
+		zap.String("reason", reason),
+	)
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/chainsafe/canton-middleware/pkg/custodial/mocks"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy"
 	"github.com/chainsafe/canton-middleware/pkg/indexer"
 	indexermocks "github.com/chainsafe/canton-middleware/pkg/indexer/client/mocks"
 	"github.com/chainsafe/canton-middleware/pkg/user"
@@ -59,7 +60,7 @@ func TestAcceptWorker_NoCustodialUsers(t *testing.T) {
 	// DB returns no custodial users — worker exits early, no indexer call
 	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{}, nil)
 
-	worker := NewAcceptWorker(tok, lister, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop())
 	worker.acceptPending(context.Background())
 }
 
@@ -77,7 +78,7 @@ func TestAcceptWorker_AcceptsSingleOffer(t *testing.T) {
 	tok.EXPECT().AcceptTransferInstruction(mock.Anything, "custodial-party::abc", "contract-1", testInstrumentAdmin).
 		Return(nil)
 
-	worker := NewAcceptWorker(tok, lister, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop())
 	worker.acceptPending(context.Background())
 }
 
@@ -104,7 +105,7 @@ func TestAcceptWorker_SkipsOffersForUnregisteredParties(t *testing.T) {
 	tok.EXPECT().AcceptTransferInstruction(mock.Anything, "custodial-party::abc", "contract-custodial", testInstrumentAdmin).
 		Return(nil)
 
-	worker := NewAcceptWorker(tok, lister, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop())
 	worker.acceptPending(context.Background())
 }
 
@@ -127,7 +128,7 @@ func TestAcceptWorker_LogsAndContinuesOnAcceptError(t *testing.T) {
 	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, "contract-2", mock.Anything).
 		Return(nil)
 
-	worker := NewAcceptWorker(tok, lister, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop())
 	worker.acceptPending(context.Background())
 }
 
@@ -140,7 +141,7 @@ func TestAcceptWorker_LogsAndContinuesOnIndexerError(t *testing.T) {
 	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).
 		Return(nil, errors.New("indexer unavailable"))
 
-	worker := NewAcceptWorker(tok, lister, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop())
 	worker.acceptPending(context.Background())
 }
 
@@ -151,7 +152,7 @@ func TestAcceptWorker_StopsOnContextCancel(t *testing.T) {
 
 	lister.EXPECT().ListCustodialUsers(mock.Anything).Maybe().Return([]*user.User{}, nil)
 
-	worker := NewAcceptWorker(tok, lister, ic, 50*time.Millisecond, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, 50*time.Millisecond, NewNopMetrics(), zap.NewNop())
 
 	ctx, cancel := context.WithCancel(context.Background())
 	done := make(chan struct{})
@@ -188,7 +189,7 @@ func TestAcceptWorker_PaginatesAllOffers(t *testing.T) {
 	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, "contract-1", mock.Anything).Return(nil)
 	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, "contract-2", mock.Anything).Return(nil)
 
-	worker := NewAcceptWorker(tok, lister, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop())
 	worker.acceptPending(context.Background())
 }
 
@@ -199,6 +200,135 @@ func TestAcceptWorker_ListCustodialUsersError(t *testing.T) {
 
 	lister.EXPECT().ListCustodialUsers(mock.Anything).Return(nil, errors.New("db down"))
 
-	worker := NewAcceptWorker(tok, lister, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker := NewAcceptWorker(tok, lister, nil, ic, time.Hour, NewNopMetrics(), zap.NewNop())
 	require.NotPanics(t, func() { worker.acceptPending(context.Background()) })
 }
+
+func TestAcceptWorker_PolicyDecisions(t *testing.T) {
+	tok := mocks.NewToken(t)
+	lister := mocks.NewUserLister(t)
+	ic := indexermocks.NewClient(t)
+	pol := mocks.NewPolicy(t)
+
+	accepted, rejected, held := pendingOffer("contract-ok"), pendingOffer("contract-spam"), pendingOffer("contract-big")
+	addr := custodialUser().EVMAddress
+	pol.EXPECT().LockParty(mock.Anything, "custodial-party::abc").Return(func() {}, nil).Times(3)
+
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).Return(allOffersPage(accepted, rejected, held), nil)
+	pol.EXPECT().Evaluate(mock.Anything, addr, &accepted).
+		Return(&policy.Decision{Action: policy.ActionAccept, Reason: policy.ReasonAllowed}, nil)
+	pol.EXPECT().Evaluate(mock.Anything, addr, &rejected).
+		Return(&policy.Decision{Action: policy.ActionReject, Reason: policy.ConditionSenderDenied}, nil)
+	pol.EXPECT().Evaluate(mock.Anything, addr, &held).
+		Return(&policy.Decision{Action: policy.ActionHold, Reason: policy.ConditionOfferLimitExceeded}, nil)
+
+	tok.EXPECT().AcceptTransferInstruction(mock.Anything, "custodial-party::abc", "contract-ok", testInstrumentAdmin).
+		Return(nil)
+	tok.EXPECT().RejectTransferInstruction(mock.Anything, "custodial-party::abc", "contract-spam", testInstrumentAdmin).
+		Return(errors.New("registry down"))
+
+	recorded := map[string]*policy.AuditRecord{}
+	pol.EXPECT().Record(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, rec *policy.AuditRecord) error {
+		recorded[rec.ContractID] = rec
+		return nil
+	}).Times(3)
+
+	worker := NewAcceptWorker(tok, lister, pol, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker.acceptPending(context.Background())
+
+	require.Len(t, recorded, 3)
+	require.Equal(t, policy.ActionAccept, recorded["contract-ok"].Action)
+	require.Empty(t, recorded["contract-ok"].Error)
+	require.Equal(t, policy.ActionReject, recorded["contract-spam"].Action)
+	require.Equal(t, "registry down", recorded["contract-spam"].Error)
+	require.Equal(t, policy.ActionHold, recorded["contract-big"].Action)
+	require.Equal(t, addr, recorded["contract-big"].EVMAddress)
+}
+
+func TestAcceptWorker_HeldOfferRecordedOnce(t *testing.T) {
+	tok := mocks.NewToken(t) // a held offer is never accepted
+	lister := mocks.NewUserLister(t)
+	ic := indexermocks.NewClient(t)
+	pol := mocks.NewPolicy(t)
+
+	offer := pendingOffer("contract-big")
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).Return(allOffersPage(offer), nil).Times(2)
+	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).Return(allOffersPage(), nil).Once()
+	pol.EXPECT().LockParty(mock.Anything, mock.Anything).Return(func() {}, nil)
+	pol.EXPECT().Evaluate(mock.Anything, mock.Anything, mock.Anything).
+		Return(&policy.Decision{Action: policy.ActionHold, Reason: policy.ConditionDailyLimitExceeded}, nil)
+	pol.EXPECT().Record(mock.Anything, mock.Anything).Return(nil).Once()
+
+	worker := NewAcceptWorker(tok, lister, pol, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker.acceptPending(context.Background())
+	worker.acceptPending(context.Background())
+	require.Contains(t, worker.held, "contract-big")
+
+	// Once the offer is no longer pending it is forgotten.
+	worker.acceptPending(context.Background())
+	require.Empty(t, worker.held)
+}
+
+func TestAcceptWorker_PolicyErrorLeavesOfferPending(t *testing.T) {
+	tok := mocks.NewToken(t) // no accept on evaluation failure
+	lister := mocks.NewUserLister(t)
+	ic := indexermocks.NewClient(t)
+	pol := mocks.NewPolicy(t)
+
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).Return(allOffersPage(pendingOffer("contract-1")), nil)
+	pol.EXPECT().LockParty(mock.Anything, mock.Anything).Return(func() {}, nil)
+	pol.EXPECT().Evaluate(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	worker := NewAcceptWorker(tok, lister, pol, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker.acceptPending(context.Background())
+}
+
+func TestAcceptWorker_PartyLockHeldUntilRecorded(t *testing.T) {
+	tok := mocks.NewToken(t)
+	lister := mocks.NewUserLister(t)
+	ic := indexermocks.NewClient(t)
+	pol := mocks.NewPolicy(t)
+
+	var steps []string
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).Return(allOffersPage(pendingOffer("contract-1")), nil)
+	pol.EXPECT().LockParty(mock.Anything, "custodial-party::abc").RunAndReturn(func(context.Context, string) (func(), error) {
+		steps = append(steps, "lock")
+		return func() { steps = append(steps, "unlock") }, nil
+	})
+	pol.EXPECT().Evaluate(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string, *indexer.Transfer) (*policy.Decision, error) {
+			steps = append(steps, "evaluate")
+			return &policy.Decision{Action: policy.ActionAccept, Reason: policy.ReasonAllowed}, nil
+		})
+	tok.EXPECT().AcceptTransferInstruction(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string, string, string) error {
+			steps = append(steps, "accept")
+			return nil
+		})
+	pol.EXPECT().Record(mock.Anything, mock.Anything).RunAndReturn(func(context.Context, *policy.AuditRecord) error {
+		steps = append(steps, "record")
+		return nil
+	})
+
+	worker := NewAcceptWorker(tok, lister, pol, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker.acceptPending(context.Background())
+	require.Equal(t, []string{"lock", "evaluate", "accept", "record", "unlock"}, steps)
+}
+
+func TestAcceptWorker_PartyLockErrorLeavesOfferPending(t *testing.T) {
+	tok := mocks.NewToken(t) // no accept without the party lock
+	lister := mocks.NewUserLister(t)
+	ic := indexermocks.NewClient(t)
+	pol := mocks.NewPolicy(t) // nor an evaluation
+
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	ic.EXPECT().GetPendingTransfers(mock.Anything, mock.Anything).Return(allOffersPage(pendingOffer("contract-1")), nil)
+	pol.EXPECT().LockParty(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	worker := NewAcceptWorker(tok, lister, pol, ic, time.Hour, NewNopMetrics(), zap.NewNop())
+	worker.acceptPending(context.Background())
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package custodial
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	cantontkn "github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+)
+
+// CompactionWorker periodically merges the holdings of custodial parties whose
+// unlocked holding count for a token exceeds a threshold. Parties that receive
+// many small offers otherwise accumulate holdings until transfers need dozens of
+// inputs.
+//
+// Each cycle lists custodial users once and checks every configured token for
+// each of them. A merge consumes at most cantontkn.MaxMergeInputs holdings, so a
+// heavily fragmented party is compacted over several cycles.
+type CompactionWorker struct {
+	cantonToken  cantontkn.Token
+	userLister   UserLister
+	tokens       []string
+	threshold    int
+	pollInterval time.Duration
+	metrics      *CompactionMetrics
+	logger       *zap.Logger
+}
+
+// NewCompactionWorker creates a new CompactionWorker. Pass NewNopCompactionMetrics()
+// in tests where metric values aren't asserted.
+func NewCompactionWorker(
+	cantonToken cantontkn.Token,
+	userLister UserLister,
+	cfg *CompactionWorkerConfig,
+	metrics *CompactionMetrics,
+	logger *zap.Logger,
+) *CompactionWorker {
+	if metrics == nil {
+		metrics = NewNopCompactionMetrics()
+	}
+	return &CompactionWorker{
+		cantonToken:  cantonToken,
+		userLister:   userLister,
+		tokens:       cfg.Tokens,
+		threshold:    cfg.Threshold,
+		pollInterval: cfg.PollInterval,
+		metrics:      metrics,
+		logger:       logger,
+	}
+}
+
+// Run starts the compaction loop. It blocks until ctx is canceled.
+func (w *CompactionWorker) Run(ctx context.Context) error {
+	w.logger.Info("compaction worker started",
+		zap.Duration("poll_interval", w.pollInterval),
+		zap.Int("threshold", w.threshold),
+		zap.Strings("tokens", w.tokens),
+	)
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("compaction worker stopped")
+			return nil
+		case <-ticker.C:
+			w.compact(ctx)
+		}
+	}
+}
+
+func (w *CompactionWorker) compact(ctx context.Context) {
+	w.metrics.RunsTotal.Inc()
+
+	users, err := w.userLister.ListCustodialUsers(ctx)
+	if err != nil {
+		w.metrics.ErrorsTotal.WithLabelValues("list_users").Inc()
+		w.logger.Warn("compaction worker: failed to list custodial users", zap.Error(err))
+		return
+	}
+
+	for _, u := range users {
+		for _, symbol := range w.tokens {
+			if ctx.Err() != nil {
+				return
+			}
+			w.compactParty(ctx, u.CantonPartyID, symbol)
+		}
+	}
+}
+
+func (w *CompactionWorker) compactParty(ctx context.Context, partyID, symbol string) {
+	holdings, err := w.cantonToken.GetHoldings(ctx, partyID, symbol)
+	if err != nil {
+		w.metrics.ErrorsTotal.WithLabelValues("get_holdings").Inc()
+		w.logger.Warn("compaction worker: failed to get holdings",
+			zap.String("party_id", partyID),
+			zap.String("token", symbol),
+			zap.Error(err),
+		)
+		return
+	}
+
+	unlocked := 0
+	for _, h := range holdings {
+		if !h.Locked {
+			unlocked++
+		}
+	}
+	if unlocked <= w.threshold {
+		return
+	}
+
+	sel, err := w.cantonToken.MergeHoldings(ctx, uuid.NewString(), partyID, symbol)
+	if err != nil {
+		if errors.Is(err, cantontkn.ErrNothingToMerge) {
+			// Holdings changed between the count and the merge; try next cycle.
+			return
+		}
+		w.metrics.MergesTotal.WithLabelValues("error").Inc()
+		w.logger.Warn("compaction worker: failed to merge holdings",
+			zap.String("party_id", partyID),
+			zap.String("token", symbol),
+			zap.Int("holdings", unlocked),
+			zap.Error(err),
+		)
+		return
+	}
+	w.metrics.MergesTotal.WithLabelValues("success").Inc()
+	w.metrics.HoldingsMergedTotal.Add(float64(len(sel.Holdings)))
+	w.logger.Info("compaction worker: merged holdings",
+		zap.String("party_id", partyID),
+		zap.String("token", symbol),
+		zap.Int("inputs", len(sel.Holdings)),
+		zap.String("amount", sel.Total),
+	)
+}
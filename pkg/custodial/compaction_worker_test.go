@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package custodial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	cantontkn "github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/mocks"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+func fragmentedHoldings(n int) []*cantontkn.Holding {
+	out := make([]*cantontkn.Holding, 0, n)
+	for i := range n {
+		out = append(out, &cantontkn.Holding{ContractID: fmt.Sprintf("h-%d", i), Amount: "1", InstrumentID: "DEMO"})
+	}
+	return out
+}
+
+func newTestCompactionWorker(tok *mocks.Token, lister *mocks.UserLister) *CompactionWorker {
+	return NewCompactionWorker(tok, lister, &CompactionWorkerConfig{
+		Tokens:       []string{"DEMO"},
+		Threshold:    3,
+		PollInterval: time.Hour,
+	}, NewNopCompactionMetrics(), zap.NewNop())
+}
+
+func TestCompactionWorker_MergesAboveThreshold(t *testing.T) {
+	tok := mocks.NewToken(t)
+	lister := mocks.NewUserLister(t)
+
+	holdings := fragmentedHoldings(5)
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	tok.EXPECT().GetHoldings(mock.Anything, "custodial-party::abc", "DEMO").Return(holdings, nil)
+	tok.EXPECT().MergeHoldings(mock.Anything, mock.Anything, "custodial-party::abc", "DEMO").
+		Return(&cantontkn.HoldingSelection{Holdings: holdings, Total: "5"}, nil)
+
+	newTestCompactionWorker(tok, lister).compact(context.Background())
+}
+
+func TestCompactionWorker_SkipsAtOrBelowThreshold(t *testing.T) {
+	tok := mocks.NewToken(t)
+	lister := mocks.NewUserLister(t)
+
+	// Locked holdings do not count towards the threshold: 3 unlocked == threshold.
+	holdings := fragmentedHoldings(3)
+	holdings = append(holdings, &cantontkn.Holding{ContractID: "locked", Amount: "9", Locked: true})
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser()}, nil)
+	tok.EXPECT().GetHoldings(mock.Anything, "custodial-party::abc", "DEMO").Return(holdings, nil)
+
+	newTestCompactionWorker(tok, lister).compact(context.Background())
+}
+
+func TestCompactionWorker_ContinuesAfterFailures(t *testing.T) {
+	tok := mocks.NewToken(t)
+	lister := mocks.NewUserLister(t)
+
+	other := &user.User{CantonPartyID: "custodial-party::def", KeyMode: user.KeyModeCustodial}
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return([]*user.User{custodialUser(), other}, nil)
+	tok.EXPECT().GetHoldings(mock.Anything, "custodial-party::abc", "DEMO").Return(fragmentedHoldings(10), nil)
+	tok.EXPECT().MergeHoldings(mock.Anything, mock.Anything, "custodial-party::abc", "DEMO").
+		Return(nil, errors.New("participant down"))
+	tok.EXPECT().GetHoldings(mock.Anything, "custodial-party::def", "DEMO").Return(fragmentedHoldings(4), nil)
+	tok.EXPECT().MergeHoldings(mock.Anything, mock.Anything, "custodial-party::def", "DEMO").
+		Return(&cantontkn.HoldingSelection{Holdings: fragmentedHoldings(4), Total: "4"}, nil)
+
+	newTestCompactionWorker(tok, lister).compact(context.Background())
+}
+
+func TestCompactionWorker_ListUsersError(t *testing.T) {
+	tok := mocks.NewToken(t)
+	lister := mocks.NewUserLister(t)
+
+	lister.EXPECT().ListCustodialUsers(mock.Anything).Return(nil, errors.New("db down"))
+
+	newTestCompactionWorker(tok, lister).compact(context.Background())
+}
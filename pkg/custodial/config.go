@@ -11,4 +11,42 @@ type AcceptWorkerConfig struct {
 	// Required when the worker is enabled.
 	IndexerURL   string        `yaml:"indexer_url" validate:"required"`
 	PollInterval time.Duration `yaml:"poll_interval" default:"10s"`
+	// Stream switches the worker from polling the indexer every PollInterval to
+	// following TransferOffer creations on the Canton update stream. Omit to
+	// keep polling.
+	Stream *AcceptStreamConfig `yaml:"stream" default:"-"`
+}
+
+// AcceptStreamConfig configures the event-driven accept worker. Offers are read
+// from the participant's update stream across all parties it hosts, so the
+// api-server's ledger user needs CanReadAsAnyParty, like the indexer's.
+//
+// The saved stream cursor is a ledger offset of one participant; after a
+// failover to another participant the sweep, not the stream, is what catches
+// offers the resumed stream skips.
+type AcceptStreamConfig struct {
+	// SweepInterval is how often all pending offers are still read from the
+	// indexer, as a safety net for offers the stream path missed or gave up on.
+	SweepInterval time.Duration `yaml:"sweep_interval" default:"10m"`
+	// MaxConcurrentAccepts caps the offers evaluated and accepted at once.
+	MaxConcurrentAccepts int `yaml:"max_concurrent_accepts" default:"8" validate:"min=1"`
+	// RetryBaseDelay is the wait before retrying a failed accept; it doubles on
+	// every further failure up to RetryMaxDelay.
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay" default:"5s"`
+	RetryMaxDelay  time.Duration `yaml:"retry_max_delay" default:"5m"`
+	// MaxRetries is the number of retries after which an offer is left to the
+	// next sweep.
+	MaxRetries int `yaml:"max_retries" default:"10" validate:"min=0"`
+}
+
+// CompactionWorkerConfig configures the background worker that merges the
+// holdings of custodial parties once they become fragmented. Omitting this block
+// disables the worker.
+type CompactionWorkerConfig struct {
+	// Tokens lists the token symbols to compact. Required when the worker is enabled.
+	Tokens []string `yaml:"tokens" validate:"required,min=1"`
+	// Threshold is the unlocked holding count above which a party's holdings of a
+	// token are merged.
+	Threshold    int           `yaml:"threshold" default:"20" validate:"min=2"`
+	PollInterval time.Duration `yaml:"poll_interval" default:"10m"`
 }
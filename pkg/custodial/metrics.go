@@ -11,17 +11,22 @@ import (
 
 // Metrics holds Prometheus collectors for the AcceptWorker.
 //
-// The worker's cycle ("acceptPending") has three observable phases:
+// The worker's cycle ("acceptPending", the sweep in stream mode) has three
+// observable phases:
 //
 //  1. Listing custodial users (one DB call to UserLister).
 //  2. Paginating pending TransferOffers from the indexer (N indexer calls).
-//  3. Accepting each custodial-owned offer (M Canton RPC calls).
+//  3. Evaluating each custodial-owned offer against the accept policy and
+//     accepting or rejecting it (M Canton RPC calls).
 //
 // Phase-1 and phase-2 failures abort the cycle and are counted in
 // ErrorsTotal with the appropriate phase label. Phase-3 (per-offer) failures
 // are *not* fatal — the worker logs and continues to the next offer — so they
 // live in their own OffersAcceptedTotal{result="error"} series rather than
 // the cycle-level ErrorsTotal.
+//
+// In stream mode offers also arrive from the ledger between sweeps; the Stream*
+// and Retry* collectors cover that path.
 type Metrics struct {
 	// RunsTotal counts every invocation of acceptPending — successful, empty,
 	// and errored alike. Pair with rate() to check the worker's tick cadence.
@@ -34,6 +39,8 @@ type Metrics struct {
 	// ErrorsTotal counts cycles that aborted early.
 	//   phase=list_users   – ListCustodialUsers failed
 	//   phase=fetch_offers – GetPendingTransfers failed on some page
+	//   phase=load_cursor  – the stream start offset could not be read (retried)
+	//   phase=save_cursor  – a stream batch's offset could not be saved
 	// Per-offer accept failures are *not* counted here (see OffersAccepted).
 	ErrorsTotal *prometheus.CounterVec
 
@@ -62,10 +69,36 @@ type Metrics struct {
 	// separately from cycle orchestration cost.
 	OfferAcceptDuration prometheus.Histogram
 
+	// PolicyDecisionsTotal counts accept-policy evaluations of custodial-owned
+	// offers. action ∈ "accept" / "reject" / "hold" / "error"; a held offer is
+	// counted on every cycle it is re-evaluated. Not incremented when no policy
+	// is configured.
+	PolicyDecisionsTotal *prometheus.CounterVec
+
+	// OffersRejectedTotal counts per-offer reject attempts for offers the
+	// policy decided to reject. result ∈ "success" / "error".
+	OffersRejectedTotal *prometheus.CounterVec
+
 	// LastSuccessfulRunTimestamp is the UNIX timestamp of the most recent
 	// cycle that completed without an abort-on-error. Powers a staleness
-	// alert: if (time() - this) >> pollInterval, the worker is stuck.
+	// alert: if (time() - this) >> pollInterval (sweepInterval in stream
+	// mode), the worker is stuck.
 	LastSuccessfulRunTimestamp prometheus.Gauge
+
+	// StreamOffersTotal counts custodial-owned offers received from the
+	// ledger stream.
+	StreamOffersTotal prometheus.Counter
+
+	// StreamOffset is the last ledger offset saved to the stream cursor.
+	// Compare with the participant's ledger end to see the stream lag.
+	StreamOffset prometheus.Gauge
+
+	// RetryQueue is the number of failed offers waiting for a retry.
+	RetryQueue prometheus.Gauge
+
+	// RetriesExhaustedTotal counts offers dropped from the retry queue after
+	// MaxRetries failures and left to the sweep.
+	RetriesExhaustedTotal prometheus.Counter
 }
 
 // NewMetrics registers AcceptWorker metrics against the given registerer.
@@ -90,7 +123,7 @@ func NewMetrics(reg sharedmetrics.NamespacedRegisterer) *Metrics {
 		ErrorsTotal: f.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns, Subsystem: sub,
 			Name: "errors_total",
-			Help: "AcceptPending cycles that aborted early, labeled by phase (list_users, fetch_offers)",
+			Help: "AcceptPending cycles that aborted early, labeled by phase (list_users, fetch_offers, load_cursor, save_cursor)",
 		}, []string{"phase"}),
 
 		CustodialUsers: f.NewGauge(prometheus.GaugeOpts{
@@ -124,11 +157,47 @@ func NewMetrics(reg sharedmetrics.NamespacedRegisterer) *Metrics {
 			Buckets: sharedmetrics.DefaultDurationBuckets,
 		}),
 
+		PolicyDecisionsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "policy_decisions_total",
+			Help: "Accept-policy evaluations of custodial-owned offers, labeled by action (accept, reject, hold, error)",
+		}, []string{"action"}),
+
+		OffersRejectedTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "offers_rejected_total",
+			Help: "Per-offer RejectTransferInstruction outcomes for policy-rejected offers",
+		}, []string{"result"}),
+
 		LastSuccessfulRunTimestamp: f.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns, Subsystem: sub,
 			Name: "last_successful_run_timestamp",
 			Help: "UNIX timestamp of the most recent acceptPending cycle that completed without an abort-on-error",
 		}),
+
+		StreamOffersTotal: f.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "stream_offers_total",
+			Help: "Custodial-owned TransferOffers received from the ledger stream",
+		}),
+
+		StreamOffset: f.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "stream_offset",
+			Help: "Last ledger offset saved to the accept worker's stream cursor",
+		}),
+
+		RetryQueue: f.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "retry_queue",
+			Help: "Failed offers waiting for a retry",
+		}),
+
+		RetriesExhaustedTotal: f.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "retries_exhausted_total",
+			Help: "Offers dropped from the retry queue after max_retries failures and left to the sweep",
+		}),
 	}
 }
 
@@ -137,3 +206,58 @@ func NewMetrics(reg sharedmetrics.NamespacedRegisterer) *Metrics {
 func NewNopMetrics() *Metrics {
 	return NewMetrics(sharedmetrics.WithNamespace(prometheus.NewRegistry(), "nop"))
 }
+
+// CompactionMetrics holds Prometheus collectors for the CompactionWorker.
+type CompactionMetrics struct {
+	// RunsTotal counts every compaction cycle.
+	RunsTotal prometheus.Counter
+
+	// ErrorsTotal counts failed lookups.
+	//   phase=list_users   – ListCustodialUsers failed (cycle aborted)
+	//   phase=get_holdings – GetHoldings failed for one party/token (skipped)
+	ErrorsTotal *prometheus.CounterVec
+
+	// MergesTotal counts merge attempts. result ∈ "success" / "error".
+	MergesTotal *prometheus.CounterVec
+
+	// HoldingsMergedTotal counts input holdings consumed by successful merges.
+	HoldingsMergedTotal prometheus.Counter
+}
+
+// NewCompactionMetrics registers CompactionWorker metrics against the given registerer.
+func NewCompactionMetrics(reg sharedmetrics.NamespacedRegisterer) *CompactionMetrics {
+	f := promauto.With(reg)
+	ns := reg.Namespace()
+	sub := "custodial_compaction_worker"
+	return &CompactionMetrics{
+		RunsTotal: f.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "runs_total",
+			Help: "Total compaction cycle invocations (regardless of outcome)",
+		}),
+
+		ErrorsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "errors_total",
+			Help: "Compaction lookup failures, labeled by phase (list_users, get_holdings)",
+		}, []string{"phase"}),
+
+		MergesTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "merges_total",
+			Help: "Per-party MergeHoldings outcomes",
+		}, []string{"result"}),
+
+		HoldingsMergedTotal: f.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "holdings_merged_total",
+			Help: "Input holdings consumed by successful merges",
+		}),
+	}
+}
+
+// NewNopCompactionMetrics returns a CompactionMetrics instance backed by a
+// throwaway registry. Use in tests where metric values are not asserted.
+func NewNopCompactionMetrics() *CompactionMetrics {
+	return NewCompactionMetrics(sharedmetrics.WithNamespace(prometheus.NewRegistry(), "nop"))
+}
@@ -4,10 +4,12 @@ package mocks
 
 import (
 	context "context"
+	iter "iter"
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 
+	ledger "github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	token "github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
 )
 
@@ -73,6 +75,55 @@ func (_c *Token_AcceptTransferInstruction_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// AllHoldings provides a mock function with given fields: ctx, filter
+func (_m *Token) AllHoldings(ctx context.Context, filter token.HoldingFilter) iter.Seq2[*token.Holding, error] {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllHoldings")
+	}
+
+	var r0 iter.Seq2[*token.Holding, error]
+	if rf, ok := ret.Get(0).(func(context.Context, token.HoldingFilter) iter.Seq2[*token.Holding, error]); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[*token.Holding, error])
+		}
+	}
+
+	return r0
+}
+
+// Token_AllHoldings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllHoldings'
+type Token_AllHoldings_Call struct {
+	*mock.Call
+}
+
+// AllHoldings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter token.HoldingFilter
+func (_e *Token_Expecter) AllHoldings(ctx interface{}, filter interface{}) *Token_AllHoldings_Call {
+	return &Token_AllHoldings_Call{Call: _e.mock.On("AllHoldings", ctx, filter)}
+}
+
+func (_c *Token_AllHoldings_Call) Run(run func(ctx context.Context, filter token.HoldingFilter)) *Token_AllHoldings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(token.HoldingFilter))
+	})
+	return _c
+}
+
+func (_c *Token_AllHoldings_Call) Return(_a0 iter.Seq2[*token.Holding, error]) *Token_AllHoldings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Token_AllHoldings_Call) RunAndReturn(run func(context.Context, token.HoldingFilter) iter.Seq2[*token.Holding, error]) *Token_AllHoldings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Burn provides a mock function with given fields: ctx, req
 func (_m *Token) Burn(ctx context.Context, req *token.BurnRequest) error {
 	ret := _m.Called(ctx, req)
@@ -750,6 +801,67 @@ func (_c *Token_GetTransferFactory_Call) RunAndReturn(run func(context.Context)
 	return _c
 }
 
+// MergeHoldings provides a mock function with given fields: ctx, commandID, partyID, tokenSymbol
+func (_m *Token) MergeHoldings(ctx context.Context, commandID string, partyID string, tokenSymbol string) (*token.HoldingSelection, error) {
+	ret := _m.Called(ctx, commandID, partyID, tokenSymbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeHoldings")
+	}
+
+	var r0 *token.HoldingSelection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*token.HoldingSelection, error)); ok {
+		return rf(ctx, commandID, partyID, tokenSymbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *token.HoldingSelection); ok {
+		r0 = rf(ctx, commandID, partyID, tokenSymbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.HoldingSelection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, commandID, partyID, tokenSymbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Token_MergeHoldings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeHoldings'
+type Token_MergeHoldings_Call struct {
+	*mock.Call
+}
+
+// MergeHoldings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commandID string
+//   - partyID string
+//   - tokenSymbol string
+func (_e *Token_Expecter) MergeHoldings(ctx interface{}, commandID interface{}, partyID interface{}, tokenSymbol interface{}) *Token_MergeHoldings_Call {
+	return &Token_MergeHoldings_Call{Call: _e.mock.On("MergeHoldings", ctx, commandID, partyID, tokenSymbol)}
+}
+
+func (_c *Token_MergeHoldings_Call) Run(run func(ctx context.Context, commandID string, partyID string, tokenSymbol string)) *Token_MergeHoldings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Token_MergeHoldings_Call) Return(_a0 *token.HoldingSelection, _a1 error) *Token_MergeHoldings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Token_MergeHoldings_Call) RunAndReturn(run func(context.Context, string, string, string) (*token.HoldingSelection, error)) *Token_MergeHoldings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Mint provides a mock function with given fields: ctx, req
 func (_m *Token) Mint(ctx context.Context, req *token.MintRequest) (string, error) {
 	ret := _m.Called(ctx, req)
@@ -868,6 +980,128 @@ func (_c *Token_PrepareAcceptTransfer_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// PrepareMergeHoldings provides a mock function with given fields: ctx, partyID, tokenSymbol
+func (_m *Token) PrepareMergeHoldings(ctx context.Context, partyID string, tokenSymbol string) (*token.PreparedTransfer, error) {
+	ret := _m.Called(ctx, partyID, tokenSymbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareMergeHoldings")
+	}
+
+	var r0 *token.PreparedTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*token.PreparedTransfer, error)); ok {
+		return rf(ctx, partyID, tokenSymbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *token.PreparedTransfer); ok {
+		r0 = rf(ctx, partyID, tokenSymbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.PreparedTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, partyID, tokenSymbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Token_PrepareMergeHoldings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareMergeHoldings'
+type Token_PrepareMergeHoldings_Call struct {
+	*mock.Call
+}
+
+// PrepareMergeHoldings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partyID string
+//   - tokenSymbol string
+func (_e *Token_Expecter) PrepareMergeHoldings(ctx interface{}, partyID interface{}, tokenSymbol interface{}) *Token_PrepareMergeHoldings_Call {
+	return &Token_PrepareMergeHoldings_Call{Call: _e.mock.On("PrepareMergeHoldings", ctx, partyID, tokenSymbol)}
+}
+
+func (_c *Token_PrepareMergeHoldings_Call) Run(run func(ctx context.Context, partyID string, tokenSymbol string)) *Token_PrepareMergeHoldings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Token_PrepareMergeHoldings_Call) Return(_a0 *token.PreparedTransfer, _a1 error) *Token_PrepareMergeHoldings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Token_PrepareMergeHoldings_Call) RunAndReturn(run func(context.Context, string, string) (*token.PreparedTransfer, error)) *Token_PrepareMergeHoldings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrepareSplitHolding provides a mock function with given fields: ctx, partyID, tokenSymbol, holdingCID, amount
+func (_m *Token) PrepareSplitHolding(ctx context.Context, partyID string, tokenSymbol string, holdingCID string, amount string) (*token.PreparedTransfer, error) {
+	ret := _m.Called(ctx, partyID, tokenSymbol, holdingCID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareSplitHolding")
+	}
+
+	var r0 *token.PreparedTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*token.PreparedTransfer, error)); ok {
+		return rf(ctx, partyID, tokenSymbol, holdingCID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *token.PreparedTransfer); ok {
+		r0 = rf(ctx, partyID, tokenSymbol, holdingCID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.PreparedTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, partyID, tokenSymbol, holdingCID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Token_PrepareSplitHolding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareSplitHolding'
+type Token_PrepareSplitHolding_Call struct {
+	*mock.Call
+}
+
+// PrepareSplitHolding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partyID string
+//   - tokenSymbol string
+//   - holdingCID string
+//   - amount string
+func (_e *Token_Expecter) PrepareSplitHolding(ctx interface{}, partyID interface{}, tokenSymbol interface{}, holdingCID interface{}, amount interface{}) *Token_PrepareSplitHolding_Call {
+	return &Token_PrepareSplitHolding_Call{Call: _e.mock.On("PrepareSplitHolding", ctx, partyID, tokenSymbol, holdingCID, amount)}
+}
+
+func (_c *Token_PrepareSplitHolding_Call) Run(run func(ctx context.Context, partyID string, tokenSymbol string, holdingCID string, amount string)) *Token_PrepareSplitHolding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Token_PrepareSplitHolding_Call) Return(_a0 *token.PreparedTransfer, _a1 error) *Token_PrepareSplitHolding_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Token_PrepareSplitHolding_Call) RunAndReturn(run func(context.Context, string, string, string, string) (*token.PreparedTransfer, error)) *Token_PrepareSplitHolding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PrepareTransfer provides a mock function with given fields: ctx, req
 func (_m *Token) PrepareTransfer(ctx context.Context, req *token.PrepareTransferRequest) (*token.PreparedTransfer, error) {
 	ret := _m.Called(ctx, req)
@@ -988,6 +1222,175 @@ func (_c *Token_PrepareWithdrawTransfer_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// RejectTransferInstruction provides a mock function with given fields: ctx, partyID, instructionCID, instrumentAdmin
+func (_m *Token) RejectTransferInstruction(ctx context.Context, partyID string, instructionCID string, instrumentAdmin string) error {
+	ret := _m.Called(ctx, partyID, instructionCID, instrumentAdmin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectTransferInstruction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, partyID, instructionCID, instrumentAdmin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Token_RejectTransferInstruction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectTransferInstruction'
+type Token_RejectTransferInstruction_Call struct {
+	*mock.Call
+}
+
+// RejectTransferInstruction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partyID string
+//   - instructionCID string
+//   - instrumentAdmin string
+func (_e *Token_Expecter) RejectTransferInstruction(ctx interface{}, partyID interface{}, instructionCID interface{}, instrumentAdmin interface{}) *Token_RejectTransferInstruction_Call {
+	return &Token_RejectTransferInstruction_Call{Call: _e.mock.On("RejectTransferInstruction", ctx, partyID, instructionCID, instrumentAdmin)}
+}
+
+func (_c *Token_RejectTransferInstruction_Call) Run(run func(ctx context.Context, partyID string, instructionCID string, instrumentAdmin string)) *Token_RejectTransferInstruction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Token_RejectTransferInstruction_Call) Return(_a0 error) *Token_RejectTransferInstruction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Token_RejectTransferInstruction_Call) RunAndReturn(run func(context.Context, string, string, string) error) *Token_RejectTransferInstruction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveOutcome provides a mock function with given fields: ctx, commandID
+func (_m *Token) ResolveOutcome(ctx context.Context, commandID string) (*ledger.Outcome, error) {
+	ret := _m.Called(ctx, commandID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveOutcome")
+	}
+
+	var r0 *ledger.Outcome
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ledger.Outcome, error)); ok {
+		return rf(ctx, commandID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ledger.Outcome); ok {
+		r0 = rf(ctx, commandID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ledger.Outcome)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, commandID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Token_ResolveOutcome_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveOutcome'
+type Token_ResolveOutcome_Call struct {
+	*mock.Call
+}
+
+// ResolveOutcome is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commandID string
+func (_e *Token_Expecter) ResolveOutcome(ctx interface{}, commandID interface{}) *Token_ResolveOutcome_Call {
+	return &Token_ResolveOutcome_Call{Call: _e.mock.On("ResolveOutcome", ctx, commandID)}
+}
+
+func (_c *Token_ResolveOutcome_Call) Run(run func(ctx context.Context, commandID string)) *Token_ResolveOutcome_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Token_ResolveOutcome_Call) Return(_a0 *ledger.Outcome, _a1 error) *Token_ResolveOutcome_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Token_ResolveOutcome_Call) RunAndReturn(run func(context.Context, string) (*ledger.Outcome, error)) *Token_ResolveOutcome_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SplitHolding provides a mock function with given fields: ctx, commandID, partyID, tokenSymbol, holdingCID, amount
+func (_m *Token) SplitHolding(ctx context.Context, commandID string, partyID string, tokenSymbol string, holdingCID string, amount string) (string, error) {
+	ret := _m.Called(ctx, commandID, partyID, tokenSymbol, holdingCID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitHolding")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (string, error)); ok {
+		return rf(ctx, commandID, partyID, tokenSymbol, holdingCID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) string); ok {
+		r0 = rf(ctx, commandID, partyID, tokenSymbol, holdingCID, amount)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, commandID, partyID, tokenSymbol, holdingCID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Token_SplitHolding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitHolding'
+type Token_SplitHolding_Call struct {
+	*mock.Call
+}
+
+// SplitHolding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commandID string
+//   - partyID string
+//   - tokenSymbol string
+//   - holdingCID string
+//   - amount string
+func (_e *Token_Expecter) SplitHolding(ctx interface{}, commandID interface{}, partyID interface{}, tokenSymbol interface{}, holdingCID interface{}, amount interface{}) *Token_SplitHolding_Call {
+	return &Token_SplitHolding_Call{Call: _e.mock.On("SplitHolding", ctx, commandID, partyID, tokenSymbol, holdingCID, amount)}
+}
+
+func (_c *Token_SplitHolding_Call) Run(run func(ctx context.Context, commandID string, partyID string, tokenSymbol string, holdingCID string, amount string)) *Token_SplitHolding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *Token_SplitHolding_Call) Return(_a0 string, _a1 error) *Token_SplitHolding_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Token_SplitHolding_Call) RunAndReturn(run func(context.Context, string, string, string, string, string) (string, error)) *Token_SplitHolding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransferByFingerprint provides a mock function with given fields: ctx, idempotencyKey, fromFingerprint, toFingerprint, amount, tokenSymbol, validity
 func (_m *Token) TransferByFingerprint(ctx context.Context, idempotencyKey string, fromFingerprint string, toFingerprint string, amount string, tokenSymbol string, validity time.Duration) error {
 	ret := _m.Called(ctx, idempotencyKey, fromFingerprint, toFingerprint, amount, tokenSymbol, validity)
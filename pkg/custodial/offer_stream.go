@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package custodial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/engine"
+
+	"go.uber.org/zap"
+)
+
+const (
+	transferOfferModule = "Utility.Registry.App.V0.Model.Transfer"
+	transferOfferEntity = "TransferOffer"
+)
+
+// LedgerOfferStream follows TransferOffer creations and archives on the Canton
+// update stream of the participant, decoded the same way the indexer decodes
+// them.
+type LedgerOfferStream struct {
+	ledger     ledger.Ledger
+	stream     *streaming.Stream[*indexer.Transfer]
+	templateID streaming.TemplateID
+}
+
+// NewLedgerOfferStream creates an OfferStream over l for the TransferOffer
+// template of the Utility Registry app package packageID.
+func NewLedgerOfferStream(l ledger.Ledger, packageID string, logger *zap.Logger) (*LedgerOfferStream, error) {
+	if packageID == "" {
+		return nil, errors.New("utility registry app package ID is required")
+	}
+	client, err := streaming.New(l, streaming.WithLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("create streaming client: %w", err)
+	}
+	return &LedgerOfferStream{
+		ledger: l,
+		stream: streaming.NewStream(client, engine.NewOfferDecoder(nil, logger)),
+		templateID: streaming.TemplateID{
+			PackageID:  packageID,
+			ModuleName: transferOfferModule,
+			EntityName: transferOfferEntity,
+		},
+	}, nil
+}
+
+// LedgerEnd returns the participant's current ledger end.
+func (s *LedgerOfferStream) LedgerEnd(ctx context.Context) (int64, error) {
+	return s.ledger.GetLedgerEnd(ctx)
+}
+
+// Subscribe streams offer batches committed after offset after, reconnecting
+// from the last delivered offset on transient errors.
+func (s *LedgerOfferStream) Subscribe(ctx context.Context, after int64) <-chan *streaming.Batch[*indexer.Transfer] {
+	lastOffset := after
+	return s.stream.Subscribe(ctx, streaming.SubscribeRequest{
+		FromOffset:  after,
+		TemplateIDs: []streaming.TemplateID{s.templateID},
+	}, &lastOffset)
+}
@@ -0,0 +1,370 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const (
+	maxRequestBodyBytes = 1 << 20 // 1MB
+	messageMaxAge       = 5 * time.Minute
+
+	// acceptPolicyAction starts the message signed for the user endpoints:
+	// "accept-policy:<address>:<method>:<body sha256>:<nonce>:<unix seconds>".
+	acceptPolicyAction = "accept-policy"
+)
+
+// NonceStore consumes the single-use nonces handed out by GET /auth/nonce.
+// Satisfied by nonceprovider.InMemory and nonceprovider.Postgres.
+type NonceStore interface {
+	// Consume returns true exactly once for a live, previously-issued nonce.
+	Consume(ctx context.Context, nonce string) (bool, error)
+}
+
+// ruleRequest is the body of the admin and user PUT endpoints. Scope and source
+// come from the route and the caller, never from the body.
+type ruleRequest struct {
+	DeniedSenders      []string                `json:"denied_senders"`
+	AllowedSenders     []string                `json:"allowed_senders"`
+	AllowedInstruments []indexer.InstrumentKey `json:"allowed_instruments"`
+	MaxAmountPerOffer  string                  `json:"max_amount_per_offer"`
+	MaxAmountPerDay    string                  `json:"max_amount_per_day"`
+	RejectDenied       bool                    `json:"reject_denied"`
+}
+
+func (r *ruleRequest) rule() *Rule {
+	return &Rule{
+		DeniedSenders:      r.DeniedSenders,
+		AllowedSenders:     r.AllowedSenders,
+		AllowedInstruments: r.AllowedInstruments,
+		MaxAmountPerOffer:  r.MaxAmountPerOffer,
+		MaxAmountPerDay:    r.MaxAmountPerDay,
+		RejectDenied:       r.RejectDenied,
+	}
+}
+
+// RulesResponse lists the rules of one scope.
+type RulesResponse struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// AuditResponse is one page of audit records, newest first. Pass NextBeforeID
+// as before_id to fetch the next page; it is 0 on the last page.
+type AuditResponse struct {
+	Items        []*AuditRecord `json:"items"`
+	NextBeforeID int64          `json:"next_before_id,omitempty"`
+}
+
+type httpHandler struct {
+	mgr       Manager
+	settings  Settings
+	nonces    NonceStore
+	tokenAuth bool
+	logger    *zap.Logger
+}
+
+// RegisterAdminRoutes mounts the privileged policy endpoints under /admin on r,
+// gated by a static bearer token. scope is "global" or a user's EVM address.
+func RegisterAdminRoutes(r chi.Router, mgr Manager, token string, logger *zap.Logger) {
+	h := &httpHandler{mgr: mgr, logger: logger}
+
+	r.Group(func(ar chi.Router) {
+		ar.Use(apphttp.BearerAuthMiddleware(token))
+		ar.Get("/admin/accept-policies/{scope}", apphttp.HandleError(h.getRules))
+		ar.Put("/admin/accept-policies/{scope}", apphttp.HandleError(h.putRule))
+		ar.Delete("/admin/accept-policies/{scope}", apphttp.HandleError(h.deleteRule))
+		ar.Get("/admin/accept-audit", apphttp.HandleError(h.listAudit))
+	})
+
+	logger.Info("Admin API enabled", zap.String("path", "/admin/accept-policies"))
+}
+
+// RegisterUserRoutes mounts the user-settings endpoints. readAuth, when non-nil
+// (typically jwt.RequireAuth), guards them and the user token names the caller.
+// Otherwise each request carries an EIP-191 signature (X-Signature) over
+// X-Message, "accept-policy:<address>:<method>:<body sha256>:<nonce>:<unix seconds>",
+// where the body hash is the hex SHA-256 of the request body (of the empty
+// string for GET and DELETE). The nonce is consumed, so a signature is good for
+// one accept-policy request, with that method and body, only.
+func RegisterUserRoutes(
+	r chi.Router, settings Settings, nonces NonceStore, readAuth func(http.Handler) http.Handler, logger *zap.Logger,
+) {
+	h := &httpHandler{settings: settings, nonces: nonces, tokenAuth: readAuth != nil, logger: logger}
+
+	r.Group(func(r chi.Router) {
+		if readAuth != nil {
+			r.Use(readAuth)
+		}
+		r.Get("/api/v2/custodial/accept-policy", apphttp.HandleError(h.getUserRule))
+		r.Put("/api/v2/custodial/accept-policy", apphttp.HandleError(h.putUserRule))
+		r.Delete("/api/v2/custodial/accept-policy", apphttp.HandleError(h.deleteUserRule))
+		r.Get("/api/v2/custodial/accept-policy/audit", apphttp.HandleError(h.listUserAudit))
+	})
+}
+
+// getRules handles GET /admin/accept-policies/{scope}.
+func (h *httpHandler) getRules(w http.ResponseWriter, r *http.Request) error {
+	rules, err := h.mgr.GetRules(r.Context(), chi.URLParam(r, "scope"))
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, RulesResponse{Rules: rules})
+	return nil
+}
+
+// putRule handles PUT /admin/accept-policies/{scope} — replaces the scope's
+// admin rule.
+func (h *httpHandler) putRule(w http.ResponseWriter, r *http.Request) error {
+	var req ruleRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	rule := req.rule()
+	rule.Scope = chi.URLParam(r, "scope")
+
+	saved, err := h.mgr.PutRule(r.Context(), rule)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, saved)
+	return nil
+}
+
+// deleteRule handles DELETE /admin/accept-policies/{scope}?source= — source
+// defaults to admin; source=user removes the user's own rule.
+func (h *httpHandler) deleteRule(w http.ResponseWriter, r *http.Request) error {
+	source := SourceAdmin
+	if s := r.URL.Query().Get("source"); s != "" {
+		source = Source(s)
+	}
+	if err := h.mgr.DeleteRule(r.Context(), chi.URLParam(r, "scope"), source); err != nil {
+		return err
+	}
+	h.writeJSON(w, map[string]string{"status": "deleted"})
+	return nil
+}
+
+// listAudit handles GET /admin/accept-audit?evm_address=&contract_id=&before_id=&limit=.
+func (h *httpHandler) listAudit(w http.ResponseWriter, r *http.Request) error {
+	beforeID, limit, err := parseAuditPage(r)
+	if err != nil {
+		return err
+	}
+	q := r.URL.Query()
+	recs, err := h.mgr.ListAudit(r.Context(), AuditFilter{
+		EVMAddress: q.Get("evm_address"),
+		ContractID: q.Get("contract_id"),
+		BeforeID:   beforeID,
+		Limit:      limit,
+	})
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, auditPage(recs, limit))
+	return nil
+}
+
+// getUserRule handles GET /api/v2/custodial/accept-policy.
+func (h *httpHandler) getUserRule(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := h.caller(r)
+	if err != nil {
+		return err
+	}
+	rule, err := h.settings.GetUserRule(r.Context(), evmAddr)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, rule)
+	return nil
+}
+
+// putUserRule handles PUT /api/v2/custodial/accept-policy.
+func (h *httpHandler) putUserRule(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := h.caller(r)
+	if err != nil {
+		return err
+	}
+	var req ruleRequest
+	if jsonErr := readJSON(r, &req); jsonErr != nil {
+		return jsonErr
+	}
+	saved, err := h.settings.PutUserRule(r.Context(), evmAddr, req.rule())
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, saved)
+	return nil
+}
+
+// deleteUserRule handles DELETE /api/v2/custodial/accept-policy.
+func (h *httpHandler) deleteUserRule(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := h.caller(r)
+	if err != nil {
+		return err
+	}
+	if err := h.settings.DeleteUserRule(r.Context(), evmAddr); err != nil {
+		return err
+	}
+	h.writeJSON(w, map[string]string{"status": "deleted"})
+	return nil
+}
+
+// listUserAudit handles GET /api/v2/custodial/accept-policy/audit?before_id=&limit=.
+func (h *httpHandler) listUserAudit(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := h.caller(r)
+	if err != nil {
+		return err
+	}
+	beforeID, limit, err := parseAuditPage(r)
+	if err != nil {
+		return err
+	}
+	recs, err := h.settings.ListUserAudit(r.Context(), evmAddr, beforeID, limit)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, auditPage(recs, limit))
+	return nil
+}
+
+// parseAuditPage reads ?before_id=&limit=, defaulting limit to DefaultAuditLimit
+// and rejecting values outside [1, MaxAuditLimit].
+func parseAuditPage(r *http.Request) (beforeID int64, limit int, err error) {
+	limit = DefaultAuditLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		v, parseErr := strconv.Atoi(s)
+		if parseErr != nil || v < 1 || v > MaxAuditLimit {
+			return 0, 0, apperrors.BadRequestError(nil, "limit must be an integer between 1 and 200")
+		}
+		limit = v
+	}
+	if s := r.URL.Query().Get("before_id"); s != "" {
+		v, parseErr := strconv.ParseInt(s, 10, 64)
+		if parseErr != nil || v < 1 {
+			return 0, 0, apperrors.BadRequestError(nil, "before_id must be a positive integer")
+		}
+		beforeID = v
+	}
+	return beforeID, limit, nil
+}
+
+// auditPage wraps recs, setting the next cursor when the page is full.
+func auditPage(recs []*AuditRecord, limit int) AuditResponse {
+	page := AuditResponse{Items: recs}
+	if len(recs) == limit && limit > 0 {
+		page.NextBeforeID = recs[len(recs)-1].ID
+	}
+	return page
+}
+
+// caller authenticates a user-settings request and returns the caller's EIP-55
+// address: the user token's with JWT auth on, else the signer of X-Message,
+// which must be an accept-policy message within messageMaxAge of the server
+// time. Binding the action keeps a signature made for another endpoint from
+// being replayed here; binding the method and body keeps it from being attached
+// to a different rule or a DELETE; consuming the nonce keeps it from being
+// replayed at all.
+func (h *httpHandler) caller(r *http.Request) (string, error) {
+	if h.tokenAuth {
+		addr, _ := auth.EVMAddressFromContext(r.Context())
+		if addr == "" {
+			return "", apperrors.ForbiddenError(nil, "a user token is required")
+		}
+		return auth.NormalizeAddress(addr), nil
+	}
+
+	sig := r.Header.Get("X-Signature")
+	msg := r.Header.Get("X-Message")
+	if sig == "" || msg == "" {
+		return "", apperrors.UnAuthorizedError(nil, "authentication required")
+	}
+
+	recovered, err := auth.VerifyEIP191Signature(msg, sig)
+	if err != nil {
+		return "", apperrors.UnAuthorizedError(err, "invalid signature")
+	}
+	evmAddr := auth.NormalizeAddress(recovered.Hex())
+
+	fields, err := auth.ParseActionMessage(msg, acceptPolicyAction, evmAddr, 3, messageMaxAge)
+	if err != nil {
+		return "", apperrors.UnAuthorizedError(err,
+			"message must be of form accept-policy:<address>:<method>:<body sha256>:<nonce>:<timestamp>")
+	}
+	if !strings.EqualFold(fields[0], r.Method) {
+		return "", apperrors.UnAuthorizedError(nil, "message was signed for another method")
+	}
+	bodyHash, err := hashBody(r)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(fields[1], bodyHash) {
+		return "", apperrors.UnAuthorizedError(nil, "message was signed for another request body")
+	}
+	// Consumed only once the signature checks out, so a forged request cannot
+	// burn the user's outstanding nonce.
+	live, err := h.nonces.Consume(r.Context(), fields[2])
+	if err != nil {
+		return "", fmt.Errorf("consume nonce: %w", err)
+	}
+	if !live {
+		return "", apperrors.UnAuthorizedError(nil, "nonce unknown, expired or already used")
+	}
+	return evmAddr, nil
+}
+
+// hashBody returns the hex SHA-256 of r's body and restores the body for the
+// handler to read.
+func hashBody(r *http.Request) (string, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodyBytes+1))
+	if err != nil {
+		return "", apperrors.BadRequestError(err, "failed to read request body")
+	}
+	if len(body) > maxRequestBodyBytes {
+		return "", apperrors.BadRequestError(nil, "request body too large")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func readJSON(r *http.Request, dst any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return apperrors.BadRequestError(err, "invalid JSON")
+	}
+	return nil
+}
+
+func (h *httpHandler) writeJSON(w http.ResponseWriter, data any) {
+	// Marshal before writing the status line so a serialization failure yields a
+	// 500 rather than a 200 with a truncated body.
+	buf, err := json.Marshal(data)
+	if err != nil {
+		h.logger.Error("failed to marshal JSON response", zap.Error(err))
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf)
+}
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	nonceprovider "github.com/chainsafe/canton-middleware/pkg/auth/service/nonce_provider"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy/mocks"
+)
+
+const adminToken = "s3cr3t-admin-token"
+
+func newAdminServer(mgr policy.Manager) http.Handler {
+	r := chi.NewRouter()
+	policy.RegisterAdminRoutes(r, mgr, adminToken, zap.NewNop())
+	return r
+}
+
+// testNonces backs the user servers built by newUserServer and the nonces
+// signed by signedRequest.
+var testNonces = nonceprovider.NewInMemory(time.Minute)
+
+func newUserServer(settings policy.Settings) http.Handler {
+	r := chi.NewRouter()
+	policy.RegisterUserRoutes(r, settings, testNonces, nil, zap.NewNop())
+	return r
+}
+
+// signedRequest builds a request authenticated with a fresh key's EIP-191
+// signature over an accept-policy message for its method and body with a fresh
+// nonce, returning it with the signer's address.
+func signedRequest(t *testing.T, method, target, body string) (*http.Request, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := auth.NormalizeAddress(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	return signedRequestWith(t, key, method, target, body, acceptPolicyMessage(t, addr, method, body)), addr
+}
+
+// acceptPolicyMessage returns an accept-policy message binding method and body,
+// with a fresh nonce issued to addr.
+func acceptPolicyMessage(t *testing.T, addr, method, body string) string {
+	t.Helper()
+	nonce, err := testNonces.Issue(context.Background(), addr)
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte(body))
+	return fmt.Sprintf("accept-policy:%s:%s:%x:%s:%d", addr, method, sum, nonce, time.Now().Unix())
+}
+
+func signedRequestWith(t *testing.T, key *ecdsa.PrivateKey, method, target, body, msg string) *http.Request {
+	t.Helper()
+	hash := crypto.Keccak256Hash([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(msg), msg)))
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("X-Signature", "0x"+hex.EncodeToString(sig))
+	r.Header.Set("X-Message", msg)
+	return r
+}
+
+func TestAdminRoutes_RequireToken(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newAdminServer(mocks.NewManager(t)).ServeHTTP(rec,
+		httptest.NewRequest(http.MethodGet, "/admin/accept-policies/global", http.NoBody))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminPutRule(t *testing.T) {
+	mgr := mocks.NewManager(t)
+	mgr.EXPECT().PutRule(mock.Anything, &policy.Rule{
+		Scope:           policy.GlobalScope,
+		DeniedSenders:   []string{"spam::1"},
+		RejectDenied:    true,
+		MaxAmountPerDay: "1000",
+	}).RunAndReturn(func(_ context.Context, r *policy.Rule) (*policy.Rule, error) {
+		r.Source = policy.SourceAdmin
+		return r, nil
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/accept-policies/global",
+		strings.NewReader(`{"denied_senders":["spam::1"],"reject_denied":true,"max_amount_per_day":"1000"}`))
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	rec := httptest.NewRecorder()
+	newAdminServer(mgr).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got policy.Rule
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, policy.SourceAdmin, got.Source)
+}
+
+func TestAdminListAudit_SetsNextCursorOnFullPage(t *testing.T) {
+	mgr := mocks.NewManager(t)
+	mgr.EXPECT().ListAudit(mock.Anything, policy.AuditFilter{ContractID: "c-1", BeforeID: 90, Limit: 2}).
+		Return([]*policy.AuditRecord{{ID: 89}, {ID: 75}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/accept-audit?contract_id=c-1&before_id=90&limit=2", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	rec := httptest.NewRecorder()
+	newAdminServer(mgr).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page policy.AuditResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Equal(t, int64(75), page.NextBeforeID)
+}
+
+func TestUserRoutes_RequireSignature(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newUserServer(mocks.NewSettings(t)).ServeHTTP(rec,
+		httptest.NewRequest(http.MethodGet, "/api/v2/custodial/accept-policy", http.NoBody))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestUserPutRule_ScopedToSigner(t *testing.T) {
+	req, signer := signedRequest(t, http.MethodPut, "/api/v2/custodial/accept-policy",
+		`{"allowed_senders":["friend::1"],"max_amount_per_offer":"50"}`)
+
+	settings := mocks.NewSettings(t)
+	settings.EXPECT().PutUserRule(mock.Anything, signer, &policy.Rule{
+		AllowedSenders:    []string{"friend::1"},
+		MaxAmountPerOffer: "50",
+	}).Return(&policy.Rule{Scope: signer, Source: policy.SourceUser}, nil)
+
+	rec := httptest.NewRecorder()
+	newUserServer(settings).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestUserPutRule_RejectsScopeInBody(t *testing.T) {
+	req, _ := signedRequest(t, http.MethodPut, "/api/v2/custodial/accept-policy", `{"scope":"global"}`)
+
+	rec := httptest.NewRecorder()
+	newUserServer(mocks.NewSettings(t)).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUserPutRule_RejectsReplay(t *testing.T) {
+	req, signer := signedRequest(t, http.MethodPut, "/api/v2/custodial/accept-policy", `{"reject_denied":true}`)
+	settings := mocks.NewSettings(t)
+	settings.EXPECT().PutUserRule(mock.Anything, signer, mock.Anything).
+		Return(&policy.Rule{Scope: signer, Source: policy.SourceUser}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	newUserServer(settings).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	replay := httptest.NewRequest(http.MethodPut, "/api/v2/custodial/accept-policy", strings.NewReader(`{"reject_denied":true}`))
+	replay.Header = req.Header.Clone()
+	rec = httptest.NewRecorder()
+	newUserServer(settings).ServeHTTP(rec, replay)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestUserRoutes_RejectSignatureForAnotherRequest(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := auth.NormalizeAddress(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	const signed = `{"reject_denied":true}`
+
+	for name, req := range map[string]*http.Request{
+		"other body": signedRequestWith(t, key, http.MethodPut, "/api/v2/custodial/accept-policy",
+			`{"allowed_senders":["attacker::1"]}`, acceptPolicyMessage(t, addr, http.MethodPut, signed)),
+		"other method": signedRequestWith(t, key, http.MethodDelete, "/api/v2/custodial/accept-policy",
+			"", acceptPolicyMessage(t, addr, http.MethodPut, signed)),
+	} {
+		rec := httptest.NewRecorder()
+		newUserServer(mocks.NewSettings(t)).ServeHTTP(rec, req)
+		require.Equal(t, http.StatusUnauthorized, rec.Code, name)
+	}
+}
+
+func TestUserDeleteRule_RejectsMessageForAnotherAction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := auth.NormalizeAddress(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	nonce, err := testNonces.Issue(context.Background(), addr)
+	require.NoError(t, err)
+
+	// A timed message signed for another endpoint, with or without a nonce.
+	for _, msg := range []string{
+		fmt.Sprintf("transfer:%d", time.Now().Unix()),
+		fmt.Sprintf("self-custody:%s:%s:%d", addr, nonce, time.Now().Unix()),
+	} {
+		rec := httptest.NewRecorder()
+		newUserServer(mocks.NewSettings(t)).ServeHTTP(rec,
+			signedRequestWith(t, key, http.MethodDelete, "/api/v2/custodial/accept-policy", "", msg))
+		require.Equal(t, http.StatusUnauthorized, rec.Code, msg)
+	}
+}
+
+func TestUserRoutes_TokenAuth(t *testing.T) {
+	const addr = "0x00000000000000000000000000000000000000aA"
+	asUser := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.WithEVMAddress(r.Context(), addr)))
+		})
+	}
+	settings := mocks.NewSettings(t)
+	settings.EXPECT().DeleteUserRule(mock.Anything, auth.NormalizeAddress(addr)).Return(nil)
+
+	r := chi.NewRouter()
+	policy.RegisterUserRoutes(r, settings, testNonces, asUser, zap.NewNop())
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v2/custodial/accept-policy", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
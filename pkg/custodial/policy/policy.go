@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package policy decides which inbound transfer offers the custodial
+// AcceptWorker may accept on behalf of a custodial party.
+//
+// Rules are stored per scope: one global rule set by operators, plus per-user
+// rules set either by an operator (admin API) or by the user themselves
+// (user-settings API). Every rule that applies to an offer must let it through,
+// so a user can only tighten what the global and admin rules allow.
+//
+// An offer from a denied sender is rejected on-ledger when the denying rule asks
+// for it (RejectDenied), returning the holding to the sender; every other
+// violation holds the offer — it is left pending for manual handling or expiry.
+// Each decision is recorded with the rule conditions that matched it in the
+// audit table.
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// GlobalScope is the scope of the rule that applies to every custodial party.
+// Any other scope is the EIP-55 EVM address of the user the rule applies to.
+const GlobalScope = "global"
+
+// Source records who wrote a rule. Admin and user rules for the same scope are
+// stored side by side, so neither overwrites the other.
+type Source string
+
+const (
+	SourceAdmin Source = "admin"
+	SourceUser  Source = "user"
+)
+
+// Action is the outcome of evaluating an offer.
+type Action string
+
+const (
+	// ActionAccept accepts the offer.
+	ActionAccept Action = "accept"
+	// ActionReject rejects the offer on-ledger, returning the holding to the sender.
+	ActionReject Action = "reject"
+	// ActionHold leaves the offer pending; it is not auto-accepted.
+	ActionHold Action = "hold"
+)
+
+// Rule conditions, recorded in Match.Condition. The *_allowed conditions are
+// positive matches of an allowlist; the rest are violations and double as the
+// decision reason.
+const (
+	ConditionSenderDenied         = "sender_denied"
+	ConditionSenderAllowed        = "sender_allowed"
+	ConditionSenderNotAllowed     = "sender_not_allowed"
+	ConditionInstrumentAllowed    = "instrument_allowed"
+	ConditionInstrumentNotAllowed = "instrument_not_allowed"
+	ConditionOfferLimitExceeded   = "offer_limit_exceeded"
+	ConditionDailyLimitExceeded   = "daily_limit_exceeded"
+)
+
+// ReasonAllowed is the reason of an accept decision.
+const ReasonAllowed = "allowed"
+
+// Rule restricts which offers are auto-accepted for a scope. Empty lists and
+// limits do not restrict.
+//
+// The amount limits are decimal strings in the offer's instrument. A rule that
+// does not restrict instruments applies its limits to each instrument
+// separately; MaxAmountPerDay caps the amount accepted per UTC calendar day.
+type Rule struct {
+	Scope              string                  `json:"scope"`
+	Source             Source                  `json:"source"`
+	DeniedSenders      []string                `json:"denied_senders,omitempty"`
+	AllowedSenders     []string                `json:"allowed_senders,omitempty"`
+	AllowedInstruments []indexer.InstrumentKey `json:"allowed_instruments,omitempty"`
+	MaxAmountPerOffer  string                  `json:"max_amount_per_offer,omitempty"`
+	MaxAmountPerDay    string                  `json:"max_amount_per_day,omitempty"`
+	// RejectDenied rejects offers from DeniedSenders on-ledger instead of
+	// letting them expire.
+	RejectDenied bool      `json:"reject_denied"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Validate checks the rule's limits and list entries.
+func (r *Rule) Validate() error {
+	for _, limit := range []struct{ name, value string }{
+		{"max_amount_per_offer", r.MaxAmountPerOffer},
+		{"max_amount_per_day", r.MaxAmountPerDay},
+	} {
+		if limit.value == "" {
+			continue
+		}
+		d, err := decimal.NewFromString(limit.value)
+		if err != nil || !d.IsPositive() {
+			return fmt.Errorf("%s must be a positive decimal", limit.name)
+		}
+	}
+	for _, p := range slices.Concat(r.DeniedSenders, r.AllowedSenders) {
+		if p == "" {
+			return fmt.Errorf("sender party ids must not be empty")
+		}
+	}
+	for _, k := range r.AllowedInstruments {
+		if k.Admin == "" || k.ID == "" {
+			return fmt.Errorf("allowed instruments need both admin and id")
+		}
+	}
+	return nil
+}
+
+// Match is one rule condition that matched an offer.
+type Match struct {
+	Scope     string `json:"scope"`
+	Source    Source `json:"source"`
+	Condition string `json:"condition"`
+}
+
+// Decision is the evaluated outcome for one offer.
+type Decision struct {
+	Action Action
+	// Reason is ReasonAllowed for accepts, otherwise the deciding violation.
+	Reason  string
+	Matches []Match
+}
+
+// Evaluate decides offer against rules. acceptedToday is the amount of the
+// offer's instrument already accepted for the receiver today; it is only read
+// by rules with a daily limit.
+//
+// A denied sender outranks every other violation. It is rejected when any rule
+// denying it has RejectDenied set, otherwise held.
+func Evaluate(rules []*Rule, offer *indexer.Transfer, acceptedToday decimal.Decimal) (*Decision, error) {
+	amount, err := decimal.NewFromString(offer.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("parse offer amount %q: %w", offer.Amount, err)
+	}
+	instrument := indexer.InstrumentKey{Admin: offer.InstrumentAdmin, ID: offer.InstrumentID}
+
+	d := &Decision{Action: ActionAccept, Reason: ReasonAllowed}
+	var denied, rejectDenied bool
+	var violation string
+	match := func(r *Rule, condition string) {
+		d.Matches = append(d.Matches, Match{Scope: r.Scope, Source: r.Source, Condition: condition})
+	}
+	violate := func(r *Rule, condition string) {
+		match(r, condition)
+		if violation == "" {
+			violation = condition
+		}
+	}
+
+	for _, r := range rules {
+		if slices.Contains(r.DeniedSenders, offer.FromPartyID) {
+			match(r, ConditionSenderDenied)
+			denied = true
+			rejectDenied = rejectDenied || r.RejectDenied
+		}
+		if len(r.AllowedSenders) > 0 {
+			if slices.Contains(r.AllowedSenders, offer.FromPartyID) {
+				match(r, ConditionSenderAllowed)
+			} else {
+				violate(r, ConditionSenderNotAllowed)
+			}
+		}
+		if len(r.AllowedInstruments) > 0 {
+			if slices.Contains(r.AllowedInstruments, instrument) {
+				match(r, ConditionInstrumentAllowed)
+			} else {
+				violate(r, ConditionInstrumentNotAllowed)
+			}
+		}
+		if exceeds(r.MaxAmountPerOffer, amount) {
+			violate(r, ConditionOfferLimitExceeded)
+		}
+		if exceeds(r.MaxAmountPerDay, acceptedToday.Add(amount)) {
+			violate(r, ConditionDailyLimitExceeded)
+		}
+	}
+
+	switch {
+	case denied && rejectDenied:
+		d.Action, d.Reason = ActionReject, ConditionSenderDenied
+	case denied:
+		d.Action, d.Reason = ActionHold, ConditionSenderDenied
+	case violation != "":
+		d.Action, d.Reason = ActionHold, violation
+	}
+	return d, nil
+}
+
+// NeedsDailyTotal reports whether any rule has a daily limit, i.e. whether
+// Evaluate needs the amount accepted today.
+func NeedsDailyTotal(rules []*Rule) bool {
+	return slices.ContainsFunc(rules, func(r *Rule) bool { return r.MaxAmountPerDay != "" })
+}
+
+// exceeds reports whether amount is over limit. An empty limit is unlimited;
+// limits are validated on write, so an unparsable one is treated as unlimited.
+func exceeds(limit string, amount decimal.Decimal) bool {
+	if limit == "" {
+		return false
+	}
+	l, err := decimal.NewFromString(limit)
+	return err == nil && amount.GreaterThan(l)
+}
+
+// AuditRecord is one evaluated offer and, for accept and reject decisions, the
+// outcome of the ledger call. Error is empty when the call succeeded.
+type AuditRecord struct {
+	ID              int64     `json:"id"`
+	ContractID      string    `json:"contract_id"`
+	EVMAddress      string    `json:"evm_address"`
+	PartyID         string    `json:"party_id"`
+	FromPartyID     string    `json:"from_party_id"`
+	InstrumentAdmin string    `json:"instrument_admin"`
+	InstrumentID    string    `json:"instrument_id"`
+	Amount          string    `json:"amount"`
+	Action          Action    `json:"action"`
+	Reason          string    `json:"reason"`
+	Matches         []Match   `json:"matches"`
+	Error           string    `json:"error,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// NewAuditRecord builds the audit record of decision for offer, received by the
+// user with evmAddress.
+func NewAuditRecord(evmAddress string, offer *indexer.Transfer, decision *Decision) *AuditRecord {
+	return &AuditRecord{
+		ContractID:      offer.ContractID,
+		EVMAddress:      evmAddress,
+		PartyID:         offer.ToPartyID,
+		FromPartyID:     offer.FromPartyID,
+		InstrumentAdmin: offer.InstrumentAdmin,
+		InstrumentID:    offer.InstrumentID,
+		Amount:          offer.Amount,
+		Action:          decision.Action,
+		Reason:          decision.Reason,
+		Matches:         decision.Matches,
+	}
+}
+
+// AuditFilter selects audit records, newest first. BeforeID pages backwards:
+// pass the last ID of the previous page.
+type AuditFilter struct {
+	EVMAddress string
+	ContractID string
+	BeforeID   int64
+	Limit      int
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const (
+	testSender = "sender-party::xyz"
+	testAdmin  = "admin-party::zzz"
+	testUser   = "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+)
+
+func testOffer(amount string) *indexer.Transfer {
+	return &indexer.Transfer{
+		ContractID:      "contract-1",
+		FromPartyID:     testSender,
+		ToPartyID:       "custodial-party::abc",
+		InstrumentAdmin: testAdmin,
+		InstrumentID:    "USDCX",
+		Amount:          amount,
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	usdcx := indexer.InstrumentKey{Admin: testAdmin, ID: "USDCX"}
+	cases := []struct {
+		name          string
+		rules         []*Rule
+		amount        string
+		acceptedToday string
+		wantAction    Action
+		wantReason    string
+		wantMatches   []string
+	}{
+		{
+			name:       "no rules",
+			amount:     "100",
+			wantAction: ActionAccept, wantReason: ReasonAllowed,
+		},
+		{
+			name: "allowlists match",
+			rules: []*Rule{{Scope: GlobalScope, Source: SourceAdmin,
+				AllowedSenders: []string{testSender}, AllowedInstruments: []indexer.InstrumentKey{usdcx}}},
+			amount:     "100",
+			wantAction: ActionAccept, wantReason: ReasonAllowed,
+			wantMatches: []string{ConditionSenderAllowed, ConditionInstrumentAllowed},
+		},
+		{
+			name:       "denied sender held",
+			rules:      []*Rule{{Scope: GlobalScope, Source: SourceAdmin, DeniedSenders: []string{testSender}}},
+			amount:     "100",
+			wantAction: ActionHold, wantReason: ConditionSenderDenied,
+			wantMatches: []string{ConditionSenderDenied},
+		},
+		{
+			name: "denied sender rejected by any denying rule",
+			rules: []*Rule{
+				{Scope: GlobalScope, Source: SourceAdmin, DeniedSenders: []string{testSender}},
+				{Scope: testUser, Source: SourceUser, DeniedSenders: []string{testSender}, RejectDenied: true},
+			},
+			amount:     "100",
+			wantAction: ActionReject, wantReason: ConditionSenderDenied,
+			wantMatches: []string{ConditionSenderDenied, ConditionSenderDenied},
+		},
+		{
+			name: "deny outranks other violations",
+			rules: []*Rule{{Scope: GlobalScope, Source: SourceAdmin, MaxAmountPerOffer: "10",
+				DeniedSenders: []string{testSender}, RejectDenied: true}},
+			amount:     "100",
+			wantAction: ActionReject, wantReason: ConditionSenderDenied,
+			wantMatches: []string{ConditionSenderDenied, ConditionOfferLimitExceeded},
+		},
+		{
+			name:       "sender not allowed",
+			rules:      []*Rule{{Scope: testUser, Source: SourceUser, AllowedSenders: []string{"other::1"}}},
+			amount:     "100",
+			wantAction: ActionHold, wantReason: ConditionSenderNotAllowed,
+			wantMatches: []string{ConditionSenderNotAllowed},
+		},
+		{
+			name: "instrument not allowed",
+			rules: []*Rule{{Scope: GlobalScope, Source: SourceAdmin,
+				AllowedInstruments: []indexer.InstrumentKey{{Admin: testAdmin, ID: "OTHER"}}}},
+			amount:     "100",
+			wantAction: ActionHold, wantReason: ConditionInstrumentNotAllowed,
+			wantMatches: []string{ConditionInstrumentNotAllowed},
+		},
+		{
+			name:       "offer at limit",
+			rules:      []*Rule{{Scope: GlobalScope, Source: SourceAdmin, MaxAmountPerOffer: "100"}},
+			amount:     "100.000",
+			wantAction: ActionAccept, wantReason: ReasonAllowed,
+		},
+		{
+			name:       "offer over limit",
+			rules:      []*Rule{{Scope: GlobalScope, Source: SourceAdmin, MaxAmountPerOffer: "100"}},
+			amount:     "100.01",
+			wantAction: ActionHold, wantReason: ConditionOfferLimitExceeded,
+			wantMatches: []string{ConditionOfferLimitExceeded},
+		},
+		{
+			name:          "daily limit includes the offer",
+			rules:         []*Rule{{Scope: testUser, Source: SourceAdmin, MaxAmountPerDay: "500"}},
+			amount:        "100",
+			acceptedToday: "450",
+			wantAction:    ActionHold, wantReason: ConditionDailyLimitExceeded,
+			wantMatches: []string{ConditionDailyLimitExceeded},
+		},
+		{
+			name:          "daily limit not reached",
+			rules:         []*Rule{{Scope: testUser, Source: SourceAdmin, MaxAmountPerDay: "500"}},
+			amount:        "100",
+			acceptedToday: "400",
+			wantAction:    ActionAccept, wantReason: ReasonAllowed,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			acceptedToday := decimal.Zero
+			if tc.acceptedToday != "" {
+				acceptedToday = decimal.RequireFromString(tc.acceptedToday)
+			}
+			d, err := Evaluate(tc.rules, testOffer(tc.amount), acceptedToday)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantAction, d.Action)
+			assert.Equal(t, tc.wantReason, d.Reason)
+			conditions := make([]string, 0, len(d.Matches))
+			for _, m := range d.Matches {
+				conditions = append(conditions, m.Condition)
+			}
+			assert.ElementsMatch(t, tc.wantMatches, conditions)
+		})
+	}
+}
+
+func TestEvaluate_InvalidAmount(t *testing.T) {
+	_, err := Evaluate(nil, testOffer("not-a-number"), decimal.Zero)
+	require.Error(t, err)
+}
+
+func TestRule_Validate(t *testing.T) {
+	require.NoError(t, (&Rule{MaxAmountPerOffer: "1.5", MaxAmountPerDay: "100"}).Validate())
+
+	for name, r := range map[string]*Rule{
+		"negative limit":     {MaxAmountPerOffer: "-1"},
+		"zero limit":         {MaxAmountPerDay: "0"},
+		"malformed limit":    {MaxAmountPerDay: "lots"},
+		"empty sender":       {DeniedSenders: []string{""}},
+		"partial instrument": {AllowedInstruments: []indexer.InstrumentKey{{ID: "USDCX"}}},
+	} {
+		assert.Error(t, r.Validate(), name)
+	}
+}
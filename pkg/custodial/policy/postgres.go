@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// RuleDao maps to the custodial_accept_rules table — at most one rule per scope
+// and source.
+type RuleDao struct {
+	bun.BaseModel      `bun:"table:custodial_accept_rules"`
+	Scope              string                  `bun:",pk,type:varchar(64)"`
+	Source             string                  `bun:",pk,type:varchar(10)"`
+	DeniedSenders      []string                `bun:",notnull,type:jsonb"`
+	AllowedSenders     []string                `bun:",notnull,type:jsonb"`
+	AllowedInstruments []indexer.InstrumentKey `bun:",notnull,type:jsonb"`
+	MaxAmountPerOffer  *string                 `bun:",type:numeric(38,18)"`
+	MaxAmountPerDay    *string                 `bun:",type:numeric(38,18)"`
+	RejectDenied       bool                    `bun:",notnull,default:false"`
+	UpdatedAt          time.Time               `bun:",notnull"`
+}
+
+// AuditDao maps to the custodial_accept_audit table — one row per evaluated
+// offer, kept as the compliance record of the accept worker's decisions.
+type AuditDao struct {
+	bun.BaseModel   `bun:"table:custodial_accept_audit"`
+	ID              int64     `bun:",pk,autoincrement"`
+	ContractID      string    `bun:",notnull,type:varchar(255)"`
+	EVMAddress      string    `bun:"evm_address,notnull,type:varchar(42)"`
+	PartyID         string    `bun:",notnull,type:varchar(255)"`
+	FromPartyID     string    `bun:",notnull,type:varchar(255)"`
+	InstrumentAdmin string    `bun:",notnull,type:varchar(255)"`
+	InstrumentID    string    `bun:",notnull,type:varchar(255)"`
+	Amount          string    `bun:",notnull,type:numeric(38,18)"`
+	Action          string    `bun:",notnull,type:varchar(10)"`
+	Reason          string    `bun:",notnull,type:varchar(32)"`
+	Matches         []Match   `bun:",notnull,type:jsonb"`
+	Error           string    `bun:",notnull,type:text,default:''"`
+	CreatedAt       time.Time `bun:",notnull"`
+}
+
+// acceptLockPrefix namespaces the advisory lock keys of LockParty.
+const acceptLockPrefix = "custodial-accept:"
+
+// Postgres is the Store backed by the api database.
+type Postgres struct {
+	db *bun.DB
+}
+
+var _ Store = (*Postgres)(nil)
+
+// NewPostgres creates a Postgres policy store.
+func NewPostgres(db *bun.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+// ListRules returns the rules of the given scopes, the global rule first.
+func (s *Postgres) ListRules(ctx context.Context, scopes []string) ([]*Rule, error) {
+	var rows []RuleDao
+	err := s.db.NewSelect().Model(&rows).
+		Where("scope IN (?)", bun.In(scopes)).
+		OrderExpr("scope = ? DESC, scope, source", GlobalScope).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list accept rules: %w", err)
+	}
+	out := make([]*Rule, len(rows))
+	for i := range rows {
+		out[i] = toRule(&rows[i])
+	}
+	return out, nil
+}
+
+// PutRule inserts the rule or replaces the one with the same scope and source.
+func (s *Postgres) PutRule(ctx context.Context, rule *Rule) error {
+	_, err := s.db.NewInsert().Model(toRuleDao(rule)).
+		On("CONFLICT (scope, source) DO UPDATE").
+		Set("denied_senders = EXCLUDED.denied_senders").
+		Set("allowed_senders = EXCLUDED.allowed_senders").
+		Set("allowed_instruments = EXCLUDED.allowed_instruments").
+		Set("max_amount_per_offer = EXCLUDED.max_amount_per_offer").
+		Set("max_amount_per_day = EXCLUDED.max_amount_per_day").
+		Set("reject_denied = EXCLUDED.reject_denied").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("put accept rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule removes the rule of scope and source, reporting whether it existed.
+func (s *Postgres) DeleteRule(ctx context.Context, scope string, source Source) (bool, error) {
+	res, err := s.db.NewDelete().Model((*RuleDao)(nil)).
+		Where("scope = ?", scope).
+		Where("source = ?", string(source)).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("delete accept rule: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// InsertAudit appends rec to the audit table, setting its ID.
+func (s *Postgres) InsertAudit(ctx context.Context, rec *AuditRecord) error {
+	dao := toAuditDao(rec)
+	if _, err := s.db.NewInsert().Model(dao).Returning("id").Exec(ctx); err != nil {
+		return fmt.Errorf("insert accept audit record: %w", err)
+	}
+	rec.ID = dao.ID
+	return nil
+}
+
+// AcceptedSince sums the amounts of instrument successfully accepted for party
+// since the given time.
+func (s *Postgres) AcceptedSince(
+	ctx context.Context, partyID string, instrument indexer.InstrumentKey, since time.Time,
+) (decimal.Decimal, error) {
+	var total sql.NullString
+	err := s.db.NewSelect().Model((*AuditDao)(nil)).
+		ColumnExpr("SUM(amount)").
+		Where("party_id = ?", partyID).
+		Where("instrument_admin = ?", instrument.Admin).
+		Where("instrument_id = ?", instrument.ID).
+		Where("action = ?", string(ActionAccept)).
+		Where("error = ''").
+		Where("created_at >= ?", since).
+		Scan(ctx, &total)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("sum accepted amount: %w", err)
+	}
+	if !total.Valid {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(total.String)
+}
+
+// LockParty takes a transaction-scoped advisory lock keyed by partyID and
+// returns the function releasing it. The transaction stays open, holding one
+// connection, until then; should its connection die, Postgres releases the lock
+// with it.
+func (s *Postgres) LockParty(ctx context.Context, partyID string) (func(), error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("lock accept party: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtextextended(?, 0))",
+		acceptLockPrefix+partyID); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("lock accept party: %w", err)
+	}
+	return func() { _ = tx.Rollback() }, nil
+}
+
+// ListAudit returns audit records matching filter, newest first.
+func (s *Postgres) ListAudit(ctx context.Context, filter AuditFilter) ([]*AuditRecord, error) {
+	var rows []AuditDao
+	q := s.db.NewSelect().Model(&rows).OrderExpr("id DESC").Limit(filter.Limit)
+	if filter.EVMAddress != "" {
+		q = q.Where("evm_address = ?", filter.EVMAddress)
+	}
+	if filter.ContractID != "" {
+		q = q.Where("contract_id = ?", filter.ContractID)
+	}
+	if filter.BeforeID > 0 {
+		q = q.Where("id < ?", filter.BeforeID)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("list accept audit records: %w", err)
+	}
+	out := make([]*AuditRecord, len(rows))
+	for i := range rows {
+		out[i] = toAuditRecord(&rows[i])
+	}
+	return out, nil
+}
+
+func toRuleDao(r *Rule) *RuleDao {
+	dao := &RuleDao{
+		Scope:              r.Scope,
+		Source:             string(r.Source),
+		DeniedSenders:      nonNil(r.DeniedSenders),
+		AllowedSenders:     nonNil(r.AllowedSenders),
+		AllowedInstruments: nonNil(r.AllowedInstruments),
+		RejectDenied:       r.RejectDenied,
+		UpdatedAt:          r.UpdatedAt,
+	}
+	if r.MaxAmountPerOffer != "" {
+		dao.MaxAmountPerOffer = &r.MaxAmountPerOffer
+	}
+	if r.MaxAmountPerDay != "" {
+		dao.MaxAmountPerDay = &r.MaxAmountPerDay
+	}
+	return dao
+}
+
+func toRule(dao *RuleDao) *Rule {
+	return &Rule{
+		Scope:              dao.Scope,
+		Source:             Source(dao.Source),
+		DeniedSenders:      dao.DeniedSenders,
+		AllowedSenders:     dao.AllowedSenders,
+		AllowedInstruments: dao.AllowedInstruments,
+		MaxAmountPerOffer:  normalizeAmount(dao.MaxAmountPerOffer),
+		MaxAmountPerDay:    normalizeAmount(dao.MaxAmountPerDay),
+		RejectDenied:       dao.RejectDenied,
+		UpdatedAt:          dao.UpdatedAt,
+	}
+}
+
+func toAuditDao(rec *AuditRecord) *AuditDao {
+	return &AuditDao{
+		ContractID:      rec.ContractID,
+		EVMAddress:      rec.EVMAddress,
+		PartyID:         rec.PartyID,
+		FromPartyID:     rec.FromPartyID,
+		InstrumentAdmin: rec.InstrumentAdmin,
+		InstrumentID:    rec.InstrumentID,
+		Amount:          rec.Amount,
+		Action:          string(rec.Action),
+		Reason:          rec.Reason,
+		Matches:         nonNil(rec.Matches),
+		Error:           rec.Error,
+		CreatedAt:       rec.CreatedAt,
+	}
+}
+
+func toAuditRecord(dao *AuditDao) *AuditRecord {
+	amount := dao.Amount
+	return &AuditRecord{
+		ID:              dao.ID,
+		ContractID:      dao.ContractID,
+		EVMAddress:      dao.EVMAddress,
+		PartyID:         dao.PartyID,
+		FromPartyID:     dao.FromPartyID,
+		InstrumentAdmin: dao.InstrumentAdmin,
+		InstrumentID:    dao.InstrumentID,
+		Amount:          normalizeAmount(&amount),
+		Action:          Action(dao.Action),
+		Reason:          dao.Reason,
+		Matches:         dao.Matches,
+		Error:           dao.Error,
+		CreatedAt:       dao.CreatedAt,
+	}
+}
+
+// normalizeAmount strips the trailing zeros numeric(38,18) pads values with.
+func normalizeAmount(v *string) string {
+	if v == nil {
+		return ""
+	}
+	d, err := decimal.NewFromString(*v)
+	if err != nil {
+		return *v
+	}
+	return d.String()
+}
+
+// nonNil stores empty lists as [] rather than JSON null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
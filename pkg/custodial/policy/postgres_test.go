@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/pgutil"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+func setupPostgres(t *testing.T) (context.Context, *Postgres) {
+	t.Helper()
+	requireDockerAccess(t)
+
+	ctx := context.Background()
+	db, cleanup := pgutil.SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	require.NoError(t, mghelper.CreateSchema(ctx, db, &RuleDao{}, &AuditDao{}))
+	return ctx, NewPostgres(db)
+}
+
+func requireDockerAccess(t *testing.T) {
+	t.Helper()
+
+	candidates := []string{
+		"/var/run/docker.sock",
+		filepath.Join(os.Getenv("HOME"), ".docker/run/docker.sock"),
+	}
+
+	for _, sock := range candidates {
+		if _, err := os.Stat(sock); err != nil {
+			continue
+		}
+		conn, err := (&net.Dialer{}).DialContext(context.Background(), "unix", sock)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+	}
+
+	t.Skip("docker daemon socket is not accessible; skipping testcontainer-backed policy store tests")
+}
+
+func TestPostgres_Rules(t *testing.T) {
+	ctx, store := setupPostgres(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user := &Rule{Scope: testUser, Source: SourceUser, AllowedSenders: []string{testSender}, UpdatedAt: now}
+	global := &Rule{Scope: GlobalScope, Source: SourceAdmin, MaxAmountPerOffer: "1000.5", RejectDenied: true, UpdatedAt: now}
+	require.NoError(t, store.PutRule(ctx, user))
+	require.NoError(t, store.PutRule(ctx, global))
+
+	rules, err := store.ListRules(ctx, []string{GlobalScope, testUser})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, GlobalScope, rules[0].Scope, "global rule first")
+	assert.Equal(t, "1000.5", rules[0].MaxAmountPerOffer)
+	assert.Empty(t, rules[0].AllowedSenders)
+	assert.Equal(t, []string{testSender}, rules[1].AllowedSenders)
+
+	// Replacing keeps one row per scope and source.
+	global.MaxAmountPerOffer = ""
+	require.NoError(t, store.PutRule(ctx, global))
+	rules, err = store.ListRules(ctx, []string{GlobalScope})
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Empty(t, rules[0].MaxAmountPerOffer)
+
+	deleted, err := store.DeleteRule(ctx, testUser, SourceAdmin)
+	require.NoError(t, err)
+	assert.False(t, deleted, "the user's rule is not an admin rule")
+	deleted, err = store.DeleteRule(ctx, testUser, SourceUser)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestPostgres_AuditAndAcceptedSince(t *testing.T) {
+	ctx, store := setupPostgres(t)
+	now := time.Now().UTC()
+	usdcx := indexer.InstrumentKey{Admin: testAdmin, ID: "USDCX"}
+
+	record := func(amount string, action Action, errMsg string, at time.Time) {
+		rec := NewAuditRecord(testUser, testOffer(amount), &Decision{Action: action, Reason: ReasonAllowed})
+		rec.Error, rec.CreatedAt = errMsg, at
+		require.NoError(t, store.InsertAudit(ctx, rec))
+		require.NotZero(t, rec.ID)
+	}
+	record("100", ActionAccept, "", now)
+	record("25.5", ActionAccept, "", now)
+	record("40", ActionAccept, "ledger error", now) // failed accepts do not count
+	record("60", ActionHold, "", now)
+	record("70", ActionAccept, "", now.Add(-48*time.Hour))
+
+	total, err := store.AcceptedSince(ctx, "custodial-party::abc", usdcx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.True(t, total.Equal(decimal.RequireFromString("125.5")), "got %s", total)
+
+	recs, err := store.ListAudit(ctx, AuditFilter{EVMAddress: testUser, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, recs, 2)
+	assert.Greater(t, recs[0].ID, recs[1].ID, "newest first")
+	assert.Equal(t, "70", recs[0].Amount)
+
+	recs, err = store.ListAudit(ctx, AuditFilter{BeforeID: recs[1].ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, recs, 3)
+}
+
+func TestPostgres_LockParty(t *testing.T) {
+	ctx, store := setupPostgres(t)
+
+	unlock, err := store.LockParty(ctx, "custodial-party::abc")
+	require.NoError(t, err)
+
+	// Another party's lock is independent.
+	unlockOther, err := store.LockParty(ctx, "custodial-party::other")
+	require.NoError(t, err)
+	unlockOther()
+
+	acquired := make(chan func())
+	go func() {
+		second, lockErr := store.LockParty(ctx, "custodial-party::abc")
+		assert.NoError(t, lockErr)
+		acquired <- second
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second holder acquired the party lock while it was held")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case second := <-acquired:
+		second()
+	case <-time.After(5 * time.Second):
+		t.Fatal("second holder did not acquire the party lock once released")
+	}
+}
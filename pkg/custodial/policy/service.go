@@ -0,0 +1,293 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+// Audit listing bounds, mirroring the whitelist listing.
+const (
+	DefaultAuditLimit = 50
+	MaxAuditLimit     = 200
+)
+
+// ErrRuleNotFound is returned when the rule to read or delete does not exist.
+var ErrRuleNotFound = errors.New("accept rule not found")
+
+// Store persists rules and the decision audit trail.
+//
+//go:generate mockery --name Store --output mocks --outpkg mocks --filename mock_store.go --with-expecter
+type Store interface {
+	ListRules(ctx context.Context, scopes []string) ([]*Rule, error)
+	PutRule(ctx context.Context, rule *Rule) error
+	DeleteRule(ctx context.Context, scope string, source Source) (bool, error)
+	InsertAudit(ctx context.Context, rec *AuditRecord) error
+	AcceptedSince(
+		ctx context.Context, partyID string, instrument indexer.InstrumentKey, since time.Time,
+	) (decimal.Decimal, error)
+	ListAudit(ctx context.Context, filter AuditFilter) ([]*AuditRecord, error)
+	// LockParty blocks until no other holder, in any process, has the lock of
+	// partyID and returns the function releasing it.
+	LockParty(ctx context.Context, partyID string) (func(), error)
+}
+
+// UserGetter looks up the user a user-settings request is for.
+//
+//go:generate mockery --name UserGetter --output mocks --outpkg mocks --filename mock_user_getter.go --with-expecter
+type UserGetter interface {
+	GetUserByEVMAddress(ctx context.Context, evmAddress string) (*user.User, error)
+}
+
+// Manager is the admin surface exposed under /admin/accept-policies.
+//
+//go:generate mockery --name Manager --output mocks --outpkg mocks --filename mock_manager.go --with-expecter
+type Manager interface {
+	GetRules(ctx context.Context, scope string) ([]*Rule, error)
+	PutRule(ctx context.Context, rule *Rule) (*Rule, error)
+	DeleteRule(ctx context.Context, scope string, source Source) error
+	ListAudit(ctx context.Context, filter AuditFilter) ([]*AuditRecord, error)
+}
+
+// Settings is the user-settings surface: a custodial user's own rule and the
+// decisions taken on their inbound offers.
+//
+//go:generate mockery --name Settings --output mocks --outpkg mocks --filename mock_settings.go --with-expecter
+type Settings interface {
+	GetUserRule(ctx context.Context, evmAddress string) (*Rule, error)
+	PutUserRule(ctx context.Context, evmAddress string, rule *Rule) (*Rule, error)
+	DeleteUserRule(ctx context.Context, evmAddress string) error
+	ListUserAudit(ctx context.Context, evmAddress string, beforeID int64, limit int) ([]*AuditRecord, error)
+}
+
+// Service evaluates offers for the accept worker and backs the admin and
+// user-settings APIs.
+type Service struct {
+	store Store
+	users UserGetter
+	now   func() time.Time
+}
+
+// Compile-time checks that Service satisfies both API interfaces.
+var (
+	_ Manager  = (*Service)(nil)
+	_ Settings = (*Service)(nil)
+)
+
+// NewService returns a policy Service backed by store.
+func NewService(store Store, users UserGetter) *Service {
+	return &Service{store: store, users: users, now: time.Now}
+}
+
+// Evaluate decides offer for the custodial user with evmAddress against the
+// global rule and the user's rules. With no rules every offer is accepted.
+func (s *Service) Evaluate(ctx context.Context, evmAddress string, offer *indexer.Transfer) (*Decision, error) {
+	rules, err := s.store.ListRules(ctx, []string{GlobalScope, auth.NormalizeAddress(evmAddress)})
+	if err != nil {
+		return nil, err
+	}
+	acceptedToday := decimal.Zero
+	if NeedsDailyTotal(rules) {
+		instrument := indexer.InstrumentKey{Admin: offer.InstrumentAdmin, ID: offer.InstrumentID}
+		startOfDay := s.now().UTC().Truncate(24 * time.Hour)
+		acceptedToday, err = s.store.AcceptedSince(ctx, offer.ToPartyID, instrument, startOfDay)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return Evaluate(rules, offer, acceptedToday)
+}
+
+// LockParty serializes the decisions on offers to partyID across every accept
+// worker sharing the store: the holder's accepts are recorded before the next
+// holder reads the party's daily total. Call the returned function once the
+// decision is acted on and recorded.
+func (s *Service) LockParty(ctx context.Context, partyID string) (func(), error) {
+	return s.store.LockParty(ctx, partyID)
+}
+
+// Record appends rec to the audit trail.
+func (s *Service) Record(ctx context.Context, rec *AuditRecord) error {
+	rec.EVMAddress = auth.NormalizeAddress(rec.EVMAddress)
+	rec.CreatedAt = s.now()
+	return s.store.InsertAudit(ctx, rec)
+}
+
+// GetRules returns the admin and user rules of scope.
+func (s *Service) GetRules(ctx context.Context, scope string) ([]*Rule, error) {
+	scope, err := normalizeScope(scope)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.store.ListRules(ctx, []string{scope})
+	if err != nil {
+		return nil, apperrors.GeneralError(fmt.Errorf("get accept rules: %w", err))
+	}
+	return rules, nil
+}
+
+// PutRule validates and stores an admin rule, replacing the scope's previous
+// admin rule. Only admin rules can be written here; user rules belong to the
+// user-settings API.
+func (s *Service) PutRule(ctx context.Context, rule *Rule) (*Rule, error) {
+	scope, err := normalizeScope(rule.Scope)
+	if err != nil {
+		return nil, err
+	}
+	rule.Scope, rule.Source = scope, SourceAdmin
+	return s.put(ctx, rule)
+}
+
+// DeleteRule removes a rule of scope written by source.
+func (s *Service) DeleteRule(ctx context.Context, scope string, source Source) error {
+	scope, err := normalizeScope(scope)
+	if err != nil {
+		return err
+	}
+	if source != SourceAdmin && source != SourceUser {
+		return apperrors.BadRequestError(nil, "source must be admin or user")
+	}
+	return s.delete(ctx, scope, source)
+}
+
+// ListAudit returns audit records matching filter, newest first. The limit is
+// clamped to [1, MaxAuditLimit].
+func (s *Service) ListAudit(ctx context.Context, filter AuditFilter) ([]*AuditRecord, error) {
+	if filter.EVMAddress != "" {
+		addr, err := normalizeAddress(filter.EVMAddress)
+		if err != nil {
+			return nil, err
+		}
+		filter.EVMAddress = addr
+	}
+	filter.Limit = clampLimit(filter.Limit)
+	recs, err := s.store.ListAudit(ctx, filter)
+	if err != nil {
+		return nil, apperrors.GeneralError(fmt.Errorf("list accept audit: %w", err))
+	}
+	return recs, nil
+}
+
+// GetUserRule returns the user's own rule, or a not-found error when they have
+// not set one.
+func (s *Service) GetUserRule(ctx context.Context, evmAddress string) (*Rule, error) {
+	addr, err := s.custodialUser(ctx, evmAddress)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.store.ListRules(ctx, []string{addr})
+	if err != nil {
+		return nil, apperrors.GeneralError(fmt.Errorf("get accept rules: %w", err))
+	}
+	for _, r := range rules {
+		if r.Source == SourceUser {
+			return r, nil
+		}
+	}
+	return nil, apperrors.ResourceNotFoundError(ErrRuleNotFound, "no accept policy set")
+}
+
+// PutUserRule validates and stores the user's own rule.
+func (s *Service) PutUserRule(ctx context.Context, evmAddress string, rule *Rule) (*Rule, error) {
+	addr, err := s.custodialUser(ctx, evmAddress)
+	if err != nil {
+		return nil, err
+	}
+	rule.Scope, rule.Source = addr, SourceUser
+	return s.put(ctx, rule)
+}
+
+// DeleteUserRule removes the user's own rule.
+func (s *Service) DeleteUserRule(ctx context.Context, evmAddress string) error {
+	addr, err := s.custodialUser(ctx, evmAddress)
+	if err != nil {
+		return err
+	}
+	return s.delete(ctx, addr, SourceUser)
+}
+
+// ListUserAudit returns the decisions taken on the user's inbound offers.
+func (s *Service) ListUserAudit(
+	ctx context.Context, evmAddress string, beforeID int64, limit int,
+) ([]*AuditRecord, error) {
+	addr, err := s.custodialUser(ctx, evmAddress)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListAudit(ctx, AuditFilter{EVMAddress: addr, BeforeID: beforeID, Limit: limit})
+}
+
+func (s *Service) put(ctx context.Context, rule *Rule) (*Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, apperrors.BadRequestError(err, err.Error())
+	}
+	rule.UpdatedAt = s.now()
+	if err := s.store.PutRule(ctx, rule); err != nil {
+		return nil, apperrors.GeneralError(fmt.Errorf("put accept rule: %w", err))
+	}
+	return rule, nil
+}
+
+func (s *Service) delete(ctx context.Context, scope string, source Source) error {
+	deleted, err := s.store.DeleteRule(ctx, scope, source)
+	if err != nil {
+		return apperrors.GeneralError(fmt.Errorf("delete accept rule: %w", err))
+	}
+	if !deleted {
+		return apperrors.ResourceNotFoundError(ErrRuleNotFound, "accept policy not found")
+	}
+	return nil
+}
+
+// custodialUser returns the normalized address of a registered custodial user.
+// Policies only steer the accept worker, which acts for custodial users alone.
+func (s *Service) custodialUser(ctx context.Context, evmAddress string) (string, error) {
+	addr, err := normalizeAddress(evmAddress)
+	if err != nil {
+		return "", err
+	}
+	usr, err := s.users.GetUserByEVMAddress(ctx, addr)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return "", apperrors.ResourceNotFoundError(err, "user not registered")
+	}
+	if err != nil {
+		return "", apperrors.GeneralError(fmt.Errorf("get user: %w", err))
+	}
+	if usr.KeyMode != user.KeyModeCustodial {
+		return "", apperrors.BadRequestError(nil, "accept policies apply to custodial users only")
+	}
+	return addr, nil
+}
+
+// normalizeScope accepts GlobalScope or an EVM address, returned in EIP-55 form.
+func normalizeScope(scope string) (string, error) {
+	if scope == GlobalScope {
+		return scope, nil
+	}
+	return normalizeAddress(scope)
+}
+
+// normalizeAddress validates an EVM address and returns its EIP-55 form.
+func normalizeAddress(evmAddress string) (string, error) {
+	if !auth.ValidateEVMAddress(evmAddress) {
+		return "", apperrors.BadRequestError(nil, "valid evm address is required")
+	}
+	return auth.NormalizeAddress(evmAddress), nil
+}
+
+func clampLimit(limit int) int {
+	if limit < 1 {
+		return DefaultAuditLimit
+	}
+	return min(limit, MaxAuditLimit)
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy"
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy/mocks"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+const lowerAddr = "0xabcdef0123456789abcdef0123456789abcdef01"
+
+var checksummed = auth.NormalizeAddress(lowerAddr)
+
+func offer(amount string) *indexer.Transfer {
+	return &indexer.Transfer{
+		ContractID:      "contract-1",
+		FromPartyID:     "sender-party::xyz",
+		ToPartyID:       "custodial-party::abc",
+		InstrumentAdmin: "admin-party::zzz",
+		InstrumentID:    "USDCX",
+		Amount:          amount,
+	}
+}
+
+func TestService_Evaluate_SumsTodaysAcceptsForDailyLimit(t *testing.T) {
+	ctx := context.Background()
+	store := mocks.NewStore(t)
+	store.EXPECT().ListRules(ctx, []string{policy.GlobalScope, checksummed}).Return([]*policy.Rule{
+		{Scope: checksummed, Source: policy.SourceUser, MaxAmountPerDay: "250"},
+	}, nil)
+	instrument := indexer.InstrumentKey{Admin: "admin-party::zzz", ID: "USDCX"}
+	startOfDay := mock.MatchedBy(func(since time.Time) bool {
+		return since.Equal(since.Truncate(24*time.Hour)) && time.Since(since) < 24*time.Hour
+	})
+	store.EXPECT().AcceptedSince(ctx, "custodial-party::abc", instrument, startOfDay).
+		Return(decimal.RequireFromString("200"), nil)
+
+	d, err := policy.NewService(store, mocks.NewUserGetter(t)).Evaluate(ctx, lowerAddr, offer("75"))
+	require.NoError(t, err)
+	assert.Equal(t, policy.ActionHold, d.Action)
+	assert.Equal(t, policy.ConditionDailyLimitExceeded, d.Reason)
+}
+
+func TestService_Evaluate_NoDailyLimitSkipsSum(t *testing.T) {
+	ctx := context.Background()
+	store := mocks.NewStore(t) // AcceptedSince must not be called
+	store.EXPECT().ListRules(ctx, []string{policy.GlobalScope, checksummed}).Return(nil, nil)
+
+	d, err := policy.NewService(store, mocks.NewUserGetter(t)).Evaluate(ctx, lowerAddr, offer("75"))
+	require.NoError(t, err)
+	assert.Equal(t, policy.ActionAccept, d.Action)
+}
+
+func TestService_PutRule_ForcesAdminSource(t *testing.T) {
+	ctx := context.Background()
+	store := mocks.NewStore(t)
+	store.EXPECT().PutRule(ctx, mock.MatchedBy(func(r *policy.Rule) bool {
+		return r.Scope == checksummed && r.Source == policy.SourceAdmin && !r.UpdatedAt.IsZero()
+	})).Return(nil).Once()
+
+	rule, err := policy.NewService(store, mocks.NewUserGetter(t)).
+		PutRule(ctx, &policy.Rule{Scope: lowerAddr, Source: policy.SourceUser, MaxAmountPerOffer: "10"})
+	require.NoError(t, err)
+	assert.Equal(t, policy.SourceAdmin, rule.Source)
+}
+
+func TestService_PutRule_Invalid(t *testing.T) {
+	ctx := context.Background()
+	svc := policy.NewService(mocks.NewStore(t), mocks.NewUserGetter(t)) // store must not be called
+
+	for name, r := range map[string]*policy.Rule{
+		"bad scope": {Scope: "everyone"},
+		"bad limit": {Scope: policy.GlobalScope, MaxAmountPerOffer: "-5"},
+	} {
+		_, err := svc.PutRule(ctx, r)
+		require.Error(t, err, name)
+		require.True(t, apperrors.Is(err, apperrors.CategoryDataError), "%s: want bad request, got %v", name, err)
+	}
+}
+
+func TestService_PutUserRule(t *testing.T) {
+	ctx := context.Background()
+	users := mocks.NewUserGetter(t)
+	users.EXPECT().GetUserByEVMAddress(ctx, checksummed).
+		Return(&user.User{EVMAddress: checksummed, KeyMode: user.KeyModeCustodial}, nil)
+	store := mocks.NewStore(t)
+	store.EXPECT().PutRule(ctx, mock.MatchedBy(func(r *policy.Rule) bool {
+		return r.Scope == checksummed && r.Source == policy.SourceUser
+	})).Return(nil).Once()
+
+	_, err := policy.NewService(store, users).
+		PutUserRule(ctx, lowerAddr, &policy.Rule{DeniedSenders: []string{"spam::1"}, RejectDenied: true})
+	require.NoError(t, err)
+}
+
+func TestService_PutUserRule_RejectsNonCustodialUser(t *testing.T) {
+	ctx := context.Background()
+	users := mocks.NewUserGetter(t)
+	users.EXPECT().GetUserByEVMAddress(ctx, checksummed).
+		Return(&user.User{EVMAddress: checksummed, KeyMode: user.KeyModeExternal}, nil)
+
+	_, err := policy.NewService(mocks.NewStore(t), users).PutUserRule(ctx, lowerAddr, &policy.Rule{})
+	require.Error(t, err)
+	require.True(t, apperrors.Is(err, apperrors.CategoryDataError), "want bad request, got %v", err)
+}
+
+func TestService_GetUserRule_NotSet(t *testing.T) {
+	ctx := context.Background()
+	users := mocks.NewUserGetter(t)
+	users.EXPECT().GetUserByEVMAddress(ctx, checksummed).
+		Return(&user.User{EVMAddress: checksummed, KeyMode: user.KeyModeCustodial}, nil)
+	store := mocks.NewStore(t)
+	// An admin rule for the user is not the user's own setting.
+	store.EXPECT().ListRules(ctx, []string{checksummed}).
+		Return([]*policy.Rule{{Scope: checksummed, Source: policy.SourceAdmin}}, nil)
+
+	_, err := policy.NewService(store, users).GetUserRule(ctx, lowerAddr)
+	require.ErrorIs(t, err, policy.ErrRuleNotFound)
+}
+
+func TestService_DeleteRule_NotFound(t *testing.T) {
+	ctx := context.Background()
+	store := mocks.NewStore(t)
+	store.EXPECT().DeleteRule(ctx, policy.GlobalScope, policy.SourceAdmin).Return(false, nil)
+
+	err := policy.NewService(store, mocks.NewUserGetter(t)).DeleteRule(ctx, policy.GlobalScope, policy.SourceAdmin)
+	require.ErrorIs(t, err, policy.ErrRuleNotFound)
+}
+
+func TestService_ListAudit_ClampsLimit(t *testing.T) {
+	ctx := context.Background()
+	store := mocks.NewStore(t)
+	store.EXPECT().ListAudit(ctx, policy.AuditFilter{EVMAddress: checksummed, Limit: policy.MaxAuditLimit}).
+		Return(nil, nil)
+
+	_, err := policy.NewService(store, mocks.NewUserGetter(t)).
+		ListAudit(ctx, policy.AuditFilter{EVMAddress: lowerAddr, Limit: 10_000})
+	require.NoError(t, err)
+}
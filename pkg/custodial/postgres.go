@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package custodial
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// acceptCursorName keys the accept worker's row in custodial_accept_cursors.
+const acceptCursorName = "accept_worker"
+
+// CursorDao maps to the custodial_accept_cursors table: the last ledger offset a
+// stream consumer has handled, one row per consumer.
+type CursorDao struct {
+	bun.BaseModel `bun:"table:custodial_accept_cursors"`
+	Name          string    `bun:"name,pk,type:varchar(64)"`
+	LedgerOffset  int64     `bun:"ledger_offset,notnull"`
+	UpdatedAt     time.Time `bun:"updated_at,notnull"`
+}
+
+// PostgresCursor persists the accept worker's stream cursor. Every api-server
+// replica running the worker shares the row, so a restarted or replacement
+// replica resumes where the last one stopped.
+type PostgresCursor struct {
+	db   *bun.DB
+	name string
+}
+
+// NewPostgresCursor creates a CursorStore for the accept worker.
+func NewPostgresCursor(db *bun.DB) *PostgresCursor {
+	return &PostgresCursor{db: db, name: acceptCursorName}
+}
+
+// LoadOffset returns the stored offset; ok is false when none has been saved.
+func (c *PostgresCursor) LoadOffset(ctx context.Context) (offset int64, ok bool, err error) {
+	dao := new(CursorDao)
+	err = c.db.NewSelect().Model(dao).Where("name = ?", c.name).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return dao.LedgerOffset, true, nil
+}
+
+// SaveOffset stores offset. The cursor never moves backwards, so a replica
+// that is behind cannot rewind one that is ahead.
+func (c *PostgresCursor) SaveOffset(ctx context.Context, offset int64) error {
+	_, err := c.db.NewInsert().
+		Model(&CursorDao{Name: c.name, LedgerOffset: offset, UpdatedAt: time.Now()}).
+		On("CONFLICT (name) DO UPDATE").
+		Set("ledger_offset = EXCLUDED.ledger_offset").
+		Set("updated_at = EXCLUDED.updated_at").
+		Where("custodial_accept_cursors.ledger_offset < EXCLUDED.ledger_offset").
+		Exec(ctx)
+	return err
+}
@@ -203,8 +203,11 @@ func (s *Submitter) drain(ctx context.Context) error {
 // process submits a single pending entry to Canton, recording the outcome on
 // the mempool row. Permanent (client-side) failures are recorded as failed so
 // they reach the receipt; transient failures (network, gRPC unavailable) — and
-// timeouts — leave the entry pending for retry on the next tick. Canton's
-// command-id idempotency makes the retry safe.
+// timeouts — leave the entry pending for retry on the next tick. The retry
+// reuses the tx hash as Canton command ID, and the token service resolves the
+// earlier submission's outcome (cantonsdk ledger.ResolveOutcome) before
+// resubmitting, so a transfer that committed after its call timed out is
+// completed rather than submitted twice.
 //
 // The Canton call runs under its own cantonCallTimeout deadline so a hung gRPC
 // call can't park a worker slot indefinitely. The follow-up mempool-status
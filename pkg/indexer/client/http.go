@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
 	"github.com/chainsafe/canton-middleware/pkg/indexer"
@@ -166,9 +167,7 @@ func (c *HTTP) ListTokenEvents(
 	p indexer.Pagination,
 ) (*indexer.Page[*indexer.ParsedEvent], error) {
 	q := pageQuery(p)
-	if f.EventType != "" {
-		q.Set("event_type", string(f.EventType))
-	}
+	setEventFilter(q, f)
 	u := c.tokenBase(admin, id) + "/events?" + q.Encode()
 	var page indexer.Page[*indexer.ParsedEvent]
 	if err := c.getJSON(ctx, u, &page); err != nil {
@@ -185,9 +184,7 @@ func (c *HTTP) ListPartyEvents(
 	p indexer.Pagination,
 ) (*indexer.Page[*indexer.ParsedEvent], error) {
 	q := pageQuery(p)
-	if f.EventType != "" {
-		q.Set("event_type", string(f.EventType))
-	}
+	setEventFilter(q, f)
 	u := c.partyBase(partyID) + "/events?" + q.Encode()
 	var page indexer.Page[*indexer.ParsedEvent]
 	if err := c.getJSON(ctx, u, &page); err != nil {
@@ -220,6 +217,7 @@ func (c *HTTP) GetTransfers(
 	if query.Status != "" {
 		q.Set("status", query.Status)
 	}
+	setHistoryFilter(q, query.HistoryFilter)
 	u := c.partyBase(partyID) + "/transfers?" + q.Encode()
 	var page indexer.Page[indexer.Transfer]
 	if err := c.getJSON(ctx, u, &page); err != nil {
@@ -250,9 +248,52 @@ func (c *HTTP) partyBase(partyID string) string {
 		c.baseURL, url.PathEscape(partyID))
 }
 
+// setEventFilter sets the query parameters of the non-zero fields of f. The
+// instrument and party are set by the endpoint path.
+func setEventFilter(q url.Values, f indexer.EventFilter) {
+	if f.EventType != "" {
+		q.Set("event_type", string(f.EventType))
+	}
+	if f.ExternalTxID != "" {
+		q.Set("external_tx_id", f.ExternalTxID)
+	}
+	setHistoryFilter(q, f.HistoryFilter)
+}
+
+// setHistoryFilter sets the query parameters of the non-zero fields of f.
+func setHistoryFilter(q url.Values, f indexer.HistoryFilter) {
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("counterparty", f.Counterparty)
+	if f.FromOffset > 0 {
+		q.Set("from_offset", strconv.FormatInt(f.FromOffset, 10))
+	}
+	if f.ToOffset > 0 {
+		q.Set("to_offset", strconv.FormatInt(f.ToOffset, 10))
+	}
+	if !f.Since.IsZero() {
+		q.Set("since", f.Since.Format(time.RFC3339Nano))
+	}
+	if !f.Until.IsZero() {
+		q.Set("until", f.Until.Format(time.RFC3339Nano))
+	}
+	set("min_amount", f.MinAmount)
+	set("max_amount", f.MaxAmount)
+}
+
 func pageQuery(p indexer.Pagination) url.Values {
 	q := url.Values{}
-	q.Set("page", strconv.Itoa(p.Page))
+	if p.Cursor != nil {
+		q.Set("cursor", p.Cursor.String())
+		if p.WithTotal {
+			q.Set("with_total", "true")
+		}
+	} else {
+		q.Set("page", strconv.Itoa(p.Page))
+	}
 	q.Set("limit", strconv.Itoa(p.Limit))
 	return q
 }
@@ -300,6 +300,29 @@ func TestHTTP_ListBalancesForParty_Success(t *testing.T) {
 	assert.Equal(t, "500.000000000000000000", got.Items[0].Amount)
 }
 
+func TestHTTP_ListBalancesForParty_Cursor(t *testing.T) {
+	cursor := indexer.Cursor{InstrumentAdmin: admin, InstrumentID: id}
+	next := indexer.Cursor{InstrumentAdmin: admin, InstrumentID: "ZZZ"}
+	page := pageOf([]*indexer.Balance{testBalance})
+	page.Page, page.NextCursor = 0, &next
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, cursor.String(), q.Get("cursor"))
+		assert.Equal(t, "true", q.Get("with_total"))
+		assert.False(t, q.Has("page"), "page must not be sent with a cursor")
+		jsonResp(http.StatusOK, page)(w, r)
+	}))
+	defer srv.Close()
+
+	c := mustNew(t, srv.URL, srv.Client())
+	got, err := c.ListBalancesForParty(context.Background(), partyID,
+		indexer.Pagination{Limit: 10, Cursor: &cursor, WithTotal: true})
+
+	require.NoError(t, err)
+	require.NotNil(t, got.NextCursor)
+	assert.Equal(t, next, *got.NextCursor)
+}
+
 // ── ListBalancesForToken ──────────────────────────────────────────────────────
 
 func TestHTTP_ListBalancesForToken_Success(t *testing.T) {
@@ -422,6 +445,34 @@ func TestHTTP_ListPartyEvents_WithEventTypeFilter(t *testing.T) {
 	assert.Len(t, got.Items, 1)
 }
 
+func TestHTTP_ListPartyEvents_WithHistoryFilters(t *testing.T) {
+	page := pageOf([]*indexer.ParsedEvent{testEvent})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "0xabc", q.Get("external_tx_id"))
+		assert.Equal(t, "bob::1220", q.Get("counterparty"))
+		assert.Equal(t, "10", q.Get("from_offset"))
+		assert.False(t, q.Has("to_offset"), "zero fields are not sent")
+		assert.Equal(t, "2026-01-01T00:00:00Z", q.Get("since"))
+		assert.Equal(t, "5", q.Get("min_amount"))
+		jsonResp(http.StatusOK, page)(w, r)
+	}))
+	defer srv.Close()
+
+	c := mustNew(t, srv.URL, srv.Client())
+	f := indexer.EventFilter{
+		ExternalTxID: "0xabc",
+		HistoryFilter: indexer.HistoryFilter{
+			Counterparty: "bob::1220",
+			FromOffset:   10,
+			Since:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			MinAmount:    "5",
+		},
+	}
+	_, err := c.ListPartyEvents(context.Background(), partyID, f, testPagination)
+	require.NoError(t, err)
+}
+
 // ── Constructor ───────────────────────────────────────────────────────────────
 
 func TestNew_NilHTTPClient_UsesDefaultClient(t *testing.T) {
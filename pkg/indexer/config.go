@@ -2,14 +2,23 @@
 
 package indexer
 
+import (
+	"slices"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/cip56"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/registry"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/packages"
+)
+
 // Config holds stream-specific settings for the indexer process.
 // It lives in the indexer domain package so that app-level config
 // (pkg/config) can embed it without creating a god-config pattern.
 type Config struct {
 	// CIP56PackageID is the DAML package ID containing CIP56.Events.TokenTransferEvent.
 	// Setting this pins the indexer to a specific package version; leave empty to
-	// match the template across all package versions.
-	CIP56PackageID string `yaml:"cip56_package_id" validate:"required"`
+	// discover the package at startup and match the template across all of its
+	// versions through a package-name reference (#cip56-token).
+	CIP56PackageID string `yaml:"cip56_package_id"`
 
 	// FilterMode controls which instruments are indexed.
 	// "all"       — index every TokenTransferEvent on the stream.
@@ -22,15 +31,114 @@ type Config struct {
 
 	// UtilityRegistryPackageID is the DAML package ID for the Utility Registry app
 	// (Utility.Registry.App.V0.Model.Transfer.TransferOffer).
-	// Leave empty to disable TransferOffer tracking.
+	// Leave empty to disable TransferOffer tracking, unless the package has a
+	// version range under packages.versions, which enables discovery.
 	UtilityRegistryPackageID string `yaml:"utility_registry_package_id"`
 
 	// UtilityRegistryHoldingPackageID is the DAML package ID for the Utility Registry
 	// Holding template (Utility.Registry.Holding.V0.Holding). Required to track
 	// USDCx-style balances — without it the indexer never sees Holding contract
 	// create/archive events and balances for AllocationFactory-based instruments
-	// stay at 0. Leave empty to disable Holding tracking.
+	// stay at 0. Leave empty to disable Holding tracking, unless the package has
+	// a version range under packages.versions, which enables discovery.
 	UtilityRegistryHoldingPackageID string `yaml:"utility_registry_holding_package_id"`
+
+	// Decoders enables further registry decoders, or lists more package IDs for
+	// the ones above — e.g. every package ID a template was deployed under when
+	// a Daml upgrade left contracts on several versions. Entries for the same
+	// decoder are merged.
+	Decoders []DecoderConfig `yaml:"decoders" validate:"dive"`
+
+	// Bootstrap seeds a fresh database from an ACS snapshot instead of replaying
+	// the ledger from the beginning.
+	Bootstrap BootstrapConfig `yaml:"bootstrap"`
+
+	// TokenMetadata sets the symbol and decimals that history exports report
+	// for each instrument. Unlisted instruments are exported with their
+	// instrument ID as symbol and no decimals.
+	TokenMetadata []TokenMetadata `yaml:"token_metadata" validate:"dive"`
+}
+
+// PackageBindings ties the package ID settings to the Daml packages they name.
+// The Utility.Registry packages are optional, as leaving them empty disables
+// their decoders.
+func (c *Config) PackageBindings() []packages.Binding {
+	return []packages.Binding{
+		{Name: cip56.CIP56TokenPackage.Name, Target: &c.CIP56PackageID},
+		{Name: registry.UtilityRegistryAppPackage.Name, Target: &c.UtilityRegistryPackageID, Optional: true},
+		{Name: registry.UtilityRegistryPackage.Name, Target: &c.UtilityRegistryHoldingPackageID, Optional: true},
+	}
+}
+
+// Registry decoder names, used in DecoderConfig.Name.
+const (
+	DecoderCIP56TransferEvent     = "cip56_transfer_event"     // CIP56.Events.TokenTransferEvent
+	DecoderUtilityRegistryOffer   = "utility_registry_offer"   // Utility.Registry TransferOffer
+	DecoderUtilityRegistryHolding = "utility_registry_holding" // Utility.Registry Holding
+	DecoderSpliceAmulet           = "splice_amulet"            // Splice.Amulet.Amulet
+	DecoderSpliceAmuletAllocation = "splice_amulet_allocation" // Splice.AmuletAllocation.AmuletAllocation
+)
+
+// DecoderConfig enables one registry decoder.
+type DecoderConfig struct {
+	// Name selects the decoder; see the Decoder* constants.
+	Name string `yaml:"name" validate:"required"`
+
+	// PackageIDs are the package IDs or package-name references (#<name>) the
+	// decoder's template is subscribed under. Leave empty to match the template
+	// across all package versions.
+	PackageIDs []string `yaml:"package_ids"`
+}
+
+// EnabledDecoders returns the decoders to register, one entry per name in
+// configuration order. The CIP-56 decoder is always enabled; the package-ID
+// fields enable their decoder when set. Their package IDs are merged with those
+// listed under Decoders, without duplicates.
+func (c *Config) EnabledDecoders() []DecoderConfig {
+	var out []DecoderConfig
+	index := make(map[string]int)
+	add := func(name string, packageIDs ...string) {
+		i, ok := index[name]
+		if !ok {
+			i = len(out)
+			index[name] = i
+			out = append(out, DecoderConfig{Name: name})
+		}
+		for _, id := range packageIDs {
+			if !slices.Contains(out[i].PackageIDs, id) {
+				out[i].PackageIDs = append(out[i].PackageIDs, id)
+			}
+		}
+	}
+
+	add(DecoderCIP56TransferEvent, c.CIP56PackageID)
+	if c.UtilityRegistryPackageID != "" {
+		add(DecoderUtilityRegistryOffer, c.UtilityRegistryPackageID)
+	}
+	if c.UtilityRegistryHoldingPackageID != "" {
+		add(DecoderUtilityRegistryHolding, c.UtilityRegistryHoldingPackageID)
+	}
+	for _, d := range c.Decoders {
+		add(d.Name, d.PackageIDs...)
+	}
+	return out
+}
+
+// BootstrapConfig controls ACS-snapshot bootstrap of a fresh indexer database.
+//
+// When enabled and no offset has been stored yet, the indexer reads the active
+// contracts of every enabled decoder that tracks holdings or transfers (e.g.
+// Holding, TransferOffer) at AtOffset, seeds balances, holdings, token supply
+// and pending transfers in one transaction, and streams from AtOffset onwards.
+// Ledger history before AtOffset is never read, so TokenTransferEvents (and the
+// CIP-56 balances and events derived from them) created before it are not
+// indexed — enable this only for deployments that track holding templates.
+type BootstrapConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// AtOffset is the ledger offset the snapshot is read at. Zero (the default)
+	// uses the ledger end at startup.
+	AtOffset int64 `yaml:"at_offset" validate:"gte=0"`
 }
 
 // InstrumentKey is the Canton equivalent of an ERC-20 contract address.
@@ -41,8 +149,8 @@ type Config struct {
 // a token with id="DEMO". The full {Admin, ID} pair IS unique and is the correct
 // key for whitelisting specific token deployments.
 type InstrumentKey struct {
-	Admin string `yaml:"admin"` // instrumentId.admin — the token admin/issuer party
-	ID    string `yaml:"id"`    // instrumentId.id   — the token identifier (e.g. "DEMO")
+	Admin string `yaml:"admin" json:"admin"` // instrumentId.admin — the token admin/issuer party
+	ID    string `yaml:"id" json:"id"`       // instrumentId.id   — the token identifier (e.g. "DEMO")
 }
 
 // FilterModeAndKeys converts the config into the domain FilterMode and instrument
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import "time"
+
+// DriftKind classifies a difference between the indexed state and the ledger.
+type DriftKind string
+
+const (
+	// DriftBalance is a party's indexed balance differing from the sum of its
+	// holdings on the ledger.
+	DriftBalance DriftKind = "balance"
+	// DriftSupply is a token's indexed total supply differing from the sum of
+	// all its holdings on the ledger.
+	DriftSupply DriftKind = "supply"
+)
+
+// Drift is a value the indexer holds that differs from the ledger's active
+// contract set at the same offset. PartyID is empty for supply drifts.
+// Repaired is set when the indexed balance was overwritten with the ledger's.
+type Drift struct {
+	ID              int64     `json:"id"`
+	Kind            DriftKind `json:"kind"`
+	PartyID         string    `json:"party_id,omitempty"`
+	InstrumentAdmin string    `json:"instrument_admin"`
+	InstrumentID    string    `json:"instrument_id"`
+	Indexed         string    `json:"indexed"`
+	Ledger          string    `json:"ledger"`
+	LedgerOffset    int64     `json:"ledger_offset"`
+	Repaired        bool      `json:"repaired"`
+	DetectedAt      time.Time `json:"detected_at"`
+}
+
+// DriftReport is the outcome of one drift check, run against the ledger at
+// LedgerOffset — the offset the indexer had reached when the check read its
+// state. Full checks compare every balance and total supply; others compare the
+// balances of the parties checked only.
+type DriftReport struct {
+	LedgerOffset   int64     `json:"ledger_offset"`
+	Full           bool      `json:"full"`
+	PartiesChecked int       `json:"parties_checked"`
+	TokensChecked  int       `json:"tokens_checked"`
+	CheckedAt      time.Time `json:"checked_at"`
+	Drifts         []Drift   `json:"drifts"`
+}
+
+// IndexedState is a consistent read of the indexed balances and tokens at
+// LedgerOffset.
+type IndexedState struct {
+	LedgerOffset int64
+	Balances     []*Balance
+	Tokens       []*Token
+}
@@ -3,35 +3,55 @@
 package engine
 
 import (
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/cip56"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/registry"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/daml/splice"
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
 	"github.com/chainsafe/canton-middleware/pkg/indexer"
 
 	"go.uber.org/zap"
 )
 
-const (
-	tokenTransferEventModule = "CIP56.Events"
-	tokenTransferEventEntity = "TokenTransferEvent"
+// CIP56 TokenTransferEvent, Utility.Registry TransferOffer and Holding are
+// decoded with the generated codecs in cantonsdk/daml; their identifiers come
+// from there too.
+var (
+	tokenTransferEventTemplate = cip56.TokenTransferEventTemplate
+	transferOfferTemplate      = registry.TransferOfferTemplate
+	holdingTemplate            = registry.HoldingTemplate
+)
 
+const (
 	// Metadata keys for bridge context stored in TokenTransferEvent.meta.values.
 	metaKeyExternalTxID    = "bridge.externalTxId"
 	metaKeyExternalAddress = "bridge.externalAddress"
 	metaKeyFingerprint     = "bridge.fingerprint"
 
-	transferOfferModule = "Utility.Registry.App.V0.Model.Transfer"
-	transferOfferEntity = "TransferOffer"
-
 	// Splice token-standard TransferInstruction choices that archive a
 	// TransferOffer. The choice name arrives on the consuming exercised event
 	// (LEDGER_EFFECTS stream shape) and decides the offer's terminal status:
 	// accept settles it ("completed"), withdraw is the sender claiming it back
 	// ("canceled"), reject is the receiver declining it ("rejected").
-	choiceInstructionAccept   = "TransferInstruction_Accept"
-	choiceInstructionWithdraw = "TransferInstruction_Withdraw"
-	choiceInstructionReject   = "TransferInstruction_Reject"
+	choiceInstructionAccept   = splice.ChoiceTransferInstructionAccept
+	choiceInstructionWithdraw = splice.ChoiceTransferInstructionWithdraw
+	choiceInstructionReject   = splice.ChoiceTransferInstructionReject
+
+	// Splice Amulet (Canton Coin). Amulet is the holding template; its
+	// instrument is identified by the DSO party and the fixed id "Amulet".
+	amuletModule       = "Splice.Amulet"
+	amuletEntity       = "Amulet"
+	amuletInstrumentID = "Amulet"
+
+	// Splice AmuletAllocation — one DvP leg (token-standard AllocationV1) funded
+	// by a LockedAmulet. The choice that archives it decides the leg's outcome:
+	// execute settles it, cancel (by the executor) and withdraw (by the sender)
+	// release the locked funds back to the sender.
+	amuletAllocationModule = "Splice.AmuletAllocation"
+	amuletAllocationEntity = "AmuletAllocation"
 
-	holdingModule = "Utility.Registry.Holding.V0.Holding"
-	holdingEntity = "Holding"
+	choiceAllocationExecute  = "Allocation_ExecuteTransfer"
+	choiceAllocationCancel   = "Allocation_Cancel"
+	choiceAllocationWithdraw = "Allocation_Withdraw"
 )
 
 // NewTokenTransferDecoder returns a decode function for use with streaming.NewStream.
@@ -40,8 +60,8 @@ const (
 //   - skips archived events
 //   - checks ModuleName == "CIP56.Events" && TemplateName == "TokenTransferEvent"
 //   - applies the FilterModeWhitelist instrument check when mode is FilterModeWhitelist
-//   - extracts all fields into a *ParsedEvent
-//   - returns nil, false for invalid events (both parties absent, filter miss)
+//   - decodes the create arguments with the generated cip56.TokenTransferEvent codec
+//   - returns nil, false for invalid events (undecodable, both parties absent, filter miss)
 func NewTokenTransferDecoder(
 	mode indexer.FilterMode,
 	allowed []indexer.InstrumentKey,
@@ -56,12 +76,22 @@ func NewTokenTransferDecoder(
 		if !ev.IsCreated {
 			return nil, false
 		}
-		if ev.ModuleName != tokenTransferEventModule || ev.TemplateName != tokenTransferEventEntity {
+		if ev.ModuleName != tokenTransferEventTemplate.Module || ev.TemplateName != tokenTransferEventTemplate.Entity {
+			return nil, false
+		}
+
+		var args cip56.TokenTransferEvent
+		if err := ev.Decode(&args); err != nil {
+			logger.Warn("dropping undecodable TokenTransferEvent",
+				zap.String("contract_id", ev.ContractID),
+				zap.String("tx_id", tx.UpdateID),
+				zap.Error(err),
+			)
 			return nil, false
 		}
 
-		instrumentID := ev.NestedTextField("instrumentId", "id")
-		instrumentAdmin := ev.NestedPartyField("instrumentId", "admin")
+		instrumentID := args.InstrumentID.ID
+		instrumentAdmin := args.InstrumentID.Admin
 		key := indexer.InstrumentKey{Admin: instrumentAdmin, ID: instrumentID}
 
 		if mode == indexer.FilterModeWhitelist {
@@ -75,36 +105,16 @@ func NewTokenTransferDecoder(
 			}
 		}
 
-		var fromPartyID *string
-		if !ev.IsNone("fromParty") {
-			v := ev.OptionalPartyField("fromParty")
-			if v != "" {
-				fromPartyID = &v
-			}
-		}
-
-		var toPartyID *string
-		if !ev.IsNone("toParty") {
-			v := ev.OptionalPartyField("toParty")
-			if v != "" {
-				toPartyID = &v
-			}
-		}
-
-		var externalTxID *string
-		if v := ev.OptionalMetaLookup("meta", metaKeyExternalTxID); v != "" {
-			externalTxID = &v
-		}
-
-		var externalAddress *string
-		if v := ev.OptionalMetaLookup("meta", metaKeyExternalAddress); v != "" {
-			externalAddress = &v
-		}
+		fromPartyID := nonEmpty(args.FromParty)
+		toPartyID := nonEmpty(args.ToParty)
 
-		var fingerprint *string
-		if v := ev.OptionalMetaLookup("meta", metaKeyFingerprint); v != "" {
-			fingerprint = &v
+		var meta map[string]string
+		if args.Meta != nil {
+			meta = args.Meta.Values
 		}
+		externalTxID := metaValue(meta, metaKeyExternalTxID)
+		externalAddress := metaValue(meta, metaKeyExternalAddress)
+		fingerprint := metaValue(meta, metaKeyFingerprint)
 
 		var et indexer.EventType
 		switch {
@@ -126,9 +136,9 @@ func NewTokenTransferDecoder(
 		return &indexer.ParsedEvent{
 			InstrumentID:    instrumentID,
 			InstrumentAdmin: instrumentAdmin,
-			Issuer:          ev.PartyField("issuer"),
+			Issuer:          args.Issuer,
 			EventType:       et,
-			Amount:          ev.NumericField("amount"),
+			Amount:          args.Amount,
 			FromPartyID:     fromPartyID,
 			ToPartyID:       toPartyID,
 			ExternalTxID:    externalTxID,
@@ -137,35 +147,46 @@ func NewTokenTransferDecoder(
 			ContractID:      ev.ContractID,
 			TxID:            tx.UpdateID,
 			LedgerOffset:    tx.Offset,
-			Timestamp:       ev.TimestampField("timestamp"),
+			Timestamp:       args.Timestamp,
 			EffectiveTime:   tx.EffectiveTime,
 		}, true
 	}
 }
 
+// nonEmpty returns p, or nil when p points at "".
+func nonEmpty(p *string) *string {
+	if p == nil || *p == "" {
+		return nil
+	}
+	return p
+}
+
+// metaValue returns a pointer to meta[key], or nil when the key is absent or empty.
+func metaValue(meta map[string]string, key string) *string {
+	if v := meta[key]; v != "" {
+		return &v
+	}
+	return nil
+}
+
 // NewOfferDecoder returns a decode function for TransferOffer CREATED and ARCHIVED
-// events, producing an *indexer.Transfer of Kind "offer". Returns nil, false when
-// packageID is empty (feature disabled).
+// events, producing an *indexer.Transfer of Kind "offer".
 //
 // On CREATED the transfer is "pending" with all fields populated; on ARCHIVED only
 // ContractID/LedgerOffset/CreatedAt, the Archived flag, and the terminal Status
 // (derived from the archiving choice) are set — the processor uses ContractID to
 // finalize the existing row.
 func NewOfferDecoder(
-	packageID string, metrics *Metrics, logger *zap.Logger,
+	metrics *Metrics, logger *zap.Logger,
 ) func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.Transfer, bool) {
-	if packageID == "" {
-		return func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.Transfer, bool) {
-			return nil, false
-		}
-	}
 	return func(tx *streaming.LedgerTransaction, ev *streaming.LedgerEvent) (*indexer.Transfer, bool) {
 		// Match by module+entity only. The stream-level filter (buildTemplateFilters)
 		// already narrowed the wire to this template; comparing ev.PackageID to a
 		// config-supplied value breaks when the config uses a package-name reference
 		// (#name) — Canton accepts those in filters but events arrive carrying the
 		// resolved package hash, so equality fails. Mirrors the CIP56 decoder.
-		if ev.ModuleName != transferOfferModule || ev.TemplateName != transferOfferEntity {
+		// Pinned package hashes are enforced by the Registry instead.
+		if ev.ModuleName != transferOfferTemplate.Module || ev.TemplateName != transferOfferTemplate.Entity {
 			return nil, false
 		}
 		transfer := &indexer.Transfer{
@@ -181,14 +202,23 @@ func NewOfferDecoder(
 		if ev.IsCreated {
 			// TransferOffer CreateArguments: {operator, provider, transfer{...}}.
 			// Receiver/sender/amount/instrumentId all live inside the nested transfer record.
+			var args registry.TransferOffer
+			if err := ev.Decode(&args); err != nil {
+				logger.Warn("dropping undecodable TransferOffer",
+					zap.String("contract_id", ev.ContractID),
+					zap.Int64("offset", tx.Offset),
+					zap.Error(err),
+				)
+				return nil, false
+			}
 			transfer.Status = indexer.TransferStatusPending
 			transfer.TxID = tx.UpdateID
-			transfer.ToPartyID = ev.NestedPartyField("transfer", "receiver")
-			transfer.FromPartyID = ev.NestedPartyField("transfer", "sender")
-			transfer.Amount = ev.NestedNumericField("transfer", "amount")
-			transfer.InstrumentAdmin = ev.DoublyNestedPartyField("transfer", "instrumentId", "admin")
-			transfer.InstrumentID = ev.DoublyNestedTextField("transfer", "instrumentId", "id")
-			if exp := ev.NestedTimestampField("transfer", "executeBefore"); !exp.IsZero() {
+			transfer.ToPartyID = args.Transfer.Receiver
+			transfer.FromPartyID = args.Transfer.Sender
+			transfer.Amount = args.Transfer.Amount
+			transfer.InstrumentAdmin = args.Transfer.InstrumentID.Admin
+			transfer.InstrumentID = args.Transfer.InstrumentID.ID
+			if exp := args.Transfer.ExecuteBefore; !exp.IsZero() {
 				transfer.ExpiresAt = &exp
 			}
 			if transfer.ToPartyID == "" {
@@ -232,8 +262,7 @@ func offerTerminalStatus(choice, contractID string, metrics *Metrics, logger *za
 }
 
 // NewHoldingDecoder returns a decode function for Utility.Registry.Holding.V0.Holding
-// CREATED and ARCHIVED events. Returns nil, false when packageID is empty (feature
-// disabled). Used so the indexer can maintain indexer_balances for Utility.Registry
+// CREATED and ARCHIVED events. Used so the indexer can maintain indexer_balances for Utility.Registry
 // instruments (e.g. USDCx) which do not emit a separate TokenTransferEvent contract.
 //
 // The Holding template's create_arguments are {operator, provider, registrar, owner,
@@ -242,16 +271,11 @@ func offerTerminalStatus(choice, contractID string, metrics *Metrics, logger *za
 // decoder mirrors that mapping so balances keyed by (admin, id) line up with the
 // per-instrument balance table.
 func NewHoldingDecoder(
-	packageID string, logger *zap.Logger,
+	logger *zap.Logger,
 ) func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.HoldingChange, bool) {
-	if packageID == "" {
-		return func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.HoldingChange, bool) {
-			return nil, false
-		}
-	}
 	return func(tx *streaming.LedgerTransaction, ev *streaming.LedgerEvent) (*indexer.HoldingChange, bool) {
 		// Match by module+entity only (see NewOfferDecoder comment).
-		if ev.ModuleName != holdingModule || ev.TemplateName != holdingEntity {
+		if ev.ModuleName != holdingTemplate.Module || ev.TemplateName != holdingTemplate.Entity {
 			return nil, false
 		}
 		change := &indexer.HoldingChange{
@@ -260,13 +284,22 @@ func NewHoldingDecoder(
 			LedgerOffset: tx.Offset,
 		}
 		if ev.IsCreated {
-			change.Owner = ev.PartyField("owner")
-			change.InstrumentAdmin = ev.PartyField("registrar")
-			change.InstrumentID = ev.NestedTextField("instrument", "id")
-			change.Amount = ev.NumericField("amount")
+			var args registry.Holding
+			if err := ev.Decode(&args); err != nil {
+				logger.Warn("dropping undecodable Holding",
+					zap.String("contract_id", ev.ContractID),
+					zap.Int64("offset", tx.Offset),
+					zap.Error(err),
+				)
+				return nil, false
+			}
+			change.Owner = args.Owner
+			change.InstrumentAdmin = args.Registrar
+			change.InstrumentID = args.Instrument.ID
+			change.Amount = args.Amount
 			// `lock` is Optional Lock: Some => the holding is escrowed by an
 			// outstanding offer (not spendable), None => freely spendable.
-			change.Locked = !ev.IsNone("lock")
+			change.Locked = args.Lock != nil
 			if change.Owner == "" || change.InstrumentID == "" {
 				logger.Warn("Holding CREATED decoded with empty owner or instrument — field-name mismatch?",
 					zap.String("contract_id", ev.ContractID),
@@ -278,25 +311,113 @@ func NewHoldingDecoder(
 	}
 }
 
-// NewMultiDecoder wraps the TokenTransfer, Offer, and Holding decoders into a single
-// any-typed decode function. The Holding decoder is only consulted when both prior
-// decoders miss — TokenTransferEvents and TransferOffers never collide with Holding
-// templates, so the order is purely a fast-path optimization for the common case.
-func NewMultiDecoder(
-	transferDecode func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.ParsedEvent, bool),
-	offerDecode func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.Transfer, bool),
-	holdingDecode func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.HoldingChange, bool),
-) func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (any, bool) {
-	return func(tx *streaming.LedgerTransaction, ev *streaming.LedgerEvent) (any, bool) {
-		if item, ok := transferDecode(tx, ev); ok {
-			return item, true
+// NewAmuletDecoder returns a decode function for Splice.Amulet.Amulet CREATED and
+// ARCHIVED events, producing *indexer.HoldingChange values that the processor
+// applies exactly like Utility.Registry holdings.
+//
+// Amulet create_arguments are {dso, owner, amount{initialAmount, createdAt,
+// ratePerRound}}. Like the Splice HoldingV1 view, the decoder reports
+// initialAmount as the holding amount — holding fees accrued since createdAt are
+// only realized when the amulet is spent — and keys the instrument as
+// (dso, "Amulet"). Locked amulets are a separate template (LockedAmulet) that is
+// deliberately not indexed, matching how locked Utility.Registry holdings are
+// excluded from balances.
+func NewAmuletDecoder(
+	logger *zap.Logger,
+) func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.HoldingChange, bool) {
+	return func(tx *streaming.LedgerTransaction, ev *streaming.LedgerEvent) (*indexer.HoldingChange, bool) {
+		if ev.ModuleName != amuletModule || ev.TemplateName != amuletEntity {
+			return nil, false
+		}
+		change := &indexer.HoldingChange{
+			ContractID:   ev.ContractID,
+			IsArchived:   !ev.IsCreated,
+			LedgerOffset: tx.Offset,
 		}
-		if item, ok := offerDecode(tx, ev); ok {
-			return item, true
+		if ev.IsCreated {
+			change.Owner = ev.PartyField("owner")
+			change.InstrumentAdmin = ev.PartyField("dso")
+			change.InstrumentID = amuletInstrumentID
+			change.Amount = ev.NestedNumericField("amount", "initialAmount")
+			if change.Owner == "" || change.InstrumentAdmin == "" {
+				logger.Warn("Amulet CREATED decoded with empty owner or dso — field-name mismatch?",
+					zap.String("contract_id", ev.ContractID),
+					zap.Int64("offset", tx.Offset),
+				)
+			}
 		}
-		if item, ok := holdingDecode(tx, ev); ok {
-			return item, true
+		return change, true
+	}
+}
+
+// NewAmuletAllocationDecoder returns a decode function for
+// Splice.AmuletAllocation.AmuletAllocation CREATED and ARCHIVED events,
+// producing an *indexer.Transfer of Kind "allocation" per DvP leg.
+//
+// On CREATED the leg is "pending" with the parties, amount and instrument of
+// allocation.transferLeg and ExpiresAt set from allocation.settlement.settleBefore;
+// on ARCHIVED only ContractID/LedgerOffset/CreatedAt, the Archived flag and the
+// terminal Status derived from the archiving choice are set, as for offers.
+func NewAmuletAllocationDecoder(
+	metrics *Metrics, logger *zap.Logger,
+) func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*indexer.Transfer, bool) {
+	return func(tx *streaming.LedgerTransaction, ev *streaming.LedgerEvent) (*indexer.Transfer, bool) {
+		if ev.ModuleName != amuletAllocationModule || ev.TemplateName != amuletAllocationEntity {
+			return nil, false
 		}
-		return nil, false
+		transfer := &indexer.Transfer{
+			ContractID:   ev.ContractID,
+			Kind:         indexer.TransferKindAllocation,
+			Archived:     !ev.IsCreated,
+			LedgerOffset: tx.Offset,
+			CreatedAt:    tx.EffectiveTime,
+		}
+		if !ev.IsCreated {
+			transfer.Status = allocationTerminalStatus(ev.Choice, ev.ContractID, metrics, logger)
+			return transfer, true
+		}
+		// AmuletAllocation CreateArguments: {lockedAmulet, allocation{settlement{...},
+		// transferLegId, transferLeg{sender, receiver, amount, instrumentId, meta}}}.
+		leg := ev.Record("allocation", "transferLeg")
+		transfer.Status = indexer.TransferStatusPending
+		transfer.TxID = tx.UpdateID
+		transfer.FromPartyID = leg.PartyField("sender")
+		transfer.ToPartyID = leg.PartyField("receiver")
+		transfer.Amount = leg.NumericField("amount")
+		transfer.InstrumentAdmin = leg.NestedPartyField("instrumentId", "admin")
+		transfer.InstrumentID = leg.NestedTextField("instrumentId", "id")
+		if exp := ev.Record("allocation", "settlement").TimestampField("settleBefore"); !exp.IsZero() {
+			transfer.ExpiresAt = &exp
+		}
+		if transfer.FromPartyID == "" || transfer.ToPartyID == "" {
+			logger.Warn("AmuletAllocation CREATED decoded with empty sender or receiver — field name mismatch?",
+				zap.String("contract_id", ev.ContractID),
+				zap.Int64("offset", tx.Offset),
+			)
+		}
+		return transfer, true
+	}
+}
+
+// allocationTerminalStatus maps the choice that archived an AmuletAllocation to
+// the leg's terminal status: execute settles it ("completed"), cancel and
+// withdraw release the funds to the sender ("canceled"). Unknown choices fall
+// back to "completed" like offerTerminalStatus, counted by
+// AllocationUnknownArchiveChoices.
+func allocationTerminalStatus(choice, contractID string, metrics *Metrics, logger *zap.Logger) string {
+	switch choice {
+	case choiceAllocationExecute:
+		return indexer.TransferStatusCompleted
+	case choiceAllocationCancel, choiceAllocationWithdraw:
+		return indexer.TransferStatusCanceled
+	default:
+		if metrics != nil {
+			metrics.AllocationUnknownArchiveChoices.Inc()
+		}
+		logger.Warn("AmuletAllocation archived by unrecognized choice — defaulting to completed",
+			zap.String("choice", choice),
+			zap.String("contract_id", contractID),
+		)
+		return indexer.TransferStatusCompleted
 	}
 }
@@ -47,7 +47,7 @@ func makeTransferEvent(contractID string, fromParty, toParty streaming.FieldValu
 		"meta":      streaming.MakeNoneField(),
 	}
 	maps.Copy(fields, extra)
-	return streaming.NewLedgerEvent(contractID, "pkg-id", tokenTransferEventModule, tokenTransferEventEntity, true, fields)
+	return streaming.NewLedgerEvent(contractID, "pkg-id", tokenTransferEventTemplate.Module, tokenTransferEventTemplate.Entity, true, fields)
 }
 
 func makeTx(offset int64, events ...*streaming.LedgerEvent) *streaming.LedgerTransaction {
@@ -75,7 +75,7 @@ func decodeAll(decode func(*streaming.LedgerTransaction, *streaming.LedgerEvent)
 // ---------------------------------------------------------------------------
 
 func makeHoldingEvent(contractID string, lock streaming.FieldValue) *streaming.LedgerEvent {
-	return streaming.NewLedgerEvent(contractID, "pkg-id", holdingModule, holdingEntity, true,
+	return streaming.NewLedgerEvent(contractID, "pkg-id", holdingTemplate.Module, holdingTemplate.Entity, true,
 		map[string]streaming.FieldValue{
 			"owner":      streaming.MakePartyField("alice::1220"),
 			"registrar":  streaming.MakePartyField("admin::1220"),
@@ -88,13 +88,13 @@ func makeHoldingEvent(contractID string, lock streaming.FieldValue) *streaming.L
 // makeOfferArchiveEvent builds a TransferOffer archive event as delivered by the
 // LEDGER_EFFECTS stream: no create arguments, just the consuming choice name.
 func makeOfferArchiveEvent(contractID, choice string) *streaming.LedgerEvent {
-	ev := streaming.NewLedgerEvent(contractID, "pkg-id", transferOfferModule, transferOfferEntity, false, nil)
+	ev := streaming.NewLedgerEvent(contractID, "pkg-id", transferOfferTemplate.Module, transferOfferTemplate.Entity, false, nil)
 	ev.Choice = choice
 	return ev
 }
 
 func TestOfferDecoder_ArchiveChoiceMapsTerminalStatus(t *testing.T) {
-	dec := NewOfferDecoder("pkg-id", NewNopMetrics(), zap.NewNop())
+	dec := NewOfferDecoder(NewNopMetrics(), zap.NewNop())
 
 	cases := []struct {
 		choice string
@@ -115,9 +115,9 @@ func TestOfferDecoder_ArchiveChoiceMapsTerminalStatus(t *testing.T) {
 }
 
 func TestOfferDecoder_CreateCarriesLedgerTxID(t *testing.T) {
-	dec := NewOfferDecoder("pkg-id", NewNopMetrics(), zap.NewNop())
+	dec := NewOfferDecoder(NewNopMetrics(), zap.NewNop())
 
-	ev := streaming.NewLedgerEvent("offer-1", "pkg-id", transferOfferModule, transferOfferEntity, true,
+	ev := streaming.NewLedgerEvent("offer-1", "pkg-id", transferOfferTemplate.Module, transferOfferTemplate.Entity, true,
 		map[string]streaming.FieldValue{
 			"transfer": streaming.MakeRecordField(map[string]streaming.FieldValue{
 				"sender":   streaming.MakePartyField(testSender),
@@ -139,7 +139,7 @@ func TestOfferDecoder_CreateCarriesLedgerTxID(t *testing.T) {
 }
 
 func TestHoldingDecoder_LockField(t *testing.T) {
-	dec := NewHoldingDecoder("pkg-id", zap.NewNop())
+	dec := NewHoldingDecoder(zap.NewNop())
 
 	unlocked, ok := dec(makeTx(1), makeHoldingEvent("h-unlocked", streaming.MakeNoneField()))
 	require.True(t, ok)
@@ -150,6 +150,92 @@ func TestHoldingDecoder_LockField(t *testing.T) {
 	assert.True(t, locked.Locked, "Some lock => escrowed")
 }
 
+func TestAmuletDecoder_CreateAndArchive(t *testing.T) {
+	dec := NewAmuletDecoder(zap.NewNop())
+
+	ev := streaming.NewLedgerEvent("amulet-1", "pkg-id", amuletModule, amuletEntity, true,
+		map[string]streaming.FieldValue{
+			"dso":   streaming.MakePartyField("dso::1220"),
+			"owner": streaming.MakePartyField("alice::1220"),
+			"amount": streaming.MakeRecordField(map[string]streaming.FieldValue{
+				"initialAmount": streaming.MakeNumericField("12.5"),
+			}),
+		})
+	created, ok := dec(makeTx(1), ev)
+	require.True(t, ok)
+	assert.Equal(t, &indexer.HoldingChange{
+		ContractID:      "amulet-1",
+		LedgerOffset:    1,
+		Owner:           "alice::1220",
+		InstrumentAdmin: "dso::1220",
+		InstrumentID:    amuletInstrumentID,
+		Amount:          "12.5",
+	}, created)
+
+	archived, ok := dec(makeTx(2), streaming.NewLedgerEvent("amulet-1", "pkg-id", amuletModule, amuletEntity, false, nil))
+	require.True(t, ok)
+	assert.True(t, archived.IsArchived)
+	assert.Empty(t, archived.Owner)
+}
+
+func TestAmuletAllocationDecoder_Create(t *testing.T) {
+	dec := NewAmuletAllocationDecoder(NewNopMetrics(), zap.NewNop())
+	settleBefore := time.Unix(1_700_003_600, 0).UTC()
+
+	ev := streaming.NewLedgerEvent("alloc-1", "pkg-id", amuletAllocationModule, amuletAllocationEntity, true,
+		map[string]streaming.FieldValue{
+			"allocation": streaming.MakeRecordField(map[string]streaming.FieldValue{
+				"settlement": streaming.MakeRecordField(map[string]streaming.FieldValue{
+					"settleBefore": streaming.MakeTimestampField(settleBefore),
+				}),
+				"transferLegId": streaming.MakeTextField("leg-0"),
+				"transferLeg": streaming.MakeRecordField(map[string]streaming.FieldValue{
+					"sender":   streaming.MakePartyField(testSender),
+					"receiver": streaming.MakePartyField(testRecipient),
+					"amount":   streaming.MakeNumericField(testAmount),
+					"instrumentId": streaming.MakeRecordField(map[string]streaming.FieldValue{
+						"admin": streaming.MakePartyField("dso::1220"),
+						"id":    streaming.MakeTextField(amuletInstrumentID),
+					}),
+				}),
+			}),
+		})
+
+	tr, ok := dec(makeTx(1), ev)
+	require.True(t, ok)
+	assert.Equal(t, indexer.TransferKindAllocation, tr.Kind)
+	assert.Equal(t, indexer.TransferStatusPending, tr.Status)
+	assert.Equal(t, testSender, tr.FromPartyID)
+	assert.Equal(t, testRecipient, tr.ToPartyID)
+	assert.Equal(t, testAmount, tr.Amount)
+	assert.Equal(t, "dso::1220", tr.InstrumentAdmin)
+	assert.Equal(t, amuletInstrumentID, tr.InstrumentID)
+	require.NotNil(t, tr.ExpiresAt)
+	assert.True(t, settleBefore.Equal(*tr.ExpiresAt))
+}
+
+func TestAmuletAllocationDecoder_ArchiveChoiceMapsTerminalStatus(t *testing.T) {
+	dec := NewAmuletAllocationDecoder(NewNopMetrics(), zap.NewNop())
+
+	cases := []struct {
+		choice string
+		want   string
+	}{
+		{choiceAllocationExecute, indexer.TransferStatusCompleted},
+		{choiceAllocationCancel, indexer.TransferStatusCanceled},
+		{choiceAllocationWithdraw, indexer.TransferStatusCanceled},
+		{"Archive", indexer.TransferStatusCompleted}, // unknown choice → fallback
+	}
+	for _, tc := range cases {
+		ev := streaming.NewLedgerEvent("alloc-1", "pkg-id", amuletAllocationModule, amuletAllocationEntity, false, nil)
+		ev.Choice = tc.choice
+		tr, ok := dec(makeTx(2), ev)
+		require.True(t, ok, "choice %q", tc.choice)
+		assert.True(t, tr.Archived, "choice %q", tc.choice)
+		assert.Equal(t, tc.want, tr.Status, "choice %q", tc.choice)
+	}
+}
+
 func TestDecoder_FilterModeAll_Mint(t *testing.T) {
 	decode := NewTokenTransferDecoder(indexer.FilterModeAll, nil, zap.NewNop())
 
@@ -209,7 +295,7 @@ func TestDecoder_BothPartiesAbsent_Dropped(t *testing.T) {
 func TestDecoder_SkipsArchivedEvent(t *testing.T) {
 	decode := NewTokenTransferDecoder(indexer.FilterModeAll, nil, zap.NewNop())
 
-	ev := streaming.NewLedgerEvent(testContractID, "pkg-id", tokenTransferEventModule, tokenTransferEventEntity, false, nil)
+	ev := streaming.NewLedgerEvent(testContractID, "pkg-id", tokenTransferEventTemplate.Module, tokenTransferEventTemplate.Entity, false, nil)
 	got := decodeAll(decode, makeTx(5, ev))
 
 	assert.Empty(t, got)
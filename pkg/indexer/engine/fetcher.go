@@ -17,15 +17,15 @@ import (
 //
 // Typical usage:
 //
-//	decode := engine.NewMultiDecoder(transferDecode, offerDecode)
-//	f := engine.NewFetcher(streamClient, templateIDs, decode, logger)
+//	registry, _ := engine.NewConfiguredRegistry(cfg, metrics, logger)
+//	f := engine.NewFetcher(streamClient, registry.TemplateIDs(), registry.Decode, logger)
 //	f.Start(ctx, lastProcessedOffset)
 //	for batch := range f.Events() { ... }
 type Fetcher struct {
 	stream      *streaming.Stream[any]
 	templateIDs []streaming.TemplateID
+	mu          sync.Mutex
 	out         <-chan *streaming.Batch[any]
-	once        sync.Once
 	logger      *zap.Logger
 }
 
@@ -33,7 +33,7 @@ type Fetcher struct {
 //
 //   - streamer:     Canton streaming client (handles reconnection, auth, backoff)
 //   - templateIDs:  DAML templates to subscribe to
-//   - decode:       per-event decode function (see NewMultiDecoder)
+//   - decode:       per-event decode function (see Registry.Decode)
 //   - logger:       caller-provided logger
 func NewFetcher(
 	streamer streaming.Streamer,
@@ -51,26 +51,33 @@ func NewFetcher(
 // Start begins streaming from offset. It is non-blocking; the underlying goroutine
 // exits when ctx is canceled or the stream closes.
 //
-// Start must be called exactly once before Events is used. Subsequent calls are no-ops.
+// Start must be called before Events is used. Calling it again, once the ctx of
+// the previous call is canceled, opens a new stream from the given offset and
+// replaces the channel returned by Events; the processor does this to resume
+// after a rewind.
 func (f *Fetcher) Start(ctx context.Context, offset int64) {
-	f.once.Do(func() {
-		f.logger.Info("fetcher starting", zap.Int64("resume_offset", offset))
+	f.logger.Info("fetcher starting", zap.Int64("resume_offset", offset))
 
-		// lastOffset is updated atomically by the streaming.Client goroutine as
-		// transactions arrive, and read back by its reconnect loop on each new
-		// connection attempt, ensuring exactly-once resumption from the right point.
-		var lastOffset int64
-		atomic.StoreInt64(&lastOffset, offset)
+	// lastOffset is updated atomically by the streaming.Client goroutine as
+	// transactions arrive, and read back by its reconnect loop on each new
+	// connection attempt, ensuring exactly-once resumption from the right point.
+	var lastOffset int64
+	atomic.StoreInt64(&lastOffset, offset)
 
-		f.out = f.stream.Subscribe(ctx, streaming.SubscribeRequest{
-			FromOffset:  offset,
-			TemplateIDs: f.templateIDs,
-		}, &lastOffset)
-	})
+	out := f.stream.Subscribe(ctx, streaming.SubscribeRequest{
+		FromOffset:  offset,
+		TemplateIDs: f.templateIDs,
+	}, &lastOffset)
+
+	f.mu.Lock()
+	f.out = out
+	f.mu.Unlock()
 }
 
-// Events returns the read-only channel of decoded batches.
+// Events returns the read-only channel of decoded batches of the latest Start.
 // Must be called after Start. The channel is closed when the stream terminates.
 func (f *Fetcher) Events() <-chan *streaming.Batch[any] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	return f.out
 }
@@ -27,6 +27,11 @@ type Metrics struct {
 	// offers may be mislabeled — alert on it and extend the decoder's mapping.
 	OfferUnknownArchiveChoices prometheus.Counter
 
+	// AllocationUnknownArchiveChoices counts AmuletAllocation archives whose
+	// consuming choice was not one of the recognized Allocation choices. Like
+	// OfferUnknownArchiveChoices, each one falls back to "completed".
+	AllocationUnknownArchiveChoices prometheus.Counter
+
 	// ── Sync state ───────────────────────────────────────────────────────────
 
 	// SyncLagSeconds reports how far behind real-time the indexer is, measured
@@ -63,6 +68,12 @@ func NewMetrics(reg sharedmetrics.NamespacedRegisterer) *Metrics {
 			Help: "TransferOffer archives via an unrecognized choice (fell back to completed status)",
 		}),
 
+		AllocationUnknownArchiveChoices: f.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "allocation_unknown_archive_choices_total",
+			Help: "AmuletAllocation archives via an unrecognized choice (fell back to completed status)",
+		}),
+
 		SyncLagSeconds: f.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns, Subsystem: sub,
 			Name: "sync_lag_seconds",
@@ -9,6 +9,8 @@ import (
 	engine "github.com/chainsafe/canton-middleware/pkg/indexer/engine"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -123,17 +125,64 @@ func (_c *Store_ApplySupplyDelta_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
-// FinalizeTransfer provides a mock function with given fields: ctx, contractID, status
-func (_m *Store) FinalizeTransfer(ctx context.Context, contractID string, status string) error {
-	ret := _m.Called(ctx, contractID, status)
+// EnqueueWebhooks provides a mock function with given fields: ctx, offset
+func (_m *Store) EnqueueWebhooks(ctx context.Context, offset int64) error {
+	ret := _m.Called(ctx, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueWebhooks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, offset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_EnqueueWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueWebhooks'
+type Store_EnqueueWebhooks_Call struct {
+	*mock.Call
+}
+
+// EnqueueWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - offset int64
+func (_e *Store_Expecter) EnqueueWebhooks(ctx interface{}, offset interface{}) *Store_EnqueueWebhooks_Call {
+	return &Store_EnqueueWebhooks_Call{Call: _e.mock.On("EnqueueWebhooks", ctx, offset)}
+}
+
+func (_c *Store_EnqueueWebhooks_Call) Run(run func(ctx context.Context, offset int64)) *Store_EnqueueWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Store_EnqueueWebhooks_Call) Return(_a0 error) *Store_EnqueueWebhooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_EnqueueWebhooks_Call) RunAndReturn(run func(context.Context, int64) error) *Store_EnqueueWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinalizeTransfer provides a mock function with given fields: ctx, contractID, status, offset
+func (_m *Store) FinalizeTransfer(ctx context.Context, contractID string, status string, offset int64) error {
+	ret := _m.Called(ctx, contractID, status, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FinalizeTransfer")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, contractID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
+		r0 = rf(ctx, contractID, status, offset)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -150,13 +199,14 @@ type Store_FinalizeTransfer_Call struct {
 //   - ctx context.Context
 //   - contractID string
 //   - status string
-func (_e *Store_Expecter) FinalizeTransfer(ctx interface{}, contractID interface{}, status interface{}) *Store_FinalizeTransfer_Call {
-	return &Store_FinalizeTransfer_Call{Call: _e.mock.On("FinalizeTransfer", ctx, contractID, status)}
+//   - offset int64
+func (_e *Store_Expecter) FinalizeTransfer(ctx interface{}, contractID interface{}, status interface{}, offset interface{}) *Store_FinalizeTransfer_Call {
+	return &Store_FinalizeTransfer_Call{Call: _e.mock.On("FinalizeTransfer", ctx, contractID, status, offset)}
 }
 
-func (_c *Store_FinalizeTransfer_Call) Run(run func(ctx context.Context, contractID string, status string)) *Store_FinalizeTransfer_Call {
+func (_c *Store_FinalizeTransfer_Call) Run(run func(ctx context.Context, contractID string, status string, offset int64)) *Store_FinalizeTransfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
 	})
 	return _c
 }
@@ -166,7 +216,7 @@ func (_c *Store_FinalizeTransfer_Call) Return(_a0 error) *Store_FinalizeTransfer
 	return _c
 }
 
-func (_c *Store_FinalizeTransfer_Call) RunAndReturn(run func(context.Context, string, string) error) *Store_FinalizeTransfer_Call {
+func (_c *Store_FinalizeTransfer_Call) RunAndReturn(run func(context.Context, string, string, int64) error) *Store_FinalizeTransfer_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -378,6 +428,101 @@ func (_c *Store_LatestOffset_Call) RunAndReturn(run func(context.Context) (int64
 	return _c
 }
 
+// RecordHistory provides a mock function with given fields: ctx, offset, effectiveTime
+func (_m *Store) RecordHistory(ctx context.Context, offset int64, effectiveTime time.Time) error {
+	ret := _m.Called(ctx, offset, effectiveTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, offset, effectiveTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_RecordHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordHistory'
+type Store_RecordHistory_Call struct {
+	*mock.Call
+}
+
+// RecordHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - offset int64
+//   - effectiveTime time.Time
+func (_e *Store_Expecter) RecordHistory(ctx interface{}, offset interface{}, effectiveTime interface{}) *Store_RecordHistory_Call {
+	return &Store_RecordHistory_Call{Call: _e.mock.On("RecordHistory", ctx, offset, effectiveTime)}
+}
+
+func (_c *Store_RecordHistory_Call) Run(run func(ctx context.Context, offset int64, effectiveTime time.Time)) *Store_RecordHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Store_RecordHistory_Call) Return(_a0 error) *Store_RecordHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_RecordHistory_Call) RunAndReturn(run func(context.Context, int64, time.Time) error) *Store_RecordHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordStats provides a mock function with given fields: ctx, effectiveTime
+func (_m *Store) RecordStats(ctx context.Context, effectiveTime time.Time) error {
+	ret := _m.Called(ctx, effectiveTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, effectiveTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store_RecordStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordStats'
+type Store_RecordStats_Call struct {
+	*mock.Call
+}
+
+// RecordStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - effectiveTime time.Time
+func (_e *Store_Expecter) RecordStats(ctx interface{}, effectiveTime interface{}) *Store_RecordStats_Call {
+	return &Store_RecordStats_Call{Call: _e.mock.On("RecordStats", ctx, effectiveTime)}
+}
+
+func (_c *Store_RecordStats_Call) Run(run func(ctx context.Context, effectiveTime time.Time)) *Store_RecordStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Store_RecordStats_Call) Return(_a0 error) *Store_RecordStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Store_RecordStats_Call) RunAndReturn(run func(context.Context, time.Time) error) *Store_RecordStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RunInTx provides a mock function with given fields: ctx, fn
 func (_m *Store) RunInTx(ctx context.Context, fn func(context.Context, engine.Store) error) error {
 	ret := _m.Called(ctx, fn)
@@ -472,9 +617,9 @@ func (_c *Store_SaveOffset_Call) RunAndReturn(run func(context.Context, int64) e
 	return _c
 }
 
-// TakeHolding provides a mock function with given fields: ctx, contractID
-func (_m *Store) TakeHolding(ctx context.Context, contractID string) (indexer.HoldingChange, bool, error) {
-	ret := _m.Called(ctx, contractID)
+// TakeHolding provides a mock function with given fields: ctx, contractID, offset
+func (_m *Store) TakeHolding(ctx context.Context, contractID string, offset int64) (indexer.HoldingChange, bool, error) {
+	ret := _m.Called(ctx, contractID, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TakeHolding")
@@ -483,23 +628,23 @@ func (_m *Store) TakeHolding(ctx context.Context, contractID string) (indexer.Ho
 	var r0 indexer.HoldingChange
 	var r1 bool
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (indexer.HoldingChange, bool, error)); ok {
-		return rf(ctx, contractID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (indexer.HoldingChange, bool, error)); ok {
+		return rf(ctx, contractID, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) indexer.HoldingChange); ok {
-		r0 = rf(ctx, contractID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) indexer.HoldingChange); ok {
+		r0 = rf(ctx, contractID, offset)
 	} else {
 		r0 = ret.Get(0).(indexer.HoldingChange)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
-		r1 = rf(ctx, contractID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) bool); ok {
+		r1 = rf(ctx, contractID, offset)
 	} else {
 		r1 = ret.Get(1).(bool)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, contractID)
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64) error); ok {
+		r2 = rf(ctx, contractID, offset)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -515,13 +660,14 @@ type Store_TakeHolding_Call struct {
 // TakeHolding is a helper method to define mock.On call
 //   - ctx context.Context
 //   - contractID string
-func (_e *Store_Expecter) TakeHolding(ctx interface{}, contractID interface{}) *Store_TakeHolding_Call {
-	return &Store_TakeHolding_Call{Call: _e.mock.On("TakeHolding", ctx, contractID)}
+//   - offset int64
+func (_e *Store_Expecter) TakeHolding(ctx interface{}, contractID interface{}, offset interface{}) *Store_TakeHolding_Call {
+	return &Store_TakeHolding_Call{Call: _e.mock.On("TakeHolding", ctx, contractID, offset)}
 }
 
-func (_c *Store_TakeHolding_Call) Run(run func(ctx context.Context, contractID string)) *Store_TakeHolding_Call {
+func (_c *Store_TakeHolding_Call) Run(run func(ctx context.Context, contractID string, offset int64)) *Store_TakeHolding_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
 	})
 	return _c
 }
@@ -531,7 +677,7 @@ func (_c *Store_TakeHolding_Call) Return(h indexer.HoldingChange, ok bool, err e
 	return _c
 }
 
-func (_c *Store_TakeHolding_Call) RunAndReturn(run func(context.Context, string) (indexer.HoldingChange, bool, error)) *Store_TakeHolding_Call {
+func (_c *Store_TakeHolding_Call) RunAndReturn(run func(context.Context, string, int64) (indexer.HoldingChange, bool, error)) *Store_TakeHolding_Call {
 	_c.Call.Return(run)
 	return _c
 }
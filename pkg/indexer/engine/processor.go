@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
@@ -19,6 +20,9 @@ import (
 // incomplete cross-participant history from actual store errors.
 var ErrNegativeBalance = errors.New("negative balance")
 
+// ErrNotRunning is returned by Processor.Pause when Run is not looping.
+var ErrNotRunning = errors.New("indexer processor is not running")
+
 var (
 	processorRetryBaseDelay = 5 * time.Second
 	processorRetryMaxDelay  = 60 * time.Second
@@ -29,10 +33,11 @@ var (
 //go:generate mockery --name EventFetcher --output mocks --outpkg mocks --filename mock_event_fetcher.go --with-expecter
 type EventFetcher interface {
 	// Start begins streaming from offset in a background goroutine.
-	// Must be called exactly once before Events is used.
+	// Must be called before Events is used. The processor calls it again, with a
+	// fresh ctx, after canceling the previous one to resume from a new offset.
 	Start(ctx context.Context, offset int64)
 
-	// Events returns the read-only channel of decoded batches.
+	// Events returns the read-only channel of decoded batches of the latest Start.
 	// The channel is closed when the stream terminates.
 	Events() <-chan *streaming.Batch[any]
 }
@@ -62,6 +67,23 @@ type Store interface {
 	// mutate any derived state a second time.
 	InsertEvent(ctx context.Context, event *indexer.ParsedEvent) (inserted bool, err error)
 
+	// RecordHistory appends a point-in-time row at offset for every balance and
+	// token supply changed earlier in the same transaction, stamped with the
+	// transaction's effective time. A no-op when nothing changed.
+	RecordHistory(ctx context.Context, offset int64, effectiveTime time.Time) error
+
+	// RecordStats adds the token activity of the same transaction — settled
+	// transfers, mints, burns, active parties and new holders — to the hourly
+	// and daily rollups of the buckets containing effectiveTime. A no-op when
+	// nothing changed.
+	RecordStats(ctx context.Context, effectiveTime time.Time) error
+
+	// EnqueueWebhooks writes a webhook delivery to the outbox for every
+	// registered endpoint matching an event indexed or a transfer status changed
+	// earlier in the same transaction, stamped with offset. A no-op when nothing
+	// changed or no endpoint matches.
+	EnqueueWebhooks(ctx context.Context, offset int64) error
+
 	// SaveOffset advances the stored ledger offset after all newly inserted events in
 	// the transaction have updated derived state. It must be safe to call even when the
 	// batch was empty or every event was already present.
@@ -89,21 +111,21 @@ type Store interface {
 	InsertTransfer(ctx context.Context, t *indexer.Transfer) error
 
 	// FinalizeTransfer transitions a still-pending transfer to the given terminal
-	// status ("completed" on accept, "canceled" on withdraw/reject) when the Canton
-	// ledger archives the offer contract. Rows already finalized are left untouched,
-	// so replays are no-ops. The row is kept for history; no-op when not found.
-	FinalizeTransfer(ctx context.Context, contractID, status string) error
+	// status ("completed" on accept, "canceled" on withdraw/reject) at offset, when
+	// the Canton ledger archives the offer contract. Rows already finalized are left
+	// untouched, so replays are no-ops. The row is kept for history; no-op when not found.
+	FinalizeTransfer(ctx context.Context, contractID, status string, offset int64) error
 
 	// InsertHolding records an active Utility.Registry.Holding contract so its amount
 	// and owner can be recovered when the contract is later archived (archive events
 	// carry only the contract ID). Idempotent on ContractID.
 	InsertHolding(ctx context.Context, h *indexer.HoldingChange) error
 
-	// TakeHolding deletes the row for contractID and returns the stored owner/
-	// instrument/amount needed to decrement the matching balance on archive.
-	// Returns ok=false on missing rows so replayed ARCHIVED events become no-ops
-	// instead of errors.
-	TakeHolding(ctx context.Context, contractID string) (h indexer.HoldingChange, ok bool, err error)
+	// TakeHolding marks the active row for contractID archived at offset and returns
+	// the stored owner/instrument/amount needed to decrement the matching balance on
+	// archive. Returns ok=false on missing or already archived rows so replayed
+	// ARCHIVED events become no-ops instead of errors.
+	TakeHolding(ctx context.Context, contractID string, offset int64) (h indexer.HoldingChange, ok bool, err error)
 }
 
 // Processor is the main run loop of the indexer. It wires the EventFetcher to the
@@ -117,23 +139,71 @@ type Processor struct {
 	store   Store
 	metrics *Metrics
 	logger  *zap.Logger
+
+	snapshot       SnapshotSource // nil = replay from offset 0 on a fresh database
+	snapshotOffset int64
+
+	afterCommit func(offset int64) // nil = no-op
+
+	running atomic.Bool
+	stopped chan struct{} // closed when Run returns
+	pauses  chan *pauseRequest
+}
+
+// pauseRequest asks the Run loop to stop streaming, run fn and resume.
+type pauseRequest struct {
+	ctx  context.Context
+	fn   func(ctx context.Context) error
+	done chan error
+}
+
+// ProcessorOption configures a Processor.
+type ProcessorOption func(*Processor)
+
+// WithSnapshotBootstrap makes the processor seed a fresh database (no stored
+// offset) from the snapshot at atOffset — the current ledger end when zero —
+// and stream from there, instead of replaying the ledger from the beginning.
+// It has no effect once an offset has been stored.
+func WithSnapshotBootstrap(source SnapshotSource, atOffset int64) ProcessorOption {
+	return func(p *Processor) {
+		p.snapshot = source
+		p.snapshotOffset = atOffset
+	}
+}
+
+// WithAfterCommit registers fn to be called after every committed batch that
+// carried at least one item, with the batch offset. It runs on the processor
+// goroutine, so it must not block — typically it wakes a worker that reads what
+// the batch wrote (e.g. the webhook dispatcher).
+func WithAfterCommit(fn func(offset int64)) ProcessorOption {
+	return func(p *Processor) {
+		p.afterCommit = fn
+	}
 }
 
 // NewProcessor creates a Processor.
-func NewProcessor(fetcher EventFetcher, store Store, metrics *Metrics, logger *zap.Logger) *Processor {
-	return &Processor{
+func NewProcessor(fetcher EventFetcher, store Store, metrics *Metrics, logger *zap.Logger, opts ...ProcessorOption) *Processor {
+	p := &Processor{
 		fetcher: fetcher,
 		store:   store,
 		metrics: metrics,
 		logger:  logger,
+		stopped: make(chan struct{}),
+		pauses:  make(chan *pauseRequest),
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	return p
 }
 
 // Run starts the indexer loop. It blocks until ctx is canceled or the fetcher
 // channel closes, then returns ctx.Err() or nil respectively.
 //
 // On startup Run loads the resume offset from the store and passes it to the fetcher,
-// so callers do not need to track offsets themselves.
+// so callers do not need to track offsets themselves. On a fresh database with
+// snapshot bootstrap enabled, Run first seeds the store from the snapshot and
+// resumes from the snapshot offset instead.
 //
 // If processBatch fails (store error) Run retries the same batch with exponential
 // backoff (5s → 60s) until it succeeds or ctx is canceled. The offset is never
@@ -144,12 +214,22 @@ func (p *Processor) Run(ctx context.Context) error {
 		return fmt.Errorf("load resume offset: %w", err)
 	}
 
+	if offset == 0 && p.snapshot != nil {
+		if offset, err = p.bootstrap(ctx); err != nil {
+			return fmt.Errorf("bootstrap from snapshot: %w", err)
+		}
+	}
+
 	p.logger.Info("indexer processor starting", zap.Int64("resume_offset", offset))
-	p.fetcher.Start(ctx, offset)
+	p.running.Store(true)
+	defer close(p.stopped)
+
+	events, stop := p.startStream(ctx, offset)
+	defer func() { stop() }()
 
 	for {
 		select {
-		case batch, ok := <-p.fetcher.Events():
+		case batch, ok := <-events:
 			if !ok {
 				p.logger.Info("indexer stream closed")
 				return nil
@@ -158,12 +238,125 @@ func (p *Processor) Run(ctx context.Context) error {
 				// Only reachable when ctx is canceled.
 				return err
 			}
+		case req := <-p.pauses:
+			stop()
+			if offset, err = p.pause(ctx, req); err != nil {
+				return err
+			}
+			events, stop = p.startStream(ctx, offset)
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
+// startStream starts the fetcher from offset under a child of ctx and returns
+// its batches and the function that stops it.
+func (p *Processor) startStream(ctx context.Context, offset int64) (<-chan *streaming.Batch[any], context.CancelFunc) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	p.fetcher.Start(streamCtx, offset)
+	return p.fetcher.Events(), cancel
+}
+
+// Pause stops the running processor between two batches, runs fn and resumes
+// streaming from the offset stored once fn returns, so fn may rewrite the
+// indexed state and the stored offset without racing the processor. Batches
+// received but not yet processed when it stops are streamed again. Pause
+// returns fn's error, ErrNotRunning when Run is not looping, or ctx.Err() when
+// ctx ends before the processor stops.
+func (p *Processor) Pause(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !p.running.Load() {
+		return ErrNotRunning
+	}
+	req := &pauseRequest{ctx: ctx, fn: fn, done: make(chan error, 1)}
+	select {
+	case p.pauses <- req:
+	case <-p.stopped:
+		return ErrNotRunning
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-req.done
+}
+
+// pause runs req with the stream stopped and returns the offset to resume from.
+func (p *Processor) pause(ctx context.Context, req *pauseRequest) (int64, error) {
+	p.logger.Info("indexer processor paused")
+	req.done <- req.fn(req.ctx)
+
+	offset, err := p.store.LatestOffset(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("reload resume offset: %w", err)
+	}
+	p.metrics.LastOffset.Set(float64(offset))
+	p.logger.Info("indexer processor resuming", zap.Int64("resume_offset", offset))
+	return offset, nil
+}
+
+// bootstrap loads the snapshot and seeds the store from it in a single
+// transaction, together with the snapshot offset. Either the whole snapshot is
+// committed or nothing is, so a failed bootstrap is simply retried on the next
+// start. Returns the offset to stream from.
+func (p *Processor) bootstrap(ctx context.Context) (int64, error) {
+	snap, err := p.snapshot.Load(ctx, p.snapshotOffset)
+	if err != nil {
+		return 0, err
+	}
+	if snap.Offset == 0 {
+		p.logger.Info("ledger is empty, nothing to bootstrap")
+		return 0, nil
+	}
+
+	err = p.store.RunInTx(ctx, func(ctx context.Context, tx Store) error {
+		return p.seedSnapshot(ctx, tx, snap)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("seed snapshot at offset %d: %w", snap.Offset, err)
+	}
+
+	p.metrics.LastOffset.Set(float64(snap.Offset))
+	p.logger.Info("bootstrapped from snapshot",
+		zap.Int64("offset", snap.Offset),
+		zap.Int("tokens", len(snap.Tokens)),
+		zap.Int("holdings", len(snap.Holdings)),
+		zap.Int("pending_transfers", len(snap.Offers)),
+	)
+	return snap.Offset, nil
+}
+
+// seedSnapshot writes snap through tx. Tokens are created first so balance
+// updates can maintain their holder counts. Holdings then go through
+// processHoldingChange, which applies the same locked/unlocked rules as the
+// live stream, so the seeded balances equal those a full replay would produce.
+// No webhooks are enqueued: seeding restores existing state, it is not activity.
+func (p *Processor) seedSnapshot(ctx context.Context, tx Store, snap *Snapshot) error {
+	for _, tok := range snap.Tokens {
+		if err := tx.UpsertToken(ctx, tok); err != nil {
+			return fmt.Errorf("upsert token %s/%s: %w", tok.InstrumentAdmin, tok.InstrumentID, err)
+		}
+		if err := tx.ApplySupplyDelta(ctx, tok.InstrumentAdmin, tok.InstrumentID, tok.TotalSupply); err != nil {
+			return fmt.Errorf("apply supply for %s/%s: %w", tok.InstrumentAdmin, tok.InstrumentID, err)
+		}
+	}
+	for _, h := range snap.Holdings {
+		if err := p.processHoldingChange(ctx, tx, h); err != nil {
+			return err
+		}
+	}
+	for _, t := range snap.Offers {
+		if err := tx.InsertTransfer(ctx, t); err != nil {
+			return fmt.Errorf("insert transfer %s: %w", t.ContractID, err)
+		}
+	}
+	if err := tx.RecordHistory(ctx, snap.Offset, snap.Time); err != nil {
+		return fmt.Errorf("record history: %w", err)
+	}
+	if err := tx.SaveOffset(ctx, snap.Offset); err != nil {
+		return fmt.Errorf("save offset: %w", err)
+	}
+	return nil
+}
+
 // processBatchWithRetry calls processBatch and retries with exponential backoff on failure.
 // It returns only when the batch is successfully persisted or ctx is canceled.
 func (p *Processor) processBatchWithRetry(ctx context.Context, batch *streaming.Batch[any]) error {
@@ -220,7 +413,7 @@ func (p *Processor) processBatch(ctx context.Context, batch *streaming.Batch[any
 						)
 						status = indexer.TransferStatusCompleted
 					}
-					if err := tx.FinalizeTransfer(ctx, item.ContractID, status); err != nil {
+					if err := tx.FinalizeTransfer(ctx, item.ContractID, status, batch.Offset); err != nil {
 						return fmt.Errorf("finalize transfer %s: %w", item.ContractID, err)
 					}
 				} else {
@@ -240,6 +433,17 @@ func (p *Processor) processBatch(ctx context.Context, batch *streaming.Batch[any
 			}
 		}
 
+		if len(batch.Items) > 0 {
+			if err := tx.RecordHistory(ctx, batch.Offset, batch.EffectiveTime); err != nil {
+				return fmt.Errorf("record history: %w", err)
+			}
+			if err := tx.RecordStats(ctx, batch.EffectiveTime); err != nil {
+				return fmt.Errorf("record stats: %w", err)
+			}
+			if err := tx.EnqueueWebhooks(ctx, batch.Offset); err != nil {
+				return fmt.Errorf("enqueue webhooks: %w", err)
+			}
+		}
 		if err := tx.SaveOffset(ctx, batch.Offset); err != nil {
 			return fmt.Errorf("save offset: %w", err)
 		}
@@ -269,6 +473,9 @@ func (p *Processor) processBatch(ctx context.Context, batch *streaming.Batch[any
 	}
 
 	if len(batch.Items) > 0 {
+		if p.afterCommit != nil {
+			p.afterCommit(batch.Offset)
+		}
 		p.logger.Debug("indexed batch",
 			zap.String("update_id", batch.UpdateID),
 			zap.Int64("offset", batch.Offset),
@@ -349,7 +556,7 @@ func (p *Processor) processTransferEvent(ctx context.Context, tx Store, e *index
 // processHoldingChange handles a Utility.Registry.Holding lifecycle event by
 // applying the matching balance delta:
 //   - CREATED: store the holding row + increment balance for owner by amount.
-//   - ARCHIVED: take (lookup + delete) the stored row + decrement balance by
+//   - ARCHIVED: take (lookup + archive) the stored row + decrement balance by
 //     the stored amount. Missing rows are treated as already-processed and skipped.
 //
 // We deliberately do not advance total supply here — internal transfers (split/
@@ -358,7 +565,7 @@ func (p *Processor) processTransferEvent(ctx context.Context, tx Store, e *index
 // Registry instruments stays at 0 until we add explicit mint/burn-event tracking.
 func (p *Processor) processHoldingChange(ctx context.Context, tx Store, h *indexer.HoldingChange) error {
 	if h.IsArchived {
-		stored, ok, err := tx.TakeHolding(ctx, h.ContractID)
+		stored, ok, err := tx.TakeHolding(ctx, h.ContractID, h.LedgerOffset)
 		if err != nil {
 			return fmt.Errorf("take holding %s: %w", h.ContractID, err)
 		}
@@ -195,6 +195,48 @@ func TestProcessor_Run_ContextCanceled(t *testing.T) {
 	assert.ErrorIs(t, <-done, context.Canceled)
 }
 
+func TestProcessor_Pause_NotRunning(t *testing.T) {
+	p := engine.NewProcessor(mocks.NewEventFetcher(t), mocks.NewStore(t), engine.NewNopMetrics(), zap.NewNop())
+
+	err := p.Pause(context.Background(), func(context.Context) error {
+		t.Fatal("fn must not run while the processor is stopped")
+		return nil
+	})
+	assert.ErrorIs(t, err, engine.ErrNotRunning)
+}
+
+func TestProcessor_Pause_ResumesFromStoredOffset(t *testing.T) {
+	store := mocks.NewStore(t)
+	fetcher := mocks.NewEventFetcher(t)
+	live := make(chan *streaming.Batch[any]) // never delivers; the stream is idle
+
+	// Offset 9 at startup; fn rewinds the store to 4, so the stream restarts there.
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(9), nil).Once()
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(4), nil).Once()
+	fetcher.EXPECT().Start(mock.Anything, int64(9)).Once()
+	fetcher.EXPECT().Events().Return((<-chan *streaming.Batch[any])(live)).Once()
+	fetcher.EXPECT().Start(mock.Anything, int64(4)).Once()
+	fetcher.EXPECT().Events().Return(feedCh()).Once()
+
+	p := engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop())
+	done := make(chan error, 1)
+	go func() { done <- p.Run(context.Background()) }()
+
+	fnErr := errors.New("rewind failed")
+	ran := false
+	require.Eventually(t, func() bool {
+		err := p.Pause(context.Background(), func(context.Context) error {
+			ran = true
+			return fnErr
+		})
+		return !errors.Is(err, engine.ErrNotRunning) && assert.ErrorIs(t, err, fnErr)
+	}, time.Second, time.Millisecond)
+	assert.True(t, ran)
+
+	// The restarted stream is closed, so Run returns once it resumes.
+	require.NoError(t, <-done)
+}
+
 // ---------------------------------------------------------------------------
 // Event-type store call verification
 // (also implicitly tests tokenFromEvent / supplyDeltaFromEvent / balanceUpdatesFromEvent)
@@ -220,6 +262,33 @@ func TestProcessor_Run_MintBatch(t *testing.T) {
 	}).Return(nil)
 	store.EXPECT().ApplySupplyDelta(mock.Anything, testInstrumentAdmin, testInstrumentID, testAmount).Return(nil)
 	store.EXPECT().ApplyBalanceDelta(mock.Anything, testRecipient, testInstrumentAdmin, testInstrumentID, testAmount).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(1), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(1)).Return(nil)
+	store.EXPECT().SaveOffset(mock.Anything, int64(1)).Return(nil)
+
+	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
+}
+
+func TestProcessor_Run_RecordsHistoryAtEffectiveTime(t *testing.T) {
+	store := mocks.NewStore(t)
+	fetcher := mocks.NewEventFetcher(t)
+	ev := mintEvent()
+	batch := makeBatch(1, ev)
+	batch.EffectiveTime = time.Unix(1_700_000_100, 0).UTC()
+
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(0), nil)
+	fetcher.EXPECT().Start(mock.Anything, int64(0))
+	fetcher.EXPECT().Events().Return(feedCh(batch))
+
+	setupRunInTx(store)
+	store.EXPECT().InsertEvent(mock.Anything, ev).Return(true, nil)
+	store.EXPECT().UpsertToken(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().ApplySupplyDelta(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().ApplyBalanceDelta(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(1), batch.EffectiveTime).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, batch.EffectiveTime).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(1)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(1)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -245,6 +314,9 @@ func TestProcessor_Run_BurnBatch(t *testing.T) {
 	}).Return(nil)
 	store.EXPECT().ApplySupplyDelta(mock.Anything, testInstrumentAdmin, testInstrumentID, "-"+testAmount).Return(nil)
 	store.EXPECT().ApplyBalanceDelta(mock.Anything, testSender, testInstrumentAdmin, testInstrumentID, "-"+testAmount).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(2), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(2)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(2)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -278,6 +350,9 @@ func TestProcessor_Run_TransferBatch(t *testing.T) {
 			t.FromPartyID == testSender && t.ToPartyID == testRecipient &&
 			t.Amount == testAmount
 	})).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(3), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(3)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(3)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -313,6 +388,9 @@ func TestProcessor_Run_Transfer_CrossParticipantSender(t *testing.T) {
 	store.EXPECT().InsertTransfer(mock.Anything, mock.MatchedBy(func(t *indexer.Transfer) bool {
 		return t.ContractID == ev.ContractID && t.Kind == indexer.TransferKindDirect
 	})).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(3), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(3)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(3)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -343,6 +421,9 @@ func TestProcessor_Run_DuplicateEvent_SkipsDerivedStateButAdvancesOffset(t *test
 
 	setupRunInTx(store)
 	store.EXPECT().InsertEvent(mock.Anything, ev).Return(false, nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(5), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(5)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(5)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -409,6 +490,9 @@ func TestProcessor_Run_PendingOfferCreated_Inserted(t *testing.T) {
 
 	setupRunInTx(store)
 	store.EXPECT().InsertTransfer(mock.Anything, offer).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(10), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(10)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(10)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -426,7 +510,10 @@ func TestProcessor_Run_OfferArchived_FinalizesTransfer(t *testing.T) {
 	fetcher.EXPECT().Events().Return(feedCh(makeOfferBatch(11, archived)))
 
 	setupRunInTx(store)
-	store.EXPECT().FinalizeTransfer(mock.Anything, archived.ContractID, indexer.TransferStatusCompleted).Return(nil)
+	store.EXPECT().FinalizeTransfer(mock.Anything, archived.ContractID, indexer.TransferStatusCompleted, int64(11)).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(11), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(11)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(11)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -444,7 +531,10 @@ func TestProcessor_Run_OfferWithdrawn_CancelsTransfer(t *testing.T) {
 	fetcher.EXPECT().Events().Return(feedCh(makeOfferBatch(12, canceled)))
 
 	setupRunInTx(store)
-	store.EXPECT().FinalizeTransfer(mock.Anything, canceled.ContractID, indexer.TransferStatusCanceled).Return(nil)
+	store.EXPECT().FinalizeTransfer(mock.Anything, canceled.ContractID, indexer.TransferStatusCanceled, int64(12)).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(12), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(12)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(12)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -473,6 +563,9 @@ func TestProcessor_Run_LockedHoldingCreated_SkippedFromBalance(t *testing.T) {
 	setupRunInTx(store)
 	// No InsertHolding / ApplyBalanceDelta expected: the strict mock fails if either
 	// is called. Only the offset advances.
+	store.EXPECT().RecordHistory(mock.Anything, int64(12), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(12)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(12)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
@@ -499,11 +592,44 @@ func TestProcessor_Run_UnlockedHoldingCreated_Tracked(t *testing.T) {
 	setupRunInTx(store)
 	store.EXPECT().InsertHolding(mock.Anything, h).Return(nil)
 	store.EXPECT().ApplyBalanceDelta(mock.Anything, testRecipient, testInstrumentAdmin, testInstrumentID, testAmount).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(13), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(13)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(13)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
 }
 
+func TestProcessor_Run_UnlockedHoldingArchived_TakenAtOffset(t *testing.T) {
+	store := mocks.NewStore(t)
+	fetcher := mocks.NewEventFetcher(t)
+	// The archive carries only the contract ID; the stored row supplies the amount
+	// and is stamped with the archiving offset.
+	archived := &indexer.HoldingChange{ContractID: "holding-1", LedgerOffset: 14, IsArchived: true}
+	stored := indexer.HoldingChange{
+		ContractID:      "holding-1",
+		Owner:           testRecipient,
+		InstrumentAdmin: testInstrumentAdmin,
+		InstrumentID:    testInstrumentID,
+		Amount:          testAmount,
+		LedgerOffset:    13,
+	}
+
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(13), nil)
+	fetcher.EXPECT().Start(mock.Anything, int64(13))
+	fetcher.EXPECT().Events().Return(feedCh(makeHoldingBatch(14, archived)))
+
+	setupRunInTx(store)
+	store.EXPECT().TakeHolding(mock.Anything, "holding-1", int64(14)).Return(stored, true, nil)
+	store.EXPECT().ApplyBalanceDelta(mock.Anything, testRecipient, testInstrumentAdmin, testInstrumentID, "-"+testAmount).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(14), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(14)).Return(nil)
+	store.EXPECT().SaveOffset(mock.Anything, int64(14)).Return(nil)
+
+	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
+}
+
 func TestProcessor_Run_MixedBatch_ParsedEventAndOffer(t *testing.T) {
 	store := mocks.NewStore(t)
 	fetcher := mocks.NewEventFetcher(t)
@@ -532,7 +658,125 @@ func TestProcessor_Run_MixedBatch_ParsedEventAndOffer(t *testing.T) {
 	store.EXPECT().ApplySupplyDelta(mock.Anything, testInstrumentAdmin, testInstrumentID, testAmount).Return(nil)
 	store.EXPECT().ApplyBalanceDelta(mock.Anything, testRecipient, testInstrumentAdmin, testInstrumentID, testAmount).Return(nil)
 	store.EXPECT().InsertTransfer(mock.Anything, offer).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(20), mock.Anything).Return(nil)
+	store.EXPECT().RecordStats(mock.Anything, mock.Anything).Return(nil)
+	store.EXPECT().EnqueueWebhooks(mock.Anything, int64(20)).Return(nil)
 	store.EXPECT().SaveOffset(mock.Anything, int64(20)).Return(nil)
 
 	require.NoError(t, engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop()).Run(context.Background()))
 }
+
+// ---------------------------------------------------------------------------
+// Snapshot bootstrap
+// ---------------------------------------------------------------------------
+
+func TestProcessor_Run_SnapshotBootstrap_SeedsAndResumesFromSnapshot(t *testing.T) {
+	store := mocks.NewStore(t)
+	fetcher := mocks.NewEventFetcher(t)
+	source := mocks.NewSnapshotSource(t)
+
+	token := &indexer.Token{
+		InstrumentAdmin: testInstrumentAdmin,
+		InstrumentID:    testInstrumentID,
+		Issuer:          testInstrumentAdmin,
+		TotalSupply:     "150",
+		FirstSeenOffset: 3,
+		FirstSeenAt:     time.Unix(1_700_000_000, 0),
+	}
+	unlocked := &indexer.HoldingChange{
+		ContractID:      "holding-1",
+		Owner:           testRecipient,
+		InstrumentAdmin: testInstrumentAdmin,
+		InstrumentID:    testInstrumentID,
+		Amount:          "100",
+		LedgerOffset:    3,
+	}
+	locked := &indexer.HoldingChange{
+		ContractID:      "holding-2",
+		Owner:           testSender,
+		InstrumentAdmin: testInstrumentAdmin,
+		InstrumentID:    testInstrumentID,
+		Amount:          "50",
+		LedgerOffset:    4,
+		Locked:          true,
+	}
+	offer := pendingOffer()
+
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(0), nil)
+	snapTime := time.Unix(1_700_000_500, 0).UTC()
+	source.EXPECT().Load(mock.Anything, int64(40)).Return(&engine.Snapshot{
+		Offset:   42,
+		Time:     snapTime,
+		Tokens:   []*indexer.Token{token},
+		Holdings: []*indexer.HoldingChange{unlocked, locked},
+		Offers:   []*indexer.Transfer{offer},
+	}, nil)
+
+	setupRunInTx(store)
+	store.EXPECT().UpsertToken(mock.Anything, token).Return(nil)
+	store.EXPECT().ApplySupplyDelta(mock.Anything, testInstrumentAdmin, testInstrumentID, "150").Return(nil)
+	// Only the unlocked holding is stored and credited, as on the live stream.
+	store.EXPECT().InsertHolding(mock.Anything, unlocked).Return(nil)
+	store.EXPECT().ApplyBalanceDelta(mock.Anything, testRecipient, testInstrumentAdmin, testInstrumentID, "100").Return(nil)
+	store.EXPECT().InsertTransfer(mock.Anything, offer).Return(nil)
+	store.EXPECT().RecordHistory(mock.Anything, int64(42), snapTime).Return(nil)
+	store.EXPECT().SaveOffset(mock.Anything, int64(42)).Return(nil)
+
+	fetcher.EXPECT().Start(mock.Anything, int64(42))
+	fetcher.EXPECT().Events().Return(feedCh())
+
+	p := engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop(),
+		engine.WithSnapshotBootstrap(source, 40))
+	require.NoError(t, p.Run(context.Background()))
+}
+
+func TestProcessor_Run_SnapshotBootstrap_SkippedWhenOffsetStored(t *testing.T) {
+	store := mocks.NewStore(t)
+	fetcher := mocks.NewEventFetcher(t)
+	// No expectations: an already-populated database must never be re-seeded.
+	source := mocks.NewSnapshotSource(t)
+
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(7), nil)
+	fetcher.EXPECT().Start(mock.Anything, int64(7))
+	fetcher.EXPECT().Events().Return(feedCh())
+
+	p := engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop(),
+		engine.WithSnapshotBootstrap(source, 0))
+	require.NoError(t, p.Run(context.Background()))
+}
+
+func TestProcessor_Run_SnapshotBootstrap_EmptyLedger(t *testing.T) {
+	store := mocks.NewStore(t)
+	fetcher := mocks.NewEventFetcher(t)
+	source := mocks.NewSnapshotSource(t)
+
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(0), nil)
+	source.EXPECT().Load(mock.Anything, int64(0)).Return(&engine.Snapshot{}, nil)
+	fetcher.EXPECT().Start(mock.Anything, int64(0))
+	fetcher.EXPECT().Events().Return(feedCh())
+
+	p := engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop(),
+		engine.WithSnapshotBootstrap(source, 0))
+	require.NoError(t, p.Run(context.Background()))
+}
+
+func TestProcessor_Run_SnapshotBootstrap_SeedErrorAbortsBeforeStreaming(t *testing.T) {
+	store := mocks.NewStore(t)
+	fetcher := mocks.NewEventFetcher(t)
+	source := mocks.NewSnapshotSource(t)
+	seedErr := errors.New("db write failed")
+
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(0), nil)
+	source.EXPECT().Load(mock.Anything, int64(0)).Return(&engine.Snapshot{
+		Offset: 9,
+		Offers: []*indexer.Transfer{pendingOffer()},
+	}, nil)
+	setupRunInTx(store)
+	store.EXPECT().InsertTransfer(mock.Anything, mock.Anything).Return(seedErr)
+
+	p := engine.NewProcessor(fetcher, store, engine.NewNopMetrics(), zap.NewNop(),
+		engine.WithSnapshotBootstrap(source, 0))
+	err := p.Run(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, seedErr)
+}
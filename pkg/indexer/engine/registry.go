@@ -0,0 +1,331 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+
+	"go.uber.org/zap"
+)
+
+// Effect names the store writes a decoded item drives in the processor. Each
+// effect corresponds to one item type the processor knows how to apply.
+type Effect string
+
+const (
+	// EffectTokenEvent items (*indexer.ParsedEvent) insert an event and
+	// maintain the token, its supply and the parties' balances.
+	EffectTokenEvent Effect = "token_event"
+	// EffectTransfer items (*indexer.Transfer) insert a pending transfer on
+	// create and finalize it on archive.
+	EffectTransfer Effect = "transfer"
+	// EffectHolding items (*indexer.HoldingChange) track holding contracts and
+	// the owner's balance.
+	EffectHolding Effect = "holding"
+)
+
+// isState reports whether the effect mirrors active contracts, which snapshot
+// bootstrap can seed from the ACS. Token events record history, not state.
+func (e Effect) isState() bool {
+	return e == EffectTransfer || e == EffectHolding
+}
+
+// effectOf returns the effect a decoded item produces.
+func effectOf(item any) (Effect, bool) {
+	switch item.(type) {
+	case *indexer.ParsedEvent:
+		return EffectTokenEvent, true
+	case *indexer.Transfer:
+		return EffectTransfer, true
+	case *indexer.HoldingChange:
+		return EffectHolding, true
+	default:
+		return "", false
+	}
+}
+
+// DecodeFunc decodes one ledger event into a processor item. It returns
+// nil, false for events it does not index.
+type DecodeFunc func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (any, bool)
+
+// Decoder describes how the indexer indexes one Daml template.
+type Decoder struct {
+	// Name identifies the decoder in configuration and logs.
+	Name string
+
+	// ModuleName and EntityName identify the template, independent of the
+	// package version it was deployed with.
+	ModuleName string
+	EntityName string
+
+	// Effects lists the store effects the decoder's items produce. Items with
+	// any other effect are dropped.
+	Effects []Effect
+
+	Decode DecodeFunc
+}
+
+// templateKey identifies a template across package versions.
+type templateKey struct {
+	module string
+	entity string
+}
+
+// registration is a Decoder together with the package IDs it is enabled for.
+type registration struct {
+	decoder    Decoder
+	packageIDs []string
+
+	// pinned holds the package hashes events must carry. Nil when any package
+	// matches: no package IDs, or a package-name reference (#<name>) the
+	// registry has no version range for, whose events arrive carrying the
+	// resolved hash of whichever version created them.
+	pinned map[string]struct{}
+}
+
+// Registry maps Daml templates to the decoders that index them. One template
+// can be subscribed under several package IDs — after a Daml package upgrade
+// the same template is live under each version's package ID — and events of
+// every listed version are routed to its decoder.
+//
+// A Registry is built once at startup and is read-only afterwards; it is safe
+// for concurrent use by the fetcher and the snapshot source.
+type Registry struct {
+	registrations []*registration
+	byTemplate    map[templateKey]*registration
+	packageRefs   func(name string) []string
+	logger        *zap.Logger
+}
+
+// RegistryOption configures a Registry.
+type RegistryOption func(*Registry)
+
+// WithPackageRefs narrows package-name references (#<name>) to the package
+// IDs resolve returns for the name — the vetted versions within its configured
+// range (see packages.Packages.InRange). A nil result leaves the reference
+// matching any version.
+func WithPackageRefs(resolve func(name string) []string) RegistryOption {
+	return func(r *Registry) {
+		r.packageRefs = resolve
+	}
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry(logger *zap.Logger, opts ...RegistryOption) *Registry {
+	r := &Registry{
+		byTemplate: make(map[templateKey]*registration),
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Register enables d for the given package IDs. With no package IDs, or an
+// empty one among them, the template is matched across all package versions.
+// A package-name reference matches any version unless WithPackageRefs narrows
+// it to the versions in range; the reference itself stays in the stream filter.
+// Returns an error when d is incomplete or its template is already registered.
+func (r *Registry) Register(d Decoder, packageIDs ...string) error {
+	if d.Name == "" || d.ModuleName == "" || d.EntityName == "" || d.Decode == nil {
+		return fmt.Errorf("decoder %q: name, template and decode function are required", d.Name)
+	}
+	if len(d.Effects) == 0 {
+		return fmt.Errorf("decoder %q: no effects declared", d.Name)
+	}
+	key := templateKey{module: d.ModuleName, entity: d.EntityName}
+	if prev, ok := r.byTemplate[key]; ok {
+		return fmt.Errorf("decoder %q: template %s:%s already registered by %q",
+			d.Name, d.ModuleName, d.EntityName, prev.decoder.Name)
+	}
+
+	reg := &registration{decoder: d}
+	if len(packageIDs) == 0 || slices.Contains(packageIDs, "") {
+		reg.packageIDs = []string{""}
+	} else {
+		reg.packageIDs = slices.Clone(packageIDs)
+		reg.pinned = make(map[string]struct{}, len(packageIDs))
+		for _, id := range packageIDs {
+			if name, ok := strings.CutPrefix(id, "#"); ok {
+				var inRange []string
+				if r.packageRefs != nil {
+					inRange = r.packageRefs(name)
+				}
+				if inRange == nil {
+					reg.pinned = nil
+					break
+				}
+				for _, pkg := range inRange {
+					reg.pinned[pkg] = struct{}{}
+				}
+				continue
+			}
+			reg.pinned[id] = struct{}{}
+		}
+	}
+	r.registrations = append(r.registrations, reg)
+	r.byTemplate[key] = reg
+	return nil
+}
+
+// Names returns the names of the registered decoders in registration order.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.registrations))
+	for i, reg := range r.registrations {
+		names[i] = reg.decoder.Name
+	}
+	return names
+}
+
+// TemplateIDs returns the stream filter for every registered decoder: one
+// template ID per enabled package ID.
+func (r *Registry) TemplateIDs() []streaming.TemplateID {
+	return r.templateIDs(func(*registration) bool { return true })
+}
+
+// SnapshotTemplateIDs returns the template IDs of the decoders that produce a
+// state effect (holdings or transfers) — the contracts snapshot bootstrap reads.
+func (r *Registry) SnapshotTemplateIDs() []streaming.TemplateID {
+	return r.templateIDs(func(reg *registration) bool {
+		return slices.ContainsFunc(reg.decoder.Effects, Effect.isState)
+	})
+}
+
+func (r *Registry) templateIDs(include func(*registration) bool) []streaming.TemplateID {
+	var ids []streaming.TemplateID
+	for _, reg := range r.registrations {
+		if !include(reg) {
+			continue
+		}
+		for _, pkg := range reg.packageIDs {
+			ids = append(ids, streaming.TemplateID{
+				PackageID:  pkg,
+				ModuleName: reg.decoder.ModuleName,
+				EntityName: reg.decoder.EntityName,
+			})
+		}
+	}
+	return ids
+}
+
+// Decode routes ev to the decoder registered for its template. It is the
+// decode function handed to NewFetcher and NewACSSnapshotSource.
+//
+// Events of a pinned decoder whose package is not listed are skipped — they
+// belong to a package version the configuration did not enable.
+func (r *Registry) Decode(tx *streaming.LedgerTransaction, ev *streaming.LedgerEvent) (any, bool) {
+	reg, ok := r.byTemplate[templateKey{module: ev.ModuleName, entity: ev.TemplateName}]
+	if !ok {
+		return nil, false
+	}
+	if reg.pinned != nil {
+		if _, ok := reg.pinned[ev.PackageID]; !ok {
+			r.logger.Debug("skipping event from unlisted package version",
+				zap.String("decoder", reg.decoder.Name),
+				zap.String("package_id", ev.PackageID),
+				zap.String("contract_id", ev.ContractID),
+			)
+			return nil, false
+		}
+	}
+	item, ok := reg.decoder.Decode(tx, ev)
+	if !ok {
+		return nil, false
+	}
+	if effect, known := effectOf(item); !known || !slices.Contains(reg.decoder.Effects, effect) {
+		r.logger.Error("decoder produced an undeclared effect, dropping item",
+			zap.String("decoder", reg.decoder.Name),
+			zap.String("type", fmt.Sprintf("%T", item)),
+			zap.String("contract_id", ev.ContractID),
+		)
+		return nil, false
+	}
+	return item, true
+}
+
+// NewConfiguredRegistry builds the Registry for the decoders cfg enables (see
+// indexer.Config.EnabledDecoders). Returns an error for an unknown decoder name.
+func NewConfiguredRegistry(cfg *indexer.Config, metrics *Metrics, logger *zap.Logger, opts ...RegistryOption) (*Registry, error) {
+	r := NewRegistry(logger, opts...)
+	for _, dc := range cfg.EnabledDecoders() {
+		d, err := builtinDecoder(dc.Name, cfg, metrics, logger)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.Register(d, dc.PackageIDs...); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// builtinDecoder returns the built-in decoder with the given name.
+//
+// Token-standard interfaces such as AllocationRequest and AllocationV1 have no
+// template of their own; the built-ins cover concrete templates implementing
+// them (e.g. AmuletAllocation). Other templates can be indexed by registering
+// a Decoder on a Registry directly.
+func builtinDecoder(name string, cfg *indexer.Config, metrics *Metrics, logger *zap.Logger) (Decoder, error) {
+	switch name {
+	case indexer.DecoderCIP56TransferEvent:
+		mode, instruments := cfg.FilterModeAndKeys()
+		return Decoder{
+			Name:       name,
+			ModuleName: tokenTransferEventTemplate.Module,
+			EntityName: tokenTransferEventTemplate.Entity,
+			Effects:    []Effect{EffectTokenEvent},
+			Decode:     decodeAny(NewTokenTransferDecoder(mode, instruments, logger)),
+		}, nil
+	case indexer.DecoderUtilityRegistryOffer:
+		return Decoder{
+			Name:       name,
+			ModuleName: transferOfferTemplate.Module,
+			EntityName: transferOfferTemplate.Entity,
+			Effects:    []Effect{EffectTransfer},
+			Decode:     decodeAny(NewOfferDecoder(metrics, logger)),
+		}, nil
+	case indexer.DecoderUtilityRegistryHolding:
+		return Decoder{
+			Name:       name,
+			ModuleName: holdingTemplate.Module,
+			EntityName: holdingTemplate.Entity,
+			Effects:    []Effect{EffectHolding},
+			Decode:     decodeAny(NewHoldingDecoder(logger)),
+		}, nil
+	case indexer.DecoderSpliceAmulet:
+		return Decoder{
+			Name:       name,
+			ModuleName: amuletModule,
+			EntityName: amuletEntity,
+			Effects:    []Effect{EffectHolding},
+			Decode:     decodeAny(NewAmuletDecoder(logger)),
+		}, nil
+	case indexer.DecoderSpliceAmuletAllocation:
+		return Decoder{
+			Name:       name,
+			ModuleName: amuletAllocationModule,
+			EntityName: amuletAllocationEntity,
+			Effects:    []Effect{EffectTransfer},
+			Decode:     decodeAny(NewAmuletAllocationDecoder(metrics, logger)),
+		}, nil
+	default:
+		return Decoder{}, fmt.Errorf("unknown decoder %q", name)
+	}
+}
+
+// decodeAny adapts a typed decode function to a DecodeFunc.
+func decodeAny[T any](decode func(*streaming.LedgerTransaction, *streaming.LedgerEvent) (*T, bool)) DecodeFunc {
+	return func(tx *streaming.LedgerTransaction, ev *streaming.LedgerEvent) (any, bool) {
+		item, ok := decode(tx, ev)
+		if !ok {
+			return nil, false
+		}
+		return item, true
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewConfiguredRegistry_MergesLegacyAndConfiguredDecoders(t *testing.T) {
+	cfg := &indexer.Config{
+		CIP56PackageID:                  "#cip56-token",
+		UtilityRegistryHoldingPackageID: "holding-v1",
+		Decoders: []indexer.DecoderConfig{
+			{Name: indexer.DecoderUtilityRegistryHolding, PackageIDs: []string{"holding-v1", "holding-v2"}},
+			{Name: indexer.DecoderSpliceAmulet},
+		},
+	}
+	r, err := NewConfiguredRegistry(cfg, NewNopMetrics(), zap.NewNop())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		indexer.DecoderCIP56TransferEvent,
+		indexer.DecoderUtilityRegistryHolding,
+		indexer.DecoderSpliceAmulet,
+	}, r.Names())
+	assert.Equal(t, []streaming.TemplateID{
+		{PackageID: "#cip56-token", ModuleName: tokenTransferEventTemplate.Module, EntityName: tokenTransferEventTemplate.Entity},
+		{PackageID: "holding-v1", ModuleName: holdingTemplate.Module, EntityName: holdingTemplate.Entity},
+		{PackageID: "holding-v2", ModuleName: holdingTemplate.Module, EntityName: holdingTemplate.Entity},
+		{PackageID: "", ModuleName: amuletModule, EntityName: amuletEntity},
+	}, r.TemplateIDs())
+
+	// Token events record history, so snapshot bootstrap skips them.
+	assert.Equal(t, []streaming.TemplateID{
+		{PackageID: "holding-v1", ModuleName: holdingTemplate.Module, EntityName: holdingTemplate.Entity},
+		{PackageID: "holding-v2", ModuleName: holdingTemplate.Module, EntityName: holdingTemplate.Entity},
+		{PackageID: "", ModuleName: amuletModule, EntityName: amuletEntity},
+	}, r.SnapshotTemplateIDs())
+}
+
+func TestNewConfiguredRegistry_UnknownDecoder(t *testing.T) {
+	cfg := &indexer.Config{Decoders: []indexer.DecoderConfig{{Name: "dvp_leg"}}}
+	_, err := NewConfiguredRegistry(cfg, NewNopMetrics(), zap.NewNop())
+	assert.ErrorContains(t, err, `unknown decoder "dvp_leg"`)
+}
+
+func TestRegistry_Register_DuplicateTemplate(t *testing.T) {
+	r := NewRegistry(zap.NewNop())
+	d := Decoder{
+		Name:       "holding",
+		ModuleName: holdingTemplate.Module,
+		EntityName: holdingTemplate.Entity,
+		Effects:    []Effect{EffectHolding},
+		Decode:     decodeAny(NewHoldingDecoder(zap.NewNop())),
+	}
+	require.NoError(t, r.Register(d, "holding-v1"))
+
+	d.Name = "holding-again"
+	assert.ErrorContains(t, r.Register(d, "holding-v2"), "already registered")
+}
+
+func TestRegistry_Decode_PinnedPackageVersions(t *testing.T) {
+	r := NewRegistry(zap.NewNop())
+	require.NoError(t, r.Register(Decoder{
+		Name:       "holding",
+		ModuleName: holdingTemplate.Module,
+		EntityName: holdingTemplate.Entity,
+		Effects:    []Effect{EffectHolding},
+		Decode:     decodeAny(NewHoldingDecoder(zap.NewNop())),
+	}, "holding-v1", "holding-v2"))
+
+	event := func(pkg string) *streaming.LedgerEvent {
+		return streaming.NewLedgerEvent("h-1", pkg, holdingTemplate.Module, holdingTemplate.Entity, false, nil)
+	}
+
+	// Both upgraded versions of the template route to the same decoder.
+	for _, pkg := range []string{"holding-v1", "holding-v2"} {
+		item, ok := r.Decode(makeTx(1), event(pkg))
+		require.True(t, ok, pkg)
+		assert.IsType(t, &indexer.HoldingChange{}, item)
+	}
+
+	_, ok := r.Decode(makeTx(1), event("holding-v3"))
+	assert.False(t, ok, "unlisted package version is skipped")
+}
+
+func TestRegistry_Decode_PackageNameMatchesAnyVersion(t *testing.T) {
+	r := NewRegistry(zap.NewNop())
+	require.NoError(t, r.Register(Decoder{
+		Name:       "holding",
+		ModuleName: holdingTemplate.Module,
+		EntityName: holdingTemplate.Entity,
+		Effects:    []Effect{EffectHolding},
+		Decode:     decodeAny(NewHoldingDecoder(zap.NewNop())),
+	}, "#utility-registry-holding-v0"))
+
+	_, ok := r.Decode(makeTx(1), streaming.NewLedgerEvent("h-1", "resolved-hash", holdingTemplate.Module, holdingTemplate.Entity, false, nil))
+	assert.True(t, ok)
+
+	_, ok = r.Decode(makeTx(1), makeTransferEvent(testContractID, streaming.MakeNoneField(), streaming.MakeSomePartyField(testRecipient), nil))
+	assert.False(t, ok, "unregistered template")
+}
+
+func TestRegistry_Decode_PackageNameNarrowedToRange(t *testing.T) {
+	inRange := map[string][]string{"utility-registry-holding-v0": {"holding-v1", "holding-v2"}}
+	r := NewRegistry(zap.NewNop(), WithPackageRefs(func(name string) []string { return inRange[name] }))
+	require.NoError(t, r.Register(Decoder{
+		Name:       "holding",
+		ModuleName: holdingTemplate.Module,
+		EntityName: holdingTemplate.Entity,
+		Effects:    []Effect{EffectHolding},
+		Decode:     decodeAny(NewHoldingDecoder(zap.NewNop())),
+	}, "#utility-registry-holding-v0"))
+
+	// The stream still subscribes by name; the range is enforced on decode.
+	assert.Equal(t, []streaming.TemplateID{
+		{PackageID: "#utility-registry-holding-v0", ModuleName: holdingTemplate.Module, EntityName: holdingTemplate.Entity},
+	}, r.TemplateIDs())
+
+	event := func(pkg string) *streaming.LedgerEvent {
+		return streaming.NewLedgerEvent("h-1", pkg, holdingTemplate.Module, holdingTemplate.Entity, false, nil)
+	}
+	for _, pkg := range []string{"holding-v1", "holding-v2"} {
+		_, ok := r.Decode(makeTx(1), event(pkg))
+		assert.True(t, ok, pkg)
+	}
+	_, ok := r.Decode(makeTx(1), event("holding-v3"))
+	assert.False(t, ok, "version outside the range is skipped")
+}
+
+func TestRegistry_Decode_DropsUndeclaredEffect(t *testing.T) {
+	r := NewRegistry(zap.NewNop())
+	require.NoError(t, r.Register(Decoder{
+		Name:       "holding",
+		ModuleName: holdingTemplate.Module,
+		EntityName: holdingTemplate.Entity,
+		Effects:    []Effect{EffectTransfer},
+		Decode:     decodeAny(NewHoldingDecoder(zap.NewNop())),
+	}))
+
+	_, ok := r.Decode(makeTx(1), makeHoldingEvent("h-1", streaming.MakeNoneField()))
+	assert.False(t, ok)
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/streaming"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeSnapshotter struct {
+	snap *streaming.Snapshot
+	err  error
+	req  streaming.SnapshotRequest
+}
+
+func (f *fakeSnapshotter) ActiveContracts(_ context.Context, req streaming.SnapshotRequest) (*streaming.Snapshot, error) {
+	f.req = req
+	return f.snap, f.err
+}
+
+func makeOfferCreatedEvent(contractID string) *streaming.LedgerEvent {
+	return streaming.NewLedgerEvent(contractID, "pkg-id", transferOfferTemplate.Module, transferOfferTemplate.Entity, true,
+		map[string]streaming.FieldValue{
+			"transfer": streaming.MakeRecordField(map[string]streaming.FieldValue{
+				"sender":   streaming.MakePartyField("alice::1220"),
+				"receiver": streaming.MakePartyField("bob::1220"),
+				"amount":   streaming.MakeNumericField("4"),
+				"instrumentId": streaming.MakeRecordField(map[string]streaming.FieldValue{
+					"admin": streaming.MakePartyField("admin::1220"),
+					"id":    streaming.MakeTextField("USDCx"),
+				}),
+			}),
+		})
+}
+
+func snapshotDecoder() DecodeFunc {
+	cfg := &indexer.Config{
+		CIP56PackageID:                  "pkg-id",
+		UtilityRegistryPackageID:        "pkg-id",
+		UtilityRegistryHoldingPackageID: "pkg-id",
+	}
+	r, err := NewConfiguredRegistry(cfg, NewNopMetrics(), zap.NewNop())
+	if err != nil {
+		panic(err)
+	}
+	return r.Decode
+}
+
+func TestACSSnapshotSource_Load(t *testing.T) {
+	early := time.Unix(1_700_000_000, 0).UTC()
+	late := early.Add(time.Hour)
+
+	fake := &fakeSnapshotter{snap: &streaming.Snapshot{
+		Offset: 500,
+		Contracts: []*streaming.ActiveContract{
+			{Event: makeHoldingEvent("h-late", streaming.MakeNoneField()), CreatedOffset: 300, CreatedAt: late},
+			{Event: makeHoldingEvent("h-locked", streaming.MakeSomePartyField("lock::1220")), CreatedOffset: 100, CreatedAt: early},
+			{Event: makeOfferCreatedEvent("offer-1"), CreatedOffset: 120, CreatedAt: early},
+		},
+	}}
+	templateIDs := []streaming.TemplateID{{ModuleName: holdingTemplate.Module, EntityName: holdingTemplate.Entity}}
+
+	snap, err := NewACSSnapshotSource(fake, templateIDs, snapshotDecoder(), zap.NewNop()).Load(context.Background(), 500)
+	require.NoError(t, err)
+
+	assert.Equal(t, streaming.SnapshotRequest{AtOffset: 500, TemplateIDs: templateIDs}, fake.req)
+	assert.Equal(t, int64(500), snap.Offset)
+
+	// Supply counts locked holdings too; first-seen tracks the earliest holding.
+	require.Len(t, snap.Tokens, 1)
+	assert.Equal(t, &indexer.Token{
+		InstrumentAdmin: "admin::1220",
+		InstrumentID:    "USDCx",
+		Issuer:          "admin::1220",
+		TotalSupply:     "20",
+		FirstSeenOffset: 100,
+		FirstSeenAt:     early,
+	}, snap.Tokens[0])
+
+	require.Len(t, snap.Holdings, 2)
+	assert.Equal(t, "h-late", snap.Holdings[0].ContractID)
+	assert.Equal(t, int64(300), snap.Holdings[0].LedgerOffset)
+	assert.True(t, snap.Holdings[1].Locked)
+
+	require.Len(t, snap.Offers, 1)
+	offer := snap.Offers[0]
+	assert.Equal(t, indexer.TransferStatusPending, offer.Status)
+	assert.Equal(t, "bob::1220", offer.ToPartyID)
+	assert.Equal(t, int64(120), offer.LedgerOffset)
+	assert.Equal(t, early, offer.CreatedAt)
+}
+
+func TestACSSnapshotSource_Load_Error(t *testing.T) {
+	readErr := errors.New("state service down")
+	fake := &fakeSnapshotter{err: readErr}
+
+	_, err := NewACSSnapshotSource(fake, nil, snapshotDecoder(), zap.NewNop()).Load(context.Background(), 0)
+	assert.ErrorIs(t, err, readErr)
+}
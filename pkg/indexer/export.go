@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+// TokenMetadata is the display metadata of an instrument. The ledger carries
+// neither field, so it is configured (see Config.TokenMetadata).
+type TokenMetadata struct {
+	Admin    string `yaml:"admin" validate:"required"`
+	ID       string `yaml:"id" validate:"required"`
+	Symbol   string `yaml:"symbol" validate:"required"`
+	Decimals int    `yaml:"decimals" validate:"gte=0"`
+}
+
+// TokenInfo is the metadata joined into exported rows. Symbol falls back to the
+// instrument ID and Decimals is nil for instruments without configured metadata.
+type TokenInfo struct {
+	Symbol   string `json:"symbol"`
+	Decimals *int   `json:"decimals,omitempty"`
+}
+
+// ExportedEvent is one row of an event export.
+type ExportedEvent struct {
+	*ParsedEvent
+	TokenInfo
+}
+
+// ExportedTransfer is one row of a transfer export.
+type ExportedTransfer struct {
+	*Transfer
+	TokenInfo
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Cursor is a position in a list sorted by a unique key, taken from one row of a
+// page. Only the fields of the list's sort key are set: LedgerOffset and
+// ContractID for events and transfers, InstrumentAdmin and InstrumentID for a
+// party's balances, PartyID for a token's balances.
+//
+// On the wire a cursor is an opaque URL-safe string; clients pass back the
+// next_cursor or prev_cursor of a page as ?cursor= without interpreting it.
+type Cursor struct {
+	LedgerOffset    int64  `json:"o,omitempty"`
+	ContractID      string `json:"c,omitempty"`
+	PartyID         string `json:"p,omitempty"`
+	InstrumentAdmin string `json:"a,omitempty"`
+	InstrumentID    string `json:"i,omitempty"`
+	// Backward selects the page before the position instead of after it.
+	Backward bool `json:"b,omitempty"`
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// MarshalText encodes the cursor as an opaque string.
+func (c Cursor) MarshalText() ([]byte, error) {
+	raw, err := json.Marshal(cursorFields(c))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, base64.RawURLEncoding.EncodedLen(len(raw)))
+	base64.RawURLEncoding.Encode(out, raw)
+	return out, nil
+}
+
+// UnmarshalText decodes a cursor produced by MarshalText.
+func (c *Cursor) UnmarshalText(text []byte) error {
+	raw := make([]byte, base64.RawURLEncoding.DecodedLen(len(text)))
+	n, err := base64.RawURLEncoding.Decode(raw, text)
+	if err != nil {
+		return errInvalidCursor
+	}
+	var f cursorFields
+	if err := json.Unmarshal(raw[:n], &f); err != nil {
+		return errInvalidCursor
+	}
+	*c = Cursor(f)
+	return nil
+}
+
+// String returns the opaque form of the cursor, as sent in ?cursor=.
+func (c Cursor) String() string {
+	b, _ := c.MarshalText()
+	return string(b)
+}
+
+// ParseCursor decodes the opaque form of a cursor.
+func ParseCursor(s string) (*Cursor, error) {
+	c := new(Cursor)
+	if err := c.UnmarshalText([]byte(s)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// cursorFields has Cursor's fields without its methods, so the JSON inside the
+// opaque form does not recurse into MarshalText.
+type cursorFields Cursor
+
+// NewPage builds the response envelope for items read with p. cursorOf returns
+// the sort key of an item; pass nil for lists without cursor support.
+func NewPage[T any](items []T, total int64, p Pagination, cursorOf func(T) Cursor) *Page[T] {
+	page := &Page[T]{Items: items, Total: total, Limit: p.Limit}
+	if p.Cursor == nil {
+		page.Page = p.Page
+	}
+	if cursorOf == nil || len(items) == 0 {
+		return page
+	}
+
+	// A page shorter than the limit is the last one in its direction. The other
+	// direction has rows whenever this page was reached from somewhere.
+	full := len(items) >= p.Limit
+	backward := p.Cursor != nil && p.Cursor.Backward
+	reached := p.Cursor != nil || p.Page > 1
+	if backward || full {
+		next := cursorOf(items[len(items)-1])
+		page.NextCursor = &next
+	}
+	if (backward && full) || (!backward && reached) {
+		prev := cursorOf(items[0])
+		prev.Backward = true
+		page.PrevCursor = &prev
+	}
+	return page
+}
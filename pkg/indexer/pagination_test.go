@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	c := indexer.Cursor{LedgerOffset: 42, ContractID: "00ab::cid", Backward: true}
+
+	got, err := indexer.ParseCursor(c.String())
+	require.NoError(t, err)
+	assert.Equal(t, c, *got)
+
+	// In JSON a cursor is its opaque string, so pages decode back into cursors.
+	raw, err := json.Marshal(indexer.Page[int]{NextCursor: &c})
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"next_cursor":"`+c.String()+`"`)
+	var page indexer.Page[int]
+	require.NoError(t, json.Unmarshal(raw, &page))
+	require.NotNil(t, page.NextCursor)
+	assert.Equal(t, c, *page.NextCursor)
+}
+
+func TestParseCursor_Invalid(t *testing.T) {
+	for _, s := range []string{"not base64!", "bm90IGpzb24"} {
+		_, err := indexer.ParseCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestNewPage_Cursors(t *testing.T) {
+	cursorOf := func(v int) indexer.Cursor { return indexer.Cursor{LedgerOffset: int64(v)} }
+	after := &indexer.Cursor{LedgerOffset: 1}
+	before := &indexer.Cursor{LedgerOffset: 9, Backward: true}
+
+	tests := []struct {
+		name     string
+		items    []int
+		p        indexer.Pagination
+		wantNext int64 // 0 = no cursor
+		wantPrev int64
+	}{
+		{"first page, full", []int{1, 2}, indexer.Pagination{Page: 1, Limit: 2}, 2, 0},
+		{"first page, last", []int{1}, indexer.Pagination{Page: 1, Limit: 2}, 0, 0},
+		{"numbered page 2", []int{3, 4}, indexer.Pagination{Page: 2, Limit: 2}, 4, 3},
+		{"forward, full", []int{2, 3}, indexer.Pagination{Limit: 2, Cursor: after}, 3, 2},
+		{"forward, last", []int{2}, indexer.Pagination{Limit: 2, Cursor: after}, 0, 2},
+		{"backward, full", []int{7, 8}, indexer.Pagination{Limit: 2, Cursor: before}, 8, 7},
+		{"backward, first", []int{8}, indexer.Pagination{Limit: 2, Cursor: before}, 8, 0},
+		{"empty", nil, indexer.Pagination{Limit: 2, Cursor: after}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := indexer.NewPage(tt.items, indexer.TotalUnknown, tt.p, cursorOf)
+			if tt.wantNext == 0 {
+				assert.Nil(t, page.NextCursor)
+			} else if assert.NotNil(t, page.NextCursor) {
+				assert.Equal(t, indexer.Cursor{LedgerOffset: tt.wantNext}, *page.NextCursor)
+			}
+			if tt.wantPrev == 0 {
+				assert.Nil(t, page.PrevCursor)
+			} else if assert.NotNil(t, page.PrevCursor) {
+				assert.Equal(t, indexer.Cursor{LedgerOffset: tt.wantPrev, Backward: true}, *page.PrevCursor)
+			}
+		})
+	}
+}
+
+func TestNewPage_WithoutCursorSupport(t *testing.T) {
+	page := indexer.NewPage([]int{1, 2}, 5, indexer.Pagination{Page: 2, Limit: 2}, nil)
+	assert.Equal(t, &indexer.Page[int]{Items: []int{1, 2}, Total: 5, Page: 2, Limit: 2}, page)
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import "time"
+
+// BalanceChange is a balance a rewind or rebuild changed (or would change, in a
+// dry run). A balance that did not exist is reported as "0".
+type BalanceChange struct {
+	PartyID         string `json:"party_id"`
+	InstrumentAdmin string `json:"instrument_admin"`
+	InstrumentID    string `json:"instrument_id"`
+	Before          string `json:"before"`
+	After           string `json:"after"`
+}
+
+// TokenChange is a token whose total supply or holder count a rewind or rebuild
+// changed. Deleted is set when a rewind removes a token first seen after its
+// target offset.
+type TokenChange struct {
+	InstrumentAdmin string `json:"instrument_admin"`
+	InstrumentID    string `json:"instrument_id"`
+	SupplyBefore    string `json:"supply_before"`
+	SupplyAfter     string `json:"supply_after"`
+	HoldersBefore   int64  `json:"holders_before"`
+	HoldersAfter    int64  `json:"holders_after"`
+	Deleted         bool   `json:"deleted,omitempty"`
+}
+
+// RewindReport describes a rewind of the indexed state from FromOffset back to
+// ToOffset. In a dry run nothing is written and the report is what the rewind
+// would do.
+//
+// Stats rollups are cleared from StatsClearedFrom, the start of the earliest
+// bucket touched by a rewound transaction; activity in that bucket from before
+// ToOffset is not restored and is under-counted until re-indexed. Nil when no
+// rewound transaction carried a time.
+type RewindReport struct {
+	DryRun            bool            `json:"dry_run"`
+	FromOffset        int64           `json:"from_offset"`
+	ToOffset          int64           `json:"to_offset"`
+	EventsDeleted     int64           `json:"events_deleted"`
+	TransfersDeleted  int64           `json:"transfers_deleted"`
+	TransfersReopened int64           `json:"transfers_reopened"`
+	HoldingsDeleted   int64           `json:"holdings_deleted"`
+	HoldingsRestored  int64           `json:"holdings_restored"`
+	WebhooksDropped   int64           `json:"webhooks_dropped"`
+	StatsClearedFrom  *time.Time      `json:"stats_cleared_from,omitempty"`
+	Balances          []BalanceChange `json:"balances"`
+	Tokens            []TokenChange   `json:"tokens"`
+}
+
+// RebuildReport describes a rebuild of balances, total supplies and holder
+// counts from the stored events and holdings at Offset, listing only the values
+// that differ from the stored ones. In a dry run nothing is written.
+type RebuildReport struct {
+	DryRun   bool            `json:"dry_run"`
+	Offset   int64           `json:"offset"`
+	Balances []BalanceChange `json:"balances"`
+	Tokens   []TokenChange   `json:"tokens"`
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package reindex
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+)
+
+const maxRequestBodyBytes = 1 << 10 // 1KB
+
+// RewindRequest is the body of POST /indexer/v1/admin/reindex/rewind.
+type RewindRequest struct {
+	Offset *int64 `json:"offset"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// RebuildRequest is the body of POST /indexer/v1/admin/reindex/rebuild.
+type RebuildRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// HTTP wraps the Service to provide HTTP endpoints.
+type HTTP struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// RegisterPrivateRoutes registers the re-index admin API on the given chi
+// router, under /indexer/v1/admin/reindex. A rewind or rebuild holds the
+// processor paused for as long as it runs, so the routes are meant for
+// operators only.
+func RegisterPrivateRoutes(r chi.Router, svc Service, logger *zap.Logger) {
+	h := &HTTP{service: svc, logger: logger}
+
+	r.Route("/indexer/v1/admin/reindex", func(r chi.Router) {
+		r.Post("/rewind", apphttp.HandleError(h.rewind))
+		r.Post("/rebuild", apphttp.HandleError(h.rebuild))
+	})
+}
+
+func (h *HTTP) rewind(w http.ResponseWriter, r *http.Request) error {
+	var req RewindRequest
+	if err := decodeBody(r, &req); err != nil {
+		return err
+	}
+	if req.Offset == nil {
+		return apperrors.BadRequestError(nil, "offset is required")
+	}
+	report, err := h.service.Rewind(r.Context(), *req.Offset, req.DryRun)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, report)
+	return nil
+}
+
+func (h *HTTP) rebuild(w http.ResponseWriter, r *http.Request) error {
+	var req RebuildRequest
+	if err := decodeBody(r, &req); err != nil {
+		return err
+	}
+	report, err := h.service.Rebuild(r.Context(), req.DryRun)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, report)
+	return nil
+}
+
+// decodeBody decodes a JSON request body into v. An empty body leaves v as is.
+func decodeBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return apperrors.BadRequestError(err, "invalid JSON")
+	}
+	return nil
+}
+
+func (h *HTTP) writeJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to write JSON response", zap.Error(err))
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package reindex_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/reindex"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/reindex/mocks"
+)
+
+const basePath = "/indexer/v1/admin/reindex"
+
+func newTestServer(t *testing.T) (*httptest.Server, *mocks.Service) {
+	t.Helper()
+	svc := mocks.NewService(t)
+	r := chi.NewRouter()
+	reindex.RegisterPrivateRoutes(r, svc, zap.NewNop())
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, svc
+}
+
+func post(t *testing.T, srv *httptest.Server, path string, body io.Reader) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+path, body)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestHTTP_Rewind(t *testing.T) {
+	t.Run("dry run returns the report", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		want := &indexer.RewindReport{
+			DryRun: true, FromOffset: 10, ToOffset: 4, EventsDeleted: 2,
+			Balances: []indexer.BalanceChange{{PartyID: "alice::1", InstrumentAdmin: "admin::1", InstrumentID: "DEMO", Before: "5", After: "3"}},
+		}
+		svc.EXPECT().Rewind(mock.Anything, int64(4), true).Return(want, nil)
+
+		resp := post(t, srv, basePath+"/rewind", strings.NewReader(`{"offset":4,"dry_run":true}`))
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var got indexer.RewindReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		assert.Equal(t, *want, got)
+	})
+
+	t.Run("offset 0 rewinds to the start", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Rewind(mock.Anything, int64(0), false).Return(&indexer.RewindReport{FromOffset: 10}, nil)
+
+		resp := post(t, srv, basePath+"/rewind", strings.NewReader(`{"offset":0}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"missing offset": `{"dry_run":true}`,
+			"unknown field":  `{"offset":1,"force":true}`,
+			"malformed":      `{"offset":`,
+		} {
+			srv, _ := newTestServer(t)
+			resp := post(t, srv, basePath+"/rewind", strings.NewReader(body))
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, name)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Rewind(mock.Anything, int64(12), false).
+			Return(nil, apperr.BadRequestError(nil, "offset must be before the indexed offset 10"))
+
+		resp := post(t, srv, basePath+"/rewind", strings.NewReader(`{"offset":12}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestHTTP_Rebuild(t *testing.T) {
+	t.Run("empty body rebuilds", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Rebuild(mock.Anything, false).Return(&indexer.RebuildReport{Offset: 10}, nil)
+
+		resp := post(t, srv, basePath+"/rebuild", http.NoBody)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var got indexer.RebuildReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		assert.Equal(t, int64(10), got.Offset)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Rebuild(mock.Anything, true).Return(&indexer.RebuildReport{DryRun: true}, nil)
+
+		resp := post(t, srv, basePath+"/rebuild", strings.NewReader(`{"dry_run":true}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Rebuild(mock.Anything, false).Return(nil, apperr.ConflictError(nil, "a rewind or rebuild is already running"))
+
+		resp := post(t, srv, basePath+"/rebuild", strings.NewReader(`{}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
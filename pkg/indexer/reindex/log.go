@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package reindex
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const reindexServiceName = "ReindexService"
+
+// logService wraps Service with automatic logging of all method calls.
+type logService struct {
+	svc    Service
+	logger *zap.Logger
+}
+
+// NewLog creates a logging decorator for the reindex Service.
+func NewLog(svc Service, logger *zap.Logger) Service {
+	return &logService{svc: svc, logger: logger}
+}
+
+func (ls *logService) Rewind(ctx context.Context, offset int64, dryRun bool) (report *indexer.RewindReport, err error) {
+	start := time.Now()
+	ls.logger.Info("Rewind started",
+		zap.String("service", reindexServiceName),
+		zap.Int64("offset", offset),
+		zap.Bool("dry_run", dryRun),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("Rewind failed",
+				zap.String("service", reindexServiceName),
+				zap.Int64("offset", offset),
+				zap.Bool("dry_run", dryRun),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("Rewind completed",
+				zap.String("service", reindexServiceName),
+				zap.Int64("from_offset", report.FromOffset),
+				zap.Int64("offset", offset),
+				zap.Bool("dry_run", dryRun),
+				zap.Int64("events_deleted", report.EventsDeleted),
+				zap.Int("balances_changed", len(report.Balances)),
+				zap.Int("tokens_changed", len(report.Tokens)),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.Rewind(ctx, offset, dryRun)
+}
+
+func (ls *logService) Rebuild(ctx context.Context, dryRun bool) (report *indexer.RebuildReport, err error) {
+	start := time.Now()
+	ls.logger.Info("Rebuild started",
+		zap.String("service", reindexServiceName),
+		zap.Bool("dry_run", dryRun),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("Rebuild failed",
+				zap.String("service", reindexServiceName),
+				zap.Bool("dry_run", dryRun),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("Rebuild completed",
+				zap.String("service", reindexServiceName),
+				zap.Int64("offset", report.Offset),
+				zap.Bool("dry_run", dryRun),
+				zap.Int("balances_changed", len(report.Balances)),
+				zap.Int("tokens_changed", len(report.Tokens)),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.Rebuild(ctx, dryRun)
+}
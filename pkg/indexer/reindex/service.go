@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reindex repairs the indexed state without re-streaming the ledger
+// from its beginning.
+//
+// A rewind returns the state to a past ledger offset — deleting what was
+// indexed after it and restoring balances, supplies and holder counts from
+// their history — and sets the stored offset there, so the processor streams
+// the rewound range again. A rebuild recomputes balances, supplies and holder
+// counts from the stored events and holdings and overwrites the ones that
+// drifted. Both have a dry-run mode that reports the changes without making
+// them.
+//
+// In the indexer process the processor is paused while a rewind or rebuild
+// runs; the reindex command runs them against a stopped indexer.
+package reindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/engine"
+)
+
+// Store is the persistence interface the Service needs.
+//
+//go:generate mockery --name Store --output mocks --outpkg mocks --filename mock_store.go --with-expecter
+type Store interface {
+	// LatestOffset returns the last persisted ledger offset, or 0 on a fresh start.
+	LatestOffset(ctx context.Context) (int64, error)
+	// Rewind returns the indexed state to offset, in one transaction that is
+	// rolled back when dryRun is set. offset must be before the stored offset.
+	Rewind(ctx context.Context, offset int64, dryRun bool) (*indexer.RewindReport, error)
+	// Rebuild recomputes balances, supplies and holder counts from the stored
+	// events and holdings, in one transaction rolled back when dryRun is set.
+	Rebuild(ctx context.Context, dryRun bool) (*indexer.RebuildReport, error)
+}
+
+// Pauser stops the indexer processor while fn rewrites the indexed state and
+// resumes it from the stored offset afterwards. *engine.Processor implements it.
+//
+//go:generate mockery --name Pauser --output mocks --outpkg mocks --filename mock_pauser.go --with-expecter
+type Pauser interface {
+	Pause(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Service rewinds and rebuilds the indexed state.
+//
+//go:generate mockery --name Service --output mocks --outpkg mocks --filename mock_service.go --with-expecter
+type Service interface {
+	// Rewind returns the indexed state to offset, which must be before the
+	// indexed offset, so the processor indexes everything after it again.
+	Rewind(ctx context.Context, offset int64, dryRun bool) (*indexer.RewindReport, error)
+	// Rebuild recomputes the derived tables from the stored events and holdings.
+	Rebuild(ctx context.Context, dryRun bool) (*indexer.RebuildReport, error)
+}
+
+// NewService creates a Service. pauser is the running processor to pause
+// around writes; nil when the indexer is stopped, as in the reindex command.
+// Dry runs never pause the processor.
+func NewService(store Store, pauser Pauser, logger *zap.Logger) Service {
+	return &svc{store: store, pauser: pauser, logger: logger}
+}
+
+type svc struct {
+	store  Store
+	pauser Pauser
+	logger *zap.Logger
+
+	mu sync.Mutex // one rewind or rebuild at a time
+}
+
+func (s *svc) Rewind(ctx context.Context, offset int64, dryRun bool) (*indexer.RewindReport, error) {
+	if offset < 0 {
+		return nil, apperrors.BadRequestError(nil, "offset must be >= 0")
+	}
+	latest, err := s.store.LatestOffset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= latest {
+		return nil, apperrors.BadRequestError(nil, fmt.Sprintf("offset must be before the indexed offset %d", latest))
+	}
+
+	var report *indexer.RewindReport
+	err = s.run(ctx, dryRun, func(ctx context.Context) error {
+		report, err = s.store.Rewind(ctx, offset, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *svc) Rebuild(ctx context.Context, dryRun bool) (*indexer.RebuildReport, error) {
+	var (
+		report *indexer.RebuildReport
+		err    error
+	)
+	err = s.run(ctx, dryRun, func(ctx context.Context) error {
+		report, err = s.store.Rebuild(ctx, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// run calls fn, with the processor paused unless dryRun is set or there is no
+// processor to pause. Concurrent calls are rejected rather than queued.
+func (s *svc) run(ctx context.Context, dryRun bool, fn func(ctx context.Context) error) error {
+	if !s.mu.TryLock() {
+		return apperrors.ConflictError(nil, "a rewind or rebuild is already running")
+	}
+	defer s.mu.Unlock()
+
+	if dryRun || s.pauser == nil {
+		return fn(ctx)
+	}
+	err := s.pauser.Pause(ctx, fn)
+	if errors.Is(err, engine.ErrNotRunning) {
+		return apperrors.UnavailableError(err, "the indexer processor is not running")
+	}
+	return err
+}
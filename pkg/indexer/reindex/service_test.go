@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package reindex_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/engine"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/reindex"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/reindex/mocks"
+)
+
+// runPaused makes pauser run fn directly, as a paused processor would.
+func runPaused(pauser *mocks.Pauser) {
+	pauser.EXPECT().Pause(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func TestSvc_Rewind(t *testing.T) {
+	t.Run("rewinds with the processor paused", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		pauser := mocks.NewPauser(t)
+		want := &indexer.RewindReport{FromOffset: 10, ToOffset: 4, EventsDeleted: 3}
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		runPaused(pauser)
+		store.EXPECT().Rewind(mock.Anything, int64(4), false).Return(want, nil)
+
+		got, err := reindex.NewService(store, pauser, zap.NewNop()).Rewind(context.Background(), 4, false)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("dry run does not pause", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		want := &indexer.RewindReport{DryRun: true, FromOffset: 10, ToOffset: 0}
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		store.EXPECT().Rewind(mock.Anything, int64(0), true).Return(want, nil)
+
+		// The strict pauser mock fails the test if Pause is called.
+		got, err := reindex.NewService(store, mocks.NewPauser(t), zap.NewNop()).Rewind(context.Background(), 0, true)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("without a processor rewinds directly", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		store.EXPECT().Rewind(mock.Anything, int64(9), false).Return(&indexer.RewindReport{}, nil)
+
+		_, err := reindex.NewService(store, nil, zap.NewNop()).Rewind(context.Background(), 9, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("offset not before the indexed offset", func(t *testing.T) {
+		for _, offset := range []int64{10, 11} {
+			store := mocks.NewStore(t)
+			store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+
+			_, err := reindex.NewService(store, nil, zap.NewNop()).Rewind(context.Background(), offset, false)
+			assert.True(t, apperrors.Is(err, apperrors.CategoryDataError), "offset %d", offset)
+		}
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		_, err := reindex.NewService(mocks.NewStore(t), nil, zap.NewNop()).Rewind(context.Background(), -1, false)
+		assert.True(t, apperrors.Is(err, apperrors.CategoryDataError))
+	})
+
+	t.Run("processor not running", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		pauser := mocks.NewPauser(t)
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		pauser.EXPECT().Pause(mock.Anything, mock.Anything).Return(engine.ErrNotRunning)
+
+		_, err := reindex.NewService(store, pauser, zap.NewNop()).Rewind(context.Background(), 4, false)
+		assert.True(t, apperrors.Is(err, apperrors.CategoryRecovering))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		storeErr := errors.New("db down")
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		store.EXPECT().Rewind(mock.Anything, int64(4), false).Return(nil, storeErr)
+
+		_, err := reindex.NewService(store, nil, zap.NewNop()).Rewind(context.Background(), 4, false)
+		assert.ErrorIs(t, err, storeErr)
+	})
+}
+
+func TestSvc_Rebuild(t *testing.T) {
+	t.Run("rebuilds with the processor paused", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		pauser := mocks.NewPauser(t)
+		want := &indexer.RebuildReport{Offset: 10, Balances: []indexer.BalanceChange{{PartyID: "alice::1", Before: "1", After: "2"}}}
+		runPaused(pauser)
+		store.EXPECT().Rebuild(mock.Anything, false).Return(want, nil)
+
+		got, err := reindex.NewService(store, pauser, zap.NewNop()).Rebuild(context.Background(), false)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("rejects a concurrent run", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		pauser := mocks.NewPauser(t)
+		svc := reindex.NewService(store, pauser, zap.NewNop())
+
+		pauser.EXPECT().Pause(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, _ func(context.Context) error) error {
+				// A second call while the first holds the processor paused.
+				_, err := svc.Rebuild(ctx, true)
+				assert.True(t, apperrors.Is(err, apperrors.CategoryDataConflict))
+				return nil
+			})
+
+		_, err := svc.Rebuild(context.Background(), false)
+		require.NoError(t, err)
+	})
+}
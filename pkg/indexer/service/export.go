@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// exportBatchSize is the page size exports read the store with.
+const exportBatchSize = 1000
+
+// Exports walk their list with cursors from before its first row: events are
+// listed oldest first, transfers newest first.
+var (
+	eventsStart    = indexer.Cursor{}
+	transfersStart = indexer.Cursor{LedgerOffset: math.MaxInt64}
+)
+
+func (s *svc) ExportTokenEvents(
+	ctx context.Context, admin, id string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error,
+) error {
+	f.InstrumentAdmin = admin
+	f.InstrumentID = id
+	return s.exportEvents(ctx, f, fn)
+}
+
+func (s *svc) ExportPartyEvents(
+	ctx context.Context, partyID string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error,
+) error {
+	f.PartyID = partyID
+	return s.exportEvents(ctx, f, fn)
+}
+
+func (s *svc) exportEvents(ctx context.Context, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error) error {
+	list := func(p indexer.Pagination) ([]*indexer.ParsedEvent, error) {
+		items, _, err := s.store.ListEvents(ctx, f, p)
+		return items, err
+	}
+	return exportPages(eventsStart, list, eventCursor, func(e *indexer.ParsedEvent) error {
+		return fn(&indexer.ExportedEvent{ParsedEvent: e, TokenInfo: s.tokenInfo(e.InstrumentAdmin, e.InstrumentID)})
+	})
+}
+
+func (s *svc) ExportTransfers(
+	ctx context.Context, partyID string, query indexer.TransferQuery, fn func(*indexer.ExportedTransfer) error,
+) error {
+	list := func(p indexer.Pagination) ([]indexer.Transfer, error) {
+		items, _, err := s.store.ListTransfers(ctx, partyID, query, p)
+		return items, err
+	}
+	return exportPages(transfersStart, list, transferCursor, func(t indexer.Transfer) error {
+		return fn(&indexer.ExportedTransfer{Transfer: &t, TokenInfo: s.tokenInfo(t.InstrumentAdmin, t.InstrumentID)})
+	})
+}
+
+// exportPages calls fn for every row of a list, reading it a page at a time,
+// each page starting after the last row of the one before.
+func exportPages[T any](
+	start indexer.Cursor, list func(indexer.Pagination) ([]T, error), cursorOf func(T) indexer.Cursor, fn func(T) error,
+) error {
+	p := indexer.Pagination{Limit: exportBatchSize, Cursor: &start}
+	for {
+		items, err := list(p)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if len(items) < p.Limit {
+			return nil
+		}
+		next := cursorOf(items[len(items)-1])
+		p.Cursor = &next
+	}
+}
+
+// tokenInfo returns the configured metadata of an instrument, defaulting the
+// symbol to the instrument ID.
+func (s *svc) tokenInfo(admin, id string) indexer.TokenInfo {
+	t, ok := s.tokens[indexer.InstrumentKey{Admin: admin, ID: id}]
+	if !ok {
+		return indexer.TokenInfo{Symbol: id}
+	}
+	return indexer.TokenInfo{Symbol: t.Symbol, Decimals: &t.Decimals}
+}
@@ -0,0 +1,6 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+// ExportBatchSize is the page size exports read the store with.
+const ExportBatchSize = exportBatchSize
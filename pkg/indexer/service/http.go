@@ -6,12 +6,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 
 	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
 	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
 	"github.com/chainsafe/canton-middleware/pkg/indexer"
 )
 
@@ -24,10 +27,12 @@ type HTTP struct {
 // RegisterPrivateRoutes registers the indexer admin API on the given chi router.
 // All routes are mounted under /indexer/v1/admin.
 //
-// These routes are unauthenticated and intended for internal/trusted callers only
-// (e.g. backend services, ops tooling). A public, JWT-protected read API will be
-// added in a future iteration — at that point these routes will remain separate.
-// Callers are responsible for restricting network access to this port.
+// Without authentication middleware in front of r, the routes are open and meant
+// for internal/trusted callers only; restrict network access to the port. When
+// jwt.RequireAuth runs first, service tokens see everything while end-user tokens
+// are confined to their own party: its /parties/{partyID} routes, the events and
+// transfers it is a party to, and token metadata. Cross-party listings (token
+// balances, holders and events, pending transfers) require a service token.
 func RegisterPrivateRoutes(r chi.Router, svc Service, logger *zap.Logger) {
 	h := &HTTP{service: svc, logger: logger}
 
@@ -35,20 +40,64 @@ func RegisterPrivateRoutes(r chi.Router, svc Service, logger *zap.Logger) {
 		r.Get("/tokens", apphttp.HandleError(h.listTokens))
 		r.Get("/tokens/{admin}/{id}", apphttp.HandleError(h.getToken))
 		r.Get("/tokens/{admin}/{id}/supply", apphttp.HandleError(h.getTokenSupply))
-		r.Get("/tokens/{admin}/{id}/balances", apphttp.HandleError(h.listTokenBalances))
-		r.Get("/tokens/{admin}/{id}/events", apphttp.HandleError(h.listTokenEvents))
 
-		r.Get("/parties/{partyID}/balances", apphttp.HandleError(h.listPartyBalances))
-		r.Get("/parties/{partyID}/balances/{admin}/{id}", apphttp.HandleError(h.getPartyBalance))
-		r.Get("/parties/{partyID}/events", apphttp.HandleError(h.listPartyEvents))
-		r.Get("/parties/{partyID}/transfers", apphttp.HandleError(h.listTransfers))
-		r.Get("/pending-transfers", apphttp.HandleError(h.listPendingTransfers))
+		r.Group(func(r chi.Router) {
+			r.Use(rejectUserTokens)
+			r.Get("/tokens/{admin}/{id}/balances", apphttp.HandleError(h.listTokenBalances))
+			r.Get("/tokens/{admin}/{id}/holders", apphttp.HandleError(h.listTokenHolders))
+			r.Get("/tokens/{admin}/{id}/events", apphttp.HandleError(h.listTokenEvents))
+			r.Get("/tokens/{admin}/{id}/stats", apphttp.HandleError(h.getTokenStats))
+			r.Get("/tokens/{admin}/{id}/stats/top-holders", apphttp.HandleError(h.getTopHolders))
+			r.Get("/tokens/{admin}/{id}/stats/distribution", apphttp.HandleError(h.getHolderDistribution))
+			r.Get("/pending-transfers", apphttp.HandleError(h.listPendingTransfers))
+		})
+
+		r.Route("/parties/{partyID}", func(r chi.Router) {
+			r.Use(RequireOwnParty)
+			r.Get("/balances", apphttp.HandleError(h.listPartyBalances))
+			r.Get("/balances/{admin}/{id}", apphttp.HandleError(h.getPartyBalance))
+			r.Get("/events", apphttp.HandleError(h.listPartyEvents))
+			r.Get("/transfers", apphttp.HandleError(h.listTransfers))
+		})
 
 		r.Get("/events/{contractID}", apphttp.HandleError(h.getEvent))
 		r.Get("/transfers/{contractID}", apphttp.HandleError(h.getTransfer))
 	})
 }
 
+// userParty returns the party an end-user token is confined to, or "" when the
+// request is unauthenticated (auth disabled) or carries a service token.
+func userParty(r *http.Request) string {
+	if auth.IsServiceFromContext(r.Context()) {
+		return ""
+	}
+	party, _ := auth.CantonPartyFromContext(r.Context())
+	return party
+}
+
+// rejectUserTokens answers 403 to end-user tokens.
+func rejectUserTokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userParty(r) != "" {
+			apphttp.DefaultErrorHandler(w, apperrors.ForbiddenError(nil, "service token required"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireOwnParty answers 403 to end-user tokens for a party other than the
+// {partyID} URL parameter.
+func RequireOwnParty(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if party := userParty(r); party != "" && party != chi.URLParam(r, "partyID") {
+			apphttp.DefaultErrorHandler(w, apperrors.ForbiddenError(nil, "token is not valid for this party"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (h *HTTP) listTokens(w http.ResponseWriter, r *http.Request) error {
 	p, err := parsePagination(r)
 	if err != nil {
@@ -76,6 +125,18 @@ func (h *HTTP) getToken(w http.ResponseWriter, r *http.Request) error {
 func (h *HTTP) getTokenSupply(w http.ResponseWriter, r *http.Request) error {
 	admin := chi.URLParam(r, "admin")
 	id := chi.URLParam(r, "id")
+	at, ok, err := parseAsOf(r)
+	if err != nil {
+		return err
+	}
+	if ok {
+		hs, err := h.service.SupplyAt(r.Context(), admin, id, at)
+		if err != nil {
+			return err
+		}
+		h.writeJSON(w, hs)
+		return nil
+	}
 	supply, err := h.service.TotalSupply(r.Context(), admin, id)
 	if err != nil {
 		return err
@@ -99,6 +160,78 @@ func (h *HTTP) listTokenBalances(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// listTokenHolders serves the holder list of a token at a point in history.
+// One of ?at_offset= or ?at_time= is required; the current holder list is
+// served by /balances.
+func (h *HTTP) listTokenHolders(w http.ResponseWriter, r *http.Request) error {
+	admin := chi.URLParam(r, "admin")
+	id := chi.URLParam(r, "id")
+	at, ok, err := parseAsOf(r)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperrors.BadRequestError(nil, "at_offset or at_time is required")
+	}
+	p, err := parsePagination(r)
+	if err != nil {
+		return err
+	}
+	page, err := h.service.ListHoldersAt(r.Context(), admin, id, at, p)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, page)
+	return nil
+}
+
+// getTokenStats serves a token's activity series. ?interval= is hour or day
+// (the default); ?since= and ?until= (RFC 3339) bound the range.
+func (h *HTTP) getTokenStats(w http.ResponseWriter, r *http.Request) error {
+	admin := chi.URLParam(r, "admin")
+	id := chi.URLParam(r, "id")
+	q, err := parseStatsQuery(r)
+	if err != nil {
+		return err
+	}
+	stats, err := h.service.TokenStats(r.Context(), admin, id, q)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, stats)
+	return nil
+}
+
+func (h *HTTP) getTopHolders(w http.ResponseWriter, r *http.Request) error {
+	admin := chi.URLParam(r, "admin")
+	id := chi.URLParam(r, "id")
+	limit := DefaultTopHolders
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil || v < 1 || v > MaxLimit {
+			return apperrors.BadRequestError(nil, "limit must be an integer between 1 and 200")
+		}
+		limit = v
+	}
+	top, err := h.service.TopHolders(r.Context(), admin, id, limit)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, top)
+	return nil
+}
+
+func (h *HTTP) getHolderDistribution(w http.ResponseWriter, r *http.Request) error {
+	admin := chi.URLParam(r, "admin")
+	id := chi.URLParam(r, "id")
+	dist, err := h.service.HolderDistribution(r.Context(), admin, id)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, dist)
+	return nil
+}
+
 func (h *HTTP) listTokenEvents(w http.ResponseWriter, r *http.Request) error {
 	admin := chi.URLParam(r, "admin")
 	id := chi.URLParam(r, "id")
@@ -106,11 +239,11 @@ func (h *HTTP) listTokenEvents(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
-	et, err := parseEventType(r)
+	f, err := parseEventFilter(r)
 	if err != nil {
 		return err
 	}
-	page, err := h.service.ListTokenEvents(r.Context(), admin, id, indexer.EventFilter{EventType: et}, p)
+	page, err := h.service.ListTokenEvents(r.Context(), admin, id, f, p)
 	if err != nil {
 		return err
 	}
@@ -136,6 +269,18 @@ func (h *HTTP) getPartyBalance(w http.ResponseWriter, r *http.Request) error {
 	partyID := chi.URLParam(r, "partyID")
 	admin := chi.URLParam(r, "admin")
 	id := chi.URLParam(r, "id")
+	at, ok, err := parseAsOf(r)
+	if err != nil {
+		return err
+	}
+	if ok {
+		hb, err := h.service.GetBalanceAt(r.Context(), partyID, admin, id, at)
+		if err != nil {
+			return err
+		}
+		h.writeJSON(w, hb)
+		return nil
+	}
 	b, err := h.service.GetBalance(r.Context(), partyID, admin, id)
 	if err != nil {
 		return err
@@ -150,11 +295,11 @@ func (h *HTTP) listPartyEvents(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
-	et, err := parseEventType(r)
+	f, err := parseEventFilter(r)
 	if err != nil {
 		return err
 	}
-	page, err := h.service.ListPartyEvents(r.Context(), partyID, indexer.EventFilter{EventType: et}, p)
+	page, err := h.service.ListPartyEvents(r.Context(), partyID, f, p)
 	if err != nil {
 		return err
 	}
@@ -168,6 +313,10 @@ func (h *HTTP) getEvent(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	// Someone else's event is reported as missing so its existence isn't leaked.
+	if party := userParty(r); party != "" && !isParty(party, e.FromPartyID, e.ToPartyID) {
+		return apperrors.ResourceNotFoundError(nil, "event not found")
+	}
 	h.writeJSON(w, e)
 	return nil
 }
@@ -178,10 +327,23 @@ func (h *HTTP) getTransfer(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	if party := userParty(r); party != "" && party != t.FromPartyID && party != t.ToPartyID {
+		return apperrors.ResourceNotFoundError(nil, "transfer not found")
+	}
 	h.writeJSON(w, t)
 	return nil
 }
 
+// isParty reports whether party is one of the (optional) event parties.
+func isParty(party string, parties ...*string) bool {
+	for _, p := range parties {
+		if p != nil && *p == party {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *HTTP) listTransfers(w http.ResponseWriter, r *http.Request) error {
 	partyID := chi.URLParam(r, "partyID")
 	p, err := parsePagination(r)
@@ -200,10 +362,16 @@ func (h *HTTP) listTransfers(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// parseTransferQuery reads ?role= and ?status= into an indexer.TransferQuery.
-// role defaults to receiver (incoming); status defaults to all.
+// parseTransferQuery reads ?role=, ?status= and the history filters into an
+// indexer.TransferQuery. role defaults to receiver (incoming); status defaults
+// to all.
 func parseTransferQuery(r *http.Request) (indexer.TransferQuery, error) {
 	q := indexer.TransferQuery{Role: indexer.TransferRoleReceiver}
+	hf, err := parseHistoryFilter(r)
+	if err != nil {
+		return q, err
+	}
+	q.HistoryFilter = hf
 
 	switch r.URL.Query().Get("role") {
 	case "", "receiver":
@@ -229,6 +397,110 @@ func parseTransferQuery(r *http.Request) (indexer.TransferQuery, error) {
 	return q, nil
 }
 
+// parseEventFilter reads ?event_type=, ?external_tx_id= and the history
+// filters into an indexer.EventFilter.
+func parseEventFilter(r *http.Request) (indexer.EventFilter, error) {
+	et, err := parseEventType(r)
+	if err != nil {
+		return indexer.EventFilter{}, err
+	}
+	hf, err := parseHistoryFilter(r)
+	if err != nil {
+		return indexer.EventFilter{}, err
+	}
+	return indexer.EventFilter{EventType: et, ExternalTxID: r.URL.Query().Get("external_tx_id"), HistoryFilter: hf}, nil
+}
+
+// parseHistoryFilter reads ?counterparty=, ?from_offset= and ?to_offset=,
+// ?since= and ?until= (RFC 3339), and ?min_amount= and ?max_amount= into an
+// indexer.HistoryFilter.
+func parseHistoryFilter(r *http.Request) (indexer.HistoryFilter, error) {
+	q := r.URL.Query()
+	f := indexer.HistoryFilter{Counterparty: q.Get("counterparty")}
+	var err error
+	if f.FromOffset, err = parseOffsetParam(q.Get("from_offset"), "from_offset"); err != nil {
+		return f, err
+	}
+	if f.ToOffset, err = parseOffsetParam(q.Get("to_offset"), "to_offset"); err != nil {
+		return f, err
+	}
+	if f.ToOffset > 0 && f.FromOffset > f.ToOffset {
+		return f, apperrors.BadRequestError(nil, "from_offset must not be after to_offset")
+	}
+	if f.Since, err = parseTimeParam(q.Get("since"), "since"); err != nil {
+		return f, err
+	}
+	if f.Until, err = parseTimeParam(q.Get("until"), "until"); err != nil {
+		return f, err
+	}
+	if !f.Until.IsZero() && !f.Since.Before(f.Until) {
+		return f, apperrors.BadRequestError(nil, "since must be before until")
+	}
+	return f, parseAmountRange(q.Get("min_amount"), q.Get("max_amount"), &f)
+}
+
+func parseOffsetParam(s, name string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < 1 {
+		return 0, apperrors.BadRequestError(nil, name+" must be an integer >= 1")
+	}
+	return v, nil
+}
+
+func parseTimeParam(s, name string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, apperrors.BadRequestError(nil, name+" must be an RFC 3339 timestamp")
+	}
+	return t.UTC(), nil
+}
+
+func parseStatsQuery(r *http.Request) (indexer.StatsQuery, error) {
+	q := r.URL.Query()
+	sq := indexer.StatsQuery{Interval: indexer.StatsIntervalDay}
+	if v := q.Get("interval"); v != "" {
+		sq.Interval = indexer.StatsInterval(v)
+		if sq.Interval.Duration() == 0 {
+			return sq, apperrors.BadRequestError(nil, "interval must be hour or day")
+		}
+	}
+	var err error
+	if sq.Since, err = parseTimeParam(q.Get("since"), "since"); err != nil {
+		return sq, err
+	}
+	if sq.Until, err = parseTimeParam(q.Get("until"), "until"); err != nil {
+		return sq, err
+	}
+	return sq, nil
+}
+
+// parseAmountRange validates the decimal bounds and sets them on f.
+func parseAmountRange(minStr, maxStr string, f *indexer.HistoryFilter) error {
+	var lo, hi decimal.Decimal
+	var err error
+	if minStr != "" {
+		if lo, err = decimal.NewFromString(minStr); err != nil {
+			return apperrors.BadRequestError(nil, "min_amount must be a decimal number")
+		}
+	}
+	if maxStr != "" {
+		if hi, err = decimal.NewFromString(maxStr); err != nil {
+			return apperrors.BadRequestError(nil, "max_amount must be a decimal number")
+		}
+	}
+	if minStr != "" && maxStr != "" && lo.GreaterThan(hi) {
+		return apperrors.BadRequestError(nil, "min_amount must not exceed max_amount")
+	}
+	f.MinAmount, f.MaxAmount = minStr, maxStr
+	return nil
+}
+
 func (h *HTTP) listPendingTransfers(w http.ResponseWriter, r *http.Request) error {
 	p, err := parsePagination(r)
 	if err != nil {
@@ -242,25 +514,71 @@ func (h *HTTP) listPendingTransfers(w http.ResponseWriter, r *http.Request) erro
 	return nil
 }
 
+// parsePagination reads ?page= or ?cursor=, ?limit= and ?with_total=.
 func parsePagination(r *http.Request) (indexer.Pagination, error) {
+	q := r.URL.Query()
 	p := indexer.Pagination{Page: 1, Limit: DefaultLimit}
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+	if pageStr := q.Get("page"); pageStr != "" {
 		v, err := strconv.Atoi(pageStr)
 		if err != nil || v < 1 {
 			return p, apperrors.BadRequestError(nil, "page must be an integer >= 1")
 		}
 		p.Page = v
 	}
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+	if limitStr := q.Get("limit"); limitStr != "" {
 		v, err := strconv.Atoi(limitStr)
 		if err != nil || v < 1 || v > MaxLimit {
 			return p, apperrors.BadRequestError(nil, "limit must be an integer between 1 and 200")
 		}
 		p.Limit = v
 	}
+	if cursorStr := q.Get("cursor"); cursorStr != "" {
+		if q.Has("page") {
+			return p, apperrors.BadRequestError(nil, "cursor and page are mutually exclusive")
+		}
+		c, err := indexer.ParseCursor(cursorStr)
+		if err != nil {
+			return p, apperrors.BadRequestError(err, "invalid cursor")
+		}
+		p.Page, p.Cursor = 0, c
+	}
+	if totalStr := q.Get("with_total"); totalStr != "" {
+		v, err := strconv.ParseBool(totalStr)
+		if err != nil {
+			return p, apperrors.BadRequestError(nil, "with_total must be true or false")
+		}
+		p.WithTotal = v
+	}
 	return p, nil
 }
 
+// parseAsOf reads ?at_offset= (a ledger offset >= 1) or ?at_time= (RFC 3339)
+// into an indexer.AsOf. ok is false when neither is set; setting both is an
+// error.
+func parseAsOf(r *http.Request) (at indexer.AsOf, ok bool, err error) {
+	offsetStr := r.URL.Query().Get("at_offset")
+	timeStr := r.URL.Query().Get("at_time")
+	switch {
+	case offsetStr != "" && timeStr != "":
+		return at, false, apperrors.BadRequestError(nil, "at_offset and at_time are mutually exclusive")
+	case offsetStr != "":
+		v, err := strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil || v < 1 {
+			return at, false, apperrors.BadRequestError(nil, "at_offset must be an integer >= 1")
+		}
+		at.Offset = v
+	case timeStr != "":
+		t, err := time.Parse(time.RFC3339, timeStr)
+		if err != nil {
+			return at, false, apperrors.BadRequestError(nil, "at_time must be an RFC 3339 timestamp")
+		}
+		at.Time = t.UTC()
+	default:
+		return at, false, nil
+	}
+	return at, true, nil
+}
+
 func parseEventType(r *http.Request) (indexer.EventType, error) {
 	et := r.URL.Query().Get("event_type")
 	if et == "" {
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const (
+	contentTypeCSV    = "text/csv; charset=utf-8"
+	contentTypeNDJSON = "application/x-ndjson"
+
+	// exportFlushRows is how many rows an export writes between flushes.
+	exportFlushRows = 500
+	// exportWriteTimeout bounds each flush, replacing the server's write
+	// timeout, which an export of millions of rows would outlast.
+	exportWriteTimeout = 30 * time.Second
+)
+
+// RegisterExportRoutes registers the history export endpoints, which stream
+// every row matching the list filters as CSV (?format=csv or Accept: text/csv)
+// or JSON lines (the default). Exports run as long as they take, so mount
+// them outside any request timeout. Access rules are those of the matching
+// lists (see RegisterPrivateRoutes).
+func RegisterExportRoutes(r chi.Router, svc Service, logger *zap.Logger) {
+	h := &HTTP{service: svc, logger: logger}
+
+	r.With(rejectUserTokens).
+		Get("/indexer/v1/admin/tokens/{admin}/{id}/events/export", apphttp.HandleError(h.exportTokenEvents))
+	r.With(RequireOwnParty).
+		Get("/indexer/v1/admin/parties/{partyID}/events/export", apphttp.HandleError(h.exportPartyEvents))
+	r.With(RequireOwnParty).
+		Get("/indexer/v1/admin/parties/{partyID}/transfers/export", apphttp.HandleError(h.exportTransfers))
+}
+
+func (h *HTTP) exportTokenEvents(w http.ResponseWriter, r *http.Request) error {
+	admin := chi.URLParam(r, "admin")
+	id := chi.URLParam(r, "id")
+	f, err := parseEventFilter(r)
+	if err != nil {
+		return err
+	}
+	return writeExport(w, r, h.logger, "events", eventColumns, eventRow, func(fn func(*indexer.ExportedEvent) error) error {
+		return h.service.ExportTokenEvents(r.Context(), admin, id, f, fn)
+	})
+}
+
+func (h *HTTP) exportPartyEvents(w http.ResponseWriter, r *http.Request) error {
+	partyID := chi.URLParam(r, "partyID")
+	f, err := parseEventFilter(r)
+	if err != nil {
+		return err
+	}
+	return writeExport(w, r, h.logger, "events", eventColumns, eventRow, func(fn func(*indexer.ExportedEvent) error) error {
+		return h.service.ExportPartyEvents(r.Context(), partyID, f, fn)
+	})
+}
+
+func (h *HTTP) exportTransfers(w http.ResponseWriter, r *http.Request) error {
+	partyID := chi.URLParam(r, "partyID")
+	query, err := parseTransferQuery(r)
+	if err != nil {
+		return err
+	}
+	return writeExport(w, r, h.logger, "transfers", transferColumns, transferRow,
+		func(fn func(*indexer.ExportedTransfer) error) error {
+			return h.service.ExportTransfers(r.Context(), partyID, query, fn)
+		})
+}
+
+// writeExport streams the rows run produces in the requested format. The
+// response starts with the first row, so an error before it is answered as
+// usual. Once rows have been sent the status can no longer change: the
+// connection is aborted instead, so a client never mistakes a truncated
+// export for a complete one.
+func writeExport[T any](
+	w http.ResponseWriter, r *http.Request, logger *zap.Logger, name string,
+	columns []string, row func(T) []string, run func(fn func(T) error) error,
+) error {
+	asCSV, err := wantsCSV(r)
+	if err != nil {
+		return err
+	}
+	out := &exportWriter{w: w, rc: http.NewResponseController(w), asCSV: asCSV, name: name, columns: columns}
+	err = run(func(v T) error {
+		if asCSV {
+			return out.write(row(v))
+		}
+		return out.write(v)
+	})
+	if err == nil {
+		err = out.finish()
+	}
+	if err != nil && out.started {
+		logger.Error("export aborted", zap.String("export", name), zap.Int("rows", out.rows), zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+	return err
+}
+
+// wantsCSV reads the export format from ?format=, falling back to the Accept header.
+func wantsCSV(r *http.Request) (bool, error) {
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		return true, nil
+	case "ndjson", "jsonl":
+		return false, nil
+	case "":
+		return strings.Contains(r.Header.Get("Accept"), "text/csv"), nil
+	default:
+		return false, apperrors.BadRequestError(nil, "format must be csv or ndjson")
+	}
+}
+
+// exportWriter writes export rows, sending the response header with the first.
+type exportWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	asCSV   bool
+	name    string
+	columns []string
+
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+	rows    int
+}
+
+// write writes one row: a []string for CSV, any JSON value otherwise.
+func (e *exportWriter) write(v any) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	var err error
+	if e.asCSV {
+		err = e.csv.Write(v.([]string))
+	} else {
+		err = e.json.Encode(v)
+	}
+	if err != nil {
+		return err
+	}
+	if e.rows++; e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// finish sends whatever is buffered, and the header of an empty export.
+func (e *exportWriter) finish() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.flush()
+}
+
+func (e *exportWriter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	if err := e.extendDeadline(); err != nil {
+		return err
+	}
+	ext, contentType := "jsonl", contentTypeNDJSON
+	if e.asCSV {
+		ext, contentType = "csv", contentTypeCSV
+	}
+	e.w.Header().Set("Content-Type", contentType)
+	e.w.Header().Set("Content-Disposition", `attachment; filename="`+e.name+"."+ext+`"`)
+	e.w.WriteHeader(http.StatusOK)
+	if !e.asCSV {
+		e.json = json.NewEncoder(e.w)
+		return nil
+	}
+	e.csv = csv.NewWriter(e.w)
+	return e.csv.Write(e.columns)
+}
+
+func (e *exportWriter) flush() error {
+	if e.asCSV {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := e.rc.Flush(); err != nil {
+		return err
+	}
+	return e.extendDeadline()
+}
+
+func (e *exportWriter) extendDeadline() error {
+	err := e.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+var eventColumns = []string{
+	"contract_id", "tx_id", "ledger_offset", "effective_time", "timestamp", "event_type",
+	"instrument_admin", "instrument_id", "symbol", "decimals", "amount",
+	"from_party_id", "to_party_id", "external_tx_id", "external_address", "fingerprint",
+}
+
+func eventRow(e *indexer.ExportedEvent) []string {
+	return []string{
+		e.ContractID, e.TxID, strconv.FormatInt(e.LedgerOffset, 10),
+		e.EffectiveTime.Format(time.RFC3339Nano), e.Timestamp.Format(time.RFC3339Nano), string(e.EventType),
+		e.InstrumentAdmin, e.InstrumentID, e.Symbol, formatDecimals(e.Decimals), e.Amount,
+		deref(e.FromPartyID), deref(e.ToPartyID), deref(e.ExternalTxID), deref(e.ExternalAddress), deref(e.Fingerprint),
+	}
+}
+
+var transferColumns = []string{
+	"contract_id", "tx_id", "ledger_offset", "created_at", "kind", "status",
+	"instrument_admin", "instrument_id", "symbol", "decimals", "amount",
+	"from_party_id", "to_party_id", "expires_at",
+}
+
+func transferRow(t *indexer.ExportedTransfer) []string {
+	expiresAt := ""
+	if t.ExpiresAt != nil {
+		expiresAt = t.ExpiresAt.Format(time.RFC3339Nano)
+	}
+	return []string{
+		t.ContractID, t.TxID, strconv.FormatInt(t.LedgerOffset, 10), t.CreatedAt.Format(time.RFC3339Nano), t.Kind, t.Status,
+		t.InstrumentAdmin, t.InstrumentID, t.Symbol, formatDecimals(t.Decimals), t.Amount,
+		t.FromPartyID, t.ToPartyID, expiresAt,
+	}
+}
+
+func formatDecimals(d *int) string {
+	if d == nil {
+		return ""
+	}
+	return strconv.Itoa(*d)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -4,11 +4,14 @@ package service_test
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +20,7 @@ import (
 	"go.uber.org/zap"
 
 	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
 	"github.com/chainsafe/canton-middleware/pkg/indexer"
 	"github.com/chainsafe/canton-middleware/pkg/indexer/service"
 	"github.com/chainsafe/canton-middleware/pkg/indexer/service/mocks"
@@ -32,11 +36,26 @@ type testEnv struct {
 
 func newTestEnv(t *testing.T) *testEnv {
 	t.Helper()
+	return newAuthTestEnv(t, nil)
+}
+
+// newAuthTestEnv is newTestEnv with info injected into every request context,
+// standing in for the JWT middleware. A nil info leaves requests unauthenticated.
+func newAuthTestEnv(t *testing.T, info *auth.AuthInfo) *testEnv {
+	t.Helper()
 
 	svcMock := mocks.NewService(t)
 
 	r := chi.NewRouter()
+	if info != nil {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(auth.WithAuthInfo(r.Context(), info)))
+			})
+		})
+	}
 	service.RegisterPrivateRoutes(r, svcMock, zap.NewNop())
+	service.RegisterExportRoutes(r, svcMock, zap.NewNop())
 
 	srv := httptest.NewServer(r)
 	t.Cleanup(srv.Close)
@@ -191,6 +210,41 @@ func TestHTTP_GetTokenSupply(t *testing.T) {
 		assert.Equal(t, "1000.0", body["total_supply"])
 	})
 
+	t.Run("at_offset returns historical supply", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().SupplyAt(mock.Anything, "admin-party", "DEMO", indexer.AsOf{Offset: 12}).
+			Return(&indexer.HistoricalSupply{InstrumentAdmin: "admin-party", InstrumentID: "DEMO",
+				TotalSupply: "400.0", HolderCount: 2, LedgerOffset: 9}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/supply?at_offset=12")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body := decodeJSON[indexer.HistoricalSupply](t, resp)
+		assert.Equal(t, "400.0", body.TotalSupply)
+		assert.Equal(t, int64(9), body.LedgerOffset)
+	})
+
+	t.Run("at_time is parsed as RFC 3339", func(t *testing.T) {
+		e := newTestEnv(t)
+		want := indexer.AsOf{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+		e.svc.EXPECT().SupplyAt(mock.Anything, "admin-party", "DEMO", want).
+			Return(&indexer.HistoricalSupply{TotalSupply: "1.0"}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/supply?at_time=2026-01-02T04:04:05%2B01:00")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+	})
+
+	t.Run("invalid as-of parameters return 400", func(t *testing.T) {
+		for _, q := range []string{"at_offset=0", "at_offset=abc", "at_time=yesterday", "at_offset=1&at_time=2026-01-02T03:04:05Z"} {
+			e := newTestEnv(t)
+			resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/supply?"+q)
+			resp.Body.Close()
+			assertStatus(t, resp, http.StatusBadRequest)
+		}
+	})
+
 	t.Run("not found returns 404", func(t *testing.T) {
 		e := newTestEnv(t)
 		e.svc.EXPECT().TotalSupply(mock.Anything, mock.Anything, mock.Anything).
@@ -238,8 +292,116 @@ func TestHTTP_ListTokenBalances(t *testing.T) {
 	})
 }
 
+// ─── GET /indexer/v1/admin/tokens/{admin}/{id}/holders ──────────────────────────────
+
+func TestHTTP_ListTokenHolders(t *testing.T) {
+	t.Run("success returns page", func(t *testing.T) {
+		e := newTestEnv(t)
+		holder := &indexer.HistoricalBalance{
+			Balance:      indexer.Balance{PartyID: e.partyID, InstrumentAdmin: "admin-party", InstrumentID: "DEMO", Amount: "75.0"},
+			LedgerOffset: 4,
+		}
+		e.svc.EXPECT().ListHoldersAt(mock.Anything, "admin-party", "DEMO", indexer.AsOf{Offset: 8}, indexer.Pagination{Page: 2, Limit: 10}).
+			Return(&indexer.Page[*indexer.HistoricalBalance]{
+				Items: []*indexer.HistoricalBalance{holder}, Total: 11, Page: 2, Limit: 10,
+			}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/holders?at_offset=8&page=2&limit=10")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		page := decodeJSON[indexer.Page[*indexer.HistoricalBalance]](t, resp)
+		assert.Equal(t, int64(11), page.Total)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, "75.0", page.Items[0].Amount)
+		assert.Equal(t, int64(4), page.Items[0].LedgerOffset)
+	})
+
+	t.Run("missing as-of returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/holders")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+}
+
 // ─── GET /indexer/v1/admin/tokens/{admin}/{id}/events ───────────────────────────────
 
+func TestHTTP_GetTokenStats(t *testing.T) {
+	t.Run("defaults to daily buckets", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().TokenStats(mock.Anything, "admin-party", "DEMO", indexer.StatsQuery{Interval: indexer.StatsIntervalDay}).
+			Return(&indexer.TokenStats{Interval: indexer.StatsIntervalDay, Buckets: []*indexer.TokenStatsBucket{{TransferCount: 4}}}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/stats")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		body := decodeJSON[indexer.TokenStats](t, resp)
+		require.Len(t, body.Buckets, 1)
+		assert.Equal(t, int64(4), body.Buckets[0].TransferCount)
+	})
+
+	t.Run("interval and range are forwarded", func(t *testing.T) {
+		e := newTestEnv(t)
+		want := indexer.StatsQuery{
+			Interval: indexer.StatsIntervalHour,
+			Since:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Until:    time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+		e.svc.EXPECT().TokenStats(mock.Anything, "admin-party", "DEMO", want).Return(&indexer.TokenStats{}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/stats?interval=hour&since=2026-01-01T00:00:00Z&until=2026-01-02T00:00:00Z")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+	})
+
+	t.Run("invalid parameters return 400", func(t *testing.T) {
+		for _, q := range []string{"interval=week", "since=yesterday", "until=1"} {
+			e := newTestEnv(t)
+			resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/stats?"+q)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, q)
+		}
+	})
+}
+
+func TestHTTP_TokenHolderStats(t *testing.T) {
+	t.Run("top holders default limit", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().TopHolders(mock.Anything, "admin-party", "DEMO", service.DefaultTopHolders).
+			Return(&indexer.TopHolders{Holders: []*indexer.RankedHolder{{Rank: 1, PartyID: alice, Amount: "5"}}}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/stats/top-holders")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		body := decodeJSON[indexer.TopHolders](t, resp)
+		require.Len(t, body.Holders, 1)
+		assert.Equal(t, alice, body.Holders[0].PartyID)
+	})
+
+	t.Run("top holders invalid limit returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/stats/top-holders?limit=0")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("distribution", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().HolderDistribution(mock.Anything, "admin-party", "DEMO").
+			Return(&indexer.HolderDistribution{HolderCount: 1, Buckets: []indexer.HolderBucket{{Min: "1", Max: "10", Holders: 1}}}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/stats/distribution")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		body := decodeJSON[indexer.HolderDistribution](t, resp)
+		assert.Equal(t, []indexer.HolderBucket{{Min: "1", Max: "10", Holders: 1}}, body.Buckets)
+	})
+}
+
 func TestHTTP_ListTokenEvents(t *testing.T) {
 	event := &indexer.ParsedEvent{
 		InstrumentAdmin: "admin-party",
@@ -327,6 +489,20 @@ func TestHTTP_GetPartyBalance(t *testing.T) {
 		assert.Equal(t, "750.0", got.Amount)
 	})
 
+	t.Run("at_offset returns historical balance", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().GetBalanceAt(mock.Anything, e.partyID, "admin-party", "DEMO", indexer.AsOf{Offset: 3}).
+			Return(&indexer.HistoricalBalance{Balance: *balance, LedgerOffset: 2}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/balances/admin-party/DEMO?at_offset=3")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		got := decodeJSON[indexer.HistoricalBalance](t, resp)
+		assert.Equal(t, "750.0", got.Amount)
+		assert.Equal(t, int64(2), got.LedgerOffset)
+	})
+
 	t.Run("not found returns 404", func(t *testing.T) {
 		e := newTestEnv(t)
 		e.svc.EXPECT().GetBalance(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -371,6 +547,197 @@ func TestHTTP_ListPartyEvents(t *testing.T) {
 	})
 }
 
+func TestHTTP_ListPartyEvents_Cursor(t *testing.T) {
+	next := indexer.Cursor{LedgerOffset: 8, ContractID: "contract-008"}
+
+	t.Run("cursor and with_total forwarded", func(t *testing.T) {
+		e := newTestEnv(t)
+		want := indexer.Pagination{Limit: 2, Cursor: &indexer.Cursor{LedgerOffset: 5, ContractID: "c"}, WithTotal: true}
+		e.svc.EXPECT().ListPartyEvents(mock.Anything, e.partyID, indexer.EventFilter{}, want).
+			Return(&indexer.Page[*indexer.ParsedEvent]{Total: 9, Limit: 2, NextCursor: &next}, nil)
+
+		cursor := want.Cursor.String()
+		resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/events?limit=2&with_total=true&cursor="+cursor)
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+
+		page := decodeJSON[indexer.Page[*indexer.ParsedEvent]](t, resp)
+		require.NotNil(t, page.NextCursor)
+		assert.Equal(t, next, *page.NextCursor)
+	})
+
+	for _, q := range []string{"?cursor=not-a-cursor", "?page=2&cursor=" + next.String(), "?with_total=maybe"} {
+		t.Run("invalid "+q, func(t *testing.T) {
+			e := newTestEnv(t)
+			resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/events"+q)
+			defer resp.Body.Close()
+			assertStatus(t, resp, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestHTTP_ListPartyEvents_HistoryFilters(t *testing.T) {
+	t.Run("filters forwarded", func(t *testing.T) {
+		e := newTestEnv(t)
+		want := indexer.EventFilter{
+			EventType:    indexer.EventTransfer,
+			ExternalTxID: "0xabc",
+			HistoryFilter: indexer.HistoryFilter{
+				Counterparty: "bob::1220",
+				FromOffset:   10,
+				ToOffset:     20,
+				Since:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:        time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				MinAmount:    "1.5",
+				MaxAmount:    "100",
+			},
+		}
+		e.svc.EXPECT().ListPartyEvents(mock.Anything, e.partyID, want, mock.Anything).
+			Return(&indexer.Page[*indexer.ParsedEvent]{Page: 1, Limit: 50}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/events?event_type=TRANSFER&external_tx_id=0xabc"+
+			"&counterparty=bob::1220&from_offset=10&to_offset=20&since=2026-01-01T00:00:00Z&until=2026-02-01T00:00:00Z"+
+			"&min_amount=1.5&max_amount=100")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+	})
+
+	for _, q := range []string{
+		"from_offset=0", "from_offset=5&to_offset=4", "since=yesterday",
+		"since=2026-02-01T00:00:00Z&until=2026-01-01T00:00:00Z", "min_amount=abc", "min_amount=10&max_amount=1",
+	} {
+		t.Run("invalid "+q, func(t *testing.T) {
+			e := newTestEnv(t)
+			resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/events?"+q)
+			defer resp.Body.Close()
+			assertStatus(t, resp, http.StatusBadRequest)
+		})
+	}
+}
+
+// ─── history exports ──────────────────────────────────────────────────────────────
+
+func TestHTTP_ExportPartyEvents_CSV(t *testing.T) {
+	e := newTestEnv(t)
+	decimals := 18
+	bob := "bob::1220"
+	event := &indexer.ExportedEvent{
+		ParsedEvent: &indexer.ParsedEvent{
+			ContractID: "c1", TxID: "tx1", LedgerOffset: 7, EventType: indexer.EventTransfer,
+			InstrumentAdmin: "admin-party", InstrumentID: "DEMO", Amount: "2.5",
+			FromPartyID: &e.partyID, ToPartyID: &bob,
+			EffectiveTime: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Timestamp:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		TokenInfo: indexer.TokenInfo{Symbol: "DMO", Decimals: &decimals},
+	}
+	want := indexer.EventFilter{HistoryFilter: indexer.HistoryFilter{MinAmount: "1"}}
+	e.svc.EXPECT().ExportPartyEvents(mock.Anything, e.partyID, want, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ indexer.EventFilter, fn func(*indexer.ExportedEvent) error) error {
+			return fn(event)
+		})
+
+	resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/events/export?format=csv&min_amount=1")
+	defer resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), `filename="events.csv"`)
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "contract_id", records[0][0])
+	assert.Equal(t, []string{
+		"c1", "tx1", "7", "2026-01-02T03:04:05Z", "2026-01-02T03:04:05Z", "TRANSFER",
+		"admin-party", "DEMO", "DMO", "18", "2.5", e.partyID, bob, "", "", "",
+	}, records[1])
+}
+
+func TestHTTP_ExportTransfers_NDJSON(t *testing.T) {
+	e := newTestEnv(t)
+	transfers := []*indexer.ExportedTransfer{
+		{Transfer: &indexer.Transfer{ContractID: "t2", Amount: "2"}, TokenInfo: indexer.TokenInfo{Symbol: "DEMO"}},
+		{Transfer: &indexer.Transfer{ContractID: "t1", Amount: "1"}, TokenInfo: indexer.TokenInfo{Symbol: "DEMO"}},
+	}
+	want := indexer.TransferQuery{Role: indexer.TransferRoleAny, HistoryFilter: indexer.HistoryFilter{Counterparty: "bob"}}
+	e.svc.EXPECT().ExportTransfers(mock.Anything, e.partyID, want, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ indexer.TransferQuery, fn func(*indexer.ExportedTransfer) error) error {
+			for _, tr := range transfers {
+				if err := fn(tr); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/transfers/export?role=any&counterparty=bob")
+	defer resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	dec := json.NewDecoder(resp.Body)
+	var got []string
+	for dec.More() {
+		var row struct {
+			ContractID string `json:"contract_id"`
+			Symbol     string `json:"symbol"`
+		}
+		require.NoError(t, dec.Decode(&row))
+		assert.Equal(t, "DEMO", row.Symbol)
+		got = append(got, row.ContractID)
+	}
+	assert.Equal(t, []string{"t2", "t1"}, got)
+}
+
+func TestHTTP_Export_Errors(t *testing.T) {
+	t.Run("error before the first row is answered", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().ExportPartyEvents(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("db error"))
+
+		resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/events/export")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusInternalServerError)
+	})
+
+	t.Run("error after rows aborts the response", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().ExportPartyEvents(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ string, _ indexer.EventFilter, fn func(*indexer.ExportedEvent) error) error {
+				if err := fn(&indexer.ExportedEvent{ParsedEvent: &indexer.ParsedEvent{ContractID: "c1"}}); err != nil {
+					return err
+				}
+				return errors.New("db error")
+			})
+
+		// The connection is dropped: before the headers if the row was still
+		// buffered, mid-body otherwise. Either way the client sees an error.
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			e.srv.URL+"/indexer/v1/admin/parties/"+e.partyID+"/events/export", http.NoBody)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			defer resp.Body.Close()
+			_, err = io.ReadAll(resp.Body)
+		}
+		assert.Error(t, err, "a truncated export must not read as complete")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		e := newTestEnv(t)
+		resp := e.get(t, "/indexer/v1/admin/parties/"+e.partyID+"/events/export?format=xml")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("token export requires a service token", func(t *testing.T) {
+		e := newAuthTestEnv(t, &auth.AuthInfo{CantonParty: "alice::1220"})
+		resp := e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/events/export")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusForbidden)
+	})
+}
+
 // ─── GET /indexer/v1/admin/events/{contractID} ─────────────────────────────────────
 
 func TestHTTP_GetEvent(t *testing.T) {
@@ -413,3 +780,93 @@ func TestHTTP_GetEvent(t *testing.T) {
 		assertStatus(t, resp, http.StatusInternalServerError)
 	})
 }
+
+// ─── token scoping ────────────────────────────────────────────────────────────
+
+func aliceToken() *auth.AuthInfo {
+	return &auth.AuthInfo{EVMAddress: "0x1111111111111111111111111111111111111111", CantonParty: alice}
+}
+
+func TestHTTP_UserTokenScoping(t *testing.T) {
+	emptyBalances := &indexer.Page[*indexer.Balance]{Page: 1, Limit: 50}
+
+	t.Run("own party is allowed", func(t *testing.T) {
+		e := newAuthTestEnv(t, aliceToken())
+		e.svc.EXPECT().ListBalancesForParty(mock.Anything, alice, mock.Anything).Return(emptyBalances, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/parties/"+alice+"/balances")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+	})
+
+	t.Run("another party is forbidden", func(t *testing.T) {
+		e := newAuthTestEnv(t, aliceToken())
+
+		resp := e.get(t, "/indexer/v1/admin/parties/bob::1220/events")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusForbidden)
+	})
+
+	t.Run("token-wide reads are forbidden", func(t *testing.T) {
+		e := newAuthTestEnv(t, aliceToken())
+
+		for _, path := range []string{
+			"/indexer/v1/admin/tokens/admin-party/DEMO/balances",
+			"/indexer/v1/admin/tokens/admin-party/DEMO/holders",
+			"/indexer/v1/admin/tokens/admin-party/DEMO/stats",
+			"/indexer/v1/admin/tokens/admin-party/DEMO/stats/top-holders",
+			"/indexer/v1/admin/pending-transfers",
+		} {
+			resp := e.get(t, path)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, path)
+		}
+	})
+
+	t.Run("uninvolved event is reported missing", func(t *testing.T) {
+		e := newAuthTestEnv(t, aliceToken())
+		bob := "bob::1220"
+		e.svc.EXPECT().GetEvent(mock.Anything, "contract-abc").
+			Return(&indexer.ParsedEvent{ContractID: "contract-abc", ToPartyID: &bob}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/events/contract-abc")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusNotFound)
+	})
+
+	t.Run("involved transfer is returned", func(t *testing.T) {
+		e := newAuthTestEnv(t, aliceToken())
+		e.svc.EXPECT().GetTransfer(mock.Anything, "offer-1").
+			Return(&indexer.Transfer{ContractID: "offer-1", FromPartyID: "bob::1220", ToPartyID: alice}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/transfers/offer-1")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusOK)
+	})
+
+	t.Run("uninvolved transfer is reported missing", func(t *testing.T) {
+		e := newAuthTestEnv(t, aliceToken())
+		e.svc.EXPECT().GetTransfer(mock.Anything, "offer-1").
+			Return(&indexer.Transfer{ContractID: "offer-1", FromPartyID: "bob::1220", ToPartyID: "carol::1220"}, nil)
+
+		resp := e.get(t, "/indexer/v1/admin/transfers/offer-1")
+		defer resp.Body.Close()
+		assertStatus(t, resp, http.StatusNotFound)
+	})
+}
+
+func TestHTTP_ServiceTokenReadsAnything(t *testing.T) {
+	e := newAuthTestEnv(t, &auth.AuthInfo{Service: true})
+	e.svc.EXPECT().ListBalancesForParty(mock.Anything, "bob::1220", mock.Anything).
+		Return(&indexer.Page[*indexer.Balance]{Page: 1, Limit: 50}, nil)
+	e.svc.EXPECT().ListBalancesForToken(mock.Anything, "admin-party", "DEMO", mock.Anything).
+		Return(&indexer.Page[*indexer.Balance]{Page: 1, Limit: 50}, nil)
+
+	resp := e.get(t, "/indexer/v1/admin/parties/bob::1220/balances")
+	resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+
+	resp = e.get(t, "/indexer/v1/admin/tokens/admin-party/DEMO/balances")
+	resp.Body.Close()
+	assertStatus(t, resp, http.StatusOK)
+}
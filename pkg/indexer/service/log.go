@@ -200,6 +200,197 @@ func (ls *logService) ListBalancesForToken(
 	return ls.svc.ListBalancesForToken(ctx, admin, id, p)
 }
 
+func (ls *logService) GetBalanceAt(
+	ctx context.Context, partyID, admin, id string, at indexer.AsOf,
+) (b *indexer.HistoricalBalance, err error) {
+	start := time.Now()
+	ls.logger.Info("GetBalanceAt started",
+		zap.String("service", indexerServiceName),
+		zap.String("party_id", partyID),
+		zap.String("admin", admin),
+		zap.String("id", id),
+		zap.Int64("at_offset", at.Offset),
+		zap.Time("at_time", at.Time),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("GetBalanceAt failed",
+				zap.String("service", indexerServiceName),
+				zap.String("party_id", partyID),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("GetBalanceAt completed",
+				zap.String("service", indexerServiceName),
+				zap.String("party_id", partyID),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.GetBalanceAt(ctx, partyID, admin, id, at)
+}
+
+func (ls *logService) SupplyAt(
+	ctx context.Context, admin, id string, at indexer.AsOf,
+) (supply *indexer.HistoricalSupply, err error) {
+	start := time.Now()
+	ls.logger.Info("SupplyAt started",
+		zap.String("service", indexerServiceName),
+		zap.String("admin", admin),
+		zap.String("id", id),
+		zap.Int64("at_offset", at.Offset),
+		zap.Time("at_time", at.Time),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("SupplyAt failed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("SupplyAt completed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.SupplyAt(ctx, admin, id, at)
+}
+
+func (ls *logService) ListHoldersAt(
+	ctx context.Context, admin, id string, at indexer.AsOf, p indexer.Pagination,
+) (page *indexer.Page[*indexer.HistoricalBalance], err error) {
+	start := time.Now()
+	ls.logger.Info("ListHoldersAt started",
+		zap.String("service", indexerServiceName),
+		zap.String("admin", admin),
+		zap.String("id", id),
+		zap.Int64("at_offset", at.Offset),
+		zap.Time("at_time", at.Time),
+		zap.Int("page", p.Page),
+		zap.Int("limit", p.Limit),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("ListHoldersAt failed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("ListHoldersAt completed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Int64("total", page.Total),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.ListHoldersAt(ctx, admin, id, at, p)
+}
+
+func (ls *logService) TokenStats(
+	ctx context.Context, admin, id string, q indexer.StatsQuery,
+) (stats *indexer.TokenStats, err error) {
+	start := time.Now()
+	ls.logger.Info("TokenStats started",
+		zap.String("service", indexerServiceName),
+		zap.String("admin", admin),
+		zap.String("id", id),
+		zap.String("interval", string(q.Interval)),
+		zap.Time("since", q.Since),
+		zap.Time("until", q.Until),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("TokenStats failed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("TokenStats completed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Int("buckets", len(stats.Buckets)),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.TokenStats(ctx, admin, id, q)
+}
+
+func (ls *logService) TopHolders(ctx context.Context, admin, id string, limit int) (top *indexer.TopHolders, err error) {
+	start := time.Now()
+	ls.logger.Info("TopHolders started",
+		zap.String("service", indexerServiceName),
+		zap.String("admin", admin),
+		zap.String("id", id),
+		zap.Int("limit", limit),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("TopHolders failed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("TopHolders completed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Int("holders", len(top.Holders)),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.TopHolders(ctx, admin, id, limit)
+}
+
+func (ls *logService) HolderDistribution(ctx context.Context, admin, id string) (dist *indexer.HolderDistribution, err error) {
+	start := time.Now()
+	ls.logger.Info("HolderDistribution started",
+		zap.String("service", indexerServiceName),
+		zap.String("admin", admin),
+		zap.String("id", id),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("HolderDistribution failed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("HolderDistribution completed",
+				zap.String("service", indexerServiceName),
+				zap.String("admin", admin),
+				zap.String("id", id),
+				zap.Int("buckets", len(dist.Buckets)),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.HolderDistribution(ctx, admin, id)
+}
+
 func (ls *logService) GetEvent(ctx context.Context, contractID string) (e *indexer.ParsedEvent, err error) {
 	start := time.Now()
 	ls.logger.Info("GetEvent started",
@@ -330,3 +521,55 @@ func (ls *logService) GetPendingTransfers(
 ) (*indexer.Page[indexer.Transfer], error) {
 	return ls.svc.GetPendingTransfers(ctx, p)
 }
+
+func (ls *logService) ExportTokenEvents(
+	ctx context.Context, admin, id string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error,
+) error {
+	var rows int64
+	return ls.logExport("ExportTokenEvents", &rows, func() error {
+		return ls.svc.ExportTokenEvents(ctx, admin, id, f, func(e *indexer.ExportedEvent) error {
+			rows++
+			return fn(e)
+		})
+	}, zap.String("admin", admin), zap.String("id", id))
+}
+
+func (ls *logService) ExportPartyEvents(
+	ctx context.Context, partyID string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error,
+) error {
+	var rows int64
+	return ls.logExport("ExportPartyEvents", &rows, func() error {
+		return ls.svc.ExportPartyEvents(ctx, partyID, f, func(e *indexer.ExportedEvent) error {
+			rows++
+			return fn(e)
+		})
+	}, zap.String("party_id", partyID))
+}
+
+func (ls *logService) ExportTransfers(
+	ctx context.Context, partyID string, query indexer.TransferQuery, fn func(*indexer.ExportedTransfer) error,
+) error {
+	var rows int64
+	return ls.logExport("ExportTransfers", &rows, func() error {
+		return ls.svc.ExportTransfers(ctx, partyID, query, func(t *indexer.ExportedTransfer) error {
+			rows++
+			return fn(t)
+		})
+	}, zap.String("party_id", partyID))
+}
+
+// logExport runs an export, logging its start and its outcome with the number
+// of rows written.
+func (ls *logService) logExport(method string, rows *int64, run func() error, fields ...zap.Field) error {
+	start := time.Now()
+	fields = append([]zap.Field{zap.String("service", indexerServiceName)}, fields...)
+	ls.logger.Info(method+" started", fields...)
+	err := run()
+	fields = append(fields, zap.Int64("rows", *rows), zap.Duration("duration", time.Since(start)))
+	if err != nil {
+		ls.logger.Error(method+" failed", append(fields, zap.Error(err))...)
+		return err
+	}
+	ls.logger.Info(method+" completed", fields...)
+	return nil
+}
@@ -22,6 +22,154 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// ExportPartyEvents provides a mock function with given fields: ctx, partyID, f, fn
+func (_m *Service) ExportPartyEvents(ctx context.Context, partyID string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error) error {
+	ret := _m.Called(ctx, partyID, f, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPartyEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, indexer.EventFilter, func(*indexer.ExportedEvent) error) error); ok {
+		r0 = rf(ctx, partyID, f, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_ExportPartyEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportPartyEvents'
+type Service_ExportPartyEvents_Call struct {
+	*mock.Call
+}
+
+// ExportPartyEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partyID string
+//   - f indexer.EventFilter
+//   - fn func(*indexer.ExportedEvent) error
+func (_e *Service_Expecter) ExportPartyEvents(ctx interface{}, partyID interface{}, f interface{}, fn interface{}) *Service_ExportPartyEvents_Call {
+	return &Service_ExportPartyEvents_Call{Call: _e.mock.On("ExportPartyEvents", ctx, partyID, f, fn)}
+}
+
+func (_c *Service_ExportPartyEvents_Call) Run(run func(ctx context.Context, partyID string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error)) *Service_ExportPartyEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(indexer.EventFilter), args[3].(func(*indexer.ExportedEvent) error))
+	})
+	return _c
+}
+
+func (_c *Service_ExportPartyEvents_Call) Return(_a0 error) *Service_ExportPartyEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Service_ExportPartyEvents_Call) RunAndReturn(run func(context.Context, string, indexer.EventFilter, func(*indexer.ExportedEvent) error) error) *Service_ExportPartyEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportTokenEvents provides a mock function with given fields: ctx, admin, id, f, fn
+func (_m *Service) ExportTokenEvents(ctx context.Context, admin string, id string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error) error {
+	ret := _m.Called(ctx, admin, id, f, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTokenEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.EventFilter, func(*indexer.ExportedEvent) error) error); ok {
+		r0 = rf(ctx, admin, id, f, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_ExportTokenEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTokenEvents'
+type Service_ExportTokenEvents_Call struct {
+	*mock.Call
+}
+
+// ExportTokenEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - f indexer.EventFilter
+//   - fn func(*indexer.ExportedEvent) error
+func (_e *Service_Expecter) ExportTokenEvents(ctx interface{}, admin interface{}, id interface{}, f interface{}, fn interface{}) *Service_ExportTokenEvents_Call {
+	return &Service_ExportTokenEvents_Call{Call: _e.mock.On("ExportTokenEvents", ctx, admin, id, f, fn)}
+}
+
+func (_c *Service_ExportTokenEvents_Call) Run(run func(ctx context.Context, admin string, id string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error)) *Service_ExportTokenEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(indexer.EventFilter), args[4].(func(*indexer.ExportedEvent) error))
+	})
+	return _c
+}
+
+func (_c *Service_ExportTokenEvents_Call) Return(_a0 error) *Service_ExportTokenEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Service_ExportTokenEvents_Call) RunAndReturn(run func(context.Context, string, string, indexer.EventFilter, func(*indexer.ExportedEvent) error) error) *Service_ExportTokenEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportTransfers provides a mock function with given fields: ctx, partyID, query, fn
+func (_m *Service) ExportTransfers(ctx context.Context, partyID string, query indexer.TransferQuery, fn func(*indexer.ExportedTransfer) error) error {
+	ret := _m.Called(ctx, partyID, query, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTransfers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, indexer.TransferQuery, func(*indexer.ExportedTransfer) error) error); ok {
+		r0 = rf(ctx, partyID, query, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_ExportTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTransfers'
+type Service_ExportTransfers_Call struct {
+	*mock.Call
+}
+
+// ExportTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partyID string
+//   - query indexer.TransferQuery
+//   - fn func(*indexer.ExportedTransfer) error
+func (_e *Service_Expecter) ExportTransfers(ctx interface{}, partyID interface{}, query interface{}, fn interface{}) *Service_ExportTransfers_Call {
+	return &Service_ExportTransfers_Call{Call: _e.mock.On("ExportTransfers", ctx, partyID, query, fn)}
+}
+
+func (_c *Service_ExportTransfers_Call) Run(run func(ctx context.Context, partyID string, query indexer.TransferQuery, fn func(*indexer.ExportedTransfer) error)) *Service_ExportTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(indexer.TransferQuery), args[3].(func(*indexer.ExportedTransfer) error))
+	})
+	return _c
+}
+
+func (_c *Service_ExportTransfers_Call) Return(_a0 error) *Service_ExportTransfers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Service_ExportTransfers_Call) RunAndReturn(run func(context.Context, string, indexer.TransferQuery, func(*indexer.ExportedTransfer) error) error) *Service_ExportTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBalance provides a mock function with given fields: ctx, partyID, admin, id
 func (_m *Service) GetBalance(ctx context.Context, partyID string, admin string, id string) (*indexer.Balance, error) {
 	ret := _m.Called(ctx, partyID, admin, id)
@@ -83,6 +231,68 @@ func (_c *Service_GetBalance_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// GetBalanceAt provides a mock function with given fields: ctx, partyID, admin, id, at
+func (_m *Service) GetBalanceAt(ctx context.Context, partyID string, admin string, id string, at indexer.AsOf) (*indexer.HistoricalBalance, error) {
+	ret := _m.Called(ctx, partyID, admin, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAt")
+	}
+
+	var r0 *indexer.HistoricalBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, indexer.AsOf) (*indexer.HistoricalBalance, error)); ok {
+		return rf(ctx, partyID, admin, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, indexer.AsOf) *indexer.HistoricalBalance); ok {
+		r0 = rf(ctx, partyID, admin, id, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.HistoricalBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, indexer.AsOf) error); ok {
+		r1 = rf(ctx, partyID, admin, id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAt'
+type Service_GetBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partyID string
+//   - admin string
+//   - id string
+//   - at indexer.AsOf
+func (_e *Service_Expecter) GetBalanceAt(ctx interface{}, partyID interface{}, admin interface{}, id interface{}, at interface{}) *Service_GetBalanceAt_Call {
+	return &Service_GetBalanceAt_Call{Call: _e.mock.On("GetBalanceAt", ctx, partyID, admin, id, at)}
+}
+
+func (_c *Service_GetBalanceAt_Call) Run(run func(ctx context.Context, partyID string, admin string, id string, at indexer.AsOf)) *Service_GetBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(indexer.AsOf))
+	})
+	return _c
+}
+
+func (_c *Service_GetBalanceAt_Call) Return(_a0 *indexer.HistoricalBalance, _a1 error) *Service_GetBalanceAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_GetBalanceAt_Call) RunAndReturn(run func(context.Context, string, string, string, indexer.AsOf) (*indexer.HistoricalBalance, error)) *Service_GetBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEvent provides a mock function with given fields: ctx, contractID
 func (_m *Service) GetEvent(ctx context.Context, contractID string) (*indexer.ParsedEvent, error) {
 	ret := _m.Called(ctx, contractID)
@@ -381,6 +591,66 @@ func (_c *Service_GetTransfers_Call) RunAndReturn(run func(context.Context, stri
 	return _c
 }
 
+// HolderDistribution provides a mock function with given fields: ctx, admin, id
+func (_m *Service) HolderDistribution(ctx context.Context, admin string, id string) (*indexer.HolderDistribution, error) {
+	ret := _m.Called(ctx, admin, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HolderDistribution")
+	}
+
+	var r0 *indexer.HolderDistribution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*indexer.HolderDistribution, error)); ok {
+		return rf(ctx, admin, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *indexer.HolderDistribution); ok {
+		r0 = rf(ctx, admin, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.HolderDistribution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, admin, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_HolderDistribution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HolderDistribution'
+type Service_HolderDistribution_Call struct {
+	*mock.Call
+}
+
+// HolderDistribution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+func (_e *Service_Expecter) HolderDistribution(ctx interface{}, admin interface{}, id interface{}) *Service_HolderDistribution_Call {
+	return &Service_HolderDistribution_Call{Call: _e.mock.On("HolderDistribution", ctx, admin, id)}
+}
+
+func (_c *Service_HolderDistribution_Call) Run(run func(ctx context.Context, admin string, id string)) *Service_HolderDistribution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_HolderDistribution_Call) Return(_a0 *indexer.HolderDistribution, _a1 error) *Service_HolderDistribution_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_HolderDistribution_Call) RunAndReturn(run func(context.Context, string, string) (*indexer.HolderDistribution, error)) *Service_HolderDistribution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListBalancesForParty provides a mock function with given fields: ctx, partyID, p
 func (_m *Service) ListBalancesForParty(ctx context.Context, partyID string, p indexer.Pagination) (*indexer.Page[*indexer.Balance], error) {
 	ret := _m.Called(ctx, partyID, p)
@@ -502,6 +772,68 @@ func (_c *Service_ListBalancesForToken_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// ListHoldersAt provides a mock function with given fields: ctx, admin, id, at, p
+func (_m *Service) ListHoldersAt(ctx context.Context, admin string, id string, at indexer.AsOf, p indexer.Pagination) (*indexer.Page[*indexer.HistoricalBalance], error) {
+	ret := _m.Called(ctx, admin, id, at, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHoldersAt")
+	}
+
+	var r0 *indexer.Page[*indexer.HistoricalBalance]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf, indexer.Pagination) (*indexer.Page[*indexer.HistoricalBalance], error)); ok {
+		return rf(ctx, admin, id, at, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf, indexer.Pagination) *indexer.Page[*indexer.HistoricalBalance]); ok {
+		r0 = rf(ctx, admin, id, at, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.Page[*indexer.HistoricalBalance])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, indexer.AsOf, indexer.Pagination) error); ok {
+		r1 = rf(ctx, admin, id, at, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ListHoldersAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHoldersAt'
+type Service_ListHoldersAt_Call struct {
+	*mock.Call
+}
+
+// ListHoldersAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - at indexer.AsOf
+//   - p indexer.Pagination
+func (_e *Service_Expecter) ListHoldersAt(ctx interface{}, admin interface{}, id interface{}, at interface{}, p interface{}) *Service_ListHoldersAt_Call {
+	return &Service_ListHoldersAt_Call{Call: _e.mock.On("ListHoldersAt", ctx, admin, id, at, p)}
+}
+
+func (_c *Service_ListHoldersAt_Call) Run(run func(ctx context.Context, admin string, id string, at indexer.AsOf, p indexer.Pagination)) *Service_ListHoldersAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(indexer.AsOf), args[4].(indexer.Pagination))
+	})
+	return _c
+}
+
+func (_c *Service_ListHoldersAt_Call) Return(_a0 *indexer.Page[*indexer.HistoricalBalance], _a1 error) *Service_ListHoldersAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ListHoldersAt_Call) RunAndReturn(run func(context.Context, string, string, indexer.AsOf, indexer.Pagination) (*indexer.Page[*indexer.HistoricalBalance], error)) *Service_ListHoldersAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPartyEvents provides a mock function with given fields: ctx, partyID, f, p
 func (_m *Service) ListPartyEvents(ctx context.Context, partyID string, f indexer.EventFilter, p indexer.Pagination) (*indexer.Page[*indexer.ParsedEvent], error) {
 	ret := _m.Called(ctx, partyID, f, p)
@@ -684,6 +1016,189 @@ func (_c *Service_ListTokens_Call) RunAndReturn(run func(context.Context, indexe
 	return _c
 }
 
+// SupplyAt provides a mock function with given fields: ctx, admin, id, at
+func (_m *Service) SupplyAt(ctx context.Context, admin string, id string, at indexer.AsOf) (*indexer.HistoricalSupply, error) {
+	ret := _m.Called(ctx, admin, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SupplyAt")
+	}
+
+	var r0 *indexer.HistoricalSupply
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf) (*indexer.HistoricalSupply, error)); ok {
+		return rf(ctx, admin, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf) *indexer.HistoricalSupply); ok {
+		r0 = rf(ctx, admin, id, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.HistoricalSupply)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, indexer.AsOf) error); ok {
+		r1 = rf(ctx, admin, id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_SupplyAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SupplyAt'
+type Service_SupplyAt_Call struct {
+	*mock.Call
+}
+
+// SupplyAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - at indexer.AsOf
+func (_e *Service_Expecter) SupplyAt(ctx interface{}, admin interface{}, id interface{}, at interface{}) *Service_SupplyAt_Call {
+	return &Service_SupplyAt_Call{Call: _e.mock.On("SupplyAt", ctx, admin, id, at)}
+}
+
+func (_c *Service_SupplyAt_Call) Run(run func(ctx context.Context, admin string, id string, at indexer.AsOf)) *Service_SupplyAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(indexer.AsOf))
+	})
+	return _c
+}
+
+func (_c *Service_SupplyAt_Call) Return(_a0 *indexer.HistoricalSupply, _a1 error) *Service_SupplyAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_SupplyAt_Call) RunAndReturn(run func(context.Context, string, string, indexer.AsOf) (*indexer.HistoricalSupply, error)) *Service_SupplyAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokenStats provides a mock function with given fields: ctx, admin, id, q
+func (_m *Service) TokenStats(ctx context.Context, admin string, id string, q indexer.StatsQuery) (*indexer.TokenStats, error) {
+	ret := _m.Called(ctx, admin, id, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokenStats")
+	}
+
+	var r0 *indexer.TokenStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.StatsQuery) (*indexer.TokenStats, error)); ok {
+		return rf(ctx, admin, id, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.StatsQuery) *indexer.TokenStats); ok {
+		r0 = rf(ctx, admin, id, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.TokenStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, indexer.StatsQuery) error); ok {
+		r1 = rf(ctx, admin, id, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_TokenStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokenStats'
+type Service_TokenStats_Call struct {
+	*mock.Call
+}
+
+// TokenStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - q indexer.StatsQuery
+func (_e *Service_Expecter) TokenStats(ctx interface{}, admin interface{}, id interface{}, q interface{}) *Service_TokenStats_Call {
+	return &Service_TokenStats_Call{Call: _e.mock.On("TokenStats", ctx, admin, id, q)}
+}
+
+func (_c *Service_TokenStats_Call) Run(run func(ctx context.Context, admin string, id string, q indexer.StatsQuery)) *Service_TokenStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(indexer.StatsQuery))
+	})
+	return _c
+}
+
+func (_c *Service_TokenStats_Call) Return(_a0 *indexer.TokenStats, _a1 error) *Service_TokenStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_TokenStats_Call) RunAndReturn(run func(context.Context, string, string, indexer.StatsQuery) (*indexer.TokenStats, error)) *Service_TokenStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TopHolders provides a mock function with given fields: ctx, admin, id, limit
+func (_m *Service) TopHolders(ctx context.Context, admin string, id string, limit int) (*indexer.TopHolders, error) {
+	ret := _m.Called(ctx, admin, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TopHolders")
+	}
+
+	var r0 *indexer.TopHolders
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*indexer.TopHolders, error)); ok {
+		return rf(ctx, admin, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *indexer.TopHolders); ok {
+		r0 = rf(ctx, admin, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.TopHolders)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, admin, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_TopHolders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TopHolders'
+type Service_TopHolders_Call struct {
+	*mock.Call
+}
+
+// TopHolders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - limit int
+func (_e *Service_Expecter) TopHolders(ctx interface{}, admin interface{}, id interface{}, limit interface{}) *Service_TopHolders_Call {
+	return &Service_TopHolders_Call{Call: _e.mock.On("TopHolders", ctx, admin, id, limit)}
+}
+
+func (_c *Service_TopHolders_Call) Run(run func(ctx context.Context, admin string, id string, limit int)) *Service_TopHolders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Service_TopHolders_Call) Return(_a0 *indexer.TopHolders, _a1 error) *Service_TopHolders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_TopHolders_Call) RunAndReturn(run func(context.Context, string, string, int) (*indexer.TopHolders, error)) *Service_TopHolders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TotalSupply provides a mock function with given fields: ctx, admin, id
 func (_m *Service) TotalSupply(ctx context.Context, admin string, id string) (string, error) {
 	ret := _m.Called(ctx, admin, id)
@@ -7,6 +7,8 @@ import (
 
 	indexer "github.com/chainsafe/canton-middleware/pkg/indexer"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -83,6 +85,68 @@ func (_c *Store_GetBalance_Call) RunAndReturn(run func(context.Context, string,
 	return _c
 }
 
+// GetBalanceAt provides a mock function with given fields: ctx, partyID, admin, id, at
+func (_m *Store) GetBalanceAt(ctx context.Context, partyID string, admin string, id string, at indexer.AsOf) (*indexer.HistoricalBalance, error) {
+	ret := _m.Called(ctx, partyID, admin, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAt")
+	}
+
+	var r0 *indexer.HistoricalBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, indexer.AsOf) (*indexer.HistoricalBalance, error)); ok {
+		return rf(ctx, partyID, admin, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, indexer.AsOf) *indexer.HistoricalBalance); ok {
+		r0 = rf(ctx, partyID, admin, id, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.HistoricalBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, indexer.AsOf) error); ok {
+		r1 = rf(ctx, partyID, admin, id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAt'
+type Store_GetBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partyID string
+//   - admin string
+//   - id string
+//   - at indexer.AsOf
+func (_e *Store_Expecter) GetBalanceAt(ctx interface{}, partyID interface{}, admin interface{}, id interface{}, at interface{}) *Store_GetBalanceAt_Call {
+	return &Store_GetBalanceAt_Call{Call: _e.mock.On("GetBalanceAt", ctx, partyID, admin, id, at)}
+}
+
+func (_c *Store_GetBalanceAt_Call) Run(run func(ctx context.Context, partyID string, admin string, id string, at indexer.AsOf)) *Store_GetBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(indexer.AsOf))
+	})
+	return _c
+}
+
+func (_c *Store_GetBalanceAt_Call) Return(_a0 *indexer.HistoricalBalance, _a1 error) *Store_GetBalanceAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetBalanceAt_Call) RunAndReturn(run func(context.Context, string, string, string, indexer.AsOf) (*indexer.HistoricalBalance, error)) *Store_GetBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEvent provides a mock function with given fields: ctx, contractID
 func (_m *Store) GetEvent(ctx context.Context, contractID string) (*indexer.ParsedEvent, error) {
 	ret := _m.Called(ctx, contractID)
@@ -142,6 +206,127 @@ func (_c *Store_GetEvent_Call) RunAndReturn(run func(context.Context, string) (*
 	return _c
 }
 
+// GetHolderDistribution provides a mock function with given fields: ctx, admin, id
+func (_m *Store) GetHolderDistribution(ctx context.Context, admin string, id string) ([]indexer.HolderBucket, error) {
+	ret := _m.Called(ctx, admin, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHolderDistribution")
+	}
+
+	var r0 []indexer.HolderBucket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]indexer.HolderBucket, error)); ok {
+		return rf(ctx, admin, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []indexer.HolderBucket); ok {
+		r0 = rf(ctx, admin, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]indexer.HolderBucket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, admin, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetHolderDistribution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHolderDistribution'
+type Store_GetHolderDistribution_Call struct {
+	*mock.Call
+}
+
+// GetHolderDistribution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+func (_e *Store_Expecter) GetHolderDistribution(ctx interface{}, admin interface{}, id interface{}) *Store_GetHolderDistribution_Call {
+	return &Store_GetHolderDistribution_Call{Call: _e.mock.On("GetHolderDistribution", ctx, admin, id)}
+}
+
+func (_c *Store_GetHolderDistribution_Call) Run(run func(ctx context.Context, admin string, id string)) *Store_GetHolderDistribution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Store_GetHolderDistribution_Call) Return(_a0 []indexer.HolderBucket, _a1 error) *Store_GetHolderDistribution_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetHolderDistribution_Call) RunAndReturn(run func(context.Context, string, string) ([]indexer.HolderBucket, error)) *Store_GetHolderDistribution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSupplyAt provides a mock function with given fields: ctx, admin, id, at
+func (_m *Store) GetSupplyAt(ctx context.Context, admin string, id string, at indexer.AsOf) (*indexer.HistoricalSupply, error) {
+	ret := _m.Called(ctx, admin, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSupplyAt")
+	}
+
+	var r0 *indexer.HistoricalSupply
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf) (*indexer.HistoricalSupply, error)); ok {
+		return rf(ctx, admin, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf) *indexer.HistoricalSupply); ok {
+		r0 = rf(ctx, admin, id, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.HistoricalSupply)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, indexer.AsOf) error); ok {
+		r1 = rf(ctx, admin, id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_GetSupplyAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSupplyAt'
+type Store_GetSupplyAt_Call struct {
+	*mock.Call
+}
+
+// GetSupplyAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - at indexer.AsOf
+func (_e *Store_Expecter) GetSupplyAt(ctx interface{}, admin interface{}, id interface{}, at interface{}) *Store_GetSupplyAt_Call {
+	return &Store_GetSupplyAt_Call{Call: _e.mock.On("GetSupplyAt", ctx, admin, id, at)}
+}
+
+func (_c *Store_GetSupplyAt_Call) Run(run func(ctx context.Context, admin string, id string, at indexer.AsOf)) *Store_GetSupplyAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(indexer.AsOf))
+	})
+	return _c
+}
+
+func (_c *Store_GetSupplyAt_Call) Return(_a0 *indexer.HistoricalSupply, _a1 error) *Store_GetSupplyAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_GetSupplyAt_Call) RunAndReturn(run func(context.Context, string, string, indexer.AsOf) (*indexer.HistoricalSupply, error)) *Store_GetSupplyAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetToken provides a mock function with given fields: ctx, admin, id
 func (_m *Store) GetToken(ctx context.Context, admin string, id string) (*indexer.Token, error) {
 	ret := _m.Called(ctx, admin, id)
@@ -261,6 +446,62 @@ func (_c *Store_GetTransfer_Call) RunAndReturn(run func(context.Context, string)
 	return _c
 }
 
+// LatestOffset provides a mock function with given fields: ctx
+func (_m *Store) LatestOffset(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestOffset")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_LatestOffset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LatestOffset'
+type Store_LatestOffset_Call struct {
+	*mock.Call
+}
+
+// LatestOffset is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Store_Expecter) LatestOffset(ctx interface{}) *Store_LatestOffset_Call {
+	return &Store_LatestOffset_Call{Call: _e.mock.On("LatestOffset", ctx)}
+}
+
+func (_c *Store_LatestOffset_Call) Run(run func(ctx context.Context)) *Store_LatestOffset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Store_LatestOffset_Call) Return(_a0 int64, _a1 error) *Store_LatestOffset_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_LatestOffset_Call) RunAndReturn(run func(context.Context) (int64, error)) *Store_LatestOffset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListBalancesForParty provides a mock function with given fields: ctx, partyID, p
 func (_m *Store) ListBalancesForParty(ctx context.Context, partyID string, p indexer.Pagination) ([]*indexer.Balance, int64, error) {
 	ret := _m.Called(ctx, partyID, p)
@@ -463,6 +704,75 @@ func (_c *Store_ListEvents_Call) RunAndReturn(run func(context.Context, indexer.
 	return _c
 }
 
+// ListHoldersAt provides a mock function with given fields: ctx, admin, id, at, p
+func (_m *Store) ListHoldersAt(ctx context.Context, admin string, id string, at indexer.AsOf, p indexer.Pagination) ([]*indexer.HistoricalBalance, int64, error) {
+	ret := _m.Called(ctx, admin, id, at, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHoldersAt")
+	}
+
+	var r0 []*indexer.HistoricalBalance
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf, indexer.Pagination) ([]*indexer.HistoricalBalance, int64, error)); ok {
+		return rf(ctx, admin, id, at, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.AsOf, indexer.Pagination) []*indexer.HistoricalBalance); ok {
+		r0 = rf(ctx, admin, id, at, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*indexer.HistoricalBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, indexer.AsOf, indexer.Pagination) int64); ok {
+		r1 = rf(ctx, admin, id, at, p)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, indexer.AsOf, indexer.Pagination) error); ok {
+		r2 = rf(ctx, admin, id, at, p)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Store_ListHoldersAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHoldersAt'
+type Store_ListHoldersAt_Call struct {
+	*mock.Call
+}
+
+// ListHoldersAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - at indexer.AsOf
+//   - p indexer.Pagination
+func (_e *Store_Expecter) ListHoldersAt(ctx interface{}, admin interface{}, id interface{}, at interface{}, p interface{}) *Store_ListHoldersAt_Call {
+	return &Store_ListHoldersAt_Call{Call: _e.mock.On("ListHoldersAt", ctx, admin, id, at, p)}
+}
+
+func (_c *Store_ListHoldersAt_Call) Run(run func(ctx context.Context, admin string, id string, at indexer.AsOf, p indexer.Pagination)) *Store_ListHoldersAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(indexer.AsOf), args[4].(indexer.Pagination))
+	})
+	return _c
+}
+
+func (_c *Store_ListHoldersAt_Call) Return(_a0 []*indexer.HistoricalBalance, _a1 int64, _a2 error) *Store_ListHoldersAt_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Store_ListHoldersAt_Call) RunAndReturn(run func(context.Context, string, string, indexer.AsOf, indexer.Pagination) ([]*indexer.HistoricalBalance, int64, error)) *Store_ListHoldersAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPendingTransfers provides a mock function with given fields: ctx, p
 func (_m *Store) ListPendingTransfers(ctx context.Context, p indexer.Pagination) ([]indexer.Transfer, int64, error) {
 	ret := _m.Called(ctx, p)
@@ -529,6 +839,69 @@ func (_c *Store_ListPendingTransfers_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// ListTokenStats provides a mock function with given fields: ctx, admin, id, interval, since, until
+func (_m *Store) ListTokenStats(ctx context.Context, admin string, id string, interval indexer.StatsInterval, since time.Time, until time.Time) ([]*indexer.TokenStatsBucket, error) {
+	ret := _m.Called(ctx, admin, id, interval, since, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTokenStats")
+	}
+
+	var r0 []*indexer.TokenStatsBucket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.StatsInterval, time.Time, time.Time) ([]*indexer.TokenStatsBucket, error)); ok {
+		return rf(ctx, admin, id, interval, since, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, indexer.StatsInterval, time.Time, time.Time) []*indexer.TokenStatsBucket); ok {
+		r0 = rf(ctx, admin, id, interval, since, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*indexer.TokenStatsBucket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, indexer.StatsInterval, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, admin, id, interval, since, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListTokenStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTokenStats'
+type Store_ListTokenStats_Call struct {
+	*mock.Call
+}
+
+// ListTokenStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - interval indexer.StatsInterval
+//   - since time.Time
+//   - until time.Time
+func (_e *Store_Expecter) ListTokenStats(ctx interface{}, admin interface{}, id interface{}, interval interface{}, since interface{}, until interface{}) *Store_ListTokenStats_Call {
+	return &Store_ListTokenStats_Call{Call: _e.mock.On("ListTokenStats", ctx, admin, id, interval, since, until)}
+}
+
+func (_c *Store_ListTokenStats_Call) Run(run func(ctx context.Context, admin string, id string, interval indexer.StatsInterval, since time.Time, until time.Time)) *Store_ListTokenStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(indexer.StatsInterval), args[4].(time.Time), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Store_ListTokenStats_Call) Return(_a0 []*indexer.TokenStatsBucket, _a1 error) *Store_ListTokenStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListTokenStats_Call) RunAndReturn(run func(context.Context, string, string, indexer.StatsInterval, time.Time, time.Time) ([]*indexer.TokenStatsBucket, error)) *Store_ListTokenStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTokens provides a mock function with given fields: ctx, p
 func (_m *Store) ListTokens(ctx context.Context, p indexer.Pagination) ([]*indexer.Token, int64, error) {
 	ret := _m.Called(ctx, p)
@@ -595,6 +968,67 @@ func (_c *Store_ListTokens_Call) RunAndReturn(run func(context.Context, indexer.
 	return _c
 }
 
+// ListTopHolders provides a mock function with given fields: ctx, admin, id, limit
+func (_m *Store) ListTopHolders(ctx context.Context, admin string, id string, limit int) ([]*indexer.Balance, error) {
+	ret := _m.Called(ctx, admin, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopHolders")
+	}
+
+	var r0 []*indexer.Balance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]*indexer.Balance, error)); ok {
+		return rf(ctx, admin, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []*indexer.Balance); ok {
+		r0 = rf(ctx, admin, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*indexer.Balance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, admin, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_ListTopHolders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopHolders'
+type Store_ListTopHolders_Call struct {
+	*mock.Call
+}
+
+// ListTopHolders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - id string
+//   - limit int
+func (_e *Store_Expecter) ListTopHolders(ctx interface{}, admin interface{}, id interface{}, limit interface{}) *Store_ListTopHolders_Call {
+	return &Store_ListTopHolders_Call{Call: _e.mock.On("ListTopHolders", ctx, admin, id, limit)}
+}
+
+func (_c *Store_ListTopHolders_Call) Run(run func(ctx context.Context, admin string, id string, limit int)) *Store_ListTopHolders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Store_ListTopHolders_Call) Return(_a0 []*indexer.Balance, _a1 error) *Store_ListTopHolders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_ListTopHolders_Call) RunAndReturn(run func(context.Context, string, string, int) ([]*indexer.Balance, error)) *Store_ListTopHolders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTransfers provides a mock function with given fields: ctx, partyID, query, p
 func (_m *Store) ListTransfers(ctx context.Context, partyID string, query indexer.TransferQuery, p indexer.Pagination) ([]indexer.Transfer, int64, error) {
 	ret := _m.Called(ctx, partyID, query, p)
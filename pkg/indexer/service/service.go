@@ -4,6 +4,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -37,6 +39,30 @@ type Store interface {
 	) ([]indexer.Transfer, int64, error)
 	// ListPendingTransfers returns all pending offer-based transfers across all parties.
 	ListPendingTransfers(ctx context.Context, p indexer.Pagination) ([]indexer.Transfer, int64, error)
+
+	// LatestOffset returns the last indexed ledger offset (0 before the first batch).
+	LatestOffset(ctx context.Context) (int64, error)
+	// GetBalanceAt returns a party's balance as of a point in history. Returns
+	// (nil, nil) when the party had no recorded balance by then.
+	GetBalanceAt(ctx context.Context, partyID, admin, id string, at indexer.AsOf) (*indexer.HistoricalBalance, error)
+	// GetSupplyAt returns a token's supply and holder count as of a point in
+	// history. Returns (nil, nil) when no supply was recorded by then.
+	GetSupplyAt(ctx context.Context, admin, id string, at indexer.AsOf) (*indexer.HistoricalSupply, error)
+	// ListHoldersAt returns the parties holding a non-zero balance of a token as
+	// of a point in history, ordered by party ID.
+	ListHoldersAt(
+		ctx context.Context, admin, id string, at indexer.AsOf, p indexer.Pagination,
+	) ([]*indexer.HistoricalBalance, int64, error)
+
+	// ListTokenStats returns a token's rollup buckets of the given interval
+	// starting in [since, until), oldest first. Buckets without activity have no row.
+	ListTokenStats(
+		ctx context.Context, admin, id string, interval indexer.StatsInterval, since, until time.Time,
+	) ([]*indexer.TokenStatsBucket, error)
+	// ListTopHolders returns a token's limit largest non-zero balances, largest first.
+	ListTopHolders(ctx context.Context, admin, id string, limit int) ([]*indexer.Balance, error)
+	// GetHolderDistribution buckets a token's non-zero balances by order of magnitude.
+	GetHolderDistribution(ctx context.Context, admin, id string) ([]indexer.HolderBucket, error)
 }
 
 //go:generate mockery --name Service --output mocks --outpkg mocks --filename mock_service.go --with-expecter
@@ -53,6 +79,18 @@ type Service interface {
 	ListBalancesForParty(ctx context.Context, partyID string, p indexer.Pagination) (*indexer.Page[*indexer.Balance], error)
 	ListBalancesForToken(ctx context.Context, admin, id string, p indexer.Pagination) (*indexer.Page[*indexer.Balance], error)
 
+	// Point-in-time queries over the per-offset balance and supply history.
+	GetBalanceAt(ctx context.Context, partyID, admin, id string, at indexer.AsOf) (*indexer.HistoricalBalance, error)
+	SupplyAt(ctx context.Context, admin, id string, at indexer.AsOf) (*indexer.HistoricalSupply, error)
+	ListHoldersAt(
+		ctx context.Context, admin, id string, at indexer.AsOf, p indexer.Pagination,
+	) (*indexer.Page[*indexer.HistoricalBalance], error)
+
+	// Analytics over the per-token activity rollups and current balances.
+	TokenStats(ctx context.Context, admin, id string, q indexer.StatsQuery) (*indexer.TokenStats, error)
+	TopHolders(ctx context.Context, admin, id string, limit int) (*indexer.TopHolders, error)
+	HolderDistribution(ctx context.Context, admin, id string) (*indexer.HolderDistribution, error)
+
 	// Audit trail (immutable, ordered by ledger_offset ASC)
 	GetEvent(ctx context.Context, contractID string) (*indexer.ParsedEvent, error)
 	ListTokenEvents(
@@ -76,15 +114,33 @@ type Service interface {
 	) (*indexer.Page[indexer.Transfer], error)
 	// GetPendingTransfers returns all pending offer-based transfers across all parties, paginated.
 	GetPendingTransfers(ctx context.Context, p indexer.Pagination) (*indexer.Page[indexer.Transfer], error)
+
+	// Exports call fn for every row of the matching list, in list order, with
+	// the token metadata joined in. The store is read a page at a time, so an
+	// export holds one page in memory however many rows it has. An error from
+	// fn stops the export and is returned.
+	ExportTokenEvents(
+		ctx context.Context, admin, id string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error,
+	) error
+	ExportPartyEvents(ctx context.Context, partyID string, f indexer.EventFilter, fn func(*indexer.ExportedEvent) error) error
+	ExportTransfers(
+		ctx context.Context, partyID string, query indexer.TransferQuery, fn func(*indexer.ExportedTransfer) error,
+	) error
 }
 
-// NewService creates a new indexer Service backed by store.
-func NewService(store Store, logger *zap.Logger) Service {
-	return &svc{store: store, logger: logger}
+// NewService creates a new indexer Service backed by store. tokens is the
+// metadata joined into exports.
+func NewService(store Store, tokens []indexer.TokenMetadata, logger *zap.Logger) Service {
+	byKey := make(map[indexer.InstrumentKey]indexer.TokenMetadata, len(tokens))
+	for _, t := range tokens {
+		byKey[indexer.InstrumentKey{Admin: t.Admin, ID: t.ID}] = t
+	}
+	return &svc{store: store, tokens: byKey, logger: logger}
 }
 
 type svc struct {
 	store  Store
+	tokens map[indexer.InstrumentKey]indexer.TokenMetadata
 	logger *zap.Logger
 }
 
@@ -100,6 +156,9 @@ func (s *svc) GetToken(ctx context.Context, admin, id string) (*indexer.Token, e
 }
 
 func (s *svc) ListTokens(ctx context.Context, p indexer.Pagination) (*indexer.Page[*indexer.Token], error) {
+	if err := requireNumberedPages(p); err != nil {
+		return nil, err
+	}
 	items, total, err := s.store.ListTokens(ctx, p)
 	if err != nil {
 		return nil, err
@@ -134,7 +193,9 @@ func (s *svc) ListBalancesForParty(ctx context.Context, partyID string, p indexe
 	if err != nil {
 		return nil, err
 	}
-	return &indexer.Page[*indexer.Balance]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+	return indexer.NewPage(items, total, p, func(b *indexer.Balance) indexer.Cursor {
+		return indexer.Cursor{InstrumentAdmin: b.InstrumentAdmin, InstrumentID: b.InstrumentID}
+	}), nil
 }
 
 func (s *svc) ListBalancesForToken(ctx context.Context, admin, id string, p indexer.Pagination) (*indexer.Page[*indexer.Balance], error) {
@@ -142,7 +203,87 @@ func (s *svc) ListBalancesForToken(ctx context.Context, admin, id string, p inde
 	if err != nil {
 		return nil, err
 	}
-	return &indexer.Page[*indexer.Balance]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+	return indexer.NewPage(items, total, p, func(b *indexer.Balance) indexer.Cursor {
+		return indexer.Cursor{PartyID: b.PartyID}
+	}), nil
+}
+
+// checkAsOf rejects offsets the indexer has not reached yet: the answer for
+// them could still change, so serving it would not be a stable snapshot.
+func (s *svc) checkAsOf(ctx context.Context, at indexer.AsOf) error {
+	if at.Offset == 0 {
+		return nil
+	}
+	latest, err := s.store.LatestOffset(ctx)
+	if err != nil {
+		return err
+	}
+	if at.Offset > latest {
+		return apperrors.BadRequestError(nil, fmt.Sprintf("at_offset %d is beyond the indexed ledger offset %d", at.Offset, latest))
+	}
+	return nil
+}
+
+// GetBalanceAt returns a zero balance rather than 404 when the party had no
+// recorded balance at that point — "held nothing" is a valid historical answer.
+func (s *svc) GetBalanceAt(
+	ctx context.Context, partyID, admin, id string, at indexer.AsOf,
+) (*indexer.HistoricalBalance, error) {
+	if err := s.checkAsOf(ctx, at); err != nil {
+		return nil, err
+	}
+	b, err := s.store.GetBalanceAt(ctx, partyID, admin, id, at)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return &indexer.HistoricalBalance{Balance: indexer.Balance{
+			PartyID:         partyID,
+			InstrumentAdmin: admin,
+			InstrumentID:    id,
+			Amount:          "0",
+		}}, nil
+	}
+	return b, nil
+}
+
+// SupplyAt returns a zero supply when the token exists but had no recorded
+// supply at that point, and 404 when the token is unknown.
+func (s *svc) SupplyAt(ctx context.Context, admin, id string, at indexer.AsOf) (*indexer.HistoricalSupply, error) {
+	if err := s.checkAsOf(ctx, at); err != nil {
+		return nil, err
+	}
+	supply, err := s.store.GetSupplyAt(ctx, admin, id, at)
+	if err != nil {
+		return nil, err
+	}
+	if supply != nil {
+		return supply, nil
+	}
+	t, err := s.store.GetToken(ctx, admin, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, apperrors.ResourceNotFoundError(nil, "token not found")
+	}
+	return &indexer.HistoricalSupply{InstrumentAdmin: admin, InstrumentID: id, TotalSupply: "0"}, nil
+}
+
+func (s *svc) ListHoldersAt(
+	ctx context.Context, admin, id string, at indexer.AsOf, p indexer.Pagination,
+) (*indexer.Page[*indexer.HistoricalBalance], error) {
+	if err := requireNumberedPages(p); err != nil {
+		return nil, err
+	}
+	if err := s.checkAsOf(ctx, at); err != nil {
+		return nil, err
+	}
+	items, total, err := s.store.ListHoldersAt(ctx, admin, id, at, p)
+	if err != nil {
+		return nil, err
+	}
+	return &indexer.Page[*indexer.HistoricalBalance]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
 }
 
 func (s *svc) GetTransfer(ctx context.Context, contractID string) (*indexer.Transfer, error) {
@@ -179,7 +320,7 @@ func (s *svc) ListTokenEvents(
 	if err != nil {
 		return nil, err
 	}
-	return &indexer.Page[*indexer.ParsedEvent]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+	return indexer.NewPage(items, total, p, eventCursor), nil
 }
 
 func (s *svc) ListPartyEvents(
@@ -193,7 +334,7 @@ func (s *svc) ListPartyEvents(
 	if err != nil {
 		return nil, err
 	}
-	return &indexer.Page[*indexer.ParsedEvent]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+	return indexer.NewPage(items, total, p, eventCursor), nil
 }
 
 func (s *svc) GetTransfers(
@@ -203,7 +344,7 @@ func (s *svc) GetTransfers(
 	if err != nil {
 		return nil, err
 	}
-	return &indexer.Page[indexer.Transfer]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+	return indexer.NewPage(items, total, p, transferCursor), nil
 }
 
 func (s *svc) GetPendingTransfers(
@@ -213,5 +354,21 @@ func (s *svc) GetPendingTransfers(
 	if err != nil {
 		return nil, err
 	}
-	return &indexer.Page[indexer.Transfer]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+	return indexer.NewPage(items, total, p, transferCursor), nil
+}
+
+// requireNumberedPages rejects a cursor for lists that only support numbered pages.
+func requireNumberedPages(p indexer.Pagination) error {
+	if p.Cursor != nil {
+		return apperrors.BadRequestError(nil, "this list does not support cursor pagination")
+	}
+	return nil
+}
+
+func eventCursor(e *indexer.ParsedEvent) indexer.Cursor {
+	return indexer.Cursor{LedgerOffset: e.LedgerOffset, ContractID: e.ContractID}
+}
+
+func transferCursor(t indexer.Transfer) indexer.Cursor {
+	return indexer.Cursor{LedgerOffset: t.LedgerOffset, ContractID: t.ContractID}
 }
@@ -5,7 +5,9 @@ package service_test
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -26,7 +28,7 @@ const (
 func newSvc(t *testing.T) (service.Service, *mocks.Store) {
 	t.Helper()
 	store := mocks.NewStore(t)
-	return service.NewService(store, zap.NewNop()), store
+	return service.NewService(store, nil, zap.NewNop()), store
 }
 
 // ─── GetToken ─────────────────────────────────────────────────────────────────
@@ -144,6 +146,105 @@ func TestSvc_GetBalance(t *testing.T) {
 	})
 }
 
+// ─── Point-in-time queries ────────────────────────────────────────────────────
+
+func TestSvc_GetBalanceAt(t *testing.T) {
+	at := indexer.AsOf{Offset: 10}
+
+	t.Run("success", func(t *testing.T) {
+		svc, store := newSvc(t)
+		hb := &indexer.HistoricalBalance{Balance: indexer.Balance{PartyID: alice, Amount: "42"}, LedgerOffset: 7}
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(20), nil)
+		store.EXPECT().GetBalanceAt(mock.Anything, alice, admin, "DEMO", at).Return(hb, nil)
+
+		got, err := svc.GetBalanceAt(context.Background(), alice, admin, "DEMO", at)
+		require.NoError(t, err)
+		assert.Equal(t, hb, got)
+	})
+
+	t.Run("no history → zero balance", func(t *testing.T) {
+		svc, store := newSvc(t)
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(20), nil)
+		store.EXPECT().GetBalanceAt(mock.Anything, alice, admin, "DEMO", at).Return(nil, nil)
+
+		got, err := svc.GetBalanceAt(context.Background(), alice, admin, "DEMO", at)
+		require.NoError(t, err)
+		assert.Equal(t, "0", got.Amount)
+		assert.Equal(t, alice, got.PartyID)
+	})
+
+	t.Run("offset beyond indexed → 400", func(t *testing.T) {
+		svc, store := newSvc(t)
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(5), nil)
+
+		_, err := svc.GetBalanceAt(context.Background(), alice, admin, "DEMO", at)
+		require.Error(t, err)
+		assert.True(t, apperr.Is(err, apperr.CategoryDataError))
+	})
+
+	t.Run("time query skips offset check", func(t *testing.T) {
+		svc, store := newSvc(t)
+		byTime := indexer.AsOf{Time: time.Unix(1_700_000_000, 0).UTC()}
+		store.EXPECT().GetBalanceAt(mock.Anything, alice, admin, "DEMO", byTime).Return(nil, nil)
+
+		_, err := svc.GetBalanceAt(context.Background(), alice, admin, "DEMO", byTime)
+		require.NoError(t, err)
+	})
+}
+
+func TestSvc_SupplyAt(t *testing.T) {
+	at := indexer.AsOf{Offset: 10}
+
+	t.Run("success", func(t *testing.T) {
+		svc, store := newSvc(t)
+		hs := &indexer.HistoricalSupply{InstrumentAdmin: admin, InstrumentID: "DEMO", TotalSupply: "900", HolderCount: 2}
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		store.EXPECT().GetSupplyAt(mock.Anything, admin, "DEMO", at).Return(hs, nil)
+
+		got, err := svc.SupplyAt(context.Background(), admin, "DEMO", at)
+		require.NoError(t, err)
+		assert.Equal(t, hs, got)
+	})
+
+	t.Run("known token without history → zero", func(t *testing.T) {
+		svc, store := newSvc(t)
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		store.EXPECT().GetSupplyAt(mock.Anything, admin, "DEMO", at).Return(nil, nil)
+		store.EXPECT().GetToken(mock.Anything, admin, "DEMO").Return(&indexer.Token{}, nil)
+
+		got, err := svc.SupplyAt(context.Background(), admin, "DEMO", at)
+		require.NoError(t, err)
+		assert.Equal(t, "0", got.TotalSupply)
+	})
+
+	t.Run("unknown token → 404", func(t *testing.T) {
+		svc, store := newSvc(t)
+		store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+		store.EXPECT().GetSupplyAt(mock.Anything, admin, "DEMO", at).Return(nil, nil)
+		store.EXPECT().GetToken(mock.Anything, admin, "DEMO").Return(nil, nil)
+
+		_, err := svc.SupplyAt(context.Background(), admin, "DEMO", at)
+		require.Error(t, err)
+		assert.True(t, apperr.Is(err, apperr.CategoryResourceNotFound))
+	})
+}
+
+func TestSvc_ListHoldersAt(t *testing.T) {
+	at := indexer.AsOf{Offset: 10}
+	p := indexer.Pagination{Page: 1, Limit: 10}
+	holder := &indexer.HistoricalBalance{Balance: indexer.Balance{PartyID: alice, Amount: "5"}, LedgerOffset: 3}
+
+	svc, store := newSvc(t)
+	store.EXPECT().LatestOffset(mock.Anything).Return(int64(10), nil)
+	store.EXPECT().ListHoldersAt(mock.Anything, admin, "DEMO", at, p).
+		Return([]*indexer.HistoricalBalance{holder}, int64(1), nil)
+
+	page, err := svc.ListHoldersAt(context.Background(), admin, "DEMO", at, p)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	assert.Equal(t, []*indexer.HistoricalBalance{holder}, page.Items)
+}
+
 // ─── ListBalancesForParty ─────────────────────────────────────────────────────
 
 func TestSvc_ListBalancesForParty(t *testing.T) {
@@ -161,6 +262,35 @@ func TestSvc_ListBalancesForParty(t *testing.T) {
 	})
 }
 
+func TestSvc_ListHoldersAt_RejectsCursor(t *testing.T) {
+	svc, _ := newSvc(t)
+	p := indexer.Pagination{Limit: 10, Cursor: &indexer.Cursor{PartyID: alice}}
+	_, err := svc.ListHoldersAt(context.Background(), admin, "DEMO", indexer.AsOf{Offset: 10}, p)
+	require.Error(t, err)
+	assert.True(t, apperr.Is(err, apperr.CategoryDataError))
+}
+
+func TestSvc_ListBalancesForParty_Cursor(t *testing.T) {
+	balances := []*indexer.Balance{
+		{PartyID: alice, InstrumentAdmin: admin, InstrumentID: "A"},
+		{PartyID: alice, InstrumentAdmin: admin, InstrumentID: "B"},
+	}
+	p := indexer.Pagination{Limit: 2, Cursor: &indexer.Cursor{InstrumentAdmin: admin, InstrumentID: "0"}}
+
+	svc, store := newSvc(t)
+	store.EXPECT().ListBalancesForParty(mock.Anything, alice, p).
+		Return(balances, int64(indexer.TotalUnknown), nil)
+
+	page, err := svc.ListBalancesForParty(context.Background(), alice, p)
+	require.NoError(t, err)
+	assert.Equal(t, int64(indexer.TotalUnknown), page.Total)
+	assert.Zero(t, page.Page)
+	require.NotNil(t, page.NextCursor)
+	assert.Equal(t, indexer.Cursor{InstrumentAdmin: admin, InstrumentID: "B"}, *page.NextCursor)
+	require.NotNil(t, page.PrevCursor)
+	assert.Equal(t, indexer.Cursor{InstrumentAdmin: admin, InstrumentID: "A", Backward: true}, *page.PrevCursor)
+}
+
 // ─── ListBalancesForToken ─────────────────────────────────────────────────────
 
 func TestSvc_ListBalancesForToken(t *testing.T) {
@@ -178,6 +308,98 @@ func TestSvc_ListBalancesForToken(t *testing.T) {
 	})
 }
 
+// ─── Token stats ──────────────────────────────────────────────────────────────
+
+func TestSvc_TokenStats(t *testing.T) {
+	token := &indexer.Token{InstrumentAdmin: admin, InstrumentID: "DEMO", TotalSupply: "100"}
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("widens the range to whole buckets and zero-fills gaps", func(t *testing.T) {
+		svc, store := newSvc(t)
+		active := &indexer.TokenStatsBucket{Start: day.Add(time.Hour), TransferCount: 3, TransferVolume: "30"}
+		store.EXPECT().GetToken(mock.Anything, admin, "DEMO").Return(token, nil)
+		store.EXPECT().ListTokenStats(mock.Anything, admin, "DEMO", indexer.StatsIntervalHour, day, day.Add(3*time.Hour)).
+			Return([]*indexer.TokenStatsBucket{active}, nil)
+
+		stats, err := svc.TokenStats(context.Background(), admin, "DEMO", indexer.StatsQuery{
+			Interval: indexer.StatsIntervalHour,
+			Since:    day.Add(30 * time.Minute),
+			Until:    day.Add(2*time.Hour + time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, day, stats.Since)
+		assert.Equal(t, day.Add(3*time.Hour), stats.Until)
+		require.Len(t, stats.Buckets, 3)
+		assert.Equal(t, &indexer.TokenStatsBucket{Start: day, TransferVolume: "0", MintVolume: "0", BurnVolume: "0"},
+			stats.Buckets[0])
+		assert.Same(t, active, stats.Buckets[1])
+		assert.Equal(t, day.Add(2*time.Hour), stats.Buckets[2].Start)
+	})
+
+	t.Run("defaults to the most recent buckets", func(t *testing.T) {
+		svc, store := newSvc(t)
+		store.EXPECT().GetToken(mock.Anything, admin, "DEMO").Return(token, nil)
+		store.EXPECT().ListTokenStats(mock.Anything, admin, "DEMO", indexer.StatsIntervalDay, day.AddDate(0, 0, -30), day).
+			Return(nil, nil)
+
+		stats, err := svc.TokenStats(context.Background(), admin, "DEMO",
+			indexer.StatsQuery{Interval: indexer.StatsIntervalDay, Until: day})
+		require.NoError(t, err)
+		assert.Len(t, stats.Buckets, 30)
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		for name, q := range map[string]indexer.StatsQuery{
+			"unknown interval":  {Interval: "week"},
+			"since after until": {Interval: indexer.StatsIntervalDay, Since: day.Add(48 * time.Hour), Until: day},
+			"too many buckets":  {Interval: indexer.StatsIntervalHour, Since: day, Until: day.AddDate(0, 2, 0)},
+			"empty after widen": {Interval: indexer.StatsIntervalDay, Since: day, Until: day},
+		} {
+			svc, _ := newSvc(t)
+			_, err := svc.TokenStats(context.Background(), admin, "DEMO", q)
+			assert.True(t, apperr.Is(err, apperr.CategoryDataError), name)
+		}
+	})
+
+	t.Run("token not found", func(t *testing.T) {
+		svc, store := newSvc(t)
+		store.EXPECT().GetToken(mock.Anything, admin, "NOPE").Return(nil, nil)
+
+		_, err := svc.TokenStats(context.Background(), admin, "NOPE", indexer.StatsQuery{Interval: indexer.StatsIntervalDay})
+		assert.True(t, apperr.Is(err, apperr.CategoryResourceNotFound))
+	})
+}
+
+func TestSvc_TopHolders(t *testing.T) {
+	svc, store := newSvc(t)
+	store.EXPECT().GetToken(mock.Anything, admin, "DEMO").
+		Return(&indexer.Token{InstrumentAdmin: admin, InstrumentID: "DEMO", TotalSupply: "300"}, nil)
+	store.EXPECT().ListTopHolders(mock.Anything, admin, "DEMO", 2).Return([]*indexer.Balance{
+		{PartyID: alice, Amount: "200"},
+		{PartyID: "bob", Amount: "100"},
+	}, nil)
+
+	top, err := svc.TopHolders(context.Background(), admin, "DEMO", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*indexer.RankedHolder{
+		{Rank: 1, PartyID: alice, Amount: "200", Share: "0.66666667"},
+		{Rank: 2, PartyID: "bob", Amount: "100", Share: "0.33333333"},
+	}, top.Holders)
+}
+
+func TestSvc_HolderDistribution(t *testing.T) {
+	svc, store := newSvc(t)
+	buckets := []indexer.HolderBucket{{Min: "10", Max: "100", Holders: 2, Amount: "150"}}
+	store.EXPECT().GetToken(mock.Anything, admin, "DEMO").
+		Return(&indexer.Token{InstrumentAdmin: admin, InstrumentID: "DEMO", HolderCount: 2}, nil)
+	store.EXPECT().GetHolderDistribution(mock.Anything, admin, "DEMO").Return(buckets, nil)
+
+	dist, err := svc.HolderDistribution(context.Background(), admin, "DEMO")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), dist.HolderCount)
+	assert.Equal(t, buckets, dist.Buckets)
+}
+
 // ─── GetEvent ─────────────────────────────────────────────────────────────────
 
 func TestSvc_GetEvent(t *testing.T) {
@@ -263,3 +485,58 @@ func TestSvc_ListPartyEvents(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// ─── Exports ──────────────────────────────────────────────────────────────────
+
+func TestSvc_ExportPartyEvents(t *testing.T) {
+	// One full page, then a short one: the export reads both, the second from
+	// a cursor after the last row of the first.
+	first := make([]*indexer.ParsedEvent, service.ExportBatchSize)
+	for i := range first {
+		first[i] = &indexer.ParsedEvent{ContractID: "c", LedgerOffset: int64(i + 1), InstrumentAdmin: admin, InstrumentID: "DEMO"}
+	}
+	last := &indexer.ParsedEvent{ContractID: "z", LedgerOffset: 5000, InstrumentAdmin: admin, InstrumentID: "OTHER"}
+	f := indexer.EventFilter{EventType: indexer.EventMint}
+	want := indexer.EventFilter{EventType: indexer.EventMint, PartyID: alice}
+
+	store := mocks.NewStore(t)
+	svc := service.NewService(store, []indexer.TokenMetadata{{Admin: admin, ID: "DEMO", Symbol: "DMO", Decimals: 18}}, zap.NewNop())
+	store.EXPECT().ListEvents(mock.Anything, want, mock.MatchedBy(func(p indexer.Pagination) bool {
+		return p.Cursor != nil && p.Cursor.LedgerOffset == 0 && !p.WithTotal
+	})).Return(first, int64(indexer.TotalUnknown), nil)
+	store.EXPECT().ListEvents(mock.Anything, want, mock.MatchedBy(func(p indexer.Pagination) bool {
+		return p.Cursor != nil && *p.Cursor == indexer.Cursor{LedgerOffset: int64(service.ExportBatchSize), ContractID: "c"}
+	})).Return([]*indexer.ParsedEvent{last}, int64(indexer.TotalUnknown), nil)
+
+	var rows []*indexer.ExportedEvent
+	err := svc.ExportPartyEvents(context.Background(), alice, f, func(e *indexer.ExportedEvent) error {
+		rows = append(rows, e)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, service.ExportBatchSize+1)
+
+	// Configured metadata is joined in; other instruments fall back to their ID.
+	assert.Equal(t, "DMO", rows[0].Symbol)
+	require.NotNil(t, rows[0].Decimals)
+	assert.Equal(t, 18, *rows[0].Decimals)
+	assert.Equal(t, "OTHER", rows[len(rows)-1].Symbol)
+	assert.Nil(t, rows[len(rows)-1].Decimals)
+}
+
+func TestSvc_ExportTransfers_StopsOnCallbackError(t *testing.T) {
+	svc, store := newSvc(t)
+	store.EXPECT().ListTransfers(mock.Anything, alice, indexer.TransferQuery{}, mock.MatchedBy(func(p indexer.Pagination) bool {
+		// Transfers are listed newest first, so the export starts above every offset.
+		return p.Cursor != nil && p.Cursor.LedgerOffset == math.MaxInt64
+	})).Return([]indexer.Transfer{{ContractID: "t1"}, {ContractID: "t2"}}, int64(indexer.TotalUnknown), nil)
+
+	stop := errors.New("client went away")
+	calls := 0
+	err := svc.ExportTransfers(context.Background(), alice, indexer.TransferQuery{}, func(*indexer.ExportedTransfer) error {
+		calls++
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const (
+	// MaxStatsBuckets bounds the length of a stats series.
+	MaxStatsBuckets = 1000
+	// DefaultTopHolders is the length of a top-holder ranking without ?limit=.
+	DefaultTopHolders = 10
+
+	// shareDecimals is the precision of a holder's share of the supply.
+	shareDecimals = 8
+)
+
+// defaultStatsBuckets is the length of a series without ?since=: two days of
+// hours or thirty days.
+var defaultStatsBuckets = map[indexer.StatsInterval]int{
+	indexer.StatsIntervalHour: 48,
+	indexer.StatsIntervalDay:  30,
+}
+
+func (s *svc) TokenStats(ctx context.Context, admin, id string, q indexer.StatsQuery) (*indexer.TokenStats, error) {
+	since, until, err := statsRange(q, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.GetToken(ctx, admin, id); err != nil {
+		return nil, err
+	}
+	rows, err := s.store.ListTokenStats(ctx, admin, id, q.Interval, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rollups only have rows for buckets with activity; fill in the rest.
+	width := q.Interval.Duration()
+	buckets := make([]*indexer.TokenStatsBucket, 0, int(until.Sub(since)/width))
+	for start := since; start.Before(until); start = start.Add(width) {
+		if len(rows) > 0 && rows[0].Start.Equal(start) {
+			buckets = append(buckets, rows[0])
+			rows = rows[1:]
+			continue
+		}
+		buckets = append(buckets, &indexer.TokenStatsBucket{
+			Start: start, TransferVolume: "0", MintVolume: "0", BurnVolume: "0",
+		})
+	}
+	return &indexer.TokenStats{
+		InstrumentAdmin: admin,
+		InstrumentID:    id,
+		Interval:        q.Interval,
+		Since:           since,
+		Until:           until,
+		Buckets:         buckets,
+	}, nil
+}
+
+// statsRange validates q and widens its range to whole buckets, defaulting
+// Until to now and Since to the default number of buckets before Until.
+func statsRange(q indexer.StatsQuery, now time.Time) (since, until time.Time, err error) {
+	width := q.Interval.Duration()
+	if width == 0 {
+		return since, until, apperrors.BadRequestError(nil, "interval must be hour or day")
+	}
+	until = now
+	if !q.Until.IsZero() {
+		until = q.Until
+	}
+	end := q.Interval.BucketStart(until)
+	if end.Before(until) {
+		end = end.Add(width)
+	}
+	until = end
+	since = until.Add(-time.Duration(defaultStatsBuckets[q.Interval]) * width)
+	if !q.Since.IsZero() {
+		since = q.Interval.BucketStart(q.Since)
+	}
+	if !since.Before(until) {
+		return since, until, apperrors.BadRequestError(nil, "since must be before until")
+	}
+	if until.Sub(since)/width > MaxStatsBuckets {
+		return since, until, apperrors.BadRequestError(nil, "range spans more than 1000 buckets")
+	}
+	return since, until, nil
+}
+
+func (s *svc) TopHolders(ctx context.Context, admin, id string, limit int) (*indexer.TopHolders, error) {
+	t, err := s.GetToken(ctx, admin, id)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.store.ListTopHolders(ctx, admin, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	// Supply is only tracked for mint/burn-based instruments; without it there
+	// is no share to report.
+	supply, _ := decimal.NewFromString(t.TotalSupply)
+	holders := make([]*indexer.RankedHolder, len(balances))
+	for i, b := range balances {
+		holders[i] = &indexer.RankedHolder{Rank: i + 1, PartyID: b.PartyID, Amount: b.Amount}
+		if amount, err := decimal.NewFromString(b.Amount); err == nil && supply.IsPositive() {
+			holders[i].Share = amount.DivRound(supply, shareDecimals).String()
+		}
+	}
+	return &indexer.TopHolders{
+		InstrumentAdmin: admin,
+		InstrumentID:    id,
+		TotalSupply:     t.TotalSupply,
+		Holders:         holders,
+	}, nil
+}
+
+func (s *svc) HolderDistribution(ctx context.Context, admin, id string) (*indexer.HolderDistribution, error) {
+	t, err := s.GetToken(ctx, admin, id)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := s.store.GetHolderDistribution(ctx, admin, id)
+	if err != nil {
+		return nil, err
+	}
+	return &indexer.HolderDistribution{
+		InstrumentAdmin: admin,
+		InstrumentID:    id,
+		HolderCount:     t.HolderCount,
+		Buckets:         buckets,
+	}, nil
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import "time"
+
+// StatsInterval is the width of the buckets of a token activity series.
+type StatsInterval string
+
+const (
+	StatsIntervalHour StatsInterval = "hour"
+	StatsIntervalDay  StatsInterval = "day"
+)
+
+// StatsIntervals lists every interval the indexer rolls activity up into.
+var StatsIntervals = []StatsInterval{StatsIntervalHour, StatsIntervalDay}
+
+// Duration returns the bucket width, or 0 for an unknown interval.
+func (i StatsInterval) Duration() time.Duration {
+	switch i {
+	case StatsIntervalHour:
+		return time.Hour
+	case StatsIntervalDay:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// BucketStart returns the start of the bucket containing t, in UTC.
+func (i StatsInterval) BucketStart(t time.Time) time.Time {
+	return t.UTC().Truncate(i.Duration())
+}
+
+// StatsQuery selects a token activity series. Since and Until are widened to
+// bucket boundaries; zero values select the most recent buckets.
+type StatsQuery struct {
+	Interval StatsInterval
+	Since    time.Time
+	Until    time.Time
+}
+
+// TokenStatsBucket is a token's activity within one bucket of a series,
+// attributed by the effective time of the ledger transaction. Volumes are
+// decimal strings. Transfers count settled transfers only: direct transfers
+// and accepted offers. ActiveParties counts the distinct parties that sent,
+// received, minted or burned; NewHolders the parties whose first balance of
+// the token was credited.
+type TokenStatsBucket struct {
+	Start          time.Time `json:"start"`
+	TransferCount  int64     `json:"transfer_count"`
+	TransferVolume string    `json:"transfer_volume"`
+	MintCount      int64     `json:"mint_count"`
+	MintVolume     string    `json:"mint_volume"`
+	BurnCount      int64     `json:"burn_count"`
+	BurnVolume     string    `json:"burn_volume"`
+	ActiveParties  int64     `json:"active_parties"`
+	NewHolders     int64     `json:"new_holders"`
+}
+
+// TokenStats is a token's activity series over [Since, Until). Every bucket
+// of the range is present, zero-filled when the token saw no activity.
+type TokenStats struct {
+	InstrumentAdmin string              `json:"instrument_admin"`
+	InstrumentID    string              `json:"instrument_id"`
+	Interval        StatsInterval       `json:"interval"`
+	Since           time.Time           `json:"since"`
+	Until           time.Time           `json:"until"`
+	Buckets         []*TokenStatsBucket `json:"buckets"`
+}
+
+// RankedHolder is one entry of a top-holder ranking. Share is the holder's
+// fraction of the total supply, omitted while the supply is not tracked.
+type RankedHolder struct {
+	Rank    int    `json:"rank"`
+	PartyID string `json:"party_id"`
+	Amount  string `json:"amount"`
+	Share   string `json:"share,omitempty"`
+}
+
+// TopHolders ranks a token's largest current holders, largest first.
+type TopHolders struct {
+	InstrumentAdmin string          `json:"instrument_admin"`
+	InstrumentID    string          `json:"instrument_id"`
+	TotalSupply     string          `json:"total_supply"`
+	Holders         []*RankedHolder `json:"holders"`
+}
+
+// HolderBucket counts the holders whose balance lies in [Min, Max) and the
+// amount they hold together.
+type HolderBucket struct {
+	Min     string `json:"min"`
+	Max     string `json:"max"`
+	Holders int64  `json:"holders"`
+	Amount  string `json:"amount"`
+}
+
+// HolderDistribution buckets a token's current holders by order of magnitude
+// of their balance, smallest first. Empty buckets are omitted.
+type HolderDistribution struct {
+	InstrumentAdmin string         `json:"instrument_admin"`
+	InstrumentID    string         `json:"instrument_id"`
+	HolderCount     int64          `json:"holder_count"`
+	Buckets         []HolderBucket `json:"buckets"`
+}
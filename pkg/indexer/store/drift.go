@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// SampleParties returns up to limit parties with an indexed balance, chosen at
+// random. A limit <= 0 returns every such party, ordered by ID.
+func (s *PGStore) SampleParties(ctx context.Context, limit int) ([]string, error) {
+	var (
+		parties []string
+		err     error
+	)
+	if limit <= 0 {
+		err = s.db.NewSelect().
+			Model((*BalanceDao)(nil)).
+			ColumnExpr("DISTINCT party_id").
+			OrderExpr("party_id").
+			Scan(ctx, &parties)
+	} else {
+		err = s.db.NewRaw(
+			"SELECT party_id FROM (SELECT DISTINCT party_id FROM indexer_balances) p ORDER BY random() LIMIT ?",
+			limit,
+		).Scan(ctx, &parties)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sample parties: %w", err)
+	}
+	return parties, nil
+}
+
+// IndexedState reads the stored offset, every token, and the balances of the
+// given parties (every balance when parties is nil) from one snapshot, so they
+// all describe the ledger at that offset.
+func (s *PGStore) IndexedState(ctx context.Context, parties []string) (*indexer.IndexedState, error) {
+	state := new(indexer.IndexedState)
+	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
+		var err error
+		if state.LedgerOffset, err = (&PGStore{db: db}).LatestOffset(ctx); err != nil {
+			return err
+		}
+
+		var tokens []TokenDao
+		if err = db.NewSelect().Model(&tokens).OrderExpr("instrument_admin, instrument_id").Scan(ctx); err != nil {
+			return fmt.Errorf("select tokens: %w", err)
+		}
+		state.Tokens = make([]*indexer.Token, len(tokens))
+		for i := range tokens {
+			state.Tokens[i] = fromTokenDao(&tokens[i])
+		}
+
+		if parties != nil && len(parties) == 0 {
+			return nil
+		}
+		var balances []BalanceDao
+		q := db.NewSelect().Model(&balances)
+		if parties != nil {
+			q = q.Where("party_id IN (?)", bun.In(parties))
+		}
+		if err = q.Scan(ctx); err != nil {
+			return fmt.Errorf("select balances: %w", err)
+		}
+		state.Balances = make([]*indexer.Balance, len(balances))
+		for i := range balances {
+			state.Balances[i] = fromBalanceDao(&balances[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("indexed state: %w", err)
+	}
+	return state, nil
+}
+
+// RecordDrift stores the drifts a check found and sets their IDs.
+func (s *PGStore) RecordDrift(ctx context.Context, drifts []indexer.Drift) error {
+	if len(drifts) == 0 {
+		return nil
+	}
+	daos := make([]*DriftDao, len(drifts))
+	for i := range drifts {
+		daos[i] = toDriftDao(&drifts[i])
+	}
+	if _, err := s.db.NewInsert().Model(&daos).Exec(ctx); err != nil {
+		return fmt.Errorf("record drift: %w", err)
+	}
+	for i := range drifts {
+		drifts[i].ID = daos[i].ID
+	}
+	return nil
+}
+
+// ListDrift returns recorded drifts, newest first, optionally restricted to a
+// party ("" = all).
+func (s *PGStore) ListDrift(ctx context.Context, partyID string, p indexer.Pagination) ([]*indexer.Drift, int64, error) {
+	var daos []DriftDao
+	var total int
+	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
+		q := db.NewSelect().Model(&daos)
+		if partyID != "" {
+			q = q.Where("party_id = ?", partyID)
+		}
+		q = q.OrderExpr("id DESC")
+		var err error
+		if total, err = q.Count(ctx); err != nil {
+			return fmt.Errorf("count: %w", err)
+		}
+		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("list drift: %w", err)
+	}
+	drifts := make([]*indexer.Drift, len(daos))
+	for i := range daos {
+		drifts[i] = fromDriftDao(&daos[i])
+	}
+	return drifts, int64(total), nil
+}
+
+// RepairBalances overwrites balances with the ledger's amounts (each change's
+// After), in one transaction, keeping holder counts in step and recording the
+// repaired amounts in the balance history at offset. The indexer must not
+// commit anything while it runs, so offset stays the stored offset.
+func (s *PGStore) RepairBalances(ctx context.Context, offset int64, changes []indexer.BalanceChange) error {
+	return s.runReindexTx(ctx, false, func(ctx context.Context, tx *PGStore) error {
+		for _, c := range changes {
+			if err := tx.repairBalance(ctx, c); err != nil {
+				return err
+			}
+		}
+		effectiveTime, err := tx.lastEffectiveTime(ctx)
+		if err != nil {
+			return err
+		}
+		return tx.RecordHistory(ctx, offset, effectiveTime)
+	})
+}
+
+// repairBalance sets one balance to c.After and adjusts its token's holder
+// count when the balance crosses zero.
+func (s *PGStore) repairBalance(ctx context.Context, c indexer.BalanceChange) error {
+	after, err := decimal.NewFromString(c.After)
+	if err != nil {
+		return fmt.Errorf("parse repaired amount %q: %w", c.After, err)
+	}
+	stored, err := s.GetBalance(ctx, c.PartyID, c.InstrumentAdmin, c.InstrumentID)
+	if err != nil {
+		return err
+	}
+	before := decimal.Zero
+	if stored != nil {
+		if before, err = decimal.NewFromString(stored.Amount); err != nil {
+			return fmt.Errorf("parse stored amount %q: %w", stored.Amount, err)
+		}
+	}
+	if err = s.setBalance(ctx, c); err != nil {
+		return err
+	}
+	s.history.touchBalance(c.PartyID, c.InstrumentAdmin, c.InstrumentID, c.After)
+
+	var holderDelta int64
+	switch {
+	case !before.IsPositive() && after.IsPositive():
+		holderDelta = 1
+	case before.IsPositive() && !after.IsPositive():
+		holderDelta = -1
+	default:
+		return nil
+	}
+	_, err = s.db.NewUpdate().
+		Model((*TokenDao)(nil)).
+		Set("holder_count = holder_count + ?", holderDelta).
+		Where("instrument_admin = ?", c.InstrumentAdmin).
+		Where("instrument_id = ?", c.InstrumentID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("update holder count: %w", err)
+	}
+	s.history.touchToken(c.InstrumentAdmin, c.InstrumentID)
+	return nil
+}
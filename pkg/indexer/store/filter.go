@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// whereEvents adds the conditions of f to an indexer_events query.
+func whereEvents(q *bun.SelectQuery, f indexer.EventFilter) *bun.SelectQuery {
+	if f.InstrumentAdmin != "" {
+		q = q.Where("instrument_admin = ?", f.InstrumentAdmin)
+	}
+	if f.InstrumentID != "" {
+		q = q.Where("instrument_id = ?", f.InstrumentID)
+	}
+	switch cp := f.Counterparty; {
+	case f.PartyID != "" && cp != "":
+		q = q.Where("((from_party_id = ? AND to_party_id = ?) OR (from_party_id = ? AND to_party_id = ?))",
+			f.PartyID, cp, cp, f.PartyID)
+	case f.PartyID != "":
+		q = q.Where("(from_party_id = ? OR to_party_id = ?)", f.PartyID, f.PartyID)
+	case cp != "":
+		q = q.Where("(from_party_id = ? OR to_party_id = ?)", cp, cp)
+	}
+	if f.EventType != "" {
+		q = q.Where("event_type = ?", string(f.EventType))
+	}
+	if f.ExternalTxID != "" {
+		q = q.Where("external_tx_id = ?", f.ExternalTxID)
+	}
+	return whereHistory(q, f.HistoryFilter, "effective_time")
+}
+
+// whereTransferParties restricts an indexer_transfers query to partyID's side
+// selected by query.Role and, with a counterparty, to the other side.
+func whereTransferParties(q *bun.SelectQuery, partyID string, query indexer.TransferQuery) *bun.SelectQuery {
+	cp := query.Counterparty
+	switch query.Role {
+	case indexer.TransferRoleSender:
+		q = q.Where("from_party_id = ?", partyID)
+		if cp != "" {
+			q = q.Where("to_party_id = ?", cp)
+		}
+	case indexer.TransferRoleAny:
+		if cp != "" {
+			return q.Where("((from_party_id = ? AND to_party_id = ?) OR (from_party_id = ? AND to_party_id = ?))",
+				partyID, cp, cp, partyID)
+		}
+		q = q.Where("(from_party_id = ? OR to_party_id = ?)", partyID, partyID)
+	default: // receiver
+		q = q.Where("to_party_id = ?", partyID)
+		if cp != "" {
+			q = q.Where("from_party_id = ?", cp)
+		}
+	}
+	return q
+}
+
+// whereHistory adds the offset, time and amount ranges of f; timeCol is the
+// column the time range applies to. Counterparty is left to the caller, which
+// knows the listed party. Amounts are stored as text and compared as numeric.
+func whereHistory(q *bun.SelectQuery, f indexer.HistoryFilter, timeCol string) *bun.SelectQuery {
+	if f.FromOffset > 0 {
+		q = q.Where("ledger_offset >= ?", f.FromOffset)
+	}
+	if f.ToOffset > 0 {
+		q = q.Where("ledger_offset <= ?", f.ToOffset)
+	}
+	if !f.Since.IsZero() {
+		q = q.Where("? >= ?", bun.Ident(timeCol), f.Since)
+	}
+	if !f.Until.IsZero() {
+		q = q.Where("? < ?", bun.Ident(timeCol), f.Until)
+	}
+	if f.MinAmount != "" {
+		q = q.Where("amount::numeric >= ?::numeric", f.MinAmount)
+	}
+	if f.MaxAmount != "" {
+		q = q.Where("amount::numeric <= ?::numeric", f.MaxAmount)
+	}
+	return q
+}
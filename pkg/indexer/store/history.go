@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+type balanceKey struct {
+	partyID string
+	token   indexer.InstrumentKey
+}
+
+// historyTracker records which balances and tokens a transaction changed, in
+// first-touch order, so RecordHistory can write one history row per change.
+type historyTracker struct {
+	balances     map[balanceKey]string // latest amount after the transaction
+	balanceOrder []balanceKey
+	tokens       map[indexer.InstrumentKey]struct{}
+	tokenOrder   []indexer.InstrumentKey
+}
+
+func newHistoryTracker() *historyTracker {
+	return &historyTracker{
+		balances: make(map[balanceKey]string),
+		tokens:   make(map[indexer.InstrumentKey]struct{}),
+	}
+}
+
+func (h *historyTracker) touchBalance(partyID, admin, id, amount string) {
+	if h == nil {
+		return
+	}
+	k := balanceKey{partyID: partyID, token: indexer.InstrumentKey{Admin: admin, ID: id}}
+	if _, ok := h.balances[k]; !ok {
+		h.balanceOrder = append(h.balanceOrder, k)
+	}
+	h.balances[k] = amount
+}
+
+func (h *historyTracker) touchToken(admin, id string) {
+	if h == nil {
+		return
+	}
+	k := indexer.InstrumentKey{Admin: admin, ID: id}
+	if _, ok := h.tokens[k]; !ok {
+		h.tokens[k] = struct{}{}
+		h.tokenOrder = append(h.tokenOrder, k)
+	}
+}
+
+// RecordHistory writes a history row at offset for every balance and token
+// changed earlier in this transaction, then resets the tracker. Rows already
+// present at offset are overwritten, so a retried transaction is harmless.
+// Must be called on the transaction-scoped store passed to RunInTx.
+func (s *PGStore) RecordHistory(ctx context.Context, offset int64, effectiveTime time.Time) error {
+	if s.history == nil {
+		return errors.New("RecordHistory called outside a transaction")
+	}
+	h := s.history
+	defer func() { s.history = newHistoryTracker() }()
+
+	if len(h.balanceOrder) > 0 {
+		rows := make([]BalanceHistoryDao, 0, len(h.balanceOrder))
+		for _, k := range h.balanceOrder {
+			rows = append(rows, BalanceHistoryDao{
+				PartyID:         k.partyID,
+				InstrumentAdmin: k.token.Admin,
+				InstrumentID:    k.token.ID,
+				LedgerOffset:    offset,
+				Amount:          h.balances[k],
+				EffectiveTime:   effectiveTime,
+			})
+		}
+		_, err := s.db.NewInsert().
+			Model(&rows).
+			On("CONFLICT (party_id, instrument_admin, instrument_id, ledger_offset) DO UPDATE").
+			Set("amount = EXCLUDED.amount").
+			Set("effective_time = EXCLUDED.effective_time").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("record balance history: %w", err)
+		}
+	}
+
+	for _, k := range h.tokenOrder {
+		// Copy the committed-so-far token row so supply and holder count are
+		// captured as they stand after every delta of this transaction.
+		_, err := s.db.NewRaw(`
+			INSERT INTO indexer_supply_history
+				(instrument_admin, instrument_id, ledger_offset, total_supply, holder_count, effective_time)
+			SELECT instrument_admin, instrument_id, ?, total_supply, holder_count, ?
+			FROM indexer_tokens
+			WHERE instrument_admin = ? AND instrument_id = ?
+			ON CONFLICT (instrument_admin, instrument_id, ledger_offset) DO UPDATE
+			SET total_supply = EXCLUDED.total_supply,
+				holder_count = EXCLUDED.holder_count,
+				effective_time = EXCLUDED.effective_time`,
+			offset, effectiveTime, k.Admin, k.ID,
+		).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("record supply history for %s/%s: %w", k.Admin, k.ID, err)
+		}
+	}
+	return nil
+}
+
+// whereAsOf restricts a history query to rows at or before the requested point.
+func whereAsOf(q *bun.SelectQuery, at indexer.AsOf) *bun.SelectQuery {
+	if at.Offset > 0 {
+		return q.Where("ledger_offset <= ?", at.Offset)
+	}
+	return q.Where("effective_time <= ?", at.Time)
+}
+
+// GetBalanceAt returns a party's balance as of the given point in ledger
+// history. Returns nil, nil when no history row exists at or before it.
+func (s *PGStore) GetBalanceAt(
+	ctx context.Context, partyID, admin, id string, at indexer.AsOf,
+) (*indexer.HistoricalBalance, error) {
+	dao := new(BalanceHistoryDao)
+	q := s.db.NewSelect().Model(dao).
+		Where("party_id = ?", partyID).
+		Where("instrument_admin = ?", admin).
+		Where("instrument_id = ?", id)
+	err := whereAsOf(q, at).
+		OrderExpr("ledger_offset DESC").
+		Limit(1).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get balance at: %w", err)
+	}
+	return fromBalanceHistoryDao(dao), nil
+}
+
+// GetSupplyAt returns a token's total supply and holder count as of the given
+// point in ledger history. Returns nil, nil when no history row exists at or
+// before it.
+func (s *PGStore) GetSupplyAt(ctx context.Context, admin, id string, at indexer.AsOf) (*indexer.HistoricalSupply, error) {
+	dao := new(SupplyHistoryDao)
+	q := s.db.NewSelect().Model(dao).
+		Where("instrument_admin = ?", admin).
+		Where("instrument_id = ?", id)
+	err := whereAsOf(q, at).
+		OrderExpr("ledger_offset DESC").
+		Limit(1).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get supply at: %w", err)
+	}
+	return fromSupplyHistoryDao(dao), nil
+}
+
+// ListHoldersAt returns every party holding a non-zero balance of the token as
+// of the given point in ledger history, ordered by party ID. Each party's
+// latest history row at or before the point is its balance then. The Count and
+// Scan run in one read-only transaction (see runReadTx).
+func (s *PGStore) ListHoldersAt(
+	ctx context.Context, admin, id string, at indexer.AsOf, p indexer.Pagination,
+) ([]*indexer.HistoricalBalance, int64, error) {
+	var daos []BalanceHistoryDao
+	var total int
+	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
+		latest := db.NewSelect().Model((*BalanceHistoryDao)(nil)).
+			DistinctOn("party_id").
+			Where("instrument_admin = ?", admin).
+			Where("instrument_id = ?", id)
+		latest = whereAsOf(latest, at).OrderExpr("party_id ASC, ledger_offset DESC")
+
+		q := db.NewSelect().
+			TableExpr("(?) AS h", latest).
+			ColumnExpr("h.*").
+			Where("h.amount::numeric > 0").
+			OrderExpr("h.party_id ASC")
+		var err error
+		if total, err = q.Count(ctx); err != nil {
+			return fmt.Errorf("count: %w", err)
+		}
+		return q.Limit(p.Limit).Offset((p.Page-1)*p.Limit).Scan(ctx, &daos)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("list holders at: %w", err)
+	}
+	holders := make([]*indexer.HistoricalBalance, len(daos))
+	for i := range daos {
+		holders[i] = fromBalanceHistoryDao(&daos[i])
+	}
+	return holders, int64(total), nil
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -134,11 +135,11 @@ func (s *instrumentedWriteStore) InsertTransfer(ctx context.Context, t *indexer.
 	return err
 }
 
-func (s *instrumentedWriteStore) FinalizeTransfer(ctx context.Context, contractID, status string) error {
+func (s *instrumentedWriteStore) FinalizeTransfer(ctx context.Context, contractID, status string, offset int64) error {
 	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpFinalizeTransfer))
 	defer timer.ObserveDuration()
 
-	err := s.inner.FinalizeTransfer(ctx, contractID, status)
+	err := s.inner.FinalizeTransfer(ctx, contractID, status, offset)
 	if err != nil {
 		s.metrics.IncErrors(OpFinalizeTransfer)
 	}
@@ -156,17 +157,50 @@ func (s *instrumentedWriteStore) InsertHolding(ctx context.Context, h *indexer.H
 	return err
 }
 
-func (s *instrumentedWriteStore) TakeHolding(ctx context.Context, contractID string) (indexer.HoldingChange, bool, error) {
+func (s *instrumentedWriteStore) TakeHolding(ctx context.Context, contractID string, offset int64) (indexer.HoldingChange, bool, error) {
 	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpTakeHolding))
 	defer timer.ObserveDuration()
 
-	h, ok, err := s.inner.TakeHolding(ctx, contractID)
+	h, ok, err := s.inner.TakeHolding(ctx, contractID, offset)
 	if err != nil {
 		s.metrics.IncErrors(OpTakeHolding)
 	}
 	return h, ok, err
 }
 
+func (s *instrumentedWriteStore) RecordHistory(ctx context.Context, offset int64, effectiveTime time.Time) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRecordHistory))
+	defer timer.ObserveDuration()
+
+	err := s.inner.RecordHistory(ctx, offset, effectiveTime)
+	if err != nil {
+		s.metrics.IncErrors(OpRecordHistory)
+	}
+	return err
+}
+
+func (s *instrumentedWriteStore) RecordStats(ctx context.Context, effectiveTime time.Time) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRecordStats))
+	defer timer.ObserveDuration()
+
+	err := s.inner.RecordStats(ctx, effectiveTime)
+	if err != nil {
+		s.metrics.IncErrors(OpRecordStats)
+	}
+	return err
+}
+
+func (s *instrumentedWriteStore) EnqueueWebhooks(ctx context.Context, offset int64) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpEnqueueWebhooks))
+	defer timer.ObserveDuration()
+
+	err := s.inner.EnqueueWebhooks(ctx, offset)
+	if err != nil {
+		s.metrics.IncErrors(OpEnqueueWebhooks)
+	}
+	return err
+}
+
 func (s *InstrumentedStore) InsertEvent(ctx context.Context, event *indexer.ParsedEvent) (bool, error) {
 	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpInsertEvent))
 	defer timer.ObserveDuration()
@@ -189,6 +223,39 @@ func (s *InstrumentedStore) SaveOffset(ctx context.Context, offset int64) error
 	return err
 }
 
+func (s *InstrumentedStore) RecordHistory(ctx context.Context, offset int64, effectiveTime time.Time) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRecordHistory))
+	defer timer.ObserveDuration()
+
+	err := s.inner.RecordHistory(ctx, offset, effectiveTime)
+	if err != nil {
+		s.metrics.IncErrors(OpRecordHistory)
+	}
+	return err
+}
+
+func (s *InstrumentedStore) RecordStats(ctx context.Context, effectiveTime time.Time) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRecordStats))
+	defer timer.ObserveDuration()
+
+	err := s.inner.RecordStats(ctx, effectiveTime)
+	if err != nil {
+		s.metrics.IncErrors(OpRecordStats)
+	}
+	return err
+}
+
+func (s *InstrumentedStore) EnqueueWebhooks(ctx context.Context, offset int64) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpEnqueueWebhooks))
+	defer timer.ObserveDuration()
+
+	err := s.inner.EnqueueWebhooks(ctx, offset)
+	if err != nil {
+		s.metrics.IncErrors(OpEnqueueWebhooks)
+	}
+	return err
+}
+
 func (s *InstrumentedStore) UpsertToken(ctx context.Context, token *indexer.Token) error {
 	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpUpsertToken))
 	defer timer.ObserveDuration()
@@ -233,11 +300,11 @@ func (s *InstrumentedStore) InsertTransfer(ctx context.Context, t *indexer.Trans
 	return err
 }
 
-func (s *InstrumentedStore) FinalizeTransfer(ctx context.Context, contractID, status string) error {
+func (s *InstrumentedStore) FinalizeTransfer(ctx context.Context, contractID, status string, offset int64) error {
 	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpFinalizeTransfer))
 	defer timer.ObserveDuration()
 
-	err := s.inner.FinalizeTransfer(ctx, contractID, status)
+	err := s.inner.FinalizeTransfer(ctx, contractID, status, offset)
 	if err != nil {
 		s.metrics.IncErrors(OpFinalizeTransfer)
 	}
@@ -255,11 +322,11 @@ func (s *InstrumentedStore) InsertHolding(ctx context.Context, h *indexer.Holdin
 	return err
 }
 
-func (s *InstrumentedStore) TakeHolding(ctx context.Context, contractID string) (indexer.HoldingChange, bool, error) {
+func (s *InstrumentedStore) TakeHolding(ctx context.Context, contractID string, offset int64) (indexer.HoldingChange, bool, error) {
 	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpTakeHolding))
 	defer timer.ObserveDuration()
 
-	h, ok, err := s.inner.TakeHolding(ctx, contractID)
+	h, ok, err := s.inner.TakeHolding(ctx, contractID, offset)
 	if err != nil {
 		s.metrics.IncErrors(OpTakeHolding)
 	}
@@ -387,3 +454,263 @@ func (s *InstrumentedStore) ListPendingTransfers(
 	}
 	return transfers, total, err
 }
+
+func (s *InstrumentedStore) GetBalanceAt(
+	ctx context.Context, partyID, admin, id string, at indexer.AsOf,
+) (*indexer.HistoricalBalance, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpGetBalanceAt))
+	defer timer.ObserveDuration()
+
+	b, err := s.inner.GetBalanceAt(ctx, partyID, admin, id, at)
+	if err != nil {
+		s.metrics.IncErrors(OpGetBalanceAt)
+	}
+	return b, err
+}
+
+func (s *InstrumentedStore) GetSupplyAt(
+	ctx context.Context, admin, id string, at indexer.AsOf,
+) (*indexer.HistoricalSupply, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpGetSupplyAt))
+	defer timer.ObserveDuration()
+
+	supply, err := s.inner.GetSupplyAt(ctx, admin, id, at)
+	if err != nil {
+		s.metrics.IncErrors(OpGetSupplyAt)
+	}
+	return supply, err
+}
+
+func (s *InstrumentedStore) ListHoldersAt(
+	ctx context.Context, admin, id string, at indexer.AsOf, p indexer.Pagination,
+) ([]*indexer.HistoricalBalance, int64, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpListHoldersAt))
+	defer timer.ObserveDuration()
+
+	holders, total, err := s.inner.ListHoldersAt(ctx, admin, id, at, p)
+	if err != nil {
+		s.metrics.IncErrors(OpListHoldersAt)
+	}
+	return holders, total, err
+}
+
+func (s *InstrumentedStore) ListTokenStats(
+	ctx context.Context, admin, id string, interval indexer.StatsInterval, since, until time.Time,
+) ([]*indexer.TokenStatsBucket, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpListTokenStats))
+	defer timer.ObserveDuration()
+
+	buckets, err := s.inner.ListTokenStats(ctx, admin, id, interval, since, until)
+	if err != nil {
+		s.metrics.IncErrors(OpListTokenStats)
+	}
+	return buckets, err
+}
+
+func (s *InstrumentedStore) ListTopHolders(ctx context.Context, admin, id string, limit int) ([]*indexer.Balance, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpListTopHolders))
+	defer timer.ObserveDuration()
+
+	holders, err := s.inner.ListTopHolders(ctx, admin, id, limit)
+	if err != nil {
+		s.metrics.IncErrors(OpListTopHolders)
+	}
+	return holders, err
+}
+
+func (s *InstrumentedStore) GetHolderDistribution(ctx context.Context, admin, id string) ([]indexer.HolderBucket, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpHolderDistribution))
+	defer timer.ObserveDuration()
+
+	buckets, err := s.inner.GetHolderDistribution(ctx, admin, id)
+	if err != nil {
+		s.metrics.IncErrors(OpHolderDistribution)
+	}
+	return buckets, err
+}
+
+// ── webhook.Store (webhook service and dispatcher) ──────────────────────────
+
+func (s *InstrumentedStore) CreateWebhookEndpoint(ctx context.Context, e *indexer.WebhookEndpoint) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpCreateWebhookEndpoint))
+	defer timer.ObserveDuration()
+
+	err := s.inner.CreateWebhookEndpoint(ctx, e)
+	if err != nil {
+		s.metrics.IncErrors(OpCreateWebhookEndpoint)
+	}
+	return err
+}
+
+func (s *InstrumentedStore) GetWebhookEndpoint(ctx context.Context, id int64) (*indexer.WebhookEndpoint, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpGetWebhookEndpoint))
+	defer timer.ObserveDuration()
+
+	e, err := s.inner.GetWebhookEndpoint(ctx, id)
+	if err != nil {
+		s.metrics.IncErrors(OpGetWebhookEndpoint)
+	}
+	return e, err
+}
+
+func (s *InstrumentedStore) ListWebhookEndpoints(ctx context.Context, p indexer.Pagination) ([]*indexer.WebhookEndpoint, int64, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpListWebhookEndpoints))
+	defer timer.ObserveDuration()
+
+	endpoints, total, err := s.inner.ListWebhookEndpoints(ctx, p)
+	if err != nil {
+		s.metrics.IncErrors(OpListWebhookEndpoints)
+	}
+	return endpoints, total, err
+}
+
+func (s *InstrumentedStore) DeleteWebhookEndpoint(ctx context.Context, id int64) (bool, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpDeleteWebhookEndpoint))
+	defer timer.ObserveDuration()
+
+	deleted, err := s.inner.DeleteWebhookEndpoint(ctx, id)
+	if err != nil {
+		s.metrics.IncErrors(OpDeleteWebhookEndpoint)
+	}
+	return deleted, err
+}
+
+func (s *InstrumentedStore) ListWebhookDeliveries(
+	ctx context.Context, endpointID int64, status string, p indexer.Pagination,
+) ([]*indexer.WebhookDelivery, int64, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpListWebhookDeliveries))
+	defer timer.ObserveDuration()
+
+	deliveries, total, err := s.inner.ListWebhookDeliveries(ctx, endpointID, status, p)
+	if err != nil {
+		s.metrics.IncErrors(OpListWebhookDeliveries)
+	}
+	return deliveries, total, err
+}
+
+func (s *InstrumentedStore) DueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*indexer.WebhookDelivery, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpDueWebhookDeliveries))
+	defer timer.ObserveDuration()
+
+	deliveries, err := s.inner.DueWebhookDeliveries(ctx, now, limit)
+	if err != nil {
+		s.metrics.IncErrors(OpDueWebhookDeliveries)
+	}
+	return deliveries, err
+}
+
+func (s *InstrumentedStore) RecordWebhookAttempt(ctx context.Context, id int64, a indexer.WebhookAttempt) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRecordWebhookAttempt))
+	defer timer.ObserveDuration()
+
+	err := s.inner.RecordWebhookAttempt(ctx, id, a)
+	if err != nil {
+		s.metrics.IncErrors(OpRecordWebhookAttempt)
+	}
+	return err
+}
+
+func (s *InstrumentedStore) ReplayWebhookDelivery(ctx context.Context, id int64, now time.Time) (*indexer.WebhookDelivery, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpReplayWebhookDelivery))
+	defer timer.ObserveDuration()
+
+	d, err := s.inner.ReplayWebhookDelivery(ctx, id, now)
+	if err != nil {
+		s.metrics.IncErrors(OpReplayWebhookDelivery)
+	}
+	return d, err
+}
+
+// ── stream.Store (stream service) ────────────────────────────────────────────
+
+func (s *InstrumentedStore) StreamEvents(ctx context.Context, partyID string, after int64, limit int) ([]*indexer.StreamEvent, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpStreamEvents))
+	defer timer.ObserveDuration()
+
+	events, err := s.inner.StreamEvents(ctx, partyID, after, limit)
+	if err != nil {
+		s.metrics.IncErrors(OpStreamEvents)
+	}
+	return events, err
+}
+
+// ── reindex.Store (reindex service and command) ─────────────────────────────
+
+func (s *InstrumentedStore) Rewind(ctx context.Context, offset int64, dryRun bool) (*indexer.RewindReport, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRewind))
+	defer timer.ObserveDuration()
+
+	report, err := s.inner.Rewind(ctx, offset, dryRun)
+	if err != nil {
+		s.metrics.IncErrors(OpRewind)
+	}
+	return report, err
+}
+
+func (s *InstrumentedStore) Rebuild(ctx context.Context, dryRun bool) (*indexer.RebuildReport, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRebuild))
+	defer timer.ObserveDuration()
+
+	report, err := s.inner.Rebuild(ctx, dryRun)
+	if err != nil {
+		s.metrics.IncErrors(OpRebuild)
+	}
+	return report, err
+}
+
+// ── verify.Store (verify service) ───────────────────────────────────────────
+
+func (s *InstrumentedStore) SampleParties(ctx context.Context, limit int) ([]string, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpSampleParties))
+	defer timer.ObserveDuration()
+
+	parties, err := s.inner.SampleParties(ctx, limit)
+	if err != nil {
+		s.metrics.IncErrors(OpSampleParties)
+	}
+	return parties, err
+}
+
+func (s *InstrumentedStore) IndexedState(ctx context.Context, parties []string) (*indexer.IndexedState, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpIndexedState))
+	defer timer.ObserveDuration()
+
+	state, err := s.inner.IndexedState(ctx, parties)
+	if err != nil {
+		s.metrics.IncErrors(OpIndexedState)
+	}
+	return state, err
+}
+
+func (s *InstrumentedStore) RecordDrift(ctx context.Context, drifts []indexer.Drift) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRecordDrift))
+	defer timer.ObserveDuration()
+
+	err := s.inner.RecordDrift(ctx, drifts)
+	if err != nil {
+		s.metrics.IncErrors(OpRecordDrift)
+	}
+	return err
+}
+
+func (s *InstrumentedStore) ListDrift(ctx context.Context, partyID string, p indexer.Pagination) ([]*indexer.Drift, int64, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpListDrift))
+	defer timer.ObserveDuration()
+
+	drifts, total, err := s.inner.ListDrift(ctx, partyID, p)
+	if err != nil {
+		s.metrics.IncErrors(OpListDrift)
+	}
+	return drifts, total, err
+}
+
+func (s *InstrumentedStore) RepairBalances(ctx context.Context, offset int64, changes []indexer.BalanceChange) error {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpRepairBalances))
+	defer timer.ObserveDuration()
+
+	err := s.inner.RepairBalances(ctx, offset, changes)
+	if err != nil {
+		s.metrics.IncErrors(OpRepairBalances)
+	}
+	return err
+}
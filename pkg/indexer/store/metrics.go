@@ -61,6 +61,9 @@ const (
 	OpFinalizeTransfer  StoreOperation = "finalize_transfer"
 	OpInsertHolding     StoreOperation = "insert_holding"
 	OpTakeHolding       StoreOperation = "take_holding"
+	OpRecordHistory     StoreOperation = "record_history"
+	OpEnqueueWebhooks   StoreOperation = "enqueue_webhooks"
+	OpRecordStats       StoreOperation = "record_stats"
 
 	// Read-path operations (HTTP API / service.Store).
 	OpGetToken             StoreOperation = "get_token"
@@ -73,6 +76,36 @@ const (
 	OpGetTransfer          StoreOperation = "get_transfer"
 	OpListTransfers        StoreOperation = "list_transfers"
 	OpListPendingTransfers StoreOperation = "list_pending_transfers"
+	OpGetBalanceAt         StoreOperation = "get_balance_at"
+	OpGetSupplyAt          StoreOperation = "get_supply_at"
+	OpListHoldersAt        StoreOperation = "list_holders_at"
+	OpListTokenStats       StoreOperation = "list_token_stats"
+	OpListTopHolders       StoreOperation = "list_top_holders"
+	OpHolderDistribution   StoreOperation = "holder_distribution"
+
+	// Webhook operations (webhook service and dispatcher).
+	OpCreateWebhookEndpoint StoreOperation = "create_webhook_endpoint"
+	OpGetWebhookEndpoint    StoreOperation = "get_webhook_endpoint"
+	OpListWebhookEndpoints  StoreOperation = "list_webhook_endpoints"
+	OpDeleteWebhookEndpoint StoreOperation = "delete_webhook_endpoint"
+	OpListWebhookDeliveries StoreOperation = "list_webhook_deliveries"
+	OpDueWebhookDeliveries  StoreOperation = "due_webhook_deliveries"
+	OpRecordWebhookAttempt  StoreOperation = "record_webhook_attempt"
+	OpReplayWebhookDelivery StoreOperation = "replay_webhook_delivery"
+
+	// Streaming operations (stream service).
+	OpStreamEvents StoreOperation = "stream_events"
+
+	// Re-index operations (reindex service and command).
+	OpRewind  StoreOperation = "rewind"
+	OpRebuild StoreOperation = "rebuild"
+
+	// Drift operations (verify service).
+	OpSampleParties  StoreOperation = "sample_parties"
+	OpIndexedState   StoreOperation = "indexed_state"
+	OpRecordDrift    StoreOperation = "record_drift"
+	OpListDrift      StoreOperation = "list_drift"
+	OpRepairBalances StoreOperation = "repair_balances"
 )
 
 // ── Helper methods ───────────────────────────────────────────────────────────
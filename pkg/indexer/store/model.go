@@ -3,6 +3,7 @@
 package store
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -54,6 +55,113 @@ type BalanceDao struct {
 	Amount          string `bun:",notnull,type:text"`
 }
 
+// BalanceHistoryDao maps to the 'indexer_balance_history' table.
+// One row per balance per ledger offset at which it changed, holding the amount
+// after that transaction. The balance at any offset is the latest row at or
+// before it; rows are never updated once the offset is committed.
+type BalanceHistoryDao struct {
+	bun.BaseModel   `bun:"table:indexer_balance_history"`
+	PartyID         string    `bun:",pk,type:varchar(255)"`
+	InstrumentAdmin string    `bun:",pk,type:varchar(255)"`
+	InstrumentID    string    `bun:",pk,type:varchar(255)"`
+	LedgerOffset    int64     `bun:",pk"`
+	Amount          string    `bun:",notnull,type:text"`
+	EffectiveTime   time.Time `bun:",notnull"`
+}
+
+// SupplyHistoryDao maps to the 'indexer_supply_history' table.
+// One row per token per ledger offset at which its total supply or holder count
+// changed, holding both values after that transaction.
+type SupplyHistoryDao struct {
+	bun.BaseModel   `bun:"table:indexer_supply_history"`
+	InstrumentAdmin string    `bun:",pk,type:varchar(255)"`
+	InstrumentID    string    `bun:",pk,type:varchar(255)"`
+	LedgerOffset    int64     `bun:",pk"`
+	TotalSupply     string    `bun:",notnull,type:text"`
+	HolderCount     int64     `bun:",notnull"`
+	EffectiveTime   time.Time `bun:",notnull"`
+}
+
+// TokenStatsDao maps to the 'indexer_token_stats' table.
+// One row per token per rollup interval ("hour", "day") per bucket, holding the
+// activity of every transaction whose effective time falls in the bucket.
+// Volumes are decimal strings, accumulated as the processor commits.
+type TokenStatsDao struct {
+	bun.BaseModel   `bun:"table:indexer_token_stats"`
+	InstrumentAdmin string    `bun:",pk,type:varchar(255)"`
+	InstrumentID    string    `bun:",pk,type:varchar(255)"`
+	Interval        string    `bun:"bucket_interval,pk,type:varchar(10)"`
+	BucketStart     time.Time `bun:",pk"`
+	TransferCount   int64     `bun:",notnull,default:0"`
+	TransferVolume  string    `bun:",notnull,type:text,default:'0'"`
+	MintCount       int64     `bun:",notnull,default:0"`
+	MintVolume      string    `bun:",notnull,type:text,default:'0'"`
+	BurnCount       int64     `bun:",notnull,default:0"`
+	BurnVolume      string    `bun:",notnull,type:text,default:'0'"`
+	ActiveParties   int64     `bun:",notnull,default:0"`
+	NewHolders      int64     `bun:",notnull,default:0"`
+}
+
+// TokenActivePartyDao maps to the 'indexer_token_active_parties' table.
+// One row per party active on a token within a stats bucket, so a party is
+// counted once in TokenStatsDao.ActiveParties however often it transacts.
+type TokenActivePartyDao struct {
+	bun.BaseModel   `bun:"table:indexer_token_active_parties"`
+	InstrumentAdmin string    `bun:",pk,type:varchar(255)"`
+	InstrumentID    string    `bun:",pk,type:varchar(255)"`
+	Interval        string    `bun:"bucket_interval,pk,type:varchar(10)"`
+	BucketStart     time.Time `bun:",pk"`
+	PartyID         string    `bun:",pk,type:varchar(255)"`
+}
+
+// WebhookEndpointDao maps to the 'indexer_webhook_endpoints' table — one row per
+// registered webhook receiver. Filter is stored as JSON.
+type WebhookEndpointDao struct {
+	bun.BaseModel `bun:"table:indexer_webhook_endpoints"`
+	ID            int64                 `bun:",pk,autoincrement"`
+	URL           string                `bun:",notnull,type:text"`
+	Secret        string                `bun:",notnull,type:varchar(255)"`
+	Description   string                `bun:",notnull,type:text,default:''"`
+	Filter        indexer.WebhookFilter `bun:",notnull,type:jsonb"`
+	CreatedAt     time.Time             `bun:",notnull"`
+}
+
+// WebhookDeliveryDao maps to the 'indexer_webhook_deliveries' table — the durable
+// outbox. Rows are written in the same transaction as the ledger data they
+// describe and are kept after delivery so they can be listed and replayed.
+type WebhookDeliveryDao struct {
+	bun.BaseModel  `bun:"table:indexer_webhook_deliveries"`
+	ID             int64      `bun:",pk,autoincrement"`
+	EndpointID     int64      `bun:",notnull"`
+	EventType      string     `bun:",notnull,type:varchar(32)"`
+	LedgerOffset   int64      `bun:",notnull"`
+	Payload        string     `bun:",notnull,type:text"` // exact body sent on every attempt
+	Status         string     `bun:",notnull,type:varchar(20)"`
+	Attempts       int        `bun:",notnull,default:0"`
+	NextAttemptAt  time.Time  `bun:",notnull"`
+	LastStatusCode int        `bun:",notnull,default:0"`
+	LastError      string     `bun:",notnull,type:text,default:''"`
+	CreatedAt      time.Time  `bun:",notnull"`
+	DeliveredAt    *time.Time `bun:",nullzero"`
+}
+
+// DriftDao maps to the 'indexer_drift' table — one row per difference between
+// the indexed state and the ledger found by a drift check. PartyID is empty for
+// supply drifts.
+type DriftDao struct {
+	bun.BaseModel   `bun:"table:indexer_drift"`
+	ID              int64     `bun:",pk,autoincrement"`
+	Kind            string    `bun:",notnull,type:varchar(20)"`
+	PartyID         string    `bun:",notnull,type:varchar(255),default:''"`
+	InstrumentAdmin string    `bun:",notnull,type:varchar(255)"`
+	InstrumentID    string    `bun:",notnull,type:varchar(255)"`
+	Indexed         string    `bun:",notnull,type:text"`
+	Ledger          string    `bun:",notnull,type:text"`
+	LedgerOffset    int64     `bun:",notnull"`
+	Repaired        bool      `bun:",notnull,default:false"`
+	DetectedAt      time.Time `bun:",notnull"`
+}
+
 // OffsetDao maps to the 'indexer_offsets' table.
 // A single row (ID=1) holds the latest persisted ledger offset.
 type OffsetDao struct {
@@ -63,9 +171,10 @@ type OffsetDao struct {
 }
 
 // HoldingDao maps to the 'indexer_holdings' table.
-// One row per active Utility.Registry.Holding.V0.Holding contract. Inserted on
-// CREATED events and deleted on ARCHIVED events; the stored amount is needed at
-// archive time to decrement balances since archive events carry only contract_id.
+// One row per unlocked Utility.Registry.Holding.V0.Holding contract. Inserted on
+// CREATED events and stamped with ArchivedOffset on ARCHIVED events; the stored
+// amount is needed at archive time to decrement balances since archive events
+// carry only contract_id. Archived rows are kept so a rewind can restore them.
 type HoldingDao struct {
 	bun.BaseModel   `bun:"table:indexer_holdings"`
 	ContractID      string `bun:",pk,type:varchar(255)"`
@@ -74,14 +183,16 @@ type HoldingDao struct {
 	InstrumentID    string `bun:",notnull,type:varchar(255)"`
 	Amount          string `bun:",notnull,type:text"`
 	LedgerOffset    int64  `bun:",notnull"`
+	// ArchivedOffset is the offset of the archiving transaction; NULL while active.
+	ArchivedOffset *int64 `bun:","`
 }
 
 // TransferDao maps to the 'indexer_transfers' table — the generalized transfer
 // log holding both direct (atomic CIP-56) and offer (2-step) transfers with a
 // mutable status lifecycle. Rows are never deleted; the table is a full history.
 //   - direct: written on a CIP-56 TRANSFER event, always Status "completed".
-//   - offer:  written "pending" on a TransferOffer CREATE, set "completed" on its
-//     ARCHIVE. ExpiresAt carries the offer's executeBefore; "expired" is derived
+//   - offer:  written "pending" on a TransferOffer CREATE, set to its terminal
+//     status on its ARCHIVE. ExpiresAt carries the offer's executeBefore; "expired" is derived
 //     at read time (pending + ExpiresAt in the past) and never stored.
 type TransferDao struct {
 	bun.BaseModel   `bun:"table:indexer_transfers"`
@@ -97,6 +208,9 @@ type TransferDao struct {
 	TxID            string     `bun:",type:varchar(255)"`
 	LedgerOffset    int64      `bun:",notnull"`
 	CreatedAt       time.Time  `bun:",notnull"`
+	// FinalizedOffset is the offset at which an offer left "pending"; NULL while
+	// pending and for direct transfers, which are completed at LedgerOffset.
+	FinalizedOffset *int64 `bun:","`
 }
 
 func toEventDao(e *indexer.ParsedEvent) *EventDao {
@@ -193,3 +307,111 @@ func fromTransferDao(d *TransferDao) indexer.Transfer {
 		CreatedAt:       d.CreatedAt,
 	}
 }
+
+func fromBalanceHistoryDao(d *BalanceHistoryDao) *indexer.HistoricalBalance {
+	t := d.EffectiveTime
+	return &indexer.HistoricalBalance{
+		Balance: indexer.Balance{
+			PartyID:         d.PartyID,
+			InstrumentAdmin: d.InstrumentAdmin,
+			InstrumentID:    d.InstrumentID,
+			Amount:          d.Amount,
+		},
+		LedgerOffset:  d.LedgerOffset,
+		EffectiveTime: &t,
+	}
+}
+
+func fromSupplyHistoryDao(d *SupplyHistoryDao) *indexer.HistoricalSupply {
+	t := d.EffectiveTime
+	return &indexer.HistoricalSupply{
+		InstrumentAdmin: d.InstrumentAdmin,
+		InstrumentID:    d.InstrumentID,
+		TotalSupply:     d.TotalSupply,
+		HolderCount:     d.HolderCount,
+		LedgerOffset:    d.LedgerOffset,
+		EffectiveTime:   &t,
+	}
+}
+
+func fromTokenStatsDao(d *TokenStatsDao) *indexer.TokenStatsBucket {
+	return &indexer.TokenStatsBucket{
+		Start:          d.BucketStart.UTC(),
+		TransferCount:  d.TransferCount,
+		TransferVolume: d.TransferVolume,
+		MintCount:      d.MintCount,
+		MintVolume:     d.MintVolume,
+		BurnCount:      d.BurnCount,
+		BurnVolume:     d.BurnVolume,
+		ActiveParties:  d.ActiveParties,
+		NewHolders:     d.NewHolders,
+	}
+}
+
+func toWebhookEndpointDao(e *indexer.WebhookEndpoint) *WebhookEndpointDao {
+	return &WebhookEndpointDao{
+		ID:          e.ID,
+		URL:         e.URL,
+		Secret:      e.Secret,
+		Description: e.Description,
+		Filter:      e.Filter,
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+func fromWebhookEndpointDao(d *WebhookEndpointDao) *indexer.WebhookEndpoint {
+	return &indexer.WebhookEndpoint{
+		ID:          d.ID,
+		URL:         d.URL,
+		Secret:      d.Secret,
+		Description: d.Description,
+		Filter:      d.Filter,
+		CreatedAt:   d.CreatedAt,
+	}
+}
+
+func fromWebhookDeliveryDao(d *WebhookDeliveryDao) *indexer.WebhookDelivery {
+	return &indexer.WebhookDelivery{
+		ID:             d.ID,
+		EndpointID:     d.EndpointID,
+		EventType:      indexer.WebhookEventType(d.EventType),
+		LedgerOffset:   d.LedgerOffset,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func toDriftDao(d *indexer.Drift) *DriftDao {
+	return &DriftDao{
+		Kind:            string(d.Kind),
+		PartyID:         d.PartyID,
+		InstrumentAdmin: d.InstrumentAdmin,
+		InstrumentID:    d.InstrumentID,
+		Indexed:         d.Indexed,
+		Ledger:          d.Ledger,
+		LedgerOffset:    d.LedgerOffset,
+		Repaired:        d.Repaired,
+		DetectedAt:      d.DetectedAt,
+	}
+}
+
+func fromDriftDao(d *DriftDao) *indexer.Drift {
+	return &indexer.Drift{
+		ID:              d.ID,
+		Kind:            indexer.DriftKind(d.Kind),
+		PartyID:         d.PartyID,
+		InstrumentAdmin: d.InstrumentAdmin,
+		InstrumentID:    d.InstrumentID,
+		Indexed:         d.Indexed,
+		Ledger:          d.Ledger,
+		LedgerOffset:    d.LedgerOffset,
+		Repaired:        d.Repaired,
+		DetectedAt:      d.DetectedAt,
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// keyset is the sort order of a list: columns that together are unique, most
+// significant first. It serves both numbered pages (OFFSET) and cursor pages,
+// which start at a row-value comparison against the cursor instead.
+type keyset struct {
+	cols []string
+	desc bool // newest first
+}
+
+var (
+	// eventKeyset and transferKeyset order ledger rows as the ledger did;
+	// contract_id breaks ties within one offset.
+	eventKeyset           = keyset{cols: []string{"ledger_offset", "contract_id"}}
+	transferKeyset        = keyset{cols: []string{"ledger_offset", "contract_id"}, desc: true}
+	pendingTransferKeyset = keyset{cols: []string{"ledger_offset", "contract_id"}}
+	partyBalanceKeyset    = keyset{cols: []string{"instrument_admin", "instrument_id"}}
+	tokenBalanceKeyset    = keyset{cols: []string{"party_id"}}
+)
+
+// Cursor sort keys, in the column order of the matching keyset.
+
+func ledgerCursorValues(c *indexer.Cursor) []any {
+	return []any{c.LedgerOffset, c.ContractID}
+}
+
+func partyBalanceCursorValues(c *indexer.Cursor) []any {
+	return []any{c.InstrumentAdmin, c.InstrumentID}
+}
+
+func tokenBalanceCursorValues(c *indexer.Cursor) []any {
+	return []any{c.PartyID}
+}
+
+// page counts q's matches when p asks for it, then scans one page of q into its
+// model, ordered by k. values returns the cursor's sort key, matching k.cols.
+// Going backward, rows are read in reverse order and returned in the list order
+// by reversing the model, *dest. Without a count the total is TotalUnknown.
+func page[T any](
+	ctx context.Context, q *bun.SelectQuery, dest *[]T, k keyset, p indexer.Pagination,
+	values func(*indexer.Cursor) []any,
+) (int64, error) {
+	total := int64(indexer.TotalUnknown)
+	if p.CountTotal() {
+		n, err := q.Count(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("count: %w", err)
+		}
+		total = int64(n)
+	}
+
+	backward := p.Cursor != nil && p.Cursor.Backward
+	desc := k.desc != backward
+	dir, op := "ASC", ">"
+	if desc {
+		dir, op = "DESC", "<"
+	}
+	if p.Cursor != nil {
+		vals := values(p.Cursor)
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(vals)), ", ")
+		q = q.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(k.cols, ", "), op, placeholders), vals...)
+	} else {
+		q = q.Offset((p.Page - 1) * p.Limit)
+	}
+	for _, col := range k.cols {
+		q = q.OrderExpr(col + " " + dir)
+	}
+	if err := q.Limit(p.Limit).Scan(ctx); err != nil {
+		return 0, err
+	}
+	if backward {
+		slices.Reverse(*dest)
+	}
+	return total, nil
+}
@@ -20,6 +20,18 @@ import (
 // It satisfies both engine.Store (write path: processor) and service.Store (read path: HTTP).
 type PGStore struct {
 	db bun.IDB
+
+	// history collects the balances and tokens changed in the current
+	// transaction until RecordHistory writes them. Nil outside RunInTx.
+	history *historyTracker
+
+	// outbox collects the webhook notifications raised in the current
+	// transaction until EnqueueWebhooks writes them. Nil outside RunInTx.
+	outbox *outboxTracker
+
+	// stats collects the token activity of the current transaction until
+	// RecordStats adds it to the rollups. Nil outside RunInTx.
+	stats *statsTracker
 }
 
 // NewStore creates a new Bun-backed indexer store.
@@ -48,7 +60,7 @@ func (s *PGStore) RunInTx(ctx context.Context, fn func(ctx context.Context, tx e
 		return errors.New("RunInTx called on a transaction-scoped store")
 	}
 	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		return fn(ctx, &PGStore{db: tx})
+		return fn(ctx, &PGStore{db: tx, history: newHistoryTracker(), outbox: new(outboxTracker), stats: newStatsTracker()})
 	})
 }
 
@@ -86,6 +98,15 @@ func (s *PGStore) InsertEvent(ctx context.Context, event *indexer.ParsedEvent) (
 	if err != nil {
 		return false, fmt.Errorf("insert event rows affected: %w", err)
 	}
+	if n > 0 {
+		if err := s.stats.addMintOrBurn(event); err != nil {
+			return false, fmt.Errorf("insert event stats: %w", err)
+		}
+		s.outbox.add(&indexer.WebhookNotification{
+			Type:  indexer.WebhookEventType(event.EventType),
+			Event: event,
+		})
+	}
 	return n > 0, nil
 }
 
@@ -134,6 +155,7 @@ func (s *PGStore) ApplySupplyDelta(ctx context.Context, instrumentAdmin, instrum
 	if err != nil {
 		return fmt.Errorf("apply supply delta: %w", err)
 	}
+	s.history.touchToken(instrumentAdmin, instrumentID)
 	return nil
 }
 
@@ -190,6 +212,10 @@ func (s *PGStore) ApplyBalanceDelta(ctx context.Context, partyID, instrumentAdmi
 	if err != nil {
 		return fmt.Errorf("upsert balance: %w", err)
 	}
+	s.history.touchBalance(partyID, instrumentAdmin, instrumentID, newAmount.String())
+	if isNew && newAmount.IsPositive() {
+		s.stats.addNewHolder(instrumentAdmin, instrumentID)
+	}
 
 	// Step 3: update holder_count if the balance crossed zero.
 	wasZero := isNew || oldAmount.IsZero()
@@ -211,6 +237,7 @@ func (s *PGStore) ApplyBalanceDelta(ctx context.Context, partyID, instrumentAdmi
 		if err != nil {
 			return fmt.Errorf("update holder count: %w", err)
 		}
+		s.history.touchToken(instrumentAdmin, instrumentID)
 	}
 	return nil
 }
@@ -238,26 +265,25 @@ func (s *PGStore) InsertHolding(ctx context.Context, h *indexer.HoldingChange) e
 	return nil
 }
 
-// TakeHolding deletes the holding row matching contractID and returns its
-// owner/instrument/amount so the caller can apply the symmetric balance delta.
-// Returns ok=false when the row does not exist (replayed ARCHIVED event).
-func (s *PGStore) TakeHolding(ctx context.Context, contractID string) (h indexer.HoldingChange, ok bool, err error) {
+// TakeHolding archives the active holding row matching contractID at offset
+// and returns its owner/instrument/amount so the caller can apply the
+// symmetric balance delta. The row is kept, stamped with offset, so a rewind
+// can restore it. Returns ok=false when no active row exists (replayed
+// ARCHIVED event).
+func (s *PGStore) TakeHolding(ctx context.Context, contractID string, offset int64) (h indexer.HoldingChange, ok bool, err error) {
 	var dao HoldingDao
-	err = s.db.NewSelect().
+	err = s.db.NewUpdate().
 		Model(&dao).
+		Set("archived_offset = ?", offset).
 		Where("contract_id = ?", contractID).
+		Where("archived_offset IS NULL").
+		Returning("*").
 		Scan(ctx)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return indexer.HoldingChange{}, false, nil
 		}
-		return indexer.HoldingChange{}, false, fmt.Errorf("select holding: %w", err)
-	}
-	if _, err := s.db.NewDelete().
-		Model((*HoldingDao)(nil)).
-		Where("contract_id = ?", contractID).
-		Exec(ctx); err != nil {
-		return indexer.HoldingChange{}, false, fmt.Errorf("delete holding: %w", err)
+		return indexer.HoldingChange{}, false, fmt.Errorf("archive holding: %w", err)
 	}
 	return indexer.HoldingChange{
 		ContractID:      dao.ContractID,
@@ -279,30 +305,52 @@ func (s *PGStore) TakeHolding(ctx context.Context, contractID string) (h indexer
 // last saved offset on restart/reconnect, so re-seeing the same contract is a
 // no-op rather than an error or a duplicate.
 func (s *PGStore) InsertTransfer(ctx context.Context, t *indexer.Transfer) error {
-	_, err := s.db.NewInsert().
+	result, err := s.db.NewInsert().
 		Model(toTransferDao(t)).
 		On("CONFLICT (contract_id) DO NOTHING").
 		Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("insert transfer: %w", err)
 	}
+	if n, err := result.RowsAffected(); err == nil && n > 0 {
+		if err := s.stats.addTransfer(t); err != nil {
+			return fmt.Errorf("insert transfer stats: %w", err)
+		}
+		s.outbox.add(&indexer.WebhookNotification{
+			Type:     indexer.WebhookEventTransferStatus,
+			Transfer: t,
+		})
+	}
 	return nil
 }
 
 // FinalizeTransfer sets an archived offer's terminal status ("completed" on
-// accept, "canceled" on withdraw/reject). Only still-pending rows are updated —
-// an already-finalized row keeps its status, making stream replays no-ops.
-// No-op when not found.
-func (s *PGStore) FinalizeTransfer(ctx context.Context, contractID, status string) error {
+// accept, "canceled" on withdraw/reject) and the offset it was set at. Only
+// still-pending rows are updated — an already-finalized row keeps its status,
+// making stream replays no-ops. No-op when not found.
+func (s *PGStore) FinalizeTransfer(ctx context.Context, contractID, status string, offset int64) error {
+	var updated []TransferDao
 	_, err := s.db.NewUpdate().
 		Model((*TransferDao)(nil)).
 		Set("status = ?", status).
+		Set("finalized_offset = ?", offset).
 		Where("contract_id = ?", contractID).
 		Where("status = ?", indexer.TransferStatusPending).
-		Exec(ctx)
+		Returning("*").
+		Exec(ctx, &updated)
 	if err != nil {
 		return fmt.Errorf("finalize transfer: %w", err)
 	}
+	for i := range updated {
+		t := fromTransferDao(&updated[i])
+		if err := s.stats.addTransfer(&t); err != nil {
+			return fmt.Errorf("finalize transfer stats: %w", err)
+		}
+		s.outbox.add(&indexer.WebhookNotification{
+			Type:     indexer.WebhookEventTransferStatus,
+			Transfer: &t,
+		})
+	}
 	return nil
 }
 
@@ -328,28 +376,20 @@ func (s *PGStore) GetTransfer(ctx context.Context, contractID string) (*indexer.
 	return &t, nil
 }
 
-// ListTransfers returns a party's transfers from indexer_transfers, newest first,
-// with native pagination. Role selects the matching side; status filters the
-// lifecycle. "expired" is derived (pending row whose expires_at is in the past) —
-// after scanning, rows still stored "pending" but past their expiry are surfaced
-// with Status "expired".
+// ListTransfers returns a party's transfers from indexer_transfers, newest first
+// by ledger offset, with numbered or cursor pagination. Role selects the
+// matching side; status filters the lifecycle and the HistoryFilter the rest. "expired" is derived (pending
+// row whose expires_at is in the past) — after scanning, rows still stored
+// "pending" but past their expiry are surfaced with Status "expired".
 func (s *PGStore) ListTransfers(
 	ctx context.Context, partyID string, query indexer.TransferQuery, p indexer.Pagination,
 ) ([]indexer.Transfer, int64, error) {
 	now := time.Now().UTC()
 	var daos []TransferDao
-	var total int
+	var total int64
 	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
-		q := db.NewSelect().Model(&daos).OrderExpr("created_at DESC")
-
-		switch query.Role {
-		case indexer.TransferRoleSender:
-			q = q.Where("from_party_id = ?", partyID)
-		case indexer.TransferRoleAny:
-			q = q.Where("(from_party_id = ? OR to_party_id = ?)", partyID, partyID)
-		default: // receiver
-			q = q.Where("to_party_id = ?", partyID)
-		}
+		q := whereTransferParties(db.NewSelect().Model(&daos), partyID, query)
+		q = whereHistory(q, query.HistoryFilter, "created_at")
 
 		switch query.Status {
 		case indexer.TransferStatusPending:
@@ -368,10 +408,8 @@ func (s *PGStore) ListTransfers(
 		}
 
 		var err error
-		if total, err = q.Count(ctx); err != nil {
-			return fmt.Errorf("count: %w", err)
-		}
-		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+		total, err = page(ctx, q, &daos, transferKeyset, p, ledgerCursorValues)
+		return err
 	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("list transfers: %w", err)
@@ -385,31 +423,28 @@ func (s *PGStore) ListTransfers(
 			out[i].Status = indexer.TransferStatusExpired
 		}
 	}
-	return out, int64(total), nil
+	return out, total, nil
 }
 
 // ListPendingTransfers returns all pending (not expired) offer-based transfers
-// across all parties, oldest first (created_at ASC), with pagination. Drives the
+// across all parties, oldest first by ledger offset, with pagination. Drives the
 // custodial accept worker.
 func (s *PGStore) ListPendingTransfers(
 	ctx context.Context, p indexer.Pagination,
 ) ([]indexer.Transfer, int64, error) {
 	now := time.Now().UTC()
 	var daos []TransferDao
-	var total int
+	var total int64
 	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
 		q := db.NewSelect().Model(&daos).
 			Where("kind = ?", indexer.TransferKindOffer).
 			Where("status = ?", indexer.TransferStatusPending).
-			Where("(expires_at IS NULL OR expires_at > ?)", now).
-			// Oldest first (FIFO): the accept worker drains the longest-pending
-			// offers — those most at risk of expiring — before newer ones.
-			OrderExpr("created_at ASC")
+			Where("(expires_at IS NULL OR expires_at > ?)", now)
 		var err error
-		if total, err = q.Count(ctx); err != nil {
-			return fmt.Errorf("count: %w", err)
-		}
-		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+		// Oldest first (FIFO): the accept worker drains the longest-pending
+		// offers — those most at risk of expiring — before newer ones.
+		total, err = page(ctx, q, &daos, pendingTransferKeyset, p, ledgerCursorValues)
+		return err
 	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("list pending transfers: %w", err)
@@ -418,7 +453,7 @@ func (s *PGStore) ListPendingTransfers(
 	for i := range daos {
 		out[i] = fromTransferDao(&daos[i])
 	}
-	return out, int64(total), nil
+	return out, total, nil
 }
 
 // ─── service.Store read-path methods ─────────────────────────────────────────
@@ -485,16 +520,12 @@ func (s *PGStore) GetBalance(ctx context.Context, partyID, admin, id string) (*i
 // and the page are derived from the same consistent snapshot (see runReadTx).
 func (s *PGStore) ListBalancesForParty(ctx context.Context, partyID string, p indexer.Pagination) ([]*indexer.Balance, int64, error) {
 	var daos []BalanceDao
-	var total int
+	var total int64
 	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
-		q := db.NewSelect().Model(&daos).
-			Where("party_id = ?", partyID).
-			OrderExpr("instrument_admin ASC, instrument_id ASC")
+		q := db.NewSelect().Model(&daos).Where("party_id = ?", partyID)
 		var err error
-		if total, err = q.Count(ctx); err != nil {
-			return fmt.Errorf("count: %w", err)
-		}
-		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+		total, err = page(ctx, q, &daos, partyBalanceKeyset, p, partyBalanceCursorValues)
+		return err
 	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("list balances for party: %w", err)
@@ -503,7 +534,7 @@ func (s *PGStore) ListBalancesForParty(ctx context.Context, partyID string, p in
 	for i := range daos {
 		balances[i] = fromBalanceDao(&daos[i])
 	}
-	return balances, int64(total), nil
+	return balances, total, nil
 }
 
 // ListBalancesForToken returns a paginated list of all holders of a given token.
@@ -511,17 +542,14 @@ func (s *PGStore) ListBalancesForParty(ctx context.Context, partyID string, p in
 // and the page are derived from the same consistent snapshot (see runReadTx).
 func (s *PGStore) ListBalancesForToken(ctx context.Context, admin, id string, p indexer.Pagination) ([]*indexer.Balance, int64, error) {
 	var daos []BalanceDao
-	var total int
+	var total int64
 	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
 		q := db.NewSelect().Model(&daos).
 			Where("instrument_admin = ?", admin).
-			Where("instrument_id = ?", id).
-			OrderExpr("party_id ASC")
+			Where("instrument_id = ?", id)
 		var err error
-		if total, err = q.Count(ctx); err != nil {
-			return fmt.Errorf("count: %w", err)
-		}
-		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+		total, err = page(ctx, q, &daos, tokenBalanceKeyset, p, tokenBalanceCursorValues)
+		return err
 	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("list balances for token: %w", err)
@@ -530,7 +558,7 @@ func (s *PGStore) ListBalancesForToken(ctx context.Context, admin, id string, p
 	for i := range daos {
 		balances[i] = fromBalanceDao(&daos[i])
 	}
-	return balances, int64(total), nil
+	return balances, total, nil
 }
 
 // GetEvent retrieves a single event by contract ID. Returns nil, nil when not found.
@@ -548,32 +576,19 @@ func (s *PGStore) GetEvent(ctx context.Context, contractID string) (*indexer.Par
 	return fromEventDao(dao), nil
 }
 
-// ListEvents returns a paginated, ledger_offset-ascending list of events.
+// ListEvents returns a paginated list of events, ascending by ledger offset and,
+// within an offset, by contract ID.
 // Zero-value EventFilter fields are ignored.
 // The Count and Scan are executed within a single read-only transaction so the total
 // and the page are derived from the same consistent snapshot (see runReadTx).
 func (s *PGStore) ListEvents(ctx context.Context, f indexer.EventFilter, p indexer.Pagination) ([]*indexer.ParsedEvent, int64, error) {
 	var daos []EventDao
-	var total int
+	var total int64
 	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
-		q := db.NewSelect().Model(&daos).OrderExpr("ledger_offset ASC")
-		if f.InstrumentAdmin != "" {
-			q = q.Where("instrument_admin = ?", f.InstrumentAdmin)
-		}
-		if f.InstrumentID != "" {
-			q = q.Where("instrument_id = ?", f.InstrumentID)
-		}
-		if f.PartyID != "" {
-			q = q.Where("(from_party_id = ? OR to_party_id = ?)", f.PartyID, f.PartyID)
-		}
-		if f.EventType != "" {
-			q = q.Where("event_type = ?", string(f.EventType))
-		}
+		q := whereEvents(db.NewSelect().Model(&daos), f)
 		var err error
-		if total, err = q.Count(ctx); err != nil {
-			return fmt.Errorf("count: %w", err)
-		}
-		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+		total, err = page(ctx, q, &daos, eventKeyset, p, ledgerCursorValues)
+		return err
 	})
 	if err != nil {
 		return nil, 0, fmt.Errorf("list events: %w", err)
@@ -582,5 +597,5 @@ func (s *PGStore) ListEvents(ctx context.Context, f indexer.EventFilter, p index
 	for i := range daos {
 		events[i] = fromEventDao(&daos[i])
 	}
-	return events, int64(total), nil
+	return events, total, nil
 }
@@ -4,9 +4,11 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -24,19 +26,21 @@ func setupIndexerStore(t *testing.T) (context.Context, *PGStore) {
 	db, cleanup := pgutil.SetupTestDB(t)
 	t.Cleanup(cleanup)
 
-	if err := mghelper.CreateSchema(ctx, db, &EventDao{}, &TokenDao{}, &BalanceDao{}, &OffsetDao{}, &TransferDao{}); err != nil {
+	if err := mghelper.CreateSchema(ctx, db, &EventDao{}, &TokenDao{}, &BalanceDao{}, &OffsetDao{}, &TransferDao{},
+		&BalanceHistoryDao{}, &SupplyHistoryDao{}, &HoldingDao{}, &WebhookEndpointDao{}, &WebhookDeliveryDao{},
+		&TokenStatsDao{}, &TokenActivePartyDao{}, &DriftDao{}); err != nil {
 		t.Fatalf("failed to create schema: %v", err)
 	}
-	// Mirror migration 7: a status index plus composite (party, created_at)
-	// indexes that back the list-by-party-newest-first queries.
+	// Mirror migrations 7 and 11: a status index plus composite (party,
+	// ledger_offset, contract_id) indexes that back the list-by-party queries.
 	if err := mghelper.CreateModelIndexes(ctx, db, &TransferDao{}, "status"); err != nil {
 		t.Fatalf("failed to create transfer status index: %v", err)
 	}
 	for _, col := range []string{"from_party_id", "to_party_id"} {
 		if _, err := db.NewCreateIndex().
 			Model(&TransferDao{}).
-			Index("idx_indexer_transfers_"+col+"_created_at").
-			Column(col, "created_at").
+			Index("idx_indexer_transfers_"+col+"_offset").
+			Column(col, "ledger_offset", "contract_id").
 			IfNotExists().
 			Exec(ctx); err != nil {
 			t.Fatalf("failed to create transfer composite index: %v", err)
@@ -45,7 +49,8 @@ func setupIndexerStore(t *testing.T) (context.Context, *PGStore) {
 	// Mirror the indexes created by the migration files so the test schema matches
 	// production. This catches index-related issues (e.g. constraint violations) and
 	// ensures query plans exercised in tests reflect real query plans.
-	if err := mghelper.CreateModelIndexes(ctx, db, &EventDao{}, "ledger_offset", "from_party_id", "to_party_id"); err != nil {
+	if err := mghelper.CreateModelIndexes(ctx, db, &EventDao{},
+		"ledger_offset", "from_party_id", "to_party_id", "external_tx_id", "effective_time"); err != nil {
 		t.Fatalf("failed to create event indexes: %v", err)
 	}
 	if err := mghelper.CreateModelIndexes(ctx, db, &BalanceDao{}, "party_id"); err != nil {
@@ -224,6 +229,72 @@ func TestPGStore_InsertEvent(t *testing.T) {
 	}
 }
 
+// ── Webhooks ──────────────────────────────────────────────────────────────────
+
+func TestPGStore_EnqueueWebhooks(t *testing.T) {
+	ctx, s := setupIndexerStore(t)
+
+	all := &indexer.WebhookEndpoint{URL: "https://a.example", Secret: "s", CreatedAt: time.Now()}
+	carol := &indexer.WebhookEndpoint{
+		URL: "https://b.example", Secret: "s", CreatedAt: time.Now(),
+		Filter: indexer.WebhookFilter{Parties: []string{"carol"}},
+	}
+	for _, e := range []*indexer.WebhookEndpoint{all, carol} {
+		if err := s.CreateWebhookEndpoint(ctx, e); err != nil {
+			t.Fatalf("CreateWebhookEndpoint failed: %v", err)
+		}
+	}
+
+	ev := makeEvent("contract-1", 10, indexer.EventTransfer, ptr("alice"), ptr("bob"))
+	err := s.RunInTx(ctx, func(ctx context.Context, tx engine.Store) error {
+		if _, err := tx.InsertEvent(ctx, ev); err != nil {
+			return err
+		}
+		// A duplicate raises no second notification.
+		if _, err := tx.InsertEvent(ctx, ev); err != nil {
+			return err
+		}
+		return tx.EnqueueWebhooks(ctx, 10)
+	})
+	if err != nil {
+		t.Fatalf("RunInTx failed: %v", err)
+	}
+
+	due, err := s.DueWebhookDeliveries(ctx, time.Now().Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("DueWebhookDeliveries failed: %v", err)
+	}
+	if len(due) != 1 || due[0].EndpointID != all.ID || due[0].LedgerOffset != 10 {
+		t.Fatalf("expected one delivery at offset 10 for endpoint %d, got %+v", all.ID, due)
+	}
+
+	// Dead-letter it, then replay.
+	id := due[0].ID
+	if err := s.RecordWebhookAttempt(ctx, id, indexer.WebhookAttempt{
+		Status: indexer.WebhookDeliveryDead, Attempts: 3, StatusCode: 500, Error: "boom", At: time.Now(),
+	}); err != nil {
+		t.Fatalf("RecordWebhookAttempt failed: %v", err)
+	}
+	if due, _ = s.DueWebhookDeliveries(ctx, time.Now().Add(time.Second), 10); len(due) != 0 {
+		t.Fatalf("expected no due deliveries after dead-lettering, got %d", len(due))
+	}
+	replayed, err := s.ReplayWebhookDelivery(ctx, id, time.Now())
+	if err != nil || replayed == nil {
+		t.Fatalf("ReplayWebhookDelivery failed: %v", err)
+	}
+	if replayed.Status != indexer.WebhookDeliveryPending || replayed.Attempts != 0 {
+		t.Fatalf("expected pending delivery with 0 attempts, got %+v", replayed)
+	}
+
+	deleted, err := s.DeleteWebhookEndpoint(ctx, all.ID)
+	if err != nil || !deleted {
+		t.Fatalf("DeleteWebhookEndpoint failed: deleted=%v err=%v", deleted, err)
+	}
+	if _, total, _ := s.ListWebhookDeliveries(ctx, all.ID, "", indexer.Pagination{Page: 1, Limit: 10}); total != 0 {
+		t.Fatalf("expected deliveries removed with endpoint, got %d", total)
+	}
+}
+
 // ── UpsertToken ───────────────────────────────────────────────────────────────
 
 func TestPGStore_UpsertToken(t *testing.T) {
@@ -749,6 +820,148 @@ func TestPGStore_ListEvents(t *testing.T) {
 	}
 }
 
+func TestPGStore_ListEvents_Cursor(t *testing.T) {
+	ctx, s := setupIndexerStore(t)
+
+	// Two events share offset 2; contract_id orders them.
+	for _, se := range []struct {
+		contractID string
+		offset     int64
+	}{{"c1", 1}, {"c2a", 2}, {"c2b", 2}, {"c3", 3}, {"c4", 4}} {
+		if _, err := s.InsertEvent(ctx, makeEvent(se.contractID, se.offset, indexer.EventMint, nil, ptr("alice"))); err != nil {
+			t.Fatalf("InsertEvent(%s) failed: %v", se.contractID, err)
+		}
+	}
+	ids := func(evs []*indexer.ParsedEvent) []string {
+		out := make([]string, len(evs))
+		for i, e := range evs {
+			out[i] = e.ContractID
+		}
+		return out
+	}
+
+	// Forward from c1: the next two rows, without a count.
+	after := &indexer.Cursor{LedgerOffset: 1, ContractID: "c1"}
+	evs, total, err := s.ListEvents(ctx, indexer.EventFilter{}, indexer.Pagination{Limit: 2, Cursor: after})
+	if err != nil {
+		t.Fatalf("ListEvents(after c1) failed: %v", err)
+	}
+	if got := ids(evs); !slices.Equal(got, []string{"c2a", "c2b"}) || total != indexer.TotalUnknown {
+		t.Fatalf("after c1: got %v total=%d", got, total)
+	}
+
+	// Forward from the tie: c2a's successor is c2b.
+	after = &indexer.Cursor{LedgerOffset: 2, ContractID: "c2a"}
+	evs, total, err = s.ListEvents(ctx, indexer.EventFilter{}, indexer.Pagination{Limit: 2, Cursor: after, WithTotal: true})
+	if err != nil {
+		t.Fatalf("ListEvents(after c2a) failed: %v", err)
+	}
+	if got := ids(evs); !slices.Equal(got, []string{"c2b", "c3"}) || total != 5 {
+		t.Fatalf("after c2a: got %v total=%d", got, total)
+	}
+
+	// Backward from c4: the two rows before it, still in list order.
+	before := &indexer.Cursor{LedgerOffset: 4, ContractID: "c4", Backward: true}
+	evs, _, err = s.ListEvents(ctx, indexer.EventFilter{}, indexer.Pagination{Limit: 2, Cursor: before})
+	if err != nil {
+		t.Fatalf("ListEvents(before c4) failed: %v", err)
+	}
+	if got := ids(evs); !slices.Equal(got, []string{"c2b", "c3"}) {
+		t.Fatalf("before c4: got %v", got)
+	}
+}
+
+func TestPGStore_HistoryFilters(t *testing.T) {
+	ctx, s := setupIndexerStore(t)
+
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, se := range []struct {
+		contractID string
+		from, to   *string
+		amount     string
+		externalTx *string
+	}{
+		{"e1", nil, ptr("alice"), "5", ptr("0xaaa")},
+		{"e2", ptr("alice"), ptr("bob"), "20.5", nil},
+		{"e3", ptr("bob"), ptr("alice"), "100", nil},
+		{"e4", ptr("alice"), ptr("carol"), "9.99", nil},
+	} {
+		ev := makeEvent(se.contractID, int64(i+1), indexer.EventTransfer, se.from, se.to)
+		ev.Amount = se.amount
+		ev.ExternalTxID = se.externalTx
+		ev.EffectiveTime = day.Add(time.Duration(i) * 24 * time.Hour)
+		if _, err := s.InsertEvent(ctx, ev); err != nil {
+			t.Fatalf("InsertEvent(%s) failed: %v", se.contractID, err)
+		}
+	}
+	p := indexer.Pagination{Page: 1, Limit: 10}
+	eventIDs := func(f indexer.EventFilter) []string {
+		t.Helper()
+		evs, _, err := s.ListEvents(ctx, f, p)
+		if err != nil {
+			t.Fatalf("ListEvents(%+v) failed: %v", f, err)
+		}
+		out := make([]string, len(evs))
+		for i, e := range evs {
+			out[i] = e.ContractID
+		}
+		return out
+	}
+
+	for _, tt := range []struct {
+		name string
+		f    indexer.EventFilter
+		want []string
+	}{
+		{"counterparty either way", indexer.EventFilter{PartyID: "alice", HistoryFilter: indexer.HistoryFilter{Counterparty: "bob"}},
+			[]string{"e2", "e3"}},
+		{"offset range", indexer.EventFilter{HistoryFilter: indexer.HistoryFilter{FromOffset: 2, ToOffset: 3}}, []string{"e2", "e3"}},
+		{"time range, until exclusive", indexer.EventFilter{HistoryFilter: indexer.HistoryFilter{
+			Since: day.Add(24 * time.Hour), Until: day.Add(3 * 24 * time.Hour)}}, []string{"e2", "e3"}},
+		// Compared as numbers: "9.99" < "20.5" although it sorts after it as text.
+		{"amount range", indexer.EventFilter{HistoryFilter: indexer.HistoryFilter{MinAmount: "9", MaxAmount: "21"}},
+			[]string{"e2", "e4"}},
+		{"external tx id", indexer.EventFilter{ExternalTxID: "0xaaa"}, []string{"e1"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventIDs(tt.f); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Transfers: the counterparty is the side the role does not select.
+	for i, tr := range []*indexer.Transfer{
+		makeDirect("t1", "alice", "bob", 1), makeDirect("t2", "bob", "alice", 2), makeDirect("t3", "carol", "alice", 3),
+	} {
+		tr.CreatedAt = day.Add(time.Duration(i) * time.Hour)
+		if err := s.InsertTransfer(ctx, tr); err != nil {
+			t.Fatalf("InsertTransfer(%s): %v", tr.ContractID, err)
+		}
+	}
+	for _, tt := range []struct {
+		role indexer.TransferRole
+		want []string
+	}{
+		{indexer.TransferRoleReceiver, []string{"t2"}},
+		{indexer.TransferRoleSender, []string{"t1"}},
+		{indexer.TransferRoleAny, []string{"t2", "t1"}}, // newest first
+	} {
+		got, _, err := s.ListTransfers(ctx, "alice",
+			indexer.TransferQuery{Role: tt.role, HistoryFilter: indexer.HistoryFilter{Counterparty: "bob"}}, p)
+		if err != nil {
+			t.Fatalf("ListTransfers(%s) failed: %v", tt.role, err)
+		}
+		ids := make([]string, len(got))
+		for i, tr := range got {
+			ids[i] = tr.ContractID
+		}
+		if !slices.Equal(ids, tt.want) {
+			t.Fatalf("role %s: got %v, want %v", tt.role, ids, tt.want)
+		}
+	}
+}
+
 func makeOffer(cid, sender, receiver string, offset int64, expiresAt *time.Time) *indexer.Transfer {
 	return &indexer.Transfer{
 		ContractID:      cid,
@@ -802,19 +1015,19 @@ func TestListTransfers(t *testing.T) {
 		}
 	}
 	// Complete the inbound offer so it reads as a completed transfer for alice.
-	if err := s.FinalizeTransfer(ctx, "o-done", indexer.TransferStatusCompleted); err != nil {
+	if err := s.FinalizeTransfer(ctx, "o-done", indexer.TransferStatusCompleted, 10); err != nil {
 		t.Fatalf("FinalizeTransfer(o-done): %v", err)
 	}
 	// Cancel the never-expires outbound offer (sender claim-back / withdraw).
-	if err := s.FinalizeTransfer(ctx, "o-noexp", indexer.TransferStatusCanceled); err != nil {
+	if err := s.FinalizeTransfer(ctx, "o-noexp", indexer.TransferStatusCanceled, 11); err != nil {
 		t.Fatalf("FinalizeTransfer(o-noexp): %v", err)
 	}
 	// A replayed archive must not overwrite an already-finalized row.
-	if err := s.FinalizeTransfer(ctx, "o-noexp", indexer.TransferStatusCompleted); err != nil {
+	if err := s.FinalizeTransfer(ctx, "o-noexp", indexer.TransferStatusCompleted, 12); err != nil {
 		t.Fatalf("FinalizeTransfer(o-noexp, replay): %v", err)
 	}
 	// Reject the last outbound offer (receiver declined).
-	if err := s.FinalizeTransfer(ctx, "o-rejected", indexer.TransferStatusRejected); err != nil {
+	if err := s.FinalizeTransfer(ctx, "o-rejected", indexer.TransferStatusRejected, 13); err != nil {
 		t.Fatalf("FinalizeTransfer(o-rejected): %v", err)
 	}
 
@@ -911,7 +1124,7 @@ func TestListTransfers_DirectAndOffer(t *testing.T) {
 	if err := s.InsertTransfer(ctx, makeOffer("of-usdcx", "carol", "alice", 2, nil)); err != nil {
 		t.Fatalf("insert offer: %v", err)
 	}
-	if err := s.FinalizeTransfer(ctx, "of-usdcx", indexer.TransferStatusCompleted); err != nil {
+	if err := s.FinalizeTransfer(ctx, "of-usdcx", indexer.TransferStatusCompleted, 10); err != nil {
 		t.Fatalf("complete offer: %v", err)
 	}
 
@@ -951,7 +1164,7 @@ func TestListPendingTransfers(t *testing.T) {
 	if err := s.InsertTransfer(ctx, makeOffer("p-done", "fred", "carol", 3, nil)); err != nil {
 		t.Fatalf("insert done offer: %v", err)
 	}
-	if err := s.FinalizeTransfer(ctx, "p-done", indexer.TransferStatusCompleted); err != nil {
+	if err := s.FinalizeTransfer(ctx, "p-done", indexer.TransferStatusCompleted, 10); err != nil {
 		t.Fatalf("complete offer: %v", err)
 	}
 	if err := s.InsertTransfer(ctx, makeDirect("p-direct", "gita", "hank", 4)); err != nil {
@@ -967,3 +1180,335 @@ func TestListPendingTransfers(t *testing.T) {
 		t.Fatalf("expected only p-live pending, got total=%d %+v", total, got)
 	}
 }
+
+func TestPGStore_StreamEvents(t *testing.T) {
+	ctx, s := setupIndexerStore(t)
+
+	mustInsert := func(e *indexer.ParsedEvent) {
+		t.Helper()
+		if _, err := s.InsertEvent(ctx, e); err != nil {
+			t.Fatalf("insert event: %v", err)
+		}
+	}
+	mustInsert(makeEvent("mint-1", 1, indexer.EventMint, nil, ptr("alice")))
+	mustInsert(makeEvent("other-1", 2, indexer.EventMint, nil, ptr("bob")))
+	mustInsert(makeEvent("xfer-1", 3, indexer.EventTransfer, ptr("alice"), ptr("bob")))
+	if err := s.InsertTransfer(ctx, makeOffer("of-1", "alice", "carol", 3, nil)); err != nil {
+		t.Fatalf("insert offer: %v", err)
+	}
+	if err := s.FinalizeTransfer(ctx, "of-1", indexer.TransferStatusCompleted, 5); err != nil {
+		t.Fatalf("finalize offer: %v", err)
+	}
+	history := []BalanceHistoryDao{
+		{PartyID: "alice", InstrumentAdmin: "admin-1", InstrumentID: "DEMO", LedgerOffset: 1, Amount: "100", EffectiveTime: time.Now()},
+		{PartyID: "alice", InstrumentAdmin: "admin-1", InstrumentID: "DEMO", LedgerOffset: 3, Amount: "0", EffectiveTime: time.Now()},
+	}
+	if _, err := s.db.NewInsert().Model(&history).Exec(ctx); err != nil {
+		t.Fatalf("insert balance history: %v", err)
+	}
+
+	offsetsAndTypes := func(events []*indexer.StreamEvent) []string {
+		out := make([]string, len(events))
+		for i, e := range events {
+			out[i] = fmt.Sprintf("%d:%s", e.LedgerOffset, e.Type)
+		}
+		return out
+	}
+
+	t.Run("all changes in offset order", func(t *testing.T) {
+		got, err := s.StreamEvents(ctx, "alice", 0, 100)
+		if err != nil {
+			t.Fatalf("StreamEvents: %v", err)
+		}
+		want := []string{"1:MINT", "1:BALANCE", "3:TRANSFER", "3:TRANSFER_STATUS", "3:BALANCE", "5:TRANSFER_STATUS"}
+		if fmt.Sprint(offsetsAndTypes(got)) != fmt.Sprint(want) {
+			t.Fatalf("got %v, want %v", offsetsAndTypes(got), want)
+		}
+		if got[3].Transfer.Status != indexer.TransferStatusPending || got[5].Transfer.Status != indexer.TransferStatusCompleted {
+			t.Fatalf("unexpected transfer statuses %q, %q", got[3].Transfer.Status, got[5].Transfer.Status)
+		}
+	})
+
+	t.Run("resumes after offset", func(t *testing.T) {
+		got, err := s.StreamEvents(ctx, "alice", 3, 100)
+		if err != nil {
+			t.Fatalf("StreamEvents: %v", err)
+		}
+		if len(got) != 1 || got[0].LedgerOffset != 5 {
+			t.Fatalf("got %v, want only offset 5", offsetsAndTypes(got))
+		}
+	})
+
+	t.Run("returns whole offsets only", func(t *testing.T) {
+		// With a limit of one row per source, the event and balance sources both
+		// stop at offset 1, which is then read whole on its own.
+		got, err := s.StreamEvents(ctx, "alice", 0, 1)
+		if err != nil {
+			t.Fatalf("StreamEvents: %v", err)
+		}
+		if want := []string{"1:MINT", "1:BALANCE"}; fmt.Sprint(offsetsAndTypes(got)) != fmt.Sprint(want) {
+			t.Fatalf("got %v, want %v", offsetsAndTypes(got), want)
+		}
+	})
+}
+
+// ── Token stats ───────────────────────────────────────────────────────────────
+
+func TestPGStore_TokenStats(t *testing.T) {
+	ctx, s := setupIndexerStore(t)
+
+	hour := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	offer := &indexer.Transfer{
+		ContractID: "offer-1", Kind: indexer.TransferKindOffer, Status: indexer.TransferStatusPending,
+		FromPartyID: "alice", ToPartyID: "bob", InstrumentAdmin: "admin-1", InstrumentID: "DEMO",
+		Amount: "10", LedgerOffset: 1, CreatedAt: hour,
+	}
+	// Offset 1 mints to alice and opens an offer, which only counts once accepted.
+	err := s.RunInTx(ctx, func(ctx context.Context, tx engine.Store) error {
+		mint := makeEvent("mint-1", 1, indexer.EventMint, nil, ptr("alice"))
+		if _, err := tx.InsertEvent(ctx, mint); err != nil {
+			return err
+		}
+		if err := tx.ApplyBalanceDelta(ctx, "alice", "admin-1", "DEMO", "100"); err != nil {
+			return err
+		}
+		if err := tx.InsertTransfer(ctx, offer); err != nil {
+			return err
+		}
+		return tx.RecordStats(ctx, hour.Add(15*time.Minute))
+	})
+	if err != nil {
+		t.Fatalf("tx at offset 1 failed: %v", err)
+	}
+	// Offset 2 settles a direct transfer and accepts the offer.
+	err = s.RunInTx(ctx, func(ctx context.Context, tx engine.Store) error {
+		direct := *offer
+		direct.ContractID, direct.Kind, direct.Status, direct.Amount = "direct-1", indexer.TransferKindDirect,
+			indexer.TransferStatusCompleted, "25"
+		if err := tx.InsertTransfer(ctx, &direct); err != nil {
+			return err
+		}
+		if err := tx.FinalizeTransfer(ctx, "offer-1", indexer.TransferStatusCompleted, 2); err != nil {
+			return err
+		}
+		if err := tx.ApplyBalanceDelta(ctx, "alice", "admin-1", "DEMO", "-35"); err != nil {
+			return err
+		}
+		if err := tx.ApplyBalanceDelta(ctx, "bob", "admin-1", "DEMO", "35"); err != nil {
+			return err
+		}
+		return tx.RecordStats(ctx, hour.Add(45*time.Minute))
+	})
+	if err != nil {
+		t.Fatalf("tx at offset 2 failed: %v", err)
+	}
+
+	want := indexer.TokenStatsBucket{
+		TransferCount: 2, TransferVolume: "35", MintCount: 1, MintVolume: "100", BurnVolume: "0",
+		ActiveParties: 2, NewHolders: 2,
+	}
+	for _, interval := range indexer.StatsIntervals {
+		buckets, err := s.ListTokenStats(ctx, "admin-1", "DEMO", interval, hour.Add(-48*time.Hour), hour.Add(48*time.Hour))
+		if err != nil {
+			t.Fatalf("ListTokenStats(%s) failed: %v", interval, err)
+		}
+		if len(buckets) != 1 {
+			t.Fatalf("ListTokenStats(%s): got %d buckets, want 1", interval, len(buckets))
+		}
+		want.Start = interval.BucketStart(hour)
+		if got := *buckets[0]; got != want {
+			t.Fatalf("ListTokenStats(%s): got %+v, want %+v", interval, got, want)
+		}
+	}
+
+	if err := s.ApplyBalanceDelta(ctx, "carol", "admin-1", "DEMO", "0.5"); err != nil {
+		t.Fatalf("ApplyBalanceDelta(carol) failed: %v", err)
+	}
+	top, err := s.ListTopHolders(ctx, "admin-1", "DEMO", 2)
+	if err != nil {
+		t.Fatalf("ListTopHolders failed: %v", err)
+	}
+	if len(top) != 2 || top[0].PartyID != "alice" || top[1].PartyID != "bob" {
+		t.Fatalf("ListTopHolders: got %v, want alice then bob", top)
+	}
+
+	dist, err := s.GetHolderDistribution(ctx, "admin-1", "DEMO")
+	if err != nil {
+		t.Fatalf("GetHolderDistribution failed: %v", err)
+	}
+	wantDist := []indexer.HolderBucket{
+		{Min: "0.1", Max: "1", Holders: 1, Amount: "0.5"},
+		{Min: "10", Max: "100", Holders: 2, Amount: "100"},
+	}
+	if !slices.Equal(dist, wantDist) {
+		t.Fatalf("GetHolderDistribution: got %+v, want %+v", dist, wantDist)
+	}
+}
+
+// ── Rewind / Rebuild ─────────────────────────────────────────────────────────
+
+// indexEvent commits e at its offset the way the processor does.
+func indexEvent(ctx context.Context, t *testing.T, s *PGStore, e *indexer.ParsedEvent, deltas map[string]string) {
+	t.Helper()
+	err := s.RunInTx(ctx, func(ctx context.Context, tx engine.Store) error {
+		if _, err := tx.InsertEvent(ctx, e); err != nil {
+			return err
+		}
+		if err := tx.UpsertToken(ctx, makeToken(e.InstrumentAdmin, e.InstrumentID, e.LedgerOffset)); err != nil {
+			return err
+		}
+		if e.EventType == indexer.EventMint {
+			if err := tx.ApplySupplyDelta(ctx, e.InstrumentAdmin, e.InstrumentID, e.Amount); err != nil {
+				return err
+			}
+		}
+		for party, delta := range deltas {
+			if err := tx.ApplyBalanceDelta(ctx, party, e.InstrumentAdmin, e.InstrumentID, delta); err != nil {
+				return err
+			}
+		}
+		if err := tx.RecordHistory(ctx, e.LedgerOffset, e.EffectiveTime); err != nil {
+			return err
+		}
+		return tx.SaveOffset(ctx, e.LedgerOffset)
+	})
+	if err != nil {
+		t.Fatalf("index %s at offset %d failed: %v", e.ContractID, e.LedgerOffset, err)
+	}
+}
+
+func balanceOf(ctx context.Context, t *testing.T, s *PGStore, party string) string {
+	t.Helper()
+	b, err := s.GetBalance(ctx, party, "admin-1", "DEMO")
+	if err != nil {
+		t.Fatalf("GetBalance(%s) failed: %v", party, err)
+	}
+	if b == nil {
+		return ""
+	}
+	return b.Amount
+}
+
+func TestPGStore_RewindAndRebuild(t *testing.T) {
+	ctx, s := setupIndexerStore(t)
+
+	indexEvent(ctx, t, s, makeEvent("mint-1", 1, indexer.EventMint, nil, ptr("alice")), map[string]string{"alice": "100"})
+	transfer := makeEvent("transfer-1", 2, indexer.EventTransfer, ptr("alice"), ptr("bob"))
+	transfer.Amount = "40"
+	indexEvent(ctx, t, s, transfer, map[string]string{"alice": "-40", "bob": "40"})
+
+	// Drift bob's balance outside the processor; a rebuild reports and repairs it.
+	if err := s.ApplyBalanceDelta(ctx, "bob", "admin-1", "DEMO", "5"); err != nil {
+		t.Fatalf("ApplyBalanceDelta(bob) failed: %v", err)
+	}
+	want := []indexer.BalanceChange{{PartyID: "bob", InstrumentAdmin: "admin-1", InstrumentID: "DEMO", Before: "45", After: "40"}}
+	dry, err := s.Rebuild(ctx, true)
+	if err != nil {
+		t.Fatalf("Rebuild(dry run) failed: %v", err)
+	}
+	if !slices.Equal(dry.Balances, want) || len(dry.Tokens) != 0 {
+		t.Fatalf("Rebuild(dry run): got %+v, want balances %+v", dry, want)
+	}
+	if got := balanceOf(ctx, t, s, "bob"); got != "45" {
+		t.Fatalf("dry run changed bob's balance to %s", got)
+	}
+	if _, err = s.Rebuild(ctx, false); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	if got := balanceOf(ctx, t, s, "bob"); got != "40" {
+		t.Fatalf("Rebuild: bob's balance is %s, want 40", got)
+	}
+
+	// Rewinding to offset 1 undoes the transfer.
+	report, err := s.Rewind(ctx, 1, false)
+	if err != nil {
+		t.Fatalf("Rewind failed: %v", err)
+	}
+	if report.FromOffset != 2 || report.EventsDeleted != 1 || len(report.Balances) != 2 {
+		t.Fatalf("Rewind: got %+v", report)
+	}
+	if got := balanceOf(ctx, t, s, "alice"); got != "100" {
+		t.Fatalf("Rewind: alice's balance is %s, want 100", got)
+	}
+	if got := balanceOf(ctx, t, s, "bob"); got != "" {
+		t.Fatalf("Rewind: bob still has a balance of %s", got)
+	}
+	tok, err := s.GetToken(ctx, "admin-1", "DEMO")
+	if err != nil || tok == nil || tok.HolderCount != 1 || tok.TotalSupply != "100" {
+		t.Fatalf("Rewind: got token %+v (err %v), want supply 100 with 1 holder", tok, err)
+	}
+	if offset, _ := s.LatestOffset(ctx); offset != 1 {
+		t.Fatalf("Rewind: offset is %d, want 1", offset)
+	}
+	if _, err = s.Rewind(ctx, 1, true); err == nil {
+		t.Fatal("Rewind to the indexed offset should fail")
+	}
+}
+
+// ── Drift ────────────────────────────────────────────────────────────────────
+
+func TestPGStore_DriftRecordAndRepair(t *testing.T) {
+	ctx, s := setupIndexerStore(t)
+
+	indexEvent(ctx, t, s, makeEvent("mint-1", 1, indexer.EventMint, nil, ptr("alice")), map[string]string{"alice": "100"})
+	indexEvent(ctx, t, s, makeEvent("mint-2", 2, indexer.EventMint, nil, ptr("bob")), map[string]string{"bob": "100"})
+
+	state, err := s.IndexedState(ctx, []string{"alice"})
+	if err != nil {
+		t.Fatalf("IndexedState failed: %v", err)
+	}
+	if state.LedgerOffset != 2 || len(state.Balances) != 1 || state.Balances[0].PartyID != "alice" || len(state.Tokens) != 1 {
+		t.Fatalf("IndexedState: got %+v", state)
+	}
+	parties, err := s.SampleParties(ctx, 0)
+	if err != nil || !slices.Equal(parties, []string{"alice", "bob"}) {
+		t.Fatalf("SampleParties(0): got %v (err %v)", parties, err)
+	}
+	if parties, err = s.SampleParties(ctx, 1); err != nil || len(parties) != 1 {
+		t.Fatalf("SampleParties(1): got %v (err %v)", parties, err)
+	}
+
+	// The ledger says bob holds nothing and carol holds 5.
+	changes := []indexer.BalanceChange{
+		{PartyID: "bob", InstrumentAdmin: "admin-1", InstrumentID: "DEMO", Before: "100", After: "0"},
+		{PartyID: "carol", InstrumentAdmin: "admin-1", InstrumentID: "DEMO", Before: "0", After: "5"},
+	}
+	if err = s.RepairBalances(ctx, 2, changes); err != nil {
+		t.Fatalf("RepairBalances failed: %v", err)
+	}
+	if got := balanceOf(ctx, t, s, "bob"); got != "0" {
+		t.Fatalf("RepairBalances: bob's balance is %s, want 0", got)
+	}
+	if got := balanceOf(ctx, t, s, "carol"); got != "5" {
+		t.Fatalf("RepairBalances: carol's balance is %s, want 5", got)
+	}
+	tok, err := s.GetToken(ctx, "admin-1", "DEMO")
+	if err != nil || tok == nil || tok.HolderCount != 2 {
+		t.Fatalf("RepairBalances: got token %+v (err %v), want 2 holders", tok, err)
+	}
+	at, err := s.GetBalanceAt(ctx, "carol", "admin-1", "DEMO", indexer.AsOf{Offset: 2})
+	if err != nil || at == nil || at.Amount != "5" {
+		t.Fatalf("RepairBalances: carol's balance at offset 2 is %+v (err %v), want 5", at, err)
+	}
+
+	drifts := []indexer.Drift{
+		{Kind: indexer.DriftBalance, PartyID: "bob", InstrumentAdmin: "admin-1", InstrumentID: "DEMO",
+			Indexed: "100", Ledger: "0", LedgerOffset: 2, Repaired: true, DetectedAt: time.Now().UTC()},
+		{Kind: indexer.DriftSupply, InstrumentAdmin: "admin-1", InstrumentID: "DEMO",
+			Indexed: "200", Ledger: "105", LedgerOffset: 2, DetectedAt: time.Now().UTC()},
+	}
+	if err = s.RecordDrift(ctx, drifts); err != nil {
+		t.Fatalf("RecordDrift failed: %v", err)
+	}
+	if drifts[0].ID == 0 || drifts[1].ID <= drifts[0].ID {
+		t.Fatalf("RecordDrift: got IDs %d, %d", drifts[0].ID, drifts[1].ID)
+	}
+	listed, total, err := s.ListDrift(ctx, "", indexer.Pagination{Page: 1, Limit: 10})
+	if err != nil || total != 2 || listed[0].Kind != indexer.DriftSupply {
+		t.Fatalf("ListDrift: got %+v, total %d (err %v), want newest first", listed, total, err)
+	}
+	listed, total, err = s.ListDrift(ctx, "bob", indexer.Pagination{Page: 1, Limit: 10})
+	if err != nil || total != 1 || !listed[0].Repaired {
+		t.Fatalf("ListDrift(bob): got %+v, total %d (err %v)", listed, total, err)
+	}
+}
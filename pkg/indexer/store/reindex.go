@@ -0,0 +1,604 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// rebuildPageSize is the number of events Rebuild reads per query.
+const rebuildPageSize = 1000
+
+// errDryRun rolls back the transaction of a dry-run rewind or rebuild once its
+// report is complete.
+var errDryRun = errors.New("dry run")
+
+// runReindexTx runs fn in a read-write transaction on a transaction-scoped
+// store, rolling it back when dryRun is set.
+func (s *PGStore) runReindexTx(ctx context.Context, dryRun bool, fn func(ctx context.Context, tx *PGStore) error) error {
+	db, ok := s.db.(*bun.DB)
+	if !ok {
+		return errors.New("reindex called on a transaction-scoped store")
+	}
+	err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := fn(ctx, &PGStore{db: tx, history: newHistoryTracker(), outbox: new(outboxTracker), stats: newStatsTracker()}); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return err
+}
+
+// Rewind returns the indexed state to what it was at offset, in one transaction:
+// events, transfers and holdings indexed after offset are deleted, offers
+// finalized and holdings archived after it are reopened, balances, supplies and
+// holder counts are restored from their history, undelivered webhooks raised
+// after it are dropped, and the stored offset is set to offset so the processor
+// streams the rewound range again. Stats rollups cannot be rewound exactly; see
+// indexer.RewindReport.
+//
+// offset must be before the stored offset. Rewinding a snapshot-bootstrapped
+// database to before its snapshot offset leaves balances the snapshot seeded
+// without history; follow it with a Rebuild.
+func (s *PGStore) Rewind(ctx context.Context, offset int64, dryRun bool) (*indexer.RewindReport, error) {
+	report := &indexer.RewindReport{DryRun: dryRun, ToOffset: offset}
+	err := s.runReindexTx(ctx, dryRun, func(ctx context.Context, tx *PGStore) error {
+		latest, err := tx.LatestOffset(ctx)
+		if err != nil {
+			return err
+		}
+		if offset < 0 || offset >= latest {
+			return fmt.Errorf("rewind target %d is not before the indexed offset %d", offset, latest)
+		}
+		report.FromOffset = latest
+
+		if report.StatsClearedFrom, err = tx.firstTimeAfter(ctx, offset); err != nil {
+			return err
+		}
+		if report.Balances, err = tx.rewindBalances(ctx, offset); err != nil {
+			return err
+		}
+		if report.Tokens, err = tx.rewindTokens(ctx, offset); err != nil {
+			return err
+		}
+		if err = tx.rewindLedgerRows(ctx, offset, report); err != nil {
+			return err
+		}
+		if err = tx.clearStats(ctx, report.StatsClearedFrom); err != nil {
+			return err
+		}
+		return tx.SaveOffset(ctx, offset)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rewind to %d: %w", offset, err)
+	}
+	return report, nil
+}
+
+// firstTimeAfter returns the earliest effective time recorded after offset, or
+// nil when nothing after offset carries a time.
+func (s *PGStore) firstTimeAfter(ctx context.Context, offset int64) (*time.Time, error) {
+	var first sql.NullTime
+	err := s.db.NewRaw(`
+		SELECT min(t) FROM (
+			SELECT min(effective_time) AS t FROM indexer_events WHERE ledger_offset > ?
+			UNION ALL SELECT min(created_at) FROM indexer_transfers WHERE ledger_offset > ?
+			UNION ALL SELECT min(effective_time) FROM indexer_balance_history WHERE ledger_offset > ?
+			UNION ALL SELECT min(effective_time) FROM indexer_supply_history WHERE ledger_offset > ?
+		) times`,
+		offset, offset, offset, offset,
+	).Scan(ctx, &first)
+	if err != nil {
+		return nil, fmt.Errorf("first time after offset: %w", err)
+	}
+	if !first.Valid {
+		return nil, nil
+	}
+	return &first.Time, nil
+}
+
+// rewindBalances restores every balance changed after offset to its latest
+// history row at or before offset, deleting balances that had none, and drops
+// the balance history after offset.
+func (s *PGStore) rewindBalances(ctx context.Context, offset int64) ([]indexer.BalanceChange, error) {
+	var rows []struct {
+		PartyID         string  `bun:"party_id"`
+		InstrumentAdmin string  `bun:"instrument_admin"`
+		InstrumentID    string  `bun:"instrument_id"`
+		Before          string  `bun:"before"`
+		After           *string `bun:"after"`
+	}
+	err := s.db.NewRaw(`
+		SELECT k.party_id, k.instrument_admin, k.instrument_id,
+			COALESCE(b.amount, '0') AS before,
+			(SELECT h.amount FROM indexer_balance_history h
+				WHERE h.party_id = k.party_id AND h.instrument_admin = k.instrument_admin
+					AND h.instrument_id = k.instrument_id AND h.ledger_offset <= ?
+				ORDER BY h.ledger_offset DESC LIMIT 1) AS after
+		FROM (SELECT DISTINCT party_id, instrument_admin, instrument_id
+			FROM indexer_balance_history WHERE ledger_offset > ?) k
+		LEFT JOIN indexer_balances b USING (party_id, instrument_admin, instrument_id)
+		ORDER BY k.instrument_admin, k.instrument_id, k.party_id`,
+		offset, offset,
+	).Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("select rewound balances: %w", err)
+	}
+
+	changes := make([]indexer.BalanceChange, 0, len(rows))
+	for _, r := range rows {
+		c := indexer.BalanceChange{
+			PartyID: r.PartyID, InstrumentAdmin: r.InstrumentAdmin, InstrumentID: r.InstrumentID,
+			Before: r.Before, After: "0",
+		}
+		if r.After == nil {
+			err = s.deleteBalance(ctx, c)
+		} else {
+			c.After = *r.After
+			err = s.setBalance(ctx, c)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !sameAmount(c.Before, c.After) {
+			changes = append(changes, c)
+		}
+	}
+	if _, err = s.db.NewDelete().Model((*BalanceHistoryDao)(nil)).Where("ledger_offset > ?", offset).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("delete balance history: %w", err)
+	}
+	return changes, nil
+}
+
+// rewindTokens restores the supply and holder count of every token changed
+// after offset from its supply history at or before offset, deletes tokens
+// first seen after offset, and drops the supply history after offset.
+func (s *PGStore) rewindTokens(ctx context.Context, offset int64) ([]indexer.TokenChange, error) {
+	var rows []struct {
+		InstrumentAdmin string  `bun:"instrument_admin"`
+		InstrumentID    string  `bun:"instrument_id"`
+		SupplyBefore    string  `bun:"supply_before"`
+		HoldersBefore   int64   `bun:"holders_before"`
+		SupplyAfter     *string `bun:"supply_after"`
+		HoldersAfter    *int64  `bun:"holders_after"`
+		Deleted         bool    `bun:"deleted"`
+	}
+	err := s.db.NewRaw(`
+		SELECT t.instrument_admin, t.instrument_id,
+			t.total_supply AS supply_before, t.holder_count AS holders_before,
+			h.total_supply AS supply_after, h.holder_count AS holders_after,
+			t.first_seen_offset > ? AS deleted
+		FROM indexer_tokens t
+		LEFT JOIN LATERAL (SELECT sh.total_supply, sh.holder_count FROM indexer_supply_history sh
+			WHERE sh.instrument_admin = t.instrument_admin AND sh.instrument_id = t.instrument_id
+				AND sh.ledger_offset <= ?
+			ORDER BY sh.ledger_offset DESC LIMIT 1) h ON true
+		WHERE t.first_seen_offset > ? OR EXISTS (SELECT 1 FROM indexer_supply_history x
+			WHERE x.instrument_admin = t.instrument_admin AND x.instrument_id = t.instrument_id
+				AND x.ledger_offset > ?)
+		ORDER BY t.instrument_admin, t.instrument_id`,
+		offset, offset, offset, offset,
+	).Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("select rewound tokens: %w", err)
+	}
+
+	changes := make([]indexer.TokenChange, 0, len(rows))
+	for _, r := range rows {
+		c := indexer.TokenChange{
+			InstrumentAdmin: r.InstrumentAdmin, InstrumentID: r.InstrumentID,
+			SupplyBefore: r.SupplyBefore, HoldersBefore: r.HoldersBefore, SupplyAfter: "0",
+			Deleted: r.Deleted,
+		}
+		if r.SupplyAfter != nil && !r.Deleted {
+			c.SupplyAfter, c.HoldersAfter = *r.SupplyAfter, *r.HoldersAfter
+		}
+		if err = s.setToken(ctx, c); err != nil {
+			return nil, err
+		}
+		if c.Deleted || !sameAmount(c.SupplyBefore, c.SupplyAfter) || c.HoldersBefore != c.HoldersAfter {
+			changes = append(changes, c)
+		}
+	}
+	if _, err = s.db.NewDelete().Model((*SupplyHistoryDao)(nil)).Where("ledger_offset > ?", offset).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("delete supply history: %w", err)
+	}
+	return changes, nil
+}
+
+// rewindLedgerRows deletes the events, transfers, holdings and undelivered
+// webhooks indexed after offset and reopens the offers and holdings closed
+// after it, counting each into report.
+func (s *PGStore) rewindLedgerRows(ctx context.Context, offset int64, report *indexer.RewindReport) error {
+	steps := []struct {
+		name  string
+		query interface {
+			Exec(ctx context.Context, dest ...any) (sql.Result, error)
+		}
+		count *int64
+	}{
+		{"delete events", s.db.NewDelete().Model((*EventDao)(nil)).
+			Where("ledger_offset > ?", offset), &report.EventsDeleted},
+		{"delete transfers", s.db.NewDelete().Model((*TransferDao)(nil)).
+			Where("ledger_offset > ?", offset), &report.TransfersDeleted},
+		{"reopen transfers", s.db.NewUpdate().Model((*TransferDao)(nil)).
+			Set("status = ?", indexer.TransferStatusPending).
+			Set("finalized_offset = NULL").
+			Where("finalized_offset > ?", offset), &report.TransfersReopened},
+		{"delete holdings", s.db.NewDelete().Model((*HoldingDao)(nil)).
+			Where("ledger_offset > ?", offset), &report.HoldingsDeleted},
+		{"restore holdings", s.db.NewUpdate().Model((*HoldingDao)(nil)).
+			Set("archived_offset = NULL").
+			Where("archived_offset > ?", offset), &report.HoldingsRestored},
+		{"drop webhooks", s.db.NewDelete().Model((*WebhookDeliveryDao)(nil)).
+			Where("ledger_offset > ?", offset).
+			Where("status = ?", indexer.WebhookDeliveryPending), &report.WebhooksDropped},
+	}
+	for _, step := range steps {
+		res, err := step.query.Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", step.name, err)
+		}
+		if *step.count, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("%s rows affected: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+// clearStats deletes the rollup buckets of every interval from the one
+// containing from onwards. No-op when from is nil.
+func (s *PGStore) clearStats(ctx context.Context, from *time.Time) error {
+	if from == nil {
+		return nil
+	}
+	for _, interval := range indexer.StatsIntervals {
+		start := interval.BucketStart(*from)
+		for _, model := range []any{(*TokenStatsDao)(nil), (*TokenActivePartyDao)(nil)} {
+			_, err := s.db.NewDelete().
+				Model(model).
+				Where("bucket_interval = ?", string(interval)).
+				Where("bucket_start >= ?", start).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("clear %s stats: %w", interval, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Rebuild recomputes balances, total supplies and holder counts from the stored
+// events and holdings, without re-streaming the ledger, and overwrites the
+// stored values that differ. Events are replayed in ledger order under the
+// processor's rules; each active holding adds its amount to its owner's
+// balance. Supply is recomputed only for tokens first seen through an event,
+// whose supply started at zero; snapshot-seeded supplies are kept. Changed
+// values get a history row at the stored offset.
+func (s *PGStore) Rebuild(ctx context.Context, dryRun bool) (*indexer.RebuildReport, error) {
+	report := &indexer.RebuildReport{DryRun: dryRun}
+	err := s.runReindexTx(ctx, dryRun, func(ctx context.Context, tx *PGStore) error {
+		var err error
+		if report.Offset, err = tx.LatestOffset(ctx); err != nil {
+			return err
+		}
+		r := newReplay()
+		if err = tx.replayEvents(ctx, r); err != nil {
+			return err
+		}
+		if err = tx.replayHoldings(ctx, r); err != nil {
+			return err
+		}
+		if report.Balances, err = tx.rebuildBalances(ctx, r); err != nil {
+			return err
+		}
+		if report.Tokens, err = tx.rebuildTokens(ctx, r); err != nil {
+			return err
+		}
+		at, err := tx.lastEffectiveTime(ctx)
+		if err != nil {
+			return err
+		}
+		return tx.RecordHistory(ctx, report.Offset, at)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rebuild: %w", err)
+	}
+	return report, nil
+}
+
+// replay accumulates the balances and supplies implied by the stored events
+// and holdings.
+type replay struct {
+	balances map[balanceKey]decimal.Decimal
+	supplies map[indexer.InstrumentKey]decimal.Decimal
+	// firstSeen is the offset of each token's earliest event.
+	firstSeen map[indexer.InstrumentKey]int64
+}
+
+func newReplay() *replay {
+	return &replay{
+		balances:  make(map[balanceKey]decimal.Decimal),
+		supplies:  make(map[indexer.InstrumentKey]decimal.Decimal),
+		firstSeen: make(map[indexer.InstrumentKey]int64),
+	}
+}
+
+func (r *replay) add(partyID string, token indexer.InstrumentKey, amount decimal.Decimal) {
+	k := balanceKey{partyID: partyID, token: token}
+	r.balances[k] = r.balances[k].Add(amount)
+}
+
+// applyEvent applies e as the processor does: a TRANSFER whose sender would go
+// negative credits the receiver only; a BURN that would is an error.
+func (r *replay) applyEvent(e *EventDao) error {
+	amount, err := decimal.NewFromString(e.Amount)
+	if err != nil {
+		return fmt.Errorf("parse amount %q of %s: %w", e.Amount, e.ContractID, err)
+	}
+	token := indexer.InstrumentKey{Admin: e.InstrumentAdmin, ID: e.InstrumentID}
+	if first, ok := r.firstSeen[token]; !ok || e.LedgerOffset < first {
+		r.firstSeen[token] = e.LedgerOffset
+	}
+	switch indexer.EventType(e.EventType) {
+	case indexer.EventMint:
+		r.supplies[token] = r.supplies[token].Add(amount)
+		if e.ToPartyID != nil {
+			r.add(*e.ToPartyID, token, amount)
+		}
+	case indexer.EventBurn:
+		r.supplies[token] = r.supplies[token].Sub(amount)
+		if e.FromPartyID != nil {
+			k := balanceKey{partyID: *e.FromPartyID, token: token}
+			if r.balances[k].LessThan(amount) {
+				return fmt.Errorf("burn %s underflows the balance of %s", e.ContractID, *e.FromPartyID)
+			}
+			r.add(*e.FromPartyID, token, amount.Neg())
+		}
+	case indexer.EventTransfer:
+		if e.FromPartyID == nil || e.ToPartyID == nil {
+			return nil
+		}
+		if k := (balanceKey{partyID: *e.FromPartyID, token: token}); !r.balances[k].LessThan(amount) {
+			r.add(*e.FromPartyID, token, amount.Neg())
+		}
+		r.add(*e.ToPartyID, token, amount)
+	}
+	return nil
+}
+
+// replayEvents applies every stored event to r in ledger order.
+func (s *PGStore) replayEvents(ctx context.Context, r *replay) error {
+	var lastOffset int64 = -1
+	lastContract := ""
+	for {
+		var events []EventDao
+		err := s.db.NewSelect().
+			Model(&events).
+			Where("(ledger_offset, contract_id) > (?, ?)", lastOffset, lastContract).
+			OrderExpr("ledger_offset ASC, contract_id ASC").
+			Limit(rebuildPageSize).
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("replay events: %w", err)
+		}
+		for i := range events {
+			if err = r.applyEvent(&events[i]); err != nil {
+				return err
+			}
+		}
+		if len(events) < rebuildPageSize {
+			return nil
+		}
+		last := events[len(events)-1]
+		lastOffset, lastContract = last.LedgerOffset, last.ContractID
+	}
+}
+
+// replayHoldings adds the active holdings of every owner to r.
+func (s *PGStore) replayHoldings(ctx context.Context, r *replay) error {
+	var rows []struct {
+		Owner           string `bun:"owner"`
+		InstrumentAdmin string `bun:"instrument_admin"`
+		InstrumentID    string `bun:"instrument_id"`
+		Amount          string `bun:"amount"`
+	}
+	err := s.db.NewRaw(`
+		SELECT owner, instrument_admin, instrument_id, sum(amount::numeric)::text AS amount
+		FROM indexer_holdings
+		WHERE archived_offset IS NULL
+		GROUP BY owner, instrument_admin, instrument_id`,
+	).Scan(ctx, &rows)
+	if err != nil {
+		return fmt.Errorf("replay holdings: %w", err)
+	}
+	for _, h := range rows {
+		amount, err := decimal.NewFromString(h.Amount)
+		if err != nil {
+			return fmt.Errorf("parse holdings amount %q: %w", h.Amount, err)
+		}
+		r.add(h.Owner, indexer.InstrumentKey{Admin: h.InstrumentAdmin, ID: h.InstrumentID}, amount)
+	}
+	return nil
+}
+
+// rebuildBalances overwrites the stored balances that differ from r. Stored
+// balances r has no amount for are set to zero.
+func (s *PGStore) rebuildBalances(ctx context.Context, r *replay) ([]indexer.BalanceChange, error) {
+	var stored []BalanceDao
+	if err := s.db.NewSelect().Model(&stored).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("select balances: %w", err)
+	}
+	before := make(map[balanceKey]string, len(stored))
+	for _, b := range stored {
+		before[balanceKey{partyID: b.PartyID, token: indexer.InstrumentKey{Admin: b.InstrumentAdmin, ID: b.InstrumentID}}] = b.Amount
+	}
+	keys := make(map[balanceKey]struct{}, len(before)+len(r.balances))
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range r.balances {
+		keys[k] = struct{}{}
+	}
+
+	var changes []indexer.BalanceChange
+	for k := range keys {
+		old := cmp.Or(before[k], "0")
+		after := r.balances[k]
+		if sameAmount(old, after.String()) {
+			continue
+		}
+		c := indexer.BalanceChange{
+			PartyID: k.partyID, InstrumentAdmin: k.token.Admin, InstrumentID: k.token.ID,
+			Before: old, After: after.String(),
+		}
+		if err := s.setBalance(ctx, c); err != nil {
+			return nil, err
+		}
+		s.history.touchBalance(c.PartyID, c.InstrumentAdmin, c.InstrumentID, c.After)
+		changes = append(changes, c)
+	}
+	slices.SortFunc(changes, func(a, b indexer.BalanceChange) int {
+		return cmp.Or(
+			cmp.Compare(a.InstrumentAdmin, b.InstrumentAdmin),
+			cmp.Compare(a.InstrumentID, b.InstrumentID),
+			cmp.Compare(a.PartyID, b.PartyID),
+		)
+	})
+	return changes, nil
+}
+
+// rebuildTokens overwrites the holder counts, and the supplies of tokens first
+// seen through an event, that differ from r.
+func (s *PGStore) rebuildTokens(ctx context.Context, r *replay) ([]indexer.TokenChange, error) {
+	holders := make(map[indexer.InstrumentKey]int64)
+	for k, amount := range r.balances {
+		if amount.IsPositive() {
+			holders[k.token]++
+		}
+	}
+	var tokens []TokenDao
+	if err := s.db.NewSelect().Model(&tokens).OrderExpr("instrument_admin, instrument_id").Scan(ctx); err != nil {
+		return nil, fmt.Errorf("select tokens: %w", err)
+	}
+
+	var changes []indexer.TokenChange
+	for _, t := range tokens {
+		k := indexer.InstrumentKey{Admin: t.InstrumentAdmin, ID: t.InstrumentID}
+		c := indexer.TokenChange{
+			InstrumentAdmin: t.InstrumentAdmin, InstrumentID: t.InstrumentID,
+			SupplyBefore: t.TotalSupply, SupplyAfter: t.TotalSupply,
+			HoldersBefore: t.HolderCount, HoldersAfter: holders[k],
+		}
+		if first, ok := r.firstSeen[k]; ok && first == t.FirstSeenOffset {
+			c.SupplyAfter = r.supplies[k].String()
+		}
+		if sameAmount(c.SupplyBefore, c.SupplyAfter) && c.HoldersBefore == c.HoldersAfter {
+			continue
+		}
+		if err := s.setToken(ctx, c); err != nil {
+			return nil, err
+		}
+		s.history.touchToken(c.InstrumentAdmin, c.InstrumentID)
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// lastEffectiveTime returns the latest effective time recorded by the indexer,
+// or now when nothing has been indexed.
+func (s *PGStore) lastEffectiveTime(ctx context.Context) (time.Time, error) {
+	var last sql.NullTime
+	err := s.db.NewRaw(`
+		SELECT max(t) FROM (
+			SELECT max(effective_time) AS t FROM indexer_events
+			UNION ALL SELECT max(effective_time) FROM indexer_balance_history
+			UNION ALL SELECT max(effective_time) FROM indexer_supply_history
+		) times`,
+	).Scan(ctx, &last)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("last effective time: %w", err)
+	}
+	if !last.Valid {
+		return time.Now().UTC(), nil
+	}
+	return last.Time, nil
+}
+
+func (s *PGStore) setBalance(ctx context.Context, c indexer.BalanceChange) error {
+	_, err := s.db.NewInsert().
+		Model(&BalanceDao{PartyID: c.PartyID, InstrumentAdmin: c.InstrumentAdmin, InstrumentID: c.InstrumentID, Amount: c.After}).
+		On("CONFLICT (party_id, instrument_admin, instrument_id) DO UPDATE").
+		Set("amount = EXCLUDED.amount").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("set balance: %w", err)
+	}
+	return nil
+}
+
+func (s *PGStore) deleteBalance(ctx context.Context, c indexer.BalanceChange) error {
+	_, err := s.db.NewDelete().
+		Model((*BalanceDao)(nil)).
+		Where("party_id = ?", c.PartyID).
+		Where("instrument_admin = ?", c.InstrumentAdmin).
+		Where("instrument_id = ?", c.InstrumentID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete balance: %w", err)
+	}
+	return nil
+}
+
+// setToken writes c's supply and holder count, or deletes the token when c is
+// a deletion.
+func (s *PGStore) setToken(ctx context.Context, c indexer.TokenChange) error {
+	var err error
+	if c.Deleted {
+		_, err = s.db.NewDelete().
+			Model((*TokenDao)(nil)).
+			Where("instrument_admin = ?", c.InstrumentAdmin).
+			Where("instrument_id = ?", c.InstrumentID).
+			Exec(ctx)
+	} else {
+		_, err = s.db.NewUpdate().
+			Model((*TokenDao)(nil)).
+			Set("total_supply = ?", c.SupplyAfter).
+			Set("holder_count = ?", c.HoldersAfter).
+			Where("instrument_admin = ?", c.InstrumentAdmin).
+			Where("instrument_id = ?", c.InstrumentID).
+			Exec(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("set token %s/%s: %w", c.InstrumentAdmin, c.InstrumentID, err)
+	}
+	return nil
+}
+
+// sameAmount reports whether two decimal strings hold the same value; strings
+// that do not parse are compared as written.
+func sameAmount(a, b string) bool {
+	da, errA := decimal.NewFromString(a)
+	db, errB := decimal.NewFromString(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return da.Equal(db)
+}
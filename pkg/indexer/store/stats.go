@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// tokenActivity is one token's activity within a transaction.
+type tokenActivity struct {
+	transfers, mints, burns, newHolders    int64
+	transferVolume, mintVolume, burnVolume decimal.Decimal
+	parties                                []string
+	seen                                   map[string]struct{}
+}
+
+func (a *tokenActivity) touchParty(partyID string) {
+	if _, ok := a.seen[partyID]; !ok {
+		a.seen[partyID] = struct{}{}
+		a.parties = append(a.parties, partyID)
+	}
+}
+
+// statsTracker collects the activity of a transaction per token, in first-touch
+// order, so RecordStats can add it to the rollups of the transaction's buckets.
+type statsTracker struct {
+	tokens map[indexer.InstrumentKey]*tokenActivity
+	order  []indexer.InstrumentKey
+}
+
+func newStatsTracker() *statsTracker {
+	return &statsTracker{tokens: make(map[indexer.InstrumentKey]*tokenActivity)}
+}
+
+func (t *statsTracker) token(admin, id string) *tokenActivity {
+	k := indexer.InstrumentKey{Admin: admin, ID: id}
+	a, ok := t.tokens[k]
+	if !ok {
+		a = &tokenActivity{seen: make(map[string]struct{})}
+		t.tokens[k] = a
+		t.order = append(t.order, k)
+	}
+	return a
+}
+
+// addMintOrBurn records a newly indexed MINT or BURN event. Transfer events are
+// counted through the transfer they settle (see addTransfer).
+func (t *statsTracker) addMintOrBurn(e *indexer.ParsedEvent) error {
+	if t == nil || (e.EventType != indexer.EventMint && e.EventType != indexer.EventBurn) {
+		return nil
+	}
+	amount, err := decimal.NewFromString(e.Amount)
+	if err != nil {
+		return fmt.Errorf("parse amount %q: %w", e.Amount, err)
+	}
+	a := t.token(e.InstrumentAdmin, e.InstrumentID)
+	if e.EventType == indexer.EventMint {
+		a.mints++
+		a.mintVolume = a.mintVolume.Add(amount)
+	} else {
+		a.burns++
+		a.burnVolume = a.burnVolume.Add(amount)
+	}
+	for _, p := range []*string{e.FromPartyID, e.ToPartyID} {
+		if p != nil {
+			a.touchParty(*p)
+		}
+	}
+	return nil
+}
+
+// addTransfer records a transfer settled in this transaction: a direct transfer
+// or an accepted offer.
+func (t *statsTracker) addTransfer(tr *indexer.Transfer) error {
+	if t == nil || tr.Status != indexer.TransferStatusCompleted {
+		return nil
+	}
+	amount, err := decimal.NewFromString(tr.Amount)
+	if err != nil {
+		return fmt.Errorf("parse amount %q: %w", tr.Amount, err)
+	}
+	a := t.token(tr.InstrumentAdmin, tr.InstrumentID)
+	a.transfers++
+	a.transferVolume = a.transferVolume.Add(amount)
+	a.touchParty(tr.FromPartyID)
+	a.touchParty(tr.ToPartyID)
+	return nil
+}
+
+// addNewHolder records a party's first credit of a token.
+func (t *statsTracker) addNewHolder(admin, id string) {
+	if t == nil {
+		return
+	}
+	t.token(admin, id).newHolders++
+}
+
+// RecordStats adds the activity collected earlier in this transaction to the
+// hourly and daily rollups of the buckets containing effectiveTime, then
+// resets the tracker. Must be called on the transaction-scoped store passed
+// to RunInTx.
+func (s *PGStore) RecordStats(ctx context.Context, effectiveTime time.Time) error {
+	if s.stats == nil {
+		return errors.New("RecordStats called outside a transaction")
+	}
+	st := s.stats
+	defer func() { s.stats = newStatsTracker() }()
+
+	for _, k := range st.order {
+		a := st.tokens[k]
+		for _, interval := range indexer.StatsIntervals {
+			if err := s.addToRollup(ctx, k, interval, interval.BucketStart(effectiveTime), a); err != nil {
+				return fmt.Errorf("record %s stats for %s/%s: %w", interval, k.Admin, k.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *PGStore) addToRollup(
+	ctx context.Context, k indexer.InstrumentKey, interval indexer.StatsInterval, start time.Time, a *tokenActivity,
+) error {
+	var active int64
+	if len(a.parties) > 0 {
+		rows := make([]TokenActivePartyDao, 0, len(a.parties))
+		for _, p := range a.parties {
+			rows = append(rows, TokenActivePartyDao{
+				InstrumentAdmin: k.Admin,
+				InstrumentID:    k.ID,
+				Interval:        string(interval),
+				BucketStart:     start,
+				PartyID:         p,
+			})
+		}
+		res, err := s.db.NewInsert().Model(&rows).On("CONFLICT DO NOTHING").Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("mark active parties: %w", err)
+		}
+		// Only parties not yet active in the bucket are inserted.
+		if active, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("mark active parties rows affected: %w", err)
+		}
+	}
+
+	_, err := s.db.NewRaw(`
+		INSERT INTO indexer_token_stats AS t
+			(instrument_admin, instrument_id, bucket_interval, bucket_start,
+			transfer_count, transfer_volume, mint_count, mint_volume, burn_count, burn_volume,
+			active_parties, new_holders)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (instrument_admin, instrument_id, bucket_interval, bucket_start) DO UPDATE
+		SET transfer_count = t.transfer_count + EXCLUDED.transfer_count,
+			transfer_volume = (t.transfer_volume::numeric + EXCLUDED.transfer_volume::numeric)::text,
+			mint_count = t.mint_count + EXCLUDED.mint_count,
+			mint_volume = (t.mint_volume::numeric + EXCLUDED.mint_volume::numeric)::text,
+			burn_count = t.burn_count + EXCLUDED.burn_count,
+			burn_volume = (t.burn_volume::numeric + EXCLUDED.burn_volume::numeric)::text,
+			active_parties = t.active_parties + EXCLUDED.active_parties,
+			new_holders = t.new_holders + EXCLUDED.new_holders`,
+		k.Admin, k.ID, string(interval), start,
+		a.transfers, a.transferVolume.String(), a.mints, a.mintVolume.String(), a.burns, a.burnVolume.String(),
+		active, a.newHolders,
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("upsert rollup: %w", err)
+	}
+	return nil
+}
+
+// ListTokenStats returns a token's rollup buckets of the given interval that
+// start in [since, until), oldest first. Buckets without activity have no row.
+func (s *PGStore) ListTokenStats(
+	ctx context.Context, admin, id string, interval indexer.StatsInterval, since, until time.Time,
+) ([]*indexer.TokenStatsBucket, error) {
+	var daos []TokenStatsDao
+	err := s.db.NewSelect().
+		Model(&daos).
+		Where("instrument_admin = ?", admin).
+		Where("instrument_id = ?", id).
+		Where("bucket_interval = ?", string(interval)).
+		Where("bucket_start >= ?", since).
+		Where("bucket_start < ?", until).
+		OrderExpr("bucket_start ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list token stats: %w", err)
+	}
+	buckets := make([]*indexer.TokenStatsBucket, len(daos))
+	for i := range daos {
+		buckets[i] = fromTokenStatsDao(&daos[i])
+	}
+	return buckets, nil
+}
+
+// ListTopHolders returns the limit largest non-zero balances of a token,
+// largest first; ties are ordered by party ID.
+func (s *PGStore) ListTopHolders(ctx context.Context, admin, id string, limit int) ([]*indexer.Balance, error) {
+	var daos []BalanceDao
+	err := s.db.NewSelect().
+		Model(&daos).
+		Where("instrument_admin = ?", admin).
+		Where("instrument_id = ?", id).
+		Where("amount::numeric > 0").
+		OrderExpr("amount::numeric DESC").
+		OrderExpr("party_id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list top holders: %w", err)
+	}
+	balances := make([]*indexer.Balance, len(daos))
+	for i := range daos {
+		balances[i] = fromBalanceDao(&daos[i])
+	}
+	return balances, nil
+}
+
+// GetHolderDistribution buckets a token's non-zero balances by order of
+// magnitude: bucket n holds balances in [10^n, 10^(n+1)). Empty buckets are
+// omitted; the rest are returned smallest first.
+func (s *PGStore) GetHolderDistribution(ctx context.Context, admin, id string) ([]indexer.HolderBucket, error) {
+	var rows []struct {
+		Magnitude int32  `bun:"magnitude"`
+		Holders   int64  `bun:"holders"`
+		Amount    string `bun:"amount"`
+	}
+	err := s.db.NewRaw(`
+		SELECT floor(log(amount::numeric))::int AS magnitude,
+			count(*) AS holders,
+			sum(amount::numeric)::text AS amount
+		FROM indexer_balances
+		WHERE instrument_admin = ? AND instrument_id = ? AND amount::numeric > 0
+		GROUP BY magnitude
+		ORDER BY magnitude`,
+		admin, id,
+	).Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("holder distribution: %w", err)
+	}
+	buckets := make([]indexer.HolderBucket, len(rows))
+	for i, r := range rows {
+		buckets[i] = indexer.HolderBucket{
+			Min:     decimal.New(1, r.Magnitude).String(),
+			Max:     decimal.New(1, r.Magnitude+1).String(),
+			Holders: r.Holders,
+			Amount:  r.Amount,
+		}
+	}
+	return buckets, nil
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// streamSource reads one kind of change concerning partyID committed in the
+// offset range (after, through], ordered by offset. A zero limit reads the
+// whole range.
+type streamSource func(
+	ctx context.Context, db bun.IDB, partyID string, after, through int64, limit int,
+) ([]*indexer.StreamEvent, error)
+
+// streamSources lists the sources merged by StreamEvents, in the order their
+// changes are emitted within one offset.
+var streamSources = []streamSource{
+	streamTokenEvents,
+	streamTransfersCreated,
+	streamTransfersFinalized,
+	streamBalances,
+}
+
+// StreamEvents returns the changes concerning partyID committed after offset
+// after — token events, transfer status transitions and balance changes — in
+// offset order. At most limit rows are read per kind of change, and only whole
+// offsets are returned: an offset whose changes did not all fit is left for the
+// next call, unless it is the first one, which is then returned in full.
+func (s *PGStore) StreamEvents(ctx context.Context, partyID string, after int64, limit int) ([]*indexer.StreamEvent, error) {
+	var out []*indexer.StreamEvent
+	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
+		// A source that filled its limit may have more rows at its last offset,
+		// so only offsets before the smallest such offset are known to be whole.
+		cut := int64(math.MaxInt64)
+		var all []*indexer.StreamEvent
+		for _, src := range streamSources {
+			events, err := src(ctx, db, partyID, after, math.MaxInt64, limit)
+			if err != nil {
+				return err
+			}
+			if len(events) == limit {
+				cut = min(cut, events[len(events)-1].LedgerOffset)
+			}
+			all = append(all, events...)
+		}
+
+		for _, e := range all {
+			if e.LedgerOffset < cut {
+				out = append(out, e)
+			}
+		}
+		if len(out) == 0 && cut != math.MaxInt64 {
+			// The first offset alone has more than limit changes of one kind.
+			for _, src := range streamSources {
+				events, err := src(ctx, db, partyID, cut-1, cut, 0)
+				if err != nil {
+					return err
+				}
+				out = append(out, events...)
+			}
+		}
+		// Stable, so changes within an offset keep the source order.
+		sort.SliceStable(out, func(i, j int) bool { return out[i].LedgerOffset < out[j].LedgerOffset })
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stream events: %w", err)
+	}
+	return out, nil
+}
+
+func streamTokenEvents(
+	ctx context.Context, db bun.IDB, partyID string, after, through int64, limit int,
+) ([]*indexer.StreamEvent, error) {
+	var daos []EventDao
+	q := db.NewSelect().Model(&daos).
+		Where("(from_party_id = ? OR to_party_id = ?)", partyID, partyID).
+		Where("ledger_offset > ? AND ledger_offset <= ?", after, through).
+		OrderExpr("ledger_offset ASC, contract_id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("token events: %w", err)
+	}
+	out := make([]*indexer.StreamEvent, len(daos))
+	for i := range daos {
+		e := fromEventDao(&daos[i])
+		out[i] = &indexer.StreamEvent{
+			Type:         indexer.StreamEventType(e.EventType),
+			LedgerOffset: e.LedgerOffset,
+			Event:        e,
+		}
+	}
+	return out, nil
+}
+
+// streamTransfersCreated emits the status each transfer was created with:
+// "pending" for an offer or allocation, "completed" for a direct transfer.
+func streamTransfersCreated(
+	ctx context.Context, db bun.IDB, partyID string, after, through int64, limit int,
+) ([]*indexer.StreamEvent, error) {
+	var daos []TransferDao
+	q := db.NewSelect().Model(&daos).
+		Where("(from_party_id = ? OR to_party_id = ?)", partyID, partyID).
+		Where("ledger_offset > ? AND ledger_offset <= ?", after, through).
+		OrderExpr("ledger_offset ASC, contract_id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("created transfers: %w", err)
+	}
+	out := make([]*indexer.StreamEvent, len(daos))
+	for i := range daos {
+		t := fromTransferDao(&daos[i])
+		t.Status = indexer.TransferStatusCompleted
+		if t.Kind != indexer.TransferKindDirect {
+			t.Status = indexer.TransferStatusPending
+		}
+		out[i] = &indexer.StreamEvent{
+			Type:         indexer.StreamEventTransferStatus,
+			LedgerOffset: t.LedgerOffset,
+			Transfer:     &t,
+		}
+	}
+	return out, nil
+}
+
+// streamTransfersFinalized emits offers leaving "pending", at the offset they
+// were finalized.
+func streamTransfersFinalized(
+	ctx context.Context, db bun.IDB, partyID string, after, through int64, limit int,
+) ([]*indexer.StreamEvent, error) {
+	var daos []TransferDao
+	q := db.NewSelect().Model(&daos).
+		Where("(from_party_id = ? OR to_party_id = ?)", partyID, partyID).
+		Where("finalized_offset > ? AND finalized_offset <= ?", after, through).
+		OrderExpr("finalized_offset ASC, contract_id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("finalized transfers: %w", err)
+	}
+	out := make([]*indexer.StreamEvent, len(daos))
+	for i := range daos {
+		t := fromTransferDao(&daos[i])
+		out[i] = &indexer.StreamEvent{
+			Type:         indexer.StreamEventTransferStatus,
+			LedgerOffset: *daos[i].FinalizedOffset,
+			Transfer:     &t,
+		}
+	}
+	return out, nil
+}
+
+// streamBalances emits the party's balance after every offset that changed it,
+// from the balance history.
+func streamBalances(
+	ctx context.Context, db bun.IDB, partyID string, after, through int64, limit int,
+) ([]*indexer.StreamEvent, error) {
+	var daos []BalanceHistoryDao
+	q := db.NewSelect().Model(&daos).
+		Where("party_id = ?", partyID).
+		Where("ledger_offset > ? AND ledger_offset <= ?", after, through).
+		OrderExpr("ledger_offset ASC, instrument_admin ASC, instrument_id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("balance history: %w", err)
+	}
+	out := make([]*indexer.StreamEvent, len(daos))
+	for i := range daos {
+		out[i] = &indexer.StreamEvent{
+			Type:         indexer.StreamEventBalance,
+			LedgerOffset: daos[i].LedgerOffset,
+			Balance:      &fromBalanceHistoryDao(&daos[i]).Balance,
+		}
+	}
+	return out, nil
+}
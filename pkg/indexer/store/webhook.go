@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// outboxTracker collects the webhook notifications raised in a transaction, in
+// the order they happened, so EnqueueWebhooks can fan them out to endpoints.
+type outboxTracker struct {
+	notifications []*indexer.WebhookNotification
+}
+
+func (o *outboxTracker) add(n *indexer.WebhookNotification) {
+	if o == nil {
+		return
+	}
+	o.notifications = append(o.notifications, n)
+}
+
+// EnqueueWebhooks writes one pending delivery per registered endpoint whose
+// filter matches a notification raised earlier in this transaction — a newly
+// indexed event or a transfer entering a status — then resets the tracker.
+// Every notification is stamped with offset, the transaction that raised it.
+// Must be called on the transaction-scoped store passed to RunInTx.
+func (s *PGStore) EnqueueWebhooks(ctx context.Context, offset int64) error {
+	if s.outbox == nil {
+		return errors.New("EnqueueWebhooks called outside a transaction")
+	}
+	pending := s.outbox.notifications
+	s.outbox.notifications = nil
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var endpoints []WebhookEndpointDao
+	if err := s.db.NewSelect().Model(&endpoints).OrderExpr("id ASC").Scan(ctx); err != nil {
+		return fmt.Errorf("load webhook endpoints: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var rows []WebhookDeliveryDao
+	for _, n := range pending {
+		n.LedgerOffset = offset
+		var payload []byte
+		for i := range endpoints {
+			if !endpoints[i].Filter.Matches(n) {
+				continue
+			}
+			if payload == nil {
+				var err error
+				if payload, err = json.Marshal(n); err != nil {
+					return fmt.Errorf("marshal webhook notification: %w", err)
+				}
+			}
+			rows = append(rows, WebhookDeliveryDao{
+				EndpointID:    endpoints[i].ID,
+				EventType:     string(n.Type),
+				LedgerOffset:  offset,
+				Payload:       string(payload),
+				Status:        indexer.WebhookDeliveryPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	if _, err := s.db.NewInsert().Model(&rows).Exec(ctx); err != nil {
+		return fmt.Errorf("insert webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// CreateWebhookEndpoint registers an endpoint and sets its ID.
+func (s *PGStore) CreateWebhookEndpoint(ctx context.Context, e *indexer.WebhookEndpoint) error {
+	dao := toWebhookEndpointDao(e)
+	if _, err := s.db.NewInsert().Model(dao).Returning("id").Exec(ctx); err != nil {
+		return fmt.Errorf("create webhook endpoint: %w", err)
+	}
+	e.ID = dao.ID
+	return nil
+}
+
+// GetWebhookEndpoint returns an endpoint, secret included. Returns nil, nil when
+// not found.
+func (s *PGStore) GetWebhookEndpoint(ctx context.Context, id int64) (*indexer.WebhookEndpoint, error) {
+	dao := new(WebhookEndpointDao)
+	err := s.db.NewSelect().Model(dao).Where("id = ?", id).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get webhook endpoint: %w", err)
+	}
+	return fromWebhookEndpointDao(dao), nil
+}
+
+// ListWebhookEndpoints returns registered endpoints ordered by ID.
+func (s *PGStore) ListWebhookEndpoints(ctx context.Context, p indexer.Pagination) ([]*indexer.WebhookEndpoint, int64, error) {
+	var daos []WebhookEndpointDao
+	var total int
+	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
+		q := db.NewSelect().Model(&daos).OrderExpr("id ASC")
+		var err error
+		if total, err = q.Count(ctx); err != nil {
+			return fmt.Errorf("count: %w", err)
+		}
+		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("list webhook endpoints: %w", err)
+	}
+	endpoints := make([]*indexer.WebhookEndpoint, len(daos))
+	for i := range daos {
+		endpoints[i] = fromWebhookEndpointDao(&daos[i])
+	}
+	return endpoints, int64(total), nil
+}
+
+// DeleteWebhookEndpoint removes an endpoint together with its deliveries.
+// Returns false when the endpoint does not exist.
+func (s *PGStore) DeleteWebhookEndpoint(ctx context.Context, id int64) (bool, error) {
+	var deleted bool
+	err := s.runTx(ctx, func(ctx context.Context, db bun.IDB) error {
+		if _, err := db.NewDelete().Model((*WebhookDeliveryDao)(nil)).Where("endpoint_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		result, err := db.NewDelete().Model((*WebhookEndpointDao)(nil)).Where("id = ?", id).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		deleted = n > 0
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("delete webhook endpoint: %w", err)
+	}
+	return deleted, nil
+}
+
+// ListWebhookDeliveries returns an endpoint's deliveries, newest first,
+// optionally filtered by status ("" = all).
+func (s *PGStore) ListWebhookDeliveries(
+	ctx context.Context, endpointID int64, status string, p indexer.Pagination,
+) ([]*indexer.WebhookDelivery, int64, error) {
+	var daos []WebhookDeliveryDao
+	var total int
+	err := s.runReadTx(ctx, func(ctx context.Context, db bun.IDB) error {
+		q := db.NewSelect().Model(&daos).Where("endpoint_id = ?", endpointID)
+		if status != "" {
+			q = q.Where("status = ?", status)
+		}
+		q = q.OrderExpr("id DESC")
+		var err error
+		if total, err = q.Count(ctx); err != nil {
+			return fmt.Errorf("count: %w", err)
+		}
+		return q.Limit(p.Limit).Offset((p.Page - 1) * p.Limit).Scan(ctx)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	deliveries := make([]*indexer.WebhookDelivery, len(daos))
+	for i := range daos {
+		deliveries[i] = fromWebhookDeliveryDao(&daos[i])
+	}
+	return deliveries, int64(total), nil
+}
+
+// DueWebhookDeliveries returns up to limit pending deliveries whose next
+// attempt is due at now, oldest first.
+func (s *PGStore) DueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*indexer.WebhookDelivery, error) {
+	var daos []WebhookDeliveryDao
+	err := s.db.NewSelect().Model(&daos).
+		Where("status = ?", indexer.WebhookDeliveryPending).
+		Where("next_attempt_at <= ?", now).
+		OrderExpr("id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("due webhook deliveries: %w", err)
+	}
+	deliveries := make([]*indexer.WebhookDelivery, len(daos))
+	for i := range daos {
+		deliveries[i] = fromWebhookDeliveryDao(&daos[i])
+	}
+	return deliveries, nil
+}
+
+// RecordWebhookAttempt stores the outcome of a delivery attempt.
+func (s *PGStore) RecordWebhookAttempt(ctx context.Context, id int64, a indexer.WebhookAttempt) error {
+	q := s.db.NewUpdate().Model((*WebhookDeliveryDao)(nil)).
+		Set("status = ?", a.Status).
+		Set("attempts = ?", a.Attempts).
+		Set("last_status_code = ?", a.StatusCode).
+		Set("last_error = ?", a.Error).
+		Where("id = ?", id)
+	switch a.Status {
+	case indexer.WebhookDeliveryPending:
+		q = q.Set("next_attempt_at = ?", a.NextAttemptAt)
+	case indexer.WebhookDeliveryDelivered:
+		q = q.Set("delivered_at = ?", a.At)
+	}
+	if _, err := q.Exec(ctx); err != nil {
+		return fmt.Errorf("record webhook attempt: %w", err)
+	}
+	return nil
+}
+
+// ReplayWebhookDelivery makes a delivery pending again with a fresh attempt
+// budget, due at now. Returns nil, nil when the delivery does not exist.
+func (s *PGStore) ReplayWebhookDelivery(ctx context.Context, id int64, now time.Time) (*indexer.WebhookDelivery, error) {
+	var updated []WebhookDeliveryDao
+	_, err := s.db.NewUpdate().Model((*WebhookDeliveryDao)(nil)).
+		Set("status = ?", indexer.WebhookDeliveryPending).
+		Set("attempts = 0").
+		Set("next_attempt_at = ?", now).
+		Set("last_status_code = 0").
+		Set("last_error = ''").
+		Set("delivered_at = NULL").
+		Where("id = ?", id).
+		Returning("*").
+		Exec(ctx, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("replay webhook delivery: %w", err)
+	}
+	if len(updated) == 0 {
+		return nil, nil
+	}
+	return fromWebhookDeliveryDao(&updated[0]), nil
+}
+
+// runTx runs fn in a read-write transaction, or directly when the store is
+// already transaction-scoped.
+func (s *PGStore) runTx(ctx context.Context, fn func(ctx context.Context, db bun.IDB) error) error {
+	db, ok := s.db.(*bun.DB)
+	if !ok {
+		return fn(ctx, s.db)
+	}
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(ctx, tx)
+	})
+}
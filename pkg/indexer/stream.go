@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import "slices"
+
+// StreamEventType classifies a change pushed to stream subscribers. MINT, BURN,
+// TRANSFER and TRANSFER_STATUS mean the same as for webhooks; BALANCE is a
+// party's balance of one instrument changing.
+type StreamEventType string
+
+const (
+	StreamEventMint           StreamEventType = "MINT"
+	StreamEventBurn           StreamEventType = "BURN"
+	StreamEventTransfer       StreamEventType = "TRANSFER"
+	StreamEventTransferStatus StreamEventType = "TRANSFER_STATUS"
+	StreamEventBalance        StreamEventType = "BALANCE"
+)
+
+// ValidStreamEventType reports whether t is a known StreamEventType.
+func ValidStreamEventType(t StreamEventType) bool {
+	switch t {
+	case StreamEventMint, StreamEventBurn, StreamEventTransfer, StreamEventTransferStatus, StreamEventBalance:
+		return true
+	default:
+		return false
+	}
+}
+
+// StreamEvent is one change committed at LedgerOffset that concerns a party.
+// Exactly one of Event (MINT/BURN/TRANSFER), Transfer (TRANSFER_STATUS) or
+// Balance (BALANCE) is set. Transfer carries the status the transfer entered at
+// LedgerOffset, which may since have changed again.
+type StreamEvent struct {
+	Type         StreamEventType `json:"type"`
+	LedgerOffset int64           `json:"ledger_offset"`
+	Event        *ParsedEvent    `json:"event,omitempty"`
+	Transfer     *Transfer       `json:"transfer,omitempty"`
+	Balance      *Balance        `json:"balance,omitempty"`
+}
+
+// instrument returns the instrument a stream event concerns.
+func (e *StreamEvent) instrument() InstrumentKey {
+	switch {
+	case e.Event != nil:
+		return InstrumentKey{Admin: e.Event.InstrumentAdmin, ID: e.Event.InstrumentID}
+	case e.Transfer != nil:
+		return InstrumentKey{Admin: e.Transfer.InstrumentAdmin, ID: e.Transfer.InstrumentID}
+	case e.Balance != nil:
+		return InstrumentKey{Admin: e.Balance.InstrumentAdmin, ID: e.Balance.InstrumentID}
+	}
+	return InstrumentKey{}
+}
+
+// StreamBatch is every change of one ledger offset delivered to a subscriber,
+// after filtering. Offsets are delivered whole and in order, so a subscriber
+// that has processed a batch can resume after its offset without gaps.
+type StreamBatch struct {
+	LedgerOffset int64          `json:"ledger_offset"`
+	Events       []*StreamEvent `json:"events"`
+}
+
+// StreamFilter selects the changes delivered to a subscriber. Every non-empty
+// field must match; an empty field matches everything.
+type StreamFilter struct {
+	// Instruments matches changes for any listed instrument.
+	Instruments []InstrumentKey
+	// EventTypes matches changes of any listed type.
+	EventTypes []StreamEventType
+}
+
+// Matches reports whether e passes the filter.
+func (f *StreamFilter) Matches(e *StreamEvent) bool {
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, e.Type) {
+		return false
+	}
+	if len(f.Instruments) > 0 && !slices.Contains(f.Instruments, e.instrument()) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import "time"
+
+// Config configures the live event streams. Omitting the block disables the
+// stream endpoints.
+type Config struct {
+	// HeartbeatInterval is how often an idle stream is sent a keepalive (an SSE
+	// comment or a WebSocket ping), so proxies don't close it and dead clients
+	// are noticed.
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" default:"15s"`
+	// WriteTimeout bounds a single write to a subscriber. A client that does not
+	// accept a write in time is too slow to keep up and is disconnected; it can
+	// resume from its last event ID.
+	WriteTimeout time.Duration `yaml:"write_timeout" default:"10s"`
+	// PageSize is the number of rows of each kind of change read per query.
+	PageSize int `yaml:"page_size" default:"500" validate:"min=1"`
+	// MaxSubscribers caps the number of concurrent streams; further ones are
+	// refused with 503.
+	MaxSubscribers int `yaml:"max_subscribers" default:"1000" validate:"min=1"`
+}
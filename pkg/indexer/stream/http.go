@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	indexerservice "github.com/chainsafe/canton-middleware/pkg/indexer/service"
+)
+
+const (
+	// HeaderLastEventID is sent by EventSource clients when they reconnect.
+	HeaderLastEventID = "Last-Event-ID"
+
+	// maxClientMessageBytes caps the frames read from a WebSocket client, which
+	// has nothing to send but control frames.
+	maxClientMessageBytes = 512
+)
+
+// HTTP wraps the Service to provide the SSE and WebSocket endpoints.
+type HTTP struct {
+	service  Service
+	cfg      Config
+	upgrader websocket.Upgrader
+	logger   *zap.Logger
+}
+
+// RegisterPrivateRoutes registers the stream endpoints on the given chi router:
+//
+//	GET /indexer/v1/admin/parties/{partyID}/stream     – Server-Sent Events
+//	GET /indexer/v1/admin/parties/{partyID}/stream/ws  – WebSocket
+//
+// Both accept ?instrument=<admin>/<id> and ?event_type=<type>, each repeatable,
+// to filter the changes sent, and resume after the offset given by the
+// Last-Event-ID header (SSE) or ?last_event_id=. Like the party routes of the
+// read API, end-user tokens may only stream their own party.
+//
+// Streams are long-lived: mount them outside any request-timeout middleware.
+func RegisterPrivateRoutes(r chi.Router, svc Service, cfg *Config, logger *zap.Logger) {
+	h := &HTTP{service: svc, cfg: *cfg, logger: logger}
+
+	r.Route("/indexer/v1/admin/parties/{partyID}/stream", func(r chi.Router) {
+		r.Use(indexerservice.RequireOwnParty)
+		r.Get("/", apphttp.HandleError(h.sse))
+		r.Get("/ws", apphttp.HandleError(h.websocket))
+	})
+}
+
+func (h *HTTP) sse(w http.ResponseWriter, r *http.Request) error {
+	req, err := parseSubscribeRequest(r)
+	if err != nil {
+		return err
+	}
+	sink := &sseSink{w: w, rc: http.NewResponseController(w), timeout: h.cfg.WriteTimeout}
+	err = h.service.Subscribe(r.Context(), req, sink)
+	if sink.opened {
+		// The response has started; the error can no longer be sent.
+		return nil
+	}
+	return err
+}
+
+func (h *HTTP) websocket(w http.ResponseWriter, r *http.Request) error {
+	req, err := parseSubscribeRequest(r)
+	if err != nil {
+		return err
+	}
+	// A hijacked connection's request context is not canceled when the client
+	// goes away; the sink's reader cancels ctx instead.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	sink := &wsSink{
+		upgrader:  &h.upgrader,
+		w:         w,
+		r:         r,
+		timeout:   h.cfg.WriteTimeout,
+		heartbeat: h.cfg.HeartbeatInterval,
+		cancel:    cancel,
+	}
+	err = h.service.Subscribe(ctx, req, sink)
+	if sink.conn != nil {
+		sink.close()
+		return nil
+	}
+	if sink.upgradeFailed {
+		// Upgrade has already answered the request.
+		return nil
+	}
+	return err
+}
+
+// parseSubscribeRequest reads the party, resume offset and filters of a stream
+// request.
+func parseSubscribeRequest(r *http.Request) (*SubscribeRequest, error) {
+	req := &SubscribeRequest{PartyID: chi.URLParam(r, "partyID")}
+
+	lastID := r.Header.Get(HeaderLastEventID)
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+	if lastID != "" {
+		v, err := strconv.ParseInt(lastID, 10, 64)
+		if err != nil || v < 0 {
+			return nil, apperrors.BadRequestError(nil, "last event ID must be an offset >= 0")
+		}
+		req.After = &v
+	}
+
+	for _, s := range r.URL.Query()["instrument"] {
+		admin, id, ok := strings.Cut(s, "/")
+		if !ok || admin == "" || id == "" {
+			return nil, apperrors.BadRequestError(nil, "instrument must be <admin>/<id>")
+		}
+		req.Filter.Instruments = append(req.Filter.Instruments, indexer.InstrumentKey{Admin: admin, ID: id})
+	}
+	for _, s := range r.URL.Query()["event_type"] {
+		t := indexer.StreamEventType(s)
+		if !indexer.ValidStreamEventType(t) {
+			return nil, apperrors.BadRequestError(nil,
+				"event_type must be MINT, BURN, TRANSFER, TRANSFER_STATUS or BALANCE")
+		}
+		req.Filter.EventTypes = append(req.Filter.EventTypes, t)
+	}
+	return req, nil
+}
+
+// sseSink writes a subscription as Server-Sent Events. Every change is one
+// event named after its type; only the last event of an offset carries the
+// offset as its ID, so a reconnecting EventSource resumes after whole offsets.
+type sseSink struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+	opened  bool
+}
+
+func (s *sseSink) Open() error {
+	s.opened = true
+	h := s.w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no") // disable proxy buffering (nginx)
+	if err := s.extendDeadline(); err != nil {
+		return err
+	}
+	s.w.WriteHeader(http.StatusOK)
+	return s.rc.Flush()
+}
+
+func (s *sseSink) Send(batch *indexer.StreamBatch) error {
+	if err := s.extendDeadline(); err != nil {
+		return err
+	}
+	for i, e := range batch.Events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("marshal event: %w", err)
+		}
+		id := ""
+		if i == len(batch.Events)-1 {
+			id = "id: " + strconv.FormatInt(batch.LedgerOffset, 10) + "\n"
+		}
+		if _, err := fmt.Fprintf(s.w, "event: %s\n%sdata: %s\n\n", e.Type, id, data); err != nil {
+			return err
+		}
+	}
+	return s.rc.Flush()
+}
+
+func (s *sseSink) Heartbeat() error {
+	if err := s.extendDeadline(); err != nil {
+		return err
+	}
+	if _, err := s.w.Write([]byte(": keepalive\n\n")); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// extendDeadline gives the next write WriteTimeout, replacing the server's
+// WriteTimeout, which counts from the start of the request.
+func (s *sseSink) extendDeadline() error {
+	err := s.rc.SetWriteDeadline(time.Now().Add(s.timeout))
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+// wsSink writes a subscription over a WebSocket, one JSON StreamBatch per text
+// frame. It reads the connection only to answer control frames and notice the
+// client going away, which cancels the subscription.
+type wsSink struct {
+	upgrader  *websocket.Upgrader
+	w         http.ResponseWriter
+	r         *http.Request
+	timeout   time.Duration
+	heartbeat time.Duration
+	cancel    context.CancelFunc
+
+	conn          *websocket.Conn
+	upgradeFailed bool
+	readerDone    chan struct{}
+}
+
+func (s *wsSink) Open() error {
+	conn, err := s.upgrader.Upgrade(s.w, s.r, nil)
+	if err != nil {
+		s.upgradeFailed = true
+		return err
+	}
+	s.conn = conn
+	s.readerDone = make(chan struct{})
+	go s.read()
+	return nil
+}
+
+// read consumes client frames until the connection fails or closes. A client
+// that stops answering pings is dropped after two heartbeat intervals.
+func (s *wsSink) read() {
+	defer close(s.readerDone)
+	defer s.cancel()
+
+	wait := 2 * s.heartbeat
+	s.conn.SetReadLimit(maxClientMessageBytes)
+	_ = s.conn.SetReadDeadline(time.Now().Add(wait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wait))
+	})
+	for {
+		if _, _, err := s.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (s *wsSink) Send(batch *indexer.StreamBatch) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(batch)
+}
+
+func (s *wsSink) Heartbeat() error {
+	return s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.timeout))
+}
+
+// close says goodbye, closes the connection and waits for the reader to exit.
+func (s *wsSink) close() {
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "stream ended")
+	_ = s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(s.timeout))
+	_ = s.conn.Close()
+	<-s.readerDone
+}
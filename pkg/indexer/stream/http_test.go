@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/stream"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/stream/mocks"
+)
+
+const streamPath = "/indexer/v1/admin/parties/" + testParty + "/stream"
+
+func newTestServer(t *testing.T) (*httptest.Server, *mocks.Service) {
+	t.Helper()
+	svc := mocks.NewService(t)
+	r := chi.NewRouter()
+	stream.RegisterPrivateRoutes(r, svc, &testCfg, zap.NewNop())
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, svc
+}
+
+func get(t *testing.T, url string, header http.Header) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// sendOffset6 is a Subscribe implementation that streams one offset with two
+// changes, then ends.
+func sendOffset6(_ context.Context, _ *stream.SubscribeRequest, sink stream.Sink) error {
+	if err := sink.Open(); err != nil {
+		return nil
+	}
+	_ = sink.Send(&indexer.StreamBatch{
+		LedgerOffset: 6,
+		Events:       []*indexer.StreamEvent{mintAt(6, "admin", "DEMO"), balanceAt(6)},
+	})
+	return nil
+}
+
+func TestSSE_StreamsEventsWithOffsetIDs(t *testing.T) {
+	srv, svc := newTestServer(t)
+	var got *stream.SubscribeRequest
+	svc.EXPECT().Subscribe(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, req *stream.SubscribeRequest, _ stream.Sink) { got = req }).
+		RunAndReturn(sendOffset6)
+
+	resp := get(t, srv.URL+streamPath+"?instrument=admin/DEMO&event_type=MINT&event_type=BALANCE",
+		http.Header{stream.HeaderLastEventID: {"5"}})
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	require.NotNil(t, got)
+	assert.Equal(t, testParty, got.PartyID)
+	require.NotNil(t, got.After)
+	assert.Equal(t, int64(5), *got.After)
+	assert.Equal(t, []indexer.InstrumentKey{{Admin: "admin", ID: "DEMO"}}, got.Filter.Instruments)
+	assert.Equal(t, []indexer.StreamEventType{indexer.StreamEventMint, indexer.StreamEventBalance}, got.Filter.EventTypes)
+
+	// Two events; only the last of the offset carries the ID.
+	events := strings.Split(strings.TrimSpace(string(body)), "\n\n")
+	require.Len(t, events, 2)
+	assert.True(t, strings.HasPrefix(events[0], "event: MINT\ndata: {"), events[0])
+	assert.True(t, strings.HasPrefix(events[1], "event: BALANCE\nid: 6\ndata: {"), events[1])
+}
+
+func TestSSE_ResumeFromQueryParameter(t *testing.T) {
+	srv, svc := newTestServer(t)
+	svc.EXPECT().Subscribe(mock.Anything, mock.MatchedBy(func(req *stream.SubscribeRequest) bool {
+		return req.After != nil && *req.After == 12
+	}), mock.Anything).RunAndReturn(sendOffset6)
+
+	resp := get(t, srv.URL+streamPath+"?last_event_id=12", nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestSSE_InvalidParameters(t *testing.T) {
+	srv, _ := newTestServer(t)
+	for _, q := range []string{"?last_event_id=abc", "?instrument=DEMO", "?event_type=LOCK"} {
+		t.Run(q, func(t *testing.T) {
+			resp := get(t, srv.URL+streamPath+q, nil)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestSSE_ErrorBeforeOpen(t *testing.T) {
+	srv, svc := newTestServer(t)
+	svc.EXPECT().Subscribe(mock.Anything, mock.Anything, mock.Anything).
+		Return(apperr.UnavailableError(nil, "too many streams, retry later"))
+
+	resp := get(t, srv.URL+streamPath, nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestWebSocket_StreamsBatches(t *testing.T) {
+	srv, svc := newTestServer(t)
+	closed := make(chan struct{})
+	svc.EXPECT().Subscribe(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req *stream.SubscribeRequest, sink stream.Sink) error {
+			_ = sendOffset6(ctx, req, sink)
+			// The stream stays open until the client goes away.
+			<-ctx.Done()
+			close(closed)
+			return nil
+		})
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + streamPath + "/ws?last_event_id=5"
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	_, msg, err := conn.ReadMessage()
+	require.NoError(t, err)
+	var batch indexer.StreamBatch
+	require.NoError(t, json.Unmarshal(msg, &batch))
+	assert.Equal(t, int64(6), batch.LedgerOffset)
+	require.Len(t, batch.Events, 2)
+	assert.Equal(t, indexer.StreamEventMint, batch.Events[0].Type)
+
+	require.NoError(t, conn.Close())
+	select {
+	case <-closed:
+	case <-time.After(waitTimeout):
+		t.Fatal("subscription was not canceled when the client disconnected")
+	}
+}
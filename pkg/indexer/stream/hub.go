@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"context"
+	"sync"
+)
+
+// closedCh is returned by Hub.Changed when the awaited offset is already committed.
+var closedCh = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// Hub tells waiting subscribers that the processor committed a new offset.
+//
+// It carries no data — subscribers read what changed from the database — so a
+// slow subscriber never holds up the processor or the others, and a wake-up
+// cannot be lost: Changed compares offsets rather than counting signals.
+type Hub struct {
+	mu      sync.Mutex
+	offset  int64
+	changed chan struct{} // closed and replaced by every Notify
+	done    chan struct{}
+}
+
+// NewHub creates a Hub.
+func NewHub() *Hub {
+	return &Hub{
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Notify records that offset was committed and wakes every waiting subscriber.
+// It never blocks; pass it to engine.WithAfterCommit.
+func (h *Hub) Notify(offset int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.offset = max(h.offset, offset)
+	close(h.changed)
+	h.changed = make(chan struct{})
+}
+
+// Changed returns a channel that is closed once an offset beyond after has
+// been committed. It may also be closed by a commit that does not get that
+// far, so the caller re-checks by calling Changed again.
+func (h *Hub) Changed(after int64) <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.offset > after {
+		return closedCh
+	}
+	return h.changed
+}
+
+// Done returns a channel that is closed when the hub stops, which ends every
+// stream.
+func (h *Hub) Done() <-chan struct{} {
+	return h.done
+}
+
+// Run blocks until ctx is canceled, then stops the hub. Streams must end with
+// the indexer: http.Server.Shutdown does not close SSE or hijacked WebSocket
+// connections, so without this it would wait for them until its timeout.
+func (h *Hub) Run(ctx context.Context) error {
+	<-ctx.Done()
+	close(h.done)
+	return nil
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const streamServiceName = "StreamService"
+
+// logService wraps Service with automatic logging of all method calls.
+type logService struct {
+	svc    Service
+	logger *zap.Logger
+}
+
+// NewLog creates a logging decorator for the stream Service.
+func NewLog(svc Service, logger *zap.Logger) Service {
+	return &logService{svc: svc, logger: logger}
+}
+
+func (ls *logService) Subscribe(ctx context.Context, req *SubscribeRequest, sink Sink) (err error) {
+	start := time.Now()
+	fields := []zap.Field{
+		zap.String("service", streamServiceName),
+		zap.String("party_id", req.PartyID),
+	}
+	if req.After != nil {
+		fields = append(fields, zap.Int64("after", *req.After))
+	}
+	ls.logger.Info("Subscribe started", fields...)
+	defer func() {
+		fields = append(fields, zap.Duration("duration", time.Since(start)))
+		if err != nil {
+			ls.logger.Error("Subscribe failed", append(fields, zap.Error(err))...)
+		} else {
+			ls.logger.Info("Subscribe completed", fields...)
+		}
+	}()
+	return ls.svc.Subscribe(ctx, req, sink)
+}
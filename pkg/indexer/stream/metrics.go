@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package stream
+
+import (
+	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds Prometheus collectors for the stream Service.
+type Metrics struct {
+	// ActiveStreams is the number of open streams.
+	ActiveStreams prometheus.Gauge
+
+	// EventsSentTotal counts events written to subscribers.
+	EventsSentTotal prometheus.Counter
+
+	// DisconnectsTotal counts ended streams by reason:
+	//   reason=client   – the client went away
+	//   reason=write    – a write failed or timed out (slow or dead client)
+	//   reason=shutdown – the indexer stopped
+	//   reason=error    – reading the changes failed
+	DisconnectsTotal *prometheus.CounterVec
+}
+
+// NewMetrics registers stream metrics against the given registerer.
+func NewMetrics(reg sharedmetrics.NamespacedRegisterer) *Metrics {
+	f := promauto.With(reg)
+	ns := reg.Namespace()
+	sub := "stream"
+	return &Metrics{
+		ActiveStreams: f.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "active",
+			Help: "Open SSE and WebSocket event streams",
+		}),
+
+		EventsSentTotal: f.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "events_sent_total",
+			Help: "Events written to stream subscribers",
+		}),
+
+		DisconnectsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "disconnects_total",
+			Help: "Ended event streams, labeled by reason (client, write, shutdown, error)",
+		}, []string{"reason"}),
+	}
+}
+
+// NewNopMetrics returns a Metrics instance backed by a throwaway registry.
+// Use in tests where metric values are not asserted.
+func NewNopMetrics() *Metrics {
+	return NewMetrics(sharedmetrics.WithNamespace(prometheus.NewRegistry(), "nop"))
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package stream pushes the changes concerning a party — token events, transfer
+// status transitions and balance changes — to live subscribers over SSE or
+// WebSocket.
+//
+// A subscription is a cursor over the indexed tables rather than a fan-out of
+// in-memory events: after every processor commit the Hub wakes each
+// subscriber, which reads what was committed past its cursor. Resuming from a
+// ledger offset and following live changes are therefore the same code path,
+// nothing is buffered per subscriber, and a subscriber that falls behind
+// catches up from the database instead of being sent an unbounded backlog.
+//
+// Changes are delivered in offset order, one whole offset at a time, so the
+// offset of the last batch a client processed is a gap-free resume point.
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+// Disconnect reasons reported by Metrics.DisconnectsTotal.
+const (
+	reasonClient   = "client"   // the client went away or canceled
+	reasonWrite    = "write"    // a write failed or timed out (slow or dead client)
+	reasonShutdown = "shutdown" // the indexer is stopping
+	reasonError    = "error"    // reading the changes failed
+)
+
+// Store is the read interface the Service needs.
+//
+//go:generate mockery --name Store --output mocks --outpkg mocks --filename mock_store.go --with-expecter
+type Store interface {
+	// LatestOffset returns the offset of the last committed processor batch.
+	LatestOffset(ctx context.Context) (int64, error)
+	// StreamEvents returns the changes concerning partyID committed after offset
+	// after, in offset order and in whole offsets, reading at most limit rows of
+	// each kind of change.
+	StreamEvents(ctx context.Context, partyID string, after int64, limit int) ([]*indexer.StreamEvent, error)
+}
+
+// Sink writes a subscription to its client. Calls are never concurrent.
+//
+//go:generate mockery --name Sink --output mocks --outpkg mocks --filename mock_sink.go --with-expecter
+type Sink interface {
+	// Open is called once the subscription is accepted, before anything else.
+	Open() error
+	// Send writes the changes of one offset.
+	Send(batch *indexer.StreamBatch) error
+	// Heartbeat writes a keepalive.
+	Heartbeat() error
+}
+
+// SubscribeRequest describes a subscription. After is the offset to resume
+// after — the ID of the last event the client received; when nil, only
+// changes committed from now on are sent.
+type SubscribeRequest struct {
+	PartyID string
+	After   *int64
+	Filter  indexer.StreamFilter
+}
+
+// Service streams a party's changes to subscribers.
+//
+//go:generate mockery --name Service --output mocks --outpkg mocks --filename mock_service.go --with-expecter
+type Service interface {
+	// Subscribe streams the changes matching req to sink until ctx is canceled,
+	// the indexer stops or a write fails, all of which return nil. Errors
+	// returned before sink.Open are meant for the client (a bad request, or 503
+	// when at capacity); later ones only for the log.
+	Subscribe(ctx context.Context, req *SubscribeRequest, sink Sink) error
+}
+
+type svc struct {
+	store   Store
+	hub     *Hub
+	cfg     Config
+	metrics *Metrics
+	logger  *zap.Logger
+
+	active atomic.Int64
+}
+
+// NewService creates a Service that is woken by hub.
+//
+// metrics receives Prometheus observations for active streams and disconnects.
+// Pass NewNopMetrics() in tests where metric values aren't asserted.
+func NewService(store Store, hub *Hub, cfg *Config, metrics *Metrics, logger *zap.Logger) Service {
+	if metrics == nil {
+		metrics = NewNopMetrics()
+	}
+	return &svc{store: store, hub: hub, cfg: *cfg, metrics: metrics, logger: logger}
+}
+
+func (s *svc) Subscribe(ctx context.Context, req *SubscribeRequest, sink Sink) error {
+	if req.PartyID == "" {
+		return apperrors.BadRequestError(nil, "party ID is required")
+	}
+	if req.After != nil && *req.After < 0 {
+		return apperrors.BadRequestError(nil, "last event ID must be an offset >= 0")
+	}
+	for _, t := range req.Filter.EventTypes {
+		if !indexer.ValidStreamEventType(t) {
+			return apperrors.BadRequestError(nil, fmt.Sprintf("unknown event type %q", t))
+		}
+	}
+
+	if s.active.Add(1) > int64(s.cfg.MaxSubscribers) {
+		s.active.Add(-1)
+		return apperrors.UnavailableError(nil, "too many streams, retry later")
+	}
+	defer s.active.Add(-1)
+
+	var cursor int64
+	if req.After != nil {
+		cursor = *req.After
+	} else {
+		latest, err := s.store.LatestOffset(ctx)
+		if err != nil {
+			return fmt.Errorf("get latest offset: %w", err)
+		}
+		cursor = latest
+	}
+
+	if err := sink.Open(); err != nil {
+		s.metrics.DisconnectsTotal.WithLabelValues(reasonWrite).Inc()
+		return nil
+	}
+	s.metrics.ActiveStreams.Inc()
+	defer s.metrics.ActiveStreams.Dec()
+
+	reason, err := s.follow(ctx, req, cursor, sink)
+	s.metrics.DisconnectsTotal.WithLabelValues(reason).Inc()
+	return err
+}
+
+// follow sends every change past cursor, then waits for the next commit, until
+// the subscription ends. It returns why it ended and, for reasonError, the error.
+func (s *svc) follow(ctx context.Context, req *SubscribeRequest, cursor int64, sink Sink) (string, error) {
+	heartbeat := time.NewTicker(s.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		// Read the committed offset before the changes: everything up to it is
+		// then visible to the query, so an empty result lets the cursor skip to it.
+		committed, err := s.store.LatestOffset(ctx)
+		if err != nil {
+			return s.failed(ctx, fmt.Errorf("get latest offset: %w", err))
+		}
+		events, err := s.store.StreamEvents(ctx, req.PartyID, cursor, s.cfg.PageSize)
+		if err != nil {
+			return s.failed(ctx, err)
+		}
+		if len(events) > 0 {
+			if err := s.send(events, &req.Filter, sink); err != nil {
+				return s.writeFailed(ctx, err)
+			}
+			cursor = events[len(events)-1].LedgerOffset
+			// More may have been committed past the page; read again before waiting.
+			continue
+		}
+		cursor = max(cursor, committed)
+
+		for waiting := true; waiting; {
+			select {
+			case <-ctx.Done():
+				return reasonClient, nil
+			case <-s.hub.Done():
+				return reasonShutdown, nil
+			case <-heartbeat.C:
+				if err := sink.Heartbeat(); err != nil {
+					return s.writeFailed(ctx, err)
+				}
+			case <-s.hub.Changed(cursor):
+				waiting = false
+			}
+		}
+	}
+}
+
+// send groups events by offset and sends each offset's matching events as one
+// batch. Offsets with no match are skipped.
+func (s *svc) send(events []*indexer.StreamEvent, filter *indexer.StreamFilter, sink Sink) error {
+	for start := 0; start < len(events); {
+		end := start
+		batch := &indexer.StreamBatch{LedgerOffset: events[start].LedgerOffset}
+		for ; end < len(events) && events[end].LedgerOffset == batch.LedgerOffset; end++ {
+			if filter.Matches(events[end]) {
+				batch.Events = append(batch.Events, events[end])
+			}
+		}
+		start = end
+		if len(batch.Events) == 0 {
+			continue
+		}
+		if err := sink.Send(batch); err != nil {
+			return err
+		}
+		s.metrics.EventsSentTotal.Add(float64(len(batch.Events)))
+	}
+	return nil
+}
+
+// failed classifies a store error: one caused by the client going away is a
+// normal disconnect.
+func (s *svc) failed(ctx context.Context, err error) (string, error) {
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return reasonClient, nil
+	}
+	return reasonError, err
+}
+
+// writeFailed classifies a failed write: after the client went away it is a
+// normal disconnect, otherwise the client was too slow or its connection died.
+func (s *svc) writeFailed(ctx context.Context, err error) (string, error) {
+	if ctx.Err() != nil {
+		return reasonClient, nil
+	}
+	s.logger.Debug("stream write failed, disconnecting", zap.Error(err))
+	return reasonWrite, nil
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/stream"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/stream/mocks"
+)
+
+const (
+	testParty   = "alice::1220"
+	waitTimeout = 2 * time.Second
+)
+
+var testCfg = stream.Config{
+	HeartbeatInterval: time.Hour,
+	WriteTimeout:      time.Second,
+	PageSize:          100,
+	MaxSubscribers:    10,
+}
+
+// chanSink records what a subscription writes.
+type chanSink struct {
+	opened  chan struct{}
+	batches chan *indexer.StreamBatch
+	sendErr error
+}
+
+func newChanSink() *chanSink {
+	return &chanSink{opened: make(chan struct{}), batches: make(chan *indexer.StreamBatch, 16)}
+}
+
+func (s *chanSink) Open() error { close(s.opened); return nil }
+
+func (s *chanSink) Send(b *indexer.StreamBatch) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.batches <- b
+	return nil
+}
+
+func (s *chanSink) Heartbeat() error { return nil }
+
+func (s *chanSink) next(t *testing.T) *indexer.StreamBatch {
+	t.Helper()
+	select {
+	case b := <-s.batches:
+		return b
+	case <-time.After(waitTimeout):
+		t.Fatal("timed out waiting for a batch")
+		return nil
+	}
+}
+
+func newSvc(t *testing.T, cfg stream.Config) (stream.Service, *mocks.Store, *stream.Hub) {
+	t.Helper()
+	store := mocks.NewStore(t)
+	hub := stream.NewHub()
+	return stream.NewService(store, hub, &cfg, stream.NewNopMetrics(), zap.NewNop()), store, hub
+}
+
+// subscribe runs Subscribe in the background and returns its result channel.
+func subscribe(ctx context.Context, svc stream.Service, req *stream.SubscribeRequest, sink stream.Sink) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- svc.Subscribe(ctx, req, sink) }()
+	return done
+}
+
+func waitErr(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(waitTimeout):
+		t.Fatal("timed out waiting for Subscribe to return")
+		return nil
+	}
+}
+
+func mintAt(offset int64, admin, id string) *indexer.StreamEvent {
+	return &indexer.StreamEvent{
+		Type:         indexer.StreamEventMint,
+		LedgerOffset: offset,
+		Event:        &indexer.ParsedEvent{InstrumentAdmin: admin, InstrumentID: id, LedgerOffset: offset},
+	}
+}
+
+func balanceAt(offset int64) *indexer.StreamEvent {
+	return &indexer.StreamEvent{
+		Type:         indexer.StreamEventBalance,
+		LedgerOffset: offset,
+		Balance:      &indexer.Balance{PartyID: testParty, InstrumentAdmin: "admin", InstrumentID: "DEMO"},
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestSubscribe_ResumesThenFollowsCommits(t *testing.T) {
+	svc, store, hub := newSvc(t, testCfg)
+	var committed atomic.Int64
+	committed.Store(7)
+	store.EXPECT().LatestOffset(mock.Anything).RunAndReturn(func(context.Context) (int64, error) {
+		return committed.Load(), nil
+	})
+	// Backlog after 5: offset 6 (two changes) and offset 7, whose only change the
+	// filter drops.
+	store.EXPECT().StreamEvents(mock.Anything, testParty, int64(5), testCfg.PageSize).
+		Return([]*indexer.StreamEvent{mintAt(6, "admin", "DEMO"), balanceAt(6), mintAt(7, "admin", "OTHER")}, nil).Once()
+	store.EXPECT().StreamEvents(mock.Anything, testParty, int64(7), testCfg.PageSize).Return(nil, nil).Once()
+	// Live: offset 9 is committed after the backlog was sent.
+	store.EXPECT().StreamEvents(mock.Anything, testParty, int64(7), testCfg.PageSize).
+		Return([]*indexer.StreamEvent{balanceAt(9)}, nil).Once()
+	store.EXPECT().StreamEvents(mock.Anything, testParty, int64(9), testCfg.PageSize).Return(nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := newChanSink()
+	done := subscribe(ctx, svc, &stream.SubscribeRequest{
+		PartyID: testParty,
+		After:   ptr(int64(5)),
+		Filter:  indexer.StreamFilter{Instruments: []indexer.InstrumentKey{{Admin: "admin", ID: "DEMO"}}},
+	}, sink)
+
+	b := sink.next(t)
+	assert.Equal(t, int64(6), b.LedgerOffset)
+	assert.Len(t, b.Events, 2)
+
+	committed.Store(9)
+	hub.Notify(9)
+	b = sink.next(t)
+	assert.Equal(t, int64(9), b.LedgerOffset)
+	assert.Len(t, b.Events, 1)
+
+	cancel()
+	require.NoError(t, waitErr(t, done))
+	assert.Empty(t, sink.batches, "offset 7 must not be sent: none of its changes match")
+}
+
+func TestSubscribe_StartsAtLatestOffsetWithoutResume(t *testing.T) {
+	svc, store, _ := newSvc(t, testCfg)
+	store.EXPECT().LatestOffset(mock.Anything).Return(42, nil)
+	store.EXPECT().StreamEvents(mock.Anything, testParty, int64(42), testCfg.PageSize).Return(nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := newChanSink()
+	done := subscribe(ctx, svc, &stream.SubscribeRequest{PartyID: testParty}, sink)
+	<-sink.opened
+	cancel()
+	require.NoError(t, waitErr(t, done))
+}
+
+func TestSubscribe_HubStopEndsStream(t *testing.T) {
+	svc, store, hub := newSvc(t, testCfg)
+	store.EXPECT().LatestOffset(mock.Anything).Return(1, nil)
+	store.EXPECT().StreamEvents(mock.Anything, testParty, mock.Anything, testCfg.PageSize).Return(nil, nil)
+
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	go func() { _ = hub.Run(hubCtx) }()
+
+	sink := newChanSink()
+	done := subscribe(context.Background(), svc, &stream.SubscribeRequest{PartyID: testParty}, sink)
+	<-sink.opened
+	stopHub()
+	require.NoError(t, waitErr(t, done))
+}
+
+func TestSubscribe_WriteFailureDisconnects(t *testing.T) {
+	svc, store, _ := newSvc(t, testCfg)
+	store.EXPECT().LatestOffset(mock.Anything).Return(1, nil)
+	store.EXPECT().StreamEvents(mock.Anything, testParty, int64(0), testCfg.PageSize).
+		Return([]*indexer.StreamEvent{balanceAt(1)}, nil)
+
+	sink := newChanSink()
+	sink.sendErr = errors.New("broken pipe")
+	err := svc.Subscribe(context.Background(), &stream.SubscribeRequest{PartyID: testParty, After: ptr(int64(0))}, sink)
+	require.NoError(t, err)
+}
+
+func TestSubscribe_StoreErrorIsReturned(t *testing.T) {
+	svc, store, _ := newSvc(t, testCfg)
+	storeErr := errors.New("db down")
+	store.EXPECT().LatestOffset(mock.Anything).Return(1, nil)
+	store.EXPECT().StreamEvents(mock.Anything, testParty, int64(0), testCfg.PageSize).Return(nil, storeErr)
+
+	err := svc.Subscribe(context.Background(), &stream.SubscribeRequest{PartyID: testParty, After: ptr(int64(0))}, newChanSink())
+	require.ErrorIs(t, err, storeErr)
+}
+
+func TestSubscribe_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  stream.SubscribeRequest
+	}{
+		{"missing party", stream.SubscribeRequest{}},
+		{"negative offset", stream.SubscribeRequest{PartyID: testParty, After: ptr(int64(-1))}},
+		{"unknown event type", stream.SubscribeRequest{
+			PartyID: testParty,
+			Filter:  indexer.StreamFilter{EventTypes: []indexer.StreamEventType{"LOCK"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newSvc(t, testCfg)
+			err := svc.Subscribe(context.Background(), &tt.req, newChanSink())
+			assert.True(t, apperr.Is(err, apperr.CategoryDataError), "got %v", err)
+		})
+	}
+}
+
+func TestSubscribe_RejectsBeyondMaxSubscribers(t *testing.T) {
+	cfg := testCfg
+	cfg.MaxSubscribers = 1
+	svc, store, _ := newSvc(t, cfg)
+	store.EXPECT().LatestOffset(mock.Anything).Return(1, nil)
+	store.EXPECT().StreamEvents(mock.Anything, testParty, mock.Anything, cfg.PageSize).Return(nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := newChanSink()
+	done := subscribe(ctx, svc, &stream.SubscribeRequest{PartyID: testParty}, first)
+	<-first.opened
+
+	err := svc.Subscribe(context.Background(), &stream.SubscribeRequest{PartyID: testParty}, newChanSink())
+	assert.True(t, apperr.Is(err, apperr.CategoryRecovering), "got %v", err)
+
+	cancel()
+	require.NoError(t, waitErr(t, done))
+}
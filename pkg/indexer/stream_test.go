@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+func TestStreamFilter_Matches(t *testing.T) {
+	demo := indexer.InstrumentKey{Admin: "admin::1220", ID: "DEMO"}
+	other := indexer.InstrumentKey{Admin: "admin::1220", ID: "OTHER"}
+
+	mint := &indexer.StreamEvent{
+		Type:  indexer.StreamEventMint,
+		Event: &indexer.ParsedEvent{InstrumentAdmin: demo.Admin, InstrumentID: demo.ID},
+	}
+	status := &indexer.StreamEvent{
+		Type:     indexer.StreamEventTransferStatus,
+		Transfer: &indexer.Transfer{InstrumentAdmin: other.Admin, InstrumentID: other.ID},
+	}
+	balance := &indexer.StreamEvent{
+		Type:    indexer.StreamEventBalance,
+		Balance: &indexer.Balance{InstrumentAdmin: demo.Admin, InstrumentID: demo.ID},
+	}
+
+	tests := []struct {
+		name   string
+		filter indexer.StreamFilter
+		e      *indexer.StreamEvent
+		want   bool
+	}{
+		{"empty filter", indexer.StreamFilter{}, status, true},
+		{"instrument of event", indexer.StreamFilter{Instruments: []indexer.InstrumentKey{demo}}, mint, true},
+		{"instrument of balance", indexer.StreamFilter{Instruments: []indexer.InstrumentKey{demo}}, balance, true},
+		{"instrument mismatch", indexer.StreamFilter{Instruments: []indexer.InstrumentKey{demo}}, status, false},
+		{
+			"type match",
+			indexer.StreamFilter{EventTypes: []indexer.StreamEventType{indexer.StreamEventBalance}},
+			balance, true,
+		},
+		{
+			"type mismatch",
+			indexer.StreamFilter{EventTypes: []indexer.StreamEventType{indexer.StreamEventBalance}},
+			mint, false,
+		},
+		{
+			"both must match",
+			indexer.StreamFilter{
+				Instruments: []indexer.InstrumentKey{other},
+				EventTypes:  []indexer.StreamEventType{indexer.StreamEventMint},
+			},
+			mint, false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.filter.Matches(tc.e))
+		})
+	}
+}
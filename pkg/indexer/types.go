@@ -29,6 +29,11 @@ const (
 	// "pending" on the TransferOffer CREATE and on its ARCHIVE becomes "completed"
 	// (accepted), "canceled" (sender withdrew), or "rejected" (receiver declined).
 	TransferKindOffer = "offer"
+	// TransferKindAllocation is one leg of a delivery-versus-payment settlement,
+	// e.g. a Splice AmuletAllocation. It starts "pending" on the allocation
+	// CREATE and on its ARCHIVE becomes "completed" (executed) or "canceled"
+	// (canceled or withdrawn).
+	TransferKindAllocation = "allocation"
 )
 
 // TransferRole selects which side of a transfer a party query matches.
@@ -42,9 +47,32 @@ const (
 
 // TransferQuery filters a party's transfers by role and status.
 // A zero Role defaults to receiver; a zero Status means "all statuses".
+// The HistoryFilter's time range applies to CreatedAt, and its Counterparty to
+// the side Role does not select.
 type TransferQuery struct {
 	Role   TransferRole
 	Status string // "" = all; pending / expired / completed / canceled / rejected
+	HistoryFilter
+}
+
+// HistoryFilter narrows event and transfer history, e.g. for reconciliation.
+// Zero-value fields are ignored; ranges are inclusive except Until.
+type HistoryFilter struct {
+	// Counterparty is the other side of the listed party's events or transfers.
+	// Without a listed party it matches either side.
+	Counterparty string
+
+	FromOffset int64
+	ToOffset   int64
+
+	// Since and Until bound the ledger effective time of events and the
+	// creation time of transfers: Since <= t < Until.
+	Since time.Time
+	Until time.Time
+
+	// MinAmount and MaxAmount are decimal strings.
+	MinAmount string
+	MaxAmount string
 }
 
 // Transfer is a token transfer, generalized across all tokens and both transfer
@@ -56,7 +84,7 @@ type TransferQuery struct {
 // derived at read time and never stored.
 type Transfer struct {
 	ContractID      string     `json:"contract_id"`
-	Kind            string     `json:"kind"`   // "direct" | "offer"
+	Kind            string     `json:"kind"`   // "direct" | "offer" | "allocation"
 	Status          string     `json:"status"` // "pending" | "expired" | "completed" | "canceled"
 	FromPartyID     string     `json:"from_party_id"`
 	ToPartyID       string     `json:"to_party_id"`
@@ -69,7 +97,7 @@ type Transfer struct {
 	CreatedAt       time.Time  `json:"created_at"`
 
 	// Archived is a decode-time signal only — not persisted. Set by the offer
-	// decoder on an ARCHIVED event so the processor finalizes the transfer with
+	// and allocation decoders on an ARCHIVED event so the processor finalizes the transfer with
 	// the terminal Status the decoder derived from the archiving choice.
 	Archived bool `json:"-"`
 }
@@ -203,6 +231,37 @@ type Balance struct {
 	Amount          string `json:"amount"`           // current balance, decimal string ≥ 0
 }
 
+// AsOf selects a point in ledger history for balance and supply queries: the
+// state after the last transaction at or before Offset, or — when Offset is
+// zero — the last transaction whose effective time is at or before Time.
+type AsOf struct {
+	Offset int64
+	Time   time.Time
+}
+
+// HistoricalBalance is a party's balance at a point in ledger history.
+// LedgerOffset and EffectiveTime identify the transaction that last changed the
+// balance at or before the requested point; both are zero when the party had
+// never held the token by then (Amount is "0").
+type HistoricalBalance struct {
+	Balance
+	LedgerOffset  int64      `json:"ledger_offset"`
+	EffectiveTime *time.Time `json:"effective_time,omitempty"`
+}
+
+// HistoricalSupply is a token's total supply and holder count at a point in
+// ledger history. LedgerOffset and EffectiveTime identify the transaction that
+// last changed either value at or before the requested point; both are zero
+// when the token had no recorded history by then.
+type HistoricalSupply struct {
+	InstrumentAdmin string     `json:"instrument_admin"`
+	InstrumentID    string     `json:"instrument_id"`
+	TotalSupply     string     `json:"total_supply"`
+	HolderCount     int64      `json:"holder_count"`
+	LedgerOffset    int64      `json:"ledger_offset"`
+	EffectiveTime   *time.Time `json:"effective_time,omitempty"`
+}
+
 // FilterMode controls which token instruments the Parser processes.
 type FilterMode int
 
@@ -218,10 +277,26 @@ const (
 	FilterModeWhitelist
 )
 
-// Pagination holds a 1-based page number and page size for list queries.
+// Pagination selects one page of a list query, either by number or by cursor.
+//
+// By number, Page is 1-based and the page is read with OFFSET, so rows written
+// between two requests shift later pages. With a Cursor, Page is ignored and the
+// page starts right after (or, going backward, ends right before) the row the
+// cursor was taken from, which is stable however many rows are added and stays
+// fast deep into large tables. See Page.NextCursor.
 type Pagination struct {
-	Page  int
-	Limit int
+	Page   int
+	Limit  int
+	Cursor *Cursor
+	// WithTotal requests the total count with a Cursor. Counting every match is
+	// what makes deep OFFSET pages slow, so cursor pages skip it by default;
+	// numbered pages always count.
+	WithTotal bool
+}
+
+// CountTotal reports whether the total number of matches is to be counted.
+func (p Pagination) CountTotal() bool {
+	return p.Cursor == nil || p.WithTotal
 }
 
 // EventFilter narrows event list queries. Zero-value fields are ignored by the store.
@@ -230,12 +305,26 @@ type EventFilter struct {
 	InstrumentID    string
 	PartyID         string
 	EventType       EventType // empty = all types
+	ExternalTxID    string    // bridge EVM transaction hash
+	HistoryFilter
 }
 
+// TotalUnknown is the Page.Total of a cursor page whose total was not counted.
+const TotalUnknown = -1
+
 // Page is the generic paginated response envelope.
+//
+// Lists with a stable sort key also return cursors: NextCursor continues after
+// the last item and PrevCursor goes back from the first. A cursor is omitted
+// when there is nothing that way — though a full last page still returns a
+// NextCursor, which then yields an empty page.
 type Page[T any] struct {
-	Items []T   `json:"items"`
-	Total int64 `json:"total"`
-	Page  int   `json:"page"`
-	Limit int   `json:"limit"`
+	Items []T `json:"items"`
+	// Total is the number of matches, or TotalUnknown when not counted (see
+	// Pagination.WithTotal).
+	Total      int64   `json:"total"`
+	Page       int     `json:"page"` // 0 for cursor pages
+	Limit      int     `json:"limit"`
+	NextCursor *Cursor `json:"next_cursor,omitempty"`
+	PrevCursor *Cursor `json:"prev_cursor,omitempty"`
 }
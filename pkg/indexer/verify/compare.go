@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+type balanceKey struct {
+	partyID string
+	token   indexer.InstrumentKey
+}
+
+// comparison holds the indexed and ledger amounts of the covered tokens.
+// Missing balances are zero on either side.
+type comparison struct {
+	tokens   map[indexer.InstrumentKey]*indexer.Token
+	indexed  map[balanceKey]decimal.Decimal
+	ledger   map[balanceKey]decimal.Decimal
+	supplies map[indexer.InstrumentKey]decimal.Decimal // sum of the ledger holdings
+}
+
+func newComparison(
+	state *indexer.IndexedState, holdings []*token.Holding, tokens map[indexer.InstrumentKey]*indexer.Token,
+) (*comparison, error) {
+	c := &comparison{
+		tokens:   tokens,
+		indexed:  make(map[balanceKey]decimal.Decimal),
+		ledger:   make(map[balanceKey]decimal.Decimal),
+		supplies: make(map[indexer.InstrumentKey]decimal.Decimal),
+	}
+	for _, b := range state.Balances {
+		k := balanceKey{partyID: b.PartyID, token: indexer.InstrumentKey{Admin: b.InstrumentAdmin, ID: b.InstrumentID}}
+		if _, ok := tokens[k.token]; !ok {
+			continue
+		}
+		amount, err := decimal.NewFromString(b.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("parse indexed balance %q of %s: %w", b.Amount, b.PartyID, err)
+		}
+		c.indexed[k] = amount
+	}
+	for _, h := range holdings {
+		k := balanceKey{partyID: h.Owner, token: indexer.InstrumentKey{Admin: h.InstrumentAdmin, ID: h.InstrumentID}}
+		if _, ok := tokens[k.token]; !ok {
+			continue
+		}
+		amount, err := decimal.NewFromString(h.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("parse amount %q of holding %s: %w", h.Amount, h.ContractID, err)
+		}
+		c.ledger[k] = c.ledger[k].Add(amount)
+		c.supplies[k.token] = c.supplies[k.token].Add(amount)
+	}
+	return c, nil
+}
+
+// parties returns the number of parties with a covered balance on either side.
+func (c *comparison) parties() int {
+	seen := make(map[string]struct{})
+	for k := range c.indexed {
+		seen[k.partyID] = struct{}{}
+	}
+	for k := range c.ledger {
+		seen[k.partyID] = struct{}{}
+	}
+	return len(seen)
+}
+
+// differing returns the balances whose indexed and ledger amounts differ,
+// ordered by token and party.
+func (c *comparison) differing() []balanceKey {
+	keys := slices.Collect(maps.Keys(c.indexed))
+	for k := range c.ledger {
+		if _, ok := c.indexed[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	keys = slices.DeleteFunc(keys, func(k balanceKey) bool {
+		return c.indexed[k].Equal(c.ledger[k])
+	})
+	slices.SortFunc(keys, func(a, b balanceKey) int {
+		return cmp.Or(compareInstruments(a.token, b.token), cmp.Compare(a.partyID, b.partyID))
+	})
+	return keys
+}
+
+func (c *comparison) balanceDrifts() []indexer.Drift {
+	keys := c.differing()
+	drifts := make([]indexer.Drift, 0, len(keys))
+	for _, k := range keys {
+		drifts = append(drifts, indexer.Drift{
+			Kind:            indexer.DriftBalance,
+			PartyID:         k.partyID,
+			InstrumentAdmin: k.token.Admin,
+			InstrumentID:    k.token.ID,
+			Indexed:         c.indexed[k].String(),
+			Ledger:          c.ledger[k].String(),
+		})
+	}
+	return drifts
+}
+
+// balanceChanges returns the changes that set every differing balance to its
+// ledger amount.
+func (c *comparison) balanceChanges() []indexer.BalanceChange {
+	keys := c.differing()
+	changes := make([]indexer.BalanceChange, 0, len(keys))
+	for _, k := range keys {
+		changes = append(changes, indexer.BalanceChange{
+			PartyID:         k.partyID,
+			InstrumentAdmin: k.token.Admin,
+			InstrumentID:    k.token.ID,
+			Before:          c.indexed[k].String(),
+			After:           c.ledger[k].String(),
+		})
+	}
+	return changes
+}
+
+// supplyDrifts compares every covered token's total supply with the sum of its
+// ledger holdings. Only meaningful when the holdings are all of the token's.
+func (c *comparison) supplyDrifts() []indexer.Drift {
+	var drifts []indexer.Drift
+	for _, k := range slices.SortedFunc(maps.Keys(c.tokens), compareInstruments) {
+		t := c.tokens[k]
+		indexed, err := decimal.NewFromString(t.TotalSupply)
+		if err == nil && indexed.Equal(c.supplies[k]) {
+			continue
+		}
+		drifts = append(drifts, indexer.Drift{
+			Kind:            indexer.DriftSupply,
+			InstrumentAdmin: k.Admin,
+			InstrumentID:    k.ID,
+			Indexed:         t.TotalSupply,
+			Ledger:          c.supplies[k].String(),
+		})
+	}
+	return drifts
+}
+
+func compareInstruments(a, b indexer.InstrumentKey) int {
+	return cmp.Or(cmp.Compare(a.Admin, b.Admin), cmp.Compare(a.ID, b.ID))
+}
+
+// markRepaired marks the balance drifts that changes repaired.
+func markRepaired(drifts []indexer.Drift, changes []indexer.BalanceChange) {
+	repaired := make(map[balanceKey]bool, len(changes))
+	for _, ch := range changes {
+		repaired[balanceKey{partyID: ch.PartyID, token: indexer.InstrumentKey{Admin: ch.InstrumentAdmin, ID: ch.InstrumentID}}] = true
+	}
+	for i := range drifts {
+		d := &drifts[i]
+		if d.Kind == indexer.DriftBalance &&
+			repaired[balanceKey{partyID: d.PartyID, token: indexer.InstrumentKey{Admin: d.InstrumentAdmin, ID: d.InstrumentID}}] {
+			d.Repaired = true
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/identity"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+)
+
+// Check modes.
+const (
+	// ModeSample checks the balances of SampleSize parties picked at random.
+	ModeSample = "sample"
+	// ModeFull checks every balance and total supply of the tokens the issuer
+	// party administers.
+	ModeFull = "full"
+)
+
+// Config configures the drift verifier. Omitting the block disables both the
+// scheduled checks and the drift admin API.
+type Config struct {
+	// Interval is the time between scheduled checks.
+	Interval time.Duration `yaml:"interval" default:"10m"`
+	// Mode is the kind of scheduled check: "sample" or "full".
+	Mode string `yaml:"mode" default:"sample" validate:"oneof=sample full"`
+	// SampleSize is the number of parties a sample check compares.
+	SampleSize int `yaml:"sample_size" default:"50" validate:"min=1"`
+	// AutoRepair overwrites drifted balances with the ledger's amounts after
+	// every scheduled check. Supplies are reported but never repaired.
+	AutoRepair bool `yaml:"auto_repair"`
+	// DomainID and IssuerParty are propagated to Identity and Token, as in the
+	// api-server's canton block. IssuerParty administers the tokens a full
+	// check covers.
+	DomainID    string `yaml:"domain_id" validate:"required"`
+	IssuerParty string `yaml:"issuer_party" validate:"required"`
+	// Identity and Token configure the Canton token client the ledger holdings
+	// are read with. An empty user_id is resolved from the ledger auth token.
+	Identity *identity.Config `yaml:"identity" validate:"required"`
+	Token    *token.Config    `yaml:"token" validate:"required"`
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const (
+	maxRequestBodyBytes = 1 << 20 // 1MB
+
+	// defaultLimit / maxLimit match the indexer admin API's pagination caps.
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// HTTP wraps the Service to provide HTTP endpoints.
+type HTTP struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// RegisterPrivateRoutes registers the drift admin API on the given chi router,
+// under /indexer/v1/admin/drift. A check reads holdings from the ledger and a
+// repair pauses the processor, so the routes are meant for operators only.
+func RegisterPrivateRoutes(r chi.Router, svc Service, logger *zap.Logger) {
+	h := &HTTP{service: svc, logger: logger}
+
+	r.Route("/indexer/v1/admin/drift", func(r chi.Router) {
+		r.Get("/", apphttp.HandleError(h.listDrift))
+		r.Post("/check", apphttp.HandleError(h.check))
+	})
+}
+
+// listDrift serves recorded drifts, newest first. ?party= restricts them to
+// one party.
+func (h *HTTP) listDrift(w http.ResponseWriter, r *http.Request) error {
+	p, err := parsePagination(r)
+	if err != nil {
+		return err
+	}
+	page, err := h.service.ListDrift(r.Context(), r.URL.Query().Get("party"), p)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, page)
+	return nil
+}
+
+// check runs a drift check. An empty body checks a random sample of parties.
+func (h *HTTP) check(w http.ResponseWriter, r *http.Request) error {
+	var req CheckRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		return apperrors.BadRequestError(err, "invalid JSON")
+	}
+	report, err := h.service.Check(r.Context(), &req)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, report)
+	return nil
+}
+
+func parsePagination(r *http.Request) (indexer.Pagination, error) {
+	p := indexer.Pagination{Page: 1, Limit: defaultLimit}
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		v, err := strconv.Atoi(pageStr)
+		if err != nil || v < 1 {
+			return p, apperrors.BadRequestError(nil, "page must be an integer >= 1")
+		}
+		p.Page = v
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil || v < 1 || v > maxLimit {
+			return p, apperrors.BadRequestError(nil, "limit must be an integer between 1 and 200")
+		}
+		p.Limit = v
+	}
+	return p, nil
+}
+
+func (h *HTTP) writeJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to write JSON response", zap.Error(err))
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/verify"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/verify/mocks"
+)
+
+const basePath = "/indexer/v1/admin/drift"
+
+func newTestServer(t *testing.T) (*httptest.Server, *mocks.Service) {
+	t.Helper()
+	svc := mocks.NewService(t)
+	r := chi.NewRouter()
+	verify.RegisterPrivateRoutes(r, svc, zap.NewNop())
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, svc
+}
+
+func do(t *testing.T, srv *httptest.Server, method, path string, body io.Reader) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, srv.URL+path, body)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestHTTP_ListDrift(t *testing.T) {
+	t.Run("filters by party", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		want := &indexer.Page[*indexer.Drift]{
+			Items: []*indexer.Drift{{ID: 4, Kind: indexer.DriftBalance, PartyID: alice, Indexed: "1", Ledger: "2"}},
+			Total: 1, Page: 2, Limit: 5,
+		}
+		svc.EXPECT().ListDrift(mock.Anything, alice, indexer.Pagination{Page: 2, Limit: 5}).Return(want, nil)
+
+		resp := do(t, srv, http.MethodGet, basePath+"/?party="+alice+"&page=2&limit=5", nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var got indexer.Page[*indexer.Drift]
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		assert.Equal(t, want.Items[0].Ledger, got.Items[0].Ledger)
+		assert.Equal(t, int64(1), got.Total)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		srv, _ := newTestServer(t)
+		resp := do(t, srv, http.MethodGet, basePath+"/?limit=500", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestHTTP_Check(t *testing.T) {
+	t.Run("empty body checks a sample", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Check(mock.Anything, &verify.CheckRequest{}).
+			Return(&indexer.DriftReport{LedgerOffset: 8, PartiesChecked: 3}, nil)
+
+		resp := do(t, srv, http.MethodPost, basePath+"/check", nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var got indexer.DriftReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		assert.Equal(t, int64(8), got.LedgerOffset)
+	})
+
+	t.Run("passes the request", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Check(mock.Anything, &verify.CheckRequest{Parties: []string{alice}, Repair: true}).
+			Return(&indexer.DriftReport{}, nil)
+
+		resp := do(t, srv, http.MethodPost, basePath+"/check", strings.NewReader(`{"parties":["alice::1"],"repair":true}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		srv, _ := newTestServer(t)
+		resp := do(t, srv, http.MethodPost, basePath+"/check", strings.NewReader(`{"all":true}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("check already running", func(t *testing.T) {
+		srv, svc := newTestServer(t)
+		svc.EXPECT().Check(mock.Anything, mock.Anything).Return(nil, apperr.ConflictError(nil, "a drift check is already running"))
+
+		resp := do(t, srv, http.MethodPost, basePath+"/check", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
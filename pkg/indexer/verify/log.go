@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const verifyServiceName = "VerifyService"
+
+// logService wraps Service with automatic logging of all method calls.
+type logService struct {
+	svc    Service
+	logger *zap.Logger
+}
+
+// NewLog creates a logging decorator for the verify Service.
+func NewLog(svc Service, logger *zap.Logger) Service {
+	return &logService{svc: svc, logger: logger}
+}
+
+func (ls *logService) Check(ctx context.Context, req *CheckRequest) (report *indexer.DriftReport, err error) {
+	start := time.Now()
+	ls.logger.Info("Check started",
+		zap.String("service", verifyServiceName),
+		zap.Int("parties", len(req.Parties)),
+		zap.Bool("full", req.Full),
+		zap.Bool("repair", req.Repair),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("Check failed",
+				zap.String("service", verifyServiceName),
+				zap.Int("parties", len(req.Parties)),
+				zap.Bool("full", req.Full),
+				zap.Bool("repair", req.Repair),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+			return
+		}
+		fields := []zap.Field{
+			zap.String("service", verifyServiceName),
+			zap.Int64("ledger_offset", report.LedgerOffset),
+			zap.Bool("full", req.Full),
+			zap.Bool("repair", req.Repair),
+			zap.Int("parties_checked", report.PartiesChecked),
+			zap.Int("drifts", len(report.Drifts)),
+			zap.Duration("duration", time.Since(start)),
+		}
+		if len(report.Drifts) > 0 {
+			ls.logger.Warn("Check found drift", fields...)
+		} else {
+			ls.logger.Info("Check completed", fields...)
+		}
+	}()
+	return ls.svc.Check(ctx, req)
+}
+
+func (ls *logService) ListDrift(
+	ctx context.Context, partyID string, p indexer.Pagination,
+) (page *indexer.Page[*indexer.Drift], err error) {
+	start := time.Now()
+	ls.logger.Info("ListDrift started",
+		zap.String("service", verifyServiceName),
+		zap.String("party_id", partyID),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("ListDrift failed",
+				zap.String("service", verifyServiceName),
+				zap.String("party_id", partyID),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("ListDrift completed",
+				zap.String("service", verifyServiceName),
+				zap.String("party_id", partyID),
+				zap.Int64("total", page.Total),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.ListDrift(ctx, partyID, p)
+}
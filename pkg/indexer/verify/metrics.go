@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds Prometheus collectors for drift checks.
+type Metrics struct {
+	// ChecksTotal counts drift checks by mode (sample, full) and result
+	// (ok, error).
+	ChecksTotal *prometheus.CounterVec
+
+	// CheckDuration is the duration of a drift check, repairs included.
+	CheckDuration prometheus.Histogram
+
+	// DriftsTotal counts the drifts found, by kind (balance, supply).
+	DriftsTotal *prometheus.CounterVec
+
+	// LastCheckDrifts is the number of drifts the last successful check found,
+	// by kind (balance, supply).
+	LastCheckDrifts *prometheus.GaugeVec
+
+	// LastCheckOffset is the ledger offset the last successful check compared at.
+	LastCheckOffset prometheus.Gauge
+
+	// RepairsTotal counts the balances overwritten with the ledger's amounts.
+	RepairsTotal prometheus.Counter
+}
+
+// NewMetrics registers drift check metrics against the given registerer.
+func NewMetrics(reg sharedmetrics.NamespacedRegisterer) *Metrics {
+	f := promauto.With(reg)
+	ns := reg.Namespace()
+	sub := "drift"
+	return &Metrics{
+		ChecksTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "checks_total",
+			Help: "Drift checks, labeled by mode (sample, full) and result (ok, error)",
+		}, []string{"mode", "result"}),
+
+		CheckDuration: f.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns, Subsystem: sub,
+			Name:    "check_duration_seconds",
+			Help:    "Drift check duration, repairs included",
+			Buckets: sharedmetrics.DefaultDurationBuckets,
+		}),
+
+		DriftsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "found_total",
+			Help: "Differences between the indexed state and the ledger, labeled by kind (balance, supply)",
+		}, []string{"kind"}),
+
+		LastCheckDrifts: f.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "last_check_drifts",
+			Help: "Differences found by the last successful drift check, labeled by kind (balance, supply)",
+		}, []string{"kind"}),
+
+		LastCheckOffset: f.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "last_check_offset",
+			Help: "Ledger offset the last successful drift check compared at",
+		}),
+
+		RepairsTotal: f.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "repairs_total",
+			Help: "Indexed balances overwritten with the ledger's amounts",
+		}),
+	}
+}
+
+// NewNopMetrics returns a Metrics instance backed by a throwaway registry.
+// Use in tests where metric values are not asserted.
+func NewNopMetrics() *Metrics {
+	return NewMetrics(sharedmetrics.WithNamespace(prometheus.NewRegistry(), "nop"))
+}
@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package verify checks the indexed state against the ledger.
+//
+// Balances are derived by applying event deltas, so a missed or misapplied
+// event leaves them wrong without any error. A drift check reads the indexed
+// balances and supplies at the stored offset, reads the holdings in the
+// ledger's active contract set at that same offset, and records every value
+// that differs in indexer_drift.
+//
+// A sample check compares the balances of randomly chosen parties; a full check
+// compares every balance and total supply of the tokens the configured issuer
+// administers, including holdings of parties the indexer has never seen. A
+// check can repair the drifted balances it finds: the processor is paused, the
+// parties are compared again at the paused offset, and their indexed balances
+// are overwritten with the ledger's. Supplies are only reported.
+package verify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/engine"
+)
+
+// Store is the persistence interface the Service needs.
+//
+//go:generate mockery --name Store --output mocks --outpkg mocks --filename mock_store.go --with-expecter
+type Store interface {
+	// SampleParties returns up to limit random parties with an indexed balance.
+	SampleParties(ctx context.Context, limit int) ([]string, error)
+	// IndexedState reads the stored offset, every token, and the balances of
+	// parties (every balance when nil) from one snapshot.
+	IndexedState(ctx context.Context, parties []string) (*indexer.IndexedState, error)
+	// RecordDrift stores drifts and sets their IDs.
+	RecordDrift(ctx context.Context, drifts []indexer.Drift) error
+	// ListDrift returns recorded drifts, newest first, optionally for one party.
+	ListDrift(ctx context.Context, partyID string, p indexer.Pagination) ([]*indexer.Drift, int64, error)
+	// RepairBalances sets each balance to its change's After, recording the
+	// new amounts in the balance history at offset.
+	RepairBalances(ctx context.Context, offset int64, changes []indexer.BalanceChange) error
+}
+
+// Ledger reads holdings from the ledger's active contract set at an offset.
+// *token.Client implements it.
+//
+//go:generate mockery --name Ledger --output mocks --outpkg mocks --filename mock_ledger.go --with-expecter
+type Ledger interface {
+	// GetHoldingsByPartyAt returns the Splice holdings owned by a party.
+	GetHoldingsByPartyAt(ctx context.Context, ownerParty, instrumentID string, offset int64) ([]*token.Holding, error)
+	// GetAllHoldingsAt returns every CIP-56 holding of the issuer's tokens.
+	GetAllHoldingsAt(ctx context.Context, offset int64) ([]*token.Holding, error)
+}
+
+// Pauser stops the indexer processor while fn repairs balances and resumes it
+// afterwards. *engine.Processor implements it.
+//
+//go:generate mockery --name Pauser --output mocks --outpkg mocks --filename mock_pauser.go --with-expecter
+type Pauser interface {
+	Pause(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// CheckRequest selects what a drift check compares. With neither Parties nor
+// Full set, SampleSize random parties are compared.
+type CheckRequest struct {
+	// Parties are the parties whose balances are compared.
+	Parties []string `json:"parties,omitempty"`
+	// Full compares every balance and supply; it excludes Parties.
+	Full bool `json:"full"`
+	// Repair overwrites the drifted balances with the ledger's amounts.
+	Repair bool `json:"repair"`
+}
+
+// Service checks the indexed state against the ledger.
+//
+//go:generate mockery --name Service --output mocks --outpkg mocks --filename mock_service.go --with-expecter
+type Service interface {
+	// Check compares the indexed state with the ledger, records the drifts it
+	// finds and returns them.
+	Check(ctx context.Context, req *CheckRequest) (*indexer.DriftReport, error)
+	// ListDrift returns recorded drifts, newest first. partyID "" lists all.
+	ListDrift(ctx context.Context, partyID string, p indexer.Pagination) (*indexer.Page[*indexer.Drift], error)
+}
+
+// NewService creates a Service. pauser is the running processor to pause
+// around repairs; with a nil pauser repairs write directly, which is only safe
+// against a stopped indexer.
+//
+// metrics receives Prometheus observations for checks. Pass NewNopMetrics() in
+// tests where metric values aren't asserted.
+func NewService(store Store, ledger Ledger, pauser Pauser, cfg *Config, metrics *Metrics, logger *zap.Logger) Service {
+	if metrics == nil {
+		metrics = NewNopMetrics()
+	}
+	return &svc{
+		store:      store,
+		ledger:     ledger,
+		pauser:     pauser,
+		sampleSize: cfg.SampleSize,
+		issuer:     cfg.IssuerParty,
+		metrics:    metrics,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+type svc struct {
+	store      Store
+	ledger     Ledger
+	pauser     Pauser
+	sampleSize int
+	issuer     string
+	metrics    *Metrics
+	logger     *zap.Logger
+	now        func() time.Time
+
+	mu sync.Mutex // one check at a time
+}
+
+func (s *svc) Check(ctx context.Context, req *CheckRequest) (*indexer.DriftReport, error) {
+	if req.Full && len(req.Parties) > 0 {
+		return nil, apperrors.BadRequestError(nil, "parties cannot be combined with full")
+	}
+	for _, p := range req.Parties {
+		if p == "" {
+			return nil, apperrors.BadRequestError(nil, "parties must not be empty")
+		}
+	}
+	if !s.mu.TryLock() {
+		return nil, apperrors.ConflictError(nil, "a drift check is already running")
+	}
+	defer s.mu.Unlock()
+
+	start := s.now()
+	report, err := s.check(ctx, req)
+	s.metrics.CheckDuration.Observe(time.Since(start).Seconds())
+	mode := ModeSample
+	if req.Full {
+		mode = ModeFull
+	}
+	if err != nil {
+		s.metrics.ChecksTotal.WithLabelValues(mode, "error").Inc()
+		return nil, err
+	}
+	s.metrics.ChecksTotal.WithLabelValues(mode, "ok").Inc()
+	s.observeReport(report)
+	return report, nil
+}
+
+func (s *svc) check(ctx context.Context, req *CheckRequest) (*indexer.DriftReport, error) {
+	parties, err := s.parties(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.store.IndexedState(ctx, parties)
+	if err != nil {
+		return nil, err
+	}
+	holdings, err := s.holdings(ctx, state.LedgerOffset, parties)
+	if err != nil {
+		return nil, err
+	}
+	c, err := newComparison(state, holdings, s.covered(state.Tokens, req.Full))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &indexer.DriftReport{
+		LedgerOffset:   state.LedgerOffset,
+		Full:           req.Full,
+		PartiesChecked: len(parties),
+		TokensChecked:  len(c.tokens),
+		CheckedAt:      s.now().UTC(),
+		Drifts:         c.balanceDrifts(),
+	}
+	if req.Full {
+		report.PartiesChecked = c.parties()
+		report.Drifts = append(report.Drifts, c.supplyDrifts()...)
+	}
+	if req.Repair {
+		if err = s.repair(ctx, report.Drifts, req.Full); err != nil {
+			return nil, err
+		}
+	}
+	for i := range report.Drifts {
+		report.Drifts[i].LedgerOffset = report.LedgerOffset
+		report.Drifts[i].DetectedAt = report.CheckedAt
+	}
+	if err = s.store.RecordDrift(ctx, report.Drifts); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// parties returns the parties a check compares: nil for a full check, which
+// compares them all, otherwise the requested parties or a random sample.
+func (s *svc) parties(ctx context.Context, req *CheckRequest) ([]string, error) {
+	if req.Full {
+		return nil, nil
+	}
+	if len(req.Parties) > 0 {
+		return req.Parties, nil
+	}
+	sampled, err := s.store.SampleParties(ctx, s.sampleSize)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{}, sampled...), nil // non-nil: no parties means no balances
+}
+
+// holdings reads the ledger holdings at offset: every issuer holding when
+// parties is nil, otherwise those of each party.
+func (s *svc) holdings(ctx context.Context, offset int64, parties []string) ([]*token.Holding, error) {
+	if offset == 0 {
+		return nil, nil
+	}
+	if parties == nil {
+		return s.ledger.GetAllHoldingsAt(ctx, offset)
+	}
+	var out []*token.Holding
+	for _, p := range parties {
+		hs, err := s.ledger.GetHoldingsByPartyAt(ctx, p, "", offset)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, hs...)
+	}
+	return out, nil
+}
+
+// covered returns the tokens a check compares: every indexed token when
+// holdings are read per party, which returns holdings of any Splice token, and
+// the issuer's tokens for a full check, which reads CIP-56 holdings only.
+func (s *svc) covered(tokens []*indexer.Token, full bool) map[indexer.InstrumentKey]*indexer.Token {
+	out := make(map[indexer.InstrumentKey]*indexer.Token, len(tokens))
+	for _, t := range tokens {
+		if full && t.InstrumentAdmin != s.issuer {
+			continue
+		}
+		out[indexer.InstrumentKey{Admin: t.InstrumentAdmin, ID: t.InstrumentID}] = t
+	}
+	return out
+}
+
+// repair overwrites the drifted balances of the parties in drifts with the
+// ledger's amounts and marks the drifts repaired. The parties are compared
+// again with the processor paused, so balances that changed since the check
+// are repaired to their current ledger amounts.
+func (s *svc) repair(ctx context.Context, drifts []indexer.Drift, full bool) error {
+	var parties []string
+	seen := make(map[string]bool)
+	for _, d := range drifts {
+		if d.Kind == indexer.DriftBalance && !seen[d.PartyID] {
+			seen[d.PartyID] = true
+			parties = append(parties, d.PartyID)
+		}
+	}
+	if len(parties) == 0 {
+		return nil
+	}
+
+	run := func(ctx context.Context) error {
+		state, err := s.store.IndexedState(ctx, parties)
+		if err != nil {
+			return err
+		}
+		holdings, err := s.holdings(ctx, state.LedgerOffset, parties)
+		if err != nil {
+			return err
+		}
+		c, err := newComparison(state, holdings, s.covered(state.Tokens, full))
+		if err != nil {
+			return err
+		}
+		changes := c.balanceChanges()
+		if err = s.store.RepairBalances(ctx, state.LedgerOffset, changes); err != nil {
+			return err
+		}
+		s.metrics.RepairsTotal.Add(float64(len(changes)))
+		markRepaired(drifts, changes)
+		return nil
+	}
+	if s.pauser == nil {
+		return run(ctx)
+	}
+	err := s.pauser.Pause(ctx, run)
+	if errors.Is(err, engine.ErrNotRunning) {
+		return apperrors.UnavailableError(err, "the indexer processor is not running")
+	}
+	return err
+}
+
+func (s *svc) observeReport(r *indexer.DriftReport) {
+	counts := map[indexer.DriftKind]int{indexer.DriftBalance: 0, indexer.DriftSupply: 0}
+	for _, d := range r.Drifts {
+		counts[d.Kind]++
+	}
+	for kind, n := range counts {
+		s.metrics.DriftsTotal.WithLabelValues(string(kind)).Add(float64(n))
+		if kind == indexer.DriftSupply && !r.Full {
+			continue // supplies were not compared
+		}
+		s.metrics.LastCheckDrifts.WithLabelValues(string(kind)).Set(float64(n))
+	}
+	s.metrics.LastCheckOffset.Set(float64(r.LedgerOffset))
+}
+
+func (s *svc) ListDrift(ctx context.Context, partyID string, p indexer.Pagination) (*indexer.Page[*indexer.Drift], error) {
+	items, total, err := s.store.ListDrift(ctx, partyID, p)
+	if err != nil {
+		return nil, err
+	}
+	return &indexer.Page[*indexer.Drift]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+}
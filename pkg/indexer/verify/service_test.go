@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/engine"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/verify"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/verify/mocks"
+)
+
+const (
+	issuer = "issuer::1"
+	alice  = "alice::1"
+	bob    = "bob::1"
+)
+
+var (
+	demo  = &indexer.Token{InstrumentAdmin: issuer, InstrumentID: "DEMO", TotalSupply: "100"}
+	usdcx = &indexer.Token{InstrumentAdmin: "registrar::1", InstrumentID: "USDCx", TotalSupply: "0"}
+)
+
+func testConfig() *verify.Config {
+	return &verify.Config{Mode: verify.ModeSample, SampleSize: 2, IssuerParty: issuer}
+}
+
+func balance(party string, t *indexer.Token, amount string) *indexer.Balance {
+	return &indexer.Balance{PartyID: party, InstrumentAdmin: t.InstrumentAdmin, InstrumentID: t.InstrumentID, Amount: amount}
+}
+
+func holding(owner string, t *indexer.Token, amount string) *token.Holding {
+	return &token.Holding{
+		ContractID: owner + "-" + amount, Owner: owner,
+		InstrumentAdmin: t.InstrumentAdmin, InstrumentID: t.InstrumentID, Amount: amount,
+	}
+}
+
+// runPaused makes pauser run fn directly, as a paused processor would.
+func runPaused(pauser *mocks.Pauser) {
+	pauser.EXPECT().Pause(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+// expectRecorded makes store.RecordDrift capture the recorded drifts.
+func expectRecorded(store *mocks.Store) *[]indexer.Drift {
+	var recorded []indexer.Drift
+	store.EXPECT().RecordDrift(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, drifts []indexer.Drift) error {
+			recorded = drifts
+			return nil
+		})
+	return &recorded
+}
+
+func TestSvc_Check_Sample(t *testing.T) {
+	t.Run("no drift", func(t *testing.T) {
+		store, ledger := mocks.NewStore(t), mocks.NewLedger(t)
+		store.EXPECT().SampleParties(mock.Anything, 2).Return([]string{alice}, nil)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice}).Return(&indexer.IndexedState{
+			LedgerOffset: 7,
+			Balances:     []*indexer.Balance{balance(alice, demo, "10.5")},
+			Tokens:       []*indexer.Token{demo},
+		}, nil)
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(7)).
+			Return([]*token.Holding{holding(alice, demo, "10"), holding(alice, demo, "0.50")}, nil)
+		recorded := expectRecorded(store)
+
+		report, err := verify.NewService(store, ledger, nil, testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), report.LedgerOffset)
+		assert.Equal(t, 1, report.PartiesChecked)
+		assert.Empty(t, report.Drifts)
+		assert.Empty(t, *recorded)
+	})
+
+	t.Run("records balance drifts on either side", func(t *testing.T) {
+		store, ledger := mocks.NewStore(t), mocks.NewLedger(t)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice, bob}).Return(&indexer.IndexedState{
+			LedgerOffset: 9,
+			Balances:     []*indexer.Balance{balance(alice, demo, "10"), balance(bob, demo, "3")},
+			Tokens:       []*indexer.Token{demo, usdcx},
+		}, nil)
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(9)).
+			Return([]*token.Holding{holding(alice, demo, "8"), holding(alice, usdcx, "1")}, nil)
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, bob, "", int64(9)).
+			Return([]*token.Holding{holding(bob, demo, "3")}, nil)
+		recorded := expectRecorded(store)
+
+		report, err := verify.NewService(store, ledger, nil, testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{Parties: []string{alice, bob}})
+		require.NoError(t, err)
+		assert.Equal(t, []indexer.Drift{
+			{
+				Kind: indexer.DriftBalance, PartyID: alice, InstrumentAdmin: issuer, InstrumentID: "DEMO",
+				Indexed: "10", Ledger: "8", LedgerOffset: 9, DetectedAt: report.CheckedAt,
+			},
+			{
+				Kind: indexer.DriftBalance, PartyID: alice, InstrumentAdmin: "registrar::1", InstrumentID: "USDCx",
+				Indexed: "0", Ledger: "1", LedgerOffset: 9, DetectedAt: report.CheckedAt,
+			},
+		}, report.Drifts)
+		assert.Equal(t, report.Drifts, *recorded)
+	})
+
+	t.Run("ignores holdings of tokens the indexer does not track", func(t *testing.T) {
+		store, ledger := mocks.NewStore(t), mocks.NewLedger(t)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice}).Return(&indexer.IndexedState{
+			LedgerOffset: 9, Tokens: []*indexer.Token{demo},
+		}, nil)
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(9)).
+			Return([]*token.Holding{holding(alice, usdcx, "1")}, nil)
+		expectRecorded(store)
+
+		report, err := verify.NewService(store, ledger, nil, testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{Parties: []string{alice}})
+		require.NoError(t, err)
+		assert.Empty(t, report.Drifts)
+	})
+
+	t.Run("nothing indexed yet", func(t *testing.T) {
+		store := mocks.NewStore(t)
+		store.EXPECT().SampleParties(mock.Anything, 2).Return(nil, nil)
+		store.EXPECT().IndexedState(mock.Anything, []string{}).Return(&indexer.IndexedState{}, nil)
+		expectRecorded(store)
+
+		// The strict ledger mock fails the test if holdings are read.
+		report, err := verify.NewService(store, mocks.NewLedger(t), nil, testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, report.Drifts)
+	})
+
+	t.Run("ledger error", func(t *testing.T) {
+		store, ledger := mocks.NewStore(t), mocks.NewLedger(t)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice}).Return(&indexer.IndexedState{LedgerOffset: 3}, nil)
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(3)).Return(nil, errors.New("pruned"))
+
+		_, err := verify.NewService(store, ledger, nil, testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{Parties: []string{alice}})
+		require.ErrorContains(t, err, "pruned")
+	})
+}
+
+func TestSvc_Check_Full(t *testing.T) {
+	store, ledger := mocks.NewStore(t), mocks.NewLedger(t)
+	store.EXPECT().IndexedState(mock.Anything, []string(nil)).Return(&indexer.IndexedState{
+		LedgerOffset: 12,
+		Balances:     []*indexer.Balance{balance(alice, demo, "60"), balance(bob, demo, "40"), balance(bob, usdcx, "5")},
+		Tokens:       []*indexer.Token{demo, usdcx},
+	}, nil)
+	// carol holds DEMO on the ledger but was never indexed.
+	ledger.EXPECT().GetAllHoldingsAt(mock.Anything, int64(12)).Return([]*token.Holding{
+		holding(alice, demo, "60"), holding(bob, demo, "40"), holding("carol::1", demo, "2"),
+	}, nil)
+	recorded := expectRecorded(store)
+
+	report, err := verify.NewService(store, ledger, nil, testConfig(), nil, zap.NewNop()).
+		Check(context.Background(), &verify.CheckRequest{Full: true})
+	require.NoError(t, err)
+	assert.True(t, report.Full)
+	assert.Equal(t, 3, report.PartiesChecked)
+	assert.Equal(t, 1, report.TokensChecked, "only the issuer's tokens are covered")
+	require.Len(t, report.Drifts, 2)
+	assert.Equal(t, indexer.Drift{
+		Kind: indexer.DriftBalance, PartyID: "carol::1", InstrumentAdmin: issuer, InstrumentID: "DEMO",
+		Indexed: "0", Ledger: "2", LedgerOffset: 12, DetectedAt: report.CheckedAt,
+	}, report.Drifts[0])
+	assert.Equal(t, indexer.Drift{
+		Kind: indexer.DriftSupply, InstrumentAdmin: issuer, InstrumentID: "DEMO",
+		Indexed: "100", Ledger: "102", LedgerOffset: 12, DetectedAt: report.CheckedAt,
+	}, report.Drifts[1])
+	assert.Equal(t, report.Drifts, *recorded)
+}
+
+func TestSvc_Check_Repair(t *testing.T) {
+	t.Run("repairs the drifted balances with the processor paused", func(t *testing.T) {
+		store, ledger, pauser := mocks.NewStore(t), mocks.NewLedger(t), mocks.NewPauser(t)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice, bob}).Return(&indexer.IndexedState{
+			LedgerOffset: 5,
+			Balances:     []*indexer.Balance{balance(alice, demo, "10"), balance(bob, demo, "1")},
+			Tokens:       []*indexer.Token{demo},
+		}, nil).Once()
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(5)).
+			Return([]*token.Holding{holding(alice, demo, "8")}, nil).Once()
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, bob, "", int64(5)).
+			Return([]*token.Holding{holding(bob, demo, "1")}, nil).Once()
+
+		// Paused at offset 6, only alice (who drifted) is compared again.
+		runPaused(pauser)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice}).Return(&indexer.IndexedState{
+			LedgerOffset: 6,
+			Balances:     []*indexer.Balance{balance(alice, demo, "10")},
+			Tokens:       []*indexer.Token{demo},
+		}, nil).Once()
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(6)).
+			Return([]*token.Holding{holding(alice, demo, "7")}, nil).Once()
+		store.EXPECT().RepairBalances(mock.Anything, int64(6), []indexer.BalanceChange{{
+			PartyID: alice, InstrumentAdmin: issuer, InstrumentID: "DEMO", Before: "10", After: "7",
+		}}).Return(nil)
+		recorded := expectRecorded(store)
+
+		report, err := verify.NewService(store, ledger, pauser, testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{Parties: []string{alice, bob}, Repair: true})
+		require.NoError(t, err)
+		require.Len(t, report.Drifts, 1)
+		assert.True(t, report.Drifts[0].Repaired)
+		assert.Equal(t, "8", report.Drifts[0].Ledger, "the drift is reported as found")
+		assert.Equal(t, report.Drifts, *recorded)
+	})
+
+	t.Run("nothing to repair does not pause", func(t *testing.T) {
+		store, ledger := mocks.NewStore(t), mocks.NewLedger(t)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice}).Return(&indexer.IndexedState{
+			LedgerOffset: 5, Tokens: []*indexer.Token{demo},
+		}, nil)
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(5)).Return(nil, nil)
+		expectRecorded(store)
+
+		// The strict pauser mock fails the test if Pause is called.
+		_, err := verify.NewService(store, ledger, mocks.NewPauser(t), testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{Parties: []string{alice}, Repair: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("processor not running", func(t *testing.T) {
+		store, ledger, pauser := mocks.NewStore(t), mocks.NewLedger(t), mocks.NewPauser(t)
+		store.EXPECT().IndexedState(mock.Anything, []string{alice}).Return(&indexer.IndexedState{
+			LedgerOffset: 5, Balances: []*indexer.Balance{balance(alice, demo, "1")}, Tokens: []*indexer.Token{demo},
+		}, nil)
+		ledger.EXPECT().GetHoldingsByPartyAt(mock.Anything, alice, "", int64(5)).Return(nil, nil)
+		pauser.EXPECT().Pause(mock.Anything, mock.Anything).Return(engine.ErrNotRunning)
+
+		_, err := verify.NewService(store, ledger, pauser, testConfig(), nil, zap.NewNop()).
+			Check(context.Background(), &verify.CheckRequest{Parties: []string{alice}, Repair: true})
+		assert.True(t, apperrors.Is(err, apperrors.CategoryRecovering))
+	})
+}
+
+func TestSvc_Check_InvalidRequest(t *testing.T) {
+	svc := verify.NewService(mocks.NewStore(t), mocks.NewLedger(t), nil, testConfig(), nil, zap.NewNop())
+
+	_, err := svc.Check(context.Background(), &verify.CheckRequest{Full: true, Parties: []string{alice}})
+	assert.True(t, apperrors.Is(err, apperrors.CategoryDataError))
+
+	_, err = svc.Check(context.Background(), &verify.CheckRequest{Parties: []string{""}})
+	assert.True(t, apperrors.Is(err, apperrors.CategoryDataError))
+}
+
+func TestSvc_Check_Concurrent(t *testing.T) {
+	store := mocks.NewStore(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	store.EXPECT().SampleParties(mock.Anything, 2).
+		RunAndReturn(func(context.Context, int) ([]string, error) {
+			close(started)
+			<-release
+			return nil, errors.New("stop")
+		})
+	svc := verify.NewService(store, mocks.NewLedger(t), nil, testConfig(), nil, zap.NewNop())
+
+	done := make(chan error)
+	go func() {
+		_, err := svc.Check(context.Background(), &verify.CheckRequest{})
+		done <- err
+	}()
+	<-started
+	_, err := svc.Check(context.Background(), &verify.CheckRequest{})
+	assert.True(t, apperrors.Is(err, apperrors.CategoryDataConflict))
+	close(release)
+	require.ErrorContains(t, <-done, "stop")
+}
+
+func TestSvc_ListDrift(t *testing.T) {
+	store := mocks.NewStore(t)
+	p := indexer.Pagination{Page: 2, Limit: 10}
+	items := []*indexer.Drift{{ID: 3, Kind: indexer.DriftBalance, PartyID: alice}}
+	store.EXPECT().ListDrift(mock.Anything, alice, p).Return(items, 11, nil)
+
+	page, err := verify.NewService(store, mocks.NewLedger(t), nil, testConfig(), nil, zap.NewNop()).
+		ListDrift(context.Background(), alice, p)
+	require.NoError(t, err)
+	assert.Equal(t, &indexer.Page[*indexer.Drift]{Items: items, Total: 11, Page: 2, Limit: 10}, page)
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Worker runs a drift check every Interval, in the configured mode, repairing
+// drifted balances when AutoRepair is set.
+//
+// It runs as a background goroutine and stops when ctx is canceled. Failed
+// checks are not retried before the next tick; pass a Service wrapped with
+// NewLog so they are logged.
+type Worker struct {
+	svc      Service
+	interval time.Duration
+	req      CheckRequest
+	logger   *zap.Logger
+}
+
+// NewWorker creates a Worker.
+func NewWorker(svc Service, cfg *Config, logger *zap.Logger) *Worker {
+	return &Worker{
+		svc:      svc,
+		interval: cfg.Interval,
+		req:      CheckRequest{Full: cfg.Mode == ModeFull, Repair: cfg.AutoRepair},
+		logger:   logger,
+	}
+}
+
+// Run starts the check loop. It blocks until ctx is canceled.
+func (w *Worker) Run(ctx context.Context) error {
+	w.logger.Info("drift verifier started",
+		zap.Duration("interval", w.interval),
+		zap.Bool("full", w.req.Full),
+		zap.Bool("auto_repair", w.req.Repair),
+	)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("drift verifier stopped")
+			return nil
+		case <-ticker.C:
+			req := w.req
+			_, _ = w.svc.Check(ctx, &req)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// WebhookEventType classifies a webhook notification. MINT, BURN and TRANSFER
+// are indexed TokenTransferEvents (same values as EventType); TRANSFER_STATUS is
+// a transfer entering a status — a new pending offer, a settled direct transfer,
+// or an offer finalized as completed, canceled or rejected.
+type WebhookEventType string
+
+const (
+	WebhookEventMint           WebhookEventType = "MINT"
+	WebhookEventBurn           WebhookEventType = "BURN"
+	WebhookEventTransfer       WebhookEventType = "TRANSFER"
+	WebhookEventTransferStatus WebhookEventType = "TRANSFER_STATUS"
+)
+
+// Webhook delivery status values. A delivery is "pending" until the endpoint
+// answers 2xx ("delivered") or it runs out of attempts ("dead"). Delivered and
+// dead deliveries can be replayed, which makes them pending again.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookNotification is the JSON body POSTed to webhook endpoints. Exactly one
+// of Event (MINT/BURN/TRANSFER) or Transfer (TRANSFER_STATUS) is set.
+type WebhookNotification struct {
+	Type         WebhookEventType `json:"type"`
+	LedgerOffset int64            `json:"ledger_offset"`
+	Event        *ParsedEvent     `json:"event,omitempty"`
+	Transfer     *Transfer        `json:"transfer,omitempty"`
+}
+
+// parties returns the parties a notification concerns.
+func (n *WebhookNotification) parties() []string {
+	var ps []string
+	switch {
+	case n.Event != nil:
+		if n.Event.FromPartyID != nil {
+			ps = append(ps, *n.Event.FromPartyID)
+		}
+		if n.Event.ToPartyID != nil {
+			ps = append(ps, *n.Event.ToPartyID)
+		}
+	case n.Transfer != nil:
+		ps = append(ps, n.Transfer.FromPartyID, n.Transfer.ToPartyID)
+	}
+	return ps
+}
+
+// instrument returns the instrument a notification concerns.
+func (n *WebhookNotification) instrument() InstrumentKey {
+	if n.Event != nil {
+		return InstrumentKey{Admin: n.Event.InstrumentAdmin, ID: n.Event.InstrumentID}
+	}
+	if n.Transfer != nil {
+		return InstrumentKey{Admin: n.Transfer.InstrumentAdmin, ID: n.Transfer.InstrumentID}
+	}
+	return InstrumentKey{}
+}
+
+// WebhookFilter selects the notifications delivered to an endpoint. Every
+// non-empty field must match; an empty field matches everything.
+type WebhookFilter struct {
+	// Parties matches notifications where any listed party is the sender or receiver.
+	Parties []string `json:"parties,omitempty"`
+	// Instruments matches notifications for any listed instrument.
+	Instruments []InstrumentKey `json:"instruments,omitempty"`
+	// EventTypes matches notifications of any listed type.
+	EventTypes []WebhookEventType `json:"event_types,omitempty"`
+	// TransferStatuses restricts TRANSFER_STATUS notifications to the listed
+	// statuses. Token events are unaffected.
+	TransferStatuses []string `json:"transfer_statuses,omitempty"`
+}
+
+// Matches reports whether n passes the filter.
+func (f *WebhookFilter) Matches(n *WebhookNotification) bool {
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, n.Type) {
+		return false
+	}
+	if len(f.TransferStatuses) > 0 && n.Transfer != nil && !slices.Contains(f.TransferStatuses, n.Transfer.Status) {
+		return false
+	}
+	if len(f.Instruments) > 0 && !slices.Contains(f.Instruments, n.instrument()) {
+		return false
+	}
+	if len(f.Parties) > 0 && !slices.ContainsFunc(n.parties(), func(p string) bool {
+		return slices.Contains(f.Parties, p)
+	}) {
+		return false
+	}
+	return true
+}
+
+// WebhookEndpoint is a registered webhook receiver. Deliveries to it are signed
+// with Secret (HMAC-SHA256), which is only returned when the endpoint is created.
+type WebhookEndpoint struct {
+	ID          int64         `json:"id"`
+	URL         string        `json:"url"`
+	Secret      string        `json:"secret,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Filter      WebhookFilter `json:"filter"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// WebhookDelivery is one notification queued for one endpoint in the durable
+// outbox. Payload is the exact JSON body sent on every attempt.
+type WebhookDelivery struct {
+	ID             int64            `json:"id"`
+	EndpointID     int64            `json:"endpoint_id"`
+	EventType      WebhookEventType `json:"event_type"`
+	LedgerOffset   int64            `json:"ledger_offset"`
+	Payload        json.RawMessage  `json:"payload"`
+	Status         string           `json:"status"` // pending | delivered | dead
+	Attempts       int              `json:"attempts"`
+	NextAttemptAt  time.Time        `json:"next_attempt_at"`
+	LastStatusCode int              `json:"last_status_code,omitempty"`
+	LastError      string           `json:"last_error,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	DeliveredAt    *time.Time       `json:"delivered_at,omitempty"`
+}
+
+// WebhookAttempt is the outcome of one delivery attempt.
+type WebhookAttempt struct {
+	Status        string    // new delivery status
+	Attempts      int       // attempts made so far, including this one
+	NextAttemptAt time.Time // when to retry; ignored unless Status is pending
+	StatusCode    int       // HTTP status of the response; 0 when none was received
+	Error         string    // failure reason; empty on success
+	At            time.Time // when the attempt finished
+}
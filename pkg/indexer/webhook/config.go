@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import "time"
+
+// Config configures outbound webhooks. Omitting the block disables both the
+// webhook admin API and delivery; notifications are only enqueued for
+// registered endpoints, so an indexer without endpoints writes nothing.
+type Config struct {
+	// PollInterval is how often the dispatcher looks for due deliveries when it
+	// is not woken by a processor commit — it bounds the delay of retries.
+	PollInterval time.Duration `yaml:"poll_interval" default:"5s"`
+	// Timeout bounds a single delivery request, including reading the response.
+	Timeout time.Duration `yaml:"timeout" default:"10s"`
+	// MaxAttempts is the number of attempts after which a delivery is dead-lettered.
+	MaxAttempts int `yaml:"max_attempts" default:"10" validate:"min=1"`
+	// InitialBackoff is the delay before the first retry; each further retry
+	// doubles it, up to MaxBackoff.
+	InitialBackoff time.Duration `yaml:"initial_backoff" default:"10s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" default:"1h"`
+	// BatchSize is the number of due deliveries loaded per query.
+	BatchSize int `yaml:"batch_size" default:"100" validate:"min=1"`
+	// Concurrency is the number of deliveries sent in parallel.
+	Concurrency int `yaml:"concurrency" default:"4" validate:"min=1"`
+}
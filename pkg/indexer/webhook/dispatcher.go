@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook delivers indexer notifications to registered HTTP endpoints.
+//
+// The processor writes one delivery per matching endpoint into the outbox
+// (indexer_webhook_deliveries) in the same transaction as the ledger data it
+// describes, so a notification exists if and only if its data was committed.
+// The Dispatcher then POSTs each delivery, retrying failures with exponential
+// backoff and dead-lettering those that exhaust their attempts.
+//
+// Delivery is at-least-once and unordered across deliveries: receivers should
+// deduplicate on the X-Webhook-ID header and order by the payload's
+// ledger_offset.
+//
+// Every request is signed. X-Webhook-Signature is "sha256=" followed by the hex
+// HMAC-SHA256, keyed with the endpoint secret, of the X-Webhook-Timestamp value
+// (Unix seconds), a ".", and the raw request body — see Sign.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+
+	"go.uber.org/zap"
+)
+
+// Delivery request headers.
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxErrorBodyBytes caps how much of a failed response is kept as LastError.
+const maxErrorBodyBytes = 512
+
+// DeliveryStore is the outbox interface the Dispatcher needs.
+//
+//go:generate mockery --name DeliveryStore --output mocks --outpkg mocks --filename mock_delivery_store.go --with-expecter
+type DeliveryStore interface {
+	// GetWebhookEndpoint returns an endpoint, secret included. Returns (nil, nil)
+	// when not found.
+	GetWebhookEndpoint(ctx context.Context, id int64) (*indexer.WebhookEndpoint, error)
+	// DueWebhookDeliveries returns up to limit pending deliveries due at now,
+	// oldest first.
+	DueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*indexer.WebhookDelivery, error)
+	// RecordWebhookAttempt stores the outcome of a delivery attempt.
+	RecordWebhookAttempt(ctx context.Context, id int64, a indexer.WebhookAttempt) error
+}
+
+// Sign returns the X-Webhook-Signature value for body sent at timestamp
+// (Unix seconds).
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher sends pending outbox deliveries to their endpoints.
+//
+// It runs as a background goroutine and stops when ctx is canceled. It wakes
+// on every PollInterval tick and whenever Notify is called (after each
+// processor commit), then drains every due delivery before sleeping again.
+type Dispatcher struct {
+	store   DeliveryStore
+	client  *http.Client
+	cfg     Config
+	metrics *Metrics
+	logger  *zap.Logger
+
+	wake chan struct{}
+	now  func() time.Time
+}
+
+// NewDispatcher creates a Dispatcher.
+//
+// metrics receives Prometheus observations for attempts and store errors. Pass
+// NewNopMetrics() in tests where metric values aren't asserted.
+func NewDispatcher(store DeliveryStore, cfg *Config, metrics *Metrics, logger *zap.Logger) *Dispatcher {
+	if metrics == nil {
+		metrics = NewNopMetrics()
+	}
+	return &Dispatcher{
+		store:   store,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		cfg:     *cfg,
+		metrics: metrics,
+		logger:  logger,
+		wake:    make(chan struct{}, 1),
+		now:     time.Now,
+	}
+}
+
+// Notify wakes the dispatcher. It never blocks; wake-ups that arrive while one
+// is already queued are merged.
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run starts the dispatch loop. It blocks until ctx is canceled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	d.logger.Info("webhook dispatcher started", zap.Duration("poll_interval", d.cfg.PollInterval))
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			d.logger.Info("webhook dispatcher stopped")
+			return nil
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// dispatch sends due deliveries batch by batch until none are left. It stops
+// early on a store error so a delivery whose outcome could not be recorded is
+// not resent in a tight loop; the next tick retries it.
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		due, err := d.store.DueWebhookDeliveries(ctx, d.now(), d.cfg.BatchSize)
+		if err != nil {
+			d.metrics.ErrorsTotal.WithLabelValues("load").Inc()
+			d.logger.Warn("webhook dispatcher: failed to load due deliveries", zap.Error(err))
+			return
+		}
+		if len(due) == 0 || !d.deliverBatch(ctx, due) || len(due) < d.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// deliverBatch sends one batch with up to Concurrency requests in flight and
+// waits for all of them. It returns false if any store call failed.
+func (d *Dispatcher) deliverBatch(ctx context.Context, due []*indexer.WebhookDelivery) bool {
+	endpoints := make(map[int64]*indexer.WebhookEndpoint)
+	for _, dv := range due {
+		if _, seen := endpoints[dv.EndpointID]; seen {
+			continue
+		}
+		ep, err := d.store.GetWebhookEndpoint(ctx, dv.EndpointID)
+		if err != nil {
+			d.metrics.ErrorsTotal.WithLabelValues("load").Inc()
+			d.logger.Warn("webhook dispatcher: failed to load endpoint",
+				zap.Int64("endpoint_id", dv.EndpointID), zap.Error(err))
+			return false
+		}
+		// A nil endpoint was deleted after the batch was read; its deliveries
+		// went with it.
+		endpoints[dv.EndpointID] = ep
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ok  = true
+		sem = make(chan struct{}, d.cfg.Concurrency)
+	)
+	for _, dv := range due {
+		ep := endpoints[dv.EndpointID]
+		if ep == nil {
+			continue
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := d.deliver(ctx, ep, dv); err != nil {
+				d.metrics.ErrorsTotal.WithLabelValues("record").Inc()
+				d.logger.Warn("webhook dispatcher: failed to record attempt",
+					zap.Int64("delivery_id", dv.ID), zap.Error(err))
+				mu.Lock()
+				ok = false
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return ok
+}
+
+// deliver makes one attempt at dv and records its outcome. An attempt cut
+// short by shutdown is not recorded, so it does not consume the retry budget.
+func (d *Dispatcher) deliver(ctx context.Context, ep *indexer.WebhookEndpoint, dv *indexer.WebhookDelivery) error {
+	start := d.now()
+	statusCode, sendErr := d.send(ctx, ep, dv, start)
+	d.metrics.AttemptDuration.Observe(time.Since(start).Seconds())
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	at := d.now()
+	attempt := indexer.WebhookAttempt{
+		Attempts:   dv.Attempts + 1,
+		StatusCode: statusCode,
+		At:         at,
+	}
+	switch {
+	case sendErr == nil:
+		attempt.Status = indexer.WebhookDeliveryDelivered
+		d.metrics.AttemptsTotal.WithLabelValues("delivered").Inc()
+	case attempt.Attempts >= d.cfg.MaxAttempts:
+		attempt.Status = indexer.WebhookDeliveryDead
+		attempt.Error = sendErr.Error()
+		d.metrics.AttemptsTotal.WithLabelValues("dead").Inc()
+		d.logger.Warn("webhook delivery dead-lettered",
+			zap.Int64("delivery_id", dv.ID),
+			zap.Int64("endpoint_id", ep.ID),
+			zap.Int("attempts", attempt.Attempts),
+			zap.Error(sendErr),
+		)
+	default:
+		attempt.Status = indexer.WebhookDeliveryPending
+		attempt.Error = sendErr.Error()
+		attempt.NextAttemptAt = at.Add(d.backoff(attempt.Attempts))
+		d.metrics.AttemptsTotal.WithLabelValues("retry").Inc()
+		d.logger.Debug("webhook delivery failed, retrying",
+			zap.Int64("delivery_id", dv.ID),
+			zap.Int64("endpoint_id", ep.ID),
+			zap.Int("attempts", attempt.Attempts),
+			zap.Time("next_attempt_at", attempt.NextAttemptAt),
+			zap.Error(sendErr),
+		)
+	}
+	return d.store.RecordWebhookAttempt(ctx, dv.ID, attempt)
+}
+
+// send POSTs the signed payload. It returns the response status code (0 when
+// none was received) and a non-nil error unless the endpoint answered 2xx.
+func (d *Dispatcher) send(ctx context.Context, ep *indexer.WebhookEndpoint, dv *indexer.WebhookDelivery, at time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(dv.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	ts := at.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, strconv.FormatInt(dv.ID, 10))
+	req.Header.Set(HeaderEvent, string(dv.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(ep.Secret, ts, dv.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return resp.StatusCode, fmt.Errorf("endpoint returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+}
+
+// backoff returns the delay before the retry following the given attempt:
+// InitialBackoff doubled per earlier attempt, capped at MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
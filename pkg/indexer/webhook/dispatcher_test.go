@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook/mocks"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func testConfig() *webhook.Config {
+	return &webhook.Config{
+		PollInterval:   time.Hour,
+		Timeout:        5 * time.Second,
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     time.Minute,
+		BatchSize:      10,
+		Concurrency:    2,
+	}
+}
+
+func pendingDelivery(id int64, attempts int) *indexer.WebhookDelivery {
+	return &indexer.WebhookDelivery{
+		ID:           id,
+		EndpointID:   1,
+		EventType:    indexer.WebhookEventMint,
+		LedgerOffset: 42,
+		Payload:      []byte(`{"type":"MINT","ledger_offset":42}`),
+		Status:       indexer.WebhookDeliveryPending,
+		Attempts:     attempts,
+	}
+}
+
+// runOnce runs the dispatcher until its first attempt has been recorded and
+// returns that attempt.
+func runOnce(
+	t *testing.T, url string, dv *indexer.WebhookDelivery,
+) indexer.WebhookAttempt {
+	t.Helper()
+	store := mocks.NewDeliveryStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.EXPECT().DueWebhookDeliveries(mock.Anything, mock.Anything, 10).
+		Return([]*indexer.WebhookDelivery{dv}, nil).Once()
+	store.EXPECT().GetWebhookEndpoint(mock.Anything, int64(1)).
+		Return(&indexer.WebhookEndpoint{ID: 1, URL: url, Secret: testSecret}, nil).Once()
+
+	var got indexer.WebhookAttempt
+	store.EXPECT().RecordWebhookAttempt(mock.Anything, dv.ID, mock.Anything).
+		Run(func(_ context.Context, _ int64, a indexer.WebhookAttempt) {
+			got = a
+			cancel()
+		}).Return(nil).Once()
+
+	d := webhook.NewDispatcher(store, testConfig(), webhook.NewNopMetrics(), zap.NewNop())
+	require.NoError(t, d.Run(ctx))
+	return got
+}
+
+func TestDispatcher_Delivered(t *testing.T) {
+	var (
+		body    []byte
+		headers http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	dv := pendingDelivery(7, 0)
+	got := runOnce(t, srv.URL, dv)
+
+	assert.Equal(t, indexer.WebhookDeliveryDelivered, got.Status)
+	assert.Equal(t, 1, got.Attempts)
+	assert.Equal(t, http.StatusNoContent, got.StatusCode)
+	assert.Empty(t, got.Error)
+
+	assert.JSONEq(t, string(dv.Payload), string(body))
+	assert.Equal(t, "7", headers.Get(webhook.HeaderID))
+	assert.Equal(t, "MINT", headers.Get(webhook.HeaderEvent))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+
+	ts, err := strconv.ParseInt(headers.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, webhook.Sign(testSecret, ts, body), headers.Get(webhook.HeaderSignature))
+}
+
+func TestDispatcher_FailureSchedulesRetry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	before := time.Now()
+	got := runOnce(t, srv.URL, pendingDelivery(7, 1))
+	after := time.Now()
+
+	assert.Equal(t, indexer.WebhookDeliveryPending, got.Status)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, got.StatusCode)
+	assert.Contains(t, got.Error, "500")
+	assert.Contains(t, got.Error, "boom")
+
+	// Second attempt: InitialBackoff doubled once.
+	assert.False(t, got.NextAttemptAt.Before(before.Add(20*time.Second)))
+	assert.False(t, got.NextAttemptAt.After(after.Add(20*time.Second)))
+}
+
+func TestDispatcher_BackoffCappedAtMax(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	cfg := testConfig()
+	cfg.MaxAttempts = 100
+	store := mocks.NewDeliveryStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.EXPECT().DueWebhookDeliveries(mock.Anything, mock.Anything, 10).
+		Return([]*indexer.WebhookDelivery{pendingDelivery(7, 20)}, nil).Once()
+	store.EXPECT().GetWebhookEndpoint(mock.Anything, int64(1)).
+		Return(&indexer.WebhookEndpoint{ID: 1, URL: srv.URL, Secret: testSecret}, nil).Once()
+	var got indexer.WebhookAttempt
+	store.EXPECT().RecordWebhookAttempt(mock.Anything, int64(7), mock.Anything).
+		Run(func(_ context.Context, _ int64, a indexer.WebhookAttempt) {
+			got = a
+			cancel()
+		}).Return(nil).Once()
+
+	d := webhook.NewDispatcher(store, cfg, webhook.NewNopMetrics(), zap.NewNop())
+	require.NoError(t, d.Run(ctx))
+
+	assert.Equal(t, indexer.WebhookDeliveryPending, got.Status)
+	assert.WithinDuration(t, got.At.Add(cfg.MaxBackoff), got.NextAttemptAt, 0)
+}
+
+func TestDispatcher_DeadLettersAfterMaxAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	got := runOnce(t, srv.URL, pendingDelivery(7, 2))
+
+	assert.Equal(t, indexer.WebhookDeliveryDead, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, got.StatusCode)
+	assert.NotEmpty(t, got.Error)
+}
+
+func TestDispatcher_UnreachableEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	got := runOnce(t, url, pendingDelivery(7, 0))
+
+	assert.Equal(t, indexer.WebhookDeliveryPending, got.Status)
+	assert.Equal(t, 0, got.StatusCode)
+	assert.NotEmpty(t, got.Error)
+}
+
+func TestDispatcher_SkipsDeletedEndpoint(t *testing.T) {
+	store := mocks.NewDeliveryStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.EXPECT().DueWebhookDeliveries(mock.Anything, mock.Anything, 10).
+		Return([]*indexer.WebhookDelivery{pendingDelivery(7, 0)}, nil).Once()
+	store.EXPECT().GetWebhookEndpoint(mock.Anything, int64(1)).
+		Run(func(context.Context, int64) { cancel() }).
+		Return(nil, nil).Once()
+	// RecordWebhookAttempt must not be called.
+
+	d := webhook.NewDispatcher(store, testConfig(), webhook.NewNopMetrics(), zap.NewNop())
+	require.NoError(t, d.Run(ctx))
+}
+
+func TestDispatcher_LoadErrorWaitsForNextWake(t *testing.T) {
+	store := mocks.NewDeliveryStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	store.EXPECT().DueWebhookDeliveries(mock.Anything, mock.Anything, 10).
+		RunAndReturn(func(context.Context, time.Time, int) ([]*indexer.WebhookDelivery, error) {
+			if calls.Add(1) == 2 {
+				cancel()
+				return nil, nil
+			}
+			return nil, errors.New("db down")
+		}).Times(2)
+
+	d := webhook.NewDispatcher(store, testConfig(), webhook.NewNopMetrics(), zap.NewNop())
+	d.Notify()
+	d.Notify() // merged with the first
+	require.NoError(t, d.Run(ctx))
+	assert.Equal(t, int32(2), calls.Load())
+}
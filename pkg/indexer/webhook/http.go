@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	apphttp "github.com/chainsafe/canton-middleware/pkg/app/http"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const (
+	maxRequestBodyBytes = 1 << 20 // 1MB
+
+	// defaultLimit / maxLimit match the indexer admin API's pagination caps.
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// HTTP wraps the Service to provide HTTP endpoints.
+type HTTP struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// RegisterPrivateRoutes registers the webhook admin API on the given chi router.
+// All routes are mounted under /indexer/v1/admin/webhooks and, like the rest of
+// the indexer admin API, are unauthenticated and meant for trusted callers only.
+func RegisterPrivateRoutes(r chi.Router, svc Service, logger *zap.Logger) {
+	h := &HTTP{service: svc, logger: logger}
+
+	r.Route("/indexer/v1/admin/webhooks", func(r chi.Router) {
+		r.Post("/", apphttp.HandleError(h.createEndpoint))
+		r.Get("/", apphttp.HandleError(h.listEndpoints))
+		r.Get("/{id}", apphttp.HandleError(h.getEndpoint))
+		r.Delete("/{id}", apphttp.HandleError(h.deleteEndpoint))
+		r.Get("/{id}/deliveries", apphttp.HandleError(h.listDeliveries))
+
+		r.Post("/deliveries/{deliveryID}/replay", apphttp.HandleError(h.replayDelivery))
+	})
+}
+
+func (h *HTTP) createEndpoint(w http.ResponseWriter, r *http.Request) error {
+	var req CreateEndpointRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return apperrors.BadRequestError(err, "invalid JSON")
+	}
+	e, err := h.service.CreateEndpoint(r.Context(), &req)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, e)
+	return nil
+}
+
+func (h *HTTP) listEndpoints(w http.ResponseWriter, r *http.Request) error {
+	p, err := parsePagination(r)
+	if err != nil {
+		return err
+	}
+	page, err := h.service.ListEndpoints(r.Context(), p)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, page)
+	return nil
+}
+
+func (h *HTTP) getEndpoint(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r, "id")
+	if err != nil {
+		return err
+	}
+	e, err := h.service.GetEndpoint(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, e)
+	return nil
+}
+
+func (h *HTTP) deleteEndpoint(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r, "id")
+	if err != nil {
+		return err
+	}
+	if err := h.service.DeleteEndpoint(r.Context(), id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// listDeliveries serves an endpoint's deliveries, newest first. ?status=
+// narrows them to pending, delivered, or dead.
+func (h *HTTP) listDeliveries(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r, "id")
+	if err != nil {
+		return err
+	}
+	p, err := parsePagination(r)
+	if err != nil {
+		return err
+	}
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", indexer.WebhookDeliveryPending, indexer.WebhookDeliveryDelivered, indexer.WebhookDeliveryDead:
+	default:
+		return apperrors.BadRequestError(nil, "status must be pending, delivered, or dead")
+	}
+	page, err := h.service.ListDeliveries(r.Context(), id, status, p)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, page)
+	return nil
+}
+
+func (h *HTTP) replayDelivery(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r, "deliveryID")
+	if err != nil {
+		return err
+	}
+	d, err := h.service.ReplayDelivery(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	h.writeJSON(w, d)
+	return nil
+}
+
+func parseID(r *http.Request, param string) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, param), 10, 64)
+	if err != nil || id < 1 {
+		return 0, apperrors.BadRequestError(nil, param+" must be a positive integer")
+	}
+	return id, nil
+}
+
+func parsePagination(r *http.Request) (indexer.Pagination, error) {
+	p := indexer.Pagination{Page: 1, Limit: defaultLimit}
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		v, err := strconv.Atoi(pageStr)
+		if err != nil || v < 1 {
+			return p, apperrors.BadRequestError(nil, "page must be an integer >= 1")
+		}
+		p.Page = v
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil || v < 1 || v > maxLimit {
+			return p, apperrors.BadRequestError(nil, "limit must be an integer between 1 and 200")
+		}
+		p.Limit = v
+	}
+	return p, nil
+}
+
+func (h *HTTP) writeJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to write JSON response", zap.Error(err))
+	}
+}
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook/mocks"
+)
+
+// ─── test helpers ─────────────────────────────────────────────────────────────
+
+type testEnv struct {
+	srv *httptest.Server
+	svc *mocks.Service
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	svcMock := mocks.NewService(t)
+
+	r := chi.NewRouter()
+	webhook.RegisterPrivateRoutes(r, svcMock, zap.NewNop())
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return &testEnv{srv: srv, svc: svcMock}
+}
+
+func (e *testEnv) do(t *testing.T, method, path string, body io.Reader) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, e.srv.URL+path, body)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeJSON[T any](t *testing.T, resp *http.Response) T {
+	t.Helper()
+	defer resp.Body.Close()
+	var v T
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&v))
+	return v
+}
+
+const basePath = "/indexer/v1/admin/webhooks"
+
+// ─── POST /indexer/v1/admin/webhooks ──────────────────────────────────────────
+
+func TestHTTP_CreateEndpoint(t *testing.T) {
+	t.Run("success returns endpoint with secret", func(t *testing.T) {
+		e := newTestEnv(t)
+		want := &webhook.CreateEndpointRequest{
+			URL:    "https://example.com/hook",
+			Filter: indexer.WebhookFilter{Parties: []string{"alice::1220"}},
+		}
+		e.svc.EXPECT().CreateEndpoint(mock.Anything, want).
+			Return(&indexer.WebhookEndpoint{ID: 1, URL: want.URL, Secret: testSecret, Filter: want.Filter}, nil)
+
+		resp := e.do(t, http.MethodPost, basePath,
+			strings.NewReader(`{"url":"https://example.com/hook","filter":{"parties":["alice::1220"]}}`))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		got := decodeJSON[indexer.WebhookEndpoint](t, resp)
+		assert.Equal(t, int64(1), got.ID)
+		assert.Equal(t, testSecret, got.Secret)
+	})
+
+	t.Run("unknown field returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+
+		resp := e.do(t, http.MethodPost, basePath, strings.NewReader(`{"url":"https://example.com","extra":1}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("validation error returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().CreateEndpoint(mock.Anything, mock.Anything).
+			Return(nil, apperr.BadRequestError(nil, "url must be an absolute http or https URL"))
+
+		resp := e.do(t, http.MethodPost, basePath, strings.NewReader(`{"url":"nope"}`))
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+// ─── GET /indexer/v1/admin/webhooks ───────────────────────────────────────────
+
+func TestHTTP_ListEndpoints(t *testing.T) {
+	t.Run("pagination params forwarded", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().ListEndpoints(mock.Anything, indexer.Pagination{Page: 2, Limit: 10}).
+			Return(&indexer.Page[*indexer.WebhookEndpoint]{Items: []*indexer.WebhookEndpoint{{ID: 1}}, Total: 11, Page: 2, Limit: 10}, nil)
+
+		resp := e.do(t, http.MethodGet, basePath+"?page=2&limit=10", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		page := decodeJSON[indexer.Page[*indexer.WebhookEndpoint]](t, resp)
+		assert.Equal(t, int64(11), page.Total)
+	})
+
+	t.Run("invalid limit returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+
+		resp := e.do(t, http.MethodGet, basePath+"?limit=999", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+// ─── GET / DELETE /indexer/v1/admin/webhooks/{id} ─────────────────────────────
+
+func TestHTTP_GetEndpoint(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().GetEndpoint(mock.Anything, int64(4)).
+			Return(&indexer.WebhookEndpoint{ID: 4, URL: "https://example.com"}, nil)
+
+		resp := e.do(t, http.MethodGet, basePath+"/4", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int64(4), decodeJSON[indexer.WebhookEndpoint](t, resp).ID)
+	})
+
+	t.Run("not found returns 404", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().GetEndpoint(mock.Anything, int64(4)).
+			Return(nil, apperr.ResourceNotFoundError(nil, "webhook endpoint not found"))
+
+		resp := e.do(t, http.MethodGet, basePath+"/4", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("non-numeric id returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+
+		resp := e.do(t, http.MethodGet, basePath+"/abc", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestHTTP_DeleteEndpoint(t *testing.T) {
+	t.Run("success returns 204", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().DeleteEndpoint(mock.Anything, int64(4)).Return(nil)
+
+		resp := e.do(t, http.MethodDelete, basePath+"/4", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().DeleteEndpoint(mock.Anything, int64(4)).Return(errors.New("db error"))
+
+		resp := e.do(t, http.MethodDelete, basePath+"/4", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+// ─── GET /indexer/v1/admin/webhooks/{id}/deliveries ───────────────────────────
+
+func TestHTTP_ListDeliveries(t *testing.T) {
+	t.Run("status filter forwarded", func(t *testing.T) {
+		e := newTestEnv(t)
+		p := indexer.Pagination{Page: 1, Limit: 50}
+		items := []*indexer.WebhookDelivery{pendingDelivery(9, 3)}
+		e.svc.EXPECT().ListDeliveries(mock.Anything, int64(4), indexer.WebhookDeliveryDead, p).
+			Return(&indexer.Page[*indexer.WebhookDelivery]{Items: items, Total: 1, Page: 1, Limit: 50}, nil)
+
+		resp := e.do(t, http.MethodGet, basePath+"/4/deliveries?status=dead", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		page := decodeJSON[indexer.Page[*indexer.WebhookDelivery]](t, resp)
+		require.Len(t, page.Items, 1)
+		assert.JSONEq(t, `{"type":"MINT","ledger_offset":42}`, string(page.Items[0].Payload))
+	})
+
+	t.Run("invalid status returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+
+		resp := e.do(t, http.MethodGet, basePath+"/4/deliveries?status=failed", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+// ─── POST /indexer/v1/admin/webhooks/deliveries/{deliveryID}/replay ───────────
+
+func TestHTTP_ReplayDelivery(t *testing.T) {
+	t.Run("success returns delivery", func(t *testing.T) {
+		e := newTestEnv(t)
+		e.svc.EXPECT().ReplayDelivery(mock.Anything, int64(9)).Return(pendingDelivery(9, 0), nil)
+
+		resp := e.do(t, http.MethodPost, basePath+"/deliveries/9/replay", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decodeJSON[indexer.WebhookDelivery](t, resp)
+		assert.Equal(t, indexer.WebhookDeliveryPending, got.Status)
+	})
+
+	t.Run("zero id returns 400", func(t *testing.T) {
+		e := newTestEnv(t)
+
+		resp := e.do(t, http.MethodPost, basePath+"/deliveries/0/replay", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const webhookServiceName = "WebhookService"
+
+// logService wraps Service with automatic logging of all method calls.
+type logService struct {
+	svc    Service
+	logger *zap.Logger
+}
+
+// NewLog creates a logging decorator for the webhook Service.
+func NewLog(svc Service, logger *zap.Logger) Service {
+	return &logService{svc: svc, logger: logger}
+}
+
+func (ls *logService) CreateEndpoint(ctx context.Context, req *CreateEndpointRequest) (e *indexer.WebhookEndpoint, err error) {
+	start := time.Now()
+	ls.logger.Info("CreateEndpoint started",
+		zap.String("service", webhookServiceName),
+		zap.String("url", req.URL),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("CreateEndpoint failed",
+				zap.String("service", webhookServiceName),
+				zap.String("url", req.URL),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("CreateEndpoint completed",
+				zap.String("service", webhookServiceName),
+				zap.String("url", req.URL),
+				zap.Int64("endpoint_id", e.ID),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.CreateEndpoint(ctx, req)
+}
+
+func (ls *logService) GetEndpoint(ctx context.Context, id int64) (e *indexer.WebhookEndpoint, err error) {
+	start := time.Now()
+	ls.logger.Info("GetEndpoint started",
+		zap.String("service", webhookServiceName),
+		zap.Int64("endpoint_id", id),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("GetEndpoint failed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("endpoint_id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("GetEndpoint completed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("endpoint_id", id),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.GetEndpoint(ctx, id)
+}
+
+func (ls *logService) ListEndpoints(ctx context.Context, p indexer.Pagination) (page *indexer.Page[*indexer.WebhookEndpoint], err error) {
+	start := time.Now()
+	ls.logger.Info("ListEndpoints started",
+		zap.String("service", webhookServiceName),
+		zap.Int("page", p.Page),
+		zap.Int("limit", p.Limit),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("ListEndpoints failed",
+				zap.String("service", webhookServiceName),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("ListEndpoints completed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("total", page.Total),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.ListEndpoints(ctx, p)
+}
+
+func (ls *logService) DeleteEndpoint(ctx context.Context, id int64) (err error) {
+	start := time.Now()
+	ls.logger.Info("DeleteEndpoint started",
+		zap.String("service", webhookServiceName),
+		zap.Int64("endpoint_id", id),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("DeleteEndpoint failed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("endpoint_id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("DeleteEndpoint completed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("endpoint_id", id),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.DeleteEndpoint(ctx, id)
+}
+
+func (ls *logService) ListDeliveries(
+	ctx context.Context, endpointID int64, status string, p indexer.Pagination,
+) (page *indexer.Page[*indexer.WebhookDelivery], err error) {
+	start := time.Now()
+	ls.logger.Info("ListDeliveries started",
+		zap.String("service", webhookServiceName),
+		zap.Int64("endpoint_id", endpointID),
+		zap.String("status", status),
+		zap.Int("page", p.Page),
+		zap.Int("limit", p.Limit),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("ListDeliveries failed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("endpoint_id", endpointID),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("ListDeliveries completed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("endpoint_id", endpointID),
+				zap.Int64("total", page.Total),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.ListDeliveries(ctx, endpointID, status, p)
+}
+
+func (ls *logService) ReplayDelivery(ctx context.Context, id int64) (d *indexer.WebhookDelivery, err error) {
+	start := time.Now()
+	ls.logger.Info("ReplayDelivery started",
+		zap.String("service", webhookServiceName),
+		zap.Int64("delivery_id", id),
+	)
+	defer func() {
+		if err != nil {
+			ls.logger.Error("ReplayDelivery failed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("delivery_id", id),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("ReplayDelivery completed",
+				zap.String("service", webhookServiceName),
+				zap.Int64("delivery_id", id),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}
+	}()
+	return ls.svc.ReplayDelivery(ctx, id)
+}
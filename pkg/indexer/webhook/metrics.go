@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	sharedmetrics "github.com/chainsafe/canton-middleware/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds Prometheus collectors for the Dispatcher.
+type Metrics struct {
+	// AttemptsTotal counts delivery attempts by outcome:
+	//   result=delivered – the endpoint answered 2xx
+	//   result=retry     – the attempt failed and a retry was scheduled
+	//   result=dead      – the attempt failed and the delivery was dead-lettered
+	AttemptsTotal *prometheus.CounterVec
+
+	// AttemptDuration is the per-request latency, failures included.
+	AttemptDuration prometheus.Histogram
+
+	// ErrorsTotal counts outbox store failures.
+	//   phase=load   – loading due deliveries or their endpoint failed
+	//   phase=record – recording an attempt outcome failed
+	ErrorsTotal *prometheus.CounterVec
+}
+
+// NewMetrics registers Dispatcher metrics against the given registerer.
+func NewMetrics(reg sharedmetrics.NamespacedRegisterer) *Metrics {
+	f := promauto.With(reg)
+	ns := reg.Namespace()
+	sub := "webhook_dispatcher"
+	return &Metrics{
+		AttemptsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "attempts_total",
+			Help: "Webhook delivery attempts, labeled by result (delivered, retry, dead)",
+		}, []string{"result"}),
+
+		AttemptDuration: f.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns, Subsystem: sub,
+			Name:    "attempt_duration_seconds",
+			Help:    "Per-request webhook delivery latency",
+			Buckets: sharedmetrics.DefaultDurationBuckets,
+		}),
+
+		ErrorsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: sub,
+			Name: "errors_total",
+			Help: "Webhook outbox store failures, labeled by phase (load, record)",
+		}, []string{"phase"}),
+	}
+}
+
+// NewNopMetrics returns a Metrics instance backed by a throwaway registry.
+// Use in tests where metric values are not asserted.
+func NewNopMetrics() *Metrics {
+	return NewMetrics(sharedmetrics.WithNamespace(prometheus.NewRegistry(), "nop"))
+}
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+const (
+	// minSecretLength is the shortest caller-chosen signing secret accepted.
+	minSecretLength = 16
+	// generatedSecretBytes is the entropy of a server-generated secret.
+	generatedSecretBytes = 32
+)
+
+// Store is the endpoint and delivery management interface the Service needs.
+//
+//go:generate mockery --name Store --output mocks --outpkg mocks --filename mock_store.go --with-expecter
+type Store interface {
+	CreateWebhookEndpoint(ctx context.Context, e *indexer.WebhookEndpoint) error
+	// GetWebhookEndpoint returns (nil, nil) when not found.
+	GetWebhookEndpoint(ctx context.Context, id int64) (*indexer.WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context, p indexer.Pagination) ([]*indexer.WebhookEndpoint, int64, error)
+	// DeleteWebhookEndpoint removes the endpoint and its deliveries; false when not found.
+	DeleteWebhookEndpoint(ctx context.Context, id int64) (bool, error)
+	// ListWebhookDeliveries returns an endpoint's deliveries, newest first.
+	// An empty status matches every status.
+	ListWebhookDeliveries(
+		ctx context.Context, endpointID int64, status string, p indexer.Pagination,
+	) ([]*indexer.WebhookDelivery, int64, error)
+	// ReplayWebhookDelivery makes a delivery pending again, due at now, with a
+	// fresh attempt budget. Returns (nil, nil) when not found.
+	ReplayWebhookDelivery(ctx context.Context, id int64, now time.Time) (*indexer.WebhookDelivery, error)
+}
+
+// CreateEndpointRequest registers a webhook endpoint. Secret is optional; one
+// is generated when it is empty.
+type CreateEndpointRequest struct {
+	URL         string                `json:"url"`
+	Secret      string                `json:"secret,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Filter      indexer.WebhookFilter `json:"filter"`
+}
+
+// Service manages webhook endpoints and their deliveries.
+//
+//go:generate mockery --name Service --output mocks --outpkg mocks --filename mock_service.go --with-expecter
+type Service interface {
+	// CreateEndpoint registers an endpoint. The returned endpoint carries its
+	// signing secret; it is never returned again.
+	CreateEndpoint(ctx context.Context, req *CreateEndpointRequest) (*indexer.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id int64) (*indexer.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, p indexer.Pagination) (*indexer.Page[*indexer.WebhookEndpoint], error)
+	DeleteEndpoint(ctx context.Context, id int64) error
+
+	ListDeliveries(
+		ctx context.Context, endpointID int64, status string, p indexer.Pagination,
+	) (*indexer.Page[*indexer.WebhookDelivery], error)
+	// ReplayDelivery queues a delivery — typically a dead-lettered one — to be
+	// sent again with a fresh attempt budget.
+	ReplayDelivery(ctx context.Context, id int64) (*indexer.WebhookDelivery, error)
+}
+
+// NewService creates a Service. notify, when non-nil, is called after a replay
+// so the dispatcher sends it without waiting for its next poll.
+func NewService(store Store, notify func(), logger *zap.Logger) Service {
+	return &svc{store: store, notify: notify, logger: logger}
+}
+
+type svc struct {
+	store  Store
+	notify func()
+	logger *zap.Logger
+}
+
+func (s *svc) CreateEndpoint(ctx context.Context, req *CreateEndpointRequest) (*indexer.WebhookEndpoint, error) {
+	if err := validateEndpointURL(req.URL); err != nil {
+		return nil, err
+	}
+	if err := validateFilter(&req.Filter); err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	switch {
+	case secret == "":
+		b := make([]byte, generatedSecretBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("generate secret: %w", err)
+		}
+		secret = hex.EncodeToString(b)
+	case len(secret) < minSecretLength:
+		return nil, apperrors.BadRequestError(nil, fmt.Sprintf("secret must be at least %d characters", minSecretLength))
+	}
+
+	e := &indexer.WebhookEndpoint{
+		URL:         req.URL,
+		Secret:      secret,
+		Description: req.Description,
+		Filter:      req.Filter,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.store.CreateWebhookEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *svc) GetEndpoint(ctx context.Context, id int64) (*indexer.WebhookEndpoint, error) {
+	e, err := s.store.GetWebhookEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, apperrors.ResourceNotFoundError(nil, "webhook endpoint not found")
+	}
+	return withoutSecret(e), nil
+}
+
+func (s *svc) ListEndpoints(ctx context.Context, p indexer.Pagination) (*indexer.Page[*indexer.WebhookEndpoint], error) {
+	items, total, err := s.store.ListWebhookEndpoints(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	for i, e := range items {
+		items[i] = withoutSecret(e)
+	}
+	return &indexer.Page[*indexer.WebhookEndpoint]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+}
+
+func (s *svc) DeleteEndpoint(ctx context.Context, id int64) error {
+	deleted, err := s.store.DeleteWebhookEndpoint(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return apperrors.ResourceNotFoundError(nil, "webhook endpoint not found")
+	}
+	return nil
+}
+
+func (s *svc) ListDeliveries(
+	ctx context.Context, endpointID int64, status string, p indexer.Pagination,
+) (*indexer.Page[*indexer.WebhookDelivery], error) {
+	e, err := s.store.GetWebhookEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, apperrors.ResourceNotFoundError(nil, "webhook endpoint not found")
+	}
+	items, total, err := s.store.ListWebhookDeliveries(ctx, endpointID, status, p)
+	if err != nil {
+		return nil, err
+	}
+	return &indexer.Page[*indexer.WebhookDelivery]{Items: items, Total: total, Page: p.Page, Limit: p.Limit}, nil
+}
+
+func (s *svc) ReplayDelivery(ctx context.Context, id int64) (*indexer.WebhookDelivery, error) {
+	d, err := s.store.ReplayWebhookDelivery(ctx, id, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, apperrors.ResourceNotFoundError(nil, "webhook delivery not found")
+	}
+	if s.notify != nil {
+		s.notify()
+	}
+	return d, nil
+}
+
+// withoutSecret returns a copy of e safe to show after creation.
+func withoutSecret(e *indexer.WebhookEndpoint) *indexer.WebhookEndpoint {
+	c := *e
+	c.Secret = ""
+	return &c
+}
+
+func validateEndpointURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apperrors.BadRequestError(err, "url must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// validateFilter rejects values that could never match. "expired" is not a
+// valid transfer status here: it is derived at read time and never raised.
+func validateFilter(f *indexer.WebhookFilter) error {
+	for _, t := range f.EventTypes {
+		switch t {
+		case indexer.WebhookEventMint, indexer.WebhookEventBurn,
+			indexer.WebhookEventTransfer, indexer.WebhookEventTransferStatus:
+		default:
+			return apperrors.BadRequestError(nil, "event_types must be MINT, BURN, TRANSFER, or TRANSFER_STATUS")
+		}
+	}
+	statuses := []string{
+		indexer.TransferStatusPending, indexer.TransferStatusCompleted,
+		indexer.TransferStatusCanceled, indexer.TransferStatusRejected,
+	}
+	for _, st := range f.TransferStatuses {
+		if !slices.Contains(statuses, st) {
+			return apperrors.BadRequestError(nil, "transfer_statuses must be pending, completed, canceled, or rejected")
+		}
+	}
+	for _, k := range f.Instruments {
+		if k.Admin == "" || k.ID == "" {
+			return apperrors.BadRequestError(nil, "instruments must have both admin and id")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook"
+	"github.com/chainsafe/canton-middleware/pkg/indexer/webhook/mocks"
+)
+
+func newSvc(t *testing.T) (webhook.Service, *mocks.Store, *int) {
+	t.Helper()
+	store := mocks.NewStore(t)
+	notified := new(int)
+	return webhook.NewService(store, func() { *notified++ }, zap.NewNop()), store, notified
+}
+
+// ─── CreateEndpoint ───────────────────────────────────────────────────────────
+
+func TestSvc_CreateEndpoint(t *testing.T) {
+	t.Run("generates a secret when none is given", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().CreateWebhookEndpoint(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, e *indexer.WebhookEndpoint) { e.ID = 5 }).
+			Return(nil)
+
+		got, err := svc.CreateEndpoint(context.Background(), &webhook.CreateEndpointRequest{
+			URL:    "https://example.com/hook",
+			Filter: indexer.WebhookFilter{EventTypes: []indexer.WebhookEventType{indexer.WebhookEventTransferStatus}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), got.ID)
+		assert.Len(t, got.Secret, 64)
+		assert.False(t, got.CreatedAt.IsZero())
+	})
+
+	t.Run("keeps a caller-chosen secret", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().CreateWebhookEndpoint(mock.Anything, mock.Anything).Return(nil)
+
+		got, err := svc.CreateEndpoint(context.Background(), &webhook.CreateEndpointRequest{
+			URL: "http://receiver:8080/", Secret: testSecret,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testSecret, got.Secret)
+	})
+
+	invalid := []struct {
+		name string
+		req  webhook.CreateEndpointRequest
+	}{
+		{"relative url", webhook.CreateEndpointRequest{URL: "/hook"}},
+		{"non-http scheme", webhook.CreateEndpointRequest{URL: "ftp://example.com/hook"}},
+		{"short secret", webhook.CreateEndpointRequest{URL: "https://example.com", Secret: "short"}},
+		{"unknown event type", webhook.CreateEndpointRequest{
+			URL:    "https://example.com",
+			Filter: indexer.WebhookFilter{EventTypes: []indexer.WebhookEventType{"LOCK"}},
+		}},
+		{"expired status", webhook.CreateEndpointRequest{
+			URL:    "https://example.com",
+			Filter: indexer.WebhookFilter{TransferStatuses: []string{indexer.TransferStatusExpired}},
+		}},
+		{"partial instrument", webhook.CreateEndpointRequest{
+			URL:    "https://example.com",
+			Filter: indexer.WebhookFilter{Instruments: []indexer.InstrumentKey{{ID: "DEMO"}}},
+		}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" → 400", func(t *testing.T) {
+			svc, _, _ := newSvc(t)
+			_, err := svc.CreateEndpoint(context.Background(), &tt.req)
+			require.Error(t, err)
+			assert.True(t, apperr.Is(err, apperr.CategoryDataError))
+		})
+	}
+}
+
+// ─── GetEndpoint / ListEndpoints ──────────────────────────────────────────────
+
+func TestSvc_GetEndpoint(t *testing.T) {
+	t.Run("strips the secret", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		stored := &indexer.WebhookEndpoint{ID: 1, URL: "https://example.com", Secret: testSecret}
+		store.EXPECT().GetWebhookEndpoint(mock.Anything, int64(1)).Return(stored, nil)
+
+		got, err := svc.GetEndpoint(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Empty(t, got.Secret)
+		assert.Equal(t, testSecret, stored.Secret, "store value must not be mutated")
+	})
+
+	t.Run("store returns nil → 404", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().GetWebhookEndpoint(mock.Anything, int64(1)).Return(nil, nil)
+
+		_, err := svc.GetEndpoint(context.Background(), 1)
+		require.Error(t, err)
+		assert.True(t, apperr.Is(err, apperr.CategoryResourceNotFound))
+	})
+}
+
+func TestSvc_ListEndpoints(t *testing.T) {
+	svc, store, _ := newSvc(t)
+	p := indexer.Pagination{Page: 1, Limit: 50}
+	store.EXPECT().ListWebhookEndpoints(mock.Anything, p).Return([]*indexer.WebhookEndpoint{
+		{ID: 1, Secret: testSecret}, {ID: 2, Secret: testSecret},
+	}, int64(2), nil)
+
+	page, err := svc.ListEndpoints(context.Background(), p)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), page.Total)
+	for _, e := range page.Items {
+		assert.Empty(t, e.Secret)
+	}
+}
+
+// ─── DeleteEndpoint ───────────────────────────────────────────────────────────
+
+func TestSvc_DeleteEndpoint(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().DeleteWebhookEndpoint(mock.Anything, int64(1)).Return(true, nil)
+		require.NoError(t, svc.DeleteEndpoint(context.Background(), 1))
+	})
+
+	t.Run("not found → 404", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().DeleteWebhookEndpoint(mock.Anything, int64(1)).Return(false, nil)
+
+		err := svc.DeleteEndpoint(context.Background(), 1)
+		require.Error(t, err)
+		assert.True(t, apperr.Is(err, apperr.CategoryResourceNotFound))
+	})
+}
+
+// ─── ListDeliveries ───────────────────────────────────────────────────────────
+
+func TestSvc_ListDeliveries(t *testing.T) {
+	p := indexer.Pagination{Page: 1, Limit: 50}
+
+	t.Run("success", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().GetWebhookEndpoint(mock.Anything, int64(1)).Return(&indexer.WebhookEndpoint{ID: 1}, nil)
+		store.EXPECT().ListWebhookDeliveries(mock.Anything, int64(1), indexer.WebhookDeliveryDead, p).
+			Return([]*indexer.WebhookDelivery{pendingDelivery(3, 10)}, int64(1), nil)
+
+		page, err := svc.ListDeliveries(context.Background(), 1, indexer.WebhookDeliveryDead, p)
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+	})
+
+	t.Run("unknown endpoint → 404", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().GetWebhookEndpoint(mock.Anything, int64(1)).Return(nil, nil)
+
+		_, err := svc.ListDeliveries(context.Background(), 1, "", p)
+		require.Error(t, err)
+		assert.True(t, apperr.Is(err, apperr.CategoryResourceNotFound))
+	})
+}
+
+// ─── ReplayDelivery ───────────────────────────────────────────────────────────
+
+func TestSvc_ReplayDelivery(t *testing.T) {
+	t.Run("success wakes the dispatcher", func(t *testing.T) {
+		svc, store, notified := newSvc(t)
+		store.EXPECT().ReplayWebhookDelivery(mock.Anything, int64(3), mock.Anything).
+			Return(pendingDelivery(3, 0), nil)
+
+		got, err := svc.ReplayDelivery(context.Background(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, indexer.WebhookDeliveryPending, got.Status)
+		assert.Equal(t, 1, *notified)
+	})
+
+	t.Run("not found → 404", func(t *testing.T) {
+		svc, store, notified := newSvc(t)
+		store.EXPECT().ReplayWebhookDelivery(mock.Anything, int64(3), mock.Anything).Return(nil, nil)
+
+		_, err := svc.ReplayDelivery(context.Background(), 3)
+		require.Error(t, err)
+		assert.True(t, apperr.Is(err, apperr.CategoryResourceNotFound))
+		assert.Zero(t, *notified)
+	})
+
+	t.Run("store error propagates", func(t *testing.T) {
+		svc, store, _ := newSvc(t)
+		store.EXPECT().ReplayWebhookDelivery(mock.Anything, int64(3), mock.Anything).
+			Return(nil, errors.New("db error"))
+
+		_, err := svc.ReplayDelivery(context.Background(), 3)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+)
+
+func TestWebhookFilter_Matches(t *testing.T) {
+	alice, bob := "alice::1220", "bob::1220"
+	demo := indexer.InstrumentKey{Admin: "admin::1220", ID: "DEMO"}
+
+	mint := &indexer.WebhookNotification{
+		Type: indexer.WebhookEventMint,
+		Event: &indexer.ParsedEvent{
+			InstrumentAdmin: demo.Admin,
+			InstrumentID:    demo.ID,
+			EventType:       indexer.EventMint,
+			ToPartyID:       &alice,
+		},
+	}
+	completed := &indexer.WebhookNotification{
+		Type: indexer.WebhookEventTransferStatus,
+		Transfer: &indexer.Transfer{
+			Status:          indexer.TransferStatusCompleted,
+			FromPartyID:     alice,
+			ToPartyID:       bob,
+			InstrumentAdmin: demo.Admin,
+			InstrumentID:    "OTHER",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter indexer.WebhookFilter
+		n      *indexer.WebhookNotification
+		want   bool
+	}{
+		{"empty filter matches event", indexer.WebhookFilter{}, mint, true},
+		{"empty filter matches transfer", indexer.WebhookFilter{}, completed, true},
+		{"party receiver", indexer.WebhookFilter{Parties: []string{alice}}, mint, true},
+		{"party absent", indexer.WebhookFilter{Parties: []string{bob}}, mint, false},
+		{"party sender on transfer", indexer.WebhookFilter{Parties: []string{bob}}, completed, true},
+		{"instrument match", indexer.WebhookFilter{Instruments: []indexer.InstrumentKey{demo}}, mint, true},
+		{"instrument mismatch", indexer.WebhookFilter{Instruments: []indexer.InstrumentKey{demo}}, completed, false},
+		{
+			"event type match",
+			indexer.WebhookFilter{EventTypes: []indexer.WebhookEventType{indexer.WebhookEventMint}},
+			mint, true,
+		},
+		{
+			"event type mismatch",
+			indexer.WebhookFilter{EventTypes: []indexer.WebhookEventType{indexer.WebhookEventBurn}},
+			mint, false,
+		},
+		{
+			"transfer status match",
+			indexer.WebhookFilter{TransferStatuses: []string{indexer.TransferStatusCompleted}},
+			completed, true,
+		},
+		{
+			"transfer status mismatch",
+			indexer.WebhookFilter{TransferStatuses: []string{indexer.TransferStatusPending}},
+			completed, false,
+		},
+		{
+			"transfer status ignores token events",
+			indexer.WebhookFilter{TransferStatuses: []string{indexer.TransferStatusPending}},
+			mint, true,
+		},
+		{
+			"all fields must match",
+			indexer.WebhookFilter{Parties: []string{alice}, EventTypes: []indexer.WebhookEventType{indexer.WebhookEventBurn}},
+			mint, false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(tt.n))
+		})
+	}
+}
@@ -333,34 +333,9 @@ func VerifyDER(compressedPubKey []byte, hash []byte, derSig []byte) error {
 	return nil
 }
 
-// KeyCipher encrypts and decrypts Canton private keys.
-type KeyCipher interface {
-	Encrypt(key []byte) (string, error)
-	Decrypt(encryptedKey string) ([]byte, error)
-}
-
-// MasterKeyCipher implements KeyCipher using AES-256-GCM with a 32-byte master key.
-type MasterKeyCipher struct {
-	masterKey []byte
-}
-
-// NewMasterKeyCipher creates a MasterKeyCipher from a 32-byte master key.
-func NewMasterKeyCipher(masterKey []byte) *MasterKeyCipher {
-	return &MasterKeyCipher{masterKey: masterKey}
-}
-
-// Encrypt encrypts a private key using AES-256-GCM.
-func (c *MasterKeyCipher) Encrypt(key []byte) (string, error) {
-	return encryptPrivateKey(key, c.masterKey)
-}
-
-// Decrypt decrypts an encrypted private key using AES-256-GCM.
-func (c *MasterKeyCipher) Decrypt(encryptedKey string) ([]byte, error) {
-	return decryptPrivateKey(encryptedKey, c.masterKey)
-}
-
 // encryptPrivateKey encrypts the private key using AES-256-GCM with the provided master key.
-// Returns the encrypted key as a base64-encoded string containing: nonce || ciphertext || tag
+// Returns the encrypted key as a base64-encoded string containing: nonce || ciphertext || tag.
+// This is the unversioned pre-envelope format; new keys are written by Keyring.Encrypt.
 func encryptPrivateKey(privateKey []byte, masterKey []byte) (string, error) {
 	if len(masterKey) != 32 {
 		return "", fmt.Errorf("master key must be 32 bytes (AES-256)")
@@ -397,7 +372,8 @@ func encryptPrivateKey(privateKey []byte, masterKey []byte) (string, error) {
 }
 
 // decryptPrivateKey decrypts an encrypted private key using AES-256-GCM.
-// The encrypted string should be base64-encoded containing: nonce || ciphertext || tag
+// The encrypted string should be base64-encoded containing: nonce || ciphertext || tag.
+// Keyring.Decrypt uses it for ciphertexts written before envelope encryption.
 func decryptPrivateKey(encrypted string, masterKey []byte) ([]byte, error) {
 	if len(masterKey) != 32 {
 		return nil, fmt.Errorf("master key must be 32 bytes (AES-256)")
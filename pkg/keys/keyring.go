@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+)
+
+// KeyCipher encrypts and decrypts Canton private keys. Ciphertexts are bound
+// to the owner they were encrypted for: decrypting with a different owner fails.
+type KeyCipher interface {
+	Encrypt(key []byte, owner KeyOwner) (string, error)
+	Decrypt(encryptedKey string, owner KeyOwner) ([]byte, error)
+}
+
+// KeyOwner identifies the user a custodial key belongs to. Both fields are
+// authenticated as associated data, so a ciphertext copied to another user's
+// row no longer decrypts.
+type KeyOwner struct {
+	EVMAddress    string
+	CantonPartyID string
+}
+
+// associatedData returns the record-level AAD. EVM addresses are compared
+// case-insensitively, as everywhere else in the user store.
+func (o KeyOwner) associatedData() []byte {
+	return []byte(envelopeVersion + "\x00" + strings.ToLower(o.EVMAddress) + "\x00" + o.CantonPartyID)
+}
+
+// Envelope format, version 1:
+//
+//	ck1:<key id>:<base64 wrapped data key>:<base64 sealed private key>
+//
+// Each record has its own random 256-bit data key. The private key is sealed
+// with the data key (AES-256-GCM, AAD = owner); the data key is wrapped with the
+// master key named by <key id> (AES-256-GCM, AAD = key id + owner). Both sealed
+// values are nonce || ciphertext || tag.
+//
+// Ciphertexts without the version prefix predate envelopes: the private key
+// sealed directly with a master key and no AAD.
+const (
+	envelopeVersion   = "ck1"
+	envelopeSeparator = ":"
+	dataKeySize       = 32
+)
+
+// ErrUnknownMasterKey is returned when a ciphertext names a master key that is
+// not in the keyring.
+var ErrUnknownMasterKey = errors.New("unknown master key")
+
+// MasterKey wraps and unwraps per-record data keys. AESMasterKey holds the key
+// in process memory; remote implementations (e.g. Vault Transit) wrap and
+// unwrap in a key-management service, so the master key never enters the
+// process. aad must be supplied unchanged to unwrap what was wrapped with it.
+type MasterKey interface {
+	WrapKey(dataKey, aad []byte) ([]byte, error)
+	UnwrapKey(wrapped, aad []byte) ([]byte, error)
+}
+
+// AESMasterKey is a MasterKey holding an AES-256 key in process memory.
+type AESMasterKey struct {
+	key []byte
+}
+
+// NewAESMasterKey creates an AESMasterKey from a 32-byte key.
+func NewAESMasterKey(key []byte) (*AESMasterKey, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("master key must be 32 bytes (AES-256), got %d", len(key))
+	}
+	return &AESMasterKey{key: key}, nil
+}
+
+// WrapKey seals dataKey with AES-256-GCM.
+func (m *AESMasterKey) WrapKey(dataKey, aad []byte) ([]byte, error) {
+	return seal(m.key, dataKey, aad)
+}
+
+// UnwrapKey opens a data key sealed by WrapKey.
+func (m *AESMasterKey) UnwrapKey(wrapped, aad []byte) ([]byte, error) {
+	return open(m.key, wrapped, aad)
+}
+
+// Keyring is a KeyCipher holding one or more master keys, identified by ID.
+// New keys are encrypted under the active master key; any master key in the
+// ring decrypts, so keys written before a rotation stay readable until they are
+// rewrapped.
+type Keyring struct {
+	masterKeys map[string]MasterKey
+	activeID   string
+	legacy     *AESMasterKey
+}
+
+// NewKeyring creates a Keyring from master keys keyed by ID. activeID selects
+// the key new ciphertexts are written under; legacyID, when not empty, selects
+// the key that decrypts unversioned pre-envelope ciphertexts, which must be an
+// *AESMasterKey since those were sealed with the raw key.
+func NewKeyring(masterKeys map[string]MasterKey, activeID, legacyID string) (*Keyring, error) {
+	for id := range masterKeys {
+		if id == "" || strings.Contains(id, envelopeSeparator) {
+			return nil, fmt.Errorf("invalid master key id %q", id)
+		}
+	}
+	if _, ok := masterKeys[activeID]; !ok {
+		return nil, fmt.Errorf("active master key %q: %w", activeID, ErrUnknownMasterKey)
+	}
+	ring := &Keyring{masterKeys: maps.Clone(masterKeys), activeID: activeID}
+	if legacyID != "" {
+		mk, ok := masterKeys[legacyID]
+		if !ok {
+			return nil, fmt.Errorf("legacy master key %q: %w", legacyID, ErrUnknownMasterKey)
+		}
+		if ring.legacy, ok = mk.(*AESMasterKey); !ok {
+			return nil, fmt.Errorf("legacy master key %q must be a local AES key", legacyID)
+		}
+	}
+	return ring, nil
+}
+
+// ActiveKeyID returns the ID of the master key new ciphertexts are written under.
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// Encrypt seals a 32-byte private key for owner under a fresh data key wrapped
+// by the active master key.
+func (k *Keyring) Encrypt(key []byte, owner KeyOwner) (string, error) {
+	if len(key) != 32 {
+		return "", fmt.Errorf("private key must be 32 bytes (secp256k1)")
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	sealed, err := seal(dataKey, key, owner.associatedData())
+	if err != nil {
+		return "", fmt.Errorf("failed to seal private key: %w", err)
+	}
+	return k.wrap(dataKey, sealed, owner)
+}
+
+// Decrypt opens a ciphertext written for owner by Encrypt, under any master key
+// in the ring, or an unversioned ciphertext when a legacy key is configured.
+func (k *Keyring) Decrypt(encryptedKey string, owner KeyOwner) ([]byte, error) {
+	env, err := parseEnvelope(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		if k.legacy == nil {
+			return nil, fmt.Errorf("unversioned ciphertext and no legacy master key configured")
+		}
+		return decryptPrivateKey(encryptedKey, k.legacy.key)
+	}
+	dataKey, err := k.unwrap(env, owner)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dataKey, env.sealed, owner.associatedData())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+	if len(plaintext) != 32 {
+		return nil, fmt.Errorf("decrypted key has wrong size: got %d, want 32", len(plaintext))
+	}
+	return plaintext, nil
+}
+
+// NeedsRewrap reports whether encryptedKey is not yet an envelope under the
+// active master key.
+func (k *Keyring) NeedsRewrap(encryptedKey string) bool {
+	env, err := parseEnvelope(encryptedKey)
+	return err != nil || env == nil || env.keyID != k.activeID
+}
+
+// Rewrap re-encrypts encryptedKey under the active master key. Envelopes keep
+// their data key and sealed private key — only the data key is rewrapped, so
+// the private key is never decrypted; unversioned ciphertexts are decrypted
+// with the legacy key and encrypted into a new envelope.
+func (k *Keyring) Rewrap(encryptedKey string, owner KeyOwner) (string, error) {
+	env, err := parseEnvelope(encryptedKey)
+	if err != nil {
+		return "", err
+	}
+	if env == nil {
+		key, err := k.Decrypt(encryptedKey, owner)
+		if err != nil {
+			return "", err
+		}
+		return k.Encrypt(key, owner)
+	}
+	dataKey, err := k.unwrap(env, owner)
+	if err != nil {
+		return "", err
+	}
+	return k.wrap(dataKey, env.sealed, owner)
+}
+
+// envelope is a parsed version-1 ciphertext.
+type envelope struct {
+	keyID   string
+	wrapped []byte
+	sealed  []byte
+}
+
+// parseEnvelope parses a version-1 ciphertext. It returns nil, nil for an
+// unversioned (pre-envelope) ciphertext.
+func parseEnvelope(encryptedKey string) (*envelope, error) {
+	if !strings.HasPrefix(encryptedKey, envelopeVersion+envelopeSeparator) {
+		return nil, nil
+	}
+	parts := strings.Split(encryptedKey, envelopeSeparator)
+	if len(parts) != 4 || parts[1] == "" {
+		return nil, fmt.Errorf("malformed %s envelope", envelopeVersion)
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode wrapped data key: %w", err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sealed private key: %w", err)
+	}
+	return &envelope{keyID: parts[1], wrapped: wrapped, sealed: sealed}, nil
+}
+
+// wrap wraps dataKey under the active master key and serializes the envelope.
+func (k *Keyring) wrap(dataKey, sealed []byte, owner KeyOwner) (string, error) {
+	wrapped, err := k.masterKeys[k.activeID].WrapKey(dataKey, wrapAssociatedData(k.activeID, owner))
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return strings.Join([]string{
+		envelopeVersion,
+		k.activeID,
+		base64.StdEncoding.EncodeToString(wrapped),
+		base64.StdEncoding.EncodeToString(sealed),
+	}, envelopeSeparator), nil
+}
+
+// unwrap recovers the data key of env with the master key it names.
+func (k *Keyring) unwrap(env *envelope, owner KeyOwner) ([]byte, error) {
+	masterKey, ok := k.masterKeys[env.keyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q: %w", env.keyID, ErrUnknownMasterKey)
+	}
+	dataKey, err := masterKey.UnwrapKey(env.wrapped, wrapAssociatedData(env.keyID, owner))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// wrapAssociatedData binds a wrapped data key to its master key ID and owner.
+func wrapAssociatedData(keyID string, owner KeyOwner) []byte {
+	return append([]byte(keyID+"\x00"), owner.associatedData()...)
+}
+
+// seal encrypts plaintext with AES-256-GCM, returning nonce || ciphertext || tag.
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts a value produced by seal.
+func open(key, sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
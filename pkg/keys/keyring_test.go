@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package keys
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	testOwner  = KeyOwner{EVMAddress: "0xAbC0000000000000000000000000000000000001", CantonPartyID: "alice::1220"}
+	otherOwner = KeyOwner{EVMAddress: "0xabc0000000000000000000000000000000000002", CantonPartyID: "bob::1220"}
+)
+
+func newTestKeyring(t *testing.T, activeID string, masterKeys map[string][]byte) *Keyring {
+	t.Helper()
+	legacyID := ""
+	if _, ok := masterKeys["k1"]; ok {
+		legacyID = "k1"
+	}
+	ring, err := NewKeyring(aesMasterKeys(t, masterKeys), activeID, legacyID)
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+	return ring
+}
+
+func aesMasterKeys(t *testing.T, raw map[string][]byte) map[string]MasterKey {
+	t.Helper()
+	masterKeys := make(map[string]MasterKey, len(raw))
+	for id, key := range raw {
+		mk, err := NewAESMasterKey(key)
+		if err != nil {
+			t.Fatalf("NewAESMasterKey failed: %v", err)
+		}
+		masterKeys[id] = mk
+	}
+	return masterKeys
+}
+
+// xorMasterKey is a stand-in for a remote MasterKey.
+type xorMasterKey byte
+
+func (x xorMasterKey) WrapKey(dataKey, _ []byte) ([]byte, error) {
+	out := make([]byte, len(dataKey))
+	for i, b := range dataKey {
+		out[i] = b ^ byte(x)
+	}
+	return out, nil
+}
+
+func (x xorMasterKey) UnwrapKey(wrapped, aad []byte) ([]byte, error) {
+	return x.WrapKey(wrapped, aad)
+}
+
+func mustMasterKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := GenerateMasterKey()
+	if err != nil {
+		t.Fatalf("GenerateMasterKey failed: %v", err)
+	}
+	return key
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	ring := newTestKeyring(t, "k1", map[string][]byte{"k1": mustMasterKey(t)})
+	kp, _ := GenerateCantonKeyPair()
+
+	encrypted, err := ring.Encrypt(kp.PrivateKey, testOwner)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, "ck1:k1:") {
+		t.Fatalf("expected a ck1 envelope under k1, got %q", encrypted)
+	}
+
+	// The EVM address is bound case-insensitively.
+	lower := KeyOwner{EVMAddress: strings.ToLower(testOwner.EVMAddress), CantonPartyID: testOwner.CantonPartyID}
+	decrypted, err := ring.Decrypt(encrypted, lower)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !bytes.Equal(decrypted, kp.PrivateKey) {
+		t.Fatal("decrypted key does not match")
+	}
+}
+
+func TestKeyring_DecryptForOtherOwnerFails(t *testing.T) {
+	ring := newTestKeyring(t, "k1", map[string][]byte{"k1": mustMasterKey(t)})
+	kp, _ := GenerateCantonKeyPair()
+
+	encrypted, err := ring.Encrypt(kp.PrivateKey, testOwner)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if _, err := ring.Decrypt(encrypted, otherOwner); err == nil {
+		t.Fatal("expected a ciphertext copied to another user to fail decryption")
+	}
+	samePartyOtherAddress := KeyOwner{EVMAddress: otherOwner.EVMAddress, CantonPartyID: testOwner.CantonPartyID}
+	if _, err := ring.Decrypt(encrypted, samePartyOtherAddress); err == nil {
+		t.Fatal("expected the EVM address to be bound")
+	}
+}
+
+func TestKeyring_RotationAndRewrap(t *testing.T) {
+	k1, k2 := mustMasterKey(t), mustMasterKey(t)
+	old := newTestKeyring(t, "k1", map[string][]byte{"k1": k1})
+	kp, _ := GenerateCantonKeyPair()
+	encrypted, err := old.Encrypt(kp.PrivateKey, testOwner)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	rotated := newTestKeyring(t, "k2", map[string][]byte{"k1": k1, "k2": k2})
+	if !rotated.NeedsRewrap(encrypted) {
+		t.Fatal("expected a k1 envelope to need rewrapping under k2")
+	}
+	// Old envelopes stay readable until rewrapped.
+	if _, err := rotated.Decrypt(encrypted, testOwner); err != nil {
+		t.Fatalf("Decrypt of k1 envelope failed: %v", err)
+	}
+
+	rewrapped, err := rotated.Rewrap(encrypted, testOwner)
+	if err != nil {
+		t.Fatalf("Rewrap failed: %v", err)
+	}
+	if rotated.NeedsRewrap(rewrapped) {
+		t.Fatal("expected the rewrapped envelope to be under the active key")
+	}
+	// The sealed private key is kept; only the data key is rewrapped.
+	if encrypted[strings.LastIndex(encrypted, ":"):] != rewrapped[strings.LastIndex(rewrapped, ":"):] {
+		t.Fatal("expected the sealed private key to be unchanged")
+	}
+
+	// Once k1 is retired the rewrapped key still decrypts, the old one does not.
+	retired := newTestKeyring(t, "k2", map[string][]byte{"k2": k2})
+	decrypted, err := retired.Decrypt(rewrapped, testOwner)
+	if err != nil {
+		t.Fatalf("Decrypt after retiring k1 failed: %v", err)
+	}
+	if !bytes.Equal(decrypted, kp.PrivateKey) {
+		t.Fatal("decrypted key does not match")
+	}
+	if _, err := retired.Decrypt(encrypted, testOwner); !errors.Is(err, ErrUnknownMasterKey) {
+		t.Fatalf("expected ErrUnknownMasterKey, got %v", err)
+	}
+}
+
+func TestKeyring_LegacyCiphertext(t *testing.T) {
+	k1 := mustMasterKey(t)
+	kp, _ := GenerateCantonKeyPair()
+	legacy, err := encryptPrivateKey(kp.PrivateKey, k1)
+	if err != nil {
+		t.Fatalf("encryptPrivateKey failed: %v", err)
+	}
+
+	ring := newTestKeyring(t, "k1", map[string][]byte{"k1": k1})
+	if !ring.NeedsRewrap(legacy) {
+		t.Fatal("expected a legacy ciphertext to need rewrapping")
+	}
+	decrypted, err := ring.Decrypt(legacy, testOwner)
+	if err != nil {
+		t.Fatalf("Decrypt of legacy ciphertext failed: %v", err)
+	}
+	if !bytes.Equal(decrypted, kp.PrivateKey) {
+		t.Fatal("decrypted key does not match")
+	}
+
+	rewrapped, err := ring.Rewrap(legacy, testOwner)
+	if err != nil {
+		t.Fatalf("Rewrap of legacy ciphertext failed: %v", err)
+	}
+	if _, err := ring.Decrypt(rewrapped, otherOwner); err == nil {
+		t.Fatal("expected the rewrapped legacy key to be bound to its owner")
+	}
+}
+
+func TestNewKeyring_Validation(t *testing.T) {
+	k1, err := NewAESMasterKey(mustMasterKey(t))
+	if err != nil {
+		t.Fatalf("NewAESMasterKey failed: %v", err)
+	}
+	cases := map[string]struct {
+		keys     map[string]MasterKey
+		activeID string
+		legacyID string
+	}{
+		"unknown active": {map[string]MasterKey{"k1": k1}, "k2", ""},
+		"unknown legacy": {map[string]MasterKey{"k1": k1}, "k1", "k0"},
+		"remote legacy":  {map[string]MasterKey{"k1": k1, "r1": xorMasterKey(7)}, "k1", "r1"},
+		"separator":      {map[string]MasterKey{"k:1": k1}, "k:1", ""},
+	}
+	for name, tc := range cases {
+		if _, err := NewKeyring(tc.keys, tc.activeID, tc.legacyID); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := NewAESMasterKey(make([]byte, 16)); err == nil {
+		t.Error("expected a short AES master key to be rejected")
+	}
+}
+
+func TestKeyring_RemoteMasterKey(t *testing.T) {
+	masterKeys := aesMasterKeys(t, map[string][]byte{"k1": mustMasterKey(t)})
+	masterKeys["r1"] = xorMasterKey(0x5a)
+	ring, err := NewKeyring(masterKeys, "r1", "k1")
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+	kp, _ := GenerateCantonKeyPair()
+
+	encrypted, err := ring.Encrypt(kp.PrivateKey, testOwner)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, "ck1:r1:") {
+		t.Fatalf("expected a ck1 envelope under r1, got %q", encrypted)
+	}
+	decrypted, err := ring.Decrypt(encrypted, testOwner)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !bytes.Equal(decrypted, kp.PrivateKey) {
+		t.Fatal("decrypted key does not match")
+	}
+	// The record-level AAD still binds the owner when the master key ignores it.
+	if _, err := ring.Decrypt(encrypted, otherOwner); err == nil {
+		t.Fatal("expected decryption for another owner to fail")
+	}
+}
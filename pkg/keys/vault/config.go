@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import "time"
+
+// Config configures a HashiCorp Vault Transit master key.
+type Config struct {
+	// KeyID is the keyring ID of the Transit key, recorded in every ciphertext
+	// it wraps. It must differ from the IDs of the local master keys.
+	KeyID string `yaml:"key_id" default:"vault" validate:"required,excludesall=:"`
+	// Address is the Vault server URL, e.g. https://vault.internal:8200.
+	Address string `yaml:"address" validate:"required,url"`
+	// Mount is the path the Transit secrets engine is mounted at.
+	Mount string `yaml:"mount" default:"transit" validate:"required"`
+	// KeyName is the Transit encryption key (aes256-gcm96 or chacha20-poly1305).
+	KeyName string `yaml:"key_name" validate:"required"`
+	// Namespace is the Vault Enterprise namespace; empty for none.
+	Namespace string `yaml:"namespace"`
+	// TokenEnv is the environment variable name containing the Vault token.
+	TokenEnv string `yaml:"token_env" default:"VAULT_TOKEN" validate:"required"`
+	// Timeout bounds a single Transit request.
+	Timeout time.Duration `yaml:"timeout" default:"10s"`
+}
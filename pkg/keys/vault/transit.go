@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package vault provides a keys.MasterKey backed by the HashiCorp Vault
+// Transit secrets engine.
+//
+// Data keys are wrapped and unwrapped by Vault, so the master key never leaves
+// it; the api-server only holds the per-record data key and the private key
+// while a request that needs them is served. Signing itself stays in process:
+// Transit has no secp256k1 key type, which Canton external parties use.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/chainsafe/canton-middleware/pkg/keys"
+)
+
+// Vault request headers.
+const (
+	headerToken     = "X-Vault-Token"
+	headerNamespace = "X-Vault-Namespace"
+)
+
+// maxErrorBodyBytes caps how much of an unparseable error response is reported.
+const maxErrorBodyBytes = 512
+
+var _ keys.MasterKey = (*Transit)(nil)
+
+// Transit is a keys.MasterKey that wraps data keys with a Vault Transit key.
+// The associated data is passed to Vault as associated_data, which binds the
+// wrapped data key to its owner on AEAD Transit keys.
+type Transit struct {
+	client    *http.Client
+	encrypt   string
+	decrypt   string
+	token     string
+	namespace string
+}
+
+// NewTransit creates a Transit master key authenticating with token.
+func NewTransit(cfg *Config, token string) (*Transit, error) {
+	if token == "" {
+		return nil, fmt.Errorf("vault token not set: env=%s", cfg.TokenEnv)
+	}
+	base, err := url.Parse(strings.TrimRight(cfg.Address, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid vault address %q", cfg.Address)
+	}
+	mount := strings.Trim(cfg.Mount, "/")
+	return &Transit{
+		client:    &http.Client{Timeout: cfg.Timeout},
+		encrypt:   base.JoinPath("v1", mount, "encrypt", cfg.KeyName).String(),
+		decrypt:   base.JoinPath("v1", mount, "decrypt", cfg.KeyName).String(),
+		token:     token,
+		namespace: cfg.Namespace,
+	}, nil
+}
+
+// WrapKey encrypts dataKey with the Transit key. The result is Vault's
+// ciphertext string ("vault:v<n>:..."), so data keys wrapped before a Transit
+// key rotation keep unwrapping.
+func (t *Transit) WrapKey(dataKey, aad []byte) ([]byte, error) {
+	var resp struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	err := t.call(t.encrypt, map[string]string{
+		"plaintext":       base64.StdEncoding.EncodeToString(dataKey),
+		"associated_data": base64.StdEncoding.EncodeToString(aad),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Ciphertext == "" {
+		return nil, fmt.Errorf("vault encrypt returned no ciphertext")
+	}
+	return []byte(resp.Ciphertext), nil
+}
+
+// UnwrapKey decrypts a data key wrapped by WrapKey.
+func (t *Transit) UnwrapKey(wrapped, aad []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	err := t.call(t.decrypt, map[string]string{
+		"ciphertext":      string(wrapped),
+		"associated_data": base64.StdEncoding.EncodeToString(aad),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode vault plaintext: %w", err)
+	}
+	return dataKey, nil
+}
+
+// call POSTs body to a Transit endpoint and decodes the response's data field
+// into out.
+func (t *Transit) call(endpoint string, body map[string]string, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode vault request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerToken, t.token)
+	if t.namespace != "" {
+		req.Header.Set(headerNamespace, t.namespace)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp.StatusCode, raw)
+	}
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return fmt.Errorf("failed to decode vault response data: %w", err)
+	}
+	return nil
+}
+
+// responseError describes a non-200 Vault response, preferring Vault's own
+// error messages.
+func responseError(status int, raw []byte) error {
+	var body struct {
+		Errors []string `json:"errors"`
+	}
+	if json.Unmarshal(raw, &body) == nil && len(body.Errors) > 0 {
+		return fmt.Errorf("vault returned %d: %s", status, strings.Join(body.Errors, "; "))
+	}
+	if len(raw) > maxErrorBodyBytes {
+		raw = raw[:maxErrorBodyBytes]
+	}
+	return fmt.Errorf("vault returned %d: %s", status, strings.TrimSpace(string(raw)))
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package vault_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainsafe/canton-middleware/pkg/keys"
+	"github.com/chainsafe/canton-middleware/pkg/keys/vault"
+)
+
+const (
+	testToken     = "s.test-token"
+	testNamespace = "canton"
+)
+
+var testOwner = keys.KeyOwner{EVMAddress: "0xabc0000000000000000000000000000000000001", CantonPartyID: "alice::1220"}
+
+// fakeTransit is a stand-in for the Vault Transit encrypt and decrypt endpoints
+// of one aes256-gcm96 key, including associated data.
+type fakeTransit struct {
+	t    *testing.T
+	gcm  cipher.AEAD
+	hits int
+}
+
+func newFakeTransit(t *testing.T) (*fakeTransit, *httptest.Server) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	f := &fakeTransit{t: t, gcm: gcm}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeTransit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.hits++
+	if r.Header.Get("X-Vault-Token") != testToken {
+		writeJSON(w, http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+	assert.Equal(f.t, testNamespace, r.Header.Get("X-Vault-Namespace"))
+
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"errors": []string{err.Error()}})
+		return
+	}
+	aad, _ := base64.StdEncoding.DecodeString(req["associated_data"])
+
+	switch r.URL.Path {
+	case "/v1/transit/encrypt/canton-keys":
+		plaintext, _ := base64.StdEncoding.DecodeString(req["plaintext"])
+		nonce := make([]byte, f.gcm.NonceSize())
+		_, _ = rand.Read(nonce)
+		sealed := f.gcm.Seal(nonce, nonce, plaintext, aad)
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]string{
+			"ciphertext": "vault:v1:" + base64.StdEncoding.EncodeToString(sealed),
+		}})
+	case "/v1/transit/decrypt/canton-keys":
+		sealed, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(req["ciphertext"], "vault:v1:"))
+		if len(sealed) < f.gcm.NonceSize() {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"errors": []string{"invalid ciphertext"}})
+			return
+		}
+		plaintext, err := f.gcm.Open(nil, sealed[:f.gcm.NonceSize()], sealed[f.gcm.NonceSize():], aad)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"errors": []string{"cipher: message authentication failed"}})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]string{
+			"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+		}})
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]any{"errors": []string{"no handler for route"}})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func testConfig(address string) *vault.Config {
+	return &vault.Config{
+		KeyID:     "vault",
+		Address:   address,
+		Mount:     "transit",
+		KeyName:   "canton-keys",
+		Namespace: testNamespace,
+		TokenEnv:  "VAULT_TOKEN",
+		Timeout:   5 * time.Second,
+	}
+}
+
+func newTransit(t *testing.T, address, token string) *vault.Transit {
+	t.Helper()
+	transit, err := vault.NewTransit(testConfig(address), token)
+	require.NoError(t, err)
+	return transit
+}
+
+func TestTransit_WrapUnwrap(t *testing.T) {
+	fake, srv := newFakeTransit(t)
+	transit := newTransit(t, srv.URL, testToken)
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, err := transit.WrapKey(dataKey, []byte("aad"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(wrapped), "vault:v1:"))
+
+	unwrapped, err := transit.UnwrapKey(wrapped, []byte("aad"))
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = transit.UnwrapKey(wrapped, []byte("other"))
+	require.ErrorContains(t, err, "message authentication failed")
+	assert.Equal(t, 3, fake.hits)
+}
+
+func TestTransit_ReportsVaultErrors(t *testing.T) {
+	_, srv := newFakeTransit(t)
+	transit := newTransit(t, srv.URL, "s.wrong")
+
+	_, err := transit.WrapKey(make([]byte, 32), nil)
+	require.ErrorContains(t, err, "vault returned 403: permission denied")
+}
+
+func TestNewTransit_Validation(t *testing.T) {
+	_, err := vault.NewTransit(testConfig("http://127.0.0.1:8200"), "")
+	require.ErrorContains(t, err, "VAULT_TOKEN")
+
+	_, err = vault.NewTransit(testConfig("not a url"), testToken)
+	require.Error(t, err)
+}
+
+func TestTransit_Keyring(t *testing.T) {
+	fake, srv := newFakeTransit(t)
+	local, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+	localKey, err := keys.NewAESMasterKey(local)
+	require.NoError(t, err)
+	masterKeys := map[string]keys.MasterKey{
+		"primary": localKey,
+		"vault":   newTransit(t, srv.URL, testToken),
+	}
+
+	before, err := keys.NewKeyring(masterKeys, "primary", "primary")
+	require.NoError(t, err)
+	ring, err := keys.NewKeyring(masterKeys, "vault", "primary")
+	require.NoError(t, err)
+
+	kp, err := keys.GenerateCantonKeyPair()
+	require.NoError(t, err)
+
+	// New keys are wrapped by Vault.
+	encrypted, err := ring.Encrypt(kp.PrivateKey, testOwner)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "ck1:vault:"))
+	decrypted, err := ring.Decrypt(encrypted, testOwner)
+	require.NoError(t, err)
+	assert.Equal(t, kp.PrivateKey, decrypted)
+
+	// Vault authenticates the owner through associated data.
+	_, err = ring.Decrypt(encrypted, keys.KeyOwner{EVMAddress: testOwner.EVMAddress, CantonPartyID: "bob::1220"})
+	require.Error(t, err)
+
+	// Keys under the local master key move to Vault by rewrapping.
+	local1, err := before.Encrypt(kp.PrivateKey, testOwner)
+	require.NoError(t, err)
+	require.True(t, ring.NeedsRewrap(local1))
+	hits := fake.hits
+	rewrapped, err := ring.Rewrap(local1, testOwner)
+	require.NoError(t, err)
+	assert.Equal(t, hits+1, fake.hits, "rewrapping a local envelope needs only a Vault encrypt")
+	assert.False(t, ring.NeedsRewrap(rewrapped))
+	decrypted, err = ring.Decrypt(rewrapped, testOwner)
+	require.NoError(t, err)
+	assert.Equal(t, kp.PrivateKey, decrypted)
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package apidb
+
+import (
+	"context"
+	"log"
+
+	authstore "github.com/chainsafe/canton-middleware/pkg/auth/store"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating auth session tables...")
+		if err := mghelper.CreateSchema(ctx, db, &authstore.SessionDao{}, &authstore.RefreshTokenDao{}); err != nil {
+			return err
+		}
+		// Session lookups by party (logout everywhere), by revocation time (the
+		// published revocation list) and by expiry (pruning); token lookups by
+		// session (pruning).
+		for _, idx := range []struct{ table, name, column string }{
+			{"auth_sessions", "idx_auth_sessions_canton_party_id", "canton_party_id"},
+			{"auth_sessions", "idx_auth_sessions_revoked_at", "revoked_at"},
+			{"auth_sessions", "idx_auth_sessions_expires_at", "expires_at"},
+			{"auth_refresh_tokens", "idx_auth_refresh_tokens_session_id", "session_id"},
+		} {
+			if err := mghelper.CreateIndex(ctx, db, idx.table, idx.name, idx.column); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping auth session tables...")
+		return mghelper.DropTables(ctx, db, &authstore.RefreshTokenDao{}, &authstore.SessionDao{})
+	})
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package apidb
+
+import (
+	"context"
+	"log"
+
+	nonceprovider "github.com/chainsafe/canton-middleware/pkg/auth/service/nonce_provider"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating auth nonces table...")
+		if err := mghelper.CreateSchema(ctx, db, &nonceprovider.NonceDao{}); err != nil {
+			return err
+		}
+		// Expiry sweeps and the live-nonce count filter on expires_at.
+		return mghelper.CreateIndex(ctx, db, "auth_nonces", "idx_auth_nonces_expires_at", "expires_at")
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping auth nonces table...")
+		return mghelper.DropTables(ctx, db, &nonceprovider.NonceDao{})
+	})
+}
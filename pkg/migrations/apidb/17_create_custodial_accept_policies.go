@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package apidb
+
+import (
+	"context"
+	"log"
+
+	"github.com/chainsafe/canton-middleware/pkg/custodial/policy"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+
+	"github.com/uptrace/bun"
+)
+
+// Migration 17 introduces custodial_accept_rules (accept-worker policy rules)
+// and custodial_accept_audit (every decision the worker took on an offer).
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating custodial accept policy tables...")
+		if err := mghelper.CreateSchema(ctx, db, &policy.RuleDao{}, &policy.AuditDao{}); err != nil {
+			return err
+		}
+
+		// (party_id, instrument, created_at) backs the daily-limit sum;
+		// (evm_address, id) the per-user audit listing; contract_id the
+		// per-offer lookup.
+		indexes := []struct {
+			name    string
+			columns []string
+		}{
+			{"idx_custodial_accept_audit_party_instrument",
+				[]string{"party_id", "instrument_admin", "instrument_id", "created_at"}},
+			{"idx_custodial_accept_audit_evm_address", []string{"evm_address", "id"}},
+			{"idx_custodial_accept_audit_contract_id", []string{"contract_id"}},
+		}
+		for _, idx := range indexes {
+			if _, err := db.NewCreateIndex().
+				Model(&policy.AuditDao{}).
+				Index(idx.name).
+				Column(idx.columns...).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping custodial accept policy tables...")
+		return mghelper.DropTables(ctx, db, &policy.AuditDao{}, &policy.RuleDao{})
+	})
+}
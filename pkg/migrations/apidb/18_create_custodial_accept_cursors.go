@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package apidb
+
+import (
+	"context"
+	"log"
+
+	"github.com/chainsafe/canton-middleware/pkg/custodial"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating custodial accept cursors table...")
+		return mghelper.CreateSchema(ctx, db, &custodial.CursorDao{})
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping custodial accept cursors table...")
+		return mghelper.DropTables(ctx, db, &custodial.CursorDao{})
+	})
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package apidb
+
+import (
+	"context"
+	"log"
+
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+	userservice "github.com/chainsafe/canton-middleware/pkg/user/service"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating self-custody migrations table...")
+		if err := mghelper.CreateSchema(ctx, db, &userservice.MigrationDao{}); err != nil {
+			return err
+		}
+		// The expiry sweep filters on expires_at.
+		return mghelper.CreateIndex(ctx, db, "self_custody_migrations", "idx_self_custody_migrations_expires_at", "expires_at")
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping self-custody migrations table...")
+		return mghelper.DropTables(ctx, db, &userservice.MigrationDao{})
+	})
+}
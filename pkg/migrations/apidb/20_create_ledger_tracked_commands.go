@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package apidb
+
+import (
+	"context"
+	"log"
+
+	"github.com/chainsafe/canton-middleware/pkg/commandstore"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating ledger_tracked_commands table...")
+		if err := mghelper.CreateSchema(ctx, db, &commandstore.CommandDao{}); err != nil {
+			return err
+		}
+		// Expiry deletes on submitted_at.
+		return mghelper.CreateModelIndexes(ctx, db, &commandstore.CommandDao{}, "submitted_at")
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping ledger_tracked_commands table...")
+		return mghelper.DropTables(ctx, db, &commandstore.CommandDao{})
+	})
+}
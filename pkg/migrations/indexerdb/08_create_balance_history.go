@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+// Migration 8 introduces indexer_balance_history and indexer_supply_history,
+// which the processor appends to for every committed transaction that changes a
+// balance or a token's supply/holder count.
+//
+// History before this migration cannot be recovered for holding-based balances
+// (archived holdings are deleted), so the current state is recorded once at the
+// stored offset, stamped with the migration time. Point-in-time queries for
+// earlier moments find no history and report zero.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating indexer_balance_history and indexer_supply_history tables...")
+		if err := mghelper.CreateSchema(ctx, db,
+			&indexerstore.BalanceHistoryDao{}, &indexerstore.SupplyHistoryDao{}); err != nil {
+			return err
+		}
+
+		// The primary keys serve the ?at_offset= lookups; these back ?at_time=.
+		indexes := []struct {
+			model   any
+			name    string
+			columns []string
+		}{
+			{&indexerstore.BalanceHistoryDao{}, "idx_indexer_balance_history_party_time",
+				[]string{"party_id", "instrument_admin", "instrument_id", "effective_time"}},
+			{&indexerstore.BalanceHistoryDao{}, "idx_indexer_balance_history_token_time",
+				[]string{"instrument_admin", "instrument_id", "effective_time"}},
+			{&indexerstore.SupplyHistoryDao{}, "idx_indexer_supply_history_token_time",
+				[]string{"instrument_admin", "instrument_id", "effective_time"}},
+		}
+		for _, idx := range indexes {
+			if _, err := db.NewCreateIndex().
+				Model(idx.model).
+				Index(idx.name).
+				Column(idx.columns...).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO indexer_balance_history
+				(party_id, instrument_admin, instrument_id, ledger_offset, amount, effective_time)
+			SELECT b.party_id, b.instrument_admin, b.instrument_id,
+				COALESCE((SELECT ledger_offset FROM indexer_offsets WHERE id = 1), 0), b.amount, now()
+			FROM indexer_balances b
+			ON CONFLICT DO NOTHING`); err != nil {
+			return err
+		}
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO indexer_supply_history
+				(instrument_admin, instrument_id, ledger_offset, total_supply, holder_count, effective_time)
+			SELECT t.instrument_admin, t.instrument_id,
+				COALESCE((SELECT ledger_offset FROM indexer_offsets WHERE id = 1), 0), t.total_supply, t.holder_count, now()
+			FROM indexer_tokens t
+			ON CONFLICT DO NOTHING`)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping indexer_balance_history and indexer_supply_history tables...")
+		return mghelper.DropTables(ctx, db, &indexerstore.BalanceHistoryDao{}, &indexerstore.SupplyHistoryDao{})
+	})
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+// Migration 9 introduces indexer_webhook_endpoints (registered receivers) and
+// indexer_webhook_deliveries (the outbox the processor writes to in the same
+// transaction as the data a delivery describes).
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating indexer_webhook_endpoints and indexer_webhook_deliveries tables...")
+		if err := mghelper.CreateSchema(ctx, db,
+			&indexerstore.WebhookEndpointDao{}, &indexerstore.WebhookDeliveryDao{}); err != nil {
+			return err
+		}
+
+		// (status, next_attempt_at) backs the dispatcher's due-delivery scan;
+		// (endpoint_id, id) backs the per-endpoint delivery listing.
+		indexes := []struct {
+			name    string
+			columns []string
+		}{
+			{"idx_indexer_webhook_deliveries_status_next_attempt", []string{"status", "next_attempt_at"}},
+			{"idx_indexer_webhook_deliveries_endpoint_id", []string{"endpoint_id", "id"}},
+		}
+		for _, idx := range indexes {
+			if _, err := db.NewCreateIndex().
+				Model(&indexerstore.WebhookDeliveryDao{}).
+				Index(idx.name).
+				Column(idx.columns...).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping indexer_webhook_endpoints and indexer_webhook_deliveries tables...")
+		return mghelper.DropTables(ctx, db, &indexerstore.WebhookDeliveryDao{}, &indexerstore.WebhookEndpointDao{})
+	})
+}
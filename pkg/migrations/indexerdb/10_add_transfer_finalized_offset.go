@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+)
+
+// Migration 10 records the offset at which an offer left "pending", so event
+// streams can replay transfer status transitions when a subscriber resumes.
+// Offers finalized before this migration keep a NULL offset and are not replayed.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("adding finalized_offset column to indexer_transfers...")
+		if _, err := db.NewAddColumn().
+			Model(&indexerstore.TransferDao{}).
+			ColumnExpr("finalized_offset BIGINT").
+			IfNotExists().
+			Exec(ctx); err != nil {
+			return err
+		}
+		_, err := db.NewCreateIndex().
+			Model(&indexerstore.TransferDao{}).
+			Index("idx_indexer_transfers_finalized_offset").
+			Column("finalized_offset").
+			IfNotExists().
+			Exec(ctx)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping finalized_offset column from indexer_transfers...")
+		_, err := db.NewDropColumn().
+			Model(&indexerstore.TransferDao{}).
+			Column("finalized_offset").
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+)
+
+// Migration 11 re-keys the per-party transfer indexes from created_at to
+// (ledger_offset, contract_id), the sort key of transfer lists since they
+// support cursor pagination, and indexes events by the same key.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("indexing indexer_transfers and indexer_events by (ledger_offset, contract_id)...")
+		for _, col := range []string{"from_party_id", "to_party_id"} {
+			if _, err := db.NewCreateIndex().
+				Model(&indexerstore.TransferDao{}).
+				Index("idx_indexer_transfers_"+col+"_offset").
+				Column(col, "ledger_offset", "contract_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+			if _, err := db.NewDropIndex().
+				Index("idx_indexer_transfers_" + col + "_created_at").
+				IfExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		_, err := db.NewCreateIndex().
+			Model(&indexerstore.EventDao{}).
+			Index("idx_indexer_events_offset_contract").
+			Column("ledger_offset", "contract_id").
+			IfNotExists().
+			Exec(ctx)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("restoring created_at indexes on indexer_transfers...")
+		if _, err := db.NewDropIndex().Index("idx_indexer_events_offset_contract").IfExists().Exec(ctx); err != nil {
+			return err
+		}
+		for _, col := range []string{"from_party_id", "to_party_id"} {
+			if _, err := db.NewCreateIndex().
+				Model(&indexerstore.TransferDao{}).
+				Index("idx_indexer_transfers_"+col+"_created_at").
+				Column(col, "created_at").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+			if _, err := db.NewDropIndex().
+				Index("idx_indexer_transfers_" + col + "_offset").
+				IfExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+// Migration 12 indexes indexer_events for the history filters: bridge lookups
+// by external_tx_id and time-range reports on effective_time.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("indexing indexer_events by external_tx_id and effective_time...")
+		return mghelper.CreateModelIndexes(ctx, db, &indexerstore.EventDao{}, "external_tx_id", "effective_time")
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping indexer_events filter indexes...")
+		return mghelper.DropModelIndexes(ctx, db, &indexerstore.EventDao{}, "external_tx_id", "effective_time")
+	})
+}
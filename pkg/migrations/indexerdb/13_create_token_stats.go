@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+// Migration 13 introduces indexer_token_stats, the hourly and daily activity
+// rollups per token, and indexer_token_active_parties, which deduplicates the
+// parties counted active in a bucket. The processor adds to both in the
+// transaction that indexes the activity.
+// Neither table references another, so the migration does not depend on the
+// tables of earlier migrations.
+//
+// Rollups start empty: activity indexed before this migration is not rolled
+// up, since the offset at which an offer was accepted has no recorded time.
+// Re-index from an earlier offset to populate them.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating indexer_token_stats and indexer_token_active_parties tables...")
+		return mghelper.CreateSchema(ctx, db, &indexerstore.TokenStatsDao{}, &indexerstore.TokenActivePartyDao{})
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping indexer_token_stats and indexer_token_active_parties tables...")
+		return mghelper.DropTables(ctx, db, &indexerstore.TokenStatsDao{}, &indexerstore.TokenActivePartyDao{})
+	})
+}
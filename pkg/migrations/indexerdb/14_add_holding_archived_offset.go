@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+)
+
+// Migration 14 keeps archived holdings, stamped with the offset that archived
+// them, instead of deleting them, so a rewind can restore the holdings active
+// at its target offset. Holdings archived before this migration are gone.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("adding archived_offset column to indexer_holdings...")
+		if _, err := db.NewAddColumn().
+			Model(&indexerstore.HoldingDao{}).
+			ColumnExpr("archived_offset BIGINT").
+			IfNotExists().
+			Exec(ctx); err != nil {
+			return err
+		}
+		_, err := db.NewCreateIndex().
+			Model(&indexerstore.HoldingDao{}).
+			Index("idx_indexer_holdings_archived_offset").
+			Column("archived_offset").
+			IfNotExists().
+			Exec(ctx)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping archived holdings and the archived_offset column of indexer_holdings...")
+		if _, err := db.NewDelete().
+			Model((*indexerstore.HoldingDao)(nil)).
+			Where("archived_offset IS NOT NULL").
+			Exec(ctx); err != nil {
+			return err
+		}
+		_, err := db.NewDropColumn().
+			Model(&indexerstore.HoldingDao{}).
+			Column("archived_offset").
+			Exec(ctx)
+		return err
+	})
+}
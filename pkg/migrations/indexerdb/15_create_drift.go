@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package indexerdb
+
+import (
+	"context"
+	"log"
+
+	"github.com/uptrace/bun"
+
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+)
+
+// Migration 15 introduces indexer_drift, where the drift verifier records every
+// difference it finds between the indexed state and the ledger.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		log.Println("creating indexer_drift table...")
+		if err := mghelper.CreateSchema(ctx, db, &indexerstore.DriftDao{}); err != nil {
+			return err
+		}
+		// (party_id, id) backs the per-party drift listing.
+		_, err := db.NewCreateIndex().
+			Model(&indexerstore.DriftDao{}).
+			Index("idx_indexer_drift_party_id").
+			Column("party_id", "id").
+			IfNotExists().
+			Exec(ctx)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		log.Println("dropping indexer_drift table...")
+		return mghelper.DropTables(ctx, db, &indexerstore.DriftDao{})
+	})
+}
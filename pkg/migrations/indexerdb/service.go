@@ -4,8 +4,37 @@
 package indexerdb
 
 import (
+	"context"
+
+	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/migrate"
 )
 
 // Migrations is the collection of all migrations for the indexer database.
+//
+// Migration files are zero-padded (01_, 02_, …): bun/migrate orders migrations
+// by comparing their names as strings.
 var Migrations = migrate.NewMigrations()
+
+// migrationsTable is the bun/migrate default table recording applied migrations.
+const migrationsTable = "bun_migrations"
+
+// RenameLegacyMigrations rewrites the recorded names of migrations applied
+// before the migration files were zero-padded ("1" … "9" become "01" … "09"),
+// so an existing database does not apply them a second time. It is a no-op on
+// a database without a migrations table and must run before the migrator.
+func RenameLegacyMigrations(ctx context.Context, db bun.IDB) error {
+	var exists bool
+	if err := db.NewRaw("SELECT to_regclass(?) IS NOT NULL", migrationsTable).Scan(ctx, &exists); err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	_, err := db.NewUpdate().
+		Table(migrationsTable).
+		Set("name = '0' || name").
+		Where("length(name) = 1").
+		Exec(ctx)
+	return err
+}
@@ -4,13 +4,17 @@ package migrations
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/migrate"
 
+	"github.com/chainsafe/canton-middleware/pkg/commandstore"
 	ethrpcstore "github.com/chainsafe/canton-middleware/pkg/ethrpc/store"
+	indexerstore "github.com/chainsafe/canton-middleware/pkg/indexer/store"
 	"github.com/chainsafe/canton-middleware/pkg/migrations/apidb"
+	"github.com/chainsafe/canton-middleware/pkg/migrations/indexerdb"
 	"github.com/chainsafe/canton-middleware/pkg/migrations/relayerdb"
 	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil"
 	reconcilerstore "github.com/chainsafe/canton-middleware/pkg/reconciler/store"
@@ -28,6 +32,32 @@ func modelCount(t *testing.T, ctx context.Context, db *bun.DB, model any) int {
 	return count
 }
 
+func columnExists(t *testing.T, ctx context.Context, db *bun.DB, table, column string) bool {
+	t.Helper()
+
+	exists, err := db.NewSelect().
+		TableExpr("information_schema.columns").
+		Where("table_name = ? AND column_name = ?", table, column).
+		Exists(ctx)
+	if err != nil {
+		t.Fatalf("column lookup failed for %s.%s: %v", table, column, err)
+	}
+	return exists
+}
+
+func indexExists(t *testing.T, ctx context.Context, db *bun.DB, index string) bool {
+	t.Helper()
+
+	exists, err := db.NewSelect().
+		TableExpr("pg_indexes").
+		Where("indexname = ?", index).
+		Exists(ctx)
+	if err != nil {
+		t.Fatalf("index lookup failed for %s: %v", index, err)
+	}
+	return exists
+}
+
 func TestAPIDBMigrations_Apply(t *testing.T) {
 	db, cleanup := mghelper.SetupTestDB(t)
 	defer cleanup()
@@ -56,6 +86,7 @@ func TestAPIDBMigrations_Apply(t *testing.T) {
 	modelCount(t, ctx, db, &ethrpcstore.EvmStateDao{})
 	modelCount(t, ctx, db, &ethrpcstore.EvmLogDao{})
 	modelCount(t, ctx, db, &reconcilerstore.UserTokenBalanceDao{})
+	modelCount(t, ctx, db, &commandstore.CommandDao{})
 }
 
 func TestRelayerDBMigrations_Apply(t *testing.T) {
@@ -79,6 +110,127 @@ func TestRelayerDBMigrations_Apply(t *testing.T) {
 
 	modelCount(t, ctx, db, &relayerstore.TransferDao{})
 	modelCount(t, ctx, db, &relayerstore.ChainStateDao{})
+	modelCount(t, ctx, db, &commandstore.CommandDao{})
+}
+
+// bun/migrate orders migrations by comparing names as strings, so the names
+// must be zero-padded for that order to be the numeric one.
+func TestIndexerDBMigrations_SortedNumerically(t *testing.T) {
+	prev := 0
+	for _, m := range indexerdb.Migrations.Sorted() {
+		n, err := strconv.Atoi(m.Name)
+		if err != nil {
+			t.Fatalf("migration %q: name is not a number: %v", m.Name, err)
+		}
+		if n <= prev {
+			t.Errorf("migration %s_%s sorts after migration %d", m.Name, m.Comment, prev)
+		}
+		prev = n
+	}
+}
+
+func TestIndexerDBMigrations_Apply(t *testing.T) {
+	db, cleanup := mghelper.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	migrator := migrate.NewMigrator(db, indexerdb.Migrations)
+
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	group, err := migrator.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate() failed: %v", err)
+	}
+	if got, want := len(group.Migrations), len(indexerdb.Migrations.Sorted()); got != want {
+		t.Errorf("expected %d migrations to run, got %d", want, got)
+	}
+
+	modelCount(t, ctx, db, &indexerstore.EventDao{})
+	modelCount(t, ctx, db, &indexerstore.TokenDao{})
+	modelCount(t, ctx, db, &indexerstore.BalanceDao{})
+	modelCount(t, ctx, db, &indexerstore.OffsetDao{})
+	modelCount(t, ctx, db, &indexerstore.HoldingDao{})
+	modelCount(t, ctx, db, &indexerstore.TransferDao{})
+	modelCount(t, ctx, db, &indexerstore.BalanceHistoryDao{})
+	modelCount(t, ctx, db, &indexerstore.SupplyHistoryDao{})
+	modelCount(t, ctx, db, &indexerstore.WebhookEndpointDao{})
+	modelCount(t, ctx, db, &indexerstore.WebhookDeliveryDao{})
+	modelCount(t, ctx, db, &indexerstore.TokenStatsDao{})
+	modelCount(t, ctx, db, &indexerstore.TokenActivePartyDao{})
+	modelCount(t, ctx, db, &indexerstore.DriftDao{})
+
+	if !columnExists(t, ctx, db, "indexer_transfers", "finalized_offset") {
+		t.Error("expected indexer_transfers.finalized_offset to exist")
+	}
+	if !columnExists(t, ctx, db, "indexer_holdings", "archived_offset") {
+		t.Error("expected indexer_holdings.archived_offset to exist")
+	}
+	if !indexExists(t, ctx, db, "idx_indexer_holdings_archived_offset") {
+		t.Error("expected the archived_offset index on indexer_holdings")
+	}
+	// Migration 11 replaces the created_at indexes migration 07 creates.
+	for _, col := range []string{"from_party_id", "to_party_id"} {
+		if !indexExists(t, ctx, db, "idx_indexer_transfers_"+col+"_offset") {
+			t.Errorf("expected the %s offset index on indexer_transfers", col)
+		}
+		if indexExists(t, ctx, db, "idx_indexer_transfers_"+col+"_created_at") {
+			t.Errorf("expected the %s created_at index on indexer_transfers to be dropped", col)
+		}
+	}
+	if !indexExists(t, ctx, db, "idx_indexer_events_offset_contract") {
+		t.Error("expected the (ledger_offset, contract_id) index on indexer_events")
+	}
+	if !indexExists(t, ctx, db, "idx_indexer_drift_party_id") {
+		t.Error("expected the party_id index on indexer_drift")
+	}
+	for _, col := range []string{"external_tx_id", "effective_time"} {
+		if !indexExists(t, ctx, db, "idx_indexer_events_"+col) {
+			t.Errorf("expected the %s index on indexer_events", col)
+		}
+	}
+}
+
+func TestIndexerDBMigrations_RenameLegacyMigrations(t *testing.T) {
+	db, cleanup := mghelper.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Without a migrations table there is nothing to rename.
+	if err := indexerdb.RenameLegacyMigrations(ctx, db); err != nil {
+		t.Fatalf("RenameLegacyMigrations() on an empty database failed: %v", err)
+	}
+
+	migrator := migrate.NewMigrator(db, indexerdb.Migrations)
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate() failed: %v", err)
+	}
+
+	// Record the applied migrations under their pre-padding names, as an
+	// existing database does.
+	if _, err := db.NewUpdate().
+		Table("bun_migrations").
+		Set("name = ltrim(name, '0')").
+		Where("name LIKE '0%'").
+		Exec(ctx); err != nil {
+		t.Fatalf("failed to restore legacy names: %v", err)
+	}
+
+	if err := indexerdb.RenameLegacyMigrations(ctx, db); err != nil {
+		t.Fatalf("RenameLegacyMigrations() failed: %v", err)
+	}
+	group, err := migrator.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate() after rename failed: %v", err)
+	}
+	if !group.IsZero() {
+		t.Errorf("expected no migrations to re-run, got %s", group)
+	}
 }
 
 func TestMigrations_Idempotency(t *testing.T) {
@@ -75,22 +75,20 @@ func (r *Reconciler) ReconcileAll(ctx context.Context) error {
 	r.logger.Info("Starting full reconciliation (supply + user balances)")
 	start := time.Now()
 
-	// Get all holdings from Canton to calculate total supply
-	holdings, err := r.cantonClient.GetAllHoldings(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get holdings from Canton: %w", err)
-	}
-
-	// Calculate total supply per token symbol
+	// Stream all holdings from Canton to calculate total supply per token symbol
 	supplyByToken := make(map[string]decimal.Decimal)
-	for _, holding := range holdings {
-		var amount decimal.Decimal
-		amount, err = decimal.NewFromString(holding.Amount)
+	var holdingsProcessed int
+	for holding, err := range r.cantonClient.AllHoldings(ctx, canton.HoldingFilter{}) {
 		if err != nil {
+			return fmt.Errorf("failed to get holdings from Canton: %w", err)
+		}
+		holdingsProcessed++
+		amount, parseErr := decimal.NewFromString(holding.Amount)
+		if parseErr != nil {
 			r.logger.Warn("Failed to parse holding amount",
 				zap.String("owner", holding.Owner),
 				zap.String("amount", holding.Amount),
-				zap.Error(err))
+				zap.Error(parseErr))
 			continue
 		}
 		symbol := holding.Symbol
@@ -102,17 +100,17 @@ func (r *Reconciler) ReconcileAll(ctx context.Context) error {
 
 	// Update per-token total supply from Canton (this is authoritative)
 	for symbol, supply := range supplyByToken {
-		if err = r.store.SetTotalSupply(ctx, symbol, supply.String()); err != nil {
+		if err := r.store.SetTotalSupply(ctx, symbol, supply.String()); err != nil {
 			return fmt.Errorf("failed to update total supply for %s: %w", symbol, err)
 		}
-		if err = r.store.UpdateLastReconciled(ctx, symbol); err != nil {
+		if err := r.store.UpdateLastReconciled(ctx, symbol); err != nil {
 			r.logger.Warn("Failed to update last reconciled timestamp",
 				zap.String("token", symbol), zap.Error(err))
 		}
 	}
 
 	r.logger.Info("Total supply reconciliation completed",
-		zap.Int("holdings_processed", len(holdings)),
+		zap.Int("holdings_processed", holdingsProcessed),
 		zap.Int("tokens_reconciled", len(supplyByToken)),
 		zap.Duration("duration", time.Since(start)))
 
@@ -169,17 +167,16 @@ func (r *Reconciler) ReconcileUserBalancesFromHoldings(ctx context.Context) erro
 	r.logger.Info("Starting holdings-based balance reconciliation")
 	start := time.Now()
 
-	// Get all holdings from Canton
-	holdings, err := r.cantonClient.GetAllHoldings(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get holdings from Canton: %w", err)
-	}
-
-	// Build a map of party -> token -> total balance
+	// Stream all holdings from Canton into a map of party -> token -> total balance
 	// Structure: map[partyID]map[tokenSymbol]decimal.Decimal
 	partyBalances := make(map[string]map[string]decimal.Decimal)
+	var holdingsProcessed int
 
-	for _, holding := range holdings {
+	for holding, err := range r.cantonClient.AllHoldings(ctx, canton.HoldingFilter{}) {
+		if err != nil {
+			return fmt.Errorf("failed to get holdings from Canton: %w", err)
+		}
+		holdingsProcessed++
 		if holding.Owner == "" || holding.Amount == "" {
 			continue
 		}
@@ -259,7 +256,7 @@ func (r *Reconciler) ReconcileUserBalancesFromHoldings(ctx context.Context) erro
 	}
 
 	r.logger.Info("Holdings-based balance reconciliation completed",
-		zap.Int("holdings_processed", len(holdings)),
+		zap.Int("holdings_processed", holdingsProcessed),
 		zap.Int("parties_with_holdings", len(partyBalances)),
 		zap.Int("users_updated", updatedCount),
 		zap.Duration("duration", time.Since(start)))
@@ -14,6 +14,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	canton "github.com/chainsafe/canton-middleware/pkg/cantonsdk/bridge"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	"github.com/chainsafe/canton-middleware/pkg/relayer"
 )
 
@@ -55,6 +56,20 @@ func (d *CantonDestination) SubmitTransfer(ctx context.Context, event *relayer.E
 		return "", true, nil
 	}
 
+	// A retry of a deposit whose earlier submission ended ambiguously must not
+	// resubmit it while that submission may still commit.
+	commandID := canton.DepositCommandID(event.SourceTxHash)
+	outcome, err := d.client.ResolveOutcome(ctx, commandID)
+	if err != nil {
+		return "", false, fmt.Errorf("resolve pending deposit outcome: %w", err)
+	}
+	switch outcome.Status {
+	case ledger.OutcomePending:
+		return "", false, fmt.Errorf("pending deposit %s is still being submitted", commandID)
+	case ledger.OutcomeCommitted:
+		return "", false, fmt.Errorf("pending deposit %s committed but is not yet visible", commandID)
+	}
+
 	pendingDeposit, err := d.client.CreatePendingDeposit(ctx, canton.CreatePendingDepositRequest{
 		Fingerprint: event.Recipient,
 		Amount:      amountStr,
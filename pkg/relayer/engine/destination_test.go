@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	bridgesdk "github.com/chainsafe/canton-middleware/pkg/cantonsdk/bridge"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	"github.com/chainsafe/canton-middleware/pkg/relayer"
 	"github.com/chainsafe/canton-middleware/pkg/relayer/engine"
 	relayermocks "github.com/chainsafe/canton-middleware/pkg/relayer/engine/mocks"
@@ -51,6 +52,8 @@ func TestCantonDestination_SubmitTransfer_Success(t *testing.T) {
 	}
 
 	bridgeClient.EXPECT().IsDepositProcessed(ctx, event.SourceTxHash).Return(false, nil)
+	bridgeClient.EXPECT().ResolveOutcome(ctx, bridgesdk.DepositCommandID(event.SourceTxHash)).
+		Return(&ledger.Outcome{Status: ledger.OutcomeUnknown}, nil)
 	bridgeClient.EXPECT().
 		CreatePendingDeposit(ctx, bridgesdk.CreatePendingDepositRequest{
 			Fingerprint: event.Recipient,
@@ -80,6 +83,8 @@ func TestCantonDestination_SubmitTransfer_CreatePendingDepositError(t *testing.T
 	bridgeClient := relayermocks.NewCantonBridge(t)
 
 	bridgeClient.EXPECT().IsDepositProcessed(ctx, "0xsource").Return(false, nil)
+	bridgeClient.EXPECT().ResolveOutcome(ctx, bridgesdk.DepositCommandID("0xsource")).
+		Return(&ledger.Outcome{Status: ledger.OutcomeRejected}, nil)
 	bridgeClient.EXPECT().
 		CreatePendingDeposit(ctx, bridgesdk.CreatePendingDepositRequest{Fingerprint: "fp", Amount: "0", EvmTxHash: "0xsource"}).
 		Return(nil, errors.New("boom"))
@@ -91,6 +96,29 @@ func TestCantonDestination_SubmitTransfer_CreatePendingDepositError(t *testing.T
 	}
 }
 
+func TestCantonDestination_SubmitTransfer_EarlierSubmissionOpen(t *testing.T) {
+	for _, status := range []ledger.OutcomeStatus{ledger.OutcomePending, ledger.OutcomeCommitted} {
+		t.Run(string(status), func(t *testing.T) {
+			ctx := context.Background()
+			bridgeClient := relayermocks.NewCantonBridge(t)
+			bridgeClient.EXPECT().IsDepositProcessed(ctx, "0xsource").Return(false, nil)
+			bridgeClient.EXPECT().ResolveOutcome(ctx, bridgesdk.DepositCommandID("0xsource")).
+				Return(&ledger.Outcome{Status: status}, nil)
+
+			// CreatePendingDeposit must not be resubmitted; the mock fails on any
+			// unexpected call.
+			destination := engine.NewCantonDestination(bridgeClient, relayer.ChainCanton, zap.NewNop())
+			_, skipped, err := destination.SubmitTransfer(ctx, &relayer.Event{SourceTxHash: "0xsource", Recipient: "fp", Amount: "0"})
+			if err == nil {
+				t.Fatalf("expected an error while the earlier submission is %s", status)
+			}
+			if skipped {
+				t.Fatalf("expected skipped=false")
+			}
+		})
+	}
+}
+
 func TestEthereumDestination_SubmitTransfer_InvalidAmount(t *testing.T) {
 	ctx := context.Background()
 	ethClient := relayermocks.NewEthereumBridgeClient(t)
@@ -7,6 +7,8 @@ import (
 
 	bridge "github.com/chainsafe/canton-middleware/pkg/cantonsdk/bridge"
 
+	ledger "github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -471,6 +473,65 @@ func (_c *CantonBridge_ProcessDepositAndMint_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ResolveOutcome provides a mock function with given fields: ctx, commandID
+func (_m *CantonBridge) ResolveOutcome(ctx context.Context, commandID string) (*ledger.Outcome, error) {
+	ret := _m.Called(ctx, commandID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveOutcome")
+	}
+
+	var r0 *ledger.Outcome
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ledger.Outcome, error)); ok {
+		return rf(ctx, commandID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ledger.Outcome); ok {
+		r0 = rf(ctx, commandID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ledger.Outcome)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, commandID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CantonBridge_ResolveOutcome_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveOutcome'
+type CantonBridge_ResolveOutcome_Call struct {
+	*mock.Call
+}
+
+// ResolveOutcome is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commandID string
+func (_e *CantonBridge_Expecter) ResolveOutcome(ctx interface{}, commandID interface{}) *CantonBridge_ResolveOutcome_Call {
+	return &CantonBridge_ResolveOutcome_Call{Call: _e.mock.On("ResolveOutcome", ctx, commandID)}
+}
+
+func (_c *CantonBridge_ResolveOutcome_Call) Run(run func(ctx context.Context, commandID string)) *CantonBridge_ResolveOutcome_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CantonBridge_ResolveOutcome_Call) Return(_a0 *ledger.Outcome, _a1 error) *CantonBridge_ResolveOutcome_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CantonBridge_ResolveOutcome_Call) RunAndReturn(run func(context.Context, string) (*ledger.Outcome, error)) *CantonBridge_ResolveOutcome_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StreamWithdrawalEvents provides a mock function with given fields: ctx, offset
 func (_m *CantonBridge) StreamWithdrawalEvents(ctx context.Context, offset string) <-chan *bridge.WithdrawalEvent {
 	ret := _m.Called(ctx, offset)
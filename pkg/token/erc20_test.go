@@ -10,6 +10,7 @@ import (
 	"time"
 
 	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	"github.com/chainsafe/canton-middleware/pkg/token"
 	"github.com/chainsafe/canton-middleware/pkg/token/mocks"
 	"github.com/chainsafe/canton-middleware/pkg/user"
@@ -268,6 +269,8 @@ func TestERC20_TransferFrom(t *testing.T) {
 		userStore.EXPECT().GetUserByEVMAddress(mock.Anything, toAddr.Hex()).Return(demoUser(), nil)
 
 		cantonToken := mocks.NewToken(t)
+		cantonToken.EXPECT().ResolveOutcome(mock.Anything, "test-cmd").
+			Return(&ledger.Outcome{CommandID: "test-cmd", Status: ledger.OutcomeUnknown}, nil)
 		cantonToken.EXPECT().TransferByFingerprint(mock.Anything, mock.Anything, promptUser().Fingerprint, demoUser().Fingerprint, "1", "PROMPT", 30*24*time.Hour).Return(nil)
 
 		svc := token.NewTokenService(newCfg(), nil, userStore, cantonToken)
@@ -283,6 +286,8 @@ func TestERC20_TransferFrom(t *testing.T) {
 		userStore.EXPECT().GetUserByEVMAddress(mock.Anything, toAddr.Hex()).Return(demoUser(), nil)
 
 		cantonToken := mocks.NewToken(t)
+		cantonToken.EXPECT().ResolveOutcome(mock.Anything, "test-cmd").
+			Return(&ledger.Outcome{CommandID: "test-cmd", Status: ledger.OutcomeUnknown}, nil)
 		cantonToken.EXPECT().TransferByFingerprint(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "PROMPT", 30*24*time.Hour).Return(nil)
 
 		svc := token.NewTokenService(newCfg(), nil, userStore, cantonToken)
@@ -335,6 +340,8 @@ func TestERC20_TransferFrom(t *testing.T) {
 		userStore.EXPECT().GetUserByEVMAddress(mock.Anything, toAddr.Hex()).Return(demoUser(), nil)
 
 		cantonToken := mocks.NewToken(t)
+		cantonToken.EXPECT().ResolveOutcome(mock.Anything, "test-cmd").
+			Return(&ledger.Outcome{CommandID: "test-cmd", Status: ledger.OutcomeUnknown}, nil)
 		cantonToken.EXPECT().TransferByFingerprint(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "PROMPT", 30*24*time.Hour).Return(errors.New("ledger down"))
 
 		svc := token.NewTokenService(newCfg(), nil, userStore, cantonToken)
@@ -345,6 +352,40 @@ func TestERC20_TransferFrom(t *testing.T) {
 		assert.Contains(t, err.Error(), "canton transfer failed")
 		assert.True(t, apperr.Is(err, apperr.CategoryDependencyFailure))
 	})
+
+	t.Run("committed earlier submission is not resubmitted", func(t *testing.T) {
+		userStore := mocks.NewUserStore(t)
+		userStore.EXPECT().GetUserByEVMAddress(mock.Anything, fromAddr.Hex()).Return(promptUser(), nil)
+		userStore.EXPECT().GetUserByEVMAddress(mock.Anything, toAddr.Hex()).Return(demoUser(), nil)
+
+		cantonToken := mocks.NewToken(t)
+		cantonToken.EXPECT().ResolveOutcome(mock.Anything, "test-cmd").
+			Return(&ledger.Outcome{CommandID: "test-cmd", Status: ledger.OutcomeCommitted}, nil)
+
+		svc := token.NewTokenService(newCfg(), nil, userStore, cantonToken)
+		erc20 := token.NewERC20(promptAddr, svc)
+
+		err := erc20.TransferFrom(ctx, "test-cmd", fromAddr, toAddr, amount)
+		require.NoError(t, err)
+	})
+
+	t.Run("pending earlier submission returns dependency error", func(t *testing.T) {
+		userStore := mocks.NewUserStore(t)
+		userStore.EXPECT().GetUserByEVMAddress(mock.Anything, fromAddr.Hex()).Return(promptUser(), nil)
+		userStore.EXPECT().GetUserByEVMAddress(mock.Anything, toAddr.Hex()).Return(demoUser(), nil)
+
+		cantonToken := mocks.NewToken(t)
+		cantonToken.EXPECT().ResolveOutcome(mock.Anything, "test-cmd").
+			Return(&ledger.Outcome{CommandID: "test-cmd", Status: ledger.OutcomePending}, nil)
+
+		svc := token.NewTokenService(newCfg(), nil, userStore, cantonToken)
+		erc20 := token.NewERC20(promptAddr, svc)
+
+		err := erc20.TransferFrom(ctx, "test-cmd", fromAddr, toAddr, amount)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "still pending")
+		assert.True(t, apperr.Is(err, apperr.CategoryDependencyFailure))
+	})
 }
 
 // ─── TestERC20_Approve ────────────────────────────────────────────────────────
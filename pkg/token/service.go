@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	apperr "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/ledger"
 	canton "github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
 	"github.com/chainsafe/canton-middleware/pkg/user"
 )
@@ -144,6 +145,21 @@ func (s *Service) transfer(ctx context.Context, idempotencyKey string, contract,
 		return apperr.BadRequestError(fmt.Errorf("failed to get recipient: %w", err), "failed to get recipient")
 	}
 
+	// A retry of a transfer whose earlier submission ended ambiguously must not
+	// commit it twice: resolve what became of that submission first.
+	outcome, err := s.cantonClient.ResolveOutcome(ctx, idempotencyKey)
+	if err != nil {
+		return apperr.DependencyError(fmt.Errorf("resolve canton transfer outcome: %w", err), "canton transfer failed")
+	}
+	switch outcome.Status {
+	case ledger.OutcomeCommitted:
+		return nil
+	case ledger.OutcomePending:
+		return apperr.DependencyError(
+			fmt.Errorf("canton transfer %s is still pending", idempotencyKey), "canton transfer pending",
+		)
+	}
+
 	err = s.cantonClient.TransferByFingerprint(ctx,
 		idempotencyKey,
 		fromUser.Fingerprint,
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+// maxSplitDenominations bounds a custodial split request. Each denomination is
+// its own ledger transaction, so the cap keeps a single HTTP call bounded.
+const maxSplitDenominations = 20
+
+// holdingOwner resolves the caller and checks the key mode required by the
+// endpoint and that the token is supported.
+func (s *TransferService) holdingOwner(ctx context.Context, evmAddr, tokenSymbol, keyMode string) (*user.User, error) {
+	if !s.allowedTokenSymbols[tokenSymbol] {
+		return nil, apperrors.BadRequestError(nil, "unsupported token")
+	}
+	u, err := s.userStore.GetUserByEVMAddress(ctx, evmAddr)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, apperrors.UnAuthorizedError(err, "user not found")
+		}
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	if u.KeyMode != keyMode {
+		return nil, apperrors.BadRequestError(nil, "this endpoint requires key_mode="+keyMode)
+	}
+	return u, nil
+}
+
+// PrepareMerge builds a self-transfer consolidating the caller's unlocked holdings
+// of the token into one. Complete it via the standard Execute endpoint.
+func (s *TransferService) PrepareMerge(ctx context.Context, evmAddr string, req *MergeRequest) (*PrepareResponse, error) {
+	u, err := s.holdingOwner(ctx, evmAddr, req.Token, user.KeyModeExternal)
+	if err != nil {
+		return nil, err
+	}
+
+	pt, err := s.cantonToken.PrepareMergeHoldings(ctx, u.CantonPartyID, req.Token)
+	if err != nil {
+		return nil, mapHoldingOpErr(err, "prepare merge")
+	}
+	return s.cachePrepared(pt)
+}
+
+// MergeCustodial consolidates a custodial user's unlocked holdings of the token
+// into one in a single server-signed call.
+func (s *TransferService) MergeCustodial(ctx context.Context, evmAddr string, req *MergeRequest) (*MergeResponse, error) {
+	u, err := s.holdingOwner(ctx, evmAddr, req.Token, user.KeyModeCustodial)
+	if err != nil {
+		return nil, err
+	}
+
+	sel, err := s.cantonToken.MergeHoldings(ctx, uuid.NewString(), u.CantonPartyID, req.Token)
+	if err != nil {
+		return nil, mapHoldingOpErr(err, "merge holdings")
+	}
+	return &MergeResponse{Status: "completed", MergedHoldings: len(sel.Holdings), Amount: sel.Total}, nil
+}
+
+// PrepareSplit builds a self-transfer splitting one of the caller's holdings into
+// req.Amount plus change. Complete it via the standard Execute endpoint.
+func (s *TransferService) PrepareSplit(ctx context.Context, evmAddr string, req *PrepareSplitRequest) (*PrepareResponse, error) {
+	u, err := s.holdingOwner(ctx, evmAddr, req.Token, user.KeyModeExternal)
+	if err != nil {
+		return nil, err
+	}
+
+	pt, err := s.cantonToken.PrepareSplitHolding(ctx, u.CantonPartyID, req.Token, req.ContractID, req.Amount)
+	if err != nil {
+		return nil, mapHoldingOpErr(err, "prepare split")
+	}
+	return s.cachePrepared(pt)
+}
+
+// SplitCustodial splits one of a custodial user's holdings into the requested
+// denominations, one server-signed self-transfer per denomination, each taken
+// from the previous step's change holding.
+func (s *TransferService) SplitCustodial(ctx context.Context, evmAddr string, req *SplitRequest) (*SplitResponse, error) {
+	if len(req.Amounts) == 0 || len(req.Amounts) > maxSplitDenominations {
+		return nil, apperrors.BadRequestError(nil, fmt.Sprintf("amounts must contain 1 to %d entries", maxSplitDenominations))
+	}
+	u, err := s.holdingOwner(ctx, evmAddr, req.Token, user.KeyModeCustodial)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkSplitTotal(ctx, u.CantonPartyID, req); err != nil {
+		return nil, err
+	}
+
+	source := req.ContractID
+	for i, amount := range req.Amounts {
+		source, err = s.cantonToken.SplitHolding(ctx, uuid.NewString(), u.CantonPartyID, req.Token, source, amount)
+		if err != nil {
+			return nil, mapHoldingOpErr(err, fmt.Sprintf("split %d of %d", i+1, len(req.Amounts)))
+		}
+	}
+	return &SplitResponse{Status: "completed", ChangeContractID: source}, nil
+}
+
+// checkSplitTotal rejects a multi-step split up front when the denominations do
+// not leave a positive remainder, so it cannot fail halfway through.
+func (s *TransferService) checkSplitTotal(ctx context.Context, partyID string, req *SplitRequest) error {
+	holdings, err := s.cantonToken.GetHoldings(ctx, partyID, req.Token)
+	if err != nil {
+		return mapTransferErr(err, "get holdings")
+	}
+	var source *token.Holding
+	for _, h := range holdings {
+		if h.ContractID == req.ContractID && !h.Locked {
+			source = h
+			break
+		}
+	}
+	if source == nil {
+		return apperrors.ResourceNotFoundError(nil, "holding not found")
+	}
+
+	sum := decimal.Zero
+	for _, a := range req.Amounts {
+		d, parseErr := decimal.NewFromString(a)
+		if parseErr != nil || !d.IsPositive() {
+			return apperrors.BadRequestError(nil, "invalid amount: must be a positive decimal number")
+		}
+		sum = sum.Add(d)
+	}
+	total, err := decimal.NewFromString(source.Amount)
+	if err != nil {
+		return fmt.Errorf("parse holding amount: %w", err)
+	}
+	if sum.GreaterThanOrEqual(total) {
+		return apperrors.BadRequestError(nil, "sum of amounts must be less than the holding amount")
+	}
+	return nil
+}
+
+// cachePrepared stores a prepared transaction for the Execute endpoint and
+// renders it as a PrepareResponse.
+func (s *TransferService) cachePrepared(pt *token.PreparedTransfer) (*PrepareResponse, error) {
+	if err := s.cache.Put(pt); err != nil {
+		return nil, apperrors.GeneralError(fmt.Errorf("too many pending transfers: %w", err))
+	}
+	return &PrepareResponse{
+		TransferID:      pt.TransferID,
+		TransactionHash: "0x" + hex.EncodeToString(pt.TransactionHash),
+		PartyID:         pt.PartyID,
+		ExpiresAt:       pt.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// mapHoldingOpErr maps merge/split failures to HTTP-shaped errors, falling back
+// to mapTransferErr for ledger rejections.
+func mapHoldingOpErr(err error, op string) error {
+	switch {
+	case errors.Is(err, token.ErrNothingToMerge):
+		return apperrors.BadRequestError(err, "nothing to merge: fewer than two unlocked holdings")
+	case errors.Is(err, token.ErrHoldingNotFound):
+		return apperrors.ResourceNotFoundError(err, "holding not found")
+	case errors.Is(err, token.ErrInsufficientBalance):
+		return apperrors.BadRequestError(err, "split amount must be less than the holding amount")
+	}
+	return mapTransferErr(err, op)
+}
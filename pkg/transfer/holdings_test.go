@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/transfer/mocks"
+)
+
+func TestTransferService_PrepareMerge_Success(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+
+	prepared := &token.PreparedTransfer{
+		TransferID:      "merge-1",
+		TransactionHash: []byte{0xab},
+		PartyID:         sender.CantonPartyID,
+		ExpiresAt:       time.Now().Add(time.Minute),
+	}
+	tok := mocks.NewToken(t)
+	tok.EXPECT().PrepareMergeHoldings(ctx, sender.CantonPartyID, "DEMO").Return(prepared, nil).Once()
+
+	cache := mocks.NewTransferCache(t)
+	cache.EXPECT().Put(prepared).Return(nil).Once()
+
+	svc := newTestService(t, tok, store, cache)
+	resp, err := svc.PrepareMerge(ctx, sender.EVMAddress, &MergeRequest{Token: "DEMO"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "merge-1", resp.TransferID)
+	assert.Equal(t, "0xab", resp.TransactionHash)
+}
+
+func TestTransferService_PrepareMerge_NothingToMerge(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().PrepareMergeHoldings(ctx, sender.CantonPartyID, "DEMO").
+		Return(nil, fmt.Errorf("wrapped: %w", token.ErrNothingToMerge)).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	_, err := svc.PrepareMerge(ctx, sender.EVMAddress, &MergeRequest{Token: "DEMO"})
+	assertServiceErrorCategory(t, err, apperrors.CategoryDataError)
+}
+
+func TestTransferService_MergeCustodial_RequiresCustodialKeyMode(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser() // external key mode
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+
+	svc := newTestService(t, mocks.NewToken(t), store, mocks.NewTransferCache(t))
+	_, err := svc.MergeCustodial(ctx, sender.EVMAddress, &MergeRequest{Token: "DEMO"})
+	assertServiceErrorCategory(t, err, apperrors.CategoryDataError)
+}
+
+func TestTransferService_MergeCustodial_Success(t *testing.T) {
+	ctx := context.Background()
+	sender := custodialSender()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().MergeHoldings(ctx, mock.Anything, sender.CantonPartyID, "DEMO").Return(&token.HoldingSelection{
+		Holdings: []*token.Holding{{ContractID: "a"}, {ContractID: "b"}, {ContractID: "c"}},
+		Total:    "42",
+	}, nil).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	resp, err := svc.MergeCustodial(ctx, sender.EVMAddress, &MergeRequest{Token: "DEMO"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.MergedHoldings)
+	assert.Equal(t, "42", resp.Amount)
+}
+
+func TestTransferService_PrepareSplit_HoldingNotFound(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().PrepareSplitHolding(ctx, sender.CantonPartyID, "DEMO", "missing", "5").
+		Return(nil, fmt.Errorf("%w: missing", token.ErrHoldingNotFound)).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	_, err := svc.PrepareSplit(ctx, sender.EVMAddress, &PrepareSplitRequest{Token: "DEMO", ContractID: "missing", Amount: "5"})
+	assertServiceErrorCategory(t, err, apperrors.CategoryResourceNotFound)
+}
+
+func TestTransferService_SplitCustodial_ChainsChangeHoldings(t *testing.T) {
+	ctx := context.Background()
+	sender := custodialSender()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().GetHoldings(ctx, sender.CantonPartyID, "DEMO").
+		Return([]*token.Holding{{ContractID: "src", Amount: "100", InstrumentID: "DEMO"}}, nil).Once()
+	// Each denomination is split off the previous step's change holding.
+	tok.EXPECT().SplitHolding(ctx, mock.Anything, sender.CantonPartyID, "DEMO", "src", "10").Return("change-1", nil).Once()
+	tok.EXPECT().SplitHolding(ctx, mock.Anything, sender.CantonPartyID, "DEMO", "change-1", "20").Return("change-2", nil).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	resp, err := svc.SplitCustodial(ctx, sender.EVMAddress, &SplitRequest{
+		Token: "DEMO", ContractID: "src", Amounts: []string{"10", "20"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "change-2", resp.ChangeContractID)
+}
+
+func TestTransferService_SplitCustodial_RejectsAmountsCoveringHolding(t *testing.T) {
+	ctx := context.Background()
+	sender := custodialSender()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().GetHoldings(ctx, sender.CantonPartyID, "DEMO").
+		Return([]*token.Holding{{ContractID: "src", Amount: "30", InstrumentID: "DEMO"}}, nil).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	_, err := svc.SplitCustodial(ctx, sender.EVMAddress, &SplitRequest{
+		Token: "DEMO", ContractID: "src", Amounts: []string{"10", "20"},
+	})
+	assertServiceErrorCategory(t, err, apperrors.CategoryDataError)
+}
@@ -38,19 +38,32 @@ type httpHandler struct {
 }
 
 // RegisterRoutes registers the non-custodial prepare/execute transfer endpoints.
-func RegisterRoutes(r chi.Router, svc Service, logger *zap.Logger) {
+//
+// readAuth, when non-nil, guards the incoming/outgoing/completed list endpoints
+// (typically jwt.RequireAuth). An end-user token scopes them to the token's EVM
+// address; without readAuth they take the address as a query parameter.
+func RegisterRoutes(r chi.Router, svc Service, readAuth func(http.Handler) http.Handler, logger *zap.Logger) {
 	h := &httpHandler{svc: svc, logger: logger}
 
 	r.Post("/api/v2/transfer/prepare", apphttp.HandleError(h.prepare))
 	r.Post("/api/v2/transfer/execute", apphttp.HandleError(h.execute))
 
+	// Pre-flight check: runs the prepare/custodial validation and holding
+	// selection without creating a prepared transfer.
+	r.Post("/api/v2/transfer/simulate", apphttp.HandleError(h.simulate))
+
 	// Custodial single-call transfer to an arbitrary recipient party id. The
 	// middleware holds the custodial user's Canton key and signs server-side.
 	r.Post("/api/v2/transfer/custodial", apphttp.HandleError(h.sendCustodial))
 
-	r.Get("/api/v2/transfer/incoming", apphttp.HandleError(h.listIncoming))
-	r.Get("/api/v2/transfer/outgoing", apphttp.HandleError(h.listOutgoing))
-	r.Get("/api/v2/transfer/completed", apphttp.HandleError(h.listCompleted))
+	r.Group(func(r chi.Router) {
+		if readAuth != nil {
+			r.Use(readAuth)
+		}
+		r.Get("/api/v2/transfer/incoming", apphttp.HandleError(h.listIncoming))
+		r.Get("/api/v2/transfer/outgoing", apphttp.HandleError(h.listOutgoing))
+		r.Get("/api/v2/transfer/completed", apphttp.HandleError(h.listCompleted))
+	})
 	r.Post("/api/v2/transfer/incoming/{contractID}/prepare", apphttp.HandleError(h.prepareAccept))
 	r.Post("/api/v2/transfer/incoming/{contractID}/execute", apphttp.HandleError(h.executeAccept))
 
@@ -60,6 +73,14 @@ func RegisterRoutes(r chi.Router, svc Service, logger *zap.Logger) {
 	r.Post("/api/v2/transfer/outgoing/{contractID}/withdraw/prepare", apphttp.HandleError(h.prepareWithdraw))
 	r.Post("/api/v2/transfer/outgoing/{contractID}/withdraw/execute", apphttp.HandleError(h.executeWithdraw))
 	r.Post("/api/v2/transfer/outgoing/{contractID}/withdraw/custodial", apphttp.HandleError(h.withdrawCustodial))
+
+	// Holding consolidation: merge a token's holdings into one, or split one
+	// holding into denominations. Both are self-transfers; the prepare variants
+	// are completed through /api/v2/transfer/execute.
+	r.Post("/api/v2/transfer/holdings/merge/prepare", apphttp.HandleError(h.prepareMerge))
+	r.Post("/api/v2/transfer/holdings/merge/custodial", apphttp.HandleError(h.mergeCustodial))
+	r.Post("/api/v2/transfer/holdings/split/prepare", apphttp.HandleError(h.prepareSplit))
+	r.Post("/api/v2/transfer/holdings/split/custodial", apphttp.HandleError(h.splitCustodial))
 }
 
 func (h *httpHandler) prepare(w http.ResponseWriter, r *http.Request) error {
@@ -97,6 +118,137 @@ func (h *httpHandler) prepare(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func (h *httpHandler) simulate(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := authenticateEVM(r)
+	if err != nil {
+		return err
+	}
+
+	var req SimulateRequest
+	if jsonErr := readJSON(r, &req); jsonErr != nil {
+		return jsonErr
+	}
+
+	// Only malformed requests are rejected here; rule violations (unknown
+	// recipient, insufficient balance, ...) come back as issues in the verdict.
+	if req.Amount == "" || req.Token == "" {
+		return apperrors.BadRequestError(nil, "amount and token are required")
+	}
+	if req.To != "" && !auth.ValidateEVMAddress(req.To) {
+		return apperrors.BadRequestError(nil, "invalid recipient address: must be a 0x-prefixed 40-hex-char EVM address")
+	}
+
+	resp, err := h.svc.Simulate(r.Context(), evmAddr, &req)
+	if err != nil {
+		return err
+	}
+
+	h.writeJSON(w, resp)
+	return nil
+}
+
+func (h *httpHandler) prepareMerge(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := authenticateEVM(r)
+	if err != nil {
+		return err
+	}
+
+	var req MergeRequest
+	if jsonErr := readJSON(r, &req); jsonErr != nil {
+		return jsonErr
+	}
+	if req.Token == "" {
+		return apperrors.BadRequestError(nil, "token is required")
+	}
+
+	resp, err := h.svc.PrepareMerge(r.Context(), evmAddr, &req)
+	if err != nil {
+		return err
+	}
+
+	h.writeJSON(w, resp)
+	return nil
+}
+
+func (h *httpHandler) mergeCustodial(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := authenticateEVM(r)
+	if err != nil {
+		return err
+	}
+
+	var req MergeRequest
+	if jsonErr := readJSON(r, &req); jsonErr != nil {
+		return jsonErr
+	}
+	if req.Token == "" {
+		return apperrors.BadRequestError(nil, "token is required")
+	}
+
+	resp, err := h.svc.MergeCustodial(r.Context(), evmAddr, &req)
+	if err != nil {
+		return err
+	}
+
+	h.writeJSON(w, resp)
+	return nil
+}
+
+func (h *httpHandler) prepareSplit(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := authenticateEVM(r)
+	if err != nil {
+		return err
+	}
+
+	var req PrepareSplitRequest
+	if jsonErr := readJSON(r, &req); jsonErr != nil {
+		return jsonErr
+	}
+	if req.Token == "" || req.ContractID == "" || req.Amount == "" {
+		return apperrors.BadRequestError(nil, "token, contract_id, and amount are required")
+	}
+	amt, parseErr := decimal.NewFromString(req.Amount)
+	if parseErr != nil || !amt.IsPositive() {
+		return apperrors.BadRequestError(nil, "invalid amount: must be a positive decimal number")
+	}
+
+	resp, err := h.svc.PrepareSplit(r.Context(), evmAddr, &req)
+	if err != nil {
+		return err
+	}
+
+	h.writeJSON(w, resp)
+	return nil
+}
+
+func (h *httpHandler) splitCustodial(w http.ResponseWriter, r *http.Request) error {
+	evmAddr, err := authenticateEVM(r)
+	if err != nil {
+		return err
+	}
+
+	var req SplitRequest
+	if jsonErr := readJSON(r, &req); jsonErr != nil {
+		return jsonErr
+	}
+	if req.Token == "" || req.ContractID == "" || len(req.Amounts) == 0 {
+		return apperrors.BadRequestError(nil, "token, contract_id, and amounts are required")
+	}
+	for _, a := range req.Amounts {
+		amt, parseErr := decimal.NewFromString(a)
+		if parseErr != nil || !amt.IsPositive() {
+			return apperrors.BadRequestError(nil, "invalid amount: must be a positive decimal number")
+		}
+	}
+
+	resp, err := h.svc.SplitCustodial(r.Context(), evmAddr, &req)
+	if err != nil {
+		return err
+	}
+
+	h.writeJSON(w, resp)
+	return nil
+}
+
 func (h *httpHandler) sendCustodial(w http.ResponseWriter, r *http.Request) error {
 	evmAddr, err := authenticateEVM(r)
 	if err != nil {
@@ -149,23 +301,19 @@ func (h *httpHandler) execute(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-// listIncoming is intentionally unauthenticated for now: callers pass the EVM
-// address as a query parameter and receive that user's pending offers. The
-// endpoint is read-only and exposes only data already visible to the receiver
-// party on-ledger, so dropping the signature requirement does not leak anything
-// new — it just lets clients (and tests) poll incoming offers without prior
-// signing-key access. Sensitive fields (party IDs) are truncated server-side.
+// listIncoming returns the caller's pending offers: the token's EVM address when
+// a user token is present, otherwise the ?address= query parameter (auth
+// disabled, or a service token). The endpoint is read-only and exposes only
+// data already visible to the receiver party on-ledger. Sensitive fields (party
+// IDs) are truncated server-side.
 //
 // Pagination is page/limit based to match the indexer envelope so each request
 // translates to exactly one indexer round-trip — no in-process buffering of all
 // offers for a receiver.
 func (h *httpHandler) listIncoming(w http.ResponseWriter, r *http.Request) error {
-	evmAddr := strings.TrimSpace(r.URL.Query().Get("address"))
-	if evmAddr == "" {
-		return apperrors.BadRequestError(nil, "address query parameter is required")
-	}
-	if !auth.ValidateEVMAddress(evmAddr) {
-		return apperrors.BadRequestError(nil, "invalid address: must be a 0x-prefixed 40-hex-char EVM address")
+	evmAddr, err := listAddress(r)
+	if err != nil {
+		return err
 	}
 
 	p, err := parseListPagination(r)
@@ -173,7 +321,7 @@ func (h *httpHandler) listIncoming(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
-	resp, err := h.svc.ListIncoming(r.Context(), auth.NormalizeAddress(evmAddr), p)
+	resp, err := h.svc.ListIncoming(r.Context(), evmAddr, p)
 	if err != nil {
 		return err
 	}
@@ -182,16 +330,13 @@ func (h *httpHandler) listIncoming(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
-// listOutgoing returns the queried address's outbound TransferOffers. Like
-// listIncoming it is unauthenticated and takes the EVM address as a query param;
-// ?status= filters by pending|expired|accepted|canceled|rejected|all (default all).
+// listOutgoing returns the caller's outbound TransferOffers, resolving the
+// address like listIncoming; ?status= filters by
+// pending|expired|accepted|canceled|rejected|all (default all).
 func (h *httpHandler) listOutgoing(w http.ResponseWriter, r *http.Request) error {
-	evmAddr := strings.TrimSpace(r.URL.Query().Get("address"))
-	if evmAddr == "" {
-		return apperrors.BadRequestError(nil, "address query parameter is required")
-	}
-	if !auth.ValidateEVMAddress(evmAddr) {
-		return apperrors.BadRequestError(nil, "invalid address: must be a 0x-prefixed 40-hex-char EVM address")
+	evmAddr, err := listAddress(r)
+	if err != nil {
+		return err
 	}
 
 	status, err := parseOutgoingStatus(r)
@@ -203,7 +348,7 @@ func (h *httpHandler) listOutgoing(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
-	resp, err := h.svc.ListOutgoing(r.Context(), auth.NormalizeAddress(evmAddr), status, p)
+	resp, err := h.svc.ListOutgoing(r.Context(), evmAddr, status, p)
 	if err != nil {
 		return err
 	}
@@ -212,15 +357,12 @@ func (h *httpHandler) listOutgoing(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
-// listCompleted returns the queried address's settled transfers across all tokens.
-// Unauthenticated, EVM address as a query param, party IDs truncated.
+// listCompleted returns the caller's settled transfers across all tokens,
+// resolving the address like listIncoming. Party IDs are truncated.
 func (h *httpHandler) listCompleted(w http.ResponseWriter, r *http.Request) error {
-	evmAddr := strings.TrimSpace(r.URL.Query().Get("address"))
-	if evmAddr == "" {
-		return apperrors.BadRequestError(nil, "address query parameter is required")
-	}
-	if !auth.ValidateEVMAddress(evmAddr) {
-		return apperrors.BadRequestError(nil, "invalid address: must be a 0x-prefixed 40-hex-char EVM address")
+	evmAddr, err := listAddress(r)
+	if err != nil {
+		return err
 	}
 
 	p, err := parseListPagination(r)
@@ -228,7 +370,7 @@ func (h *httpHandler) listCompleted(w http.ResponseWriter, r *http.Request) erro
 		return err
 	}
 
-	resp, err := h.svc.ListCompleted(r.Context(), auth.NormalizeAddress(evmAddr), p)
+	resp, err := h.svc.ListCompleted(r.Context(), evmAddr, p)
 	if err != nil {
 		return err
 	}
@@ -237,6 +379,29 @@ func (h *httpHandler) listCompleted(w http.ResponseWriter, r *http.Request) erro
 	return nil
 }
 
+// listAddress resolves whose transfers a list endpoint returns. A user token
+// pins it to the token's EVM address; an ?address= naming anyone else is
+// rejected. Otherwise — auth disabled, or a service token — ?address= is
+// required.
+func listAddress(r *http.Request) (string, error) {
+	query := strings.TrimSpace(r.URL.Query().Get("address"))
+	if query != "" && !auth.ValidateEVMAddress(query) {
+		return "", apperrors.BadRequestError(nil, "invalid address: must be a 0x-prefixed 40-hex-char EVM address")
+	}
+
+	if tokenAddr, _ := auth.EVMAddressFromContext(r.Context()); tokenAddr != "" {
+		if query != "" && auth.NormalizeAddress(query) != auth.NormalizeAddress(tokenAddr) {
+			return "", apperrors.ForbiddenError(nil, "address does not match the authenticated account")
+		}
+		return auth.NormalizeAddress(tokenAddr), nil
+	}
+
+	if query == "" {
+		return "", apperrors.BadRequestError(nil, "address query parameter is required")
+	}
+	return auth.NormalizeAddress(query), nil
+}
+
 // parseOutgoingStatus maps ?status= to a transfer status filter for the outgoing
 // endpoint. Empty or "all" means no status filter. "accepted" is accepted as a
 // backward-compatible alias for "completed".
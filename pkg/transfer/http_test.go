@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/indexer"
+	"github.com/chainsafe/canton-middleware/pkg/transfer"
+)
+
+const (
+	ownAddr   = "0x1111111111111111111111111111111111111111"
+	otherAddr = "0x2222222222222222222222222222222222222222"
+)
+
+// withInfo stands in for the JWT middleware, injecting a fixed identity.
+func withInfo(info *auth.AuthInfo) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.WithAuthInfo(r.Context(), info)))
+		})
+	}
+}
+
+// listService records the address each list call was made for. The package's
+// internal tests use the mocks package, which rules out a generated Service
+// mock here, so only the list methods are implemented.
+type listService struct {
+	transfer.Service
+	addrs []string
+}
+
+func (s *listService) ListIncoming(_ context.Context, addr string, _ indexer.Pagination) (*transfer.IncomingTransfersList, error) {
+	s.addrs = append(s.addrs, addr)
+	return &transfer.IncomingTransfersList{}, nil
+}
+
+func (s *listService) ListOutgoing(
+	_ context.Context, addr, _ string, _ indexer.Pagination,
+) (*transfer.OutgoingTransfersList, error) {
+	s.addrs = append(s.addrs, addr)
+	return &transfer.OutgoingTransfersList{}, nil
+}
+
+func (s *listService) ListCompleted(
+	_ context.Context, addr string, _ indexer.Pagination,
+) (*transfer.CompletedTransfersList, error) {
+	s.addrs = append(s.addrs, addr)
+	return &transfer.CompletedTransfersList{}, nil
+}
+
+func newListServer(t *testing.T, readAuth func(http.Handler) http.Handler) (*httptest.Server, *listService) {
+	t.Helper()
+	svc := &listService{}
+	r := chi.NewRouter()
+	transfer.RegisterRoutes(r, svc, readAuth, zap.NewNop())
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, svc
+}
+
+func get(t *testing.T, srv *httptest.Server, path string) int {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+path, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestHTTP_ListIncoming_AddressScoping(t *testing.T) {
+	user := withInfo(&auth.AuthInfo{EVMAddress: ownAddr})
+
+	t.Run("user token supplies the address", func(t *testing.T) {
+		srv, svc := newListServer(t, user)
+		assert.Equal(t, http.StatusOK, get(t, srv, "/api/v2/transfer/incoming"))
+		assert.Equal(t, []string{ownAddr}, svc.addrs)
+	})
+
+	t.Run("matching address is accepted", func(t *testing.T) {
+		srv, svc := newListServer(t, user)
+		assert.Equal(t, http.StatusOK, get(t, srv, "/api/v2/transfer/incoming?address="+ownAddr))
+		assert.Equal(t, []string{ownAddr}, svc.addrs)
+	})
+
+	t.Run("another address is forbidden", func(t *testing.T) {
+		srv, svc := newListServer(t, user)
+		assert.Equal(t, http.StatusForbidden, get(t, srv, "/api/v2/transfer/incoming?address="+otherAddr))
+		assert.Empty(t, svc.addrs)
+	})
+
+	t.Run("service token queries any address", func(t *testing.T) {
+		srv, svc := newListServer(t, withInfo(&auth.AuthInfo{Service: true}))
+		assert.Equal(t, http.StatusOK, get(t, srv, "/api/v2/transfer/incoming?address="+otherAddr))
+		assert.Equal(t, []string{otherAddr}, svc.addrs)
+	})
+
+	t.Run("without auth the address is required", func(t *testing.T) {
+		srv, _ := newListServer(t, nil)
+		assert.Equal(t, http.StatusBadRequest, get(t, srv, "/api/v2/transfer/incoming"))
+	})
+
+	t.Run("invalid address returns 400", func(t *testing.T) {
+		srv, _ := newListServer(t, user)
+		assert.Equal(t, http.StatusBadRequest, get(t, srv, "/api/v2/transfer/incoming?address=0xnope"))
+	})
+}
+
+func TestHTTP_ListOutgoingAndCompleted_UseTokenAddress(t *testing.T) {
+	srv, svc := newListServer(t, withInfo(&auth.AuthInfo{EVMAddress: ownAddr}))
+	assert.Equal(t, http.StatusOK, get(t, srv, "/api/v2/transfer/outgoing"))
+	assert.Equal(t, http.StatusOK, get(t, srv, "/api/v2/transfer/completed"))
+	assert.Equal(t, http.StatusForbidden, get(t, srv, "/api/v2/transfer/completed?address="+otherAddr))
+	assert.Equal(t, []string{ownAddr, ownAddr}, svc.addrs)
+}
@@ -409,6 +409,186 @@ func (ls *logService) WithdrawCustodial(
 	return ls.svc.WithdrawCustodial(ctx, evmAddr, contractID)
 }
 
+// Simulate wraps the service method with logging.
+func (ls *logService) Simulate(
+	ctx context.Context, senderEVMAddr string, req *SimulateRequest,
+) (resp *SimulateResponse, err error) {
+	start := time.Now()
+	ls.logger.Info("Simulate started",
+		zap.String("service", transferServiceName),
+		zap.String("method", "Simulate"),
+		zap.String("sender", senderEVMAddr),
+		zap.String("to", req.To),
+		zap.String("to_party_id", req.ToPartyID),
+		zap.String("amount", req.Amount),
+		zap.String("token", req.Token),
+	)
+	defer func() {
+		duration := time.Since(start)
+		if err != nil {
+			ls.logger.Error("Simulate failed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "Simulate"),
+				zap.String("sender", senderEVMAddr),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("Simulate completed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "Simulate"),
+				zap.String("sender", senderEVMAddr),
+				zap.Bool("allowed", resp.Allowed),
+				zap.Int("issues", len(resp.Issues)),
+				zap.Int("inputs", len(resp.HoldingsToConsume)),
+				zap.Duration("duration", duration),
+			)
+		}
+	}()
+	return ls.svc.Simulate(ctx, senderEVMAddr, req)
+}
+
+// PrepareMerge wraps the service method with logging.
+func (ls *logService) PrepareMerge(
+	ctx context.Context, evmAddr string, req *MergeRequest,
+) (resp *PrepareResponse, err error) {
+	start := time.Now()
+	ls.logger.Info("PrepareMerge started",
+		zap.String("service", transferServiceName),
+		zap.String("method", "PrepareMerge"),
+		zap.String("evm_addr", evmAddr),
+		zap.String("token", req.Token),
+	)
+	defer func() {
+		duration := time.Since(start)
+		if err != nil {
+			ls.logger.Error("PrepareMerge failed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "PrepareMerge"),
+				zap.String("evm_addr", evmAddr),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("PrepareMerge completed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "PrepareMerge"),
+				zap.String("evm_addr", evmAddr),
+				zap.String("transfer_id", resp.TransferID),
+				zap.Duration("duration", duration),
+			)
+		}
+	}()
+	return ls.svc.PrepareMerge(ctx, evmAddr, req)
+}
+
+// MergeCustodial wraps the service method with logging.
+func (ls *logService) MergeCustodial(
+	ctx context.Context, evmAddr string, req *MergeRequest,
+) (resp *MergeResponse, err error) {
+	start := time.Now()
+	ls.logger.Info("MergeCustodial started",
+		zap.String("service", transferServiceName),
+		zap.String("method", "MergeCustodial"),
+		zap.String("evm_addr", evmAddr),
+		zap.String("token", req.Token),
+	)
+	defer func() {
+		duration := time.Since(start)
+		if err != nil {
+			ls.logger.Error("MergeCustodial failed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "MergeCustodial"),
+				zap.String("evm_addr", evmAddr),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("MergeCustodial completed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "MergeCustodial"),
+				zap.String("evm_addr", evmAddr),
+				zap.Int("merged_holdings", resp.MergedHoldings),
+				zap.String("amount", resp.Amount),
+				zap.Duration("duration", duration),
+			)
+		}
+	}()
+	return ls.svc.MergeCustodial(ctx, evmAddr, req)
+}
+
+// PrepareSplit wraps the service method with logging.
+func (ls *logService) PrepareSplit(
+	ctx context.Context, evmAddr string, req *PrepareSplitRequest,
+) (resp *PrepareResponse, err error) {
+	start := time.Now()
+	ls.logger.Info("PrepareSplit started",
+		zap.String("service", transferServiceName),
+		zap.String("method", "PrepareSplit"),
+		zap.String("evm_addr", evmAddr),
+		zap.String("token", req.Token),
+		zap.String("contract_id", req.ContractID),
+		zap.String("amount", req.Amount),
+	)
+	defer func() {
+		duration := time.Since(start)
+		if err != nil {
+			ls.logger.Error("PrepareSplit failed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "PrepareSplit"),
+				zap.String("evm_addr", evmAddr),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("PrepareSplit completed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "PrepareSplit"),
+				zap.String("evm_addr", evmAddr),
+				zap.String("transfer_id", resp.TransferID),
+				zap.Duration("duration", duration),
+			)
+		}
+	}()
+	return ls.svc.PrepareSplit(ctx, evmAddr, req)
+}
+
+// SplitCustodial wraps the service method with logging.
+func (ls *logService) SplitCustodial(
+	ctx context.Context, evmAddr string, req *SplitRequest,
+) (resp *SplitResponse, err error) {
+	start := time.Now()
+	ls.logger.Info("SplitCustodial started",
+		zap.String("service", transferServiceName),
+		zap.String("method", "SplitCustodial"),
+		zap.String("evm_addr", evmAddr),
+		zap.String("token", req.Token),
+		zap.String("contract_id", req.ContractID),
+		zap.Strings("amounts", req.Amounts),
+	)
+	defer func() {
+		duration := time.Since(start)
+		if err != nil {
+			ls.logger.Error("SplitCustodial failed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "SplitCustodial"),
+				zap.String("evm_addr", evmAddr),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("SplitCustodial completed",
+				zap.String("service", transferServiceName),
+				zap.String("method", "SplitCustodial"),
+				zap.String("evm_addr", evmAddr),
+				zap.String("change_contract_id", resp.ChangeContractID),
+				zap.Duration("duration", duration),
+			)
+		}
+	}()
+	return ls.svc.SplitCustodial(ctx, evmAddr, req)
+}
+
 // redactSignature redacts signature data to show only metadata.
 // Signatures are sensitive and should not be logged in full.
 func redactSignature(sig string) string {
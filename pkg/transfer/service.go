@@ -71,13 +71,13 @@ type Service interface {
 	SendCustodial(ctx context.Context, senderEVMAddr string, req *CustodialTransferRequest) (*ExecuteResponse, error)
 
 	// ListIncoming returns one page of pending inbound TransferOffer details for the
-	// user with the given EVM address. This call is unauthenticated — anyone can
-	// query any address's pending offers; the response is intentionally minimized
-	// (party IDs truncated) to keep that from leaking counterparties.
+	// user with the given EVM address. The HTTP layer scopes evmAddr to the
+	// caller's JWT when auth is enabled; the response is still minimized (party
+	// IDs truncated) to keep it from leaking counterparties.
 	ListIncoming(ctx context.Context, evmAddr string, p indexer.Pagination) (*IncomingTransfersList, error)
 	// ListOutgoing returns one page of the user's outbound transfers filtered
 	// by status (pending / expired / completed / canceled / rejected / all).
-	// Like ListIncoming it is unauthenticated and truncates party IDs.
+	// Like ListIncoming it truncates party IDs.
 	ListOutgoing(ctx context.Context, evmAddr string, status string, p indexer.Pagination) (*OutgoingTransfersList, error)
 	// ListCompleted returns one page of the user's settled transfers across all
 	// tokens (TokenTransferEvents and accepted TransferOffers), newest first.
@@ -94,6 +94,23 @@ type Service interface {
 	// WithdrawCustodial claims back a pending/expired offer for a custodial sender in a
 	// single server-signed call.
 	WithdrawCustodial(ctx context.Context, evmAddr, contractID string) (*ExecuteResponse, error)
+	// Simulate dry-runs a transfer: it applies every validation Prepare and
+	// SendCustodial would and reports a verdict with the holdings the transfer
+	// would consume. Nothing is prepared, cached, or submitted.
+	Simulate(ctx context.Context, senderEVMAddr string, req *SimulateRequest) (*SimulateResponse, error)
+
+	// PrepareMerge builds a self-transfer consolidating the user's holdings of a
+	// token into one, for external signing. Complete it via Execute.
+	PrepareMerge(ctx context.Context, evmAddr string, req *MergeRequest) (*PrepareResponse, error)
+	// MergeCustodial consolidates a custodial user's holdings of a token in a single
+	// server-signed call.
+	MergeCustodial(ctx context.Context, evmAddr string, req *MergeRequest) (*MergeResponse, error)
+	// PrepareSplit builds a self-transfer splitting one holding into an amount plus
+	// change, for external signing. Complete it via Execute.
+	PrepareSplit(ctx context.Context, evmAddr string, req *PrepareSplitRequest) (*PrepareResponse, error)
+	// SplitCustodial splits one of a custodial user's holdings into the requested
+	// denominations in a single server-signed call.
+	SplitCustodial(ctx context.Context, evmAddr string, req *SplitRequest) (*SplitResponse, error)
 }
 
 // TransferService implements the non-custodial prepare/execute transfer flow.
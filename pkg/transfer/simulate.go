@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+// Simulation issue codes. They are part of the API contract, so keep them stable.
+const (
+	IssueUnsupportedToken    = "unsupported_token"
+	IssueInvalidAmount       = "invalid_amount"
+	IssueInvalidValidity     = "invalid_validity"
+	IssueInvalidRecipient    = "invalid_recipient"
+	IssueRecipientNotFound   = "recipient_not_found"
+	IssueRecipientRejected   = "recipient_rejected"
+	IssueSelfTransfer        = "self_transfer"
+	IssueInsufficientBalance = "insufficient_balance"
+	IssueFragmentedHoldings  = "fragmented_holdings"
+)
+
+// fragmentationWarnInputs is the number of input holdings above which a
+// simulation warns that the sender's balance is fragmented. Large input sets
+// still settle but produce big transactions; merging holdings first is cheaper.
+const fragmentationWarnInputs = 20
+
+// Simulate runs the same validation Prepare and SendCustodial apply — token
+// rules, recipient resolution and topology lookup, and holding selection —
+// without preparing, caching, or submitting anything. Rule violations are
+// reported as issues in the verdict rather than returned as errors; only an
+// unknown sender or a failing dependency surfaces as an error.
+func (s *TransferService) Simulate(ctx context.Context, senderEVMAddr string, req *SimulateRequest) (*SimulateResponse, error) {
+	sender, err := s.userStore.GetUserByEVMAddress(ctx, senderEVMAddr)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, apperrors.UnAuthorizedError(err, "user not found")
+		}
+		return nil, fmt.Errorf("lookup sender: %w", err)
+	}
+
+	resp := &SimulateResponse{
+		Issues:   []SimulationIssue{},
+		Warnings: []SimulationIssue{},
+		KeyMode:  sender.KeyMode,
+		Token:    req.Token,
+		Amount:   req.Amount,
+	}
+
+	tokenAllowed := s.allowedTokenSymbols[req.Token]
+	if !tokenAllowed {
+		resp.addIssue(IssueUnsupportedToken, "unsupported token")
+	}
+	if req.ValiditySeconds != 0 {
+		if _, vErr := validityDuration(req.ValiditySeconds); vErr != nil {
+			resp.addIssue(IssueInvalidValidity, issueMessage(vErr))
+		}
+	}
+
+	toPartyID, err := s.simulateRecipient(ctx, sender, req, tokenAllowed, resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.ToPartyID = toPartyID
+
+	if tokenAllowed {
+		if err = s.simulateHoldings(ctx, sender.CantonPartyID, req, resp); err != nil {
+			return nil, err
+		}
+	}
+
+	resp.Allowed = len(resp.Issues) == 0
+	return resp, nil
+}
+
+// simulateRecipient resolves the recipient party id the way Prepare and
+// SendCustodial do, recording rejections on resp. It returns the resolved party
+// id (empty when it could not be resolved).
+func (s *TransferService) simulateRecipient(
+	ctx context.Context, sender *user.User, req *SimulateRequest, tokenAllowed bool, resp *SimulateResponse,
+) (string, error) {
+	if (req.To == "") == (req.ToPartyID == "") {
+		resp.addIssue(IssueInvalidRecipient, "exactly one of to or to_party_id is required")
+		return "", nil
+	}
+
+	if req.To != "" {
+		// The custodial endpoint only takes a party id; a custodial sender must
+		// resolve the EVM address themselves.
+		if sender.KeyMode == user.KeyModeCustodial {
+			resp.addIssue(IssueInvalidRecipient, "custodial transfers require to_party_id")
+		}
+		recipient, err := s.userStore.GetUserByEVMAddress(ctx, req.To)
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				resp.addIssue(IssueRecipientNotFound, "recipient not found")
+				return "", nil
+			}
+			return "", fmt.Errorf("lookup recipient: %w", err)
+		}
+		if recipient.CantonPartyID == sender.CantonPartyID {
+			resp.addIssue(IssueSelfTransfer, "cannot transfer to self")
+		}
+		return recipient.CantonPartyID, nil
+	}
+
+	if err := validatePartyID(req.ToPartyID); err != nil {
+		resp.addIssue(IssueInvalidRecipient, "invalid recipient party id")
+		return "", nil
+	}
+	if req.ToPartyID == sender.CantonPartyID {
+		resp.addIssue(IssueSelfTransfer, "cannot transfer to self")
+		return req.ToPartyID, nil
+	}
+	// The external-transfer rule is per token, so it cannot be evaluated for a
+	// token we do not support (already reported above).
+	if !tokenAllowed {
+		return req.ToPartyID, nil
+	}
+	if err := s.checkRecipientParty(ctx, req.Token, req.ToPartyID); err != nil {
+		if !apperrors.Is(err, apperrors.CategoryDataError) {
+			return "", err
+		}
+		resp.addIssue(IssueRecipientRejected, issueMessage(err))
+	}
+	return req.ToPartyID, nil
+}
+
+// simulateHoldings loads the sender's holdings of the token and predicts which
+// of them the transfer would consume, using the same selection as the ledger
+// client so the preview matches what a real prepare would build.
+func (s *TransferService) simulateHoldings(
+	ctx context.Context, senderPartyID string, req *SimulateRequest, resp *SimulateResponse,
+) error {
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		resp.addIssue(IssueInvalidAmount, "invalid amount: must be a positive decimal number")
+		return nil
+	}
+
+	holdings, err := s.cantonToken.GetHoldings(ctx, senderPartyID, req.Token)
+	if err != nil {
+		return mapTransferErr(err, "get holdings")
+	}
+
+	unlocked, locked := decimal.Zero, decimal.Zero
+	for _, h := range holdings {
+		v, parseErr := decimal.NewFromString(h.Amount)
+		if parseErr != nil {
+			return fmt.Errorf("parse holding %s amount: %w", h.ContractID, parseErr)
+		}
+		if h.Locked {
+			locked = locked.Add(v)
+			continue
+		}
+		unlocked = unlocked.Add(v)
+		resp.HoldingCount++
+	}
+	resp.UnlockedBalance = unlocked.String()
+	resp.LockedBalance = locked.String()
+
+	sel, err := token.SelectHoldings(holdings, req.Amount)
+	if err != nil {
+		if errors.Is(err, token.ErrInsufficientBalance) {
+			resp.addIssue(IssueInsufficientBalance, "insufficient balance")
+			return nil
+		}
+		return fmt.Errorf("select holdings: %w", err)
+	}
+
+	resp.InstrumentAdmin = sel.InstrumentAdmin
+	resp.InstrumentID = sel.InstrumentID
+	resp.HoldingsToConsume = make([]SimulatedHolding, 0, len(sel.Holdings))
+	for _, h := range sel.Holdings {
+		resp.HoldingsToConsume = append(resp.HoldingsToConsume, SimulatedHolding{ContractID: h.ContractID, Amount: h.Amount})
+	}
+	total, err := decimal.NewFromString(sel.Total)
+	if err != nil {
+		return fmt.Errorf("parse selected total: %w", err)
+	}
+	resp.Change = total.Sub(amount).String()
+
+	if len(sel.Holdings) > fragmentationWarnInputs {
+		resp.Warnings = append(resp.Warnings, SimulationIssue{
+			Code: IssueFragmentedHoldings,
+			Message: fmt.Sprintf(
+				"transfer would consume %d holdings; consider merging holdings first", len(sel.Holdings),
+			),
+		})
+	}
+	return nil
+}
+
+func (r *SimulateResponse) addIssue(code, message string) {
+	r.Issues = append(r.Issues, SimulationIssue{Code: code, Message: message})
+}
+
+// issueMessage extracts the client-facing message of a service error.
+func issueMessage(err error) string {
+	var se *apperrors.ServiceError
+	if errors.As(err, &se) && se.Message != "" {
+		return se.Message
+	}
+	return err.Error()
+}
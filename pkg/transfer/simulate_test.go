@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/cantonsdk/token"
+	"github.com/chainsafe/canton-middleware/pkg/transfer/mocks"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+func demoHolding(cid, amount string, locked bool) *token.Holding {
+	return &token.Holding{
+		ContractID:      cid,
+		Owner:           "party::sender",
+		Amount:          amount,
+		InstrumentAdmin: "issuer::1220aa",
+		InstrumentID:    "DEMO",
+		Locked:          locked,
+	}
+}
+
+func issueCodes(issues []SimulationIssue) []string {
+	codes := make([]string, 0, len(issues))
+	for _, i := range issues {
+		codes = append(codes, i.Code)
+	}
+	return codes
+}
+
+func TestTransferService_Simulate_Success(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+	recipient := recipientUser()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+	store.EXPECT().GetUserByEVMAddress(ctx, recipient.EVMAddress).Return(recipient, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().GetHoldings(ctx, sender.CantonPartyID, "DEMO").Return([]*token.Holding{
+		demoHolding("h-locked", "100", true),
+		demoHolding("h1", "30", false),
+		demoHolding("h2", "50", false),
+		demoHolding("h3", "40", false),
+	}, nil).Once()
+
+	// Simulation must never prepare or cache anything: the cache mock has no expectations.
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	resp, err := svc.Simulate(ctx, sender.EVMAddress, &SimulateRequest{
+		To:     recipient.EVMAddress,
+		Amount: "60",
+		Token:  "DEMO",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Empty(t, resp.Issues)
+	assert.Equal(t, recipient.CantonPartyID, resp.ToPartyID)
+	assert.Equal(t, user.KeyModeExternal, resp.KeyMode)
+	assert.Equal(t, "120", resp.UnlockedBalance)
+	assert.Equal(t, "100", resp.LockedBalance)
+	assert.Equal(t, 3, resp.HoldingCount)
+	assert.Equal(t, []SimulatedHolding{
+		{ContractID: "h1", Amount: "30"},
+		{ContractID: "h2", Amount: "50"},
+	}, resp.HoldingsToConsume)
+	assert.Equal(t, "20", resp.Change)
+	assert.Equal(t, "issuer::1220aa", resp.InstrumentAdmin)
+	assert.Equal(t, "DEMO", resp.InstrumentID)
+}
+
+func TestTransferService_Simulate_InsufficientBalance(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+	recipient := recipientUser()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+	store.EXPECT().GetUserByEVMAddress(ctx, recipient.EVMAddress).Return(recipient, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().GetHoldings(ctx, sender.CantonPartyID, "DEMO").Return([]*token.Holding{
+		demoHolding("h1", "10", false),
+		demoHolding("h-locked", "100", true),
+	}, nil).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	resp, err := svc.Simulate(ctx, sender.EVMAddress, &SimulateRequest{
+		To:     recipient.EVMAddress,
+		Amount: "50",
+		Token:  "DEMO",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Equal(t, []string{IssueInsufficientBalance}, issueCodes(resp.Issues))
+	assert.Equal(t, "10", resp.UnlockedBalance)
+	assert.Empty(t, resp.HoldingsToConsume)
+}
+
+func TestTransferService_Simulate_CollectsMultipleIssues(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+	store.EXPECT().GetUserByEVMAddress(ctx, "0xdddddddddddddddddddddddddddddddddddddddd").
+		Return(nil, user.ErrUserNotFound).Once()
+
+	// An unsupported token skips the holdings lookup entirely.
+	svc := newTestService(t, mocks.NewToken(t), store, mocks.NewTransferCache(t))
+	resp, err := svc.Simulate(ctx, sender.EVMAddress, &SimulateRequest{
+		To:              "0xdddddddddddddddddddddddddddddddddddddddd",
+		Amount:          "1",
+		Token:           "NOPE",
+		ValiditySeconds: -5,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Equal(t,
+		[]string{IssueUnsupportedToken, IssueInvalidValidity, IssueRecipientNotFound},
+		issueCodes(resp.Issues),
+	)
+}
+
+func TestTransferService_Simulate_ExternalToken_UnknownParty(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+	store.EXPECT().GetUserByCantonPartyID(ctx, validExternalPartyID).Return(nil, user.ErrUserNotFound).Once()
+
+	registry := mocks.NewPartyRegistry(t)
+	registry.EXPECT().PartyExists(ctx, validExternalPartyID).Return(false, nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().GetHoldings(ctx, sender.CantonPartyID, "USDCx").Return([]*token.Holding{
+		{ContractID: "u1", Amount: "25", InstrumentAdmin: "usdc::1220bb", InstrumentID: "USDCx"},
+	}, nil).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	svc.partyRegistry = registry
+	resp, err := svc.Simulate(ctx, sender.EVMAddress, &SimulateRequest{
+		ToPartyID: validExternalPartyID,
+		Amount:    "10",
+		Token:     "USDCx",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	require.Equal(t, []string{IssueRecipientRejected}, issueCodes(resp.Issues))
+	assert.Contains(t, resp.Issues[0].Message, "not known on the network")
+	// Holding selection still runs so the caller sees the full picture.
+	assert.Len(t, resp.HoldingsToConsume, 1)
+}
+
+func TestTransferService_Simulate_FragmentedHoldingsWarning(t *testing.T) {
+	ctx := context.Background()
+	sender := custodialSender()
+
+	holdings := make([]*token.Holding, 0, fragmentationWarnInputs+5)
+	for i := range fragmentationWarnInputs + 5 {
+		holdings = append(holdings, demoHolding(fmt.Sprintf("h%d", i), "1", false))
+	}
+
+	store := mocks.NewUserStore(t)
+	store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+	store.EXPECT().GetUserByCantonPartyID(ctx, "bob::1220beef").Return(recipientUser(), nil).Once()
+
+	tok := mocks.NewToken(t)
+	tok.EXPECT().GetHoldings(ctx, sender.CantonPartyID, "DEMO").Return(holdings, nil).Once()
+
+	svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+	resp, err := svc.Simulate(ctx, sender.EVMAddress, &SimulateRequest{
+		ToPartyID: "bob::1220beef",
+		Amount:    "22",
+		Token:     "DEMO",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Len(t, resp.HoldingsToConsume, 22)
+	assert.Equal(t, "0", resp.Change)
+	assert.Equal(t, []string{IssueFragmentedHoldings}, issueCodes(resp.Warnings))
+}
+
+func TestTransferService_Simulate_Errors(t *testing.T) {
+	ctx := context.Background()
+	sender := senderUser()
+
+	t.Run("unknown sender", func(t *testing.T) {
+		store := mocks.NewUserStore(t)
+		store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(nil, user.ErrUserNotFound).Once()
+
+		svc := newTestService(t, mocks.NewToken(t), store, mocks.NewTransferCache(t))
+		_, err := svc.Simulate(ctx, sender.EVMAddress, &SimulateRequest{ToPartyID: "bob::1220beef", Amount: "1", Token: "DEMO"})
+		assertServiceErrorCategory(t, err, apperrors.CategoryUnauthorized)
+	})
+
+	t.Run("ledger unavailable", func(t *testing.T) {
+		store := mocks.NewUserStore(t)
+		store.EXPECT().GetUserByEVMAddress(ctx, sender.EVMAddress).Return(sender, nil).Once()
+		store.EXPECT().GetUserByCantonPartyID(ctx, "bob::1220beef").Return(recipientUser(), nil).Once()
+
+		tok := mocks.NewToken(t)
+		tok.EXPECT().GetHoldings(ctx, sender.CantonPartyID, "DEMO").
+			Return(nil, grpcstatus.Error(codes.Unavailable, "participant down")).Once()
+
+		svc := newTestService(t, tok, store, mocks.NewTransferCache(t))
+		_, err := svc.Simulate(ctx, sender.EVMAddress, &SimulateRequest{ToPartyID: "bob::1220beef", Amount: "1", Token: "DEMO"})
+		assertServiceErrorCategory(t, err, apperrors.CategoryDependencyFailure)
+	})
+}
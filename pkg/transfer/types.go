@@ -136,3 +136,84 @@ type CompletedTransfersList struct {
 type PrepareAcceptRequest struct {
 	InstrumentAdmin string `json:"instrument_admin"` // Canton party ID of the instrument admin
 }
+
+// SimulateRequest is the HTTP request body for a transfer pre-flight check. It
+// takes the same recipient/amount/token fields as PrepareRequest; ValiditySeconds
+// is optional here and only validated when set.
+type SimulateRequest struct {
+	To              string `json:"to,omitempty"`               // Recipient EVM address (0x...) of a registered user
+	ToPartyID       string `json:"to_party_id,omitempty"`      // Recipient Canton party id (<hint>::<fingerprint>)
+	Amount          string `json:"amount"`                     // Token amount (decimal string)
+	Token           string `json:"token"`                      // Token symbol
+	ValiditySeconds int64  `json:"validity_seconds,omitempty"` // Optional offer validity window in seconds
+}
+
+// SimulationIssue is a single reason a simulated transfer would be rejected
+// (or, when returned as a warning, would succeed but deserves attention).
+type SimulationIssue struct {
+	Code    string `json:"code"`    // machine-readable, e.g. "insufficient_balance"
+	Message string `json:"message"` // same wording the real endpoint would return
+}
+
+// SimulatedHolding is a holding the transfer would consume as an input.
+type SimulatedHolding struct {
+	ContractID string `json:"contract_id"`
+	Amount     string `json:"amount"`
+}
+
+// SimulateResponse is the verdict of a transfer pre-flight check. Allowed is
+// true when no issues were found, i.e. the matching prepare (external key mode)
+// or custodial call is expected to pass validation. Nothing is submitted or
+// cached by a simulation.
+type SimulateResponse struct {
+	Allowed           bool               `json:"allowed"`
+	Issues            []SimulationIssue  `json:"issues"`
+	Warnings          []SimulationIssue  `json:"warnings"`
+	KeyMode           string             `json:"key_mode"` // sender key mode; selects prepare/execute vs custodial
+	ToPartyID         string             `json:"to_party_id,omitempty"`
+	Token             string             `json:"token"`
+	Amount            string             `json:"amount"`
+	InstrumentAdmin   string             `json:"instrument_admin,omitempty"`
+	InstrumentID      string             `json:"instrument_id,omitempty"`
+	UnlockedBalance   string             `json:"unlocked_balance,omitempty"`
+	LockedBalance     string             `json:"locked_balance,omitempty"`
+	HoldingCount      int                `json:"holding_count"`                 // unlocked holdings of the token
+	HoldingsToConsume []SimulatedHolding `json:"holdings_to_consume,omitempty"` // inputs the transfer would use
+	Change            string             `json:"change,omitempty"`              // returned to the sender as a new holding
+}
+
+// MergeRequest is the HTTP request body for consolidating a user's holdings of
+// one token into a single holding.
+type MergeRequest struct {
+	Token string `json:"token"` // Token symbol
+}
+
+// MergeResponse is the HTTP response body for a custodial merge.
+type MergeResponse struct {
+	Status         string `json:"status"`          // "completed"
+	MergedHoldings int    `json:"merged_holdings"` // number of input holdings consolidated
+	Amount         string `json:"amount"`          // amount of the resulting holding
+}
+
+// PrepareSplitRequest is the HTTP request body for preparing a non-custodial
+// split of one holding into Amount plus change. Split into several
+// denominations by repeating prepare/execute on the change holding.
+type PrepareSplitRequest struct {
+	Token      string `json:"token"`       // Token symbol
+	ContractID string `json:"contract_id"` // Holding to split
+	Amount     string `json:"amount"`      // Amount to split off; must be less than the holding amount
+}
+
+// SplitRequest is the HTTP request body for a custodial split of one holding
+// into the given denominations; the remainder stays in a change holding.
+type SplitRequest struct {
+	Token      string   `json:"token"`       // Token symbol
+	ContractID string   `json:"contract_id"` // Holding to split
+	Amounts    []string `json:"amounts"`     // Denominations to split off; their sum must be less than the holding amount
+}
+
+// SplitResponse is the HTTP response body for a custodial split.
+type SplitResponse struct {
+	Status           string `json:"status"`             // "completed"
+	ChangeContractID string `json:"change_contract_id"` // holding carrying the remainder
+}
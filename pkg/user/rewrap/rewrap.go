@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rewrap re-encrypts custodial Canton keys under the keyring's active
+// master key, e.g. after a master-key rotation or to move keys written before
+// envelope encryption into envelopes.
+//
+// Users are processed in batches in user-ID order. Keys already under the
+// active master key are skipped, so an interrupted run is resumed by running it
+// again — from the start, or after the last reported user ID. Each key is
+// swapped only if it is unchanged since it was read, so a run is safe against a
+// live api-server.
+package rewrap
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/keys"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+// DefaultBatchSize is the number of users read per batch when Options.BatchSize is zero.
+const DefaultBatchSize = 500
+
+// Store is the persistence interface the Rewrapper needs.
+//
+//go:generate mockery --name Store --output mocks --outpkg mocks --filename mock_store.go --with-expecter
+type Store interface {
+	ListEncryptedKeys(ctx context.Context, afterID int64, limit int) ([]*user.EncryptedKey, error)
+	UpdateEncryptedKey(ctx context.Context, userID int64, current, updated string) (bool, error)
+}
+
+// Keyring rewraps ciphertexts under its active master key. *keys.Keyring implements it.
+type Keyring interface {
+	NeedsRewrap(encryptedKey string) bool
+	Rewrap(encryptedKey string, owner keys.KeyOwner) (string, error)
+}
+
+// Options controls a run.
+type Options struct {
+	// AfterID resumes a run after this user ID.
+	AfterID int64
+	// BatchSize is the number of users read per batch.
+	BatchSize int
+	// DryRun rewraps keys in memory, verifying they can be, without storing them.
+	DryRun bool
+}
+
+// Report summarizes a run.
+type Report struct {
+	Scanned   int `json:"scanned"`
+	Rewrapped int `json:"rewrapped"`
+	// Current counts keys already under the active master key.
+	Current int `json:"current"`
+	// Changed counts keys modified while the run was rewrapping them; running
+	// again picks them up.
+	Changed int `json:"changed"`
+	// LastUserID is the ID of the last user processed; pass it as AfterID to
+	// resume after an interrupted run.
+	LastUserID int64 `json:"last_user_id"`
+	DryRun     bool  `json:"dry_run"`
+}
+
+// Rewrapper rewraps stored custodial keys.
+type Rewrapper struct {
+	store   Store
+	keyring Keyring
+	logger  *zap.Logger
+}
+
+// New creates a Rewrapper.
+func New(store Store, keyring Keyring, logger *zap.Logger) *Rewrapper {
+	return &Rewrapper{store: store, keyring: keyring, logger: logger}
+}
+
+// Run rewraps every stored key that is not under the active master key. On
+// error the returned report covers the users processed before it.
+func (r *Rewrapper) Run(ctx context.Context, opts Options) (*Report, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	report := &Report{LastUserID: opts.AfterID, DryRun: opts.DryRun}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		batch, err := r.store.ListEncryptedKeys(ctx, report.LastUserID, batchSize)
+		if err != nil {
+			return report, err
+		}
+		for _, k := range batch {
+			if err := r.rewrap(ctx, k, opts.DryRun, report); err != nil {
+				return report, err
+			}
+			report.LastUserID = k.UserID
+		}
+		r.logger.Info("rewrapped key batch",
+			zap.Int("batch", len(batch)),
+			zap.Int64("last_user_id", report.LastUserID),
+			zap.Int("rewrapped", report.Rewrapped),
+		)
+		if len(batch) < batchSize {
+			return report, nil
+		}
+	}
+}
+
+func (r *Rewrapper) rewrap(ctx context.Context, k *user.EncryptedKey, dryRun bool, report *Report) error {
+	report.Scanned++
+	if !r.keyring.NeedsRewrap(k.Encrypted) {
+		report.Current++
+		return nil
+	}
+	updated, err := r.keyring.Rewrap(k.Encrypted, keys.KeyOwner{
+		EVMAddress:    k.EVMAddress,
+		CantonPartyID: k.CantonPartyID,
+	})
+	if err != nil {
+		return fmt.Errorf("rewrap key of user %d: %w", k.UserID, err)
+	}
+	if dryRun {
+		report.Rewrapped++
+		return nil
+	}
+	ok, err := r.store.UpdateEncryptedKey(ctx, k.UserID, k.Encrypted, updated)
+	if err != nil {
+		return fmt.Errorf("store key of user %d: %w", k.UserID, err)
+	}
+	if !ok {
+		r.logger.Warn("key changed during rewrap, skipping", zap.Int64("user_id", k.UserID))
+		report.Changed++
+		return nil
+	}
+	report.Rewrapped++
+	return nil
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rewrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/chainsafe/canton-middleware/pkg/keys"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+	"github.com/chainsafe/canton-middleware/pkg/user/rewrap/mocks"
+)
+
+func newRing(t *testing.T, activeID string, masterKeys map[string][]byte) *keys.Keyring {
+	t.Helper()
+	ring, err := keys.NewKeyring(aesMasterKeys(t, masterKeys), activeID, "")
+	require.NoError(t, err)
+	return ring
+}
+
+func aesMasterKeys(t *testing.T, raw map[string][]byte) map[string]keys.MasterKey {
+	t.Helper()
+	masterKeys := make(map[string]keys.MasterKey, len(raw))
+	for id, key := range raw {
+		mk, err := keys.NewAESMasterKey(key)
+		require.NoError(t, err)
+		masterKeys[id] = mk
+	}
+	return masterKeys
+}
+
+func encryptedKey(t *testing.T, ring *keys.Keyring, id int64) *user.EncryptedKey {
+	t.Helper()
+	kp, err := keys.GenerateCantonKeyPair()
+	require.NoError(t, err)
+	k := &user.EncryptedKey{UserID: id, EVMAddress: "0xabc", CantonPartyID: "party::1220"}
+	k.Encrypted, err = ring.Encrypt(kp.PrivateKey, keys.KeyOwner{EVMAddress: k.EVMAddress, CantonPartyID: k.CantonPartyID})
+	require.NoError(t, err)
+	return k
+}
+
+func TestRewrapper_Run(t *testing.T) {
+	k1, _ := keys.GenerateMasterKey()
+	k2, _ := keys.GenerateMasterKey()
+	old := newRing(t, "k1", map[string][]byte{"k1": k1})
+	ring := newRing(t, "k2", map[string][]byte{"k1": k1, "k2": k2})
+
+	stale1, stale2 := encryptedKey(t, old, 3), encryptedKey(t, old, 9)
+	current := encryptedKey(t, ring, 7)
+
+	store := mocks.NewStore(t)
+	store.EXPECT().ListEncryptedKeys(mock.Anything, int64(0), 2).Return([]*user.EncryptedKey{stale1, current}, nil)
+	store.EXPECT().ListEncryptedKeys(mock.Anything, int64(7), 2).Return([]*user.EncryptedKey{stale2}, nil)
+	store.EXPECT().UpdateEncryptedKey(mock.Anything, int64(3), stale1.Encrypted, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ int64, _, updated string) (bool, error) {
+			assert.False(t, ring.NeedsRewrap(updated))
+			return true, nil
+		})
+	store.EXPECT().UpdateEncryptedKey(mock.Anything, int64(9), stale2.Encrypted, mock.Anything).Return(false, nil)
+
+	report, err := New(store, ring, zap.NewNop()).Run(context.Background(), Options{BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Scanned: 3, Rewrapped: 1, Current: 1, Changed: 1, LastUserID: 9}, report)
+}
+
+func TestRewrapper_Run_DryRunResumesAfterID(t *testing.T) {
+	k1, _ := keys.GenerateMasterKey()
+	k2, _ := keys.GenerateMasterKey()
+	old := newRing(t, "k1", map[string][]byte{"k1": k1})
+	ring := newRing(t, "k2", map[string][]byte{"k1": k1, "k2": k2})
+
+	store := mocks.NewStore(t)
+	store.EXPECT().ListEncryptedKeys(mock.Anything, int64(40), DefaultBatchSize).
+		Return([]*user.EncryptedKey{encryptedKey(t, old, 41)}, nil)
+
+	report, err := New(store, ring, zap.NewNop()).Run(context.Background(), Options{AfterID: 40, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Scanned: 1, Rewrapped: 1, LastUserID: 41, DryRun: true}, report)
+}
+
+func TestRewrapper_Run_StopsAtUndecryptableKey(t *testing.T) {
+	k1, _ := keys.GenerateMasterKey()
+	k2, _ := keys.GenerateMasterKey()
+	foreign := newRing(t, "k9", map[string][]byte{"k9": k1})
+	ring := newRing(t, "k2", map[string][]byte{"k2": k2})
+
+	store := mocks.NewStore(t)
+	store.EXPECT().ListEncryptedKeys(mock.Anything, int64(0), DefaultBatchSize).
+		Return([]*user.EncryptedKey{encryptedKey(t, foreign, 5)}, nil)
+
+	report, err := New(store, ring, zap.NewNop()).Run(context.Background(), Options{})
+	require.ErrorIs(t, err, keys.ErrUnknownMasterKey)
+	assert.Equal(t, int64(0), report.LastUserID, "resume point stays before the failed user")
+}
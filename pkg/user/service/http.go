@@ -33,6 +33,8 @@ func RegisterRoutes(r chi.Router, service Service, logger *zap.Logger) {
 	r.Post("/register", apphttp.HandleError(h.register))
 	r.Post("/register/prepare-topology", apphttp.HandleError(h.prepareTopology))
 	r.Get("/profile", apphttp.HandleError(h.getUser))
+	r.Post("/self-custody/export", apphttp.HandleError(h.exportKey))
+	r.Post("/self-custody/complete", apphttp.HandleError(h.completeSelfCustody))
 }
 
 // register handles HTTP requests
@@ -144,6 +146,65 @@ func (h *HTTP) getUser(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// exportKey handles step 1 of a custodial user's migration to self-custody.
+// The EIP-191 signature may be sent in the body or the X-Signature and
+// X-Message headers.
+func (h *HTTP) exportKey(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodyBytes))
+	if err != nil {
+		return apperrors.BadRequestError(err, "failed to read request")
+	}
+
+	var req user.SelfCustodyExportRequest
+	if jsonErr := json.Unmarshal(body, &req); jsonErr != nil {
+		return apperrors.BadRequestError(jsonErr, "invalid JSON")
+	}
+
+	if req.Signature == "" {
+		req.Signature = r.Header.Get("X-Signature")
+		req.Message = r.Header.Get("X-Message")
+	}
+	if req.Signature == "" || req.Message == "" {
+		return apperrors.UnAuthorizedError(nil, "signature and message required")
+	}
+	if req.RecipientPublicKey == "" {
+		return apperrors.BadRequestError(nil, "recipient_public_key is required")
+	}
+
+	resp, err := h.service.ExportKeyForSelfCustody(r.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeJSON(w, http.StatusOK, resp)
+	return nil
+}
+
+// completeSelfCustody handles step 2 of the migration to self-custody.
+func (h *HTTP) completeSelfCustody(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodyBytes))
+	if err != nil {
+		return apperrors.BadRequestError(err, "failed to read request")
+	}
+
+	var req user.SelfCustodyCompleteRequest
+	if jsonErr := json.Unmarshal(body, &req); jsonErr != nil {
+		return apperrors.BadRequestError(jsonErr, "invalid JSON")
+	}
+	if req.MigrationToken == "" || req.ChallengeSignature == "" {
+		return apperrors.BadRequestError(nil, "migration_token and challenge_signature are required")
+	}
+
+	resp, err := h.service.CompleteSelfCustody(r.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+	return nil
+}
+
 func (h *HTTP) writeJSON(w http.ResponseWriter, status int, data any) {
 	// Marshal before writing the status line so a serialization failure yields a
 	// 500 rather than the intended status with a truncated body.
@@ -267,3 +267,41 @@ func TestGetUserHTTP_ServiceReturnsUnauthorized_Returns401(t *testing.T) {
 		t.Fatalf("expected error %q, got %q", "invalid signature", got.Error)
 	}
 }
+
+func TestSelfCustodyExportHTTP_HeadersAndNoStore(t *testing.T) {
+	svc := mocks.NewService(t)
+	svc.EXPECT().
+		ExportKeyForSelfCustody(mock.Anything, &user.SelfCustodyExportRequest{
+			Signature:          "0xsig",
+			Message:            "self-custody:0xabc:1234567890",
+			RecipientPublicKey: "02ab",
+		}).
+		Return(&user.SelfCustodyExportResponse{MigrationToken: "token"}, nil)
+	handler := newRegisterTestServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/self-custody/export", bytes.NewBufferString(`{"recipient_public_key":"02ab"}`))
+	req.Header.Set("X-Signature", "0xsig")
+	req.Header.Set("X-Message", "self-custody:0xabc:1234567890")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Fatalf("expected Cache-Control no-store, got %q", got)
+	}
+}
+
+func TestSelfCustodyCompleteHTTP_MissingFields_ReturnsBadRequest(t *testing.T) {
+	svc := mocks.NewService(t)
+	handler := newRegisterTestServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/self-custody/complete", bytes.NewBufferString(`{"migration_token":"token"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
@@ -198,6 +198,81 @@ func (ls *logService) GetUser(ctx context.Context, evmAddress, msg, sig string)
 	return ls.svc.GetUser(ctx, evmAddress, msg, sig)
 }
 
+// ExportKeyForSelfCustody wraps the service method with logging
+func (ls *logService) ExportKeyForSelfCustody(
+	ctx context.Context,
+	req *user.SelfCustodyExportRequest,
+) (resp *user.SelfCustodyExportResponse, err error) {
+	start := time.Now()
+
+	ls.logger.Info("ExportKeyForSelfCustody started",
+		zap.String("service", serviceName),
+		zap.String("method", "ExportKeyForSelfCustody"),
+		zap.String("message", truncateString(req.Message, logMessageMaxLen)),
+		zap.String("signature", redactSignature(req.Signature)),
+	)
+
+	defer func() {
+		duration := time.Since(start)
+
+		if err != nil {
+			ls.logger.Error("ExportKeyForSelfCustody failed",
+				zap.String("service", serviceName),
+				zap.String("method", "ExportKeyForSelfCustody"),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("ExportKeyForSelfCustody completed",
+				zap.String("service", serviceName),
+				zap.String("method", "ExportKeyForSelfCustody"),
+				zap.String("migration_token", redactToken(resp.MigrationToken)),
+				zap.String("fingerprint", resp.PublicKeyFingerprint),
+				zap.Duration("duration", duration),
+			)
+		}
+	}()
+
+	return ls.svc.ExportKeyForSelfCustody(ctx, req)
+}
+
+// CompleteSelfCustody wraps the service method with logging
+func (ls *logService) CompleteSelfCustody(
+	ctx context.Context,
+	req *user.SelfCustodyCompleteRequest,
+) (resp *user.RegisterResponse, err error) {
+	start := time.Now()
+
+	ls.logger.Info("CompleteSelfCustody started",
+		zap.String("service", serviceName),
+		zap.String("method", "CompleteSelfCustody"),
+		zap.String("migration_token", redactToken(req.MigrationToken)),
+	)
+
+	defer func() {
+		duration := time.Since(start)
+
+		if err != nil {
+			ls.logger.Error("CompleteSelfCustody failed",
+				zap.String("service", serviceName),
+				zap.String("method", "CompleteSelfCustody"),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			)
+		} else {
+			ls.logger.Info("CompleteSelfCustody completed",
+				zap.String("service", serviceName),
+				zap.String("method", "CompleteSelfCustody"),
+				zap.String("evm_address", resp.EVMAddress),
+				zap.String("party_id", resp.Party),
+				zap.Duration("duration", duration),
+			)
+		}
+	}()
+
+	return ls.svc.CompleteSelfCustody(ctx, req)
+}
+
 // Helper functions for sensitive data redaction
 
 // truncateString limits string length for logging to prevent log spam
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+var (
+	ErrMigrationNotFound = errors.New("migration not found")
+	ErrMigrationExpired  = errors.New("migration expired")
+)
+
+const migrationCleanupInterval = 30 * time.Second
+
+// MigrationCacheProvider is the interface for storing and retrieving pending
+// self-custody migrations. Implementations: MigrationCache (in process, single
+// replica) and PostgresMigrationCache (shared by every api-server replica).
+//
+//go:generate mockery --name MigrationCacheProvider --output mocks --outpkg mocks --filename mock_migration_cache.go --with-expecter
+type MigrationCacheProvider interface {
+	// Put stores m keyed by migration token and sets its ExpiresAt. It replaces
+	// any earlier pending migration of the same user.
+	Put(ctx context.Context, token string, m *user.PendingMigration) error
+	// GetAndDelete atomically retrieves and removes a pending migration. It
+	// returns ErrMigrationNotFound or ErrMigrationExpired when there is none.
+	GetAndDelete(ctx context.Context, token string) (*user.PendingMigration, error)
+}
+
+// MigrationCache stores exported keys awaiting proof of possession, one per user,
+// in process memory. It only suits a single api-server replica; pending
+// migrations are lost on restart.
+type MigrationCache struct {
+	mu      sync.Mutex
+	entries map[string]*user.PendingMigration
+	byUser  map[string]string // evm address -> token
+	ttl     time.Duration
+}
+
+// NewMigrationCache creates a new migration cache with the given TTL.
+func NewMigrationCache(ttl time.Duration) *MigrationCache {
+	return &MigrationCache{
+		entries: make(map[string]*user.PendingMigration),
+		byUser:  make(map[string]string),
+		ttl:     ttl,
+	}
+}
+
+// Put stores a pending migration keyed by migration token.
+func (c *MigrationCache) Put(_ context.Context, token string, m *user.PendingMigration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev, ok := c.byUser[m.EVMAddress]; ok {
+		delete(c.entries, prev)
+	}
+	m.ExpiresAt = time.Now().Add(c.ttl)
+	c.entries[token] = m
+	c.byUser[m.EVMAddress] = token
+	return nil
+}
+
+// GetAndDelete atomically retrieves and removes a pending migration.
+func (c *MigrationCache) GetAndDelete(_ context.Context, token string) (*user.PendingMigration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[token]
+	if !ok {
+		return nil, ErrMigrationNotFound
+	}
+	delete(c.entries, token)
+	delete(c.byUser, entry.EVMAddress)
+
+	if time.Now().After(entry.ExpiresAt) {
+		return nil, ErrMigrationExpired
+	}
+
+	return entry, nil
+}
+
+// Start runs a background goroutine that periodically removes expired entries.
+func (c *MigrationCache) Start(ctx context.Context) error {
+	ticker := time.NewTicker(migrationCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.cleanup()
+		}
+	}
+}
+
+func (c *MigrationCache) cleanup() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for token, entry := range c.entries {
+		if now.After(entry.ExpiresAt) {
+			delete(c.entries, token)
+			delete(c.byUser, entry.EVMAddress)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+// MigrationDao maps to the self_custody_migrations table. The EVM address is the
+// primary key, which is what caps each user at one pending migration; the token
+// is unique so GetAndDelete can find it without the address.
+//
+// EncryptedKey is the user's stored ciphertext, kept only to detect a key change
+// before the switch; it is no more exposed here than in the users table.
+type MigrationDao struct {
+	bun.BaseModel        `bun:"table:self_custody_migrations"`
+	EVMAddress           string    `bun:"evm_address,pk,type:varchar(42)"`
+	Token                string    `bun:"token,notnull,unique,type:varchar(64)"`
+	CantonPartyID        string    `bun:"canton_party_id,notnull"`
+	EncryptedKey         string    `bun:"encrypted_key,notnull"`
+	PublicKey            []byte    `bun:"public_key,notnull"`
+	PublicKeyFingerprint string    `bun:"public_key_fingerprint,notnull"`
+	Challenge            []byte    `bun:"challenge,notnull"`
+	ExpiresAt            time.Time `bun:"expires_at,notnull"`
+}
+
+// PostgresMigrationCache stores pending self-custody migrations in the api
+// database, so an export served by one replica can be completed on another and
+// pending migrations survive a restart. It keeps the MigrationCache semantics:
+// one pending migration per user, single use, and a TTL.
+type PostgresMigrationCache struct {
+	db  *bun.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewPostgresMigrationCache creates a migration cache whose entries expire after ttl.
+func NewPostgresMigrationCache(db *bun.DB, ttl time.Duration) *PostgresMigrationCache {
+	return &PostgresMigrationCache{db: db, ttl: ttl, now: time.Now}
+}
+
+// Put stores m keyed by token, replacing the user's earlier pending migration.
+func (c *PostgresMigrationCache) Put(ctx context.Context, token string, m *user.PendingMigration) error {
+	m.ExpiresAt = c.now().Add(c.ttl)
+	_, err := c.db.NewInsert().
+		Model(&MigrationDao{
+			EVMAddress:           m.EVMAddress,
+			Token:                token,
+			CantonPartyID:        m.CantonPartyID,
+			EncryptedKey:         m.EncryptedKey,
+			PublicKey:            m.PublicKey,
+			PublicKeyFingerprint: m.PublicKeyFingerprint,
+			Challenge:            m.Challenge,
+			ExpiresAt:            m.ExpiresAt,
+		}).
+		On("CONFLICT (evm_address) DO UPDATE").
+		Set("token = EXCLUDED.token").
+		Set("canton_party_id = EXCLUDED.canton_party_id").
+		Set("encrypted_key = EXCLUDED.encrypted_key").
+		Set("public_key = EXCLUDED.public_key").
+		Set("public_key_fingerprint = EXCLUDED.public_key_fingerprint").
+		Set("challenge = EXCLUDED.challenge").
+		Set("expires_at = EXCLUDED.expires_at").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("insert pending migration: %w", err)
+	}
+	return nil
+}
+
+// GetAndDelete deletes the pending migration and returns it. The delete is what
+// makes a token single-use: of two concurrent calls, only one gets the row back.
+func (c *PostgresMigrationCache) GetAndDelete(ctx context.Context, token string) (*user.PendingMigration, error) {
+	row := new(MigrationDao)
+	err := c.db.NewDelete().Model(row).
+		Where("token = ?", token).
+		Returning("*").
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMigrationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("delete pending migration: %w", err)
+	}
+	if !c.now().Before(row.ExpiresAt) {
+		return nil, ErrMigrationExpired
+	}
+	return &user.PendingMigration{
+		EVMAddress:           row.EVMAddress,
+		CantonPartyID:        row.CantonPartyID,
+		EncryptedKey:         row.EncryptedKey,
+		PublicKey:            row.PublicKey,
+		PublicKeyFingerprint: row.PublicKeyFingerprint,
+		Challenge:            row.Challenge,
+		ExpiresAt:            row.ExpiresAt,
+	}, nil
+}
+
+// Start periodically deletes expired migrations until ctx is done.
+func (c *PostgresMigrationCache) Start(ctx context.Context) error {
+	ticker := time.NewTicker(migrationCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// A failed sweep is retried on the next tick; expired rows are
+			// rejected by GetAndDelete in the meantime.
+			_ = c.purgeExpired(ctx)
+		}
+	}
+}
+
+func (c *PostgresMigrationCache) purgeExpired(ctx context.Context) error {
+	_, err := c.db.NewDelete().Model((*MigrationDao)(nil)).Where("expires_at <= ?", c.now()).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("purge expired migrations: %w", err)
+	}
+	return nil
+}
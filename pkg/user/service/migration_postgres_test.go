@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chainsafe/canton-middleware/pkg/pgutil"
+	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+func setupPostgresMigrationCache(t *testing.T, ttl time.Duration) (context.Context, *PostgresMigrationCache) {
+	t.Helper()
+	requireDockerAccess(t)
+
+	ctx := context.Background()
+	db, cleanup := pgutil.SetupTestDB(t)
+	t.Cleanup(cleanup)
+
+	if err := mghelper.CreateSchema(ctx, db, &MigrationDao{}); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	return ctx, NewPostgresMigrationCache(db, ttl)
+}
+
+func requireDockerAccess(t *testing.T) {
+	t.Helper()
+
+	candidates := []string{
+		"/var/run/docker.sock",
+		filepath.Join(os.Getenv("HOME"), ".docker/run/docker.sock"),
+	}
+
+	for _, sock := range candidates {
+		if _, err := os.Stat(sock); err != nil {
+			continue
+		}
+		conn, err := (&net.Dialer{}).DialContext(context.Background(), "unix", sock)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+	}
+
+	t.Skip("docker daemon socket is not accessible; skipping testcontainer-backed migration cache tests")
+}
+
+func pendingMigration(evmAddress string) *user.PendingMigration {
+	return &user.PendingMigration{
+		EVMAddress:           evmAddress,
+		CantonPartyID:        "user::1220ab",
+		EncryptedKey:         "v2:k1:ciphertext",
+		PublicKey:            []byte{0x02, 0x01},
+		PublicKeyFingerprint: "1220ab",
+		Challenge:            []byte{0xaa, 0xbb},
+	}
+}
+
+func TestPostgresMigrationCache_CompletesOnAnotherReplica(t *testing.T) {
+	ctx, cache := setupPostgresMigrationCache(t, time.Minute)
+	// A second replica sharing the database.
+	other := NewPostgresMigrationCache(cache.db, time.Minute)
+
+	want := pendingMigration("0xabc")
+	if err := cache.Put(ctx, "token-1", want); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	got, err := other.GetAndDelete(ctx, "token-1")
+	if err != nil {
+		t.Fatalf("GetAndDelete() failed: %v", err)
+	}
+	if got.EVMAddress != want.EVMAddress || got.EncryptedKey != want.EncryptedKey ||
+		string(got.Challenge) != string(want.Challenge) || string(got.PublicKey) != string(want.PublicKey) {
+		t.Fatalf("GetAndDelete() = %+v, want %+v", got, want)
+	}
+
+	if _, err = cache.GetAndDelete(ctx, "token-1"); !errors.Is(err, ErrMigrationNotFound) {
+		t.Fatalf("expected ErrMigrationNotFound on reuse, got %v", err)
+	}
+}
+
+func TestPostgresMigrationCache_NewExportReplacesPending(t *testing.T) {
+	ctx, cache := setupPostgresMigrationCache(t, time.Minute)
+
+	if err := cache.Put(ctx, "token-1", pendingMigration("0xabc")); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	if err := cache.Put(ctx, "token-2", pendingMigration("0xabc")); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	if _, err := cache.GetAndDelete(ctx, "token-1"); !errors.Is(err, ErrMigrationNotFound) {
+		t.Fatalf("expected the first token to be replaced, got %v", err)
+	}
+	if _, err := cache.GetAndDelete(ctx, "token-2"); err != nil {
+		t.Fatalf("GetAndDelete() failed: %v", err)
+	}
+}
+
+func TestPostgresMigrationCache_Expired(t *testing.T) {
+	ctx, cache := setupPostgresMigrationCache(t, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	if err := cache.Put(ctx, "token-1", pendingMigration("0xabc")); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	cache.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+	if _, err := cache.GetAndDelete(ctx, "token-1"); !errors.Is(err, ErrMigrationExpired) {
+		t.Fatalf("expected ErrMigrationExpired, got %v", err)
+	}
+}
@@ -23,6 +23,124 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// CompleteSelfCustody provides a mock function with given fields: ctx, req
+func (_m *Service) CompleteSelfCustody(ctx context.Context, req *user.SelfCustodyCompleteRequest) (*user.RegisterResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteSelfCustody")
+	}
+
+	var r0 *user.RegisterResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.SelfCustodyCompleteRequest) (*user.RegisterResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.SelfCustodyCompleteRequest) *user.RegisterResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.RegisterResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.SelfCustodyCompleteRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_CompleteSelfCustody_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteSelfCustody'
+type Service_CompleteSelfCustody_Call struct {
+	*mock.Call
+}
+
+// CompleteSelfCustody is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *user.SelfCustodyCompleteRequest
+func (_e *Service_Expecter) CompleteSelfCustody(ctx interface{}, req interface{}) *Service_CompleteSelfCustody_Call {
+	return &Service_CompleteSelfCustody_Call{Call: _e.mock.On("CompleteSelfCustody", ctx, req)}
+}
+
+func (_c *Service_CompleteSelfCustody_Call) Run(run func(ctx context.Context, req *user.SelfCustodyCompleteRequest)) *Service_CompleteSelfCustody_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*user.SelfCustodyCompleteRequest))
+	})
+	return _c
+}
+
+func (_c *Service_CompleteSelfCustody_Call) Return(_a0 *user.RegisterResponse, _a1 error) *Service_CompleteSelfCustody_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_CompleteSelfCustody_Call) RunAndReturn(run func(context.Context, *user.SelfCustodyCompleteRequest) (*user.RegisterResponse, error)) *Service_CompleteSelfCustody_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportKeyForSelfCustody provides a mock function with given fields: ctx, req
+func (_m *Service) ExportKeyForSelfCustody(ctx context.Context, req *user.SelfCustodyExportRequest) (*user.SelfCustodyExportResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportKeyForSelfCustody")
+	}
+
+	var r0 *user.SelfCustodyExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.SelfCustodyExportRequest) (*user.SelfCustodyExportResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.SelfCustodyExportRequest) *user.SelfCustodyExportResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.SelfCustodyExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.SelfCustodyExportRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ExportKeyForSelfCustody_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportKeyForSelfCustody'
+type Service_ExportKeyForSelfCustody_Call struct {
+	*mock.Call
+}
+
+// ExportKeyForSelfCustody is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *user.SelfCustodyExportRequest
+func (_e *Service_Expecter) ExportKeyForSelfCustody(ctx interface{}, req interface{}) *Service_ExportKeyForSelfCustody_Call {
+	return &Service_ExportKeyForSelfCustody_Call{Call: _e.mock.On("ExportKeyForSelfCustody", ctx, req)}
+}
+
+func (_c *Service_ExportKeyForSelfCustody_Call) Run(run func(ctx context.Context, req *user.SelfCustodyExportRequest)) *Service_ExportKeyForSelfCustody_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*user.SelfCustodyExportRequest))
+	})
+	return _c
+}
+
+func (_c *Service_ExportKeyForSelfCustody_Call) Return(_a0 *user.SelfCustodyExportResponse, _a1 error) *Service_ExportKeyForSelfCustody_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ExportKeyForSelfCustody_Call) RunAndReturn(run func(context.Context, *user.SelfCustodyExportRequest) (*user.SelfCustodyExportResponse, error)) *Service_ExportKeyForSelfCustody_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUser provides a mock function with given fields: ctx, evmAddress, msg, sig
 func (_m *Service) GetUser(ctx context.Context, evmAddress string, msg string, sig string) (*user.User, error) {
 	ret := _m.Called(ctx, evmAddress, msg, sig)
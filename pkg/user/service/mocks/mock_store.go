@@ -235,6 +235,65 @@ func (_c *Store_GetUserByEVMAddress_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// SwitchToExternalKey provides a mock function with given fields: ctx, evmAddress, currentEncryptedKey, publicKeyFingerprint
+func (_m *Store) SwitchToExternalKey(ctx context.Context, evmAddress string, currentEncryptedKey string, publicKeyFingerprint string) (bool, error) {
+	ret := _m.Called(ctx, evmAddress, currentEncryptedKey, publicKeyFingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwitchToExternalKey")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return rf(ctx, evmAddress, currentEncryptedKey, publicKeyFingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, evmAddress, currentEncryptedKey, publicKeyFingerprint)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, evmAddress, currentEncryptedKey, publicKeyFingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store_SwitchToExternalKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwitchToExternalKey'
+type Store_SwitchToExternalKey_Call struct {
+	*mock.Call
+}
+
+// SwitchToExternalKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - evmAddress string
+//   - currentEncryptedKey string
+//   - publicKeyFingerprint string
+func (_e *Store_Expecter) SwitchToExternalKey(ctx interface{}, evmAddress interface{}, currentEncryptedKey interface{}, publicKeyFingerprint interface{}) *Store_SwitchToExternalKey_Call {
+	return &Store_SwitchToExternalKey_Call{Call: _e.mock.On("SwitchToExternalKey", ctx, evmAddress, currentEncryptedKey, publicKeyFingerprint)}
+}
+
+func (_c *Store_SwitchToExternalKey_Call) Run(run func(ctx context.Context, evmAddress string, currentEncryptedKey string, publicKeyFingerprint string)) *Store_SwitchToExternalKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Store_SwitchToExternalKey_Call) Return(_a0 bool, _a1 error) *Store_SwitchToExternalKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Store_SwitchToExternalKey_Call) RunAndReturn(run func(context.Context, string, string, string) (bool, error)) *Store_SwitchToExternalKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserExists provides a mock function with given fields: ctx, evmAddress
 func (_m *Store) UserExists(ctx context.Context, evmAddress string) (bool, error) {
 	ret := _m.Called(ctx, evmAddress)
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	"github.com/chainsafe/canton-middleware/pkg/keys"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+)
+
+const (
+	// selfCustodyAction starts the message a user signs to export their key:
+	// "self-custody:<address>:<recipient public key>:<nonce>:<unix seconds>".
+	// The recipient key is signed so a captured message cannot redirect the
+	// export to another key, and the nonce (GET /auth/nonce) makes it single-use.
+	selfCustodyAction = "self-custody"
+
+	// selfCustodyMessageMaxAge bounds the age of an export message. Exports
+	// hand out a private key, so the window is much shorter than for logins.
+	selfCustodyMessageMaxAge = 5 * time.Minute
+
+	challengeSize = 32
+)
+
+var (
+	ErrNotCustodial     = errors.New("user does not hold a custodial key")
+	ErrKeyNotPartyOwner = errors.New("custodial key does not own the user's Canton party")
+	ErrNonceUsed        = errors.New("nonce unknown, expired or already used")
+)
+
+// ExportKeyForSelfCustody is step 1 of migrating a custodial user to self-custody.
+//
+// It returns the user's Canton private key ECIES-encrypted to the recipient
+// public key, together with a migration token and a challenge. The stored key
+// is left untouched until CompleteSelfCustody receives a signature of the
+// challenge made with the exported key, so a lost response only costs a retry.
+//
+// Only keys that own the user's party can be exported: the party was allocated
+// with this key as its namespace and signing key, so after the export the user
+// signs for the party exactly as an external user does. Canton native users
+// already hold their party key and have nothing to migrate.
+func (s *registrationService) ExportKeyForSelfCustody(
+	ctx context.Context,
+	req *user.SelfCustodyExportRequest,
+) (*user.SelfCustodyExportResponse, error) {
+	recoveredAddr, err := auth.VerifyEIP191Signature(req.Message, req.Signature)
+	if err != nil {
+		return nil, apperrors.UnAuthorizedError(err, "invalid signature")
+	}
+	evmAddress := auth.NormalizeAddress(recoveredAddr.Hex())
+	fields, err := auth.ParseActionMessage(req.Message, selfCustodyAction, evmAddress, 2, selfCustodyMessageMaxAge)
+	if err != nil {
+		return nil, apperrors.UnAuthorizedError(err,
+			"message must be of form self-custody:<address>:<recipient public key>:<nonce>:<timestamp>")
+	}
+	signedKey, nonce := fields[0], fields[1]
+
+	recipient, err := parseRecipientKey(req.RecipientPublicKey)
+	if err != nil {
+		return nil, apperrors.BadRequestError(err, "invalid recipient_public_key")
+	}
+	signed, err := parseRecipientKey(signedKey)
+	if err != nil || !signed.ExportECDSA().Equal(recipient.ExportECDSA()) {
+		return nil, apperrors.UnAuthorizedError(err, "signed message is for another recipient_public_key")
+	}
+
+	// Consume the nonce only once the signature checks out, so a forged request
+	// cannot burn the user's outstanding nonce.
+	live, err := s.nonces.Consume(ctx, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("consume nonce: %w", err)
+	}
+	if !live {
+		return nil, apperrors.UnAuthorizedError(ErrNonceUsed, "nonce unknown, expired or already used")
+	}
+
+	usr, err := s.store.GetUserByEVMAddress(ctx, evmAddress)
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if usr == nil {
+		return nil, apperrors.ResourceNotFoundError(err, "user not found")
+	}
+	if usr.KeyMode != user.KeyModeCustodial || usr.CantonPrivateKeyEncrypted == "" {
+		return nil, apperrors.ConflictError(ErrNotCustodial, "user does not hold a custodial key")
+	}
+
+	privKey, err := s.keyCipher.Decrypt(usr.CantonPrivateKeyEncrypted, keys.KeyOwner{
+		EVMAddress:    usr.EVMAddress,
+		CantonPartyID: usr.CantonPartyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key: %w", err)
+	}
+	kp, err := keys.CantonKeyPairFromPrivateKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored key: %w", err)
+	}
+	keyFingerprint, err := kp.Fingerprint()
+	if err != nil {
+		return nil, fmt.Errorf("key fingerprint: %w", err)
+	}
+	if _, namespace, _ := strings.Cut(usr.CantonPartyID, "::"); namespace != keyFingerprint {
+		return nil, apperrors.ConflictError(ErrKeyNotPartyOwner, "custodial key does not own the user's Canton party")
+	}
+
+	encrypted, err := ecies.Encrypt(rand.Reader, recipient, kp.PrivateKey, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key for export: %w", err)
+	}
+	challenge := make([]byte, challengeSize)
+	if _, err = rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	token := uuid.NewString()
+	pending := &user.PendingMigration{
+		EVMAddress:           usr.EVMAddress,
+		CantonPartyID:        usr.CantonPartyID,
+		EncryptedKey:         usr.CantonPrivateKeyEncrypted,
+		PublicKey:            kp.PublicKey,
+		PublicKeyFingerprint: keyFingerprint,
+		Challenge:            challenge,
+	}
+	if err = s.migrationCache.Put(ctx, token, pending); err != nil {
+		return nil, fmt.Errorf("failed to store pending migration: %w", err)
+	}
+
+	s.logger.Info("Exported custodial key for self-custody migration",
+		zap.String("evm_address", usr.EVMAddress),
+		zap.String("party_id", usr.CantonPartyID))
+
+	return &user.SelfCustodyExportResponse{
+		EncryptedKey:         "0x" + hex.EncodeToString(encrypted),
+		CantonPublicKey:      kp.PublicKeyHex(),
+		PublicKeyFingerprint: keyFingerprint,
+		MigrationToken:       token,
+		Challenge:            "0x" + hex.EncodeToString(challenge),
+		ExpiresAt:            pending.ExpiresAt,
+	}, nil
+}
+
+// CompleteSelfCustody is step 2 of migrating a custodial user to self-custody.
+// Given a signature of the export challenge made with the exported key, it
+// destroys the stored encrypted key and switches the user to external mode.
+func (s *registrationService) CompleteSelfCustody(
+	ctx context.Context,
+	req *user.SelfCustodyCompleteRequest,
+) (*user.RegisterResponse, error) {
+	pending, err := s.migrationCache.GetAndDelete(ctx, req.MigrationToken)
+	if err != nil {
+		if errors.Is(err, ErrMigrationNotFound) {
+			return nil, apperrors.ResourceNotFoundError(err, "migration token not found or already used")
+		}
+		if errors.Is(err, ErrMigrationExpired) {
+			return nil, apperrors.GoneError(err, "migration token expired")
+		}
+		return nil, fmt.Errorf("migration cache lookup: %w", err)
+	}
+
+	derSig, err := hex.DecodeString(strings.TrimPrefix(req.ChallengeSignature, "0x"))
+	if err != nil {
+		return nil, apperrors.BadRequestError(err, "invalid challenge_signature hex")
+	}
+	if err = keys.VerifyDER(pending.PublicKey, pending.Challenge, derSig); err != nil {
+		return nil, apperrors.UnAuthorizedError(err, "challenge signature does not match the exported key")
+	}
+
+	switched, err := s.store.SwitchToExternalKey(ctx, pending.EVMAddress, pending.EncryptedKey, pending.PublicKeyFingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to switch user to external mode: %w", err)
+	}
+	if !switched {
+		return nil, apperrors.ConflictError(ErrNotCustodial, "custodial key changed since it was exported")
+	}
+
+	s.logger.Info("Completed self-custody migration",
+		zap.String("evm_address", pending.EVMAddress),
+		zap.String("party_id", pending.CantonPartyID),
+		zap.String("fingerprint", pending.PublicKeyFingerprint))
+
+	return &user.RegisterResponse{
+		Party:      pending.CantonPartyID,
+		EVMAddress: pending.EVMAddress,
+		KeyMode:    user.KeyModeExternal,
+	}, nil
+}
+
+// parseRecipientKey parses a hex-encoded compressed or uncompressed secp256k1
+// public key into an ECIES public key.
+func parseRecipientKey(hexKey string) (*ecies.PublicKey, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	const compressedPubKeyLen = 33
+	if len(raw) == compressedPubKeyLen {
+		pub, err := crypto.DecompressPubkey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compressed secp256k1 public key: %w", err)
+		}
+		return ecies.ImportECDSAPublic(pub), nil
+	}
+	pub, err := crypto.UnmarshalPubkey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+	}
+	return ecies.ImportECDSAPublic(pub), nil
+}
@@ -0,0 +1,320 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	apperrors "github.com/chainsafe/canton-middleware/pkg/app/errors"
+	"github.com/chainsafe/canton-middleware/pkg/auth"
+	nonceprovider "github.com/chainsafe/canton-middleware/pkg/auth/service/nonce_provider"
+	"github.com/chainsafe/canton-middleware/pkg/keys"
+	"github.com/chainsafe/canton-middleware/pkg/user"
+	"github.com/chainsafe/canton-middleware/pkg/user/service/mocks"
+)
+
+type selfCustodyFixture struct {
+	svc        Service
+	store      *mocks.Store
+	ring       *keys.Keyring
+	evmKey     *ecdsa.PrivateKey
+	evmAddress string
+	cantonKey  *keys.CantonKeyPair
+	usr        *user.User
+	recipient  *ecdsa.PrivateKey
+	nonces     *nonceprovider.InMemory
+}
+
+// newSelfCustodyFixture builds a custodial user whose party is owned by its
+// stored key, as RegisterWeb3User allocates it.
+func newSelfCustodyFixture(t *testing.T) *selfCustodyFixture {
+	t.Helper()
+
+	masterKey, err := keys.GenerateMasterKey()
+	if err != nil {
+		t.Fatalf("GenerateMasterKey() failed: %v", err)
+	}
+	aesKey, err := keys.NewAESMasterKey(masterKey)
+	if err != nil {
+		t.Fatalf("NewAESMasterKey() failed: %v", err)
+	}
+	ring, err := keys.NewKeyring(map[string]keys.MasterKey{"k1": aesKey}, "k1", "")
+	if err != nil {
+		t.Fatalf("NewKeyring() failed: %v", err)
+	}
+
+	evmKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() failed: %v", err)
+	}
+	evmAddress := auth.NormalizeAddress(crypto.PubkeyToAddress(evmKey.PublicKey).Hex())
+
+	cantonKey, err := keys.GenerateCantonKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateCantonKeyPair() failed: %v", err)
+	}
+	fingerprint, err := cantonKey.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() failed: %v", err)
+	}
+	partyID := "user_test::" + fingerprint
+	encrypted, err := ring.Encrypt(cantonKey.PrivateKey, keys.KeyOwner{EVMAddress: evmAddress, CantonPartyID: partyID})
+	if err != nil {
+		t.Fatalf("Encrypt() failed: %v", err)
+	}
+
+	recipient, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() failed: %v", err)
+	}
+
+	store := mocks.NewStore(t)
+	nonces := nonceprovider.NewInMemory(time.Minute)
+	return &selfCustodyFixture{
+		svc:        NewService(store, nil, ring, zap.NewNop(), false, nil, nil, NewMigrationCache(time.Minute), nonces),
+		store:      store,
+		ring:       ring,
+		evmKey:     evmKey,
+		evmAddress: evmAddress,
+		cantonKey:  cantonKey,
+		usr:        user.New(evmAddress, partyID, auth.ComputeFingerprint(evmAddress), "mapping-cid", encrypted),
+		recipient:  recipient,
+		nonces:     nonces,
+	}
+}
+
+func (f *selfCustodyFixture) exportRequest(t *testing.T, message string) *user.SelfCustodyExportRequest {
+	t.Helper()
+	hash := crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)))
+	sig, err := crypto.Sign(hash, f.evmKey)
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	return &user.SelfCustodyExportRequest{
+		Message:            message,
+		Signature:          "0x" + hex.EncodeToString(sig),
+		RecipientPublicKey: f.recipientKeyHex(),
+	}
+}
+
+func (f *selfCustodyFixture) recipientKeyHex() string {
+	return hex.EncodeToString(crypto.CompressPubkey(&f.recipient.PublicKey))
+}
+
+// validMessage signs the export to the fixture's recipient with a fresh nonce.
+func (f *selfCustodyFixture) validMessage(t *testing.T) string {
+	t.Helper()
+	nonce, err := f.nonces.Issue(context.Background(), f.evmAddress)
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	return fmt.Sprintf("self-custody:%s:%s:%s:%d", f.evmAddress, f.recipientKeyHex(), nonce, time.Now().Unix())
+}
+
+func TestSelfCustody_ExportAndComplete(t *testing.T) {
+	ctx := context.Background()
+	f := newSelfCustodyFixture(t)
+	f.store.EXPECT().GetUserByEVMAddress(ctx, f.evmAddress).Return(f.usr, nil).Once()
+
+	resp, err := f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, f.validMessage(t)))
+	if err != nil {
+		t.Fatalf("ExportKeyForSelfCustody() failed: %v", err)
+	}
+
+	ciphertext, err := hex.DecodeString(strings.TrimPrefix(resp.EncryptedKey, "0x"))
+	if err != nil {
+		t.Fatalf("invalid encrypted_key hex: %v", err)
+	}
+	exported, err := ecies.ImportECDSA(f.recipient).Decrypt(ciphertext, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to decrypt the exported key: %v", err)
+	}
+	if !bytes.Equal(exported, f.cantonKey.PrivateKey) {
+		t.Fatal("exported key does not match the custodial key")
+	}
+	if resp.CantonPublicKey != f.cantonKey.PublicKeyHex() {
+		t.Fatalf("expected canton_public_key %s, got %s", f.cantonKey.PublicKeyHex(), resp.CantonPublicKey)
+	}
+
+	exportedKey, err := keys.CantonKeyPairFromPrivateKey(exported)
+	if err != nil {
+		t.Fatalf("CantonKeyPairFromPrivateKey() failed: %v", err)
+	}
+	challenge, _ := hex.DecodeString(strings.TrimPrefix(resp.Challenge, "0x"))
+	derSig, err := exportedKey.SignHashDER(challenge)
+	if err != nil {
+		t.Fatalf("SignHashDER() failed: %v", err)
+	}
+
+	f.store.EXPECT().
+		SwitchToExternalKey(ctx, f.evmAddress, f.usr.CantonPrivateKeyEncrypted, resp.PublicKeyFingerprint).
+		Return(true, nil).Once()
+
+	done, err := f.svc.CompleteSelfCustody(ctx, &user.SelfCustodyCompleteRequest{
+		MigrationToken:     resp.MigrationToken,
+		ChallengeSignature: hex.EncodeToString(derSig),
+	})
+	if err != nil {
+		t.Fatalf("CompleteSelfCustody() failed: %v", err)
+	}
+	if done.KeyMode != user.KeyModeExternal || done.Party != f.usr.CantonPartyID {
+		t.Fatalf("unexpected response: %+v", done)
+	}
+
+	// The token completes a migration once.
+	_, err = f.svc.CompleteSelfCustody(ctx, &user.SelfCustodyCompleteRequest{
+		MigrationToken:     resp.MigrationToken,
+		ChallengeSignature: hex.EncodeToString(derSig),
+	})
+	if !errors.Is(err, ErrMigrationNotFound) {
+		t.Fatalf("expected ErrMigrationNotFound on reuse, got %v", err)
+	}
+}
+
+func TestSelfCustody_CompleteRejectsWrongSignature(t *testing.T) {
+	ctx := context.Background()
+	f := newSelfCustodyFixture(t)
+	f.store.EXPECT().GetUserByEVMAddress(ctx, f.evmAddress).Return(f.usr, nil).Once()
+
+	resp, err := f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, f.validMessage(t)))
+	if err != nil {
+		t.Fatalf("ExportKeyForSelfCustody() failed: %v", err)
+	}
+
+	other, _ := keys.GenerateCantonKeyPair()
+	challenge, _ := hex.DecodeString(strings.TrimPrefix(resp.Challenge, "0x"))
+	derSig, _ := other.SignHashDER(challenge)
+
+	_, err = f.svc.CompleteSelfCustody(ctx, &user.SelfCustodyCompleteRequest{
+		MigrationToken:     resp.MigrationToken,
+		ChallengeSignature: hex.EncodeToString(derSig),
+	})
+	if !apperrors.Is(err, apperrors.CategoryUnauthorized) {
+		t.Fatalf("expected CategoryUnauthorized, got %v", err)
+	}
+	f.store.AssertNotCalled(t, "SwitchToExternalKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSelfCustody_CompleteConflictWhenKeyChanged(t *testing.T) {
+	ctx := context.Background()
+	f := newSelfCustodyFixture(t)
+	f.store.EXPECT().GetUserByEVMAddress(ctx, f.evmAddress).Return(f.usr, nil).Once()
+
+	resp, err := f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, f.validMessage(t)))
+	if err != nil {
+		t.Fatalf("ExportKeyForSelfCustody() failed: %v", err)
+	}
+	challenge, _ := hex.DecodeString(strings.TrimPrefix(resp.Challenge, "0x"))
+	derSig, _ := f.cantonKey.SignHashDER(challenge)
+
+	f.store.EXPECT().SwitchToExternalKey(ctx, f.evmAddress, mock.Anything, mock.Anything).Return(false, nil).Once()
+
+	_, err = f.svc.CompleteSelfCustody(ctx, &user.SelfCustodyCompleteRequest{
+		MigrationToken:     resp.MigrationToken,
+		ChallengeSignature: hex.EncodeToString(derSig),
+	})
+	if !apperrors.Is(err, apperrors.CategoryDataConflict) {
+		t.Fatalf("expected CategoryDataConflict, got %v", err)
+	}
+}
+
+func TestSelfCustody_ExportRejections(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("message for another purpose", func(t *testing.T) {
+		f := newSelfCustodyFixture(t)
+		msg := fmt.Sprintf("login:%s:%d", f.evmAddress, time.Now().Unix())
+		_, err := f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, msg))
+		if !apperrors.Is(err, apperrors.CategoryUnauthorized) {
+			t.Fatalf("expected CategoryUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("expired message", func(t *testing.T) {
+		f := newSelfCustodyFixture(t)
+		nonce, _ := f.nonces.Issue(ctx, f.evmAddress)
+		msg := fmt.Sprintf("self-custody:%s:%s:%s:%d",
+			f.evmAddress, f.recipientKeyHex(), nonce, time.Now().Add(-time.Hour).Unix())
+		_, err := f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, msg))
+		if !apperrors.Is(err, apperrors.CategoryUnauthorized) {
+			t.Fatalf("expected CategoryUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("message without recipient and nonce", func(t *testing.T) {
+		f := newSelfCustodyFixture(t)
+		msg := fmt.Sprintf("self-custody:%s:%d", f.evmAddress, time.Now().Unix())
+		_, err := f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, msg))
+		if !apperrors.Is(err, apperrors.CategoryUnauthorized) {
+			t.Fatalf("expected CategoryUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("replayed with another recipient key", func(t *testing.T) {
+		f := newSelfCustodyFixture(t)
+		req := f.exportRequest(t, f.validMessage(t))
+		attacker, _ := crypto.GenerateKey()
+		req.RecipientPublicKey = hex.EncodeToString(crypto.CompressPubkey(&attacker.PublicKey))
+		_, err := f.svc.ExportKeyForSelfCustody(ctx, req)
+		if !apperrors.Is(err, apperrors.CategoryUnauthorized) {
+			t.Fatalf("expected CategoryUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("replayed message", func(t *testing.T) {
+		f := newSelfCustodyFixture(t)
+		f.store.EXPECT().GetUserByEVMAddress(ctx, f.evmAddress).Return(f.usr, nil).Once()
+		req := f.exportRequest(t, f.validMessage(t))
+		if _, err := f.svc.ExportKeyForSelfCustody(ctx, req); err != nil {
+			t.Fatalf("ExportKeyForSelfCustody() failed: %v", err)
+		}
+		_, err := f.svc.ExportKeyForSelfCustody(ctx, req)
+		if !errors.Is(err, ErrNonceUsed) {
+			t.Fatalf("expected ErrNonceUsed, got %v", err)
+		}
+	})
+
+	t.Run("external user", func(t *testing.T) {
+		f := newSelfCustodyFixture(t)
+		external := user.NewExternal(f.evmAddress, f.usr.CantonPartyID, f.usr.Fingerprint, "mapping-cid", "1220ab")
+		f.store.EXPECT().GetUserByEVMAddress(ctx, f.evmAddress).Return(external, nil).Once()
+		_, err := f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, f.validMessage(t)))
+		if !errors.Is(err, ErrNotCustodial) {
+			t.Fatalf("expected ErrNotCustodial, got %v", err)
+		}
+	})
+
+	t.Run("key does not own the party", func(t *testing.T) {
+		f := newSelfCustodyFixture(t)
+		// A Canton native user: the stored key is not the party's namespace key.
+		native := *f.usr
+		native.CantonPartyID = "loop_user::1220" + strings.Repeat("ab", 32)
+		native.CantonParty = native.CantonPartyID
+		encrypted, err := f.ring.Encrypt(f.cantonKey.PrivateKey, keys.KeyOwner{
+			EVMAddress:    native.EVMAddress,
+			CantonPartyID: native.CantonPartyID,
+		})
+		if err != nil {
+			t.Fatalf("Encrypt() failed: %v", err)
+		}
+		native.CantonPrivateKeyEncrypted = encrypted
+		f.store.EXPECT().GetUserByEVMAddress(ctx, f.evmAddress).Return(&native, nil).Once()
+		_, err = f.svc.ExportKeyForSelfCustody(ctx, f.exportRequest(t, f.validMessage(t)))
+		if !errors.Is(err, ErrKeyNotPartyOwner) {
+			t.Fatalf("expected ErrKeyNotPartyOwner, got %v", err)
+		}
+	})
+}
@@ -49,6 +49,14 @@ var (
 	ErrPartyAlreadyRegistered = errors.New("canton party already registered")
 )
 
+// NonceStore consumes the single-use nonces handed out by GET /auth/nonce, which
+// signed actions such as the self-custody export embed in their message.
+// Satisfied by nonceprovider.InMemory and nonceprovider.Postgres.
+type NonceStore interface {
+	// Consume returns true exactly once for a live, previously-issued nonce.
+	Consume(ctx context.Context, nonce string) (bool, error)
+}
+
 // Store is the narrow data-access interface for the registration service.
 // Defined here to keep registration service decoupled from userstore implementation details.
 //
@@ -59,6 +67,10 @@ type Store interface {
 	GetUserByCantonPartyID(ctx context.Context, partyID string) (*user.User, error)
 	GetUserByEVMAddress(ctx context.Context, evmAddress string) (*user.User, error)
 	DeleteUser(ctx context.Context, evmAddress string) error
+	// SwitchToExternalKey destroys a custodial user's encrypted key and switches
+	// them to external mode, provided the key still equals currentEncryptedKey.
+	// Returns false when it does not.
+	SwitchToExternalKey(ctx context.Context, evmAddress, currentEncryptedKey, publicKeyFingerprint string) (bool, error)
 }
 
 // Service defines the interface for the registration business logic
@@ -69,6 +81,8 @@ type Service interface {
 	RegisterCantonNativeUser(ctx context.Context, req *user.RegisterRequest) (*user.RegisterResponse, error)
 	PrepareExternalRegistration(ctx context.Context, req *user.RegisterRequest) (*user.PrepareTopologyResponse, error)
 	GetUser(ctx context.Context, evmAddress, msg, sig string) (*user.User, error)
+	ExportKeyForSelfCustody(ctx context.Context, req *user.SelfCustodyExportRequest) (*user.SelfCustodyExportResponse, error)
+	CompleteSelfCustody(ctx context.Context, req *user.SelfCustodyCompleteRequest) (*user.RegisterResponse, error)
 }
 
 type registrationService struct {
@@ -79,6 +93,8 @@ type registrationService struct {
 	skipCantonSignatureVerification bool
 	whitelist                       whitelist.Checker
 	topologyCache                   TopologyCacheProvider
+	migrationCache                  MigrationCacheProvider
+	nonces                          NonceStore
 }
 
 // NewService creates a new registration service
@@ -90,6 +106,8 @@ func NewService(
 	skipCantonSignatureVerification bool,
 	whitelist whitelist.Checker,
 	topologyCache TopologyCacheProvider,
+	migrationCache MigrationCacheProvider,
+	nonces NonceStore,
 ) Service {
 	return &registrationService{
 		store:                           store,
@@ -99,6 +117,8 @@ func NewService(
 		skipCantonSignatureVerification: skipCantonSignatureVerification,
 		whitelist:                       whitelist,
 		topologyCache:                   topologyCache,
+		migrationCache:                  migrationCache,
+		nonces:                          nonces,
 	}
 }
 
@@ -196,7 +216,10 @@ func (s *registrationService) RegisterWeb3User(
 		return nil, fmt.Errorf("fingerprint mapping creation failed: %w", err)
 	}
 
-	encryptedPKey, err := s.keyCipher.Encrypt(cantonKeyPair.PrivateKey)
+	encryptedPKey, err := s.keyCipher.Encrypt(cantonKeyPair.PrivateKey, keys.KeyOwner{
+		EVMAddress:    evmAddress,
+		CantonPartyID: cantonPartyID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt key: %w", err)
 	}
@@ -301,7 +324,10 @@ func (s *registrationService) RegisterCantonNativeUser(
 		return nil, apperrors.BadRequestError(nil, fmt.Sprintf("canton_private_key must be a hex-encoded %d-byte key", cantonKeySize))
 	}
 
-	encryptedPKey, err := s.keyCipher.Encrypt(keyToStore)
+	encryptedPKey, err := s.keyCipher.Encrypt(keyToStore, keys.KeyOwner{
+		EVMAddress:    evmAddress,
+		CantonPartyID: req.CantonPartyID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt key: %w", err)
 	}
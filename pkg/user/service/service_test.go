@@ -59,7 +59,7 @@ func TestRegistrationService_RegisterWeb3User_UserAlreadyRegistered(t *testing.T
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().UserExists(ctx, evmAddress).Return(true, nil).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil, nil, nil)
 
 	_, err := svc.RegisterWeb3User(ctx, &user.RegisterRequest{
 		Message:   testMessage,
@@ -82,7 +82,7 @@ func TestRegistrationService_RegisterWeb3User_NotWhitelisted(t *testing.T) {
 
 	storeMock := mocks.NewStore(t)
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: false}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: false}, nil, nil, nil)
 
 	_, err := svc.RegisterWeb3User(ctx, &user.RegisterRequest{
 		Message:   testMessage,
@@ -106,7 +106,7 @@ func TestPrepareExternalRegistration_UserAlreadyExists(t *testing.T) {
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().UserExists(ctx, evmAddress).Return(true, nil).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil, nil, nil)
 
 	_, err := svc.PrepareExternalRegistration(ctx, &user.RegisterRequest{
 		Message:         testMessage,
@@ -131,7 +131,7 @@ func TestPrepareExternalRegistration_NotWhitelisted(t *testing.T) {
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().UserExists(ctx, evmAddress).Return(false, nil).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: false}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: false}, nil, nil, nil)
 
 	_, err := svc.PrepareExternalRegistration(ctx, &user.RegisterRequest{
 		Message:         testMessage,
@@ -157,7 +157,7 @@ func TestRegistrationService_RegisterCantonNativeUser_StoreError(t *testing.T) {
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().GetUserByCantonPartyID(ctx, partyID).Return(nil, storeErr).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), true, stubChecker{allow: true}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), true, stubChecker{allow: true}, nil, nil, nil)
 
 	_, err := svc.RegisterCantonNativeUser(ctx, &user.RegisterRequest{
 		CantonPartyID: partyID,
@@ -180,7 +180,7 @@ func TestRegistrationService_RegisterCantonNativeUser_PartyAlreadyRegistered(t *
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().GetUserByCantonPartyID(ctx, partyID).Return(&user.User{CantonPartyID: partyID}, nil).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), true, stubChecker{allow: true}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), true, stubChecker{allow: true}, nil, nil, nil)
 
 	_, err := svc.RegisterCantonNativeUser(ctx, &user.RegisterRequest{
 		CantonPartyID: partyID,
@@ -230,7 +230,7 @@ func TestGetUser_Success(t *testing.T) {
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().GetUserByEVMAddress(ctx, evmAddress).Return(expected, nil).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil, nil, nil)
 	got, err := svc.GetUser(ctx, evmAddress, message, signature)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -245,7 +245,7 @@ func TestGetUser_ExpiredMessage(t *testing.T) {
 	// Timestamp 25 hours in the past — beyond the 24-hour loginMessageMaxAge.
 	evmAddress, message, signature := signLoginMessage(t, -25*time.Hour)
 
-	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil)
+	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil, nil, nil)
 	_, err := svc.GetUser(ctx, evmAddress, message, signature)
 	if err == nil {
 		t.Fatal("expected unauthorized error for expired message, got nil")
@@ -260,7 +260,7 @@ func TestGetUser_WrongAddress(t *testing.T) {
 	_, message, signature := signLoginMessage(t, 0)
 	otherAddress := "0x000000000000000000000000000000000000dEaD"
 
-	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil)
+	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil, nil, nil)
 	_, err := svc.GetUser(ctx, otherAddress, message, signature)
 	if err == nil {
 		t.Fatal("expected unauthorized error for mismatched address, got nil")
@@ -277,7 +277,7 @@ func TestGetUser_UserNotFound(t *testing.T) {
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().GetUserByEVMAddress(ctx, evmAddress).Return(nil, user.ErrUserNotFound).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil, nil, nil)
 	_, err := svc.GetUser(ctx, evmAddress, message, signature)
 	if err == nil {
 		t.Fatal("expected not-found error, got nil")
@@ -290,7 +290,7 @@ func TestGetUser_UserNotFound(t *testing.T) {
 func TestGetUser_InvalidSignature(t *testing.T) {
 	ctx := context.Background()
 
-	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil)
+	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil, nil, nil)
 	_, err := svc.GetUser(ctx, "0xdeadbeef", "some message", "not-a-valid-signature")
 	if err == nil {
 		t.Fatal("expected unauthorized error for invalid signature, got nil")
@@ -319,7 +319,7 @@ func TestGetUser_InvalidMessageFormat_ReturnsUnauthorized(t *testing.T) {
 	}
 	hexSig := "0x" + hex.EncodeToString(sig)
 
-	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil)
+	svc := NewService(nil, nil, nil, zap.NewNop(), false, nil, nil, nil, nil)
 	_, err = svc.GetUser(ctx, addr, msg, hexSig)
 	if err == nil {
 		t.Fatal("expected unauthorized error for wrong message prefix, got nil")
@@ -337,7 +337,7 @@ func TestGetUser_StoreError(t *testing.T) {
 	storeMock := mocks.NewStore(t)
 	storeMock.EXPECT().GetUserByEVMAddress(ctx, evmAddress).Return(nil, storeErr).Once()
 
-	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil)
+	svc := NewService(storeMock, nil, nil, zap.NewNop(), false, stubChecker{allow: true}, nil, nil, nil)
 	_, err := svc.GetUser(ctx, evmAddress, message, signature)
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -35,6 +35,15 @@ type WhitelistEntry struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// EncryptedKey is a custodial user's encrypted Canton private key together
+// with the identity the ciphertext is bound to.
+type EncryptedKey struct {
+	UserID        int64
+	EVMAddress    string
+	CantonPartyID string
+	Encrypted     string
+}
+
 // New creates a custodial User from the given parameters.
 func New(evmAddress, cantonPartyID, fingerprint, mappingCID, encryptedPKey string) *User {
 	now := time.Now()
@@ -113,5 +122,50 @@ type PendingTopology struct {
 	ExpiresAt time.Time
 }
 
+// SelfCustodyExportRequest starts a custodial user's migration to self-custody.
+// The Canton private key is returned encrypted to RecipientPublicKey; the stored
+// copy is only destroyed once the user proves they decrypted it.
+type SelfCustodyExportRequest struct {
+	// EIP-191 signature over Message,
+	// "self-custody:<evm address>:<recipient public key>:<nonce>:<unix seconds>",
+	// where nonce comes from GET /auth/nonce and is consumed by the export.
+	Signature string `json:"signature,omitzero"`
+	Message   string `json:"message,omitzero"`
+
+	// RecipientPublicKey is the hex secp256k1 public key (compressed or
+	// uncompressed) the private key is ECIES-encrypted to.
+	RecipientPublicKey string `json:"recipient_public_key"`
+}
+
+// SelfCustodyExportResponse carries the exported key and the challenge that
+// completes the migration.
+type SelfCustodyExportResponse struct {
+	// EncryptedKey is the hex ECIES (secp256k1, AES-128-CTR, HMAC-SHA-256)
+	// ciphertext of the 32-byte Canton private key.
+	EncryptedKey         string    `json:"encrypted_key"`
+	CantonPublicKey      string    `json:"canton_public_key"` // hex compressed secp256k1 public key
+	PublicKeyFingerprint string    `json:"public_key_fingerprint"`
+	MigrationToken       string    `json:"migration_token"`
+	Challenge            string    `json:"challenge"` // 0x-hex 32-byte hash to sign with the exported key
+	ExpiresAt            time.Time `json:"expires_at"`
+}
+
+// SelfCustodyCompleteRequest finishes a migration started by an export.
+type SelfCustodyCompleteRequest struct {
+	MigrationToken     string `json:"migration_token"`
+	ChallengeSignature string `json:"challenge_signature"` // DER sig of the challenge (hex)
+}
+
+// PendingMigration holds an exported key awaiting proof of possession.
+type PendingMigration struct {
+	EVMAddress           string
+	CantonPartyID        string
+	EncryptedKey         string // the stored ciphertext at export time
+	PublicKey            []byte // compressed secp256k1 public key
+	PublicKeyFingerprint string
+	Challenge            []byte
+	ExpiresAt            time.Time
+}
+
 var ErrKeyNotFound = errors.New("key not found")
 var ErrUserNotFound = errors.New("user not found")
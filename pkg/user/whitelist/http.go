@@ -3,13 +3,10 @@
 package whitelist
 
 import (
-	"crypto/sha256"
-	"crypto/subtle"
 	"encoding/json"
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -32,15 +29,17 @@ type httpHandler struct {
 }
 
 // RegisterAdminRoutes mounts the privileged whitelist-management endpoints under
-// /admin on r, gated by a static bearer token.
+// /admin on r, gated by a static bearer token. The routes are registered in a
+// group rather than a mounted /admin subrouter so other packages can add their
+// own /admin endpoints to the same router.
 func RegisterAdminRoutes(r chi.Router, mgr Manager, token string, logger *zap.Logger) {
 	h := &httpHandler{mgr: mgr, logger: logger}
 
-	r.Route("/admin", func(ar chi.Router) {
-		ar.Use(bearerAuth(token))
-		ar.Post("/whitelist", apphttp.HandleError(h.add))
-		ar.Delete("/whitelist/{address}", apphttp.HandleError(h.remove))
-		ar.Get("/whitelist", apphttp.HandleError(h.list))
+	r.Group(func(ar chi.Router) {
+		ar.Use(apphttp.BearerAuthMiddleware(token))
+		ar.Post("/admin/whitelist", apphttp.HandleError(h.add))
+		ar.Delete("/admin/whitelist/{address}", apphttp.HandleError(h.remove))
+		ar.Get("/admin/whitelist", apphttp.HandleError(h.list))
 	})
 
 	logger.Info("Admin API enabled", zap.String("path", "/admin/whitelist"))
@@ -122,32 +121,3 @@ func (h *httpHandler) writeJSON(w http.ResponseWriter, data any) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(buf)
 }
-
-// bearerAuth returns a chi middleware that authorizes requests carrying a static
-// admin token as "Authorization: Bearer <token>". The comparison is constant-time
-// over SHA-256 digests so neither the token value nor its length leaks via timing.
-func bearerAuth(token string) func(http.Handler) http.Handler {
-	want := sha256.Sum256([]byte(token))
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			provided := bearerToken(r)
-			got := sha256.Sum256([]byte(provided))
-			if provided == "" || subtle.ConstantTimeCompare(got[:], want[:]) != 1 {
-				apphttp.DefaultErrorHandler(w, apperrors.UnAuthorizedError(nil, "invalid or missing admin credentials"))
-				return
-			}
-			next.ServeHTTP(w, r)
-		})
-	}
-}
-
-// bearerToken extracts the token from an "Authorization: Bearer <token>" header,
-// returning "" when the header is absent or not a bearer credential.
-func bearerToken(r *http.Request) string {
-	const prefix = "Bearer "
-	h := r.Header.Get("Authorization")
-	if len(h) <= len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
-		return ""
-	}
-	return strings.TrimSpace(h[len(prefix):])
-}
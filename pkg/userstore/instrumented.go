@@ -195,3 +195,36 @@ func (s *InstrumentedStore) GetUserKeyByFingerprint(
 	}
 	return key, err
 }
+
+func (s *InstrumentedStore) ListEncryptedKeys(ctx context.Context, afterID int64, limit int) ([]*user.EncryptedKey, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpListEncryptedKeys))
+	defer timer.ObserveDuration()
+
+	keys, err := s.inner.ListEncryptedKeys(ctx, afterID, limit)
+	if err != nil {
+		s.metrics.IncErrors(OpListEncryptedKeys)
+	}
+	return keys, err
+}
+
+func (s *InstrumentedStore) UpdateEncryptedKey(ctx context.Context, userID int64, current, updated string) (bool, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpUpdateEncryptedKey))
+	defer timer.ObserveDuration()
+
+	ok, err := s.inner.UpdateEncryptedKey(ctx, userID, current, updated)
+	if err != nil {
+		s.metrics.IncErrors(OpUpdateEncryptedKey)
+	}
+	return ok, err
+}
+
+func (s *InstrumentedStore) SwitchToExternalKey(ctx context.Context, evmAddress, currentEncryptedKey, publicKeyFingerprint string) (bool, error) {
+	timer := prometheus.NewTimer(s.metrics.ObserveQueryDuration(OpSwitchToExternalKey))
+	defer timer.ObserveDuration()
+
+	ok, err := s.inner.SwitchToExternalKey(ctx, evmAddress, currentEncryptedKey, publicKeyFingerprint)
+	if err != nil {
+		s.metrics.IncErrors(OpSwitchToExternalKey)
+	}
+	return ok, err
+}
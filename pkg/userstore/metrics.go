@@ -57,6 +57,9 @@ const (
 	OpGetKeyByCantonPartyID  StoreOperation = "get_key_by_canton_party_id"
 	OpGetKeyByEVMAddress     StoreOperation = "get_key_by_evm_address"
 	OpGetKeyByFingerprint    StoreOperation = "get_key_by_fingerprint"
+	OpListEncryptedKeys      StoreOperation = "list_encrypted_keys"
+	OpUpdateEncryptedKey     StoreOperation = "update_encrypted_key"
+	OpSwitchToExternalKey    StoreOperation = "switch_to_external_key"
 )
 
 // ObserveQueryDuration returns the observer for the given operation.
@@ -11,11 +11,13 @@ import (
 
 	"github.com/uptrace/bun"
 
+	"github.com/chainsafe/canton-middleware/pkg/keys"
 	"github.com/chainsafe/canton-middleware/pkg/user"
 )
 
-// KeyDecryptor decrypts an encrypted private key string into raw bytes.
-type KeyDecryptor func(encryptedKey string) ([]byte, error)
+// KeyDecryptor decrypts an encrypted private key string into raw bytes. owner
+// is the user the row belongs to, which the ciphertext is bound to.
+type KeyDecryptor func(encryptedKey string, owner keys.KeyOwner) ([]byte, error)
 
 // whereWhitelistAddress matches a whitelist row by EVM address case-insensitively.
 const whereWhitelistAddress = "LOWER(evm_address) = LOWER(?)"
@@ -200,7 +202,7 @@ func (s *pgStore) getUserKeyBy(ctx context.Context, decryptor KeyDecryptor, colu
 	dao := new(UserDao)
 	query := s.db.NewSelect().
 		Model(dao).
-		Column("canton_private_key_encrypted").
+		Column("evm_address", "canton_party_id", "canton_private_key_encrypted").
 		Where(column+" = ?", value)
 
 	err := query.Scan(ctx)
@@ -215,7 +217,7 @@ func (s *pgStore) getUserKeyBy(ctx context.Context, decryptor KeyDecryptor, colu
 		return nil, nil
 	}
 
-	decryptedKey, err := decryptor(*dao.CantonPrivateKeyEncrypted)
+	decryptedKey, err := decryptor(*dao.CantonPrivateKeyEncrypted, keyOwner(dao))
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt key: %w", err)
 	}
@@ -234,3 +236,82 @@ func (s *pgStore) GetUserKeyByEVMAddress(ctx context.Context, decryptor KeyDecry
 func (s *pgStore) GetUserKeyByFingerprint(ctx context.Context, decryptor KeyDecryptor, fingerprint string) ([]byte, error) {
 	return s.getUserKeyBy(ctx, decryptor, "fingerprint", fingerprint)
 }
+
+// keyOwner returns the identity a user row's encrypted key is bound to.
+func keyOwner(dao *UserDao) keys.KeyOwner {
+	owner := keys.KeyOwner{EVMAddress: dao.EVMAddress}
+	if dao.CantonPartyID != nil {
+		owner.CantonPartyID = *dao.CantonPartyID
+	}
+	return owner
+}
+
+// ListEncryptedKeys returns up to limit users that hold an encrypted Canton key,
+// ordered by user ID, starting strictly after afterID.
+func (s *pgStore) ListEncryptedKeys(ctx context.Context, afterID int64, limit int) ([]*user.EncryptedKey, error) {
+	var daos []UserDao
+	err := s.db.NewSelect().
+		Model(&daos).
+		Column("id", "evm_address", "canton_party_id", "canton_private_key_encrypted").
+		Where("id > ?", afterID).
+		Where("canton_private_key_encrypted IS NOT NULL AND canton_private_key_encrypted <> ''").
+		OrderExpr("id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list encrypted keys: %w", err)
+	}
+	out := make([]*user.EncryptedKey, len(daos))
+	for i := range daos {
+		owner := keyOwner(&daos[i])
+		out[i] = &user.EncryptedKey{
+			UserID:        daos[i].ID,
+			EVMAddress:    owner.EVMAddress,
+			CantonPartyID: owner.CantonPartyID,
+			Encrypted:     *daos[i].CantonPrivateKeyEncrypted,
+		}
+	}
+	return out, nil
+}
+
+// UpdateEncryptedKey replaces a user's encrypted Canton key, provided it still
+// equals current. Returns false when the row changed since it was read.
+func (s *pgStore) UpdateEncryptedKey(ctx context.Context, userID int64, current, updated string) (bool, error) {
+	res, err := s.db.NewUpdate().
+		Model((*UserDao)(nil)).
+		Set("canton_private_key_encrypted = ?", updated).
+		Where("id = ?", userID).
+		Where("canton_private_key_encrypted = ?", current).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to update encrypted key: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update encrypted key: %w", err)
+	}
+	return n == 1, nil
+}
+
+// SwitchToExternalKey clears a custodial user's encrypted key and switches
+// them to external mode with the given public key fingerprint, provided the
+// stored key still equals currentEncryptedKey. Returns false otherwise.
+func (s *pgStore) SwitchToExternalKey(ctx context.Context, evmAddress, currentEncryptedKey, publicKeyFingerprint string) (bool, error) {
+	res, err := s.db.NewUpdate().
+		Model((*UserDao)(nil)).
+		Set("key_mode = ?", user.KeyModeExternal).
+		Set("canton_public_key_fingerprint = ?", publicKeyFingerprint).
+		Set("canton_private_key_encrypted = NULL").
+		Where("evm_address = ?", evmAddress).
+		Where("key_mode = ?", user.KeyModeCustodial).
+		Where("canton_private_key_encrypted = ?", currentEncryptedKey).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to switch user to external key: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to switch user to external key: %w", err)
+	}
+	return n == 1, nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/uptrace/bun/driver/pgdriver"
 
+	"github.com/chainsafe/canton-middleware/pkg/keys"
 	"github.com/chainsafe/canton-middleware/pkg/pgutil"
 	mghelper "github.com/chainsafe/canton-middleware/pkg/pgutil/migrations"
 	"github.com/chainsafe/canton-middleware/pkg/user"
@@ -396,10 +397,13 @@ func TestUserPGStore_GetUserKeyMethods(t *testing.T) {
 		t.Fatalf("CreateUser(withoutKey) failed: %v", err)
 	}
 
-	decryptor := func(encrypted string) ([]byte, error) {
+	decryptor := func(encrypted string, owner keys.KeyOwner) ([]byte, error) {
 		if encrypted != "ciphertext" {
 			return nil, fmt.Errorf("unexpected encrypted value: %s", encrypted)
 		}
+		if owner.EVMAddress != withKey.EVMAddress || owner.CantonPartyID != withKey.CantonPartyID {
+			return nil, fmt.Errorf("unexpected key owner: %+v", owner)
+		}
 		return []byte(plainKey), nil
 	}
 
@@ -441,7 +445,7 @@ func TestUserPGStore_GetUserKeyMethods(t *testing.T) {
 	}
 
 	decryptErr := errors.New("decrypt failed")
-	badDecryptor := func(string) ([]byte, error) {
+	badDecryptor := func(string, keys.KeyOwner) ([]byte, error) {
 		return nil, decryptErr
 	}
 	_, err = s.GetUserKeyByCantonPartyID(ctx, badDecryptor, withKey.CantonPartyID)
@@ -452,3 +456,80 @@ func TestUserPGStore_GetUserKeyMethods(t *testing.T) {
 		t.Fatalf("expected decrypt error to be wrapped with errors.Is, got %v", err)
 	}
 }
+
+func TestUserPGStore_ListAndUpdateEncryptedKeys(t *testing.T) {
+	ctx, s := setupStore(t)
+
+	first := newTestUser("0x5555555555555555555555555555555555555555", "party::r1", "0xr1")
+	noKey := newTestUser("0x4444444444444444444444444444444444444444", "party::r2", "0xr2")
+	noKey.CantonPrivateKeyEncrypted = ""
+	second := newTestUser("0x3333333333333333333333333333333333333333", "party::r3", "0xr3")
+	for _, u := range []*user.User{first, noKey, second} {
+		if err := s.CreateUser(ctx, u); err != nil {
+			t.Fatalf("CreateUser() failed: %v", err)
+		}
+	}
+
+	page, err := s.ListEncryptedKeys(ctx, 0, 1)
+	if err != nil {
+		t.Fatalf("ListEncryptedKeys() failed: %v", err)
+	}
+	if len(page) != 1 || page[0].EVMAddress != first.EVMAddress || page[0].CantonPartyID != first.CantonPartyID {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+
+	rest, err := s.ListEncryptedKeys(ctx, page[0].UserID, 10)
+	if err != nil {
+		t.Fatalf("ListEncryptedKeys(after) failed: %v", err)
+	}
+	if len(rest) != 1 || rest[0].EVMAddress != second.EVMAddress {
+		t.Fatalf("expected only the second keyed user after the cursor, got %+v", rest)
+	}
+
+	ok, err := s.UpdateEncryptedKey(ctx, rest[0].UserID, "stale", "rewrapped")
+	if err != nil || ok {
+		t.Fatalf("expected a stale swap to be refused, got ok=%v err=%v", ok, err)
+	}
+	ok, err = s.UpdateEncryptedKey(ctx, rest[0].UserID, rest[0].Encrypted, "rewrapped")
+	if err != nil || !ok {
+		t.Fatalf("expected the swap to succeed, got ok=%v err=%v", ok, err)
+	}
+	got, err := s.GetUserByEVMAddress(ctx, second.EVMAddress)
+	if err != nil {
+		t.Fatalf("GetUserByEVMAddress() failed: %v", err)
+	}
+	if got.CantonPrivateKeyEncrypted != "rewrapped" {
+		t.Fatalf("expected the rewrapped key to be stored, got %q", got.CantonPrivateKeyEncrypted)
+	}
+}
+
+func TestUserPGStore_SwitchToExternalKey(t *testing.T) {
+	ctx, s := setupStore(t)
+
+	u := newTestUser("0x6666666666666666666666666666666666666666", "party::m1", "0xm1")
+	if err := s.CreateUser(ctx, u); err != nil {
+		t.Fatalf("CreateUser() failed: %v", err)
+	}
+
+	ok, err := s.SwitchToExternalKey(ctx, u.EVMAddress, "stale", "1220ab")
+	if err != nil || ok {
+		t.Fatalf("expected a stale switch to be refused, got ok=%v err=%v", ok, err)
+	}
+	ok, err = s.SwitchToExternalKey(ctx, u.EVMAddress, u.CantonPrivateKeyEncrypted, "1220ab")
+	if err != nil || !ok {
+		t.Fatalf("expected the switch to succeed, got ok=%v err=%v", ok, err)
+	}
+
+	got, err := s.GetUserByEVMAddress(ctx, u.EVMAddress)
+	if err != nil {
+		t.Fatalf("GetUserByEVMAddress() failed: %v", err)
+	}
+	if got.KeyMode != user.KeyModeExternal || got.CantonPublicKeyFingerprint != "1220ab" || got.CantonPrivateKeyEncrypted != "" {
+		t.Fatalf("unexpected user after switch: %+v", got)
+	}
+
+	ok, err = s.SwitchToExternalKey(ctx, u.EVMAddress, u.CantonPrivateKeyEncrypted, "1220ab")
+	if err != nil || ok {
+		t.Fatalf("expected a second switch to be refused, got ok=%v err=%v", ok, err)
+	}
+}
@@ -28,6 +28,16 @@ type Store interface {
 	GetUserKeyByCantonPartyID(ctx context.Context, decryptor KeyDecryptor, partyID string) ([]byte, error)
 	GetUserKeyByEVMAddress(ctx context.Context, decryptor KeyDecryptor, evmAddress string) ([]byte, error)
 	GetUserKeyByFingerprint(ctx context.Context, decryptor KeyDecryptor, fingerprint string) ([]byte, error)
+	// ListEncryptedKeys returns up to limit users holding an encrypted Canton
+	// key, ordered by user ID and starting strictly after afterID.
+	ListEncryptedKeys(ctx context.Context, afterID int64, limit int) ([]*user.EncryptedKey, error)
+	// UpdateEncryptedKey swaps a user's encrypted key from current to updated,
+	// returning false when it no longer equals current.
+	UpdateEncryptedKey(ctx context.Context, userID int64, current, updated string) (bool, error)
+	// SwitchToExternalKey destroys a custodial user's encrypted key and switches
+	// them to external mode, returning false when the key no longer equals
+	// currentEncryptedKey.
+	SwitchToExternalKey(ctx context.Context, evmAddress, currentEncryptedKey, publicKeyFingerprint string) (bool, error)
 }
 
 // Compile-time check that pgStore implements Store.